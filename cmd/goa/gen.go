@@ -18,6 +18,14 @@ import (
 	"golang.org/x/tools/go/packages"
 )
 
+// ErrStale is the error returned by Generator.Run in check mode when the
+// generated code differs from the content of the output directory.
+var ErrStale = errors.New("generated code is out of date")
+
+// staleExitCode is the exit status of the generator in check mode when the
+// generated code is stale.
+const staleExitCode = 2
+
 // Generator is the code generation management data structure.
 type Generator struct {
 	// Command is the name of the command to run.
@@ -33,6 +41,10 @@ type Generator struct {
 	// DesignVersion is either 2 or 3.
 	DesignVersion int
 
+	// Check indicates whether the generator compares the generated code
+	// with the content of the output directory instead of writing it.
+	Check bool
+
 	// bin is the filename of the generated generator.
 	bin string
 
@@ -117,8 +129,12 @@ func (g *Generator) Write(_ bool) error {
 	{
 		data := map[string]any{
 			"Command":       g.Command,
-			"CleanupDirs":   cleanupDirs(g.Command, g.Output),
 			"DesignVersion": g.DesignVersion,
+			"Check":         g.Check,
+			"StaleExitCode": staleExitCode,
+		}
+		if !g.Check {
+			data["CleanupDirs"] = cleanupDirs(g.Command, g.Output)
 		}
 		ver := ""
 		if g.DesignVersion > 2 {
@@ -164,7 +180,8 @@ func (g *Generator) Compile() error {
 	if len(pkgs) != 1 {
 		return fmt.Errorf("expected to find one package in %s", g.tmpDir)
 	}
-	if !g.hasVendorDirectory {
+	// Do not update go.mod and go.sum when checking the generated code.
+	if !g.hasVendorDirectory && !g.Check {
 		if err := g.runGoCmd("get", pkgs[0].PkgPath); err != nil {
 			return err
 		}
@@ -182,25 +199,31 @@ func (g *Generator) Compile() error {
 	return err
 }
 
-// Run runs the compiled binary and return the output lines.
+// Run runs the compiled binary and return the output lines. In check mode
+// the output lines are the unified diff of the stale files and Run returns
+// ErrStale if there is any.
 func (g *Generator) Run() ([]string, error) {
 	var cmdl string
 	{
-		args := make([]string, len(os.Args)-1)
+		var args []string
 		gopaths := filepath.SplitList(os.Getenv("GOPATH"))
 		if len(gopaths) == 0 {
 			gopaths = []string{build.Default.GOPATH}
 		}
-		for i, a := range os.Args[1:] {
+		for _, a := range os.Args[1:] {
+			if isCheckFlag(a) {
+				// The command line is recorded in the generated
+				// files, it must be the same as when generating.
+				continue
+			}
+			arg := a
 			for _, p := range gopaths {
 				if strings.HasPrefix(a, p) {
-					args[i] = strings.Replace(a, p, "$(GOPATH)", 1)
+					arg = strings.Replace(a, p, "$(GOPATH)", 1)
 					break
 				}
 			}
-			if args[i] == "" {
-				args[i] = a
-			}
+			args = append(args, arg)
 		}
 		cmdl = " " + strings.Join(args, " ")
 		rawcmd := filepath.Base(os.Args[0])
@@ -213,16 +236,34 @@ func (g *Generator) Run() ([]string, error) {
 	args := []string{"--version=" + strconv.Itoa(g.DesignVersion), "--output=" + g.Output, "--cmd=" + cmdl}
 	cmd := exec.Command(filepath.Join(g.tmpDir, g.bin), args...)
 	out, err := cmd.CombinedOutput()
+	var stale bool
 	if err != nil {
-		return nil, fmt.Errorf("%s\n%s", err, string(out))
+		var exitErr *exec.ExitError
+		if !g.Check || !errors.As(err, &exitErr) || exitErr.ExitCode() != staleExitCode {
+			return nil, fmt.Errorf("%s\n%s", err, string(out))
+		}
+		stale = true
 	}
 	res := strings.Split(string(out), "\n")
 	for (len(res) > 0) && (res[len(res)-1] == "") {
 		res = res[:len(res)-1]
 	}
+	if stale {
+		return res, ErrStale
+	}
 	return res, nil
 }
 
+// isCheckFlag returns true if the given command line argument is the check
+// flag.
+func isCheckFlag(arg string) bool {
+	switch arg {
+	case "-check", "--check", "-check=true", "--check=true":
+		return true
+	}
+	return false
+}
+
 // Remove deletes the package files.
 func (g *Generator) Remove() {
 	if g.tmpDir != "" {
@@ -328,12 +369,22 @@ const mainT = `func main() {
 {{- if gt .DesignVersion 2 }}
 	codegen.DesignVersion = ver
 {{- end }}
+{{- if .Check }}
+	stale, err := generator.Check(*out, {{ printf "%q" .Command }}, os.Stdout)
+	if err != nil {
+		fail(err.Error())
+	}
+	if len(stale) > 0 {
+		os.Exit({{ .StaleExitCode }})
+	}
+{{- else }}
 	outputs, err := generator.Generate(*out, {{ printf "%q" .Command }})
 	if err != nil {
 		fail(err.Error())
 	}
 
 	fmt.Println(strings.Join(outputs, "\n"))
+{{- end }}
 }
 
 func fail(msg string, vals ...any) {
@@ -40,6 +40,7 @@ func main() {
 
 	var (
 		output = "."
+		check  bool
 		debug  bool
 	)
 	if len(os.Args) > offset+1 {
@@ -48,6 +49,7 @@ func main() {
 			o    = fset.String("o", "", "output `directory`")
 			out  = fset.String("output", output, "output `directory`")
 		)
+		fset.BoolVar(&check, "check", false, "Report stale generated files without writing them")
 		fset.BoolVar(&debug, "debug", false, "Print debug information")
 
 		fset.Usage = usage
@@ -62,7 +64,7 @@ func main() {
 		}
 	}
 
-	if err := gen(cmd, path, output, debug); err != nil {
+	if err := gen(cmd, path, output, check, debug); err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
@@ -74,7 +76,7 @@ var (
 	gen   = generate
 )
 
-func generate(cmd, path, output string, debug bool) error {
+func generate(cmd, path, output string, check, debug bool) error {
 	var (
 		files []string
 		err   error
@@ -86,6 +88,7 @@ func generate(cmd, path, output string, debug bool) error {
 	}
 
 	tmp = NewGenerator(cmd, path, output)
+	tmp.Check = check
 
 	if err = tmp.Write(debug); err != nil {
 		goto fail
@@ -95,11 +98,14 @@ func generate(cmd, path, output string, debug bool) error {
 		goto fail
 	}
 
-	if files, err = tmp.Run(); err != nil {
+	files, err = tmp.Run()
+	if len(files) > 0 {
+		fmt.Println(strings.Join(files, "\n"))
+	}
+	if err != nil {
 		goto fail
 	}
 
-	fmt.Println(strings.Join(files, "\n"))
 	if !debug {
 		tmp.Remove()
 	}
@@ -116,8 +122,8 @@ func help() {
 Learn more at https://goa.design.
 
 Usage:
-  goa gen PACKAGE [--output DIRECTORY] [--debug]
-  goa example PACKAGE [--output DIRECTORY] [--debug]
+  goa gen PACKAGE [--output DIRECTORY] [--check] [--debug]
+  goa example PACKAGE [--output DIRECTORY] [--check] [--debug]
   goa version
 
 Commands:
@@ -136,6 +142,11 @@ Flags:
   -o, -output DIRECTORY
         output directory, defaults to the current working directory
 
+  -check
+        Compare the generated code with the content of the output directory
+        without writing any file, print a unified diff of the stale files and
+        exit with a non-zero status if any
+
   -debug
         Print debug information (mainly intended for Goa developers)
 
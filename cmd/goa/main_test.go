@@ -15,11 +15,14 @@ func TestCmdLine(t *testing.T) {
 		usageCalled  bool
 		cmd          string
 		path, output string
-		debug        bool
+		check, debug bool
 	)
 
 	usage = func() { usageCalled = true }
-	gen = func(c string, p, o string, d bool) error { cmd, path, output, debug = c, p, o, d; return nil }
+	gen = func(c string, p, o string, ch, d bool) error {
+		cmd, path, output, check, debug = c, p, o, ch, d
+		return nil
+	}
 	defer func() {
 		usage = help
 		gen = generate
@@ -31,18 +34,21 @@ func TestCmdLine(t *testing.T) {
 		ExpectedCommand string
 		ExpectedPath    string
 		ExpectedOutput  string
+		ExpectedCheck   bool
 		ExpectedDebug   bool
 	}{
-		"gen": {"gen " + testPkg, false, "gen", testPkg, ".", false},
+		"gen": {"gen " + testPkg, false, "gen", testPkg, ".", false, false},
+
+		"invalid":     {"invalid " + testPkg, true, "", "", ".", false, false},
+		"empty":       {"", true, "", "", ".", false, false},
+		"invalid gen": {"invalid gen" + testPkg, true, "", "", ".", false, false},
 
-		"invalid":     {"invalid " + testPkg, true, "", "", ".", false},
-		"empty":       {"", true, "", "", ".", false},
-		"invalid gen": {"invalid gen" + testPkg, true, "", "", ".", false},
+		"output":       {"gen " + testPkg + " -output " + testOutput, false, "gen", testPkg, testOutput, false, false},
+		"output short": {"gen " + testPkg + " -o " + testOutput, false, "gen", testPkg, testOutput, false, false},
 
-		"output":       {"gen " + testPkg + " -output " + testOutput, false, "gen", testPkg, testOutput, false},
-		"output short": {"gen " + testPkg + " -o " + testOutput, false, "gen", testPkg, testOutput, false},
+		"debug": {"gen " + testPkg + " -debug", false, "gen", testPkg, ".", false, true},
 
-		"debug": {"gen " + testPkg + " -debug", false, "gen", testPkg, ".", true},
+		"check": {"gen " + testPkg + " --check", false, "gen", testPkg, ".", true, false},
 	}
 
 	for k, c := range cases {
@@ -53,6 +59,7 @@ func TestCmdLine(t *testing.T) {
 			cmd = ""
 			path = ""
 			output = ""
+			check = false
 			debug = false
 		}
 
@@ -70,6 +77,9 @@ func TestCmdLine(t *testing.T) {
 		if output != c.ExpectedOutput {
 			t.Errorf("%s: Expected output to be %s but got %s", k, c.ExpectedOutput, output)
 		}
+		if check != c.ExpectedCheck {
+			t.Errorf("%s: Expected check to be %v but got %v", k, c.ExpectedCheck, check)
+		}
 		if debug != c.ExpectedDebug {
 			t.Errorf("%s: Expected debug to be %v but got %v", k, c.ExpectedDebug, debug)
 		}
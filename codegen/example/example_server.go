@@ -44,6 +44,7 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 		{Path: "sync"},
 		{Path: "syscall"},
 		{Path: "time"},
+		codegen.GoaImport(""),
 		codegen.GoaImport("middleware"),
 	}
 
@@ -105,10 +106,13 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			Name:   "server-main-endpoints",
 			Source: mainEndpointsT,
 			Data: map[string]any{
+				"APIPkg":   apiPkg,
 				"Services": svcData,
 			},
 			FuncMap: map[string]any{
 				"mustInitServices": mustInitServices,
+				"hasRateLimits":    hasRateLimits,
+				"anyRateLimits":    anyRateLimits,
 			},
 		}, {
 			Name:   "server-main-interrupts",
@@ -146,6 +150,28 @@ func mustInitServices(data []*service.Data) bool {
 	return false
 }
 
+// hasRateLimits returns true if at least one method of the given service
+// defines rate or concurrency limits.
+func hasRateLimits(svc *service.Data) bool {
+	for _, m := range svc.Methods {
+		if m.RateLimit != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// anyRateLimits returns true if at least one of the given services defines
+// rate or concurrency limits.
+func anyRateLimits(data []*service.Data) bool {
+	for _, svc := range data {
+		if hasRateLimits(svc) {
+			return true
+		}
+	}
+	return false
+}
+
 const (
 	// input: map[string]interface{"Server": *ServerData}
 	mainStartT = `
@@ -210,9 +236,16 @@ func main() {
 	{{- end }}
 	)
 	{
+	{{- if anyRateLimits .Services }}
+		{{ comment "Enforce the rate and concurrency limits defined in the design. The in-memory store enforces the limits per process, use a shared goa.LimiterStore implementation to enforce them across processes." }}
+		limiter := goa.NewMemoryLimiterStore()
+	{{- end }}
 	{{- range .Services }}
 		{{- if .Methods }}
-			{{ .VarName }}Endpoints = {{ .PkgName }}.NewEndpoints({{ .VarName }}Svc)
+			{{ .VarName }}Endpoints = {{ .PkgName }}.NewEndpoints({{ .VarName }}Svc{{ if .ServerInterceptors }}, {{ $.APIPkg }}.New{{ .StructName }}ServerInterceptors(logger){{ end }})
+			{{- if hasRateLimits . }}
+			{{ .VarName }}Endpoints.UseLimiter(limiter)
+			{{- end }}
 		{{- end }}
 	{{- end }}
 	}
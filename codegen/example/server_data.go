@@ -209,7 +209,9 @@ func buildServerData(svr *expr.ServerExpr) *Data {
 		for _, svc := range svr.Services {
 			_, seenHTTP := foundTrans[TransportHTTP]
 			_, seenGRPC := foundTrans[TransportGRPC]
-			if expr.Root.API.HTTP.Service(svc) != nil {
+			// JSON-RPC requests are sent over HTTP, the example HTTP
+			// server also serves the JSON-RPC services.
+			if expr.Root.API.HTTP.Service(svc) != nil || isJSONRPC(svc) {
 				httpServices = append(httpServices, svc)
 				if !seenHTTP {
 					transports = append(transports, newHTTPTransport())
@@ -245,6 +247,12 @@ func buildServerData(svr *expr.ServerExpr) *Data {
 	}
 }
 
+// isJSONRPC returns true if the service with the given name is exposed via the
+// JSON-RPC transport.
+func isJSONRPC(svc string) bool {
+	return expr.Root.API.JSONRPC != nil && expr.Root.API.JSONRPC.Service(svc) != nil
+}
+
 // buildHostData builds the host data for the given host expression.
 func buildHostData(host *expr.HostExpr) *HostData {
 	var (
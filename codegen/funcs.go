@@ -36,6 +36,7 @@ func TemplateFuncs() map[string]any {
 	return map[string]any{
 		"commandLine": CommandLine,
 		"comment":     Comment,
+		"deprecated":  AppendDeprecated,
 	}
 }
 
@@ -67,6 +68,52 @@ func Comment(elems ...string) string {
 	return Indent(WrapText(t, 77), "// ")
 }
 
+// DeprecatedComment returns the "Deprecated:" paragraph of the comment of a
+// generated element given its deprecation. It returns an empty string if d is
+// nil.
+func DeprecatedComment(d *expr.Deprecation) string {
+	if d == nil {
+		return ""
+	}
+	text := "Deprecated:"
+	if d.Reason != "" {
+		text += " " + d.Reason
+	} else {
+		text += " do not use."
+	}
+	if !d.Sunset.IsZero() {
+		text = EndSentence(text) + " Sunset date: " + d.Sunset.Format("2006-01-02") + "."
+	}
+	return Comment(text)
+}
+
+// EndSentence returns text terminated with a period unless it already ends
+// with a period, an exclamation mark or a question mark.
+func EndSentence(text string) string {
+	text = strings.TrimRight(text, " \t\n")
+	if text == "" || strings.ContainsAny(text[len(text)-1:], ".!?") {
+		return text
+	}
+	return text + "."
+}
+
+// AppendDeprecated appends the "Deprecated:" paragraph of the given
+// deprecation to comment. It returns comment unchanged if d is nil. Templates
+// use it via the "deprecated" function, for example:
+//
+//	{{ comment .Description | deprecated .Deprecation }}
+func AppendDeprecated(d *expr.Deprecation, comment string) string {
+	dep := DeprecatedComment(d)
+	switch {
+	case dep == "":
+		return comment
+	case comment == "":
+		return dep
+	default:
+		return comment + "\n//\n" + dep
+	}
+}
+
 // Indent inserts prefix at the beginning of each non-empty line of s. The
 // end-of-line marker is NL.
 func Indent(s, prefix string) string {
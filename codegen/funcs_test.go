@@ -2,6 +2,9 @@ package codegen
 
 import (
 	"testing"
+	"time"
+
+	"goa.design/goa/v3/expr"
 )
 
 func TestSnakeCase(t *testing.T) {
@@ -164,3 +167,47 @@ func TestWrapText(t *testing.T) {
 		}
 	}
 }
+
+func TestAppendDeprecated(t *testing.T) {
+	sunset := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		deprecation *expr.Deprecation
+		comment     string
+		expected    string
+	}{
+		"not-deprecated": {
+			comment:  "// Foo does foo.",
+			expected: "// Foo does foo.",
+		},
+		"no-comment": {
+			deprecation: &expr.Deprecation{Reason: "Use Bar instead."},
+			expected:    "// Deprecated: Use Bar instead.",
+		},
+		"no-reason": {
+			deprecation: &expr.Deprecation{},
+			comment:     "// Foo does foo.",
+			expected:    "// Foo does foo.\n//\n// Deprecated: do not use.",
+		},
+		"sunset": {
+			deprecation: &expr.Deprecation{Reason: "Use Bar instead.", Sunset: sunset},
+			comment:     "// Foo does foo.",
+			expected:    "// Foo does foo.\n//\n// Deprecated: Use Bar instead. Sunset date: 2030-01-02.",
+		},
+		"sunset-no-period": {
+			deprecation: &expr.Deprecation{Reason: "Use Bar instead", Sunset: sunset},
+			expected:    "// Deprecated: Use Bar instead. Sunset date: 2030-01-02.",
+		},
+		"sunset-no-reason": {
+			deprecation: &expr.Deprecation{Sunset: sunset},
+			expected:    "// Deprecated: do not use. Sunset date: 2030-01-02.",
+		},
+	}
+
+	for k, tc := range cases {
+		actual := AppendDeprecated(tc.deprecation, tc.comment)
+
+		if actual != tc.expected {
+			t.Errorf("%s: got `%s`, expected `%s`", k, actual, tc.expected)
+		}
+	}
+}
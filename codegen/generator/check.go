@@ -0,0 +1,285 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"golang.org/x/tools/go/packages"
+)
+
+// diffContext is the number of unchanged lines shown around each change in
+// the unified diffs.
+const diffContext = 3
+
+// Check runs the code generation algorithms and compares the generated files
+// with the files in the output directory dir without modifying it. The files
+// are rendered in a temporary directory. Check writes a unified diff of each
+// file that differs to w and returns the paths of these files relative to dir.
+// For the "gen" command files found in the subdirectories of the gen directory
+// that are not generated anymore are also reported. For the "example" command
+// files that already exist are ignored as they are not overwritten by the
+// generator.
+func Check(dir, cmd string, w io.Writer) ([]string, error) {
+	// 1. Compute design roots.
+	roots, err := eval.Context.Roots()
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Compute "gen" package import path.
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	var genpkg string
+	{
+		pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName}, filepath.Join(base, codegen.Gendir))
+		if err != nil {
+			return nil, err
+		}
+		if len(pkgs) == 0 || pkgs[0].PkgPath == "" {
+			return nil, fmt.Errorf("failed to compute import path of %s", filepath.Join(base, codegen.Gendir))
+		}
+		genpkg = pkgs[0].PkgPath
+	}
+
+	// 3. Generate the files.
+	genfiles, err := generateFiles(cmd, genpkg, roots)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Render the files in a temporary directory.
+	tmp, err := os.MkdirTemp("", "goa-check")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	for _, f := range genfiles {
+		if f.SkipExist {
+			if _, err := os.Stat(filepath.Join(base, f.Path)); err == nil {
+				continue
+			}
+		}
+		if _, err := f.Render(tmp); err != nil {
+			return nil, err
+		}
+	}
+
+	// 5. Compare the rendered files with the files on disk.
+	paths := make(map[string]struct{})
+	if err := addFiles(tmp, tmp, paths); err != nil {
+		return nil, err
+	}
+	if cmd == "gen" {
+		entries, err := os.ReadDir(filepath.Join(base, codegen.Gendir))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				if err := addFiles(base, filepath.Join(base, codegen.Gendir, e.Name()), paths); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+	var stale []string
+	for _, p := range sorted {
+		got, gok, err := readFile(filepath.Join(tmp, p))
+		if err != nil {
+			return nil, err
+		}
+		actual, aok, err := readFile(filepath.Join(base, p))
+		if err != nil {
+			return nil, err
+		}
+		if gok == aok && bytes.Equal(got, actual) {
+			continue
+		}
+		stale = append(stale, filepath.ToSlash(p))
+		from, to := "a/"+filepath.ToSlash(p), "b/"+filepath.ToSlash(p)
+		if !aok {
+			from = "/dev/null"
+		}
+		if !gok {
+			to = "/dev/null"
+		}
+		if _, err := io.WriteString(w, UnifiedDiff(from, to, string(actual), string(got))); err != nil {
+			return nil, err
+		}
+	}
+	return stale, nil
+}
+
+// UnifiedDiff returns the unified diff that transforms the text a into the
+// text b. from and to are the names of the original and new files used in
+// the diff header. UnifiedDiff returns an empty string if a and b are
+// identical.
+func UnifiedDiff(from, to, a, b string) string {
+	if a == b {
+		return ""
+	}
+	type line struct {
+		op   diffmatchpatch.Operation
+		text string
+	}
+	var lines []line
+	{
+		// Map each distinct line to a rune and diff the resulting rune
+		// slices so that the diff operates on whole lines.
+		var (
+			texts []string
+			index = make(map[string]rune)
+		)
+		toRunes := func(s string) []rune {
+			var rs []rune
+			for _, l := range strings.SplitAfter(s, "\n") {
+				if l == "" {
+					continue
+				}
+				r, ok := index[l]
+				if !ok {
+					r = lineRune(len(texts))
+					texts = append(texts, l)
+					index[l] = r
+				}
+				rs = append(rs, r)
+			}
+			return rs
+		}
+		ra, rb := toRunes(a), toRunes(b)
+		for _, d := range diffmatchpatch.New().DiffMainRunes(ra, rb, false) {
+			for _, r := range d.Text {
+				lines = append(lines, line{d.Type, texts[runeLine(r)]})
+			}
+		}
+	}
+
+	// Compute the line numbers in a and b of each line of the diff.
+	na, nb := make([]int, len(lines)+1), make([]int, len(lines)+1)
+	for i, l := range lines {
+		na[i+1], nb[i+1] = na[i], nb[i]
+		if l.op != diffmatchpatch.DiffInsert {
+			na[i+1]++
+		}
+		if l.op != diffmatchpatch.DiffDelete {
+			nb[i+1]++
+		}
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", from, to)
+	for i := 0; i < len(lines); {
+		if lines[i].op == diffmatchpatch.DiffEqual {
+			i++
+			continue
+		}
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := i + 1
+		for j := i; j < len(lines); j++ {
+			if lines[j].op != diffmatchpatch.DiffEqual {
+				end = j + 1
+			} else if j-end >= 2*diffContext {
+				break
+			}
+		}
+		stop := end + diffContext
+		if stop > len(lines) {
+			stop = len(lines)
+		}
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n", hunkRange(na[start], na[stop]), hunkRange(nb[start], nb[stop]))
+		for _, l := range lines[start:stop] {
+			switch l.op {
+			case diffmatchpatch.DiffEqual:
+				buf.WriteByte(' ')
+			case diffmatchpatch.DiffDelete:
+				buf.WriteByte('-')
+			case diffmatchpatch.DiffInsert:
+				buf.WriteByte('+')
+			}
+			buf.WriteString(l.text)
+			if !strings.HasSuffix(l.text, "\n") {
+				buf.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = stop
+	}
+	return buf.String()
+}
+
+// lineRune returns the rune that represents the line with the given index,
+// it skips the surrogate range which cannot be encoded in strings.
+func lineRune(i int) rune {
+	if i >= 0xD800 {
+		i += 0x800
+	}
+	return rune(i)
+}
+
+// runeLine is the inverse of lineRune.
+func runeLine(r rune) int {
+	if r >= 0xE000 {
+		r -= 0x800
+	}
+	return int(r)
+}
+
+// hunkRange returns the range of a unified diff hunk header given the number
+// of lines before the hunk and the number of lines up to the end of the hunk.
+func hunkRange(before, after int) string {
+	count := after - before
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", before)
+	case 1:
+		return fmt.Sprintf("%d", before+1)
+	default:
+		return fmt.Sprintf("%d,%d", before+1, count)
+	}
+}
+
+// addFiles adds the paths relative to base of the regular files found under
+// root to paths.
+func addFiles(base, root string, paths map[string]struct{}) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		paths[rel] = struct{}{}
+		return nil
+	})
+}
+
+// readFile returns the content of the file at path and whether it exists.
+func readFile(path string) ([]byte, bool, error) {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	return b, err == nil, err
+}
@@ -0,0 +1,58 @@
+package generator
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	cases := map[string]struct {
+		a, b     string
+		expected string
+	}{
+		"identical": {"a\nb\n", "a\nb\n", ""},
+		"change": {"1\n2\n3\n4\n5\n6\n7\n8\n", "1\n2\n3\n4\nfive\n6\n7\n8\n", `--- a/f
++++ b/f
+@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+`},
+		"two hunks": {"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n", `--- a/f
++++ b/f
+@@ -1,4 +1,4 @@
+-1
++one
+ 2
+ 3
+ 4
+@@ -7,4 +7,4 @@
+ 7
+ 8
+ 9
+-10
++ten
+`},
+		"new file": {"", "a\n", `--- a/f
++++ b/f
+@@ -0,0 +1 @@
++a
+`},
+		"no newline": {"a\n", "a\nb", `--- a/f
++++ b/f
+@@ -1 +1,2 @@
+ a
++b
+\ No newline at end of file
+`},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			if got := UnifiedDiff("a/f", "b/f", c.a, c.b); got != c.expected {
+				t.Errorf("got:\n%s\nexpected:\n%s", got, c.expected)
+			}
+		})
+	}
+}
@@ -1,6 +1,6 @@
 /*
 Package generator contains the code generation algorithms for a service server,
-client, and OpenAPI specification.
+client, OpenAPI specification and TypeScript client SDK.
 
 Server and Client
 
@@ -17,5 +17,12 @@ OpenAPI
 
 The OpenAPI generator generates a OpenAPI v2 specification for the service
 REST endpoints. This generator requires the design to define the HTTP transport.
+
+TypeScript
+
+The TypeScript generator generates a fetch based client SDK for the service
+REST endpoints under gen/http/typescript. This generator requires the design to
+define the HTTP transport and is opt-in: the API must define the
+"typescript:generate" meta.
 */
 package generator
@@ -35,8 +35,8 @@ func Example(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 			files = append(files, fs...)
 		}
 
-		// HTTP
-		if len(r.API.HTTP.Services) > 0 {
+		// HTTP, the example HTTP server also serves the JSON-RPC services
+		if len(r.API.HTTP.Services) > 0 || len(r.API.JSONRPC.Services) > 0 {
 			if fs := httpcodegen.ExampleServerFiles(genpkg, r); len(fs) != 0 {
 				files = append(files, fs...)
 			}
@@ -59,39 +59,13 @@ func Generate(dir, cmd string) (outputs []string, err1 error) {
 		genpkg = pkgs[0].PkgPath
 	}
 
-	// 3. Retrieve goa generators for given command.
-	var genfuncs []Genfunc
-	{
-		gs, err := Generators(cmd)
-		if err != nil {
-			return nil, err
-		}
-		genfuncs = gs
-	}
-
-	// 4. Run the code pre generation plugins.
-	err := codegen.RunPluginsPrepare(cmd, genpkg, roots)
-	if err != nil {
-		return nil, err
-	}
-
-	// 5. Generate initial set of files produced by goa code generators.
-	var genfiles []*codegen.File
-	for _, gen := range genfuncs {
-		fs, err := gen(genpkg, roots)
-		if err != nil {
-			return nil, err
-		}
-		genfiles = append(genfiles, fs...)
-	}
-
-	// 6. Run the code generation plugins.
-	genfiles, err = codegen.RunPlugins(cmd, genpkg, roots, genfiles)
+	// 3. Generate the files.
+	genfiles, err := generateFiles(cmd, genpkg, roots)
 	if err != nil {
 		return nil, err
 	}
 
-	// 7. Write the files.
+	// 4. Write the files.
 	written := make(map[string]struct{})
 	for _, f := range genfiles {
 		filename, err := f.Render(dir)
@@ -103,7 +77,7 @@ func Generate(dir, cmd string) (outputs []string, err1 error) {
 		}
 	}
 
-	// 8. Compute all output filenames.
+	// 5. Compute all output filenames.
 	{
 		outputs = make([]string, len(written))
 		cwd, err := os.Getwd()
@@ -124,3 +98,35 @@ func Generate(dir, cmd string) (outputs []string, err1 error) {
 
 	return outputs, nil
 }
+
+// generateFiles runs the code generators and plugins for the given command.
+func generateFiles(cmd, genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	// 1. Retrieve goa generators for given command.
+	var genfuncs []Genfunc
+	{
+		gs, err := Generators(cmd)
+		if err != nil {
+			return nil, err
+		}
+		genfuncs = gs
+	}
+
+	// 2. Run the code pre generation plugins.
+	err := codegen.RunPluginsPrepare(cmd, genpkg, roots)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Generate initial set of files produced by goa code generators.
+	var genfiles []*codegen.File
+	for _, gen := range genfuncs {
+		fs, err := gen(genpkg, roots)
+		if err != nil {
+			return nil, err
+		}
+		genfiles = append(genfiles, fs...)
+	}
+
+	// 4. Run the code generation plugins.
+	return codegen.RunPlugins(cmd, genpkg, roots, genfiles)
+}
@@ -21,7 +21,7 @@ var Generators = generators
 func generators(cmd string) ([]Genfunc, error) {
 	switch cmd {
 	case "gen":
-		return []Genfunc{Service, Transport, OpenAPI}, nil
+		return []Genfunc{Service, Transport, OpenAPI, TypeScript}, nil
 	case "example":
 		return []Genfunc{Example}, nil
 	default:
@@ -24,6 +24,9 @@ func Service(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 			files = append(files, service.Files(genpkg, s, userTypePkgs)...)
 			files = append(files, service.EndpointFile(genpkg, s))
 			files = append(files, service.ClientFile(genpkg, s))
+			if f := service.InterceptorsFile(genpkg, s); f != nil {
+				files = append(files, f)
+			}
 			if f := service.ViewsFile(genpkg, s); f != nil {
 				files = append(files, f)
 			}
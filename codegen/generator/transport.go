@@ -7,6 +7,7 @@ import (
 	"goa.design/goa/v3/expr"
 	grpccodegen "goa.design/goa/v3/grpc/codegen"
 	httpcodegen "goa.design/goa/v3/http/codegen"
+	jsonrpccodegen "goa.design/goa/v3/jsonrpc/codegen"
 )
 
 // Transport iterates through the roots and returns the files needed to render
@@ -36,6 +37,13 @@ func Transport(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 		files = append(files, grpccodegen.ClientTypeFiles(genpkg, r)...)
 		files = append(files, grpccodegen.ClientCLIFiles(genpkg, r)...)
 
+		// JSON-RPC
+		files = append(files, jsonrpccodegen.ServerFiles(genpkg, r)...)
+		files = append(files, jsonrpccodegen.ClientFiles(genpkg, r)...)
+		files = append(files, jsonrpccodegen.ServerTypeFiles(genpkg, r)...)
+		files = append(files, jsonrpccodegen.ClientTypeFiles(genpkg, r)...)
+		files = append(files, jsonrpccodegen.ClientCLIFiles(genpkg, r)...)
+
 		for _, f := range files {
 			if len(f.SectionTemplates) > 0 {
 				for _, s := range r.Services {
@@ -0,0 +1,22 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/typescript"
+)
+
+// TypeScript iterates through the roots and returns the files needed to render
+// the TypeScript client SDK. It produces the client only if the roots define
+// a HTTP service and the API enables the SDK with the "typescript:generate"
+// meta.
+func TypeScript(_ string, roots []eval.Root) ([]*codegen.File, error) {
+	var files []*codegen.File
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			files = append(files, typescript.Files(r)...)
+		}
+	}
+	return files, nil
+}
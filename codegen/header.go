@@ -33,6 +33,14 @@ func AddImport(section *SectionTemplate, imprts ...*ImportSpec) {
 	}
 }
 
+// AddBuildTag sets the build constraint of the file generated with the given
+// header section, e.g. "go1.21".
+func AddBuildTag(section *SectionTemplate, tag string) {
+	if data, ok := section.Data.(map[string]any); ok {
+		data["BuildTag"] = tag
+	}
+}
+
 const (
 	headerT = `{{if .Title}}// Code generated by goa {{.ToolVersion}}, DO NOT EDIT.
 //
@@ -41,6 +49,8 @@ const (
 // Command:
 {{comment commandLine}}
 
+{{end}}{{if .BuildTag}}//go:build {{.BuildTag}}
+
 {{end}}package {{.Pkg}}
 
 {{if .Imports}}import {{if gt (len .Imports) 1}}(
@@ -151,7 +151,10 @@ func (s *NameScope) goTypeDef(att *expr.AttributeExpr, ptr, useDefault bool, pkg
 					tdef = "*" + tdef
 				}
 				if at.Description != "" {
-					desc = Comment(at.Description) + "\n\t"
+					desc = Comment(at.Description)
+				}
+				if desc = AppendDeprecated(expr.DeprecationOf(at.Meta), desc); desc != "" {
+					desc += "\n\t"
 				}
 				tags = AttributeTags(att, at)
 			}
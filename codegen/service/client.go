@@ -46,13 +46,23 @@ func ClientFile(_ string, service *expr.ServiceExpr) *codegen.File {
 				Data:   m,
 			})
 		}
+		for _, m := range data.Methods {
+			if m.Pagination == nil {
+				continue
+			}
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "client-iterator",
+				Source: serviceClientIteratorT,
+				Data:   m,
+			})
+		}
 	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
 // input: endpointsData
-const serviceClientT = `// {{ .ClientVarName }} is the {{ printf "%q" .Name }} service client.
+const serviceClientT = `{{ printf "%s is the %q service client." .ClientVarName .Name | comment | deprecated .Deprecation }}
 type {{ .ClientVarName }} struct {
 {{- range .Methods}}
 	{{ .VarName }}Endpoint goa.Endpoint
@@ -62,10 +72,14 @@ type {{ .ClientVarName }} struct {
 
 // input: endpointsData
 const serviceClientInitT = `{{ printf "New%s initializes a %q service client given the endpoints." .ClientVarName .Name | comment }}
-func New{{ .ClientVarName }}({{ .ClientInitArgs }} goa.Endpoint) *{{ .ClientVarName }} {
+func New{{ .ClientVarName }}({{ .ClientInitArgs }} goa.Endpoint{{ if .ClientInterceptors }}, ci ClientInterceptors{{ end }}) *{{ .ClientVarName }} {
 	return &{{ .ClientVarName }}{
 {{- range .Methods }}
+	{{- if .ClientInterceptors }}
+		{{ .VarName }}Endpoint: Wrap{{ .VarName }}ClientEndpoint({{ .ArgName }}, ci),
+	{{- else }}
 		{{ .VarName }}Endpoint: {{ .ArgName }},
+	{{- end }}
 {{- end }}
 	}
 }
@@ -81,6 +95,10 @@ const serviceClientMethodT = `
 	{{- end }}
 //	- error: internal error
 {{- end }}
+{{- with .Deprecation }}
+//
+{{ deprecated . "" }}
+{{- end }}
 {{- $resultType := .ResultRef }}
 {{- if .ClientStream }}
 	{{- $resultType = .ClientStream.Interface }}
@@ -105,3 +123,93 @@ func (c *{{ .ClientVarName }}) {{ .VarName }}(ctx context.Context, {{ if .Payloa
 	{{- end }}
 }
 `
+
+// input: EndpointMethodData
+const serviceClientIteratorT = `{{ printf "%s iterates over the items of the pages returned by the %q method of the %q service." .Pagination.IteratorName .Name .ServiceName | comment }}
+type {{ .Pagination.IteratorName }} struct {
+	client  *{{ .ClientVarName }}
+	payload *{{ .Pagination.PayloadName }}
+	page    []{{ .Pagination.ItemRef }}
+	index   int
+	done    bool
+	err     error
+}
+
+{{ printf "%s returns an iterator over the items of the pages returned by the %q endpoint starting with the page requested by p. The iterator fetches the next pages as needed." .Pagination.IteratorName .Name | comment }}
+func (c *{{ .ClientVarName }}) {{ .Pagination.IteratorName }}(p *{{ .Pagination.PayloadName }}) *{{ .Pagination.IteratorName }} {
+	if p == nil {
+		p = &{{ .Pagination.PayloadName }}{}
+	}
+	return &{{ .Pagination.IteratorName }}{client: c, payload: p, index: -1}
+}
+
+{{ comment "Next advances the iterator to the next item, it fetches the next page if needed. Next returns false once all the items have been visited or if an error occurs." }}
+func (it *{{ .Pagination.IteratorName }}) Next(ctx context.Context) bool {
+	for it.index+1 >= len(it.page) {
+		if it.done || it.err != nil {
+			return false
+		}
+		res, err := it.client.{{ .VarName }}(ctx, it.payload)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page, it.index = res.{{ .Pagination.ItemsField }}, -1
+{{- if .Pagination.Cursor }}
+	{{- if .Pagination.NextCursorPointer }}
+		if res.{{ .Pagination.NextCursorField }} == nil || *res.{{ .Pagination.NextCursorField }} == "" {
+			it.done = true
+			continue
+		}
+		cursor := *res.{{ .Pagination.NextCursorField }}
+	{{- else }}
+		if res.{{ .Pagination.NextCursorField }} == "" {
+			it.done = true
+			continue
+		}
+		cursor := res.{{ .Pagination.NextCursorField }}
+	{{- end }}
+		next := *it.payload
+		next.{{ .Pagination.CursorField }} = {{ if .Pagination.CursorPointer }}&{{ end }}cursor
+{{- else }}
+		if len(it.page) == 0 {
+			it.done = true
+			continue
+		}
+	{{- if .Pagination.LimitField }}
+		{{- if .Pagination.LimitPointer }}
+		if it.payload.{{ .Pagination.LimitField }} != nil && len(it.page) < *it.payload.{{ .Pagination.LimitField }} {
+		{{- else }}
+		if len(it.page) < it.payload.{{ .Pagination.LimitField }} {
+		{{- end }}
+			it.done = true
+			continue
+		}
+	{{- end }}
+		offset := len(it.page)
+	{{- if .Pagination.OffsetPointer }}
+		if it.payload.{{ .Pagination.OffsetField }} != nil {
+			offset += *it.payload.{{ .Pagination.OffsetField }}
+		}
+	{{- else }}
+		offset += it.payload.{{ .Pagination.OffsetField }}
+	{{- end }}
+		next := *it.payload
+		next.{{ .Pagination.OffsetField }} = {{ if .Pagination.OffsetPointer }}&{{ end }}offset
+{{- end }}
+		it.payload = &next
+	}
+	it.index++
+	return true
+}
+
+{{ comment "Value returns the current item." }}
+func (it *{{ .Pagination.IteratorName }}) Value() {{ .Pagination.ItemRef }} {
+	return it.page[it.index]
+}
+
+{{ comment "Err returns the error that caused Next to return false if any." }}
+func (it *{{ .Pagination.IteratorName }}) Err() error {
+	return it.err
+}
+`
@@ -17,6 +17,8 @@ type (
 		Name string
 		// Description is the service description.
 		Description string
+		// Deprecation describes the service deprecation if any.
+		Deprecation *expr.Deprecation
 		// VarName is the endpoint struct name.
 		VarName string
 		// ClientVarName is the client struct name.
@@ -30,6 +32,14 @@ type (
 		// Schemes contains the security schemes types used by the
 		// all the endpoints.
 		Schemes SchemesData
+		// ServerInterceptors lists the interceptors that wrap the
+		// service endpoints.
+		ServerInterceptors []*InterceptorData
+		// ClientInterceptors lists the interceptors that wrap the
+		// service client endpoints.
+		ClientInterceptors []*InterceptorData
+		// Health contains the health checks of the service if any.
+		Health *HealthData
 	}
 
 	// EndpointMethodData describes a single endpoint method.
@@ -70,6 +80,7 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 			{Path: "context"},
 			{Path: "io"},
 			{Path: "fmt"},
+			{Path: "time"},
 			codegen.GoaImport(""),
 			codegen.GoaImport("security"),
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: svc.ViewsPkg},
@@ -115,6 +126,13 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 			Source: serviceEndpointsUseT,
 			Data:   data,
 		})
+		if data.HasRateLimits() {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "endpoints-use-limiter",
+				Source: serviceEndpointsUseLimiterT,
+				Data:   data,
+			})
+		}
 		for _, m := range data.Methods {
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:    "endpoint-method",
@@ -123,6 +141,16 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 				FuncMap: map[string]any{"payloadVar": payloadVar},
 			})
 		}
+		for _, m := range data.Methods {
+			if m.RateLimit == nil {
+				continue
+			}
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "endpoint-rate-limiter",
+				Source: serviceEndpointRateLimiterT,
+				Data:   m,
+			})
+		}
 	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
@@ -144,17 +172,32 @@ func endpointData(service *expr.ServiceExpr) *EndpointsData {
 	}
 	desc := fmt.Sprintf("%s wraps the %q service endpoints.", endpointsStructName, service.Name)
 	return &EndpointsData{
-		Name:           service.Name,
-		Description:    desc,
-		VarName:        endpointsStructName,
-		ClientVarName:  clientStructName,
-		ServiceVarName: serviceInterfaceName,
-		ClientInitArgs: strings.Join(names, ", "),
-		Methods:        methods,
-		Schemes:        svc.Schemes,
+		Name:               service.Name,
+		Description:        desc,
+		Deprecation:        svc.Deprecation,
+		VarName:            endpointsStructName,
+		ClientVarName:      clientStructName,
+		ServiceVarName:     serviceInterfaceName,
+		ClientInitArgs:     strings.Join(names, ", "),
+		Methods:            methods,
+		Schemes:            svc.Schemes,
+		ServerInterceptors: svc.ServerInterceptors,
+		ClientInterceptors: svc.ClientInterceptors,
+		Health:             svc.Health,
 	}
 }
 
+// HasRateLimits returns true if at least one of the service methods defines
+// rate or concurrency limits.
+func (d *EndpointsData) HasRateLimits() bool {
+	for _, m := range d.Methods {
+		if m.RateLimit != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func payloadVar(e *EndpointMethodData) string {
 	if e.ServerStream != nil || e.SkipRequestBodyEncodeDecode {
 		return "ep.Payload"
@@ -168,19 +211,38 @@ type {{ .VarName }} struct {
 {{- range .Methods}}
 	{{ .VarName }} goa.Endpoint
 {{- end }}
+{{- if .Health }}
+	{{ comment "Health lists the dependency checks run by the readiness probes." }}
+	Health []*goa.HealthCheck
+{{- end }}
 }
 `
 
 // input: endpointsData
 const serviceEndpointsInitT = `{{ printf "New%s wraps the methods of the %q service with endpoints." .VarName .Name | comment }}
-func New{{ .VarName }}(s {{ .ServiceVarName }}) *{{ .VarName }} {
+func New{{ .VarName }}(s {{ .ServiceVarName }}{{ if .ServerInterceptors }}, si ServerInterceptors{{ end }}) *{{ .VarName }} {
 {{- if .Schemes }}
 	// Casting service to Auther interface
 	a := s.(Auther)
+{{- end }}
+{{- if and .Health .Health.Checks }}
+	// Casting service to HealthChecker interface
+	hc := s.(HealthChecker)
 {{- end }}
 	return &{{ .VarName }}{
 {{- range .Methods }}
+	{{- if .ServerInterceptors }}
+		{{ .VarName }}: Wrap{{ .VarName }}Endpoint(New{{ .VarName }}Endpoint(s{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}), si),
+	{{- else }}
 		{{ .VarName }}: New{{ .VarName }}Endpoint(s{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}),
+	{{- end }}
+{{- end }}
+{{- if and .Health .Health.Checks }}
+		Health: []*goa.HealthCheck{
+		{{- range .Health.Checks }}
+			{Name: {{ printf "%q" .Name }}, Check: hc.{{ .FuncName }}},
+		{{- end }}
+		},
 {{- end }}
 	}
 }
@@ -385,3 +447,57 @@ func (e *{{ .VarName }}) Use(m func(goa.Endpoint) goa.Endpoint) {
 {{- end }}
 }
 `
+
+// input: EndpointsData
+const serviceEndpointsUseLimiterT = `{{ printf "UseLimiter applies the rate and concurrency limits defined in the design to the %q service endpoints using the given store." .Name | comment }}
+func (e *{{ .VarName }}) UseLimiter(store goa.LimiterStore) {
+{{- range .Methods }}
+	{{- if .RateLimit }}
+	e.{{ .VarName }} = New{{ .VarName }}RateLimiter(store)(e.{{ .VarName }})
+	{{- end }}
+{{- end }}
+}
+`
+
+// input: EndpointMethodData
+const serviceEndpointRateLimiterT = `{{ printf "New%sRateLimiter returns an endpoint middleware that enforces the rate and concurrency limits of the method %q of service %q using the given store." .VarName .Name .ServiceName | comment }}
+func New{{ .VarName }}RateLimiter(store goa.LimiterStore) func(goa.Endpoint) goa.Endpoint {
+	limit := &goa.RateLimit{
+		Name: {{ printf "%q" .RateLimit.Name }},
+	{{- if .RateLimit.Requests }}
+		Requests: {{ .RateLimit.Requests }},
+		Interval: {{ .RateLimit.Interval }},
+	{{- end }}
+	{{- if .RateLimit.Burst }}
+		Burst: {{ .RateLimit.Burst }},
+	{{- end }}
+	{{- if .RateLimit.MaxInFlight }}
+		MaxInFlight: {{ .RateLimit.MaxInFlight }},
+	{{- end }}
+	}
+{{- if .RateLimit.KeyField }}
+	key := func(ctx context.Context, req any) string {
+	{{- if .ServerStream }}
+		p := req.(*{{ .ServerStream.EndpointStruct }}).Payload
+	{{- else if .SkipRequestBodyEncodeDecode }}
+		p := req.(*{{ .RequestStruct }}).Payload
+	{{- else }}
+		p := req.({{ .PayloadRef }})
+	{{- end }}
+	{{- if .RateLimit.KeyPointer }}
+		if p.{{ .RateLimit.KeyField }} == nil {
+			return ""
+		}
+	{{- end }}
+	{{- if .RateLimit.KeyString }}
+		return {{ if .RateLimit.KeyPointer }}*{{ end }}p.{{ .RateLimit.KeyField }}
+	{{- else }}
+		return fmt.Sprint({{ if .RateLimit.KeyPointer }}*{{ end }}p.{{ .RateLimit.KeyField }})
+	{{- end }}
+	}
+	return goa.RateLimiter(store, limit, key)
+{{- else }}
+	return goa.RateLimiter(store, limit, nil)
+{{- end }}
+}
+`
@@ -31,6 +31,10 @@ func TestEndpoint(t *testing.T) {
 		{"endpoint-streaming-payload-no-result", testdata.StreamingPayloadNoResultMethodDSL, testdata.StreamingPayloadNoResultMethodEndpoint},
 		{"endpoint-bidirectional-streaming", testdata.BidirectionalStreamingEndpointDSL, testdata.BidirectionalStreamingMethodEndpoint},
 		{"endpoint-bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadMethodDSL, testdata.BidirectionalStreamingNoPayloadMethodEndpoint},
+		{"endpoint-rate-limit", testdata.RateLimitEndpointDSL, testdata.RateLimitEndpoint},
+		{"endpoint-rate-limit-principal", testdata.RateLimitPrincipalEndpointDSL, testdata.RateLimitPrincipalEndpoint},
+		{"endpoint-interceptors", testdata.InterceptorsEndpointDSL, testdata.InterceptorsEndpoint},
+		{"endpoint-health", testdata.HealthEndpointDSL, testdata.HealthEndpoint},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
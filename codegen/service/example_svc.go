@@ -72,6 +72,7 @@ func exampleServiceFile(genpkg string, _ *expr.RootExpr, svc *expr.ServiceExpr,
 		{Path: "fmt"},
 		{Path: "strings"},
 		{Path: path.Join(genpkg, svcName), Name: data.PkgName},
+		codegen.GoaImport(""),
 		{Path: "goa.design/goa/v3/security"},
 	}
 	sections := []*codegen.SectionTemplate{
@@ -86,6 +87,20 @@ func exampleServiceFile(genpkg string, _ *expr.RootExpr, svc *expr.ServiceExpr,
 			Data:   data,
 		})
 	}
+	if data.Health != nil && len(data.Health.Checks) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "health-checkfuncs",
+			Source: dummyHealthFuncsT,
+			Data:   data,
+		})
+	}
+	if len(data.ServerInterceptors) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "basic-server-interceptors",
+			Source: serverInterceptorsExampleT,
+			Data:   data,
+		})
+	}
 	for _, m := range svc.Methods {
 		sections = append(sections, basicEndpointSection(m, data))
 	}
@@ -143,6 +158,26 @@ type {{ .VarName }}srvc struct {
 func New{{ .StructName }}(logger *log.Logger) {{ .PkgName }}.Service {
 	return &{{ .VarName }}srvc{logger}
 }
+`
+
+	// input: service.Data
+	serverInterceptorsExampleT = `{{ printf "%sServerInterceptors is the example implementation of the %s service server interceptors.\nThe example interceptors log the requests and call the next interceptor or endpoint." .VarName .Name | comment }}
+type {{ .VarName }}ServerInterceptors struct {
+	logger *log.Logger
+}
+
+{{ printf "New%sServerInterceptors returns the %s service server interceptors implementation." .StructName .Name | comment }}
+func New{{ .StructName }}ServerInterceptors(logger *log.Logger) {{ .PkgName }}.ServerInterceptors {
+	return &{{ .VarName }}ServerInterceptors{logger}
+}
+{{- range .ServerInterceptors }}
+
+{{ printf "%s implements the %q interceptor." .VarName .Name | comment }}
+func (i *{{ $.VarName }}ServerInterceptors) {{ .VarName }}(ctx context.Context, info *{{ $.PkgName }}.{{ .InfoName }}, next goa.Endpoint) (any, error) {
+	i.logger.Printf("%s.%s: {{ .Name }} interceptor", info.Service(), info.Method())
+	return next(ctx, info.RawPayload())
+}
+{{- end }}
 `
 
 	// input: basicEndpointData
@@ -0,0 +1,15 @@
+package service
+
+// data: Data
+const dummyHealthFuncsT = `{{ range .Health.Checks }}
+{{ printf "%s implements the %q dependency check of service %q." .FuncName .Name $.Name | comment }}
+func (s *{{ $.VarName }}srvc) {{ .FuncName }}(ctx context.Context) error {
+	//
+	// TBD: check that the dependency is available, e.g. ping the
+	// database. The readiness probes fail if this function returns an
+	// error.
+	//
+	return nil
+}
+{{- end }}
+`
@@ -0,0 +1,546 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// InterceptorData describes an interceptor used by the service
+	// endpoints or clients.
+	InterceptorData struct {
+		// Name is the interceptor name.
+		Name string
+		// Description is the interceptor description.
+		Description string
+		// VarName is the name of the interceptor interface method.
+		VarName string
+		// InfoName is the name of the struct given to the interceptor
+		// that gives access to the payload and result.
+		InfoName string
+		// PayloadAccessor is the name of the payload accessor interface,
+		// empty if the interceptor does not access the payload.
+		PayloadAccessor string
+		// ResultAccessor is the name of the result accessor interface,
+		// empty if the interceptor does not access the result.
+		ResultAccessor string
+		// ReadPayload lists the payload attributes read by the
+		// interceptor.
+		ReadPayload []*InterceptorAttributeData
+		// WritePayload lists the payload attributes written by the
+		// interceptor.
+		WritePayload []*InterceptorAttributeData
+		// ReadResult lists the result attributes read by the interceptor.
+		ReadResult []*InterceptorAttributeData
+		// WriteResult lists the result attributes written by the
+		// interceptor.
+		WriteResult []*InterceptorAttributeData
+		// Methods lists the methods the interceptor applies to.
+		Methods []*InterceptorMethodData
+	}
+
+	// InterceptorMethodData contains the data needed to generate the
+	// accessors of an interceptor for a given method.
+	InterceptorMethodData struct {
+		// Name is the method name.
+		Name string
+		// PayloadAccess is the name of the struct that implements the
+		// payload accessor interface for the method.
+		PayloadAccess string
+		// ResultAccess is the name of the struct that implements the
+		// result accessor interface for the method.
+		ResultAccess string
+		// PayloadRef is the reference to the method payload type.
+		PayloadRef string
+		// ResultRef is the reference to the method result type.
+		ResultRef string
+		// RequestStruct is the name of the struct that wraps the payload
+		// if the method skips the request body encoding and decoding.
+		RequestStruct string
+		// ResponseStruct is the name of the struct that wraps the result
+		// if the method skips the response body encoding and decoding.
+		ResponseStruct string
+		// ReadPayload lists the payload attributes read by the
+		// interceptor.
+		ReadPayload []*InterceptorAttributeData
+		// WritePayload lists the payload attributes written by the
+		// interceptor.
+		WritePayload []*InterceptorAttributeData
+		// ReadResult lists the result attributes read by the interceptor.
+		ReadResult []*InterceptorAttributeData
+		// WriteResult lists the result attributes written by the
+		// interceptor.
+		WriteResult []*InterceptorAttributeData
+	}
+
+	// InterceptorAttributeData describes an attribute accessed by an
+	// interceptor.
+	InterceptorAttributeData struct {
+		// Name is the attribute name.
+		Name string
+		// FieldName is the name of the corresponding struct field and
+		// accessor methods.
+		FieldName string
+		// TypeRef is the reference to the attribute Go type.
+		TypeRef string
+		// Pointer is true if the struct field is a pointer to TypeRef.
+		Pointer bool
+	}
+)
+
+// InterceptorsFile returns the file that defines the interceptor interfaces
+// and the functions that wrap the endpoints with the interceptors for the
+// given service, nil if the service does not use interceptors.
+func InterceptorsFile(_ string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	if len(svc.ServerInterceptors) == 0 && len(svc.ClientInterceptors) == 0 {
+		return nil
+	}
+	path := filepath.Join(codegen.Gendir, svc.PathName, "interceptors.go")
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		codegen.GoaImport(""),
+	}
+	imports = append(imports, svc.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" interceptors", svc.PkgName, imports),
+	}
+	if len(svc.ServerInterceptors) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "server-interceptors",
+			Source:  serverInterceptorsT,
+			Data:    svc,
+			FuncMap: map[string]any{"interceptorComment": interceptorMethodComment},
+		})
+	}
+	if len(svc.ClientInterceptors) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "client-interceptors",
+			Source:  clientInterceptorsT,
+			Data:    svc,
+			FuncMap: map[string]any{"interceptorComment": interceptorMethodComment},
+		})
+	}
+	for _, i := range svc.interceptors() {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "interceptor-types",
+			Source: interceptorTypesT,
+			Data:   i,
+		})
+	}
+	for _, i := range svc.interceptors() {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "interceptor-info",
+			Source: interceptorInfoT,
+			Data:   i,
+		})
+	}
+	for _, m := range svc.Methods {
+		if len(m.ServerInterceptors) > 0 {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:    "server-interceptor-wrapper",
+				Source:  serverInterceptorWrapperT,
+				Data:    map[string]any{"Service": svc, "Method": m},
+				FuncMap: map[string]any{"reverse": reverseInterceptors},
+			})
+		}
+		if len(m.ClientInterceptors) > 0 {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:    "client-interceptor-wrapper",
+				Source:  clientInterceptorWrapperT,
+				Data:    map[string]any{"Service": svc, "Method": m},
+				FuncMap: map[string]any{"reverse": reverseInterceptors},
+			})
+		}
+	}
+	for _, i := range svc.interceptors() {
+		for _, m := range i.Methods {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "interceptor-accessors",
+				Source: interceptorAccessorsT,
+				Data:   m,
+			})
+		}
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// interceptors returns the interceptors used by the service endpoints or
+// clients.
+func (s *Data) interceptors() []*InterceptorData {
+	is := append([]*InterceptorData{}, s.ServerInterceptors...)
+	for _, c := range s.ClientInterceptors {
+		found := false
+		for _, i := range is {
+			if i == c {
+				found = true
+				break
+			}
+		}
+		if !found {
+			is = append(is, c)
+		}
+	}
+	return is
+}
+
+// buildInterceptorsData initializes the interceptor data of the service and of
+// its methods. The interceptors used by both the server and the client share
+// the same data.
+func buildInterceptorsData(service *expr.ServiceExpr, methods []*MethodData, scope *codegen.NameScope) (server, client []*InterceptorData) {
+	seen := make(map[string]*InterceptorData)
+	build := func(i *expr.InterceptorExpr, m *expr.MethodExpr, md *MethodData) *InterceptorData {
+		data, ok := seen[i.Name]
+		if !ok {
+			varName := codegen.Goify(i.Name, true)
+			data = &InterceptorData{
+				Name:        i.Name,
+				Description: i.Description,
+				VarName:     varName,
+				InfoName:    varName + "Info",
+			}
+			if i.HasPayloadAccess() {
+				data.PayloadAccessor = varName + "Payload"
+			}
+			if i.HasResultAccess() {
+				data.ResultAccessor = varName + "Result"
+			}
+			seen[i.Name] = data
+		}
+		for _, im := range data.Methods {
+			if im.Name == m.Name {
+				return data
+			}
+		}
+		im := &InterceptorMethodData{
+			Name:         m.Name,
+			ReadPayload:  interceptorAttributes(i.ReadPayload, m.Payload, scope),
+			WritePayload: interceptorAttributes(i.WritePayload, m.Payload, scope),
+			ReadResult:   interceptorAttributes(i.ReadResult, m.Result, scope),
+			WriteResult:  interceptorAttributes(i.WriteResult, m.Result, scope),
+		}
+		prefix := codegen.Goify(i.Name, false) + md.VarName
+		if data.PayloadAccessor != "" {
+			im.PayloadAccess = prefix + "Payload"
+			im.PayloadRef = md.PayloadRef
+			if md.SkipRequestBodyEncodeDecode {
+				im.RequestStruct = md.RequestStruct
+			}
+		}
+		if data.ResultAccessor != "" {
+			im.ResultAccess = prefix + "Result"
+			im.ResultRef = md.ResultRef
+			if md.SkipResponseBodyEncodeDecode {
+				im.ResponseStruct = md.ResponseStruct
+			}
+		}
+		if len(data.Methods) == 0 {
+			data.ReadPayload = im.ReadPayload
+			data.WritePayload = im.WritePayload
+			data.ReadResult = im.ReadResult
+			data.WriteResult = im.WriteResult
+		}
+		data.Methods = append(data.Methods, im)
+		return data
+	}
+	add := func(is []*InterceptorData, i *InterceptorData) []*InterceptorData {
+		for _, e := range is {
+			if e == i {
+				return is
+			}
+		}
+		return append(is, i)
+	}
+	for idx, m := range service.Methods {
+		md := methods[idx]
+		for _, i := range m.AllServerInterceptors() {
+			data := build(i, m, md)
+			md.ServerInterceptors = append(md.ServerInterceptors, data)
+			server = add(server, data)
+		}
+		for _, i := range m.AllClientInterceptors() {
+			data := build(i, m, md)
+			md.ClientInterceptors = append(md.ClientInterceptors, data)
+			client = add(client, data)
+		}
+	}
+	return
+}
+
+// interceptorAttributes returns the data describing the attributes of att
+// accessed by an interceptor. parent is the method payload or result that
+// defines the attributes.
+func interceptorAttributes(att, parent *expr.AttributeExpr, scope *codegen.NameScope) []*InterceptorAttributeData {
+	if att == nil {
+		return nil
+	}
+	obj := expr.AsObject(att.Type)
+	if obj == nil {
+		return nil
+	}
+	data := make([]*InterceptorAttributeData, 0, len(*obj))
+	for _, nat := range *obj {
+		pat := parent.Find(nat.Name)
+		if pat == nil {
+			continue
+		}
+		data = append(data, &InterceptorAttributeData{
+			Name:      nat.Name,
+			FieldName: codegen.GoifyAtt(pat, nat.Name, true),
+			TypeRef:   scope.GoTypeRef(pat),
+			Pointer:   parent.IsPrimitivePointer(nat.Name, true),
+		})
+	}
+	return data
+}
+
+// reverseInterceptors returns the interceptors in reverse order so that the
+// first interceptor wraps all the others.
+func reverseInterceptors(is []*InterceptorData) []*InterceptorData {
+	res := make([]*InterceptorData, len(is))
+	for i, in := range is {
+		res[len(is)-1-i] = in
+	}
+	return res
+}
+
+// interceptorMethodComment returns the comment of the interface method
+// implementing the given interceptor.
+func interceptorMethodComment(i *InterceptorData) string {
+	if i.Description != "" {
+		return i.Description
+	}
+	return fmt.Sprintf("%s implements the %q interceptor.", i.VarName, i.Name)
+}
+
+// input: Data
+const serverInterceptorsT = `{{ printf "ServerInterceptors defines the interceptors that wrap the %q service endpoints. Each interceptor must call next to invoke the next interceptor or the endpoint and return the result." .Name | comment }}
+type ServerInterceptors interface {
+{{- range .ServerInterceptors }}
+	{{ interceptorComment . | comment }}
+	{{ .VarName }}(ctx context.Context, info *{{ .InfoName }}, next goa.Endpoint) (any, error)
+{{- end }}
+}
+`
+
+// input: Data
+const clientInterceptorsT = `{{ printf "ClientInterceptors defines the interceptors that wrap the %q service client endpoints. Each interceptor must call next to invoke the next interceptor or the endpoint and return the result." .Name | comment }}
+type ClientInterceptors interface {
+{{- range .ClientInterceptors }}
+	{{ interceptorComment . | comment }}
+	{{ .VarName }}(ctx context.Context, info *{{ .InfoName }}, next goa.Endpoint) (any, error)
+{{- end }}
+}
+`
+
+// input: InterceptorData
+const interceptorTypesT = `{{ printf "%s gives the %q interceptor access to the current request." .InfoName .Name | comment }}
+type {{ .InfoName }} struct {
+	service    string
+	method     string
+	rawPayload any
+}
+{{- if .PayloadAccessor }}
+
+{{ printf "%s gives the %q interceptor access to the payload attributes listed in the design." .PayloadAccessor .Name | comment }}
+type {{ .PayloadAccessor }} interface {
+	{{- range .ReadPayload }}
+	{{ .FieldName }}() {{ .TypeRef }}
+	{{- end }}
+	{{- range .WritePayload }}
+	Set{{ .FieldName }}({{ .TypeRef }})
+	{{- end }}
+}
+{{- end }}
+{{- if .ResultAccessor }}
+
+{{ printf "%s gives the %q interceptor access to the result attributes listed in the design." .ResultAccessor .Name | comment }}
+type {{ .ResultAccessor }} interface {
+	{{- range .ReadResult }}
+	{{ .FieldName }}() {{ .TypeRef }}
+	{{- end }}
+	{{- range .WriteResult }}
+	Set{{ .FieldName }}({{ .TypeRef }})
+	{{- end }}
+}
+{{- end }}
+{{- range .Methods }}
+	{{- if .PayloadAccess }}
+
+type {{ .PayloadAccess }} struct {
+	payload {{ .PayloadRef }}
+}
+	{{- end }}
+	{{- if .ResultAccess }}
+
+type {{ .ResultAccess }} struct {
+	result {{ .ResultRef }}
+}
+	{{- end }}
+{{- end }}
+`
+
+// input: InterceptorData
+const interceptorInfoT = `{{ comment "Service returns the name of the service." }}
+func (info *{{ .InfoName }}) Service() string {
+	return info.service
+}
+
+{{ comment "Method returns the name of the method." }}
+func (info *{{ .InfoName }}) Method() string {
+	return info.method
+}
+
+{{ comment "RawPayload returns the request given to the endpoint." }}
+func (info *{{ .InfoName }}) RawPayload() any {
+	return info.rawPayload
+}
+{{- if .PayloadAccessor }}
+
+{{ comment "Payload returns the accessor of the request payload attributes." }}
+func (info *{{ .InfoName }}) Payload() {{ .PayloadAccessor }} {
+	switch info.method {
+	{{- range .Methods }}
+	case {{ printf "%q" .Name }}:
+		{{- if .RequestStruct }}
+		req, ok := info.rawPayload.(*{{ .RequestStruct }})
+		if !ok {
+			return nil
+		}
+		return &{{ .PayloadAccess }}{payload: req.Payload}
+		{{- else }}
+		p, ok := info.rawPayload.({{ .PayloadRef }})
+		if !ok {
+			return nil
+		}
+		return &{{ .PayloadAccess }}{payload: p}
+		{{- end }}
+	{{- end }}
+	default:
+		return nil
+	}
+}
+{{- end }}
+{{- if .ResultAccessor }}
+
+{{ comment "Result returns the accessor of the attributes of the result returned by next, nil if the result is nil." }}
+func (info *{{ .InfoName }}) Result(res any) {{ .ResultAccessor }} {
+	switch info.method {
+	{{- range .Methods }}
+	case {{ printf "%q" .Name }}:
+		{{- if .ResponseStruct }}
+		resp, ok := res.(*{{ .ResponseStruct }})
+		if !ok || resp.Result == nil {
+			return nil
+		}
+		return &{{ .ResultAccess }}{result: resp.Result}
+		{{- else }}
+		r, ok := res.({{ .ResultRef }})
+		if !ok || r == nil {
+			return nil
+		}
+		return &{{ .ResultAccess }}{result: r}
+		{{- end }}
+	{{- end }}
+	default:
+		return nil
+	}
+}
+{{- end }}
+`
+
+// input: map[string]any{"Service": Data, "Method": MethodData}
+const serverInterceptorWrapperT = `{{ printf "Wrap%sEndpoint wraps the %q endpoint of the %q service with the server interceptors defined in the design." .Method.VarName .Method.Name .Service.Name | comment }}
+func Wrap{{ .Method.VarName }}Endpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+{{- range reverse .Method.ServerInterceptors }}
+	endpoint = wrap{{ $.Method.VarName }}{{ .VarName }}(endpoint, i)
+{{- end }}
+	return endpoint
+}
+{{- range .Method.ServerInterceptors }}
+
+{{ printf "wrap%s%s applies the %q server interceptor to the %q endpoint." $.Method.VarName .VarName .Name $.Method.Name | comment }}
+func wrap{{ $.Method.VarName }}{{ .VarName }}(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		info := &{{ .InfoName }}{
+			service:    {{ printf "%q" $.Service.Name }},
+			method:     {{ printf "%q" $.Method.Name }},
+			rawPayload: req,
+		}
+		return i.{{ .VarName }}(ctx, info, endpoint)
+	}
+}
+{{- end }}
+`
+
+// input: map[string]any{"Service": Data, "Method": MethodData}
+const clientInterceptorWrapperT = `{{ printf "Wrap%sClientEndpoint wraps the %q client endpoint of the %q service with the client interceptors defined in the design." .Method.VarName .Method.Name .Service.Name | comment }}
+func Wrap{{ .Method.VarName }}ClientEndpoint(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+{{- range reverse .Method.ClientInterceptors }}
+	endpoint = wrapClient{{ $.Method.VarName }}{{ .VarName }}(endpoint, i)
+{{- end }}
+	return endpoint
+}
+{{- range .Method.ClientInterceptors }}
+
+{{ printf "wrapClient%s%s applies the %q client interceptor to the %q client endpoint." $.Method.VarName .VarName .Name $.Method.Name | comment }}
+func wrapClient{{ $.Method.VarName }}{{ .VarName }}(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		info := &{{ .InfoName }}{
+			service:    {{ printf "%q" $.Service.Name }},
+			method:     {{ printf "%q" $.Method.Name }},
+			rawPayload: req,
+		}
+		return i.{{ .VarName }}(ctx, info, endpoint)
+	}
+}
+{{- end }}
+`
+
+// input: InterceptorMethodData
+const interceptorAccessorsT = `{{- $access := .PayloadAccess }}
+{{- range .ReadPayload }}
+{{ printf "%s returns the value of the %q payload attribute." .FieldName .Name | comment }}
+func (p *{{ $access }}) {{ .FieldName }}() {{ .TypeRef }} {
+	{{- if .Pointer }}
+	if p.payload.{{ .FieldName }} == nil {
+		var zero {{ .TypeRef }}
+		return zero
+	}
+	return *p.payload.{{ .FieldName }}
+	{{- else }}
+	return p.payload.{{ .FieldName }}
+	{{- end }}
+}
+{{ end }}
+{{- range .WritePayload }}
+{{ printf "Set%s sets the value of the %q payload attribute." .FieldName .Name | comment }}
+func (p *{{ $access }}) Set{{ .FieldName }}(v {{ .TypeRef }}) {
+	p.payload.{{ .FieldName }} = {{ if .Pointer }}&{{ end }}v
+}
+{{ end }}
+{{- $access = .ResultAccess }}
+{{- range .ReadResult }}
+{{ printf "%s returns the value of the %q result attribute." .FieldName .Name | comment }}
+func (r *{{ $access }}) {{ .FieldName }}() {{ .TypeRef }} {
+	{{- if .Pointer }}
+	if r.result.{{ .FieldName }} == nil {
+		var zero {{ .TypeRef }}
+		return zero
+	}
+	return *r.result.{{ .FieldName }}
+	{{- else }}
+	return r.result.{{ .FieldName }}
+	{{- end }}
+}
+{{ end }}
+{{- range .WriteResult }}
+{{ printf "Set%s sets the value of the %q result attribute." .FieldName .Name | comment }}
+func (r *{{ $access }}) Set{{ .FieldName }}(v {{ .TypeRef }}) {
+	r.result.{{ .FieldName }} = {{ if .Pointer }}&{{ end }}v
+}
+{{ end }}
+`
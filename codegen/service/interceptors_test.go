@@ -0,0 +1,45 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestInterceptors(t *testing.T) {
+	codegen.RunDSL(t, testdata.InterceptorsEndpointDSL)
+	if len(expr.Root.Services) != 1 {
+		t.Fatalf("got %d services, expected 1", len(expr.Root.Services))
+	}
+	fs := InterceptorsFile("test/gen", expr.Root.Services[0])
+	if fs == nil {
+		t.Fatalf("got nil file, expected not nil")
+	}
+	buf := new(bytes.Buffer)
+	for _, s := range fs.SectionTemplates[1:] {
+		if err := s.Write(buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bs, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Println(buf.String())
+		t.Fatal(err)
+	}
+	code := string(bs)
+	if code != testdata.InterceptorsCode {
+		t.Errorf("got\n%s\ngot vs expected\n:%s", code, codegen.Diff(t, code, testdata.InterceptorsCode))
+	}
+}
+
+func TestNoInterceptors(t *testing.T) {
+	codegen.RunDSL(t, testdata.SingleEndpointDSL)
+	if fs := InterceptorsFile("test/gen", expr.Root.Services[0]); fs != nil {
+		t.Errorf("got file %s, expected nil", fs.Path)
+	}
+}
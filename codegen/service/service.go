@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
@@ -100,6 +101,36 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 		})
 	}
 
+	// The String methods are generated next to the types, the LogValue
+	// methods are generated in separate files that require Go 1.21 for
+	// log/slog.
+	var (
+		redactPaths   = make(map[string]struct{})
+		logValuePaths []string
+		logValueSecs  = make(map[string][]*codegen.SectionTemplate)
+	)
+	for _, m := range svc.redactMethods {
+		path := pathWithDefault(m.Loc, svcPath)
+		if m.String {
+			redactPaths[path] = struct{}{}
+			addTypeDefSection(path, "~"+m.TypeName+".String", &codegen.SectionTemplate{
+				Name:   "service-redact-string",
+				Source: redactStringT,
+				Data:   m,
+			})
+		}
+		if m.LogValue {
+			if _, ok := logValueSecs[path]; !ok {
+				logValuePaths = append(logValuePaths, path)
+			}
+			logValueSecs[path] = append(logValueSecs[path], &codegen.SectionTemplate{
+				Name:   "service-redact-log-value",
+				Source: redactLogValueT,
+				Data:   m,
+			})
+		}
+	}
+
 	for _, et := range errorTypes {
 		// Don't override the section created for the error type
 		// declaration, make sure the key does not clash with existing
@@ -168,6 +199,9 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 		codegen.GoaImport("security"),
 		codegen.NewImport(svc.ViewsPkg, genpkg+"/"+svcName+"/views"),
 	}
+	if _, ok := redactPaths[svcPath]; ok {
+		imports = append(imports, codegen.SimpleImport("fmt"))
+	}
 	imports = append(imports, svc.UserTypeImports...)
 	header := codegen.Header(service.Name+" service", svc.PkgName, imports)
 	def := &codegen.SectionTemplate{
@@ -228,11 +262,38 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 		}
 		fullRelPath := filepath.Join(codegen.Gendir, p)
 		dir, _ := filepath.Split(fullRelPath)
-		h := codegen.Header("User types", codegen.Goify(filepath.Base(dir), false), nil)
+		var imports []*codegen.ImportSpec
+		if _, ok := redactPaths[p]; ok {
+			imports = []*codegen.ImportSpec{codegen.SimpleImport("fmt")}
+		}
+		h := codegen.Header("User types", codegen.Goify(filepath.Base(dir), false), imports)
 		sections := append([]*codegen.SectionTemplate{h}, secs...)
 		files = append(files, &codegen.File{Path: fullRelPath, SectionTemplates: sections})
 	}
 
+	// LogValue methods
+	for _, p := range logValuePaths {
+		var (
+			title = "User types"
+			pkg   string
+			fpath string
+		)
+		if p == svcPath {
+			title = service.Name + " service"
+			pkg = svc.PkgName
+			fpath = strings.TrimSuffix(p, ".go") + "_slog.go"
+		} else {
+			fullRelPath := filepath.Join(codegen.Gendir, p)
+			dir, _ := filepath.Split(fullRelPath)
+			pkg = codegen.Goify(filepath.Base(dir), false)
+			fpath = strings.TrimSuffix(fullRelPath, ".go") + "_slog.go"
+		}
+		h := codegen.Header(title, pkg, []*codegen.ImportSpec{codegen.SimpleImport("log/slog")})
+		codegen.AddBuildTag(h, "go1.21")
+		sections := append([]*codegen.SectionTemplate{h}, logValueSecs[p]...)
+		files = append(files, &codegen.File{Path: fpath, SectionTemplates: sections})
+	}
+
 	return files
 }
 
@@ -295,10 +356,10 @@ func pathWithDefault(loc *codegen.Location, def string) string {
 
 // serviceT is the template used to write an service definition.
 const serviceT = `
-{{ comment .Description }}
+{{ comment .Description | deprecated .Deprecation }}
 type Service interface {
 {{- range .Methods }}
-	{{ comment .Description }}
+	{{ comment .Description | deprecated .Deprecation }}
 	{{- if .ViewedResult }}
 		{{- if not .ViewedResult.ViewName }}
 			{{ comment "The \"view\" return value must have one of the following views" }}
@@ -329,6 +390,20 @@ type Auther interface {
 }
 {{- end }}
 
+{{- if and .Health .Health.Checks }}
+// HealthChecker defines the dependency checks to be implemented by the service.
+type HealthChecker interface {
+	{{- range .Health.Checks }}
+	{{- if .Description }}
+	{{ printf "%s returns an error if the %q dependency is not available. %s" .FuncName .Name .Description | comment }}
+	{{- else }}
+	{{ printf "%s returns an error if the %q dependency is not available." .FuncName .Name | comment }}
+	{{- end }}
+	{{ .FuncName }}(context.Context) error
+	{{- end }}
+}
+{{- end }}
+
 // ServiceName is the name of the service as defined in the design. This is the
 // same value that is set in the endpoint request contexts under the ServiceKey
 // key.
@@ -380,7 +455,7 @@ const resultT = `{{ comment .ResultDesc }}
 type {{ .Result }} {{ .ResultDef }}
 `
 
-const userTypeT = `{{ comment .Description }}
+const userTypeT = `{{ comment .Description | deprecated .Deprecation }}
 type {{ .VarName }} {{ .Def }}
 `
 
@@ -402,6 +477,51 @@ func (e {{ .Ref }}) GoaErrorName() string {
 }
 `
 
+// input: RedactMethodsData
+const redactStringT = `{{ printf "String returns a string representation of %s where the sensitive fields are redacted." .TypeName | comment }}
+func (t *{{ .TypeName }}) String() string {
+	if t == nil {
+		return "<nil>"
+	}
+{{- range .Fields }}
+	{{- if .Pointer }}
+	var {{ .VarName }} any
+	if t.{{ .FieldName }} != nil {
+		{{ .VarName }} = *t.{{ .FieldName }}
+	}
+	{{- end }}
+{{- end }}
+	return fmt.Sprintf("&{ {{- range $i, $f := .Fields }}{{ if $i }} {{ end }}{{ $f.FieldName }}:{{ if $f.Sensitive }}[REDACTED]{{ else }}%v{{ end }}{{ end }}}"
+	{{- range .Fields }}{{ if not .Sensitive }}, {{ if .Pointer }}{{ .VarName }}{{ else }}t.{{ .FieldName }}{{ end }}{{ end }}{{ end }})
+}
+`
+
+// input: RedactMethodsData
+const redactLogValueT = `{{ printf "LogValue implements slog.LogValuer and redacts the sensitive fields of %s." .TypeName | comment }}
+func (t *{{ .TypeName }}) LogValue() slog.Value {
+	if t == nil {
+		return slog.AnyValue(nil)
+	}
+{{- range .Fields }}
+	{{- if .Pointer }}
+	var {{ .VarName }} any
+	if t.{{ .FieldName }} != nil {
+		{{ .VarName }} = *t.{{ .FieldName }}
+	}
+	{{- end }}
+{{- end }}
+	return slog.GroupValue(
+	{{- range .Fields }}
+		{{- if .Sensitive }}
+		slog.String({{ printf "%q" .Name }}, "[REDACTED]"),
+		{{- else }}
+		slog.Any({{ printf "%q" .Name }}, {{ if .Pointer }}{{ .VarName }}{{ else }}t.{{ .FieldName }}{{ end }}),
+		{{- end }}
+	{{- end }}
+	)
+}
+`
+
 const unionValueMethodT = `func ({{ .TypeRef }}) {{ .Name }}() {}
 `
 
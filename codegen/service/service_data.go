@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 	"text/template"
+	"time"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
@@ -41,6 +42,8 @@ type (
 		Name string
 		// Description is the service description.
 		Description string
+		// Deprecation describes the service deprecation if any.
+		Deprecation *expr.Deprecation
 		// StructName is the service struct name.
 		StructName string
 		// VarName is the service variable name (first letter in lowercase).
@@ -67,6 +70,15 @@ type (
 		// ProtoImports lists the import specifications for the custom
 		// proto types used by the service.
 		ProtoImports []*codegen.ImportSpec
+		// ServerInterceptors lists the interceptors that wrap the service
+		// endpoints.
+		ServerInterceptors []*InterceptorData
+		// ClientInterceptors lists the interceptors that wrap the service
+		// client endpoints.
+		ClientInterceptors []*InterceptorData
+		// Health contains the data needed to generate the health checks
+		// of the service if any.
+		Health *HealthData
 
 		// userTypes lists the type definitions that the service depends on.
 		userTypes []*UserTypeData
@@ -84,6 +96,44 @@ type (
 		viewedResultTypes []*ViewedResultTypeData
 		// unionValueMethods lists the methods used to define union types.
 		unionValueMethods []*UnionValueMethodData
+		// redactMethods lists the String and LogValue methods of the types
+		// that have sensitive attributes.
+		redactMethods []*RedactMethodsData
+	}
+
+	// RedactMethodsData describes the String and LogValue methods generated
+	// for a service type that has sensitive attributes.
+	RedactMethodsData struct {
+		// TypeName is the name of the type.
+		TypeName string
+		// Fields lists the type fields.
+		Fields []*RedactFieldData
+		// String is true if the String method is generated, false if
+		// the type has a field named String.
+		String bool
+		// LogValue is true if the LogValue method is generated, false
+		// if the type has a field named LogValue.
+		LogValue bool
+		// Loc defines the file and Go package of the type if overridden
+		// in the design via Meta.
+		Loc *codegen.Location
+	}
+
+	// RedactFieldData describes a field of a type that has sensitive
+	// attributes.
+	RedactFieldData struct {
+		// Name is the attribute name.
+		Name string
+		// FieldName is the name of the struct field.
+		FieldName string
+		// Sensitive is true if the attribute is sensitive.
+		Sensitive bool
+		// Pointer is true if the field is a pointer to a primitive
+		// value that must be dereferenced before being formatted.
+		Pointer bool
+		// VarName is the name of the variable that holds the
+		// dereferenced value of pointer fields.
+		VarName string
 	}
 
 	// UnionValueMethodData describes a method used on a union value type.
@@ -124,6 +174,8 @@ type (
 		Name string
 		// Description is the method description.
 		Description string
+		// Deprecation describes the method deprecation if any.
+		Deprecation *expr.Deprecation
 		// VarName is the Go method name.
 		VarName string
 		// Payload is the name of the payload type if any,
@@ -201,6 +253,131 @@ type (
 		// result and response body reader when SkipResponseBodyEncodeDecode is
 		// used.
 		ResponseStruct string
+		// Retry contains the data needed to generate the client retry
+		// policy if any.
+		Retry *RetryData
+		// RateLimit contains the data needed to generate the endpoint
+		// rate limiter if any.
+		RateLimit *RateLimitData
+		// Pagination contains the data needed to generate the client
+		// iterator if the method is paginated.
+		Pagination *PaginationData
+		// ServerInterceptors lists the interceptors that wrap the method
+		// endpoint in the order they run.
+		ServerInterceptors []*InterceptorData
+		// ClientInterceptors lists the interceptors that wrap the method
+		// client endpoint in the order they run.
+		ClientInterceptors []*InterceptorData
+	}
+
+	// RetryData contains the data needed to initialize the goa.RetryPolicy
+	// used by the generated client endpoints.
+	RetryData struct {
+		// MaxAttempts is the maximum number of attempts.
+		MaxAttempts int
+		// InitialBackoff is the Go code for the initial backoff duration.
+		InitialBackoff string
+		// MaxBackoff is the Go code for the maximum backoff duration.
+		MaxBackoff string
+		// Jitter is the fraction of the backoff delay that is randomized.
+		Jitter float64
+		// HedgeDelay is the Go code for the hedging delay if any.
+		HedgeDelay string
+		// Errors lists the names of the retryable errors: the errors
+		// listed in the design and the method errors designed as
+		// temporary or timeouts.
+		Errors []string
+		// StatusCodes lists the retryable HTTP status codes.
+		StatusCodes []int
+		// IdempotentOnly is true if only idempotent requests may be
+		// retried.
+		IdempotentOnly bool
+	}
+
+	// HealthData contains the data needed to generate the health checks of
+	// a service.
+	HealthData struct {
+		// LivenessPath is the HTTP path of the liveness probe.
+		LivenessPath string
+		// ReadinessPath is the HTTP path of the readiness probe.
+		ReadinessPath string
+		// Checks lists the dependency checks.
+		Checks []*HealthCheckData
+		// Shared is true if the checks are defined in the API and
+		// shared by the services that do not define their own. The HTTP
+		// probes of shared checks are mounted once by the server.
+		Shared bool
+	}
+
+	// HealthCheckData describes a dependency check.
+	HealthCheckData struct {
+		// Name is the name of the checked dependency.
+		Name string
+		// Description is the dependency description.
+		Description string
+		// FuncName is the name of the HealthChecker interface function
+		// that implements the check.
+		FuncName string
+	}
+
+	// RateLimitData contains the data needed to initialize the
+	// goa.RateLimit used by the generated endpoint rate limiter.
+	RateLimitData struct {
+		// Name identifies the limit in the limiter store.
+		Name string
+		// Requests is the number of requests allowed during Interval.
+		Requests int
+		// Interval is the Go code for the rate limit interval.
+		Interval string
+		// Burst is the maximum number of requests allowed at once.
+		Burst int
+		// MaxInFlight is the maximum number of concurrent requests.
+		MaxInFlight int
+		// KeyField is the name of the payload field whose value is used
+		// to group requests if any.
+		KeyField string
+		// KeyPointer is true if the key field is a pointer.
+		KeyPointer bool
+		// KeyString is true if the key field is a string.
+		KeyString bool
+	}
+
+	// PaginationData contains the data needed to generate the iterator
+	// that walks the pages returned by a paginated method.
+	PaginationData struct {
+		// IteratorName is the name of the iterator struct.
+		IteratorName string
+		// PayloadName is the name of the payload struct.
+		PayloadName string
+		// Cursor is true if the method uses cursor pagination, false if
+		// it uses offset pagination.
+		Cursor bool
+		// CursorField is the name of the payload field that holds the
+		// token of the requested page.
+		CursorField string
+		// CursorPointer is true if the cursor field is a pointer.
+		CursorPointer bool
+		// NextCursorField is the name of the result field that holds the
+		// token of the next page.
+		NextCursorField string
+		// NextCursorPointer is true if the next cursor field is a
+		// pointer.
+		NextCursorPointer bool
+		// OffsetField is the name of the payload field that holds the
+		// index of the first item of the requested page.
+		OffsetField string
+		// OffsetPointer is true if the offset field is a pointer.
+		OffsetPointer bool
+		// LimitField is the name of the payload field that holds the page
+		// size if any.
+		LimitField string
+		// LimitPointer is true if the limit field is a pointer.
+		LimitPointer bool
+		// ItemsField is the name of the result field that lists the page
+		// items.
+		ItemsField string
+		// ItemRef is the reference to the Go type of the page items.
+		ItemRef string
 	}
 
 	// StreamData is the data used to generate client and server interfaces that
@@ -256,6 +433,8 @@ type (
 		VarName string
 		// Description is the type human description.
 		Description string
+		// Deprecation describes the type deprecation if any.
+		Deprecation *expr.Deprecation
 		// Def is the type definition Go code.
 		Def string
 		// Ref is the reference to the type.
@@ -733,6 +912,27 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		}
 	}
 
+	var (
+		redactMethods []*RedactMethodsData
+	)
+	{
+		seen := make(map[string]struct{})
+		for _, t := range types {
+			redactMethods = append(redactMethods, collectRedactMethods(&expr.AttributeExpr{Type: t.Type}, scope, seen)...)
+		}
+		for _, t := range errTypes {
+			redactMethods = append(redactMethods, collectRedactMethods(&expr.AttributeExpr{Type: t.Type}, scope, seen)...)
+		}
+		for _, m := range service.Methods {
+			redactMethods = append(redactMethods, collectRedactMethods(m.Payload, scope, seen)...)
+			redactMethods = append(redactMethods, collectRedactMethods(m.StreamingPayload, scope, seen)...)
+			redactMethods = append(redactMethods, collectRedactMethods(m.Result, scope, seen)...)
+		}
+		sort.Slice(redactMethods, func(i, j int) bool {
+			return redactMethods[i].TypeName < redactMethods[j].TypeName
+		})
+	}
+
 	var (
 		desc string
 	)
@@ -743,10 +943,13 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		}
 	}
 
+	serverInterceptors, clientInterceptors := buildInterceptorsData(service, methods, scope)
+
 	varName := codegen.Goify(service.Name, false)
 	data := &Data{
 		Name:               service.Name,
 		Description:        desc,
+		Deprecation:        expr.DeprecationOf(service.Meta),
 		VarName:            varName,
 		PathName:           codegen.SnakeCase(varName),
 		StructName:         codegen.Goify(service.Name, true),
@@ -754,6 +957,9 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		ViewsPkg:           viewspkg,
 		Methods:            methods,
 		Schemes:            schemes,
+		ServerInterceptors: serverInterceptors,
+		ClientInterceptors: clientInterceptors,
+		Health:             buildHealthData(service),
 		Scope:              scope,
 		ViewScope:          viewScope,
 		errorTypes:         errTypes,
@@ -763,6 +969,7 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		viewedUnionMethods: viewedUnionMeths,
 		viewedResultTypes:  viewedRTs,
 		unionValueMethods:  unionMethods,
+		redactMethods:      redactMethods,
 	}
 	d[service.Name] = data
 
@@ -798,6 +1005,7 @@ func collectTypes(at *expr.AttributeExpr, scope *codegen.NameScope, seen map[str
 			Name:        dt.Name(),
 			VarName:     scope.GoTypeName(at),
 			Description: dt.Attribute().Description,
+			Deprecation: expr.DeprecationOf(dt.Attribute().Meta),
 			Def:         scope.GoTypeDef(dt.Attribute(), false, true),
 			Ref:         scope.GoTypeRef(at),
 			Loc:         codegen.UserTypeLocation(dt),
@@ -861,6 +1069,76 @@ func collectUnionMethods(att *expr.AttributeExpr, scope *codegen.NameScope, loc
 	return
 }
 
+// collectRedactMethods traverses the attribute to gather the user types that
+// have sensitive attributes.
+func collectRedactMethods(att *expr.AttributeExpr, scope *codegen.NameScope, seen map[string]struct{}) (data []*RedactMethodsData) {
+	if att == nil || att.Type == expr.Empty {
+		return
+	}
+	collect := func(at *expr.AttributeExpr) []*RedactMethodsData {
+		return collectRedactMethods(at, scope, seen)
+	}
+	switch dt := att.Type.(type) {
+	case expr.UserType:
+		if _, ok := seen[dt.ID()]; ok {
+			return nil
+		}
+		seen[dt.ID()] = struct{}{}
+		if obj := expr.AsObject(dt); obj != nil && !expr.IsUnion(dt) {
+			var (
+				fields    []*RedactFieldData
+				sensitive bool
+				names     = make(map[string]struct{})
+				vars      = codegen.NewNameScope()
+			)
+			vars.Unique("t") // method receiver
+			for _, nat := range *obj {
+				s := nat.Attribute.IsSensitive()
+				sensitive = sensitive || s
+				f := &RedactFieldData{
+					Name:      nat.Name,
+					FieldName: codegen.GoifyAtt(nat.Attribute, nat.Name, true),
+					Sensitive: s,
+					Pointer:   !s && dt.Attribute().IsPrimitivePointer(nat.Name, true),
+				}
+				if f.Pointer {
+					f.VarName = vars.Unique(codegen.Goify(nat.Name, false))
+				}
+				names[f.FieldName] = struct{}{}
+				fields = append(fields, f)
+			}
+			// A struct cannot have a field and a method with the same
+			// name, skip the methods that would clash with a field.
+			_, hasString := names["String"]
+			_, hasLogValue := names["LogValue"]
+			if sensitive && (!hasString || !hasLogValue) {
+				data = append(data, &RedactMethodsData{
+					TypeName: scope.GoTypeName(&expr.AttributeExpr{Type: dt}),
+					Fields:   fields,
+					String:   !hasString,
+					LogValue: !hasLogValue,
+					Loc:      codegen.UserTypeLocation(dt),
+				})
+			}
+		}
+		data = append(data, collect(dt.Attribute())...)
+	case *expr.Object:
+		for _, nat := range *dt {
+			data = append(data, collect(nat.Attribute)...)
+		}
+	case *expr.Array:
+		data = append(data, collect(dt.ElemType)...)
+	case *expr.Map:
+		data = append(data, collect(dt.KeyType)...)
+		data = append(data, collect(dt.ElemType)...)
+	case *expr.Union:
+		for _, nat := range dt.Values {
+			data = append(data, collect(nat.Attribute)...)
+		}
+	}
+	return
+}
+
 // buildErrorInitData creates the data needed to generate code around endpoint error return values.
 func buildErrorInitData(er *expr.ErrorExpr, scope *codegen.NameScope) *ErrorInitData {
 	_, temporary := er.AttributeExpr.Meta["goa:error:temporary"]
@@ -963,6 +1241,7 @@ func buildMethodData(m *expr.MethodExpr, scope *codegen.NameScope) *MethodData {
 		Name:                         m.Name,
 		VarName:                      vname,
 		Description:                  desc,
+		Deprecation:                  expr.DeprecationOf(m.Meta),
 		Payload:                      payloadName,
 		PayloadLoc:                   payloadLoc,
 		PayloadDef:                   payloadDef,
@@ -988,10 +1267,170 @@ func buildMethodData(m *expr.MethodExpr, scope *codegen.NameScope) *MethodData {
 	}
 	if m.IsStreaming() {
 		initStreamData(data, m, vname, rname, resultRef, scope)
+	} else if m.Retry != nil && m.Retry.MaxAttempts > 1 {
+		data.Retry = buildRetryData(m.Retry, errors)
+	}
+	if m.RateLimit != nil {
+		data.RateLimit = buildRateLimitData(m, schemes)
+	}
+	if m.Pagination != nil {
+		data.Pagination = buildPaginationData(m, vname, payloadLoc, scope)
 	}
 	return data
 }
 
+// buildPaginationData builds the data needed to generate the client iterator
+// of the given paginated method.
+func buildPaginationData(m *expr.MethodExpr, vname string, payloadLoc *codegen.Location, scope *codegen.NameScope) *PaginationData {
+	p := m.Pagination
+	data := &PaginationData{
+		IteratorName: scope.Unique(vname + "Iterator"),
+		PayloadName:  scope.GoFullTypeName(m.Payload, payloadLoc.PackageName()),
+		Cursor:       p.IsCursor(),
+		ItemsField:   codegen.Goify(p.Items, true),
+	}
+	if p.IsCursor() {
+		data.CursorField = codegen.Goify(p.Cursor, true)
+		data.CursorPointer = m.Payload.IsPrimitivePointer(p.Cursor, true)
+		data.NextCursorField = codegen.Goify(p.NextCursor, true)
+		data.NextCursorPointer = m.Result.IsPrimitivePointer(p.NextCursor, true)
+	} else {
+		data.OffsetField = codegen.Goify(p.Offset, true)
+		data.OffsetPointer = m.Payload.IsPrimitivePointer(p.Offset, true)
+	}
+	if p.Limit != "" {
+		data.LimitField = codegen.Goify(p.Limit, true)
+		data.LimitPointer = m.Payload.IsPrimitivePointer(p.Limit, true)
+	}
+	if arr := expr.AsArray(m.Result.Find(p.Items).Type); arr != nil {
+		data.ItemRef = scope.GoFullTypeRef(arr.ElemType, codegen.UserTypeLocation(arr.ElemType.Type).PackageName())
+	}
+	return data
+}
+
+// buildHealthData builds the data needed to generate the health checks of
+// the given service. It returns nil if the service does not define health
+// checks.
+func buildHealthData(svc *expr.ServiceExpr) *HealthData {
+	h := svc.Health
+	if h == nil {
+		return nil
+	}
+	checks := make([]*HealthCheckData, len(h.Checks))
+	for i, c := range h.Checks {
+		checks[i] = &HealthCheckData{
+			Name:        c.Name,
+			Description: c.Description,
+			FuncName:    "Check" + codegen.Goify(c.Name, true),
+		}
+	}
+	return &HealthData{
+		LivenessPath:  h.LivenessPath,
+		ReadinessPath: h.ReadinessPath,
+		Checks:        checks,
+		Shared:        h.Shared(),
+	}
+}
+
+// buildRateLimitData builds the data needed to generate the endpoint rate
+// limiter of the given method. schemes lists the security schemes of the
+// method, the credential of the first one is used when the limits are keyed
+// by principal.
+func buildRateLimitData(m *expr.MethodExpr, schemes SchemesData) *RateLimitData {
+	r := m.RateLimit
+	data := &RateLimitData{
+		Name:        m.Service.Name + "." + m.Name,
+		Requests:    r.Requests,
+		Burst:       r.Burst,
+		MaxInFlight: r.MaxInFlight,
+	}
+	if r.Requests > 0 {
+		data.Interval = DurationCode(r.Interval)
+	}
+	switch {
+	case r.Key != "":
+		att := m.Payload.Find(r.Key)
+		data.KeyField = codegen.GoifyAtt(att, r.Key, true)
+		data.KeyPointer = m.Payload.IsPrimitivePointer(r.Key, true)
+		data.KeyString = att.Type.Kind() == expr.StringKind
+	case r.Principal:
+		for _, s := range schemes {
+			if s.UsernameField != "" {
+				data.KeyField, data.KeyPointer = s.UsernameField, s.UsernamePointer
+			} else if s.CredField != "" {
+				data.KeyField, data.KeyPointer = s.CredField, s.CredPointer
+			} else {
+				continue
+			}
+			data.KeyString = true
+			break
+		}
+	}
+	return data
+}
+
+// buildRetryData builds the data needed to generate the client retry policy
+// from the given retry expression and method errors.
+func buildRetryData(r *expr.RetryExpr, errors []*ErrorInitData) *RetryData {
+	var names []string
+	seen := make(map[string]struct{})
+	add := func(name string) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	for _, name := range r.Errors {
+		add(name)
+	}
+	for _, e := range errors {
+		if e.Temporary || e.Timeout {
+			add(e.ErrName)
+		}
+	}
+	var hedge string
+	if r.HedgeDelay > 0 {
+		hedge = DurationCode(r.HedgeDelay)
+	}
+	return &RetryData{
+		MaxAttempts:    r.MaxAttempts,
+		InitialBackoff: DurationCode(r.InitialBackoff),
+		MaxBackoff:     DurationCode(r.MaxBackoff),
+		Jitter:         r.Jitter,
+		HedgeDelay:     hedge,
+		Errors:         names,
+		StatusCodes:    r.StatusCodes,
+		IdempotentOnly: r.IdempotentOnly,
+	}
+}
+
+// DurationCode returns the Go code for the given duration, e.g.
+// "100 * time.Millisecond".
+func DurationCode(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	if d == 0 {
+		return "0"
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			if d == u.unit {
+				return u.name
+			}
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("%d", int64(d))
+}
+
 // initStreamData initializes the streaming payload data structures and methods.
 func initStreamData(data *MethodData, m *expr.MethodExpr, vname, rname, resultRef string, scope *codegen.NameScope) {
 	var (
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go/format"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"goa.design/goa/v3/codegen"
@@ -53,6 +54,9 @@ func TestService(t *testing.T) {
 		{"service-bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadMethodDSL, testdata.BidirectionalStreamingNoPayloadMethod},
 		{"service-bidirectional-streaming-result-with-views", testdata.BidirectionalStreamingResultWithViewsMethodDSL, testdata.BidirectionalStreamingResultWithViewsMethod},
 		{"service-bidirectional-streaming-result-with-explicit-view", testdata.BidirectionalStreamingResultWithExplicitViewMethodDSL, testdata.BidirectionalStreamingResultWithExplicitViewMethod},
+		{"service-health", testdata.HealthMethodDSL, testdata.HealthMethod},
+		{"service-sensitive", testdata.SensitiveMethodDSL, testdata.SensitiveMethod},
+		{"service-deprecated", testdata.DeprecatedMethodDSL, testdata.DeprecatedMethod},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -69,6 +73,22 @@ func TestService(t *testing.T) {
 	}
 }
 
+func TestRedactLogValue(t *testing.T) {
+	codegen.RunDSL(t, testdata.SensitiveMethodDSL)
+	files := Files("goa.design/goa/example", expr.Root.Services[0], make(map[string][]string))
+	if len(files) != 2 {
+		t.Fatalf("got %d files, expected 2", len(files))
+	}
+	validateFile(t, files[1], filepath.Join("gen", "sensitive_method", "service_slog.go"), testdata.SensitiveMethodLogValue)
+	var buf bytes.Buffer
+	if err := files[1].SectionTemplates[0].Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "//go:build go1.21\n\npackage sensitivemethod\n") {
+		t.Errorf("got header\n%s\nexpected go1.21 build constraint", buf.String())
+	}
+}
+
 func TestStructPkgPath(t *testing.T) {
 	fooPath := filepath.Join("gen", "foo", "foo.go")
 	recursiveFooPath := filepath.Join("gen", "foo", "recursive_foo.go")
@@ -283,3 +283,196 @@ func (c *Client) BidirectionalStreamingNoPayloadMethod(ctx context.Context) (res
 	return ires.(BidirectionalStreamingNoPayloadMethodClientStream), nil
 }
 `
+
+const InterceptorsMethodClient = `// Client is the "InterceptorsEndpoint" service client.
+type Client struct {
+	GetEndpoint  goa.Endpoint
+	ListEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "InterceptorsEndpoint" service client given the
+// endpoints.
+func NewClient(get, list goa.Endpoint, ci ClientInterceptors) *Client {
+	return &Client{
+		GetEndpoint:  WrapGetClientEndpoint(get, ci),
+		ListEndpoint: WrapListClientEndpoint(list, ci),
+	}
+}
+
+// Get calls the "Get" endpoint of the "InterceptorsEndpoint" service.
+func (c *Client) Get(ctx context.Context, p *GetPayload) (res *Item, err error) {
+	var ires any
+	ires, err = c.GetEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*Item), nil
+}
+
+// List calls the "List" endpoint of the "InterceptorsEndpoint" service.
+func (c *Client) List(ctx context.Context) (res []*Item, err error) {
+	var ires any
+	ires, err = c.ListEndpoint(ctx, nil)
+	if err != nil {
+		return
+	}
+	return ires.([]*Item), nil
+}
+`
+
+const PaginatedMethodClient = `// Client is the "PaginatedEndpoint" service client.
+type Client struct {
+	ListEndpoint goa.Endpoint
+	PageEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "PaginatedEndpoint" service client given the
+// endpoints.
+func NewClient(list, page goa.Endpoint) *Client {
+	return &Client{
+		ListEndpoint: list,
+		PageEndpoint: page,
+	}
+}
+
+// List calls the "List" endpoint of the "PaginatedEndpoint" service.
+func (c *Client) List(ctx context.Context, p *ListPayload) (res *ListResult, err error) {
+	var ires any
+	ires, err = c.ListEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*ListResult), nil
+}
+
+// Page calls the "Page" endpoint of the "PaginatedEndpoint" service.
+func (c *Client) Page(ctx context.Context, p *PagePayload) (res *PageResult, err error) {
+	var ires any
+	ires, err = c.PageEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*PageResult), nil
+}
+
+// ListIterator iterates over the items of the pages returned by the "List"
+// method of the "PaginatedEndpoint" service.
+type ListIterator struct {
+	client  *Client
+	payload *ListPayload
+	page    []*Item
+	index   int
+	done    bool
+	err     error
+}
+
+// ListIterator returns an iterator over the items of the pages returned by the
+// "List" endpoint starting with the page requested by p. The iterator fetches
+// the next pages as needed.
+func (c *Client) ListIterator(p *ListPayload) *ListIterator {
+	if p == nil {
+		p = &ListPayload{}
+	}
+	return &ListIterator{client: c, payload: p, index: -1}
+}
+
+// Next advances the iterator to the next item, it fetches the next page if
+// needed. Next returns false once all the items have been visited or if an
+// error occurs.
+func (it *ListIterator) Next(ctx context.Context) bool {
+	for it.index+1 >= len(it.page) {
+		if it.done || it.err != nil {
+			return false
+		}
+		res, err := it.client.List(ctx, it.payload)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page, it.index = res.Items, -1
+		if res.NextCursor == nil || *res.NextCursor == "" {
+			it.done = true
+			continue
+		}
+		cursor := *res.NextCursor
+		next := *it.payload
+		next.Cursor = &cursor
+		it.payload = &next
+	}
+	it.index++
+	return true
+}
+
+// Value returns the current item.
+func (it *ListIterator) Value() *Item {
+	return it.page[it.index]
+}
+
+// Err returns the error that caused Next to return false if any.
+func (it *ListIterator) Err() error {
+	return it.err
+}
+
+// PageIterator iterates over the items of the pages returned by the "Page"
+// method of the "PaginatedEndpoint" service.
+type PageIterator struct {
+	client  *Client
+	payload *PagePayload
+	page    []string
+	index   int
+	done    bool
+	err     error
+}
+
+// PageIterator returns an iterator over the items of the pages returned by the
+// "Page" endpoint starting with the page requested by p. The iterator fetches
+// the next pages as needed.
+func (c *Client) PageIterator(p *PagePayload) *PageIterator {
+	if p == nil {
+		p = &PagePayload{}
+	}
+	return &PageIterator{client: c, payload: p, index: -1}
+}
+
+// Next advances the iterator to the next item, it fetches the next page if
+// needed. Next returns false once all the items have been visited or if an
+// error occurs.
+func (it *PageIterator) Next(ctx context.Context) bool {
+	for it.index+1 >= len(it.page) {
+		if it.done || it.err != nil {
+			return false
+		}
+		res, err := it.client.Page(ctx, it.payload)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page, it.index = res.Entries, -1
+		if len(it.page) == 0 {
+			it.done = true
+			continue
+		}
+		if len(it.page) < it.payload.Size {
+			it.done = true
+			continue
+		}
+		offset := len(it.page)
+		offset += it.payload.Start
+		next := *it.payload
+		next.Start = offset
+		it.payload = &next
+	}
+	it.index++
+	return true
+}
+
+// Value returns the current item.
+func (it *PageIterator) Value() string {
+	return it.page[it.index]
+}
+
+// Err returns the error that caused Next to return false if any.
+func (it *PageIterator) Err() error {
+	return it.err
+}
+`
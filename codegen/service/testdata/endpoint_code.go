@@ -513,3 +513,259 @@ func NewBidirectionalStreamingNoPayloadMethodEndpoint(s Service) goa.Endpoint {
 	}
 }
 `
+
+const RateLimitEndpoint = `// Endpoints wraps the "RateLimitEndpoint" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+	B goa.Endpoint
+	C goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "RateLimitEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		A: NewAEndpoint(s),
+		B: NewBEndpoint(s),
+		C: NewCEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "RateLimitEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+	e.B = m(e.B)
+	e.C = m(e.C)
+}
+
+// UseLimiter applies the rate and concurrency limits defined in the design to
+// the "RateLimitEndpoint" service endpoints using the given store.
+func (e *Endpoints) UseLimiter(store goa.LimiterStore) {
+	e.A = NewARateLimiter(store)(e.A)
+	e.B = NewBRateLimiter(store)(e.B)
+	e.C = NewCRateLimiter(store)(e.C)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "RateLimitEndpoint".
+func NewAEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*APayload)
+		return nil, s.A(ctx, p)
+	}
+}
+
+// NewBEndpoint returns an endpoint function that calls the method "B" of
+// service "RateLimitEndpoint".
+func NewBEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*BPayload)
+		return nil, s.B(ctx, p)
+	}
+}
+
+// NewCEndpoint returns an endpoint function that calls the method "C" of
+// service "RateLimitEndpoint".
+func NewCEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*CPayload)
+		return nil, s.C(ctx, p)
+	}
+}
+
+// NewARateLimiter returns an endpoint middleware that enforces the rate and
+// concurrency limits of the method "A" of service "RateLimitEndpoint" using
+// the given store.
+func NewARateLimiter(store goa.LimiterStore) func(goa.Endpoint) goa.Endpoint {
+	limit := &goa.RateLimit{
+		Name:     "RateLimitEndpoint.A",
+		Requests: 100,
+		Interval: time.Minute,
+		Burst:    20,
+	}
+	key := func(ctx context.Context, req any) string {
+		p := req.(*APayload)
+		if p.Tenant == nil {
+			return ""
+		}
+		return *p.Tenant
+	}
+	return goa.RateLimiter(store, limit, key)
+}
+
+// NewBRateLimiter returns an endpoint middleware that enforces the rate and
+// concurrency limits of the method "B" of service "RateLimitEndpoint" using
+// the given store.
+func NewBRateLimiter(store goa.LimiterStore) func(goa.Endpoint) goa.Endpoint {
+	limit := &goa.RateLimit{
+		Name:        "RateLimitEndpoint.B",
+		MaxInFlight: 5,
+	}
+	key := func(ctx context.Context, req any) string {
+		p := req.(*BPayload)
+		return fmt.Sprint(p.TenantID)
+	}
+	return goa.RateLimiter(store, limit, key)
+}
+
+// NewCRateLimiter returns an endpoint middleware that enforces the rate and
+// concurrency limits of the method "C" of service "RateLimitEndpoint" using
+// the given store.
+func NewCRateLimiter(store goa.LimiterStore) func(goa.Endpoint) goa.Endpoint {
+	limit := &goa.RateLimit{
+		Name:        "RateLimitEndpoint.C",
+		Requests:    10,
+		Interval:    time.Second,
+		MaxInFlight: 2,
+	}
+	return goa.RateLimiter(store, limit, nil)
+}
+`
+
+const RateLimitPrincipalEndpoint = `// Endpoints wraps the "RateLimitPrincipalEndpoint" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "RateLimitPrincipalEndpoint" service
+// with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	return &Endpoints{
+		A: NewAEndpoint(s, a.APIKeyAuth),
+	}
+}
+
+// Use applies the given middleware to all the "RateLimitPrincipalEndpoint"
+// service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
+// UseLimiter applies the rate and concurrency limits defined in the design to
+// the "RateLimitPrincipalEndpoint" service endpoints using the given store.
+func (e *Endpoints) UseLimiter(store goa.LimiterStore) {
+	e.A = NewARateLimiter(store)(e.A)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "RateLimitPrincipalEndpoint".
+func NewAEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*APayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "api_key",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		var key string
+		if p.Key != nil {
+			key = *p.Key
+		}
+		ctx, err = authAPIKeyFn(ctx, key, &sc)
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.A(ctx, p)
+	}
+}
+
+// NewARateLimiter returns an endpoint middleware that enforces the rate and
+// concurrency limits of the method "A" of service "RateLimitPrincipalEndpoint"
+// using the given store.
+func NewARateLimiter(store goa.LimiterStore) func(goa.Endpoint) goa.Endpoint {
+	limit := &goa.RateLimit{
+		Name:     "RateLimitPrincipalEndpoint.A",
+		Requests: 5,
+		Interval: time.Second,
+	}
+	key := func(ctx context.Context, req any) string {
+		p := req.(*APayload)
+		if p.Key == nil {
+			return ""
+		}
+		return *p.Key
+	}
+	return goa.RateLimiter(store, limit, key)
+}
+`
+
+const InterceptorsEndpoint = `// Endpoints wraps the "InterceptorsEndpoint" service endpoints.
+type Endpoints struct {
+	Get  goa.Endpoint
+	List goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "InterceptorsEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service, si ServerInterceptors) *Endpoints {
+	return &Endpoints{
+		Get:  WrapGetEndpoint(NewGetEndpoint(s), si),
+		List: WrapListEndpoint(NewListEndpoint(s), si),
+	}
+}
+
+// Use applies the given middleware to all the "InterceptorsEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Get = m(e.Get)
+	e.List = m(e.List)
+}
+
+// NewGetEndpoint returns an endpoint function that calls the method "Get" of
+// service "InterceptorsEndpoint".
+func NewGetEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*GetPayload)
+		return s.Get(ctx, p)
+	}
+}
+
+// NewListEndpoint returns an endpoint function that calls the method "List" of
+// service "InterceptorsEndpoint".
+func NewListEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		return s.List(ctx)
+	}
+}
+`
+
+const HealthEndpoint = `// Endpoints wraps the "HealthEndpoint" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+	// Health lists the dependency checks run by the readiness probes.
+	Health []*goa.HealthCheck
+}
+
+// NewEndpoints wraps the methods of the "HealthEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to HealthChecker interface
+	hc := s.(HealthChecker)
+	return &Endpoints{
+		A: NewAEndpoint(s),
+		Health: []*goa.HealthCheck{
+			{Name: "database", Check: hc.CheckDatabase},
+			{Name: "cache", Check: hc.CheckCache},
+		},
+	}
+}
+
+// Use applies the given middleware to all the "HealthEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "HealthEndpoint".
+func NewAEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(string)
+		return nil, s.A(ctx, p)
+	}
+}
+`
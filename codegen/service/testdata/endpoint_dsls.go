@@ -181,3 +181,133 @@ var BidirectionalStreamingEndpointDSL = func() {
 		})
 	})
 }
+
+var RateLimitEndpointDSL = func() {
+	Service("RateLimitEndpoint", func() {
+		RateLimit(100, "1m", func() {
+			Burst(20)
+			RateLimitKey("tenant")
+		})
+		Method("A", func() {
+			Payload(func() {
+				Attribute("tenant", String)
+			})
+		})
+		Method("B", func() {
+			Payload(func() {
+				Attribute("tenant", Int, func() {
+					Meta("struct:field:name", "TenantID")
+				})
+				Required("tenant")
+			})
+			ConcurrencyLimit(5, func() {
+				RateLimitKey("tenant")
+			})
+		})
+		Method("C", func() {
+			Payload(func() {
+				Attribute("tenant", String)
+			})
+			RateLimit(10, "1s", func() {
+				ConcurrencyLimit(2)
+			})
+		})
+	})
+}
+
+var RateLimitPrincipalEndpointDSL = func() {
+	var APIKeyAuth = APIKeySecurity("api_key")
+	Service("RateLimitPrincipalEndpoint", func() {
+		Method("A", func() {
+			Security(APIKeyAuth)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+			RateLimit(5, "1s", func() {
+				RateLimitPrincipal()
+			})
+		})
+	})
+}
+
+var InterceptorsEndpointDSL = func() {
+	var (
+		Audit = Interceptor("audit", func() {
+			Description("Audit logs the requests.")
+		})
+		Cache = Interceptor("cache", func() {
+			ReadPayload(func() {
+				Attribute("id")
+			})
+			WriteResult(func() {
+				Attribute("cached_at")
+			})
+		})
+	)
+	var Item = Type("Item", func() {
+		Attribute("name", String)
+		Attribute("cached_at", String, func() {
+			Meta("struct:field:name", "CacheTime")
+		})
+		Required("name")
+	})
+	Service("InterceptorsEndpoint", func() {
+		ServerInterceptor(Audit)
+		ClientInterceptor(Audit)
+		Method("Get", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Required("id")
+			})
+			Result(Item)
+			ServerInterceptor(Cache)
+		})
+		Method("List", func() {
+			Result(ArrayOf(Item))
+		})
+	})
+}
+
+var PaginatedEndpointDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("name", String)
+	})
+	Service("PaginatedEndpoint", func() {
+		Method("List", func() {
+			Payload(func() {
+				Attribute("filter", String)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(Item))
+			})
+			Paginated()
+		})
+		Method("Page", func() {
+			Payload(func() {
+				Attribute("start", Int)
+				Attribute("size", Int)
+				Required("start", "size")
+			})
+			Result(func() {
+				Attribute("entries", ArrayOf(String))
+			})
+			Paginated(func() {
+				Offset("start")
+				PageSize("size")
+				Items("entries")
+			})
+		})
+	})
+}
+
+var HealthEndpointDSL = func() {
+	Service("HealthEndpoint", func() {
+		Health(func() {
+			Check("database", "Primary database")
+			Check("cache")
+		})
+		Method("A", func() {
+			Payload(String)
+		})
+	})
+}
@@ -0,0 +1,217 @@
+package testdata
+
+const InterceptorsCode = `// ServerInterceptors defines the interceptors that wrap the
+// "InterceptorsEndpoint" service endpoints. Each interceptor must call next to
+// invoke the next interceptor or the endpoint and return the result.
+type ServerInterceptors interface {
+	// Audit logs the requests.
+	Audit(ctx context.Context, info *AuditInfo, next goa.Endpoint) (any, error)
+	// Cache implements the "cache" interceptor.
+	Cache(ctx context.Context, info *CacheInfo, next goa.Endpoint) (any, error)
+}
+
+// ClientInterceptors defines the interceptors that wrap the
+// "InterceptorsEndpoint" service client endpoints. Each interceptor must call
+// next to invoke the next interceptor or the endpoint and return the result.
+type ClientInterceptors interface {
+	// Audit logs the requests.
+	Audit(ctx context.Context, info *AuditInfo, next goa.Endpoint) (any, error)
+}
+
+// AuditInfo gives the "audit" interceptor access to the current request.
+type AuditInfo struct {
+	service    string
+	method     string
+	rawPayload any
+}
+
+// CacheInfo gives the "cache" interceptor access to the current request.
+type CacheInfo struct {
+	service    string
+	method     string
+	rawPayload any
+}
+
+// CachePayload gives the "cache" interceptor access to the payload attributes
+// listed in the design.
+type CachePayload interface {
+	ID() string
+}
+
+// CacheResult gives the "cache" interceptor access to the result attributes
+// listed in the design.
+type CacheResult interface {
+	SetCacheTime(string)
+}
+
+type cacheGetPayload struct {
+	payload *GetPayload
+}
+
+type cacheGetResult struct {
+	result *Item
+}
+
+// Service returns the name of the service.
+func (info *AuditInfo) Service() string {
+	return info.service
+}
+
+// Method returns the name of the method.
+func (info *AuditInfo) Method() string {
+	return info.method
+}
+
+// RawPayload returns the request given to the endpoint.
+func (info *AuditInfo) RawPayload() any {
+	return info.rawPayload
+}
+
+// Service returns the name of the service.
+func (info *CacheInfo) Service() string {
+	return info.service
+}
+
+// Method returns the name of the method.
+func (info *CacheInfo) Method() string {
+	return info.method
+}
+
+// RawPayload returns the request given to the endpoint.
+func (info *CacheInfo) RawPayload() any {
+	return info.rawPayload
+}
+
+// Payload returns the accessor of the request payload attributes.
+func (info *CacheInfo) Payload() CachePayload {
+	switch info.method {
+	case "Get":
+		p, ok := info.rawPayload.(*GetPayload)
+		if !ok {
+			return nil
+		}
+		return &cacheGetPayload{payload: p}
+	default:
+		return nil
+	}
+}
+
+// Result returns the accessor of the attributes of the result returned by
+// next, nil if the result is nil.
+func (info *CacheInfo) Result(res any) CacheResult {
+	switch info.method {
+	case "Get":
+		r, ok := res.(*Item)
+		if !ok || r == nil {
+			return nil
+		}
+		return &cacheGetResult{result: r}
+	default:
+		return nil
+	}
+}
+
+// WrapGetEndpoint wraps the "Get" endpoint of the "InterceptorsEndpoint"
+// service with the server interceptors defined in the design.
+func WrapGetEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	endpoint = wrapGetCache(endpoint, i)
+	endpoint = wrapGetAudit(endpoint, i)
+	return endpoint
+}
+
+// wrapGetAudit applies the "audit" server interceptor to the "Get" endpoint.
+func wrapGetAudit(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		info := &AuditInfo{
+			service:    "InterceptorsEndpoint",
+			method:     "Get",
+			rawPayload: req,
+		}
+		return i.Audit(ctx, info, endpoint)
+	}
+}
+
+// wrapGetCache applies the "cache" server interceptor to the "Get" endpoint.
+func wrapGetCache(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		info := &CacheInfo{
+			service:    "InterceptorsEndpoint",
+			method:     "Get",
+			rawPayload: req,
+		}
+		return i.Cache(ctx, info, endpoint)
+	}
+}
+
+// WrapGetClientEndpoint wraps the "Get" client endpoint of the
+// "InterceptorsEndpoint" service with the client interceptors defined in the
+// design.
+func WrapGetClientEndpoint(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	endpoint = wrapClientGetAudit(endpoint, i)
+	return endpoint
+}
+
+// wrapClientGetAudit applies the "audit" client interceptor to the "Get"
+// client endpoint.
+func wrapClientGetAudit(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		info := &AuditInfo{
+			service:    "InterceptorsEndpoint",
+			method:     "Get",
+			rawPayload: req,
+		}
+		return i.Audit(ctx, info, endpoint)
+	}
+}
+
+// WrapListEndpoint wraps the "List" endpoint of the "InterceptorsEndpoint"
+// service with the server interceptors defined in the design.
+func WrapListEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	endpoint = wrapListAudit(endpoint, i)
+	return endpoint
+}
+
+// wrapListAudit applies the "audit" server interceptor to the "List" endpoint.
+func wrapListAudit(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		info := &AuditInfo{
+			service:    "InterceptorsEndpoint",
+			method:     "List",
+			rawPayload: req,
+		}
+		return i.Audit(ctx, info, endpoint)
+	}
+}
+
+// WrapListClientEndpoint wraps the "List" client endpoint of the
+// "InterceptorsEndpoint" service with the client interceptors defined in the
+// design.
+func WrapListClientEndpoint(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	endpoint = wrapClientListAudit(endpoint, i)
+	return endpoint
+}
+
+// wrapClientListAudit applies the "audit" client interceptor to the "List"
+// client endpoint.
+func wrapClientListAudit(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		info := &AuditInfo{
+			service:    "InterceptorsEndpoint",
+			method:     "List",
+			rawPayload: req,
+		}
+		return i.Audit(ctx, info, endpoint)
+	}
+}
+
+// ID returns the value of the "id" payload attribute.
+func (p *cacheGetPayload) ID() string {
+	return p.payload.ID
+}
+
+// SetCacheTime sets the value of the "cached_at" result attribute.
+func (r *cacheGetResult) SetCacheTime(v string) {
+	r.result.CacheTime = &v
+}
+
+`
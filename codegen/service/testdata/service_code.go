@@ -2939,3 +2939,146 @@ type Foo struct {
 	IntField *int
 }
 `
+
+const HealthMethod = `
+// Service is the HealthMethod service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, string) (err error)
+}
+
+// HealthChecker defines the dependency checks to be implemented by the service.
+type HealthChecker interface {
+	// CheckDatabase returns an error if the "database" dependency is not
+	// available. Primary database
+	CheckDatabase(context.Context) error
+	// CheckCache returns an error if the "cache" dependency is not available.
+	CheckCache(context.Context) error
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "HealthMethod"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+`
+
+const SensitiveMethod = `
+// Service is the SensitiveMethod service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *Credentials) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "SensitiveMethod"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// Credentials is the payload type of the SensitiveMethod service A method.
+type Credentials struct {
+	Username string
+	Password string
+	Realm    *string
+	Token    *Token
+}
+
+type Token struct {
+	String *string
+	Secret *string
+}
+
+// String returns a string representation of Credentials where the sensitive
+// fields are redacted.
+func (t *Credentials) String() string {
+	if t == nil {
+		return "<nil>"
+	}
+	var realm any
+	if t.Realm != nil {
+		realm = *t.Realm
+	}
+	return fmt.Sprintf("&{Username:%v Password:[REDACTED] Realm:%v Token:%v}", t.Username, realm, t.Token)
+}
+`
+
+const SensitiveMethodLogValue = `// LogValue implements slog.LogValuer and redacts the sensitive fields of
+// Credentials.
+func (t *Credentials) LogValue() slog.Value {
+	if t == nil {
+		return slog.AnyValue(nil)
+	}
+	var realm any
+	if t.Realm != nil {
+		realm = *t.Realm
+	}
+	return slog.GroupValue(
+		slog.Any("username", t.Username),
+		slog.String("password", "[REDACTED]"),
+		slog.Any("realm", realm),
+		slog.Any("token", t.Token),
+	)
+}
+
+// LogValue implements slog.LogValuer and redacts the sensitive fields of Token.
+func (t *Token) LogValue() slog.Value {
+	if t == nil {
+		return slog.AnyValue(nil)
+	}
+	var string_ any
+	if t.String != nil {
+		string_ = *t.String
+	}
+	return slog.GroupValue(
+		slog.Any("string", string_),
+		slog.String("secret", "[REDACTED]"),
+	)
+}
+`
+
+const DeprecatedMethod = `
+// Service is the DeprecatedMethod service interface.
+//
+// Deprecated: Use the Modern service instead.
+type Service interface {
+	// A creates a resource.
+	//
+	// Deprecated: Use B instead.
+	A(context.Context, *Request) (err error)
+	// B implements B.
+	B(context.Context, *Request) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "DeprecatedMethod"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [2]string{"A", "B"}
+
+// Deprecated: Use Modern instead.
+type Legacy struct {
+	ID *string
+}
+
+// Request is the payload type of the DeprecatedMethod service A method.
+type Request struct {
+	// Name of the resource.
+	Name *string
+	// Deprecated: Use name instead. Sunset date: 2030-01-02.
+	LegacyName *string
+	Legacy     *Legacy
+}
+`
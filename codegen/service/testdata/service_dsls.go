@@ -967,3 +967,63 @@ var PkgPathPayloadAttributeDSL = func() {
 		})
 	})
 }
+
+var HealthMethodDSL = func() {
+	Service("HealthMethod", func() {
+		Health(func() {
+			Check("database", "Primary database")
+			Check("cache")
+		})
+		Method("A", func() {
+			Payload(String)
+		})
+	})
+}
+
+var SensitiveMethodDSL = func() {
+	var Token = Type("Token", func() {
+		Attribute("string", String)
+		Attribute("secret", String, func() {
+			Sensitive()
+		})
+	})
+	var Credentials = Type("Credentials", func() {
+		Attribute("username", String)
+		Attribute("password", String, func() {
+			Sensitive()
+		})
+		Attribute("realm", String)
+		Attribute("token", Token)
+		Required("username", "password")
+	})
+	Service("SensitiveMethod", func() {
+		Method("A", func() {
+			Payload(Credentials)
+		})
+	})
+}
+
+var DeprecatedMethodDSL = func() {
+	var Legacy = Type("Legacy", func() {
+		Deprecated("Use Modern instead.")
+		Attribute("id", String)
+	})
+	var Request = Type("Request", func() {
+		Attribute("name", String, "Name of the resource.")
+		Attribute("legacy_name", String, func() {
+			Deprecated("Use name instead.", "2030-01-02")
+		})
+		Attribute("legacy", Legacy)
+	})
+	Service("DeprecatedMethod", func() {
+		Deprecated("Use the Modern service instead.")
+		Method("A", func() {
+			Description("A creates a resource.")
+			Deprecated("Use B instead.")
+			Payload(Request)
+		})
+		Method("B", func() {
+			Payload(Request)
+		})
+	})
+}
@@ -235,6 +235,24 @@ var TestTypesDSL = func() {
 			Required("required_int", "required_string", "required_bytes", "required_any", "required_array", "required_map")
 		})
 
+		_ = Type("WellKnown", func() {
+			Attribute("date_time", String, func() {
+				Format(FormatDateTime)
+			})
+			Attribute("required_date_time", String, func() {
+				Format(FormatDateTime)
+			})
+			Attribute("duration", String, func() {
+				Format(FormatDuration)
+			})
+			Attribute("any", Any)
+			Attribute("array", ArrayOf(String, func() {
+				Format(FormatDateTime)
+			}))
+			Attribute("map", MapOf(String, Any))
+			Required("required_date_time")
+		})
+
 		StringAlias = Type("StringAlias", String)
 		BoolAlias   = Type("BoolAlias", Boolean, func() {
 			Default(true)
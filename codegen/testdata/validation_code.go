@@ -532,5 +532,18 @@ const (
 		}
 	}
 }
+`
+
+	SensitiveValidationCode = `func Validate() (err error) {
+	if utf8.RuneCountInString(target.Password) < 8 {
+		err = goa.MergeErrors(err, goa.RedactError(goa.InvalidLengthError("target.password", target.Password, utf8.RuneCountInString(target.Password), 8, true)))
+	}
+	if target.Pin != nil {
+		err = goa.MergeErrors(err, goa.RedactError(goa.ValidatePattern("target.pin", *target.Pin, "^[0-9]+$")))
+	}
+	if target.Email != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("target.email", *target.Email, goa.FormatEmail))
+	}
+}
 `
 )
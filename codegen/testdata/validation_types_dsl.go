@@ -153,5 +153,20 @@ var ValidationTypesDSL = func() {
 				Attribute("integer", IntegerT)
 			})
 		})
+
+		_ = Type("Sensitive", func() {
+			Attribute("password", String, func() {
+				MinLength(8)
+				Sensitive()
+			})
+			Attribute("pin", String, func() {
+				Pattern("^[0-9]+$")
+				Redact()
+			})
+			Attribute("email", String, func() {
+				Format(FormatEmail)
+			})
+			Required("password")
+		})
 	)
 }
@@ -85,6 +85,9 @@ type (
 		ParamTypeRef  string
 		ResultTypeRef string
 		Code          string
+		// ReturnsError is true if the function returns an error in
+		// addition to the result.
+		ReturnsError bool
 	}
 )
 
@@ -276,6 +276,7 @@ func validationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req, alia
 		"string":    kind == expr.StringKind,
 		"array":     expr.IsArray(att.Type),
 		"map":       expr.IsMap(att.Type),
+		"sensitive": att.IsSensitive(),
 	}
 	runTemplate := func(tmpl *template.Template, data any) string {
 		var buf bytes.Buffer
@@ -495,6 +496,8 @@ func constant(formatName string) string {
 		return "goa.FormatJSON"
 	case "rfc1123":
 		return "goa.FormatRFC1123"
+	case "duration":
+		return "goa.FormatDuration"
 	}
 	panic("unknown format") // bug
 }
@@ -523,7 +526,7 @@ const (
 	enumValTmpl = `{{ if .isPointer }}if {{ .target }} != nil {
 {{ end -}}
 if !({{ oneof .targetVal .values }}) {
-        err = goa.MergeErrors(err, goa.InvalidEnumValueError({{ printf "%q" .context }}, {{ .targetVal }}, {{ slice .values }}))
+        err = goa.MergeErrors(err, {{ if .sensitive }}goa.RedactError({{ end }}goa.InvalidEnumValueError({{ printf "%q" .context }}, {{ .targetVal }}, {{ slice .values }}){{ if .sensitive }}){{ end }})
 {{ if .isPointer -}}
 }
 {{ end -}}
@@ -531,14 +534,14 @@ if !({{ oneof .targetVal .values }}) {
 
 	patternValTmpl = `{{ if .isPointer }}if {{ .target }} != nil {
 {{ end -}}
-        err = goa.MergeErrors(err, goa.ValidatePattern({{ printf "%q" .context }}, {{ .targetVal }}, {{ printf "%q" .pattern }}))
+        err = goa.MergeErrors(err, {{ if .sensitive }}goa.RedactError({{ end }}goa.ValidatePattern({{ printf "%q" .context }}, {{ .targetVal }}, {{ printf "%q" .pattern }}){{ if .sensitive }}){{ end }})
 {{- if .isPointer }}
 }
 {{- end }}`
 
 	formatValTmpl = `{{ if .isPointer }}if {{ .target }} != nil {
 {{ end -}}
-        err = goa.MergeErrors(err, goa.ValidateFormat({{ printf "%q" .context }}, {{ .targetVal}}, {{ constant .format }}))
+        err = goa.MergeErrors(err, {{ if .sensitive }}goa.RedactError({{ end }}goa.ValidateFormat({{ printf "%q" .context }}, {{ .targetVal}}, {{ constant .format }}){{ if .sensitive }}){{ end }})
 {{- if .isPointer }}
 }
 {{- end }}`
@@ -546,7 +549,7 @@ if !({{ oneof .targetVal .values }}) {
 	exclMinMaxValTmpl = `{{ if .isPointer }}if {{ .target }} != nil {
 {{ end -}}
         if {{ .targetVal }} {{ if .isExclMin }}<={{ else }}>={{ end }} {{ if .isExclMin }}{{ .exclMin }}{{ else }}{{ .exclMax }}{{ end }} {
-        err = goa.MergeErrors(err, goa.InvalidRangeError({{ printf "%q" .context }}, {{ .targetVal }}, {{ if .isExclMin }}{{ .exclMin }}, true{{ else }}{{ .exclMax }}, false{{ end }}))
+        err = goa.MergeErrors(err, {{ if .sensitive }}goa.RedactError({{ end }}goa.InvalidRangeError({{ printf "%q" .context }}, {{ .targetVal }}, {{ if .isExclMin }}{{ .exclMin }}, true{{ else }}{{ .exclMax }}, false{{ end }}){{ if .sensitive }}){{ end }})
 {{ if .isPointer -}}
 }
 {{ end -}}
@@ -555,7 +558,7 @@ if !({{ oneof .targetVal .values }}) {
 	minMaxValTmpl = `{{ if .isPointer -}}if {{ .target }} != nil {
 {{ end -}}
         if {{ .targetVal }} {{ if .isMin }}<{{ else }}>{{ end }} {{ if .isMin }}{{ .min }}{{ else }}{{ .max }}{{ end }} {
-        err = goa.MergeErrors(err, goa.InvalidRangeError({{ printf "%q" .context }}, {{ .targetVal }}, {{ if .isMin }}{{ .min }}, true{{ else }}{{ .max }}, false{{ end }}))
+        err = goa.MergeErrors(err, {{ if .sensitive }}goa.RedactError({{ end }}goa.InvalidRangeError({{ printf "%q" .context }}, {{ .targetVal }}, {{ if .isMin }}{{ .min }}, true{{ else }}{{ .max }}, false{{ end }}){{ if .sensitive }}){{ end }})
 {{ if .isPointer -}}
 }
 {{ end -}}
@@ -566,7 +569,7 @@ if !({{ oneof .targetVal .values }}) {
 if {{ .target }} != nil {
 {{ end -}}
 if {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }} {{ if .isMinLength }}<{{ else }}>{{ end }} {{ if .isMinLength }}{{ .minLength }}{{ else }}{{ .maxLength }}{{ end }} {
-        err = goa.MergeErrors(err, goa.InvalidLengthError({{ printf "%q" .context }}, {{ $target }}, {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }}, {{ if .isMinLength }}{{ .minLength }}, true{{ else }}{{ .maxLength }}, false{{ end }}))
+        err = goa.MergeErrors(err, {{ if .sensitive }}goa.RedactError({{ end }}goa.InvalidLengthError({{ printf "%q" .context }}, {{ $target }}, {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }}, {{ if .isMinLength }}{{ .minLength }}, true{{ else }}{{ .maxLength }}, false{{ end }}){{ if .sensitive }}){{ end }})
 }{{- if and .isPointer .string }}
 }
 {{- end }}`
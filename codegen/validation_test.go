@@ -25,6 +25,7 @@ func TestRecursiveValidationCode(t *testing.T) {
 		rtcolT   = root.UserType("Collection")
 		colT     = root.UserType("TypeWithCollection")
 		deepT    = root.UserType("Deep")
+		sensT    = root.UserType("Sensitive")
 	)
 	cases := []struct {
 		Name       string
@@ -60,6 +61,7 @@ func TestRecursiveValidationCode(t *testing.T) {
 		{"collection-pointer", rtcolT, false, true, false, testdata.ResultCollectionPointerValidationCode},
 		{"type-with-collection-pointer", colT, false, true, false, testdata.TypeWithCollectionPointerValidationCode},
 		{"type-with-embedded-type", deepT, false, true, false, testdata.TypeWithEmbeddedTypeValidationCode},
+		{"sensitive", sensT, true, false, false, testdata.SensitiveValidationCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -0,0 +1,128 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Deprecated marks a service, method, type or attribute as deprecated. The
+// generated OpenAPI specifications flag the corresponding operations, schemas
+// and parameters as deprecated, the generated .proto files set the deprecated
+// option on the corresponding services, methods, messages and fields and the
+// generated Go code includes "Deprecated:" comments so that tools such as
+// staticcheck warn the callers. The generated HTTP handlers of deprecated
+// methods (or methods of deprecated services) set the "Deprecation" response
+// header (RFC 9745) and the "Sunset" response header (RFC 8594) if a sunset
+// date is given. Use DeprecatedSince to record when the element was deprecated.
+//
+// Deprecated must appear in a Service, Method, Type, ResultType or Attribute
+// expression.
+//
+// Deprecated accepts one or two arguments: the first argument explains why
+// the element is deprecated and what to use instead, the optional second
+// argument is the date after which the element is expected to become
+// unavailable using the YYYY-MM-DD or RFC 3339 format.
+//
+// Example:
+//
+//    var _ = Service("accounts", func() {
+//        Method("show", func() {
+//            Deprecated("Use the get method instead.", "2025-12-31")
+//            Payload(func() {
+//                Attribute("id", String)
+//                Attribute("legacy_id", Int, func() {
+//                    Deprecated("Use id instead.")
+//                })
+//            })
+//            Result(Account)
+//            HTTP(func() {
+//                GET("/{id}")
+//            })
+//        })
+//    })
+func Deprecated(reason string, sunset ...string) {
+	if len(sunset) > 1 {
+		eval.InvalidArgError("zero or one sunset date", len(sunset))
+		return
+	}
+	var date string
+	if len(sunset) == 1 {
+		t, err := expr.ParseSunset(sunset[0])
+		if err != nil {
+			eval.ReportError("invalid sunset date %q, date must use the YYYY-MM-DD or RFC 3339 format", sunset[0])
+			return
+		}
+		date = t.Format(time.RFC3339)
+	}
+	deprecate := func(meta expr.MetaExpr) expr.MetaExpr {
+		if meta == nil {
+			meta = make(expr.MetaExpr)
+		}
+		meta[expr.DeprecatedMetaKey] = []string{reason}
+		if date != "" {
+			meta[expr.SunsetMetaKey] = []string{date}
+		}
+		return meta
+	}
+	switch e := eval.Current().(type) {
+	case *expr.ServiceExpr:
+		e.Meta = deprecate(e.Meta)
+	case *expr.MethodExpr:
+		e.Meta = deprecate(e.Meta)
+	case *expr.AttributeExpr:
+		e.Meta = deprecate(e.Meta)
+	case expr.CompositeExpr:
+		att := e.Attribute()
+		att.Meta = deprecate(att.Meta)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// DeprecatedSince records the date at which the service or method was or will
+// be deprecated. The generated HTTP handlers set the "Deprecation" response
+// header to this date using the RFC 9745 format (e.g. "@1735689600"). Without
+// a date the header value is "true" as specified by the earlier drafts of RFC
+// 9745.
+//
+// DeprecatedSince must appear in a Service or Method expression and only has an
+// effect if the service or method is also marked with Deprecated.
+//
+// DeprecatedSince accepts one argument: the date using the YYYY-MM-DD or RFC
+// 3339 format.
+//
+// Example:
+//
+//    var _ = Service("accounts", func() {
+//        Method("show", func() {
+//            Deprecated("Use the get method instead.", "2025-12-31")
+//            DeprecatedSince("2025-01-01")
+//            HTTP(func() {
+//                GET("/{id}")
+//            })
+//        })
+//    })
+func DeprecatedSince(date string) {
+	t, err := expr.ParseSunset(date)
+	if err != nil {
+		eval.ReportError("invalid deprecation date %q, date must use the YYYY-MM-DD or RFC 3339 format", date)
+		return
+	}
+	since := func(meta expr.MetaExpr) expr.MetaExpr {
+		if meta == nil {
+			meta = make(expr.MetaExpr)
+		}
+		meta[expr.DeprecationDateMetaKey] = []string{t.Format(time.RFC3339)}
+		return meta
+	}
+	switch e := eval.Current().(type) {
+	case *expr.ServiceExpr:
+		e.Meta = since(e.Meta)
+	case *expr.MethodExpr:
+		e.Meta = since(e.Meta)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
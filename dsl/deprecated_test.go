@@ -0,0 +1,94 @@
+package dsl_test
+
+import (
+	"testing"
+	"time"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+func TestDeprecated(t *testing.T) {
+	cases := map[string]struct {
+		Expr     eval.Expression
+		Args     []string
+		MetaFunc func(e eval.Expression) expr.MetaExpr
+		Sunset   time.Time
+		Error    bool
+	}{
+		"service":       {&expr.ServiceExpr{Name: "svc"}, []string{"Use svc2."}, func(e eval.Expression) expr.MetaExpr { return e.(*expr.ServiceExpr).Meta }, time.Time{}, false},
+		"method":        {&expr.MethodExpr{Name: "m"}, []string{"Use m2.", "2030-01-02"}, methodMeta, time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		"attribute":     {&expr.AttributeExpr{}, []string{"Use id.", "2030-01-02T10:00:00+02:00"}, attributeMeta, time.Date(2030, 1, 2, 8, 0, 0, 0, time.UTC), false},
+		"userType":      {&expr.UserTypeExpr{AttributeExpr: &expr.AttributeExpr{}}, []string{""}, userTypeMeta, time.Time{}, false},
+		"invalid-date":  {&expr.MethodExpr{Name: "m"}, []string{"Use m2.", "01/02/2030"}, methodMeta, time.Time{}, true},
+		"too-many-args": {&expr.MethodExpr{Name: "m"}, []string{"Use m2.", "2030-01-02", "2031-01-02"}, methodMeta, time.Time{}, true},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			eval.Context = &eval.DSLContext{}
+			eval.Execute(func() {
+				Deprecated(tc.Args[0], tc.Args[1:]...)
+			}, tc.Expr)
+			if tc.Error {
+				if eval.Context.Errors == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if eval.Context.Errors != nil {
+				t.Fatalf("Deprecated failed unexpectedly with %s", eval.Context.Errors)
+			}
+			d := expr.DeprecationOf(tc.MetaFunc(tc.Expr))
+			if d == nil {
+				t.Fatal("got nil deprecation")
+			}
+			if d.Reason != tc.Args[0] {
+				t.Errorf("got reason %q, expected %q", d.Reason, tc.Args[0])
+			}
+			if !d.Sunset.Equal(tc.Sunset) {
+				t.Errorf("got sunset %s, expected %s", d.Sunset, tc.Sunset)
+			}
+		})
+	}
+}
+
+func TestDeprecatedSince(t *testing.T) {
+	cases := map[string]struct {
+		Expr     eval.Expression
+		Date     string
+		MetaFunc func(e eval.Expression) expr.MetaExpr
+		Expected time.Time
+		Error    bool
+	}{
+		"service":      {&expr.ServiceExpr{Name: "svc"}, "2025-01-01", func(e eval.Expression) expr.MetaExpr { return e.(*expr.ServiceExpr).Meta }, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		"method":       {&expr.MethodExpr{Name: "m"}, "2025-01-01T10:00:00+02:00", methodMeta, time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC), false},
+		"invalid-date": {&expr.MethodExpr{Name: "m"}, "01/01/2025", methodMeta, time.Time{}, true},
+		"attribute":    {&expr.AttributeExpr{}, "2025-01-01", attributeMeta, time.Time{}, true},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			eval.Context = &eval.DSLContext{}
+			eval.Execute(func() {
+				Deprecated("Deprecated.")
+				DeprecatedSince(tc.Date)
+			}, tc.Expr)
+			if tc.Error {
+				if eval.Context.Errors == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if eval.Context.Errors != nil {
+				t.Fatalf("DeprecatedSince failed unexpectedly with %s", eval.Context.Errors)
+			}
+			d := expr.DeprecationOf(tc.MetaFunc(tc.Expr))
+			if d == nil {
+				t.Fatal("got nil deprecation")
+			}
+			if !d.Date.Equal(tc.Expected) {
+				t.Errorf("got date %s, expected %s", d.Date, tc.Expected)
+			}
+		})
+	}
+}
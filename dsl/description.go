@@ -38,6 +38,8 @@ func Description(d string) {
 		e.Description = d
 	case *expr.ExampleExpr:
 		e.Description = d
+	case *expr.InterceptorExpr:
+		e.Description = d
 	case *expr.SchemeExpr:
 		e.Description = d
 	case *expr.HTTPResponseExpr:
@@ -0,0 +1,118 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Health defines the health checks of the API services. The generated code
+// defines a HealthChecker interface in the service package with one function
+// per dependency check that the service implementation must implement. The
+// generated HTTP servers serve a liveness probe that always succeeds and a
+// readiness probe that runs the checks and writes a JSON report, the responses
+// use status 503 (Service Unavailable) if a check fails. The generated gRPC
+// servers provide a function that registers the checks with the standard gRPC
+// health service (grpc.health.v1.Health). The probes are documented in the
+// generated OpenAPI specifications.
+//
+// Health must appear in an API or Service expression. Health checks defined
+// in the API apply to the services that do not define their own, their HTTP
+// probes are mounted once by the example HTTP server and run the checks of
+// all the inheriting services. The probes of services that define their own
+// health checks are mounted by the generated service servers, their paths
+// must be distinct from the paths used by the other services and by the API.
+// The probe paths are not prefixed with the API or service base paths. The
+// probe handlers are not wrapped by the middleware given to the Use method of
+// the generated servers.
+//
+// Health accepts an optional function that may use Check, Liveness and
+// Readiness. The probes are served under "/healthz" and "/readyz" by default.
+//
+// Example:
+//
+//    var _ = Service("calc", func() {
+//        Health(func() {
+//            Check("database", "Primary PostgreSQL database")
+//            Check("cache")
+//        })
+//    })
+//
+func Health(fns ...func()) {
+	if len(fns) > 1 {
+		eval.InvalidArgError("zero or one function", len(fns))
+		return
+	}
+	var h *expr.HealthExpr
+	switch e := eval.Current().(type) {
+	case *expr.APIExpr:
+		h = expr.NewHealthExpr(e)
+		e.Health = h
+	case *expr.ServiceExpr:
+		h = expr.NewHealthExpr(e)
+		e.Health = h
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(fns) == 1 {
+		eval.Execute(fns[0], h)
+	}
+}
+
+// Check defines a named dependency check run by the readiness probe. The
+// generated HealthChecker interface defines a function named after the check,
+// e.g. CheckDatabase for the check "database", that returns an error if the
+// dependency is not available.
+//
+// Check must appear in a Health expression.
+//
+// Check accepts one or two arguments. The first argument is the name of the
+// dependency, the second optional argument is its description.
+func Check(name string, description ...string) {
+	h, ok := eval.Current().(*expr.HealthExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(description) > 1 {
+		eval.InvalidArgError("zero or one description", len(description))
+		return
+	}
+	if h.Check(name) != nil {
+		eval.ReportError("check %q is already defined", name)
+		return
+	}
+	c := &expr.HealthCheckExpr{Name: name, Health: h}
+	if len(description) == 1 {
+		c.Description = description[0]
+	}
+	h.Checks = append(h.Checks, c)
+}
+
+// Liveness sets the HTTP path of the liveness probe, "/healthz" by default.
+//
+// Liveness must appear in a Health expression.
+//
+// Liveness accepts one argument: the probe path.
+func Liveness(path string) {
+	h, ok := eval.Current().(*expr.HealthExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	h.LivenessPath = path
+}
+
+// Readiness sets the HTTP path of the readiness probe, "/readyz" by default.
+//
+// Readiness must appear in a Health expression.
+//
+// Readiness accepts one argument: the probe path.
+func Readiness(path string) {
+	h, ok := eval.Current().(*expr.HealthExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	h.ReadinessPath = path
+}
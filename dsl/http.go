@@ -230,7 +230,8 @@ func Produces(args ...string) {
 // As a special case, if you want to generate a path with a trailing slash, you can use
 // GET("/./") to generate a path such as '/foo/'.
 //
-// Path must appear in a API HTTP expression or a Service HTTP expression.
+// Path must appear in a API HTTP or JSONRPC expression or a Service HTTP or
+// JSONRPC expression.
 //
 // Path accepts one argument: the HTTP path prefix.
 func Path(val string) {
@@ -240,6 +241,11 @@ func Path(val string) {
 			eval.ReportError(`only one base path may be specified for an API, got base paths %q and %q`, expr.Root.API.HTTP.Path, val)
 		}
 		expr.Root.API.HTTP.Path = val
+	case *expr.JSONRPCExpr:
+		if def.Path != "" {
+			eval.ReportError(`only one base path may be specified for the API JSON-RPC services, got base paths %q and %q`, def.Path, val)
+		}
+		def.Path = val
 	case *expr.HTTPServiceExpr:
 		if !strings.HasPrefix(val, "//") {
 			rp := expr.Root.API.HTTP.Path
@@ -0,0 +1,107 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// ETag defines the entity tag of the responses of a HTTP endpoint and enables
+// conditional requests (RFC 7232). The generated server sets the "ETag"
+// response header and replies with 304 Not Modified to GET and HEAD requests
+// whose "If-None-Match" header matches the entity tag and with 412 Precondition
+// Failed to requests whose "If-Match" header does not match it.
+//
+// ETag must appear in a HTTP endpoint expression.
+//
+// ETag accepts an optional argument: the name of the String result attribute
+// that holds the entity tag. The attribute is mapped to the "ETag" header of
+// the successful responses unless mapped explicitly and the generated server
+// quotes its value if needed. The generated client removes the quotes so that
+// the attribute holds the same value on both sides. Without argument the
+// entity tag is computed from the hash of the encoded response body.
+//
+// The preconditions of requests made with other methods (e.g. PUT or DELETE)
+// cannot be evaluated once the request has been processed. The generated
+// server stores them in the request context instead so that the service
+// method may call the goa CheckPreconditions function with the current entity
+// tag and modification time of the resource prior to modifying it.
+//
+// The generated client sets the conditional headers from the preconditions
+// stored in the request context with the goa WithPreconditions function. Use
+// the WithResponseCache client option of the goa http package to cache
+// responses and make conditional requests automatically.
+//
+// Example:
+//
+//    var _ = Service("documents", func() {
+//        Method("show", func() {
+//            Payload(String)
+//            Result(Document)
+//            HTTP(func() {
+//                GET("/{id}")
+//                ETag("version")
+//                LastModified("updated_at")
+//            })
+//        })
+//        Method("list", func() {
+//            Result(ArrayOf(Document))
+//            HTTP(func() {
+//                GET("/")
+//                ETag()
+//            })
+//        })
+//    })
+//
+func ETag(attribute ...string) {
+	c := conditional()
+	if c == nil {
+		return
+	}
+	if len(attribute) > 1 {
+		eval.InvalidArgError("zero or one attribute name", len(attribute))
+		return
+	}
+	if len(attribute) == 0 {
+		c.HashETag = true
+		return
+	}
+	c.ETagField = attribute[0]
+}
+
+// LastModified defines the modification time of the responses of a HTTP
+// endpoint and enables conditional requests (RFC 7232). The generated server
+// sets the "Last-Modified" response header and replies with 304 Not Modified
+// to GET and HEAD requests whose "If-Modified-Since" header is later than the
+// modification time and with 412 Precondition Failed to requests whose
+// "If-Unmodified-Since" header is earlier.
+//
+// LastModified must appear in a HTTP endpoint expression.
+//
+// LastModified accepts one argument: the name of the String result attribute
+// that holds the modification time formatted as a HTTP date (e.g. "Mon, 02 Jan
+// 2006 15:04:05 GMT"). The attribute may use Format(FormatRFC1123) but no
+// other format. The attribute is mapped to the "Last-Modified" header of the
+// successful responses unless mapped explicitly.
+//
+// See ETag for an example.
+func LastModified(attribute string) {
+	c := conditional()
+	if c == nil {
+		return
+	}
+	c.LastModifiedField = attribute
+}
+
+// conditional returns the conditional expression of the current HTTP
+// endpoint, creating it if needed.
+func conditional() *expr.HTTPConditionalExpr {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+	if e.Conditional == nil {
+		e.Conditional = &expr.HTTPConditionalExpr{Endpoint: e}
+	}
+	return e.Conditional
+}
@@ -0,0 +1,160 @@
+package dsl
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Origin defines the Cross-Origin Resource Sharing (CORS) policy that applies
+// to requests made from the given origin. The generated HTTP servers mount
+// handlers for the preflight OPTIONS requests and add the CORS headers to the
+// responses of the endpoints the policy applies to.
+//
+// Origin must appear in an API expression or in the HTTP expression of an
+// API, service or method. Origins defined in a method apply to the method
+// endpoint, origins defined in a service apply to the service endpoints that
+// do not define their own and origins defined in the API apply to the
+// endpoints of the services that do not define any.
+//
+// Origin accepts one or two arguments. The first argument is the allowed
+// origin: "*" allows any origin and origins that start and end with a slash
+// are regular expressions, e.g. `/^https://.*\.example\.com$/`. Regular
+// expressions should be anchored so that they do not match unexpected origins.
+// The second optional argument is a function that may use AllowMethods,
+// AllowHeaders, ExposeHeaders, MaxAge and AllowCredentials. If AllowMethods is
+// not used the policy allows the HTTP methods of the endpoint routes.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        Origin("https://example.com", func() {
+//            AllowHeaders("Authorization", "X-Request-Id")
+//            ExposeHeaders("X-Time")
+//            MaxAge(600)
+//            AllowCredentials()
+//        })
+//    })
+//
+//    var _ = Service("public", func() {
+//        HTTP(func() {
+//            Origin(`/^https://.*\.example\.com$/`)
+//        })
+//        Method("show", func() {
+//            HTTP(func() {
+//                GET("/{id}")
+//                Origin("*", func() {
+//                    AllowMethods("GET", "HEAD")
+//                })
+//            })
+//        })
+//    })
+//
+func Origin(origin string, fns ...func()) {
+	if len(fns) > 1 {
+		eval.InvalidArgError("zero or one function", fmt.Sprintf("%d functions", len(fns)))
+		return
+	}
+	var origins *[]*expr.HTTPCORSExpr
+	parent := eval.Current()
+	switch e := parent.(type) {
+	case *expr.APIExpr:
+		origins = &e.HTTP.Origins
+		parent = e.HTTP
+	case *expr.RootExpr:
+		origins = &e.API.HTTP.Origins
+		parent = e.API.HTTP
+	case *expr.HTTPServiceExpr:
+		origins = &e.Origins
+	case *expr.HTTPEndpointExpr:
+		origins = &e.Origins
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	cors := expr.NewHTTPCORSExpr(origin, parent)
+	if len(fns) == 1 {
+		if !eval.Execute(fns[0], cors) {
+			return
+		}
+	}
+	*origins = append(*origins, cors)
+}
+
+// AllowMethods lists the HTTP methods allowed when accessing the resource from
+// the origin. The methods are returned in the "Access-Control-Allow-Methods"
+// header of the preflight responses.
+//
+// AllowMethods must appear in an Origin expression.
+//
+// AllowMethods accepts the HTTP methods as arguments.
+func AllowMethods(methods ...string) {
+	if cors, ok := eval.Current().(*expr.HTTPCORSExpr); ok {
+		cors.Methods = append(cors.Methods, methods...)
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// AllowHeaders lists the request headers allowed when accessing the resource
+// from the origin. The headers are returned in the
+// "Access-Control-Allow-Headers" header of the preflight responses. The
+// special value "*" allows any header.
+//
+// AllowHeaders must appear in an Origin expression.
+//
+// AllowHeaders accepts the header names as arguments.
+func AllowHeaders(headers ...string) {
+	if cors, ok := eval.Current().(*expr.HTTPCORSExpr); ok {
+		cors.Headers = append(cors.Headers, headers...)
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// ExposeHeaders lists the response headers that browsers are allowed to
+// access. The headers are returned in the "Access-Control-Expose-Headers"
+// header of the responses.
+//
+// ExposeHeaders must appear in an Origin expression.
+//
+// ExposeHeaders accepts the header names as arguments.
+func ExposeHeaders(headers ...string) {
+	if cors, ok := eval.Current().(*expr.HTTPCORSExpr); ok {
+		cors.Expose = append(cors.Expose, headers...)
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// MaxAge sets the number of seconds browsers may cache the results of the
+// preflight requests. The value is returned in the "Access-Control-Max-Age"
+// header of the preflight responses.
+//
+// MaxAge must appear in an Origin expression.
+//
+// MaxAge accepts one argument: the number of seconds.
+func MaxAge(seconds int) {
+	if cors, ok := eval.Current().(*expr.HTTPCORSExpr); ok {
+		cors.MaxAge = seconds
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// AllowCredentials allows the resource to be accessed using credentials
+// (cookies, authorization headers or TLS client certificates). It sets the
+// "Access-Control-Allow-Credentials" response header. AllowCredentials cannot
+// be used with the wildcard origin "*".
+//
+// AllowCredentials must appear in an Origin expression.
+//
+// AllowCredentials takes no argument.
+func AllowCredentials() {
+	if cors, ok := eval.Current().(*expr.HTTPCORSExpr); ok {
+		cors.Credentials = true
+		return
+	}
+	eval.IncompatibleDSL()
+}
@@ -0,0 +1,54 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Idempotent indicates that the HTTP endpoint honors the "Idempotency-Key"
+// request header so that clients may safely retry unsafe requests such as POST
+// or PATCH requests. The generated server records the status, headers and
+// encoded body of the first response to a request made with a given key and
+// replays it when a request is made with the same key. Requests that reuse a
+// key with a different method, path or body are rejected with a
+// "idempotency_key_mismatch" error (status 422) and requests made while the
+// first request is still being processed are rejected with a
+// "idempotency_key_in_use" error (status 409). Responses with a 5xx status
+// code are not recorded. Keys are scoped to the endpoint and to the value of
+// the Authorization request header so that different endpoints or clients do
+// not share responses. Requests whose body exceeds 1 MiB are rejected with an
+// "idempotent_request_too_large" error (status 413).
+//
+// The generated servers record responses in memory by default, use the
+// UseIdempotencyStore method of the generated server to use a store shared by
+// multiple instances. Use the UseIdempotencyScope and
+// UseIdempotencyMaxBodySize methods to change the scope of the keys, e.g. to
+// use credentials read from other headers, and the maximum body size. The generated clients set the header automatically
+// using the same key for all the retries of a request. Use the
+// WithIdempotencyKey function of the goa http package to provide the key.
+//
+// Idempotent must appear in a HTTP endpoint expression. It is not compatible
+// with streaming methods, SkipRequestBodyEncodeDecode and
+// SkipResponseBodyEncodeDecode.
+//
+// Example:
+//
+//    var _ = Service("payments", func() {
+//        Method("charge", func() {
+//            Payload(Charge)
+//            Result(Receipt)
+//            HTTP(func() {
+//                POST("/charges")
+//                Idempotent()
+//            })
+//        })
+//    })
+//
+func Idempotent() {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.Idempotent = true
+}
@@ -0,0 +1,56 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// NDJSON indicates that the HTTP endpoint streams its payload and/or results
+// as newline delimited JSON (application/x-ndjson) over plain HTTP requests
+// and responses instead of a WebSocket connection. The generated server
+// writes one JSON value per line to the chunked response body and flushes it
+// after each result sent to the stream. The generated client streams the
+// payloads in the request body and closes the body when the client is done
+// sending (half-close). The generated streams implement the same interfaces
+// as the WebSocket streams.
+//
+// Methods that define both a StreamingPayload and a StreamingResult require
+// HTTP/2 or a server that supports full duplex HTTP/1.1 requests.
+//
+// NDJSON must appear in a HTTP endpoint expression. The method must define a
+// StreamingPayload, a StreamingResult or both. The request body must be empty
+// when the method defines a StreamingPayload.
+//
+// NDJSON takes no argument.
+//
+// Example:
+//
+//    var _ = Service("logs", func() {
+//        Method("tail", func() {
+//            Payload(func() {
+//                Attribute("source", String)
+//            })
+//            StreamingResult(LogEntry)
+//            HTTP(func() {
+//                GET("/logs/{source}")
+//                NDJSON()
+//            })
+//        })
+//        Method("ingest", func() {
+//            StreamingPayload(LogEntry)
+//            Result(Summary)
+//            HTTP(func() {
+//                POST("/logs")
+//                NDJSON()
+//            })
+//        })
+//    })
+//
+func NDJSON() {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.NDJSON = true
+}
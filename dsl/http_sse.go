@@ -0,0 +1,122 @@
+package dsl
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// ServerSentEvents indicates that the HTTP endpoint streams its results using
+// Server-Sent Events (text/event-stream) instead of a WebSocket connection.
+// The generated server writes one event per result sent to the stream, the
+// event data is the JSON representation of the result. The generated client
+// stream implements the same Recv method as the WebSocket client stream.
+//
+// ServerSentEvents must appear in a HTTP endpoint expression. The method must
+// define a StreamingResult and no StreamingPayload.
+//
+// ServerSentEvents accepts an optional function that may use SSEEventID,
+// SSEEventType, SSEEventRetry and SSERequestID to map result and payload
+// attributes to event fields and to the "Last-Event-ID" request header.
+//
+// Example:
+//
+//    var _ = Service("events", func() {
+//        Method("subscribe", func() {
+//            Payload(func() {
+//                Attribute("topic", String)
+//                Attribute("last_event_id", String)
+//            })
+//            StreamingResult(Event)
+//            HTTP(func() {
+//                GET("/{topic}/events")
+//                ServerSentEvents(func() {
+//                    SSEEventID("id")
+//                    SSEEventType("kind")
+//                    SSEEventRetry("retry_ms")
+//                    SSERequestID("last_event_id")
+//                })
+//            })
+//        })
+//    })
+//
+func ServerSentEvents(fns ...func()) {
+	if len(fns) > 1 {
+		eval.InvalidArgError("zero or one function", fmt.Sprintf("%d functions", len(fns)))
+		return
+	}
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	sse := &expr.HTTPSSEExpr{Endpoint: e}
+	if len(fns) == 1 {
+		if !eval.Execute(fns[0], sse) {
+			return
+		}
+	}
+	e.SSE = sse
+}
+
+// SSEEventID sets the name of the result attribute used to initialize the
+// "id" field of the events. The attribute must be of type String. Clients
+// send the ID of the last event they received in the "Last-Event-ID" header
+// when resuming a stream, see SSERequestID.
+//
+// SSEEventID must appear in a ServerSentEvents expression.
+//
+// SSEEventID accepts one argument: the name of the result attribute.
+func SSEEventID(name string) {
+	if sse, ok := eval.Current().(*expr.HTTPSSEExpr); ok {
+		sse.IDField = name
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// SSEEventType sets the name of the result attribute used to initialize the
+// "event" field of the events. The attribute must be of type String.
+//
+// SSEEventType must appear in a ServerSentEvents expression.
+//
+// SSEEventType accepts one argument: the name of the result attribute.
+func SSEEventType(name string) {
+	if sse, ok := eval.Current().(*expr.HTTPSSEExpr); ok {
+		sse.EventField = name
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// SSEEventRetry sets the name of the result attribute used to initialize the
+// "retry" field of the events, that is the reconnection time in milliseconds.
+// The attribute must be an integer.
+//
+// SSEEventRetry must appear in a ServerSentEvents expression.
+//
+// SSEEventRetry accepts one argument: the name of the result attribute.
+func SSEEventRetry(name string) {
+	if sse, ok := eval.Current().(*expr.HTTPSSEExpr); ok {
+		sse.RetryField = name
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// SSERequestID sets the name of the payload attribute initialized from the
+// "Last-Event-ID" request header. Clients set this header when resuming a
+// stream so that the service can skip the events that were already received.
+// The attribute must be of type String.
+//
+// SSERequestID must appear in a ServerSentEvents expression.
+//
+// SSERequestID accepts one argument: the name of the payload attribute.
+func SSERequestID(name string) {
+	if sse, ok := eval.Current().(*expr.HTTPSSEExpr); ok {
+		sse.RequestIDField = name
+		return
+	}
+	eval.IncompatibleDSL()
+}
@@ -0,0 +1,178 @@
+package dsl
+
+import (
+	"fmt"
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// WebSocket defines the settings of the websocket connections used by
+// streaming endpoints. The generated server and client streams send pings to
+// keep idle connections alive, fail reads when the peer stops answering,
+// limit the size of the messages they read and bound the time spent writing
+// messages. Clients of server streaming endpoints may also re-dial dropped
+// connections, see Reconnect.
+//
+// WebSocket must appear in the HTTP expression of a service or method.
+// Settings defined in a service apply to the service streaming endpoints that
+// do not define their own.
+//
+// WebSocket accepts one argument: a function that may use PingInterval,
+// PongTimeout, MaxMessageSize, WriteTimeout and Reconnect.
+//
+// Example:
+//
+//    var _ = Service("ticker", func() {
+//        HTTP(func() {
+//            WebSocket(func() {
+//                PingInterval("30s")
+//                PongTimeout("10s")
+//                MaxMessageSize(1 << 20)
+//                WriteTimeout("5s")
+//            })
+//        })
+//        Method("subscribe", func() {
+//            StreamingResult(Tick)
+//            HTTP(func() {
+//                GET("/ticks")
+//                WebSocket(func() {
+//                    PingInterval("15s")
+//                    Reconnect(func() {
+//                        MaxAttempts(10)
+//                        Backoff("500ms", "30s")
+//                    })
+//                })
+//            })
+//        })
+//    })
+//
+func WebSocket(fn func()) {
+	parent := eval.Current()
+	ws := &expr.HTTPWebSocketExpr{Parent: parent}
+	if !eval.Execute(fn, ws) {
+		return
+	}
+	switch e := parent.(type) {
+	case *expr.HTTPServiceExpr:
+		e.WebSocket = ws
+	case *expr.HTTPEndpointExpr:
+		e.WebSocket = ws
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// PingInterval sets the interval between two pings sent to the peer of the
+// websocket connections. Pings keep idle connections alive through proxies and
+// load balancers. The duration uses the syntax accepted by time.ParseDuration,
+// e.g. "30s".
+//
+// PingInterval must appear in a WebSocket expression.
+//
+// PingInterval accepts one argument: the interval between two pings.
+func PingInterval(interval string) {
+	ws, ok := eval.Current().(*expr.HTTPWebSocketExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		eval.ReportError("invalid ping interval %q: %s", interval, err)
+		return
+	}
+	ws.PingInterval = d
+}
+
+// PongTimeout sets the maximum time the generated streams wait for the pong
+// answering a ping. Reading from a stream fails if nothing is received from
+// the peer during the ping interval plus the pong timeout. PongTimeout
+// requires PingInterval.
+//
+// PongTimeout must appear in a WebSocket expression.
+//
+// PongTimeout accepts one argument: the timeout using the syntax accepted by
+// time.ParseDuration.
+func PongTimeout(timeout string) {
+	ws, ok := eval.Current().(*expr.HTTPWebSocketExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		eval.ReportError("invalid pong timeout %q: %s", timeout, err)
+		return
+	}
+	ws.PongTimeout = d
+}
+
+// MaxMessageSize sets the maximum size in bytes of the messages read from the
+// websocket connections. The connection is closed if the peer sends a larger
+// message.
+//
+// MaxMessageSize must appear in a WebSocket expression.
+//
+// MaxMessageSize accepts one argument: the maximum size in bytes.
+func MaxMessageSize(size int64) {
+	ws, ok := eval.Current().(*expr.HTTPWebSocketExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	ws.MaxMessageSize = size
+}
+
+// WriteTimeout sets the maximum time allowed to write a message or a ping to
+// the websocket connections.
+//
+// WriteTimeout must appear in a WebSocket expression.
+//
+// WriteTimeout accepts one argument: the timeout using the syntax accepted by
+// time.ParseDuration.
+func WriteTimeout(timeout string) {
+	ws, ok := eval.Current().(*expr.HTTPWebSocketExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		eval.ReportError("invalid write timeout %q: %s", timeout, err)
+		return
+	}
+	ws.WriteTimeout = d
+}
+
+// Reconnect makes the generated clients of server streaming endpoints re-dial
+// the websocket connection when it drops. The stream Recv method makes the
+// same request again and resumes reading from the new connection so that the
+// failure is transparent to the caller. Connections closed normally by the
+// server are not re-dialed.
+//
+// Reconnect must appear in a WebSocket expression. Settings defined in a
+// service only re-dial the connections of the server streaming endpoints.
+//
+// Reconnect accepts an optional function that may use MaxAttempts, Backoff and
+// Jitter. By default clients make up to 3 attempts with an initial backoff of
+// 100ms and a max backoff of 10s.
+func Reconnect(fns ...func()) {
+	if len(fns) > 1 {
+		eval.InvalidArgError("zero or one function", fmt.Sprintf("%d functions", len(fns)))
+		return
+	}
+	ws, ok := eval.Current().(*expr.HTTPWebSocketExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r := expr.NewRetryExpr(ws)
+	if len(fns) == 1 {
+		if !eval.Execute(fns[0], r) {
+			return
+		}
+	}
+	ws.Reconnect = r
+}
@@ -0,0 +1,178 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Interceptor defines an interceptor: a function that runs around the
+// generated endpoints and that may read or write the payload and result
+// attributes listed in its definition. The code generated for each service
+// includes the ServerInterceptors and ClientInterceptors interfaces that list
+// the interceptors used by the service. Each interceptor receives an info
+// struct whose Payload and Result methods return accessors that read and write
+// the listed attributes with compile-time type checking.
+//
+// Interceptor must appear at the top level of the design. Use
+// ServerInterceptor and ClientInterceptor to apply an interceptor to the
+// endpoints of an API, a service or a method.
+//
+// Interceptor accepts two arguments: the interceptor name and a function that
+// may use Description, ReadPayload, WritePayload, ReadResult and WriteResult.
+// Interceptors that access payload or result attributes cannot be used with
+// streaming methods and interceptors that access result attributes cannot be
+// used with methods whose result is defined with ResultType.
+//
+// Example:
+//
+//    var Cache = Interceptor("cache", func() {
+//        Description("Serve cached results")
+//        ReadPayload(func() {
+//            Attribute("id")
+//        })
+//        WriteResult(func() {
+//            Attribute("cached_at")
+//        })
+//    })
+//
+//    var _ = Service("catalog", func() {
+//        ServerInterceptor(Cache)
+//        Method("get", func() {
+//            Payload(func() {
+//                Attribute("id", String)
+//            })
+//            Result(Item)
+//        })
+//    })
+//
+func Interceptor(name string, fn ...func()) *expr.InterceptorExpr {
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments given to Interceptor")
+		return nil
+	}
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+	if name == "" {
+		eval.ReportError("interceptor name cannot be empty")
+		return nil
+	}
+	i := &expr.InterceptorExpr{Name: name}
+	if len(fn) > 0 {
+		i.DSLFunc = fn[0]
+	}
+	expr.Root.Interceptors = append(expr.Root.Interceptors, i)
+	return i
+}
+
+// ServerInterceptor applies the given interceptors to the service endpoints.
+// The interceptors run in the order they are listed, the API interceptors run
+// first, then the service interceptors and finally the method interceptors.
+//
+// ServerInterceptor must appear in an API, Service or Method expression.
+//
+// ServerInterceptor accepts one or more interceptors defined with Interceptor.
+func ServerInterceptor(interceptors ...*expr.InterceptorExpr) {
+	switch e := eval.Current().(type) {
+	case *expr.APIExpr:
+		e.ServerInterceptors = append(e.ServerInterceptors, interceptors...)
+	case *expr.ServiceExpr:
+		e.ServerInterceptors = append(e.ServerInterceptors, interceptors...)
+	case *expr.MethodExpr:
+		e.ServerInterceptors = append(e.ServerInterceptors, interceptors...)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// ClientInterceptor applies the given interceptors to the client endpoints.
+// The interceptors run in the order they are listed, the API interceptors run
+// first, then the service interceptors and finally the method interceptors.
+//
+// ClientInterceptor must appear in an API, Service or Method expression.
+//
+// ClientInterceptor accepts one or more interceptors defined with
+// Interceptor.
+func ClientInterceptor(interceptors ...*expr.InterceptorExpr) {
+	switch e := eval.Current().(type) {
+	case *expr.APIExpr:
+		e.ClientInterceptors = append(e.ClientInterceptors, interceptors...)
+	case *expr.ServiceExpr:
+		e.ClientInterceptors = append(e.ClientInterceptors, interceptors...)
+	case *expr.MethodExpr:
+		e.ClientInterceptors = append(e.ClientInterceptors, interceptors...)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// ReadPayload lists the payload attributes read by the interceptor. The
+// attribute types are the types defined in the method payloads.
+//
+// ReadPayload must appear in an Interceptor expression.
+//
+// ReadPayload accepts one argument: a function listing the attributes with
+// Attribute.
+func ReadPayload(fn func()) {
+	if i, ok := eval.Current().(*expr.InterceptorExpr); ok {
+		i.ReadPayload = interceptorAttributes(i.ReadPayload, fn)
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// WritePayload lists the payload attributes written by the interceptor. The
+// attribute types are the types defined in the method payloads.
+//
+// WritePayload must appear in an Interceptor expression.
+//
+// WritePayload accepts one argument: a function listing the attributes with
+// Attribute.
+func WritePayload(fn func()) {
+	if i, ok := eval.Current().(*expr.InterceptorExpr); ok {
+		i.WritePayload = interceptorAttributes(i.WritePayload, fn)
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// ReadResult lists the result attributes read by the interceptor. The
+// attribute types are the types defined in the method results.
+//
+// ReadResult must appear in an Interceptor expression.
+//
+// ReadResult accepts one argument: a function listing the attributes with
+// Attribute.
+func ReadResult(fn func()) {
+	if i, ok := eval.Current().(*expr.InterceptorExpr); ok {
+		i.ReadResult = interceptorAttributes(i.ReadResult, fn)
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// WriteResult lists the result attributes written by the interceptor. The
+// attribute types are the types defined in the method results.
+//
+// WriteResult must appear in an Interceptor expression.
+//
+// WriteResult accepts one argument: a function listing the attributes with
+// Attribute.
+func WriteResult(fn func()) {
+	if i, ok := eval.Current().(*expr.InterceptorExpr); ok {
+		i.WriteResult = interceptorAttributes(i.WriteResult, fn)
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// interceptorAttributes runs fn to add the listed attributes to att creating
+// it if needed.
+func interceptorAttributes(att *expr.AttributeExpr, fn func()) *expr.AttributeExpr {
+	if att == nil {
+		att = &expr.AttributeExpr{Type: &expr.Object{}}
+	}
+	eval.Execute(fn, att)
+	return att
+}
@@ -0,0 +1,108 @@
+package dsl
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+const (
+	// RPCParseError is the JSON-RPC error code returned when the server
+	// cannot parse the request.
+	RPCParseError = -32700
+	// RPCInvalidRequest is the JSON-RPC error code returned when the
+	// request is not a valid JSON-RPC request object.
+	RPCInvalidRequest = -32600
+	// RPCMethodNotFound is the JSON-RPC error code returned when the
+	// request method does not exist.
+	RPCMethodNotFound = -32601
+	// RPCInvalidParams is the JSON-RPC error code returned when the request
+	// params are invalid.
+	RPCInvalidParams = -32602
+	// RPCInternalError is the JSON-RPC error code returned for internal
+	// errors.
+	RPCInternalError = -32603
+)
+
+// JSONRPC defines the JSON-RPC 2.0 transport specific properties of an API, a
+// service or a single method. Methods that use JSONRPC are served by the
+// generated JSON-RPC server of their service: the JSON-RPC requests are sent
+// via HTTP POST requests to the service path and the request method is the
+// name of the method as defined in the design. Batch requests and
+// notifications are supported. The requests made to streaming methods are sent
+// via a websocket connection opened with a GET request to the same path: the
+// first message is the JSON-RPC request, the streaming payloads are sent as
+// notifications and the results as responses that use the request ID. As with
+// HTTP only the methods that use JSONRPC are exposed by the JSON-RPC server.
+//
+// The request params hold the whole method payload, including the security
+// credentials, and the response result the whole method result. The payload
+// and streaming payload must be objects. The only properties that may be
+// defined in JSONRPC are the service path, the error codes and meta:
+//
+//   - Path sets the path of the service in a service expression or the path
+//     prefix of all the services in the API expression. The path of a service
+//     defaults to the name of the service and may not be used by the routes of
+//     the HTTP endpoints.
+//   - Response maps a method error to a JSON-RPC error code, the error type is
+//     returned in the data field of the JSON-RPC error. Errors that are not
+//     mapped use RPCInternalError for faults, -32000 (server error) for
+//     temporary and timeout errors and RPCInvalidParams otherwise, for
+//     example for validation errors. The errors mapped in the API expression
+//     must be defined in the API expression.
+//
+// JSONRPC must appear in an API, a Service or a Method expression.
+//
+// JSONRPC accepts an optional argument which is the defining DSL function.
+//
+// Example:
+//
+//	var _ = API("calc", func() {
+//	    Error("unauthorized")
+//	    JSONRPC(func() {
+//	        Path("/rpc")
+//	        Response("unauthorized", -32001)
+//	    })
+//	})
+//
+//	var _ = Service("calc", func() {
+//	    Error("unauthorized")
+//	    JSONRPC(func() {
+//	        Path("/calc")
+//	    })
+//	    Method("div", func() {
+//	        Payload(Operands)
+//	        Result(Int)
+//	        Error("div_by_zero")
+//	        JSONRPC(func() {
+//	            Response("div_by_zero", -32002)
+//	        })
+//	    })
+//	})
+func JSONRPC(fns ...func()) {
+	if len(fns) > 1 {
+		eval.InvalidArgError("zero or one function", fmt.Sprintf("%d functions", len(fns)))
+		return
+	}
+	fn := func() {}
+	if len(fns) == 1 {
+		fn = fns[0]
+	}
+	switch actual := eval.Current().(type) {
+	case *expr.APIExpr:
+		eval.Execute(fn, expr.Root.API.JSONRPC)
+	case *expr.ServiceExpr:
+		res := expr.Root.API.JSONRPC.ServiceFor(actual)
+		res.DSLFunc = fn
+	case *expr.MethodExpr:
+		res := expr.Root.API.JSONRPC.ServiceFor(actual.Service)
+		act := res.EndpointFor(actual.Name, actual)
+		act.DSLFunc = fn
+		if len(act.Routes) == 0 {
+			act.Routes = []*expr.RouteExpr{{Method: "POST", Endpoint: act}}
+		}
+	default:
+		eval.IncompatibleDSL()
+	}
+}
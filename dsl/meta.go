@@ -127,6 +127,51 @@ import (
 //	    Meta("protoc:include", "/usr/local/include/google/protobuf")
 //	})
 //
+// - "protoc:builtin" generates the Go protocol buffer types and gRPC stubs
+// without running protoc and protoc-gen-go-grpc. The generation only requires
+// the Go toolchain: the Go types are generated by running the protoc-gen-go
+// plugin of the google.golang.org/protobuf module required by the current
+// module with "go run" and the gRPC stubs are generated by Goa. The include
+// paths set with "protoc:include" are used to find the imported files and
+// the files they import, the generation fails if an imported file cannot be
+// found. Applicable to API and service definitions only, use the value
+// "false" to disable it for a service.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("protoc:builtin")
+//	})
+//
+// - "grpc:reflection" adds the design documentation and validations to the
+// generated protocol buffer descriptors as custom options so that the clients
+// that use the gRPC server reflection service (e.g. grpcurl) can display them
+// and validate the requests. The options are defined in the
+// goadesign_goa_options.proto file of the goa.design/goa/v3/grpc/pb package:
+// descriptions, examples, default values and validations of the attributes,
+// required fields of the types and names and codes of the errors returned by
+// the methods. The example server generated by "goa example" registers the
+// server reflection service. Applicable to API and service definitions only,
+// use the value "false" to disable it for a service.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("grpc:reflection")
+//	})
+//
+// - "grpc:connect" generates HTTP handlers that serve the gRPC methods of the
+// service using the Connect protocol (JSON or binary protocol buffer messages
+// over HTTP/1.1 or HTTP/2) and the gRPC-Web protocol so that browsers and
+// tools such as curl can call them. The handlers invoke the generated gRPC
+// server so that the same endpoints serve the native gRPC, Connect and
+// gRPC-Web protocols (including the base64 encoded application/grpc-web-text
+// variant). The generated MountConnect function mounts the handlers on a
+// goahttp.Muxer and runs the given unary and stream interceptors, pass the
+// same interceptors as the ones given to the gRPC server so that both serve
+// the requests the same way. Applicable to API and service definitions only,
+// use the value "false" to disable it for a service.
+//
+//	var _ = Service("calc", func() {
+//	    Meta("grpc:connect")
+//	})
+//
 // - "swagger:generate" DEPRECATED, use "openapi:generate" instead.
 //
 // - "openapi:generate" specifies whether OpenAPI specification should be
@@ -137,6 +182,19 @@ import (
 //	    Meta("openapi:generate", "false")
 //	})
 //
+// - "typescript:generate" specifies whether the TypeScript client SDK should be
+// generated. The SDK is opt-in: it is only generated if the API defines the
+// meta with a value other than "false". Services may then use the value
+// "false" to exclude themselves from the SDK. Applicable to API and services.
+//
+//	var _ = API("MyAPI", func() {
+//	    Meta("typescript:generate")
+//	})
+//
+//	var _ = Service("MyService", func() {
+//	    Meta("typescript:generate", "false")
+//	})
+//
 // - "swagger:summary" DEPRECATED, use "openapi:summary" instead
 //
 // - "openapi:summary" sets the OpenAPI operation summary field. The special
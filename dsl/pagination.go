@@ -0,0 +1,126 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Paginated indicates that the method returns its results in pages. The
+// generated service client includes an iterator that walks the pages
+// automatically and the HTTP servers set the "Link" header of responses that
+// are followed by another page.
+//
+// Paginated must appear in a Method expression.
+//
+// Paginated accepts an optional function that may use Cursor, Offset,
+// PageSize and Items to override the names of the pagination attributes. By
+// default the method uses cursor pagination: the "cursor" payload attribute
+// holds the token of the requested page, the "next_cursor" result attribute
+// holds the token of the next page, the "limit" payload attribute holds the
+// page size and the "items" result attribute lists the page items. The
+// cursor, offset, page size and next cursor attributes are added to the
+// payload and result if they are not defined explicitly. The HTTP transport
+// maps the payload attributes to query string parameters unless they are
+// mapped explicitly.
+//
+// Example:
+//
+//    Method("list", func() {
+//        Payload(func() {
+//            Attribute("filter", String)
+//        })
+//        Result(func() {
+//            Attribute("items", ArrayOf(Item))
+//            Required("items")
+//        })
+//        Paginated(func() {
+//            Cursor("page_token", "next_page_token")
+//            PageSize("page_size")
+//        })
+//        HTTP(func() {
+//            GET("/items")
+//        })
+//    })
+//
+func Paginated(fn ...func()) {
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments given to Paginated")
+		return
+	}
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p := expr.NewPaginationExpr(m)
+	if len(fn) > 0 {
+		if !eval.Execute(fn[0], p) {
+			return
+		}
+	}
+	m.Pagination = p
+}
+
+// Cursor sets the names of the payload attribute that holds the token of the
+// requested page and of the result attribute that holds the token of the next
+// page.
+//
+// Cursor must appear in a Paginated expression.
+//
+// Cursor accepts two arguments: the payload and result attribute names.
+func Cursor(name, next string) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.Cursor, p.NextCursor, p.Offset = name, next, ""
+}
+
+// Offset makes the method use offset pagination and sets the name of the
+// payload attribute that holds the index of the first item of the requested
+// page. The generated iterators request the next page by adding the number of
+// items in the current page to the offset and stop when a page is empty or
+// contains less items than requested.
+//
+// Offset must appear in a Paginated expression.
+//
+// Offset accepts one argument: the payload attribute name.
+func Offset(name string) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.Offset, p.Cursor, p.NextCursor = name, "", ""
+}
+
+// PageSize sets the name of the payload attribute that holds the maximum
+// number of items per page.
+//
+// PageSize must appear in a Paginated expression.
+//
+// PageSize accepts one argument: the payload attribute name.
+func PageSize(name string) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.Limit = name
+}
+
+// Items sets the name of the result attribute that lists the page items. The
+// attribute must be an array.
+//
+// Items must appear in a Paginated expression.
+//
+// Items accepts one argument: the result attribute name.
+func Items(name string) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.Items = name
+}
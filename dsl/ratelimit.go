@@ -0,0 +1,184 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// RateLimit limits the rate of the requests handled by the generated service
+// endpoints. The requests are limited using token buckets: up to requests
+// requests are allowed during each interval, bursts are capped by the value
+// given to Burst. Requests that exceed the limit fail with an error named
+// "rate_limited" that the HTTP servers return with status 429 (Too Many
+// Requests) and a Retry-After header and the gRPC servers return with code
+// RESOURCE_EXHAUSTED.
+//
+// The limits are enforced by the endpoint middleware generated in the service
+// endpoints.go file. The generated Endpoints struct UseLimiter method applies
+// the middleware using a goa.LimiterStore such as the in-memory store
+// returned by goa.NewMemoryLimiterStore.
+//
+// RateLimit must appear in a Service or Method expression. Limits defined in
+// a service apply to all the service methods that do not define their own.
+//
+// RateLimit accepts two or three arguments. The first argument is the number
+// of requests allowed during the interval given as second argument. The
+// interval uses the syntax accepted by time.ParseDuration, e.g. "1s" or "1m".
+// The last optional argument is a function that may use Burst,
+// ConcurrencyLimit, RateLimitKey and RateLimitPrincipal. By default the limits
+// apply to all requests regardless of their origin.
+//
+// Example:
+//
+//    var _ = Service("calc", func() {
+//        RateLimit(100, "1m", func() {
+//            Burst(20)
+//            RateLimitPrincipal()
+//        })
+//        Method("add", func() {
+//            Payload(func() {
+//                Attribute("tenant_id", String)
+//                Attribute("a", Int)
+//                Attribute("b", Int)
+//            })
+//            RateLimit(10, "1s", func() {
+//                RateLimitKey("tenant_id")
+//                ConcurrencyLimit(5)
+//            })
+//        })
+//    })
+//
+func RateLimit(requests int, interval string, fns ...func()) {
+	if len(fns) > 1 {
+		eval.InvalidArgError("zero or one function", len(fns))
+		return
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		eval.ReportError("invalid rate limit interval %q: %s", interval, err)
+		return
+	}
+	r := rateLimitExpr()
+	if r == nil {
+		return
+	}
+	r.Requests = requests
+	r.Interval = d
+	if len(fns) == 1 {
+		eval.Execute(fns[0], r)
+	}
+}
+
+// ConcurrencyLimit limits the number of requests handled concurrently by the
+// generated service endpoints. Requests that exceed the limit fail with an
+// error named "rate_limited", see RateLimit. The HTTP responses have no
+// Retry-After header as the delay after which a request completes is unknown.
+//
+// ConcurrencyLimit must appear in a Service, Method or RateLimit expression.
+// Limits defined in a service apply to all the service methods that do not
+// define their own.
+//
+// ConcurrencyLimit accepts one or two arguments. The first argument is the
+// maximum number of concurrent requests. The second optional argument is a
+// function that may use RateLimitKey and RateLimitPrincipal.
+//
+// Example:
+//
+//    var _ = Service("report", func() {
+//        Method("generate", func() {
+//            ConcurrencyLimit(2)
+//        })
+//    })
+//
+func ConcurrencyLimit(max int, fns ...func()) {
+	if len(fns) > 1 {
+		eval.InvalidArgError("zero or one function", len(fns))
+		return
+	}
+	if r, ok := eval.Current().(*expr.RateLimitExpr); ok {
+		if len(fns) > 0 {
+			eval.ReportError("ConcurrencyLimit does not accept a function when used in RateLimit")
+			return
+		}
+		r.MaxInFlight = max
+		return
+	}
+	r := rateLimitExpr()
+	if r == nil {
+		return
+	}
+	r.MaxInFlight = max
+	if len(fns) == 1 {
+		eval.Execute(fns[0], r)
+	}
+}
+
+// Burst sets the maximum number of requests allowed at once. It defaults to
+// the number of requests allowed per interval.
+//
+// Burst must appear in a RateLimit expression.
+//
+// Burst accepts one argument: the maximum number of requests.
+func Burst(n int) {
+	r, ok := eval.Current().(*expr.RateLimitExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.Burst = n
+}
+
+// RateLimitKey enforces the limits separately for each value of the given
+// payload attribute, for example a tenant ID.
+//
+// RateLimitKey must appear in a RateLimit or ConcurrencyLimit expression.
+//
+// RateLimitKey accepts one argument: the name of a primitive payload
+// attribute.
+func RateLimitKey(attribute string) {
+	r, ok := eval.Current().(*expr.RateLimitExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.Key = attribute
+}
+
+// RateLimitPrincipal enforces the limits separately for each security
+// principal: the user name for basic authentication, the key for API key
+// authentication and the token for JWT and OAuth2 authentication.
+//
+// RateLimitPrincipal must appear in a RateLimit or ConcurrencyLimit
+// expression of a method that defines security requirements.
+//
+// RateLimitPrincipal takes no argument.
+func RateLimitPrincipal() {
+	r, ok := eval.Current().(*expr.RateLimitExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.Principal = true
+}
+
+// rateLimitExpr returns the rate limit expression of the current service or
+// method expression creating it if needed.
+func rateLimitExpr() *expr.RateLimitExpr {
+	switch e := eval.Current().(type) {
+	case *expr.ServiceExpr:
+		if e.RateLimit == nil {
+			e.RateLimit = &expr.RateLimitExpr{Parent: e}
+		}
+		return e.RateLimit
+	case *expr.MethodExpr:
+		if e.RateLimit == nil {
+			e.RateLimit = &expr.RateLimitExpr{Parent: e}
+		}
+		return e.RateLimit
+	default:
+		eval.IncompatibleDSL()
+		return nil
+	}
+}
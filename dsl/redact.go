@@ -0,0 +1,48 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Sensitive marks an attribute or a type as holding a sensitive value such as
+// a password, a token or personal data. The generated service types that have
+// sensitive attributes implement fmt.Stringer and slog.LogValuer and replace
+// the sensitive values with "[REDACTED]", the generated validation errors do
+// not include the rejected sensitive values and the HTTP debug middleware and
+// debug client doer scrub the sensitive body fields, headers and query
+// parameters. The attributes defined with Password or PasswordField are
+// sensitive.
+//
+// The generated LogValue methods are written to separate files built with Go
+// 1.21 or later only. The String and LogValue methods are not generated for
+// types that have a field with the same name.
+//
+// Sensitive must appear in an Attribute or Type expression.
+//
+// Sensitive takes no argument.
+//
+// Example:
+//
+//    var Signup = Type("Signup", func() {
+//        Attribute("email", String, func() {
+//            Format(FormatEmail)
+//            Sensitive()
+//        })
+//        Password("password", String)
+//    })
+func Sensitive() {
+	switch e := eval.Current().(type) {
+	case *expr.AttributeExpr:
+		e.AddMeta("goa:sensitive")
+	case expr.CompositeExpr:
+		e.Attribute().AddMeta("goa:sensitive")
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// Redact is an alias for Sensitive.
+func Redact() {
+	Sensitive()
+}
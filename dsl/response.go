@@ -20,7 +20,8 @@ import (
 // to all the service methods. Response may also appear in a method expression
 // to define both success and error responses specific to the method. In both
 // cases Response must appear in the transport specific DSL (i.e. in a HTTP or
-// gRPC subexpression).
+// gRPC subexpression). In a JSONRPC subexpression Response maps an error to a
+// JSON-RPC error code given as status code, e.g. Response("not_found", -32004).
 //
 // Response accepts one to three arguments. Success response accepts a status
 // code as first argument. If the first argument is a status code then a
@@ -120,6 +121,14 @@ func Response(val any, args ...any) {
 		if e := httpError(name, t, args...); e != nil {
 			t.Errors = append(t.Errors, e)
 		}
+	case *expr.JSONRPCExpr:
+		if !ok {
+			eval.InvalidArgError("name of error", val)
+			return
+		}
+		if e := httpError(name, t, args...); e != nil {
+			t.Errors = append(t.Errors, e)
+		}
 	case *expr.GRPCExpr:
 		if !ok {
 			eval.InvalidArgError("name of error", val)
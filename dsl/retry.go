@@ -0,0 +1,201 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Retry defines the policy used by the generated clients to retry failed
+// requests. The generated client endpoints retry the requests that fail with
+// an error designed as Temporary or Timeout, with one of the errors listed
+// with RetryOn or, for HTTP clients, with one of the status codes listed with
+// RetryOnStatus. The delay between two attempts grows exponentially starting
+// with the initial backoff and capped by the max backoff. Clients never retry
+// requests whose context is canceled and do not wait past the context
+// deadline. Streaming methods are never retried.
+//
+// Retry must appear in an API, Service or Method expression. A retry policy
+// defined in a service applies to all the service methods that do not define
+// their own, a policy defined in the API applies to all the methods of the
+// services that do not define one.
+//
+// Retry accepts an optional function that may use MaxAttempts, Backoff,
+// Jitter, Hedge, RetryOn, RetryOnStatus and IdempotentOnly. By default clients
+// make up to 3 attempts with an initial backoff of 100ms and a max backoff of
+// 10s.
+//
+// Example:
+//
+//    var _ = Service("calc", func() {
+//        Error("unavailable", ErrorResult, func() {
+//            Temporary()
+//        })
+//        Retry(func() {
+//            MaxAttempts(5)
+//            Backoff("200ms", "5s")
+//            Jitter(0.2)
+//            RetryOn("conflict")
+//            RetryOnStatus(StatusBadGateway)
+//            IdempotentOnly()
+//        })
+//        Method("add", func() {
+//            Error("conflict")
+//            Retry(func() {
+//                Hedge("50ms")
+//            })
+//        })
+//    })
+//
+func Retry(fns ...func()) {
+	if len(fns) > 1 {
+		eval.InvalidArgError("zero or one function", len(fns))
+		return
+	}
+	parent := eval.Current()
+	r := expr.NewRetryExpr(parent)
+	if len(fns) == 1 {
+		if !eval.Execute(fns[0], r) {
+			return
+		}
+	}
+	switch e := parent.(type) {
+	case *expr.APIExpr:
+		e.Retry = r
+	case *expr.ServiceExpr:
+		e.Retry = r
+	case *expr.MethodExpr:
+		e.Retry = r
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// MaxAttempts sets the maximum number of attempts including the initial
+// request. A value of 1 disables retries.
+//
+// MaxAttempts must appear in a Retry expression.
+//
+// MaxAttempts accepts one argument: the maximum number of attempts.
+func MaxAttempts(n int) {
+	r, ok := eval.Current().(*expr.RetryExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.MaxAttempts = n
+}
+
+// Backoff sets the delay before the first retry and the maximum delay between
+// two attempts. The delay doubles after each attempt. The durations use the
+// syntax accepted by time.ParseDuration, e.g. "100ms" or "2s".
+//
+// Backoff must appear in a Retry expression.
+//
+// Backoff accepts two arguments: the initial and maximum delays.
+func Backoff(initial, max string) {
+	r, ok := eval.Current().(*expr.RetryExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	i, err := time.ParseDuration(initial)
+	if err != nil {
+		eval.ReportError("invalid initial backoff %q: %s", initial, err)
+		return
+	}
+	m, err := time.ParseDuration(max)
+	if err != nil {
+		eval.ReportError("invalid max backoff %q: %s", max, err)
+		return
+	}
+	r.InitialBackoff = i
+	r.MaxBackoff = m
+}
+
+// Jitter sets the fraction of the backoff delay that is randomized to avoid
+// synchronized retries, e.g. 0.2 yields delays between 80% and 120% of the
+// computed backoff.
+//
+// Jitter must appear in a Retry expression.
+//
+// Jitter accepts one argument: a number between 0 and 1.
+func Jitter(fraction float64) {
+	r, ok := eval.Current().(*expr.RetryExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.Jitter = fraction
+}
+
+// Hedge enables request hedging: instead of waiting for an attempt to fail
+// the client starts a new attempt if the pending ones have not completed
+// after the given delay. The first successful response is used and the other
+// attempts are canceled. Hedging should only be used with idempotent
+// methods.
+//
+// Hedge must appear in a Retry expression.
+//
+// Hedge accepts one argument: the hedging delay using the syntax accepted by
+// time.ParseDuration.
+func Hedge(delay string) {
+	r, ok := eval.Current().(*expr.RetryExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	d, err := time.ParseDuration(delay)
+	if err != nil {
+		eval.ReportError("invalid hedge delay %q: %s", delay, err)
+		return
+	}
+	r.HedgeDelay = d
+}
+
+// RetryOn lists the names of the errors that are retried in addition to the
+// errors designed as Temporary or Timeout.
+//
+// RetryOn must appear in a Retry expression.
+//
+// RetryOn accepts the names of the errors as arguments.
+func RetryOn(names ...string) {
+	r, ok := eval.Current().(*expr.RetryExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.Errors = append(r.Errors, names...)
+}
+
+// RetryOnStatus lists the HTTP response status codes that are retried when
+// returned by the server with a response that is not described in the
+// design.
+//
+// RetryOnStatus must appear in a Retry expression.
+//
+// RetryOnStatus accepts the status codes as arguments.
+func RetryOnStatus(codes ...int) {
+	r, ok := eval.Current().(*expr.RetryExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.StatusCodes = append(r.StatusCodes, codes...)
+}
+
+// IdempotentOnly restricts retries to idempotent requests: HTTP clients do
+// not retry POST and PATCH requests and gRPC clients do not retry at all.
+//
+// IdempotentOnly must appear in a Retry expression.
+//
+// IdempotentOnly takes no argument.
+func IdempotentOnly() {
+	r, ok := eval.Current().(*expr.RetryExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.IdempotentOnly = true
+}
@@ -51,6 +51,10 @@ const (
 
 	// FormatRFC1123 describes RFC1123 date time values.
 	FormatRFC1123 = expr.FormatRFC1123
+
+	// FormatDuration describes durations as accepted by time.ParseDuration
+	// (e.g. "1h30m").
+	FormatDuration = expr.FormatDuration
 )
 
 // Enum adds a "enum" validation to the attribute.
@@ -142,6 +146,8 @@ func Enum(vals ...any) {
 //
 // FormatRFC1123: RFC1123 date time
 //
+// FormatDuration: duration as accepted by time.ParseDuration, e.g. "1h30m"
+//
 // Example:
 //
 //    Attribute("created_at", String, func() {
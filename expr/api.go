@@ -36,10 +36,24 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// Retry is the retry policy that applies to all the API service
+		// methods if any.
+		Retry *RetryExpr
+		// ServerInterceptors lists the interceptors that run around all
+		// the API service endpoints.
+		ServerInterceptors []*InterceptorExpr
+		// ClientInterceptors lists the interceptors that run around all
+		// the API client endpoints.
+		ClientInterceptors []*InterceptorExpr
+		// Health describes the health checks of the API services that do
+		// not define their own if any.
+		Health *HealthExpr
 		// HTTP contains the HTTP specific API level expressions.
 		HTTP *HTTPExpr
 		// GRPC contains the gRPC specific API level expressions.
 		GRPC *GRPCExpr
+		// JSONRPC contains the JSON-RPC specific API level expressions.
+		JSONRPC *JSONRPCExpr
 
 		// random generator used to build examples for the API types.
 		ExampleGenerator *ExampleGenerator
@@ -78,6 +92,7 @@ func NewAPIExpr(name string, dsl func()) *APIExpr {
 		Name:             name,
 		HTTP:             new(HTTPExpr),
 		GRPC:             new(GRPCExpr),
+		JSONRPC:          new(JSONRPCExpr),
 		DSLFunc:          dsl,
 		ExampleGenerator: NewRandom(name),
 	}
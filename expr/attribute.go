@@ -146,6 +146,10 @@ const (
 
 	// FormatRFC1123 describes RFC1123 date time values.
 	FormatRFC1123 = "rfc1123"
+
+	// FormatDuration describes durations as accepted by time.ParseDuration
+	// (e.g. "1h30m").
+	FormatDuration = "duration"
 )
 
 // EvalName returns the name used by the DSL evaluation.
@@ -476,6 +480,27 @@ func (a *AttributeExpr) HasTagPrefix(prefix string) bool {
 	return false
 }
 
+// IsSensitive returns true if the attribute holds a sensitive value that must
+// not appear in logs, debug output or error messages. Attributes are sensitive
+// if they use the Sensitive DSL, are defined with Password or PasswordField or
+// if their type is a sensitive user type.
+func (a *AttributeExpr) IsSensitive() bool {
+	if a == nil {
+		return false
+	}
+	if _, ok := a.Meta["goa:sensitive"]; ok {
+		return true
+	}
+	if _, ok := a.Meta["security:password"]; ok {
+		return true
+	}
+	if ut, ok := a.Type.(UserType); ok {
+		_, ok := ut.Attribute().Meta["goa:sensitive"]
+		return ok
+	}
+	return false
+}
+
 // FieldTag returns the field tag if the attribute is a field.
 func (a *AttributeExpr) FieldTag() (tag string, found bool) {
 	if a == nil {
@@ -955,6 +980,8 @@ func (*AttributeExpr) IsSupportedValidationFormat(vf ValidationFormat) bool {
 		return true
 	case FormatRFC1123:
 		return true
+	case FormatDuration:
+		return true
 	}
 	return false
 }
@@ -732,6 +732,47 @@ func TestAttributeExprHasTag(t *testing.T) {
 	}
 }
 
+func TestAttributeExprIsSensitive(t *testing.T) {
+	sensitiveType := &UserTypeExpr{
+		TypeName: "Secret",
+		AttributeExpr: &AttributeExpr{
+			Type: String,
+			Meta: MetaExpr{"goa:sensitive": nil},
+		},
+	}
+	cases := map[string]struct {
+		attribute *AttributeExpr
+		expected  bool
+	}{
+		"attribute expr is nil": {
+			attribute: nil,
+			expected:  false,
+		},
+		"sensitive": {
+			attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"goa:sensitive": nil}},
+			expected:  true,
+		},
+		"password": {
+			attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"security:password": nil}},
+			expected:  true,
+		},
+		"sensitive user type": {
+			attribute: &AttributeExpr{Type: sensitiveType},
+			expected:  true,
+		},
+		"not sensitive": {
+			attribute: &AttributeExpr{Type: String},
+			expected:  false,
+		},
+	}
+
+	for k, tc := range cases {
+		if actual := tc.attribute.IsSensitive(); tc.expected != actual {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
+
 func TestAttributeExprHasTagPrefix(t *testing.T) {
 	var (
 		tag    = "security:apikey:api_key"
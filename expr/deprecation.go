@@ -0,0 +1,78 @@
+package expr
+
+import (
+	"time"
+)
+
+type (
+	// Deprecation describes the deprecation of a service, method, type or
+	// attribute defined with the Deprecated DSL.
+	Deprecation struct {
+		// Reason explains why the element is deprecated and what to use
+		// instead if anything.
+		Reason string
+		// Date is the time at which the element was or will be deprecated,
+		// zero if unknown.
+		Date time.Time
+		// Sunset is the time after which the element is expected to
+		// become unavailable, zero if unknown.
+		Sunset time.Time
+	}
+)
+
+const (
+	// DeprecatedMetaKey is the meta key set by the Deprecated DSL, its
+	// value is the deprecation reason.
+	DeprecatedMetaKey = "goa:deprecated"
+
+	// SunsetMetaKey is the meta key set by the Deprecated DSL when a sunset
+	// date is given, its value is the date formatted using RFC 3339.
+	SunsetMetaKey = "goa:sunset"
+
+	// DeprecationDateMetaKey is the meta key set by the DeprecatedSince
+	// DSL, its value is the date formatted using RFC 3339.
+	DeprecationDateMetaKey = "goa:deprecation:date"
+)
+
+// DeprecationOf returns the deprecation recorded in meta by the Deprecated
+// DSL, nil if there is none.
+func DeprecationOf(meta MetaExpr) *Deprecation {
+	if _, ok := meta[DeprecatedMetaKey]; !ok {
+		return nil
+	}
+	reason, _ := meta.Last(DeprecatedMetaKey)
+	d := &Deprecation{Reason: reason}
+	if s, ok := meta.Last(SunsetMetaKey); ok {
+		d.Sunset, _ = time.Parse(time.RFC3339, s)
+	}
+	if s, ok := meta.Last(DeprecationDateMetaKey); ok {
+		d.Date, _ = time.Parse(time.RFC3339, s)
+	}
+	return d
+}
+
+// ParseSunset parses the sunset date given to the Deprecated DSL or the date
+// given to the DeprecatedSince DSL. The date must use the "YYYY-MM-DD" or RFC
+// 3339 format.
+func ParseSunset(date string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", date); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
+
+// Deprecation returns the deprecation of the method, nil if neither the method
+// nor its service is deprecated.
+func (m *MethodExpr) Deprecation() *Deprecation {
+	if d := DeprecationOf(m.Meta); d != nil {
+		return d
+	}
+	if m.Service != nil {
+		return DeprecationOf(m.Service.Meta)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package expr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMethodExprDeprecation(t *testing.T) {
+	sunset := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		ServiceMeta MetaExpr
+		MethodMeta  MetaExpr
+		Expected    *Deprecation
+	}{
+		"none":    {nil, nil, nil},
+		"method":  {nil, MetaExpr{DeprecatedMetaKey: {"method"}, SunsetMetaKey: {sunset.Format(time.RFC3339)}}, &Deprecation{Reason: "method", Sunset: sunset}},
+		"service": {MetaExpr{DeprecatedMetaKey: {"service"}}, nil, &Deprecation{Reason: "service"}},
+		"both":    {MetaExpr{DeprecatedMetaKey: {"service"}}, MetaExpr{DeprecatedMetaKey: {"method"}}, &Deprecation{Reason: "method"}},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			m := &MethodExpr{Meta: tc.MethodMeta, Service: &ServiceExpr{Meta: tc.ServiceMeta}}
+			got := m.Deprecation()
+			if tc.Expected == nil {
+				if got != nil {
+					t.Errorf("got %+v, expected nil", got)
+				}
+				return
+			}
+			if got == nil || got.Reason != tc.Expected.Reason || !got.Sunset.Equal(tc.Expected.Sunset) {
+				t.Errorf("got %+v, expected %+v", got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestParseSunset(t *testing.T) {
+	cases := map[string]struct {
+		Date     string
+		Expected time.Time
+		Error    bool
+	}{
+		"date":    {"2030-01-02", time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		"rfc3339": {"2030-01-02T10:00:00+02:00", time.Date(2030, 1, 2, 8, 0, 0, 0, time.UTC), false},
+		"invalid": {"Jan 2 2030", time.Time{}, true},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			got, err := ParseSunset(tc.Date)
+			if tc.Error {
+				if err == nil {
+					t.Errorf("got %s, expected an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tc.Expected) {
+				t.Errorf("got %s, expected %s", got, tc.Expected)
+			}
+		})
+	}
+}
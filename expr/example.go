@@ -217,7 +217,8 @@ func byFormat(a *AttributeExpr, r *ExampleGenerator) any {
 			}
 			return uuid.String()
 		}(),
-		FormatJSON: `{"name":"example","email":"mail@example.com"}`,
+		FormatJSON:     `{"name":"example","email":"mail@example.com"}`,
+		FormatDuration: (time.Duration(r.Int()%86400) * time.Second).String(),
 	}[format]; ok {
 		return res
 	}
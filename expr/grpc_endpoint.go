@@ -151,12 +151,12 @@ func (e *GRPCEndpointExpr) Validate() error {
 		verr.Add(e, "Endpoint name cannot be empty")
 	}
 
-	// error if payload, result, and error type define attribute of Any type
-	// which is unsupported.
-	verr.Merge(e.hasAnyType(e.MethodExpr.Payload, "Payload"))
-	verr.Merge(e.hasAnyType(e.MethodExpr.Result, "Result"))
+	// error if payload, result, and error type define maps with keys of Any
+	// type which is unsupported (protocol buffer map keys must be scalars).
+	verr.Merge(e.hasAnyMapKey(e.MethodExpr.Payload))
+	verr.Merge(e.hasAnyMapKey(e.MethodExpr.Result))
 	for _, er := range e.MethodExpr.Errors {
-		verr.Merge(e.hasAnyType(er.AttributeExpr, fmt.Sprintf("Error %q", er.Name)))
+		verr.Merge(e.hasAnyMapKey(er.AttributeExpr))
 	}
 
 	var hasMessage, hasMetadata bool
@@ -517,13 +517,11 @@ func getSecurityAttributes(m *MethodExpr) []string {
 	return secAttrs
 }
 
-// hasAnyType recurses through the given attribute and returns validation error
-// if any attribute is of Any type.
-func (e *GRPCEndpointExpr) hasAnyType(a *AttributeExpr, typ string, seen ...map[string]struct{}) *eval.ValidationErrors {
+// hasAnyMapKey recurses through the given attribute and returns validation
+// error if any map key is of Any type. Attributes of Any type are otherwise
+// mapped to google.protobuf.Value.
+func (e *GRPCEndpointExpr) hasAnyMapKey(a *AttributeExpr, seen ...map[string]struct{}) *eval.ValidationErrors {
 	verr := new(eval.ValidationErrors)
-	if a.Type == Any {
-		verr.Add(e, "%s type is Any type which is not supported in gRPC", typ)
-	}
 	switch actual := a.Type.(type) {
 	case UserType:
 		var s map[string]struct{}
@@ -537,39 +535,22 @@ func (e *GRPCEndpointExpr) hasAnyType(a *AttributeExpr, typ string, seen ...map[
 			return verr
 		}
 		s[actual.ID()] = struct{}{}
-		verr.Merge(e.hasAnyType(actual.Attribute(), typ, seen...))
+		verr.Merge(e.hasAnyMapKey(actual.Attribute(), seen...))
 	case *Array:
-		if IsPrimitive(actual.ElemType.Type) {
-			if actual.ElemType.Type == Any {
-				verr.Add(e, "Array element type is Any type which is not supported in gRPC")
-			}
-			return verr
-		}
-		verr.Merge(e.hasAnyType(actual.ElemType, typ, seen...))
+		verr.Merge(e.hasAnyMapKey(actual.ElemType, seen...))
 	case *Map:
-		if IsPrimitive(actual.KeyType.Type) {
-			if actual.KeyType.Type == Any {
-				verr.Add(e, "Map key type is Any type which is not supported in gRPC")
-			}
-		} else {
-			verr.Merge(e.hasAnyType(actual.KeyType, typ, seen...))
+		if actual.KeyType.Type == Any {
+			verr.Add(e, "Map key type is Any type which is not supported in gRPC")
 		}
-		if IsPrimitive(actual.ElemType.Type) {
-			if actual.ElemType.Type == Any {
-				verr.Add(e, "Map element type is Any type which is not supported in gRPC")
-			}
-			return verr
-		}
-		verr.Merge(e.hasAnyType(actual.ElemType, typ, seen...))
+		verr.Merge(e.hasAnyMapKey(actual.KeyType, seen...))
+		verr.Merge(e.hasAnyMapKey(actual.ElemType, seen...))
 	case *Object:
 		for _, nat := range *actual {
-			if IsPrimitive(nat.Attribute.Type) {
-				if nat.Attribute.Type == Any {
-					verr.Add(e, "Attribute %q is Any type which is not supported in gRPC", nat.Name)
-				}
-				continue
-			}
-			verr.Merge(e.hasAnyType(nat.Attribute, typ, seen...))
+			verr.Merge(e.hasAnyMapKey(nat.Attribute, seen...))
+		}
+	case *Union:
+		for _, nat := range actual.Values {
+			verr.Merge(e.hasAnyMapKey(nat.Attribute, seen...))
 		}
 	}
 	return verr
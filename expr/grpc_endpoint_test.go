@@ -14,14 +14,8 @@ func TestGRPCEndpointValidation(t *testing.T) {
 		Errors []string
 	}{
 		"endpoint-with-any-type": {
-			DSL: testdata.GRPCEndpointWithAnyType,
-			Errors: []string{`service "Service" gRPC endpoint "Method": Map key type is Any type which is not supported in gRPC
-service "Service" gRPC endpoint "Method": Array element type is Any type which is not supported in gRPC
-service "Service" gRPC endpoint "Method": Attribute "invalid_primitive" is Any type which is not supported in gRPC
-service "Service" gRPC endpoint "Method": Array element type is Any type which is not supported in gRPC
-service "Service" gRPC endpoint "Method": Error "invalid_error_type" type is Any type which is not supported in gRPC
-service "Service" gRPC endpoint "Method": Map element type is Any type which is not supported in gRPC`,
-			},
+			DSL:    testdata.GRPCEndpointWithAnyType,
+			Errors: []string{`service "Service" gRPC endpoint "Method": Map key type is Any type which is not supported in gRPC`},
 		},
 		"endpoint-with-untagged-fields": {
 			DSL: testdata.GRPCEndpointWithUntaggedFields,
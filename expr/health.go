@@ -0,0 +1,203 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HealthExpr describes the health checks of a service. The generated
+	// HTTP servers serve the liveness and readiness probes and the
+	// generated gRPC servers register the checks with the standard gRPC
+	// health service.
+	HealthExpr struct {
+		// LivenessPath is the HTTP path of the liveness probe.
+		LivenessPath string
+		// ReadinessPath is the HTTP path of the readiness probe.
+		ReadinessPath string
+		// Checks lists the dependency checks run by the readiness probe.
+		Checks []*HealthCheckExpr
+		// Parent is the API or service expression that defines the
+		// health checks.
+		Parent eval.Expression
+	}
+
+	// HealthCheckExpr describes a named dependency check.
+	HealthCheckExpr struct {
+		// Name is the name of the checked dependency.
+		Name string
+		// Description describes the dependency.
+		Description string
+		// Health is the health expression that defines the check.
+		Health *HealthExpr
+	}
+)
+
+const (
+	// DefaultLivenessPath is the default HTTP path of the liveness probe.
+	DefaultLivenessPath = "/healthz"
+	// DefaultReadinessPath is the default HTTP path of the readiness probe.
+	DefaultReadinessPath = "/readyz"
+)
+
+// NewHealthExpr returns a health expression that uses the default probe
+// paths.
+func NewHealthExpr(parent eval.Expression) *HealthExpr {
+	return &HealthExpr{
+		LivenessPath:  DefaultLivenessPath,
+		ReadinessPath: DefaultReadinessPath,
+		Parent:        parent,
+	}
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (h *HealthExpr) EvalName() string {
+	var prefix string
+	if h.Parent != nil {
+		prefix = h.Parent.EvalName() + " "
+	}
+	return prefix + "health"
+}
+
+// Shared returns true if the health checks are defined in the API and shared
+// by the services that do not define their own.
+func (h *HealthExpr) Shared() bool {
+	_, ok := h.Parent.(*APIExpr)
+	return ok
+}
+
+// Check returns the check with the given name if any.
+func (h *HealthExpr) Check(name string) *HealthCheckExpr {
+	for _, c := range h.Checks {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Validate makes sure the probe paths are valid and distinct and that the
+// check names are unique and do not clash with the methods of the service s
+// once converted to the names of the generated functions (Check followed by
+// the check name in CamelCase).
+func (h *HealthExpr) Validate(s *ServiceExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	for _, p := range []string{h.LivenessPath, h.ReadinessPath} {
+		if !strings.HasPrefix(p, "/") {
+			verr.Add(h, "probe path %q must start with /", p)
+		}
+	}
+	if h.LivenessPath == h.ReadinessPath {
+		verr.Add(h, "liveness and readiness probes cannot use the same path %q", h.LivenessPath)
+	}
+	seen := make(map[string]string)
+	for _, c := range h.Checks {
+		if healthIdent(c.Name) == "" {
+			verr.Add(c, "check name must contain letters or digits")
+			continue
+		}
+		fn := "check" + healthIdent(c.Name)
+		if other, ok := seen[fn]; ok {
+			verr.Add(c, "check name conflicts with check %q", other)
+			continue
+		}
+		seen[fn] = c.Name
+		for _, m := range s.Methods {
+			if healthIdent(m.Name) == fn {
+				verr.Add(c, "check function conflicts with method %q of %s", m.Name, s.EvalName())
+			}
+		}
+	}
+	return verr
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (c *HealthCheckExpr) EvalName() string {
+	var prefix string
+	if c.Health != nil {
+		prefix = c.Health.EvalName() + " "
+	}
+	return prefix + "check " + c.Name
+}
+
+// healthIdent returns the lower case letters and digits of name which
+// identify the Go function generated for name.
+func healthIdent(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+// SharedHTTPHealth returns the API health checks if at least one service
+// served over HTTP inherits them, nil otherwise. The probes of the shared
+// checks are mounted once by the HTTP servers instead of once per service.
+func (r *RootExpr) SharedHTTPHealth() *HealthExpr {
+	if r.API == nil || r.API.Health == nil || r.API.HTTP == nil {
+		return nil
+	}
+	for _, s := range r.Services {
+		if s.health() == r.API.Health && r.API.HTTP.Service(s.Name) != nil {
+			return r.API.Health
+		}
+	}
+	return nil
+}
+
+// validateHealthPaths makes sure that the services served over HTTP that
+// define their own health checks use distinct probe paths and that the probe
+// paths do not clash with the GET routes of the endpoints. The probes of the
+// API health checks are served once for all the services that inherit them.
+func validateHealthPaths(r *RootExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if r.API == nil || r.API.HTTP == nil {
+		return verr
+	}
+	var shared []string
+	if h := r.SharedHTTPHealth(); h != nil {
+		shared = []string{h.LivenessPath, h.ReadinessPath}
+	}
+	owner := func(name string) string {
+		if name == "" {
+			return "the API"
+		}
+		return fmt.Sprintf("service %q", name)
+	}
+	paths := make(map[string]string)
+	for _, p := range shared {
+		paths[p] = ""
+	}
+	for _, s := range r.Services {
+		h := s.health()
+		if h == nil || h.Shared() || r.API.HTTP.Service(s.Name) == nil {
+			continue
+		}
+		for _, p := range []string{h.LivenessPath, h.ReadinessPath} {
+			if other, ok := paths[p]; ok && other != s.Name {
+				verr.Add(s, "health probe path %q is already used by %s, use Liveness and Readiness to set distinct paths", p, owner(other))
+				continue
+			}
+			paths[p] = s.Name
+		}
+	}
+	for _, svc := range r.API.HTTP.Services {
+		for _, e := range svc.HTTPEndpoints {
+			for _, route := range e.Routes {
+				if route.Method != "GET" {
+					continue
+				}
+				for _, p := range route.FullPaths() {
+					if other, ok := paths[p]; ok {
+						verr.Add(e, "route GET %q conflicts with the health probe of %s", p, owner(other))
+					}
+				}
+			}
+		}
+	}
+	return verr
+}
@@ -0,0 +1,77 @@
+package expr_test
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestHealthExprFinalize(t *testing.T) {
+	root := expr.RunDSL(t, testdata.HealthDSL)
+	if h := root.SharedHTTPHealth(); h == nil || !h.Shared() {
+		t.Errorf("got shared health %v, expected the API health", h)
+	}
+	cases := []struct {
+		Name      string
+		Service   string
+		Liveness  string
+		Readiness string
+		Checks    []string
+	}{
+		{"inherited", "Inherited", expr.DefaultLivenessPath, expr.DefaultReadinessPath, []string{"database"}},
+		{"also-inherited", "AlsoInherited", expr.DefaultLivenessPath, expr.DefaultReadinessPath, []string{"database"}},
+		{"overridden", "Overridden", "/overridden/healthz", "/overridden/readyz", []string{"cache"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			h := root.Service(c.Service).Health
+			if h == nil {
+				t.Fatal("got nil health")
+			}
+			if h.LivenessPath != c.Liveness {
+				t.Errorf("got liveness path %q, expected %q", h.LivenessPath, c.Liveness)
+			}
+			if h.ReadinessPath != c.Readiness {
+				t.Errorf("got readiness path %q, expected %q", h.ReadinessPath, c.Readiness)
+			}
+			if len(h.Checks) != len(c.Checks) {
+				t.Fatalf("got %d checks, expected %d", len(h.Checks), len(c.Checks))
+			}
+			for i, check := range h.Checks {
+				if check.Name != c.Checks[i] {
+					t.Errorf("got check %q at index %d, expected %q", check.Name, i, c.Checks[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHealthExprValidation(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Expected string
+	}{
+		{"invalid", testdata.InvalidHealthDSL, `service "Service" health: probe path "healthz" must start with /
+service "Service" health: probe path "healthz" must start with /
+service "Service" health: liveness and readiness probes cannot use the same path "healthz"
+service "Service" health check MyDB: check name conflicts with check "my-db"
+service "Service" health check --: check name must contain letters or digits
+service "Service" health check method: check function conflicts with method "CheckMethod" of service "Service"`},
+		{"conflicting", testdata.ConflictingHealthDSL, `service "Service3": health probe path "/svc/healthz" is already used by service "Service2", use Liveness and Readiness to set distinct paths
+service "Service3": health probe path "/readyz" is already used by the API, use Liveness and Readiness to set distinct paths
+service "Service1" HTTP endpoint "Method": route GET "/readyz" conflicts with the health probe of the API`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if err.Error() != c.Expected {
+				t.Errorf("got error %q\nexpected %q", err.Error(), c.Expected)
+			}
+		})
+	}
+}
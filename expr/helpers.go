@@ -17,6 +17,10 @@ func Title(s string) string {
 func findKey(exp eval.Expression, keyAtt string) (string, string) {
 	switch e := exp.(type) {
 	case *HTTPEndpointExpr:
+		if e.Service.IsJSONRPC() {
+			// JSON-RPC requests carry the whole payload in the params.
+			return keyAtt, "body"
+		}
 		if n, exists := e.Params.FindKey(keyAtt); exists {
 			return n, "query"
 		} else if n, exists := e.Headers.FindKey(keyAtt); exists {
@@ -29,6 +29,9 @@ type (
 		Services []*HTTPServiceExpr
 		// Errors lists the error HTTP responses.
 		Errors []*HTTPErrorExpr
+		// Origins lists the CORS policies that apply to all the API
+		// endpoints whose service does not define its own.
+		Origins []*HTTPCORSExpr
 	}
 )
 
@@ -36,6 +39,10 @@ type (
 // parameters.
 var HTTPWildcardRegex = regexp.MustCompile(`/{\*?([a-zA-Z0-9_]+)}`)
 
+// NDJSONContentType is the content type of the bodies of the streaming
+// endpoints that use NDJSON.
+const NDJSONContentType = "application/x-ndjson"
+
 // ExtractHTTPWildcards returns the names of the wildcards that appear in
 // a HTTP path.
 func ExtractHTTPWildcards(path string) []string {
@@ -0,0 +1,108 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPConditionalExpr describes the validators (entity tag and
+	// modification time) of the responses of a HTTP endpoint that supports
+	// conditional requests (RFC 7232).
+	HTTPConditionalExpr struct {
+		// ETagField is the name of the result attribute that holds the
+		// entity tag if any.
+		ETagField string
+		// HashETag is true if the entity tag is computed from the hash
+		// of the encoded response body.
+		HashETag bool
+		// LastModifiedField is the name of the result attribute that
+		// holds the modification time if any.
+		LastModifiedField string
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+)
+
+const (
+	// ETagHeader is the name of the HTTP response header that holds the
+	// entity tag.
+	ETagHeader = "ETag"
+
+	// LastModifiedHeader is the name of the HTTP response header that
+	// holds the modification time.
+	LastModifiedHeader = "Last-Modified"
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (c *HTTPConditionalExpr) EvalName() string {
+	var prefix string
+	if c.Endpoint != nil {
+		prefix = c.Endpoint.EvalName() + " "
+	}
+	return prefix + "conditional requests"
+}
+
+// Prepare maps the entity tag and modification time result attributes to the
+// "ETag" and "Last-Modified" headers of the successful responses unless they
+// are mapped explicitly. Attributes that do not exist are left for Validate to
+// report.
+func (c *HTTPConditionalExpr) Prepare() {
+	result := c.Endpoint.MethodExpr.Result
+	mapHeader := func(r *HTTPResponseExpr, header, field string) {
+		if field == "" || !IsObject(result.Type) || result.Find(field) == nil {
+			return
+		}
+		if _, ok := r.Headers.FindKey(field); ok {
+			return
+		}
+		r.Headers.Type.(*Object).Set(field, &AttributeExpr{Type: String})
+		r.Headers.Map(header, field)
+	}
+	for _, r := range c.Endpoint.Responses {
+		if r.StatusCode >= 300 {
+			continue
+		}
+		mapHeader(r, ETagHeader, c.ETagField)
+		mapHeader(r, LastModifiedHeader, c.LastModifiedField)
+	}
+}
+
+// Validate makes sure the method does not stream and that the attributes used
+// to initialize the validators exist and are strings. The modification time
+// attribute must hold a HTTP date so it may only use the RFC1123 format.
+func (c *HTTPConditionalExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	e := c.Endpoint
+	if e.MethodExpr.IsStreaming() {
+		verr.Add(c, "ETag and LastModified cannot be used when the method defines a StreamingPayload or a StreamingResult.")
+	}
+	if e.SkipResponseBodyEncodeDecode {
+		verr.Add(c, "ETag and LastModified cannot be used with SkipResponseBodyEncodeDecode.")
+	}
+	validateField := func(dsl, name string) *AttributeExpr {
+		if name == "" {
+			return nil
+		}
+		if !IsObject(e.MethodExpr.Result.Type) {
+			verr.Add(c, "%s requires the method result type to be an object.", dsl)
+			return nil
+		}
+		att := e.MethodExpr.Result.Find(name)
+		if att == nil {
+			verr.Add(c, "%s: attribute %q not found in result type.", dsl, name)
+			return nil
+		}
+		if att.Type.Kind() != StringKind {
+			verr.Add(c, "%s: attribute %q must be a String.", dsl, name)
+			return nil
+		}
+		return att
+	}
+	validateField("ETag", c.ETagField)
+	if att := validateField("LastModified", c.LastModifiedField); att != nil {
+		if v := att.Validation; v != nil && v.Format != "" && v.Format != FormatRFC1123 {
+			verr.Add(c, "LastModified: attribute %q must hold a HTTP date, format %q is not supported (use FormatRFC1123).", c.LastModifiedField, v.Format)
+		}
+	}
+	return verr
+}
@@ -0,0 +1,92 @@
+package expr
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPCORSExpr describes the Cross-Origin Resource Sharing (CORS)
+	// policy that applies to requests made from a given origin.
+	HTTPCORSExpr struct {
+		// Origin is the allowed origin, "*" allows any origin. If Regexp
+		// is true Origin is a regular expression matching the allowed
+		// origins.
+		Origin string
+		// Regexp is true if Origin is a regular expression.
+		Regexp bool
+		// Methods lists the HTTP methods allowed when accessing the
+		// resource. An empty list allows the methods of the endpoint
+		// routes.
+		Methods []string
+		// Headers lists the request headers allowed when accessing the
+		// resource.
+		Headers []string
+		// Expose lists the response headers that browsers are allowed
+		// to access.
+		Expose []string
+		// MaxAge is the number of seconds the results of a preflight
+		// request can be cached.
+		MaxAge int
+		// Credentials indicates whether the resource can be accessed
+		// using credentials.
+		Credentials bool
+		// Parent is the API HTTP, service HTTP or endpoint expression
+		// that defines the origin.
+		Parent eval.Expression
+	}
+)
+
+// NewHTTPCORSExpr creates a new CORS expression for the given origin. Origins
+// starting and ending with a slash (e.g. `/^https://.*\.example\.com$/`) are
+// regular expressions.
+func NewHTTPCORSExpr(origin string, parent eval.Expression) *HTTPCORSExpr {
+	cors := &HTTPCORSExpr{Origin: origin, Parent: parent}
+	if l := len(origin); l > 1 && origin[0] == '/' && origin[l-1] == '/' {
+		cors.Origin = origin[1 : l-1]
+		cors.Regexp = true
+	}
+	return cors
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (c *HTTPCORSExpr) EvalName() string {
+	var prefix string
+	if c.Parent != nil {
+		prefix = c.Parent.EvalName() + " "
+	}
+	return prefix + "CORS origin " + c.Origin
+}
+
+// Validate makes sure the origin regular expression compiles, that the
+// methods are valid HTTP methods and that wildcard origins do not allow
+// credentials.
+func (c *HTTPCORSExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if c.Origin == "" {
+		verr.Add(c, "origin cannot be empty")
+	}
+	if c.Regexp {
+		if _, err := regexp.Compile(c.Origin); err != nil {
+			verr.Add(c, "invalid origin regular expression: %s", err)
+		}
+	}
+	if c.Origin == "*" && c.Credentials {
+		verr.Add(c, "AllowCredentials cannot be used with the wildcard origin \"*\"")
+	}
+	for _, m := range c.Methods {
+		switch strings.ToUpper(m) {
+		case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+			http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		default:
+			verr.Add(c, "invalid HTTP method %q", m)
+		}
+	}
+	if c.MaxAge < 0 {
+		verr.Add(c, "MaxAge cannot be negative, got %d", c.MaxAge)
+	}
+	return verr
+}
@@ -0,0 +1,73 @@
+package expr_test
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestHTTPCORSExprOrigin(t *testing.T) {
+	cases := map[string]struct {
+		origin   string
+		expected string
+		regexp   bool
+	}{
+		"literal":  {origin: "https://example.com", expected: "https://example.com"},
+		"wildcard": {origin: "*", expected: "*"},
+		"regexp":   {origin: `/.*\.example\.com/`, expected: `.*\.example\.com`, regexp: true},
+		"slash":    {origin: "/", expected: "/"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			c := expr.NewHTTPCORSExpr(tc.origin, nil)
+			if c.Origin != tc.expected {
+				t.Errorf("got origin %q, expected %q", c.Origin, tc.expected)
+			}
+			if c.Regexp != tc.regexp {
+				t.Errorf("got regexp %v, expected %v", c.Regexp, tc.regexp)
+			}
+		})
+	}
+}
+
+func TestHTTPCORSValidation(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.InvalidCORSDSL)
+	expected := `service "InvalidCORS" HTTP endpoint "Method" CORS origin *: AllowCredentials cannot be used with the wildcard origin "*"
+service "InvalidCORS" HTTP endpoint "Method" CORS origin *: invalid HTTP method "FETCH"
+service "InvalidCORS" HTTP endpoint "Method" CORS origin *: MaxAge cannot be negative, got -1
+service "InvalidCORS" HTTP endpoint "Method" CORS origin [: invalid origin regular expression: error parsing regexp: missing closing ]: ` + "`[`"
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err.Error() != expected {
+		t.Errorf("got error %q\nexpected %q", err.Error(), expected)
+	}
+}
+
+func TestHTTPEndpointCORSOrigins(t *testing.T) {
+	cases := map[string]struct {
+		service  string
+		endpoint string
+		expected []string
+	}{
+		"service":  {service: "Service", endpoint: "Inherited", expected: []string{`.*\.example\.com`}},
+		"endpoint": {service: "Service", endpoint: "Overridden", expected: []string{"*"}},
+		"api":      {service: "Other", endpoint: "Method", expected: []string{"https://api.example.com"}},
+	}
+	root := expr.RunDSL(t, testdata.CORSDSL)
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			e := root.API.HTTP.Service(tc.service).Endpoint(tc.endpoint)
+			origins := e.CORSOrigins()
+			if len(origins) != len(tc.expected) {
+				t.Fatalf("got %d origins, expected %d", len(origins), len(tc.expected))
+			}
+			for i, o := range origins {
+				if o.Origin != tc.expected[i] {
+					t.Errorf("got origin %q at index %d, expected %q", o.Origin, i, tc.expected[i])
+				}
+			}
+		})
+	}
+}
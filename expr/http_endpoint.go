@@ -66,6 +66,24 @@ type (
 		MultipartRequest bool
 		// Redirect defines a redirect for the endpoint.
 		Redirect *HTTPRedirectExpr
+		// SSE defines the Server-Sent Events settings of the endpoint if
+		// it streams its results using Server-Sent Events.
+		SSE *HTTPSSEExpr
+		// NDJSON indicates that the endpoint streams its payload and/or
+		// results as newline delimited JSON over the HTTP request and
+		// response bodies instead of a WebSocket connection.
+		NDJSON bool
+		// Origins lists the CORS policies of the endpoint if any.
+		Origins []*HTTPCORSExpr
+		// Idempotent indicates that the endpoint honors the
+		// "Idempotency-Key" request header.
+		Idempotent bool
+		// Conditional defines the validators of the endpoint responses
+		// if it supports conditional requests.
+		Conditional *HTTPConditionalExpr
+		// WebSocket defines the settings of the endpoint websocket
+		// connections if any.
+		WebSocket *HTTPWebSocketExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator, see dsl.Meta.
 		Meta MetaExpr
@@ -101,10 +119,14 @@ func (e *HTTPEndpointExpr) Description() string {
 // EvalName returns the generic expression name used in error messages.
 func (e *HTTPEndpointExpr) EvalName() string {
 	var prefix, suffix string
+	transport := "HTTP"
+	if e.Service != nil && e.Service.IsJSONRPC() {
+		transport = "JSON-RPC"
+	}
 	if e.Name() != "" {
-		suffix = fmt.Sprintf("HTTP endpoint %#v", e.Name())
+		suffix = fmt.Sprintf("%s endpoint %#v", transport, e.Name())
 	} else {
-		suffix = "unnamed HTTP endpoint"
+		suffix = fmt.Sprintf("unnamed %s endpoint", transport)
 	}
 	if e.Service != nil {
 		prefix = e.Service.EvalName() + " "
@@ -122,6 +144,35 @@ func (e *HTTPEndpointExpr) HasAbsoluteRoutes() bool {
 	return true
 }
 
+// CORSOrigins returns the CORS policies that apply to the endpoint: the
+// endpoint origins if any, the service origins otherwise and finally the API
+// origins.
+func (e *HTTPEndpointExpr) CORSOrigins() []*HTTPCORSExpr {
+	if len(e.Origins) > 0 {
+		return e.Origins
+	}
+	if len(e.Service.Origins) > 0 {
+		return e.Service.Origins
+	}
+	return e.Service.API().Origins
+}
+
+// PaginationParam returns the name of the query string parameter that holds
+// the page cursor or offset if the endpoint method is paginated, the empty
+// string otherwise.
+func (e *HTTPEndpointExpr) PaginationParam() string {
+	p := e.MethodExpr.Pagination
+	if p == nil {
+		return ""
+	}
+	name := p.Cursor
+	if !p.IsCursor() {
+		name = p.Offset
+	}
+	key, _ := e.Params.FindKey(name)
+	return key
+}
+
 // PathParams computes a mapped attribute containing the subset of e.Params that
 // describe path parameters.
 func (e *HTTPEndpointExpr) PathParams() *MappedAttributeExpr {
@@ -194,16 +245,17 @@ func (e *HTTPEndpointExpr) Prepare() {
 	}
 
 	// Inherit headers, cookies and params from parent service and API
+	api := e.Service.API()
 	headers := NewEmptyMappedAttributeExpr()
-	headers.Merge(Root.API.HTTP.Headers)
+	headers.Merge(api.Headers)
 	headers.Merge(e.Service.Headers)
 
 	cookies := NewEmptyMappedAttributeExpr()
-	cookies.Merge(Root.API.HTTP.Cookies)
+	cookies.Merge(api.Cookies)
 	cookies.Merge(e.Service.Cookies)
 
 	params := NewEmptyMappedAttributeExpr()
-	params.Merge(Root.API.HTTP.Params)
+	params.Merge(api.Params)
 	params.Merge(e.Service.Params)
 
 	if p := e.Service.Parent(); p != nil {
@@ -244,6 +296,39 @@ func (e *HTTPEndpointExpr) Prepare() {
 	e.Cookies = cookies
 	e.Params = params
 
+	// Map the Last-Event-ID header to the SSE request ID payload attribute.
+	if e.SSE != nil && e.SSE.RequestIDField != "" {
+		if _, ok := e.Headers.FindKey(e.SSE.RequestIDField); !ok {
+			e.Headers.Type.(*Object).Set(e.SSE.RequestIDField, &AttributeExpr{Type: String})
+			e.Headers.Map(SSELastEventIDHeader, e.SSE.RequestIDField)
+		}
+	}
+
+	// Map the pagination payload attributes to query string parameters
+	// unless they are mapped explicitly or the endpoint defines its body.
+	if pg := e.MethodExpr.Pagination; pg != nil && e.Body == nil {
+		for _, name := range []string{pg.Cursor, pg.Offset, pg.Limit} {
+			if name == "" {
+				continue
+			}
+			att := e.MethodExpr.Payload.Find(name)
+			if att == nil {
+				continue
+			}
+			if _, ok := e.Headers.FindKey(name); ok {
+				continue
+			}
+			if _, ok := e.Cookies.FindKey(name); ok {
+				continue
+			}
+			if _, ok := e.Params.FindKey(name); ok {
+				continue
+			}
+			e.Params.Type.(*Object).Set(name, &AttributeExpr{Type: att.Type})
+			e.Params.Map(name, name)
+		}
+	}
+
 	// Initialize path params that are not defined explicitly in
 	for _, r := range e.Routes {
 		for _, p := range r.Params() {
@@ -271,6 +356,24 @@ func (e *HTTPEndpointExpr) Prepare() {
 		e.Responses = []*HTTPResponseExpr{{StatusCode: status}}
 	}
 
+	// Server-Sent Events streams use the text/event-stream content type.
+	if e.SSE != nil {
+		for _, r := range e.Responses {
+			if r.StatusCode < 400 && r.ContentType == "" {
+				r.ContentType = "text/event-stream"
+			}
+		}
+	}
+
+	// NDJSON streams use the application/x-ndjson content type.
+	if e.NDJSON && e.MethodExpr.Result.Type != Empty {
+		for _, r := range e.Responses {
+			if r.StatusCode < 400 && r.ContentType == "" {
+				r.ContentType = NDJSONContentType
+			}
+		}
+	}
+
 	// Error -> ResponseError
 	methodErrors := map[string]struct{}{}
 	for _, v := range e.HTTPErrors {
@@ -293,7 +396,7 @@ func (e *HTTPEndpointExpr) Prepare() {
 			continue
 		}
 		// Lookup undefined HTTP errors in API.
-		for _, v := range Root.API.HTTP.Errors {
+		for _, v := range api.Errors {
 			if me.Name == v.Name {
 				e.HTTPErrors = append(e.HTTPErrors, v.Dup())
 			}
@@ -313,7 +416,7 @@ func (e *HTTPEndpointExpr) Prepare() {
 			}
 		}
 		if !found {
-			for _, ae := range Root.API.HTTP.Errors {
+			for _, ae := range api.Errors {
 				if se.Name == ae.Name {
 					e.HTTPErrors = append(e.HTTPErrors, ae.Dup())
 					break
@@ -329,6 +432,11 @@ func (e *HTTPEndpointExpr) Prepare() {
 	for _, er := range e.HTTPErrors {
 		er.Response.Prepare()
 	}
+
+	// Map the validators of conditional requests to response headers.
+	if e.Conditional != nil {
+		e.Conditional.Prepare()
+	}
 }
 
 // Validate validates the endpoint expression.
@@ -374,6 +482,56 @@ func (e *HTTPEndpointExpr) Validate() error {
 		}
 	}
 
+	// Paginated methods need the decoded payload and result.
+	if e.MethodExpr.Pagination != nil && (e.SkipRequestBodyEncodeDecode || e.SkipResponseBodyEncodeDecode) {
+		verr.Add(e, "Endpoint of a paginated method cannot use SkipRequestBodyEncodeDecode or SkipResponseBodyEncodeDecode.")
+	}
+
+	for _, o := range e.Origins {
+		verr.Merge(o.Validate())
+	}
+
+	// Idempotent records whole responses and is not compatible with
+	// streaming.
+	if e.Idempotent {
+		if e.MethodExpr.IsStreaming() {
+			verr.Add(e, "Endpoint cannot use Idempotent when method defines a StreamingPayload or a StreamingResult.")
+		}
+		if e.SkipRequestBodyEncodeDecode || e.SkipResponseBodyEncodeDecode {
+			verr.Add(e, "Endpoint cannot use Idempotent with SkipRequestBodyEncodeDecode or SkipResponseBodyEncodeDecode.")
+		}
+	}
+
+	if e.Conditional != nil {
+		verr.Merge(e.Conditional.Validate())
+	}
+
+	// WebSocket settings only apply to endpoints that use websockets.
+	if e.WebSocket != nil {
+		if !e.IsWebSocket() {
+			verr.Add(e, "Endpoint cannot use WebSocket when method does not define a StreamingPayload or a StreamingResult or when using ServerSentEvents or NDJSON.")
+		}
+		verr.Merge(e.WebSocket.Validate())
+	}
+
+	// ServerSentEvents is only compatible with streaming results.
+	if e.SSE != nil {
+		verr.Merge(e.SSE.Validate())
+		if e.SkipResponseBodyEncodeDecode {
+			verr.Add(e, "Endpoint cannot use SkipResponseBodyEncodeDecode when using ServerSentEvents.")
+		}
+	}
+
+	// NDJSON replaces the WebSocket connection of streaming endpoints.
+	if e.NDJSON {
+		if !e.MethodExpr.IsStreaming() {
+			verr.Add(e, "Endpoint cannot use NDJSON when method does not define a StreamingPayload or a StreamingResult.")
+		}
+		if e.SSE != nil {
+			verr.Add(e, "Endpoint cannot use both NDJSON and ServerSentEvents.")
+		}
+	}
+
 	// Redirect is not compatible with Response.
 	if e.Redirect != nil {
 		found := false
@@ -640,11 +798,16 @@ func (e *HTTPEndpointExpr) Validate() error {
 	if e.SkipRequestBodyEncodeDecode && body.Type != Empty {
 		verr.Add(e, "HTTP endpoint request body must be empty when using SkipRequestBodyEncodeDecode but not all method payload attributes are mapped to headers and params. Make sure to define Headers and Params as needed.")
 	}
-	if e.MethodExpr.IsStreaming() && body.Type != Empty {
+	if e.IsWebSocket() && body.Type != Empty && !e.Service.IsJSONRPC() {
 		// Refer Websocket protocol - https://tools.ietf.org/html/rfc6455
-		// Protocol does not allow HTTP request body to be passed.
+		// Protocol does not allow HTTP request body to be passed. JSON-RPC
+		// sends the payload in the first websocket message instead.
 		verr.Add(e, "HTTP endpoint request body must be empty when the endpoint uses streaming. Payload attributes must be mapped to headers and/or params.")
 	}
+	if e.NDJSON && e.MethodExpr.IsPayloadStreaming() && body.Type != Empty {
+		// The request body carries the streaming payload.
+		verr.Add(e, "HTTP endpoint request body must be empty when the endpoint uses NDJSON and defines a StreamingPayload. Payload attributes must be mapped to headers and/or params.")
+	}
 
 	return verr
 }
@@ -666,6 +829,10 @@ func (e *HTTPEndpointExpr) Finalize() {
 				case NoKind:
 					continue
 				case BasicAuthKind:
+					if e.Service.IsJSONRPC() {
+						sch.In = "body"
+						continue
+					}
 					sch.In = "header"
 					sch.Name = "Authorization"
 					continue
@@ -919,7 +1086,7 @@ func (r *RouteExpr) Validate() *eval.ValidationErrors {
 	}
 
 	// For streaming endpoints, websockets does not support verbs other than GET
-	if r.Endpoint.MethodExpr.IsStreaming() && len(r.Endpoint.Responses) > 0 {
+	if r.Endpoint.IsWebSocket() && len(r.Endpoint.Responses) > 0 {
 		if r.Method != "GET" {
 			verr.Add(r, "WebSocket endpoint supports only \"GET\" method. Got %q.", r.Method)
 		}
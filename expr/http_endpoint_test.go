@@ -177,6 +177,47 @@ service "Service" HTTP endpoint "Method": HTTP endpoint response body must be em
 service "Service" HTTP endpoint "MethodB": HTTP endpoint request body must be empty when the endpoint uses streaming. Payload attributes must be mapped to headers and/or params.
 service "Service" HTTP endpoint "MethodC": HTTP endpoint request body must be empty when the endpoint uses streaming. Payload attributes must be mapped to headers and/or params.`,
 		},
+		"endpoint-sse": {
+			DSL: testdata.EndpointSSE,
+		},
+		"endpoint-sse-not-streaming": {
+			DSL:   testdata.EndpointSSENotStreaming,
+			Error: `service "Service" HTTP endpoint "Method" server sent events: ServerSentEvents requires the method to define a StreamingResult and no StreamingPayload.`,
+		},
+		"endpoint-sse-invalid-fields": {
+			DSL: testdata.EndpointSSEInvalidFields,
+			Error: `service "Service" HTTP endpoint "Method" server sent events: SSEEventID: attribute "id" must be a String.
+service "Service" HTTP endpoint "Method" server sent events: SSEEventType: attribute "kind" not found in result type.
+service "Service" HTTP endpoint "Method" server sent events: SSEEventRetry: attribute "retry" must be an integer.`,
+		},
+		"endpoint-ndjson": {
+			DSL: testdata.EndpointNDJSON,
+		},
+		"endpoint-ndjson-invalid": {
+			DSL: testdata.EndpointNDJSONInvalid,
+			Error: `service "Service" HTTP endpoint "NotStreaming": Endpoint cannot use NDJSON when method does not define a StreamingPayload or a StreamingResult.
+service "Service" HTTP endpoint "SSE": Endpoint cannot use both NDJSON and ServerSentEvents.
+service "Service" HTTP endpoint "Body": HTTP endpoint request body must be empty when the endpoint uses NDJSON and defines a StreamingPayload. Payload attributes must be mapped to headers and/or params.`,
+		},
+		"endpoint-idempotent": {
+			DSL: testdata.EndpointIdempotent,
+		},
+		"endpoint-idempotent-streaming": {
+			DSL: testdata.EndpointIdempotentStreaming,
+			Error: `service "Service" HTTP endpoint "Method": Endpoint cannot use Idempotent when method defines a StreamingPayload or a StreamingResult.
+service "Service" HTTP endpoint "MethodB": Endpoint cannot use Idempotent with SkipRequestBodyEncodeDecode or SkipResponseBodyEncodeDecode.`,
+		},
+		"endpoint-conditional": {
+			DSL: testdata.EndpointConditional,
+		},
+		"endpoint-conditional-invalid": {
+			DSL: testdata.EndpointConditionalInvalid,
+			Error: `service "Service" HTTP endpoint "Method" conditional requests: ETag: attribute "version" must be a String.
+service "Service" HTTP endpoint "Method" conditional requests: LastModified: attribute "updated_at" not found in result type.
+service "Service" HTTP endpoint "MethodB" conditional requests: ETag requires the method result type to be an object.
+service "Service" HTTP endpoint "MethodC" conditional requests: ETag and LastModified cannot be used when the method defines a StreamingPayload or a StreamingResult.
+service "Service" HTTP endpoint "MethodD" conditional requests: LastModified: attribute "updated_at" must hold a HTTP date, format "date-time" is not supported (use FormatRFC1123).`,
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -262,6 +303,21 @@ func TestHTTPEndpointFinalization(t *testing.T) {
 	}
 }
 
+func TestHTTPEndpointNDJSON(t *testing.T) {
+	root := expr.RunDSL(t, testdata.EndpointNDJSON)
+	for _, e := range root.API.HTTP.Services[0].HTTPEndpoints {
+		if e.IsWebSocket() {
+			t.Errorf("%s: expected endpoint not to use a websocket connection", e.Name())
+		}
+		if e.WebSocketSettings() != nil {
+			t.Errorf("%s: expected no websocket settings", e.Name())
+		}
+		if ct := e.Responses[0].ContentType; ct != expr.NDJSONContentType {
+			t.Errorf("%s: got response content type %q, expected %q", e.Name(), ct, expr.NDJSONContentType)
+		}
+	}
+}
+
 func TestHTTPAuthorizationMapping(t *testing.T) {
 	cases := []struct {
 		Name           string
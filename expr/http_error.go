@@ -20,12 +20,41 @@ type (
 
 // EvalName returns the generic definition name used in error messages.
 func (e *HTTPErrorExpr) EvalName() string {
+	if e.isJSONRPC() {
+		return "JSON-RPC error " + e.Name
+	}
 	return "HTTP error " + e.Name
 }
 
 // Validate makes sure there is a error expression that matches the HTTP error
-// expression.
+// expression. The API level JSON-RPC errors are copied to each JSON-RPC
+// service and endpoint, they are validated once by the JSON-RPC expression
+// instead.
 func (e *HTTPErrorExpr) Validate() *eval.ValidationErrors {
+	if _, ok := e.Response.Parent.(*JSONRPCExpr); ok {
+		return nil
+	}
+	return e.validate()
+}
+
+// isJSONRPC returns true if the error is defined by a JSON-RPC expression.
+func (e *HTTPErrorExpr) isJSONRPC() bool {
+	if e.Response == nil {
+		return false
+	}
+	switch p := e.Response.Parent.(type) {
+	case *JSONRPCExpr:
+		return true
+	case *HTTPServiceExpr:
+		return p.IsJSONRPC()
+	case *HTTPEndpointExpr:
+		return p.Service != nil && p.Service.IsJSONRPC()
+	}
+	return false
+}
+
+// validate implements Validate.
+func (e *HTTPErrorExpr) validate() *eval.ValidationErrors {
 	verr := new(eval.ValidationErrors)
 	switch p := e.Response.Parent.(type) {
 	case *HTTPEndpointExpr:
@@ -36,7 +65,7 @@ func (e *HTTPErrorExpr) Validate() *eval.ValidationErrors {
 		if p.Error(e.Name) == nil {
 			verr.Add(e, "Error %#v does not match an error defined in the service", e.Name)
 		}
-	case *RootExpr:
+	case *RootExpr, *JSONRPCExpr:
 		if Root.Error(e.Name) == nil {
 			verr.Add(e, "Error %#v does not match an error defined in the API", e.Name)
 		}
@@ -48,7 +77,7 @@ func (e *HTTPErrorExpr) Validate() *eval.ValidationErrors {
 		ee = p.MethodExpr.Error(e.Name)
 	case *HTTPServiceExpr:
 		ee = p.Error(e.Name)
-	case *RootExpr:
+	case *RootExpr, *JSONRPCExpr:
 		ee = Root.Error(e.Name)
 	}
 
@@ -92,7 +121,7 @@ func (e *HTTPErrorExpr) Finalize(a *HTTPEndpointExpr) {
 		ee = p.MethodExpr.Error(e.Name)
 	case *HTTPServiceExpr:
 		ee = p.Error(e.Name)
-	case *RootExpr:
+	case *RootExpr, *JSONRPCExpr:
 		ee = Root.Error(e.Name)
 	}
 	e.ErrorExpr = ee
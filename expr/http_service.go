@@ -40,6 +40,13 @@ type (
 		HTTPErrors []*HTTPErrorExpr
 		// FileServers is the list of static asset serving endpoints
 		FileServers []*HTTPFileServerExpr
+		// Origins lists the CORS policies that apply to all the service
+		// endpoints that do not define their own.
+		Origins []*HTTPCORSExpr
+		// WebSocket defines the settings of the websocket connections
+		// of the service streaming endpoints that do not define their
+		// own.
+		WebSocket *HTTPWebSocketExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Meta MetaExpr
@@ -104,7 +111,7 @@ func (svc *HTTPServiceExpr) CanonicalEndpoint() *HTTPEndpointExpr {
 // API and parent service base paths as needed.
 func (svc *HTTPServiceExpr) FullPaths() []string {
 	if len(svc.Paths) == 0 {
-		return []string{path.Join(Root.API.HTTP.Path)}
+		return []string{path.Join(svc.API().Path)}
 	}
 	var paths []string
 	for _, p := range svc.Paths {
@@ -127,7 +134,7 @@ func (svc *HTTPServiceExpr) FullPaths() []string {
 				}
 			}
 		} else {
-			basePaths = []string{Root.API.HTTP.Path}
+			basePaths = []string{svc.API().Path}
 		}
 		for _, base := range basePaths {
 			v := httppath.Clean(path.Join(base, p))
@@ -144,13 +151,36 @@ func (svc *HTTPServiceExpr) FullPaths() []string {
 // Parent returns the parent service if any, nil otherwise.
 func (svc *HTTPServiceExpr) Parent() *HTTPServiceExpr {
 	if svc.ParentName != "" {
-		if parent := Root.API.HTTP.Service(svc.ParentName); parent != nil {
+		if parent := svc.API().Service(svc.ParentName); parent != nil {
 			return parent
 		}
 	}
 	return nil
 }
 
+// API returns the API level expression of the transport that exposes the
+// service: the JSON-RPC expression for JSON-RPC services and the HTTP
+// expression otherwise.
+func (svc *HTTPServiceExpr) API() *HTTPExpr {
+	if svc.IsJSONRPC() {
+		return &Root.API.JSONRPC.HTTPExpr
+	}
+	return Root.API.HTTP
+}
+
+// IsJSONRPC returns true if the service is exposed via the JSON-RPC transport.
+func (svc *HTTPServiceExpr) IsJSONRPC() bool {
+	if Root.API.JSONRPC == nil {
+		return false
+	}
+	for _, s := range Root.API.JSONRPC.Services {
+		if s == svc {
+			return true
+		}
+	}
+	return false
+}
+
 // HTTPError returns the service HTTP error with given name if any.
 func (svc *HTTPServiceExpr) HTTPError(name string) *HTTPErrorExpr {
 	for _, erro := range svc.HTTPErrors {
@@ -181,7 +211,7 @@ func (svc *HTTPServiceExpr) Prepare() {
 			}
 		}
 		if !found {
-			for _, herr := range Root.API.HTTP.Errors {
+			for _, herr := range svc.API().Errors {
 				if herr.Name == err.Name {
 					svc.HTTPErrors = append(svc.HTTPErrors, herr.Dup())
 				}
@@ -203,7 +233,7 @@ func (svc *HTTPServiceExpr) Validate() error {
 		verr.Merge(svc.Headers.Validate("headers", svc))
 	}
 	if n := svc.ParentName; n != "" {
-		if p := Root.API.HTTP.Service(n); p == nil {
+		if p := svc.API().Service(n); p == nil {
 			verr.Add(svc, "Parent service %s not found", n)
 		} else {
 			if p.CanonicalEndpoint() == nil {
@@ -220,11 +250,19 @@ func (svc *HTTPServiceExpr) Validate() error {
 		}
 	}
 
+	for _, o := range svc.Origins {
+		verr.Merge(o.Validate())
+	}
+
+	if svc.WebSocket != nil {
+		verr.Merge(svc.WebSocket.Validate())
+	}
+
 	// Validate errors (have status codes and bodies are valid)
 	for _, er := range svc.HTTPErrors {
 		verr.Merge(er.Validate())
 	}
-	for _, er := range Root.API.HTTP.Errors {
+	for _, er := range svc.API().Errors {
 		// This may result in the same error being validated multiple
 		// times however service is the top level expression being
 		// walked and errors cannot be walked until all expressions have
@@ -0,0 +1,87 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPSSEExpr describes a HTTP endpoint that streams its results using
+	// Server-Sent Events (text/event-stream) instead of a WebSocket
+	// connection.
+	HTTPSSEExpr struct {
+		// IDField is the name of the result attribute used to set the
+		// event "id" field if any.
+		IDField string
+		// EventField is the name of the result attribute used to set the
+		// event "event" (type) field if any.
+		EventField string
+		// RetryField is the name of the result attribute used to set the
+		// event "retry" field if any.
+		RetryField string
+		// RequestIDField is the name of the payload attribute initialized
+		// from the "Last-Event-ID" request header if any.
+		RequestIDField string
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+)
+
+// SSELastEventIDHeader is the name of the HTTP header sent by clients that
+// resume a Server-Sent Events stream.
+const SSELastEventIDHeader = "Last-Event-ID"
+
+// EvalName returns the generic definition name used in error messages.
+func (s *HTTPSSEExpr) EvalName() string {
+	var prefix string
+	if s.Endpoint != nil {
+		prefix = s.Endpoint.EvalName() + " "
+	}
+	return prefix + "server sent events"
+}
+
+// Validate makes sure the endpoint method streams results and that the
+// attributes used to initialize the event fields exist and have the proper
+// types.
+func (s *HTTPSSEExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	m := s.Endpoint.MethodExpr
+	if m.Stream != ServerStreamKind {
+		verr.Add(s, "ServerSentEvents requires the method to define a StreamingResult and no StreamingPayload.")
+		return verr
+	}
+	if s.IDField != "" || s.EventField != "" || s.RetryField != "" {
+		if !IsObject(m.Result.Type) {
+			verr.Add(s, "SSEEventID, SSEEventType and SSEEventRetry require the method result type to be an object.")
+			return verr
+		}
+	}
+	validateField := func(dsl, name, typ string, kinds ...Kind) {
+		if name == "" {
+			return
+		}
+		att := m.Result.Find(name)
+		if att == nil {
+			verr.Add(s, "%s: attribute %q not found in result type.", dsl, name)
+			return
+		}
+		for _, k := range kinds {
+			if att.Type.Kind() == k {
+				return
+			}
+		}
+		verr.Add(s, "%s: attribute %q must be %s.", dsl, name, typ)
+	}
+	validateField("SSEEventID", s.IDField, "a String", StringKind)
+	validateField("SSEEventType", s.EventField, "a String", StringKind)
+	validateField("SSEEventRetry", s.RetryField, "an integer", IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind)
+	if s.RequestIDField != "" {
+		if !IsObject(m.Payload.Type) {
+			verr.Add(s, "SSERequestID requires the method payload type to be an object.")
+		} else if att := m.Payload.Find(s.RequestIDField); att == nil {
+			verr.Add(s, "SSERequestID: attribute %q not found in payload type.", s.RequestIDField)
+		} else if att.Type.Kind() != StringKind {
+			verr.Add(s, "SSERequestID: attribute %q must be of type String.", s.RequestIDField)
+		}
+	}
+	return verr
+}
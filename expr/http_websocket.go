@@ -0,0 +1,85 @@
+package expr
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPWebSocketExpr describes the settings of the websocket connections
+	// used by the streaming endpoints of a HTTP service.
+	HTTPWebSocketExpr struct {
+		// PingInterval is the interval between two pings sent to the
+		// peer, zero disables pings.
+		PingInterval time.Duration
+		// PongTimeout is the maximum delay after a ping for receiving
+		// the pong, zero disables the read deadline.
+		PongTimeout time.Duration
+		// MaxMessageSize is the maximum size in bytes of the messages
+		// read from the peer, zero means no limit.
+		MaxMessageSize int64
+		// WriteTimeout is the maximum time allowed to write a message,
+		// zero means no limit.
+		WriteTimeout time.Duration
+		// Reconnect is the policy used by the clients of server
+		// streaming endpoints to re-dial dropped connections if any.
+		Reconnect *RetryExpr
+		// Parent is the HTTP service or endpoint expression that
+		// defines the settings.
+		Parent eval.Expression
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (w *HTTPWebSocketExpr) EvalName() string {
+	var prefix string
+	if w.Parent != nil {
+		prefix = w.Parent.EvalName() + " "
+	}
+	return prefix + "websocket"
+}
+
+// Validate makes sure the settings are consistent.
+func (w *HTTPWebSocketExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if w.PingInterval < 0 || w.PongTimeout < 0 || w.WriteTimeout < 0 {
+		verr.Add(w, "PingInterval, PongTimeout and WriteTimeout durations cannot be negative")
+	}
+	if w.PongTimeout > 0 && w.PingInterval == 0 {
+		verr.Add(w, "PongTimeout requires PingInterval")
+	}
+	if w.MaxMessageSize < 0 {
+		verr.Add(w, "MaxMessageSize cannot be negative, got %d", w.MaxMessageSize)
+	}
+	if r := w.Reconnect; r != nil {
+		if e, ok := w.Parent.(*HTTPEndpointExpr); ok && e.MethodExpr.Stream != ServerStreamKind {
+			verr.Add(w, "Reconnect requires the method to define a StreamingResult and no StreamingPayload.")
+		}
+		if r.HedgeDelay > 0 || len(r.Errors) > 0 || len(r.StatusCodes) > 0 || r.IdempotentOnly {
+			verr.Add(w, "Reconnect only supports MaxAttempts, Backoff and Jitter.")
+		}
+		verr.Merge(r.Validate())
+	}
+	return verr
+}
+
+// WebSocketSettings returns the websocket settings that apply to the endpoint:
+// the endpoint settings if any, the service settings otherwise. It returns nil
+// if the endpoint does not use a websocket connection.
+func (e *HTTPEndpointExpr) WebSocketSettings() *HTTPWebSocketExpr {
+	if !e.IsWebSocket() {
+		return nil
+	}
+	if e.WebSocket != nil {
+		return e.WebSocket
+	}
+	return e.Service.WebSocket
+}
+
+// IsWebSocket returns true if the endpoint streams its payload or results
+// using a websocket connection, that is if the method defines a streaming
+// payload or result and the endpoint does not use ServerSentEvents or NDJSON.
+func (e *HTTPEndpointExpr) IsWebSocket() bool {
+	return e.MethodExpr.IsStreaming() && e.SSE == nil && !e.NDJSON
+}
@@ -0,0 +1,54 @@
+package expr_test
+
+import (
+	"testing"
+	"time"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestHTTPWebSocketValidation(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.InvalidWebSocketDSL)
+	expected := `service "InvalidWebSocket" HTTP endpoint "Unary": Endpoint cannot use WebSocket when method does not define a StreamingPayload or a StreamingResult or when using ServerSentEvents or NDJSON.
+service "InvalidWebSocket" HTTP endpoint "Unary" websocket: MaxMessageSize cannot be negative, got -1
+service "InvalidWebSocket" HTTP endpoint "Chat" websocket: PongTimeout requires PingInterval
+service "InvalidWebSocket" HTTP endpoint "Chat" websocket: Reconnect requires the method to define a StreamingResult and no StreamingPayload.
+service "InvalidWebSocket" HTTP endpoint "Chat" websocket: Reconnect only supports MaxAttempts, Backoff and Jitter.`
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err.Error() != expected {
+		t.Errorf("got error %q\nexpected %q", err.Error(), expected)
+	}
+}
+
+func TestHTTPEndpointWebSocketSettings(t *testing.T) {
+	cases := map[string]struct {
+		endpoint string
+		expected time.Duration
+		none     bool
+	}{
+		"service":  {endpoint: "Inherited", expected: 30 * time.Second},
+		"endpoint": {endpoint: "Overridden", expected: 10 * time.Second},
+		"unary":    {endpoint: "Unary", none: true},
+	}
+	root := expr.RunDSL(t, testdata.WebSocketDSL)
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			ws := root.API.HTTP.Service("Service").Endpoint(tc.endpoint).WebSocketSettings()
+			if tc.none {
+				if ws != nil {
+					t.Errorf("got settings %+v, expected none", ws)
+				}
+				return
+			}
+			if ws == nil {
+				t.Fatal("got no settings")
+			}
+			if ws.PingInterval != tc.expected {
+				t.Errorf("got ping interval %s, expected %s", ws.PingInterval, tc.expected)
+			}
+		})
+	}
+}
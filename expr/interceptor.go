@@ -0,0 +1,165 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// InterceptorExpr describes an interceptor: a function that runs around
+	// the service endpoints (server interceptors) or the client endpoints
+	// (client interceptors) and that may read or write the payload and
+	// result attributes listed in the design.
+	InterceptorExpr struct {
+		// Name is the interceptor name.
+		Name string
+		// Description is the interceptor description.
+		Description string
+		// ReadPayload lists the payload attributes read by the
+		// interceptor.
+		ReadPayload *AttributeExpr
+		// WritePayload lists the payload attributes written by the
+		// interceptor.
+		WritePayload *AttributeExpr
+		// ReadResult lists the result attributes read by the
+		// interceptor.
+		ReadResult *AttributeExpr
+		// WriteResult lists the result attributes written by the
+		// interceptor.
+		WriteResult *AttributeExpr
+		// DSLFunc contains the DSL used to initialize the expression.
+		DSLFunc func()
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (i *InterceptorExpr) EvalName() string {
+	return fmt.Sprintf("interceptor %q", i.Name)
+}
+
+// DSL returns the interceptor DSL.
+func (i *InterceptorExpr) DSL() func() {
+	return i.DSLFunc
+}
+
+// HasPayloadAccess returns true if the interceptor reads or writes payload
+// attributes.
+func (i *InterceptorExpr) HasPayloadAccess() bool {
+	return hasAttributes(i.ReadPayload) || hasAttributes(i.WritePayload)
+}
+
+// HasResultAccess returns true if the interceptor reads or writes result
+// attributes.
+func (i *InterceptorExpr) HasResultAccess() bool {
+	return hasAttributes(i.ReadResult) || hasAttributes(i.WriteResult)
+}
+
+// validateMethod makes sure the payload and result of m define the attributes
+// accessed by the interceptor.
+func (i *InterceptorExpr) validateMethod(m *MethodExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if (i.HasPayloadAccess() || i.HasResultAccess()) && m.IsStreaming() {
+		verr.Add(m, "%s cannot access the payload or result of streaming method %q", i.EvalName(), m.Name)
+		return verr
+	}
+	check := func(att, target *AttributeExpr, kind string) {
+		if !hasAttributes(att) {
+			return
+		}
+		if !IsObject(target.Type) {
+			verr.Add(m, "%s accesses %s attributes but the %s of method %q is not an object", i.EvalName(), kind, kind, m.Name)
+			return
+		}
+		for _, nat := range *AsObject(att.Type) {
+			if target.Find(nat.Name) == nil {
+				verr.Add(m, "%s accesses %s attribute %q not defined in method %q", i.EvalName(), kind, nat.Name, m.Name)
+			}
+		}
+	}
+	check(i.ReadPayload, m.Payload, "payload")
+	check(i.WritePayload, m.Payload, "payload")
+	if i.HasResultAccess() {
+		if _, ok := m.Result.Type.(*ResultTypeExpr); ok {
+			verr.Add(m, "%s cannot access the result of method %q: results defined with ResultType are not supported, use Type instead", i.EvalName(), m.Name)
+			return verr
+		}
+	}
+	check(i.ReadResult, m.Result, "result")
+	check(i.WriteResult, m.Result, "result")
+	return verr
+}
+
+// hasAttributes returns true if att is an object with at least one attribute.
+func hasAttributes(att *AttributeExpr) bool {
+	if att == nil {
+		return false
+	}
+	obj := AsObject(att.Type)
+	return obj != nil && len(*obj) > 0
+}
+
+// validateInterceptors makes sure the interceptor names are unique and that
+// the attributes accessed by each interceptor have the same type in all the
+// methods the interceptor applies to so that the generated accessors are
+// consistent.
+func validateInterceptors(r *RootExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	names := make(map[string]struct{})
+	for _, i := range r.Interceptors {
+		if _, ok := names[i.Name]; ok {
+			verr.Add(i, "name is used by multiple interceptors")
+		}
+		names[i.Name] = struct{}{}
+	}
+	seen := make(map[string]string)
+	for _, s := range r.Services {
+		for _, m := range s.Methods {
+			if m.IsStreaming() {
+				continue
+			}
+			for _, i := range appendInterceptors(m.AllServerInterceptors(), m.AllClientInterceptors()...) {
+				check := func(att, target *AttributeExpr, kind string) {
+					if !hasAttributes(att) || !IsObject(target.Type) {
+						return
+					}
+					for _, nat := range *AsObject(att.Type) {
+						ta := target.Find(nat.Name)
+						if ta == nil {
+							continue
+						}
+						key := i.Name + "::" + kind + "::" + nat.Name
+						if h, ok := seen[key]; ok && h != ta.Type.Hash() {
+							verr.Add(i, "%s attribute %q must have the same type in all the methods the interceptor applies to, got a different type in method %q of service %q", kind, nat.Name, m.Name, s.Name)
+							continue
+						}
+						seen[key] = ta.Type.Hash()
+					}
+				}
+				check(i.ReadPayload, m.Payload, "payload")
+				check(i.WritePayload, m.Payload, "payload")
+				check(i.ReadResult, m.Result, "result")
+				check(i.WriteResult, m.Result, "result")
+			}
+		}
+	}
+	return verr
+}
+
+// appendInterceptors appends the interceptors that are not already in the
+// given slice.
+func appendInterceptors(is []*InterceptorExpr, others ...*InterceptorExpr) []*InterceptorExpr {
+	for _, o := range others {
+		found := false
+		for _, i := range is {
+			if i == o {
+				found = true
+				break
+			}
+		}
+		if !found {
+			is = append(is, o)
+		}
+	}
+	return is
+}
@@ -0,0 +1,40 @@
+package expr_test
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestInterceptorExprValidation(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.InvalidInterceptorDSL)
+	expected := `interceptor "payload": name is used by multiple interceptors
+interceptor "payload": payload attribute "id" must have the same type in all the methods the interceptor applies to, got a different type in method "Mismatch" of service "Service"
+service "Service" method "Streaming": interceptor "payload" cannot access the payload or result of streaming method "Streaming"
+service "Service" method "ResultType": interceptor "result" cannot access the result of method "ResultType": results defined with ResultType are not supported, use Type instead
+service "Service" method "NotObject": interceptor "payload" accesses payload attributes but the payload of method "NotObject" is not an object
+service "Service" method "Missing": interceptor "payload" accesses payload attribute "missing" not defined in method "Missing"`
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err.Error() != expected {
+		t.Errorf("got error %q\nexpected %q", err.Error(), expected)
+	}
+}
+
+func TestMethodExprInterceptors(t *testing.T) {
+	root := expr.RunDSL(t, testdata.InterceptorDSL)
+	m := root.Service("Service").Method("Method")
+	var server []string
+	for _, i := range m.AllServerInterceptors() {
+		server = append(server, i.Name)
+	}
+	if len(server) != 2 || server[0] != "audit" || server[1] != "cache" {
+		t.Errorf("got server interceptors %v, expected [audit cache]", server)
+	}
+	client := m.AllClientInterceptors()
+	if len(client) != 1 || client[0].Name != "audit" {
+		t.Errorf("got %d client interceptors, expected [audit]", len(client))
+	}
+}
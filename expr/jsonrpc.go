@@ -0,0 +1,152 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// JSONRPCExpr contains the API level JSON-RPC specific expressions.
+	// JSON-RPC requests are sent in the bodies of HTTP POST requests or
+	// over websocket connections for streaming methods so the JSON-RPC
+	// services and methods are described with HTTP service and endpoint
+	// expressions. All the methods of a JSON-RPC service are served on the
+	// service path, the JSON-RPC request method identifies the endpoint.
+	JSONRPCExpr struct {
+		// HTTPExpr contains the JSON-RPC services and the error
+		// responses defined globally.
+		HTTPExpr
+	}
+)
+
+// EvalName returns the name printed in case of evaluation error.
+func (*JSONRPCExpr) EvalName() string {
+	return "API JSON-RPC"
+}
+
+// Prepare initializes the path of the JSON-RPC services that do not define
+// one explicitly with the name of the service. It also makes sure the routes
+// of the streaming endpoints use GET as the websocket connections are opened
+// with GET requests.
+func (j *JSONRPCExpr) Prepare() {
+	for _, svc := range j.Services {
+		if len(svc.Paths) == 0 {
+			svc.Paths = []string{"/" + svc.Name()}
+		}
+		for _, e := range svc.HTTPEndpoints {
+			if e.IsWebSocket() {
+				for _, r := range e.Routes {
+					r.Method = "GET"
+				}
+			}
+		}
+	}
+}
+
+// Validate makes sure the JSON-RPC services and endpoints only use the HTTP
+// features that can be mapped to JSON-RPC requests and responses. It also
+// validates the API level JSON-RPC errors.
+func (j *JSONRPCExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	routes := make(map[string]string)
+	if Root.API.HTTP != nil {
+		for _, svc := range Root.API.HTTP.Services {
+			for _, e := range svc.HTTPEndpoints {
+				for _, r := range e.Routes {
+					for _, p := range r.FullPaths() {
+						routes[r.Method+" "+p] = svc.Name()
+					}
+				}
+			}
+		}
+	}
+	for _, er := range j.Errors {
+		verr.Merge(er.validate())
+	}
+	paths := make(map[string]string)
+	for _, svc := range j.Services {
+		for _, p := range svc.FullPaths() {
+			for _, m := range []string{"POST", "GET"} {
+				if other, ok := routes[m+" "+p]; ok {
+					verr.Add(svc, "JSON-RPC service path %q conflicts with the %s route of HTTP service %q", p, m, other)
+				}
+			}
+			if len(ExtractHTTPWildcards(p)) > 0 {
+				verr.Add(svc, "JSON-RPC service path %q cannot define path parameters", p)
+			}
+			if other, ok := paths[p]; ok {
+				verr.Add(svc, "JSON-RPC service path %q is already used by service %q", p, other)
+			}
+			paths[p] = svc.Name()
+		}
+		if len(svc.FileServers) > 0 {
+			verr.Add(svc, "JSON-RPC services cannot serve static files")
+		}
+		for _, e := range svc.HTTPEndpoints {
+			verr.Merge(validateJSONRPCEndpoint(e))
+		}
+	}
+	return verr
+}
+
+// validateJSONRPCEndpoint makes sure the given JSON-RPC endpoint does not use
+// HTTP specific features.
+func validateJSONRPCEndpoint(e *HTTPEndpointExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if len(e.Routes) != 1 || e.Routes[0].Path != "" {
+		verr.Add(e, "JSON-RPC endpoints cannot define HTTP routes")
+	}
+	if !e.Params.IsEmpty() || !e.Headers.IsEmpty() || !e.Cookies.IsEmpty() {
+		verr.Add(e, "JSON-RPC endpoints cannot define HTTP parameters, headers or cookies, the request params hold the whole method payload")
+	}
+	if e.Body != nil {
+		verr.Add(e, "JSON-RPC endpoints cannot define the HTTP request body, the request params hold the whole method payload")
+	}
+	if p := e.MethodExpr.Payload; p.Type != Empty && !IsObject(p.Type) {
+		verr.Add(e, "JSON-RPC request params must be objects, the method payload must be an object")
+	}
+	if sp := e.MethodExpr.StreamingPayload; sp.Type != Empty && !IsObject(sp.Type) {
+		verr.Add(e, "JSON-RPC request params must be objects, the method streaming payload must be an object")
+	}
+	features := []struct {
+		used bool
+		name string
+	}{
+		{e.SSE != nil, "Server-Sent Events"},
+		{e.NDJSON, "NDJSON streaming"},
+		{e.Redirect != nil, "redirects"},
+		{e.MultipartRequest, "multipart requests"},
+		{e.SkipRequestBodyEncodeDecode || e.SkipResponseBodyEncodeDecode, "raw request and response bodies"},
+		{e.Conditional != nil, "conditional requests"},
+		{e.Idempotent, "idempotency keys"},
+		{e.WebSocketSettings() != nil, "websocket settings"},
+	}
+	for _, f := range features {
+		if f.used {
+			verr.Add(e, "JSON-RPC endpoints do not support %s", f.name)
+		}
+	}
+	if len(e.Responses) > 1 {
+		verr.Add(e, "JSON-RPC endpoints cannot define more than one success response")
+	}
+	for _, r := range e.Responses {
+		if !r.Headers.IsEmpty() || !r.Cookies.IsEmpty() {
+			verr.Add(r, "JSON-RPC responses cannot define HTTP headers or cookies")
+		}
+	}
+	codes := make(map[int]string)
+	for _, er := range e.HTTPErrors {
+		if !er.Response.Headers.IsEmpty() || !er.Response.Cookies.IsEmpty() {
+			verr.Add(er, "JSON-RPC error responses cannot define HTTP headers or cookies")
+		}
+		if other, ok := codes[er.Response.StatusCode]; ok {
+			verr.Add(e, "JSON-RPC errors %q and %q cannot use the same error code %d", other, er.Name, er.Response.StatusCode)
+		}
+		codes[er.Response.StatusCode] = er.Name
+	}
+	if rt, ok := e.MethodExpr.Result.Type.(*ResultTypeExpr); ok && len(rt.Views) > 1 {
+		if _, ok := e.MethodExpr.Result.Meta["view"]; !ok {
+			verr.Add(e, "JSON-RPC endpoints cannot return result types with multiple views, use View in the method Result expression to select the view")
+		}
+	}
+	return verr
+}
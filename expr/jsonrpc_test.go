@@ -0,0 +1,63 @@
+package expr_test
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestJSONRPCValidation(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.InvalidJSONRPCDSL)
+	expected := `service "InvalidJSONRPC": JSON-RPC service path "/rpc" conflicts with the GET route of HTTP service "Other"
+service "InvalidJSONRPC" JSON-RPC endpoint "Scalar": JSON-RPC request params must be objects, the method payload must be an object
+service "InvalidJSONRPC" JSON-RPC endpoint "Scalar": JSON-RPC errors "first" and "second" cannot use the same error code -32001`
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err.Error() != expected {
+		t.Errorf("got error %q\nexpected %q", err.Error(), expected)
+	}
+}
+
+func TestJSONRPCEndpoints(t *testing.T) {
+	root := expr.RunDSL(t, testdata.JSONRPCDSL)
+	svc := root.API.JSONRPC.Service("Calc")
+	if svc == nil {
+		t.Fatal("got no JSON-RPC service")
+	}
+	if got := svc.Paths; len(got) != 1 || got[0] != "/calc" {
+		t.Errorf("got paths %v, expected [/calc]", got)
+	}
+	e := svc.Endpoint("Add")
+	if e == nil {
+		t.Fatal("got no JSON-RPC endpoint")
+	}
+	if len(e.Routes) != 1 || e.Routes[0].Method != "POST" {
+		t.Errorf("got routes %v, expected a single POST route", e.Routes)
+	}
+	if root.API.HTTP.Service("Calc") != nil {
+		t.Errorf("got HTTP service for JSON-RPC only service")
+	}
+}
+
+func TestJSONRPCAPIErrors(t *testing.T) {
+	root := expr.RunDSL(t, testdata.JSONRPCAPIErrorDSL)
+	e := root.API.JSONRPC.Service("calc").Endpoint("div")
+	codes := make(map[string]int)
+	for _, er := range e.HTTPErrors {
+		codes[er.Name] = er.Response.StatusCode
+	}
+	if codes["unauthorized"] != -32001 || codes["div_by_zero"] != -32002 {
+		t.Errorf("got error codes %v, expected unauthorized: -32001 and div_by_zero: -32002", codes)
+	}
+
+	err := expr.RunInvalidDSL(t, testdata.JSONRPCUndefinedAPIErrorDSL)
+	expected := `JSON-RPC error unauthorized: Error "unauthorized" does not match an error defined in the API`
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err.Error() != expected {
+		t.Errorf("got error %q\nexpected %q", err.Error(), expected)
+	}
+}
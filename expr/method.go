@@ -31,6 +31,21 @@ type (
 		// schemes. Incoming requests must validate at least one
 		// requirement to be authorized.
 		Requirements []*SecurityExpr
+		// Retry is the retry policy used by the generated clients to
+		// call the method if any.
+		Retry *RetryExpr
+		// RateLimit describes the rate and concurrency limits enforced
+		// by the method endpoint if any.
+		RateLimit *RateLimitExpr
+		// Pagination describes how the method returns its results in
+		// pages if it does.
+		Pagination *PaginationExpr
+		// ServerInterceptors lists the interceptors that run around the
+		// method endpoint.
+		ServerInterceptors []*InterceptorExpr
+		// ClientInterceptors lists the interceptors that run around the
+		// method client endpoint.
+		ClientInterceptors []*InterceptorExpr
 		// Service that owns method.
 		Service *ServiceExpr
 		// Meta is an arbitrary set of key/value pairs, see dsl.Meta
@@ -94,6 +109,9 @@ func (m *MethodExpr) Prepare() {
 	if m.Result == nil {
 		m.Result = &AttributeExpr{Type: Empty}
 	}
+	if m.Pagination != nil {
+		m.Pagination.Prepare()
+	}
 }
 
 // Validate validates the method payloads, results, and errors (if any).
@@ -109,6 +127,18 @@ func (m *MethodExpr) Validate() error {
 	} else if len(Root.API.Requirements) > 0 {
 		requirements = Root.API.Requirements
 	}
+	if m.Retry != nil {
+		verr.Merge(m.Retry.Validate())
+	}
+	if r := m.rateLimit(); r != nil {
+		verr.Merge(r.Validate(m, requirements))
+	}
+	if m.Pagination != nil {
+		verr.Merge(m.Pagination.Validate())
+	}
+	for _, i := range appendInterceptors(m.AllServerInterceptors(), m.AllClientInterceptors()...) {
+		verr.Merge(i.validateMethod(m))
+	}
 	var (
 		hasBasicAuth bool
 		hasAPIKey    bool
@@ -300,6 +330,18 @@ func (m *MethodExpr) Finalize() {
 		e.Finalize()
 	}
 
+	// Inherit retry policy
+	if m.Retry == nil {
+		if r := m.retry(); r != nil {
+			m.Retry = r.Dup(m)
+		}
+	}
+
+	// Inherit rate limits
+	if m.RateLimit == nil && m.Service.RateLimit != nil {
+		m.RateLimit = m.Service.RateLimit.Dup(m)
+	}
+
 	// Inherit security requirements
 	noreq := false
 loop:
@@ -325,6 +367,52 @@ loop:
 	}
 }
 
+// retry returns the retry policy that applies to the method: the method
+// retry policy if any, the service retry policy otherwise and finally the API
+// retry policy.
+func (m *MethodExpr) retry() *RetryExpr {
+	if m.Retry != nil {
+		return m.Retry
+	}
+	if m.Service.Retry != nil {
+		return m.Service.Retry
+	}
+	return Root.API.Retry
+}
+
+// AllServerInterceptors returns the server interceptors that apply to the
+// method in order of execution: the API interceptors first, then the service
+// interceptors and finally the method interceptors.
+func (m *MethodExpr) AllServerInterceptors() []*InterceptorExpr {
+	var is []*InterceptorExpr
+	if Root.API != nil {
+		is = appendInterceptors(is, Root.API.ServerInterceptors...)
+	}
+	is = appendInterceptors(is, m.Service.ServerInterceptors...)
+	return appendInterceptors(is, m.ServerInterceptors...)
+}
+
+// AllClientInterceptors returns the client interceptors that apply to the
+// method in order of execution: the API interceptors first, then the service
+// interceptors and finally the method interceptors.
+func (m *MethodExpr) AllClientInterceptors() []*InterceptorExpr {
+	var is []*InterceptorExpr
+	if Root.API != nil {
+		is = appendInterceptors(is, Root.API.ClientInterceptors...)
+	}
+	is = appendInterceptors(is, m.Service.ClientInterceptors...)
+	return appendInterceptors(is, m.ClientInterceptors...)
+}
+
+// rateLimit returns the limits that apply to the method: the method limits if
+// any, the service limits otherwise.
+func (m *MethodExpr) rateLimit() *RateLimitExpr {
+	if m.RateLimit != nil {
+		return m.RateLimit
+	}
+	return m.Service.RateLimit
+}
+
 // IsStreaming determines whether the method streams payload or result.
 func (m *MethodExpr) IsStreaming() bool {
 	return m.IsPayloadStreaming() || m.IsResultStreaming()
@@ -32,6 +32,27 @@ service "AnotherInvalidSecuritySchemesService" method "Method": payload of metho
 service "AnotherInvalidSecuritySchemesService" method "Method": payload of method "Method" of service "AnotherInvalidSecuritySchemesService" defines a JWT token attribute, but no JWT auth security scheme exist
 service "AnotherInvalidSecuritySchemesService" method "Method": payload of method "Method" of service "AnotherInvalidSecuritySchemesService" defines a OAuth2 access token attribute, but no OAuth2 security scheme exist`,
 		},
+		{"valid-retry", testdata.ValidRetryDSL, ""},
+		{"invalid-retry", testdata.InvalidRetryDSL,
+			`service "InvalidRetryService" method "Method" retry policy: MaxAttempts must be at least 1, got 0
+service "InvalidRetryService" method "Method" retry policy: initial backoff 2s is greater than max backoff 1s
+service "InvalidRetryService" method "Method" retry policy: Jitter must be between 0 and 1, got 1.5
+service "InvalidRetryService" method "Method" retry policy: invalid HTTP status code 42
+service "InvalidRetryService" method "Method" retry policy: undefined error "unknown"`,
+		},
+		{"invalid-api-retry", testdata.InvalidAPIRetryDSL,
+			`API InvalidAPIRetryAPI retry policy: MaxAttempts must be at least 1, got 0
+service "InvalidAPIRetryService" retry policy: Jitter must be between 0 and 1, got 2`,
+		},
+		{"valid-rate-limit", testdata.ValidRateLimitDSL, ""},
+		{"invalid-rate-limit", testdata.InvalidRateLimitDSL,
+			`service "InvalidRateLimitService" method "Method" rate limit: requests, burst and max in-flight cannot be negative
+service "InvalidRateLimitService" method "Method" rate limit: RateLimitKey and RateLimitPrincipal cannot be used together
+service "InvalidRateLimitService" method "Method" rate limit: payload of method "Method" does not define attribute "tenant"
+service "InvalidRateLimitService" method "Method" rate limit: RateLimitPrincipal requires method "Method" to define security requirements
+service "InvalidRateLimitService" rate limit: key attribute "tenant" of method "InheritedMethod" must be a primitive
+service "InvalidRateLimitService" method "NoLimitMethod" rate limit: must define a rate limit or a concurrency limit`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -47,6 +68,40 @@ service "AnotherInvalidSecuritySchemesService" method "Method": payload of metho
 	}
 }
 
+func TestMethodExprRetry(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ValidRetryDSL)
+	svc := root.Service("ValidRetryService")
+
+	m := svc.Method("Method")
+	if m.Retry == nil || m.Retry.MaxAttempts != expr.DefaultRetryMaxAttempts || m.Retry.HedgeDelay == 0 {
+		t.Errorf("got method retry policy %+v, expected the method policy", m.Retry)
+	}
+	inherited := svc.Method("InheritedMethod")
+	if inherited.Retry == nil || inherited.Retry.MaxAttempts != 5 || inherited.Retry.Jitter != 0.2 {
+		t.Errorf("got inherited retry policy %+v, expected the service policy", inherited.Retry)
+	}
+	if inherited.Retry == svc.Retry {
+		t.Error("inherited retry policy must be a copy of the service policy")
+	}
+}
+
+func TestMethodExprRateLimit(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ValidRateLimitDSL)
+	svc := root.Service("ValidRateLimitService")
+
+	m := svc.Method("Method")
+	if m.RateLimit == nil || m.RateLimit.MaxInFlight != 5 || m.RateLimit.Requests != 0 {
+		t.Errorf("got method rate limit %+v, expected the method limits", m.RateLimit)
+	}
+	inherited := svc.Method("InheritedMethod")
+	if inherited.RateLimit == nil || inherited.RateLimit.Requests != 100 || inherited.RateLimit.Key != "tenant" {
+		t.Errorf("got inherited rate limit %+v, expected the service limits", inherited.RateLimit)
+	}
+	if inherited.RateLimit == svc.RateLimit || inherited.RateLimit.Parent != inherited {
+		t.Error("inherited rate limit must be a copy of the service limits")
+	}
+}
+
 func TestMethodExprError(t *testing.T) {
 	var (
 		errorFoo = &expr.ErrorExpr{
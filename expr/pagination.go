@@ -0,0 +1,156 @@
+package expr
+
+import (
+	"strconv"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// PaginationExpr describes how a method returns its results in pages.
+	// Cursor pagination uses an opaque token returned by each page to
+	// request the next one. Offset pagination uses the index of the first
+	// item of the page.
+	PaginationExpr struct {
+		// Cursor is the name of the payload attribute that holds the
+		// token of the requested page, empty for offset pagination.
+		Cursor string
+		// NextCursor is the name of the result attribute that holds the
+		// token of the next page, empty for offset pagination.
+		NextCursor string
+		// Offset is the name of the payload attribute that holds the
+		// index of the first item of the requested page, empty for
+		// cursor pagination.
+		Offset string
+		// Limit is the name of the payload attribute that holds the
+		// maximum number of items per page.
+		Limit string
+		// Items is the name of the result attribute that lists the
+		// items of the page.
+		Items string
+		// Method is the paginated method.
+		Method *MethodExpr
+	}
+)
+
+const (
+	// DefaultPaginationCursor is the default name of the payload
+	// attribute that holds the page token.
+	DefaultPaginationCursor = "cursor"
+	// DefaultPaginationNextCursor is the default name of the result
+	// attribute that holds the next page token.
+	DefaultPaginationNextCursor = "next_cursor"
+	// DefaultPaginationLimit is the default name of the payload attribute
+	// that holds the page size.
+	DefaultPaginationLimit = "limit"
+	// DefaultPaginationItems is the default name of the result attribute
+	// that lists the page items.
+	DefaultPaginationItems = "items"
+)
+
+// NewPaginationExpr returns a cursor pagination expression that uses the
+// default attribute names.
+func NewPaginationExpr(m *MethodExpr) *PaginationExpr {
+	return &PaginationExpr{
+		Cursor:     DefaultPaginationCursor,
+		NextCursor: DefaultPaginationNextCursor,
+		Limit:      DefaultPaginationLimit,
+		Items:      DefaultPaginationItems,
+		Method:     m,
+	}
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (p *PaginationExpr) EvalName() string {
+	var prefix string
+	if p.Method != nil {
+		prefix = p.Method.EvalName() + " "
+	}
+	return prefix + "pagination"
+}
+
+// IsCursor returns true if the method uses cursor pagination.
+func (p *PaginationExpr) IsCursor() bool {
+	return p.Cursor != ""
+}
+
+// Prepare adds the cursor, offset and limit attributes to the method payload
+// and the next cursor attribute to the method result if they are not defined
+// explicitly and the payload and result are not user types. The added
+// attributes use the next available gRPC field numbers.
+func (p *PaginationExpr) Prepare() {
+	m := p.Method
+	if m.Payload.Type == Empty {
+		m.Payload.Type = &Object{}
+	}
+	if obj, ok := m.Payload.Type.(*Object); ok {
+		addPaginationAttribute(obj, p.Cursor, String, "Token of the requested page")
+		addPaginationAttribute(obj, p.Offset, Int, "Index of the first item of the requested page")
+		addPaginationAttribute(obj, p.Limit, Int, "Maximum number of items in the page")
+	}
+	if obj, ok := m.Result.Type.(*Object); ok {
+		addPaginationAttribute(obj, p.NextCursor, String, "Token of the next page, empty if this is the last page")
+	}
+}
+
+// Validate makes sure the method payload and result define the pagination
+// attributes with the proper types.
+func (p *PaginationExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	m := p.Method
+	if m.IsStreaming() {
+		verr.Add(p, "streaming methods cannot be paginated")
+		return verr
+	}
+	if (p.Cursor == "") == (p.Offset == "") {
+		verr.Add(p, "must define either a cursor or an offset")
+	}
+	if p.Cursor != "" && p.NextCursor == "" {
+		verr.Add(p, "cursor pagination requires a next cursor result attribute")
+	}
+	check := func(parent *AttributeExpr, kind, name string, valid func(DataType) bool, expected string) {
+		if name == "" {
+			return
+		}
+		att := parent.Find(name)
+		switch {
+		case att == nil || !IsObject(parent.Type):
+			verr.Add(p, "%s of method %q does not define attribute %q", kind, m.Name, name)
+		case !valid(att.Type):
+			verr.Add(p, "%s attribute %q of method %q must be %s", kind, name, m.Name, expected)
+		}
+	}
+	isString := func(dt DataType) bool { return dt.Kind() == StringKind }
+	isInt := func(dt DataType) bool { return dt.Kind() == IntKind }
+	check(m.Payload, "payload", p.Cursor, isString, "a String")
+	check(m.Payload, "payload", p.Offset, isInt, "an Int")
+	check(m.Payload, "payload", p.Limit, isInt, "an Int")
+	if _, ok := m.Result.Type.(*ResultTypeExpr); ok {
+		verr.Add(p, "results defined with ResultType are not supported, use Type instead")
+		return verr
+	}
+	check(m.Result, "result", p.NextCursor, isString, "a String")
+	check(m.Result, "result", p.Items, IsArray, "an array")
+	return verr
+}
+
+// addPaginationAttribute adds the attribute with the given name, type and
+// description to obj unless name is empty or obj already defines it. The
+// attribute "rpc:tag" meta is set to the next available field number.
+func addPaginationAttribute(obj *Object, name string, dt DataType, desc string) {
+	if name == "" || obj.Attribute(name) != nil {
+		return
+	}
+	tag := 1
+	for _, nat := range *obj {
+		v, ok := nat.Attribute.FieldTag()
+		if !ok {
+			continue
+		}
+		if t, err := strconv.Atoi(v); err == nil && t >= tag {
+			tag = t + 1
+		}
+	}
+	att := &AttributeExpr{Type: dt, Description: desc, Meta: MetaExpr{"rpc:tag": []string{strconv.Itoa(tag)}}}
+	obj.Set(name, att)
+}
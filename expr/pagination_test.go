@@ -0,0 +1,56 @@
+package expr_test
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestPaginationExprPrepare(t *testing.T) {
+	root := expr.RunDSL(t, testdata.PaginationDSL)
+	svc := root.Service("Service")
+	cases := []struct {
+		Name      string
+		Attribute *expr.AttributeExpr
+		Expected  []string
+	}{
+		{"cursor-payload", svc.Method("Cursor").Payload, []string{"filter", "cursor", "limit"}},
+		{"cursor-result", svc.Method("Cursor").Result, []string{"items", "next_cursor"}},
+		{"offset-payload", svc.Method("Offset").Payload, []string{"start", "size"}},
+		{"offset-result", svc.Method("Offset").Result, []string{"entries"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			obj := expr.AsObject(c.Attribute.Type)
+			if len(*obj) != len(c.Expected) {
+				t.Fatalf("got %d attributes, expected %d", len(*obj), len(c.Expected))
+			}
+			for i, nat := range *obj {
+				if nat.Name != c.Expected[i] {
+					t.Errorf("got attribute %q at index %d, expected %q", nat.Name, i, c.Expected[i])
+				}
+			}
+		})
+	}
+	tag, _ := svc.Method("Cursor").Payload.Find("limit").FieldTag()
+	if tag != "3" {
+		t.Errorf("got rpc:tag %q for limit, expected %q", tag, "3")
+	}
+}
+
+func TestPaginationExprValidation(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.InvalidPaginationDSL)
+	expected := `service "Service" method "Streaming" pagination: streaming methods cannot be paginated
+service "Service" method "NoItems" pagination: result of method "NoItems" does not define attribute "items"
+service "Service" method "BadTypes" pagination: payload attribute "cursor" of method "BadTypes" must be a String
+service "Service" method "BadTypes" pagination: payload attribute "limit" of method "BadTypes" must be an Int
+service "Service" method "BadTypes" pagination: result attribute "items" of method "BadTypes" must be an array
+service "Service" method "ResultType" pagination: results defined with ResultType are not supported, use Type instead`
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err.Error() != expected {
+		t.Errorf("got error %q\nexpected %q", err.Error(), expected)
+	}
+}
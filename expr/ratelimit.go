@@ -0,0 +1,84 @@
+package expr
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// RateLimitExpr describes the rate and concurrency limits enforced by
+	// the generated service endpoints.
+	RateLimitExpr struct {
+		// Requests is the number of requests allowed during Interval,
+		// zero if the rate is not limited.
+		Requests int
+		// Interval is the period during which Requests requests are
+		// allowed.
+		Interval time.Duration
+		// Burst is the maximum number of requests allowed at once.
+		Burst int
+		// MaxInFlight is the maximum number of concurrent requests, zero
+		// if the concurrency is not limited.
+		MaxInFlight int
+		// Key is the name of the payload attribute whose value is used
+		// to group the requests if any.
+		Key string
+		// Principal is true if the requests are grouped by the security
+		// principal (user name, API key or token).
+		Principal bool
+		// Parent is the service or method expression that defines the
+		// limits.
+		Parent eval.Expression
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (r *RateLimitExpr) EvalName() string {
+	var prefix string
+	if r.Parent != nil {
+		prefix = r.Parent.EvalName() + " "
+	}
+	return prefix + "rate limit"
+}
+
+// Validate makes sure the limits are consistent and that the key attribute
+// exists in the payload of the method m the limits apply to.
+func (r *RateLimitExpr) Validate(m *MethodExpr, requirements []*SecurityExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if r.Requests < 0 || r.Burst < 0 || r.MaxInFlight < 0 {
+		verr.Add(r, "requests, burst and max in-flight cannot be negative")
+	}
+	if r.Requests > 0 && r.Interval <= 0 {
+		verr.Add(r, "interval must be positive, got %s", r.Interval)
+	}
+	if r.Requests == 0 && r.MaxInFlight == 0 {
+		verr.Add(r, "must define a rate limit or a concurrency limit")
+	}
+	if r.Burst > 0 && r.Requests == 0 {
+		verr.Add(r, "Burst requires a rate limit")
+	}
+	if r.Key != "" && r.Principal {
+		verr.Add(r, "RateLimitKey and RateLimitPrincipal cannot be used together")
+	}
+	if r.Key != "" {
+		att := m.Payload.Find(r.Key)
+		switch {
+		case att == nil || !IsObject(m.Payload.Type):
+			verr.Add(r, "payload of method %q does not define attribute %q", m.Name, r.Key)
+		case !IsPrimitive(att.Type):
+			verr.Add(r, "key attribute %q of method %q must be a primitive", r.Key, m.Name)
+		}
+	}
+	if r.Principal && len(requirements) == 0 {
+		verr.Add(r, "RateLimitPrincipal requires method %q to define security requirements", m.Name)
+	}
+	return verr
+}
+
+// Dup returns a copy of the rate limit expression with the given parent.
+func (r *RateLimitExpr) Dup(parent eval.Expression) *RateLimitExpr {
+	dup := *r
+	dup.Parent = parent
+	return &dup
+}
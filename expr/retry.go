@@ -0,0 +1,108 @@
+package expr
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// RetryExpr describes the policy used by the generated clients to retry
+	// failed requests.
+	RetryExpr struct {
+		// MaxAttempts is the maximum number of attempts including the
+		// initial request.
+		MaxAttempts int
+		// InitialBackoff is the delay before the first retry.
+		InitialBackoff time.Duration
+		// MaxBackoff is the maximum delay between two attempts.
+		MaxBackoff time.Duration
+		// Jitter is the fraction of the backoff delay that is randomized.
+		Jitter float64
+		// HedgeDelay is the delay after which a new attempt is made
+		// concurrently with the pending ones if not zero.
+		HedgeDelay time.Duration
+		// Errors lists the names of the retryable errors.
+		Errors []string
+		// StatusCodes lists the retryable HTTP response status codes.
+		StatusCodes []int
+		// IdempotentOnly restricts retries to idempotent requests.
+		IdempotentOnly bool
+		// Parent is the API, service or method expression that defines
+		// the retry policy.
+		Parent eval.Expression
+	}
+)
+
+const (
+	// DefaultRetryMaxAttempts is the default maximum number of attempts.
+	DefaultRetryMaxAttempts = 3
+	// DefaultRetryInitialBackoff is the default delay before the first
+	// retry.
+	DefaultRetryInitialBackoff = 100 * time.Millisecond
+	// DefaultRetryMaxBackoff is the default maximum delay between two
+	// attempts.
+	DefaultRetryMaxBackoff = 10 * time.Second
+)
+
+// NewRetryExpr returns a retry expression initialized with the default
+// values.
+func NewRetryExpr(parent eval.Expression) *RetryExpr {
+	return &RetryExpr{
+		MaxAttempts:    DefaultRetryMaxAttempts,
+		InitialBackoff: DefaultRetryInitialBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+		Parent:         parent,
+	}
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (r *RetryExpr) EvalName() string {
+	var prefix string
+	if r.Parent != nil {
+		prefix = r.Parent.EvalName() + " "
+	}
+	return prefix + "retry policy"
+}
+
+// Validate makes sure the retry policy values are consistent. The names of the
+// retryable errors are only validated when the policy is defined on a method.
+// Policies defined on the API or a service are validated once by the parent
+// expression rather than by each method that inherits them.
+func (r *RetryExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if r.MaxAttempts < 1 {
+		verr.Add(r, "MaxAttempts must be at least 1, got %d", r.MaxAttempts)
+	}
+	if r.InitialBackoff < 0 || r.MaxBackoff < 0 || r.HedgeDelay < 0 {
+		verr.Add(r, "Backoff and Hedge durations cannot be negative")
+	}
+	if r.MaxBackoff > 0 && r.InitialBackoff > r.MaxBackoff {
+		verr.Add(r, "initial backoff %s is greater than max backoff %s", r.InitialBackoff, r.MaxBackoff)
+	}
+	if r.Jitter < 0 || r.Jitter > 1 {
+		verr.Add(r, "Jitter must be between 0 and 1, got %v", r.Jitter)
+	}
+	for _, code := range r.StatusCodes {
+		if code < 100 || code > 599 {
+			verr.Add(r, "invalid HTTP status code %d", code)
+		}
+	}
+	if m, ok := r.Parent.(*MethodExpr); ok {
+		for _, name := range r.Errors {
+			if m.Error(name) == nil {
+				verr.Add(r, "undefined error %q", name)
+			}
+		}
+	}
+	return verr
+}
+
+// Dup returns a copy of the retry expression with the given parent.
+func (r *RetryExpr) Dup(parent eval.Expression) *RetryExpr {
+	dup := *r
+	dup.Errors = append([]string(nil), r.Errors...)
+	dup.StatusCodes = append([]int(nil), r.StatusCodes...)
+	dup.Parent = parent
+	return &dup
+}
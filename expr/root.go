@@ -34,6 +34,8 @@ type (
 		Creations []*TypeMap
 		// Schemes list the registered security schemes.
 		Schemes []*SchemeExpr
+		// Interceptors list the interceptors defined in the DSL.
+		Interceptors []*InterceptorExpr
 	}
 
 	// MetaExpr is a set of key/value pairs
@@ -65,6 +67,9 @@ func (r *RootExpr) WalkSets(walk eval.SetWalker) {
 	// Servers
 	walk(eval.ToExpressionSet(r.API.Servers))
 
+	// Interceptors
+	walk(eval.ToExpressionSet(r.Interceptors))
+
 	// User types
 	types := make(eval.ExpressionSet, len(r.Types))
 	for i, t := range r.Types {
@@ -127,6 +132,22 @@ func (r *RootExpr) WalkSets(walk eval.SetWalker) {
 	walk(eval.ExpressionSet{r.API.GRPC})
 	walk(grpcsvcs)
 	walk(grpcepts)
+
+	// JSON-RPC services and endpoints
+	if r.API.JSONRPC == nil {
+		return
+	}
+	jsonrpcsvcs := make(eval.ExpressionSet, len(r.API.JSONRPC.Services))
+	var jsonrpcepts eval.ExpressionSet
+	for i, svc := range r.API.JSONRPC.Services {
+		jsonrpcsvcs[i] = svc
+		for _, e := range svc.HTTPEndpoints {
+			jsonrpcepts = append(jsonrpcepts, e)
+		}
+	}
+	walk(eval.ExpressionSet{r.API.JSONRPC})
+	walk(jsonrpcsvcs)
+	walk(jsonrpcepts)
 }
 
 // DependsOn returns nil, the core DSL has no dependency.
@@ -222,7 +243,18 @@ func (r *RootExpr) Validate() error {
 	var verr eval.ValidationErrors
 	if r.API == nil {
 		verr.Add(r, "Missing API declaration")
+	} else {
+		if r.API.Retry != nil {
+			verr.Merge(r.API.Retry.Validate())
+		}
+		if r.API.HTTP != nil {
+			for _, o := range r.API.HTTP.Origins {
+				verr.Merge(o.Validate())
+			}
+		}
 	}
+	verr.Merge(validateInterceptors(r))
+	verr.Merge(validateHealthPaths(r))
 	return &verr
 }
 
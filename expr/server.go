@@ -81,6 +81,10 @@ func (s *ServerExpr) Finalize() {
 	}
 	for _, svc := range s.Services {
 		hasHTTP := Root.API.HTTP.Service(svc) != nil
+		if Root.API.JSONRPC != nil && Root.API.JSONRPC.Service(svc) != nil {
+			// JSON-RPC requests are sent over HTTP.
+			hasHTTP = true
+		}
 		hasGRPC := Root.API.GRPC.Service(svc) != nil
 		for _, h := range s.Hosts {
 			if hasHTTP && !h.HasHTTPScheme() {
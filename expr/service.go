@@ -26,6 +26,22 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// Retry is the retry policy that applies to all the service
+		// methods if any.
+		Retry *RetryExpr
+		// RateLimit describes the rate and concurrency limits enforced
+		// by all the service endpoints that do not define their own if
+		// any.
+		RateLimit *RateLimitExpr
+		// ServerInterceptors lists the interceptors that run around all
+		// the service endpoints.
+		ServerInterceptors []*InterceptorExpr
+		// ClientInterceptors lists the interceptors that run around all
+		// the service client endpoints.
+		ClientInterceptors []*InterceptorExpr
+		// Health describes the service health checks if any. Services
+		// that do not define health checks inherit the API ones.
+		Health *HealthExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Meta MetaExpr
@@ -85,6 +101,12 @@ func (s *ServiceExpr) Validate() error {
 			}
 		}
 	}
+	if s.Retry != nil {
+		verr.Merge(s.Retry.Validate())
+	}
+	if h := s.health(); h != nil {
+		verr.Merge(h.Validate(s))
+	}
 	return verr
 }
 
@@ -93,6 +115,20 @@ func (s *ServiceExpr) Finalize() {
 	for _, e := range s.Errors {
 		e.Finalize()
 	}
+	// Inherit health checks
+	s.Health = s.health()
+}
+
+// health returns the health checks that apply to the service: the service
+// checks if any, the API checks otherwise.
+func (s *ServiceExpr) health() *HealthExpr {
+	if s.Health != nil {
+		return s.Health
+	}
+	if Root.API == nil {
+		return nil
+	}
+	return Root.API.Health
 }
 
 // Validate checks that the error name is found in the result meta for
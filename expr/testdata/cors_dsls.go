@@ -0,0 +1,55 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CORSDSL = func() {
+	API("test", func() {
+		Origin("https://api.example.com")
+	})
+	Service("Service", func() {
+		HTTP(func() {
+			Origin(`/.*\.example\.com/`, func() {
+				AllowHeaders("X-Request-Id")
+			})
+		})
+		Method("Inherited", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("Overridden", func() {
+			HTTP(func() {
+				POST("/")
+				Origin("*", func() {
+					AllowMethods("POST")
+					MaxAge(600)
+				})
+			})
+		})
+	})
+	Service("Other", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/other")
+			})
+		})
+	})
+}
+
+var InvalidCORSDSL = func() {
+	Service("InvalidCORS", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Origin("*", func() {
+					AllowMethods("FETCH")
+					MaxAge(-1)
+					AllowCredentials()
+				})
+				Origin("/[/")
+			})
+		})
+	})
+}
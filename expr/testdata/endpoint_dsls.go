@@ -705,3 +705,215 @@ var GRPCEndpointWithExtendedTypes = func() {
 		})
 	})
 }
+
+var EndpointSSE = func() {
+	var Event = Type("Event", func() {
+		Attribute("id", String)
+		Attribute("retry", Int)
+		Attribute("message", String)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("last_event_id", String)
+			})
+			StreamingResult(Event)
+			HTTP(func() {
+				POST("/")
+				ServerSentEvents(func() {
+					SSEEventID("id")
+					SSEEventRetry("retry")
+					SSERequestID("last_event_id")
+				})
+			})
+		})
+	})
+}
+
+var EndpointSSENotStreaming = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				ServerSentEvents()
+			})
+		})
+	})
+}
+
+var EndpointSSEInvalidFields = func() {
+	var Event = Type("Event", func() {
+		Attribute("id", Int)
+		Attribute("retry", String)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(Event)
+			HTTP(func() {
+				GET("/")
+				ServerSentEvents(func() {
+					SSEEventID("id")
+					SSEEventType("kind")
+					SSEEventRetry("retry")
+				})
+			})
+		})
+	})
+}
+
+var EndpointNDJSON = func() {
+	Service("Service", func() {
+		Method("Tail", func() {
+			Payload(func() {
+				Attribute("filter", String)
+			})
+			StreamingResult(String)
+			HTTP(func() {
+				POST("/tail")
+				NDJSON()
+			})
+		})
+		Method("Chat", func() {
+			Payload(func() {
+				Attribute("room", String)
+			})
+			StreamingPayload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				POST("/chat/{room}")
+				NDJSON()
+			})
+		})
+	})
+}
+
+var EndpointNDJSONInvalid = func() {
+	Service("Service", func() {
+		Method("NotStreaming", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				NDJSON()
+			})
+		})
+		Method("SSE", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/sse")
+				ServerSentEvents()
+				NDJSON()
+			})
+		})
+		Method("Body", func() {
+			Payload(func() {
+				Attribute("room", String)
+			})
+			StreamingPayload(String)
+			HTTP(func() {
+				POST("/body")
+				NDJSON()
+			})
+		})
+	})
+}
+
+var EndpointIdempotent = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/")
+				Idempotent()
+			})
+		})
+	})
+}
+
+var EndpointIdempotentStreaming = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				Idempotent()
+			})
+		})
+		Method("MethodB", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				POST("/{id}")
+				SkipRequestBodyEncodeDecode()
+				Idempotent()
+			})
+		})
+	})
+}
+
+var EndpointConditional = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("version", String)
+				Attribute("updated_at", String, func() {
+					Format(FormatRFC1123)
+				})
+			})
+			HTTP(func() {
+				GET("/")
+				ETag("version")
+				LastModified("updated_at")
+			})
+		})
+		Method("MethodB", func() {
+			Result(ArrayOf(String))
+			HTTP(func() {
+				GET("/b")
+				ETag()
+			})
+		})
+	})
+}
+
+var EndpointConditionalInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("version", Int)
+			})
+			HTTP(func() {
+				GET("/")
+				ETag("version")
+				LastModified("updated_at")
+			})
+		})
+		Method("MethodB", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/b")
+				ETag("version")
+			})
+		})
+		Method("MethodC", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/c")
+				ETag()
+			})
+		})
+		Method("MethodD", func() {
+			Result(func() {
+				Attribute("updated_at", String, func() {
+					Format(FormatDateTime)
+				})
+			})
+			HTTP(func() {
+				GET("/d")
+				LastModified("updated_at")
+			})
+		})
+	})
+}
@@ -0,0 +1,92 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var HealthDSL = func() {
+	API("API", func() {
+		Health(func() {
+			Check("database", "Primary database")
+		})
+	})
+	Service("Inherited", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+	Service("AlsoInherited", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/also")
+			})
+		})
+	})
+	Service("Overridden", func() {
+		Health(func() {
+			Liveness("/overridden/healthz")
+			Readiness("/overridden/readyz")
+			Check("cache")
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var InvalidHealthDSL = func() {
+	Service("Service", func() {
+		Health(func() {
+			Liveness("healthz")
+			Readiness("healthz")
+			Check("my-db")
+			Check("MyDB")
+			Check("--")
+			Check("method")
+		})
+		Method("CheckMethod", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ConflictingHealthDSL = func() {
+	API("API", func() {
+		Health()
+	})
+	Service("Service1", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/readyz")
+			})
+		})
+	})
+	Service("Service2", func() {
+		Health(func() {
+			Liveness("/svc/healthz")
+			Readiness("/svc/readyz")
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+	Service("Service3", func() {
+		Health(func() {
+			Liveness("/svc/healthz")
+			Readiness("/readyz")
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/svc")
+			})
+		})
+	})
+}
@@ -0,0 +1,85 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var InterceptorDSL = func() {
+	var (
+		Audit = Interceptor("audit")
+		Cache = Interceptor("cache", func() {
+			ReadPayload(func() {
+				Attribute("id")
+			})
+			WriteResult(func() {
+				Attribute("cached_at")
+			})
+		})
+	)
+	API("test", func() {
+		ServerInterceptor(Audit)
+		ClientInterceptor(Audit)
+	})
+	Service("Service", func() {
+		ServerInterceptor(Cache, Audit)
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(func() {
+				Attribute("cached_at", String)
+			})
+			ServerInterceptor(Cache)
+		})
+	})
+}
+
+var InvalidInterceptorDSL = func() {
+	var (
+		ReadID = Interceptor("payload", func() {
+			ReadPayload(func() {
+				Attribute("id")
+				Attribute("missing")
+			})
+		})
+		WriteName = Interceptor("result", func() {
+			WriteResult(func() {
+				Attribute("name")
+			})
+		})
+		_ = Interceptor("payload")
+	)
+	var RT = ResultType("application/vnd.rt", func() {
+		Attribute("name", String)
+	})
+	Service("Service", func() {
+		Method("Streaming", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			StreamingResult(String)
+			ServerInterceptor(ReadID)
+		})
+		Method("ResultType", func() {
+			Result(RT)
+			ClientInterceptor(WriteName)
+		})
+		Method("NotObject", func() {
+			Payload(String)
+			ServerInterceptor(ReadID)
+		})
+		Method("Missing", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			ServerInterceptor(ReadID)
+		})
+		Method("Mismatch", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("missing", String)
+			})
+			ServerInterceptor(ReadID)
+		})
+	})
+}
@@ -0,0 +1,101 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var JSONRPCDSL = func() {
+	Service("Calc", func() {
+		Error("unauthorized")
+		JSONRPC(func() {
+			Path("/calc")
+			Response("unauthorized", -32001)
+		})
+		Method("Add", func() {
+			Payload(func() {
+				Attribute("a", Int)
+				Attribute("b", Int)
+			})
+			Result(Int)
+			JSONRPC()
+		})
+	})
+}
+
+var InvalidJSONRPCDSL = func() {
+	Service("InvalidJSONRPC", func() {
+		Error("first")
+		Error("second")
+		JSONRPC(func() {
+			Path("/rpc")
+		})
+		Method("Scalar", func() {
+			Payload(String)
+			JSONRPC(func() {
+				Response("first", -32001)
+				Response("second", -32001)
+			})
+		})
+	})
+	Service("Other", func() {
+		HTTP(func() {
+			Path("/rpc")
+		})
+		Method("Get", func() {
+			HTTP(func() {
+				GET("")
+			})
+		})
+	})
+}
+
+var JSONRPCUndefinedAPIErrorDSL = func() {
+	API("calc", func() {
+		JSONRPC(func() {
+			Response("unauthorized", -32001)
+		})
+	})
+	Service("Calc", func() {
+		Error("unauthorized")
+		Method("Add", func() {
+			Payload(func() {
+				Attribute("a", Int)
+			})
+			JSONRPC()
+		})
+		Method("Sub", func() {
+			Payload(func() {
+				Attribute("a", Int)
+			})
+			JSONRPC()
+		})
+	})
+}
+
+var JSONRPCAPIErrorDSL = func() {
+	var Operands = Type("Operands", func() {
+		Attribute("a", Int)
+		Attribute("b", Int)
+	})
+	API("calc", func() {
+		Error("unauthorized")
+		JSONRPC(func() {
+			Path("/rpc")
+			Response("unauthorized", -32001)
+		})
+	})
+	Service("calc", func() {
+		Error("unauthorized")
+		JSONRPC(func() {
+			Path("/calc")
+		})
+		Method("div", func() {
+			Payload(Operands)
+			Result(Int)
+			Error("div_by_zero")
+			JSONRPC(func() {
+				Response("div_by_zero", -32002)
+			})
+		})
+	})
+}
@@ -71,3 +71,101 @@ var InvalidSecuritySchemesDSL = func() {
 		})
 	})
 }
+
+var ValidRetryDSL = func() {
+	API("ValidRetryAPI", func() {
+		Retry()
+	})
+	Service("ValidRetryService", func() {
+		Error("unavailable", func() {
+			Temporary()
+		})
+		Retry(func() {
+			MaxAttempts(5)
+			Backoff("50ms", "1s")
+			Jitter(0.2)
+			RetryOn("unavailable")
+			RetryOnStatus(StatusBadGateway)
+		})
+		Method("Method", func() {
+			Error("conflict")
+			Retry(func() {
+				RetryOn("conflict")
+				Hedge("20ms")
+			})
+		})
+		Method("InheritedMethod", func() {})
+	})
+}
+
+var InvalidRetryDSL = func() {
+	Service("InvalidRetryService", func() {
+		Method("Method", func() {
+			Retry(func() {
+				MaxAttempts(0)
+				Backoff("2s", "1s")
+				Jitter(1.5)
+				RetryOn("unknown")
+				RetryOnStatus(42)
+			})
+		})
+	})
+}
+
+var InvalidAPIRetryDSL = func() {
+	API("InvalidAPIRetryAPI", func() {
+		Retry(func() {
+			MaxAttempts(0)
+		})
+	})
+	Service("InvalidAPIRetryService", func() {
+		Retry(func() {
+			Jitter(2)
+		})
+		Method("Method", func() {})
+		Method("OtherMethod", func() {})
+	})
+}
+
+var ValidRateLimitDSL = func() {
+	Service("ValidRateLimitService", func() {
+		RateLimit(100, "1m", func() {
+			Burst(10)
+			RateLimitKey("tenant")
+		})
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("tenant", String)
+			})
+			ConcurrencyLimit(5)
+		})
+		Method("InheritedMethod", func() {
+			Payload(func() {
+				Attribute("tenant", String)
+			})
+		})
+	})
+}
+
+var InvalidRateLimitDSL = func() {
+	Service("InvalidRateLimitService", func() {
+		RateLimit(10, "1s", func() {
+			RateLimitKey("tenant")
+		})
+		Method("Method", func() {
+			RateLimit(-1, "0s", func() {
+				Burst(2)
+				RateLimitKey("tenant")
+				RateLimitPrincipal()
+			})
+		})
+		Method("InheritedMethod", func() {
+			Payload(func() {
+				Attribute("tenant", ArrayOf(String))
+			})
+		})
+		Method("NoLimitMethod", func() {
+			ConcurrencyLimit(0)
+		})
+	})
+}
@@ -0,0 +1,65 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var PaginationDSL = func() {
+	Service("Service", func() {
+		Method("Cursor", func() {
+			Payload(func() {
+				Field(1, "filter", String)
+			})
+			Result(func() {
+				Field(1, "items", ArrayOf(String))
+			})
+			Paginated()
+		})
+		Method("Offset", func() {
+			Result(func() {
+				Attribute("entries", ArrayOf(String))
+			})
+			Paginated(func() {
+				Offset("start")
+				PageSize("size")
+				Items("entries")
+			})
+		})
+	})
+}
+
+var InvalidPaginationDSL = func() {
+	var Page = ResultType("application/vnd.page", func() {
+		Attribute("items", ArrayOf(String))
+		Attribute("next_cursor", String)
+	})
+	Service("Service", func() {
+		Method("Streaming", func() {
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+			})
+			StreamingResult(String)
+			Paginated()
+		})
+		Method("NoItems", func() {
+			Result(func() {
+				Attribute("next_cursor", String)
+			})
+			Paginated()
+		})
+		Method("BadTypes", func() {
+			Payload(func() {
+				Attribute("cursor", Int)
+				Attribute("limit", String)
+			})
+			Result(func() {
+				Attribute("items", String)
+			})
+			Paginated()
+		})
+		Method("ResultType", func() {
+			Result(Page)
+			Paginated()
+		})
+	})
+}
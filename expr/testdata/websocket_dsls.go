@@ -0,0 +1,61 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var WebSocketDSL = func() {
+	Service("Service", func() {
+		HTTP(func() {
+			WebSocket(func() {
+				PingInterval("30s")
+			})
+		})
+		Method("Inherited", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/inherited")
+			})
+		})
+		Method("Overridden", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/overridden")
+				WebSocket(func() {
+					PingInterval("10s")
+				})
+			})
+		})
+		Method("Unary", func() {
+			HTTP(func() {
+				GET("/unary")
+			})
+		})
+	})
+}
+
+var InvalidWebSocketDSL = func() {
+	Service("InvalidWebSocket", func() {
+		Method("Unary", func() {
+			HTTP(func() {
+				GET("/unary")
+				WebSocket(func() {
+					MaxMessageSize(-1)
+				})
+			})
+		})
+		Method("Chat", func() {
+			StreamingPayload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/chat")
+				WebSocket(func() {
+					PongTimeout("5s")
+					Reconnect(func() {
+						Hedge("1s")
+					})
+				})
+			})
+		})
+	})
+}
@@ -38,6 +38,7 @@ func client(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 		fpath = filepath.Join(codegen.Gendir, "grpc", svcName, "client", "client.go")
 		imports := []*codegen.ImportSpec{
 			{Path: "context"},
+			{Path: "time"},
 			{Path: "google.golang.org/grpc"},
 			codegen.GoaImport(""),
 			codegen.GoaNamedImport("grpc", "goagrpc"),
@@ -203,11 +204,29 @@ func New{{ .ClientStruct }}(cc *grpc.ClientConn, opts ...grpc.CallOption) *{{ .C
 const clientEndpointInitT = `{{ printf "%s calls the %q function in %s.%s interface." .Method.VarName .Method.VarName .PkgName .ClientInterface | comment }}
 func (c *{{ .ClientStruct }}) {{ .Method.VarName }}() goa.Endpoint {
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
+		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
 		inv := goagrpc.NewInvoker(
 			Build{{ .Method.VarName }}Func(c.grpccli, c.opts...),
 			{{ if .PayloadRef }}Encode{{ .Method.VarName }}Request{{ else }}nil{{ end }},
 			{{ if or .ResultRef .ClientStream }}Decode{{ .Method.VarName }}Response{{ else }}nil{{ end }})
+	{{- if .Retry }}
+		retry := goa.Retry(&goa.RetryPolicy{
+			MaxAttempts: {{ .Retry.MaxAttempts }},
+			InitialBackoff: {{ .Retry.InitialBackoff }},
+			MaxBackoff: {{ .Retry.MaxBackoff }},
+			{{- if .Retry.Jitter }}
+			Jitter: {{ .Retry.Jitter }},
+			{{- end }}
+			{{- if .Retry.HedgeDelay }}
+			HedgeDelay: {{ .Retry.HedgeDelay }},
+			{{- end }}
+			Retryable: goagrpc.RetryableError({{ if .Retry.Errors }}[]string{ {{ range $i, $e := .Retry.Errors }}{{ if $i }}, {{ end }}{{ printf "%q" $e }}{{ end }} }{{ else }}nil{{ end }}),
+		})
+		res, err := retry(inv.Invoke)(ctx, v)
+	{{- else }}
 		res, err := inv.Invoke(ctx, v)
+	{{- end }}
 		if err != nil {
 		{{- if .Errors }}
 			resp := goagrpc.DecodeError(err)
@@ -416,7 +435,7 @@ func Encode{{ .Method.VarName }}Request(ctx context.Context, v any, md *metadata
 	{{- end }}
 {{- end }}
 {{- if .Request.ClientConvert }}
-	return {{ .Request.ClientConvert.Init.Name }}({{ range .Request.ClientConvert.Init.Args }}{{ .Name }}, {{ end }}), nil
+	return {{ .Request.ClientConvert.Init.Name }}({{ range .Request.ClientConvert.Init.Args }}{{ .Name }}, {{ end }}){{ if not .Request.ClientConvert.Init.ReturnsError }}, nil{{ end }}
 {{- else }}
 	return nil, nil
 {{- end }}
@@ -117,6 +117,7 @@ func payloadBuilders(genpkg string, svc *expr.GRPCServiceExpr, data *cli.Command
 		{Path: "strconv"},
 		{Path: "unicode/utf8"},
 		codegen.GoaImport(""),
+		codegen.GoaNamedImport("grpc", "goagrpc"),
 		{Path: path.Join(genpkg, svcName), Name: sd.Service.PkgName},
 		{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: sd.PkgName},
 	}
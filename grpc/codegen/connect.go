@@ -0,0 +1,177 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// connectEndpointData contains the data used to render the HTTP
+	// handler that serves a gRPC endpoint using the Connect and gRPC-Web
+	// protocols.
+	connectEndpointData struct {
+		*EndpointData
+		// FullMethod is the full name of the gRPC method, e.g.
+		// "/calc.Calc/Add".
+		FullMethod string
+		// RequestType is the name of the generated request message type
+		// in *.pb.go.
+		RequestType string
+		// ResponseType is the name of the generated response message
+		// type in *.pb.go.
+		ResponseType string
+		// StreamStruct is the name of the struct that implements the
+		// stream interface in *.pb.go for streaming endpoints.
+		StreamStruct string
+		// SendName is the name of the stream interface method that sends
+		// the responses, "SendAndClose" for client streaming endpoints.
+		SendName string
+		// IsClientStream is true if the client streams the requests.
+		IsClientStream bool
+		// IsServerStream is true if the server streams the responses.
+		IsServerStream bool
+	}
+)
+
+// connectEnabled returns true if the HTTP handlers that serve the methods of
+// the given service using the Connect and gRPC-Web protocols must be
+// generated, see the "grpc:connect" meta. The service meta takes precedence
+// over the API meta.
+func connectEnabled(svc *expr.GRPCServiceExpr) bool {
+	for _, meta := range []expr.MetaExpr{svc.ServiceExpr.Meta, expr.Root.API.Meta} {
+		if v, ok := meta["grpc:connect"]; ok {
+			return len(v) == 0 || v[len(v)-1] != "false"
+		}
+	}
+	return false
+}
+
+// connectServerFile returns the file defining the HTTP handlers that serve
+// the gRPC methods of the given service using the Connect and gRPC-Web
+// protocols. It returns nil if the handlers are not enabled for the service.
+func connectServerFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
+	if !connectEnabled(svc) {
+		return nil
+	}
+	data := GRPCServices.Get(svc.Name())
+	svcName := data.Service.PathName
+	fpath := filepath.Join(codegen.Gendir, "grpc", svcName, "server", "connect.go")
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "net/http"},
+		{Path: "google.golang.org/grpc"},
+		{Path: "google.golang.org/protobuf/proto"},
+		codegen.GoaNamedImport("grpc", "goagrpc"),
+		codegen.GoaNamedImport("http", "goahttp"),
+		{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: data.PkgName},
+	}
+	var (
+		prefix    = "/" + pkgName(svc, svcName) + "." + data.Name + "/"
+		endpoints = make([]*connectEndpointData, len(data.Endpoints))
+	)
+	for i, e := range data.Endpoints {
+		ed := &connectEndpointData{
+			EndpointData: e,
+			FullMethod:   prefix + e.Method.VarName,
+			RequestType:  strings.TrimPrefix(e.Request.Message.Ref, "*"),
+			ResponseType: strings.TrimPrefix(e.Response.Message.Ref, "*"),
+		}
+		if e.ServerStream != nil {
+			ed.StreamStruct = codegen.Goify(e.Method.VarName+"ConnectServerStream", false)
+			ed.SendName = "Send"
+			ed.IsClientStream = e.Method.StreamKind != expr.ServerStreamKind
+			ed.IsServerStream = e.Method.StreamKind != expr.ClientStreamKind
+			if !ed.IsServerStream {
+				ed.SendName = "SendAndClose"
+			}
+		}
+		endpoints[i] = ed
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(svc.Name()+" Connect and gRPC-Web handlers", "server", imports),
+		{
+			Name:   "server-connect-mount",
+			Source: connectMountT,
+			Data:   map[string]any{"Service": data.Service, "Endpoints": endpoints},
+		},
+	}
+	for _, e := range endpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "server-connect-handler",
+			Source: connectHandlerT,
+			Data:   e,
+		})
+	}
+	for _, e := range endpoints {
+		if e.ServerStream != nil {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "server-connect-stream",
+				Source: connectStreamT,
+				Data:   e,
+			})
+		}
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// input: map[string]any{"Service":*service.Data, "Endpoints":[]*connectEndpointData}
+const connectMountT = `{{ printf "MountConnect configures the mux to serve the %q service gRPC methods using the Connect and gRPC-Web protocols. The handlers run the given interceptors the same way as a gRPC server configured with grpc.ChainUnaryInterceptor(unary...) and grpc.ChainStreamInterceptor(stream...)." .Service.Name | comment }}
+func MountConnect(mux goahttp.Muxer, srv *Server, unary []grpc.UnaryServerInterceptor, stream []grpc.StreamServerInterceptor) {
+{{- range .Endpoints }}
+	mux.Handle("POST", {{ printf "%q" .FullMethod }}, New{{ .Method.VarName }}ConnectHandler(srv, {{ if .ServerStream }}stream{{ else }}unary{{ end }}...).ServeHTTP)
+{{- end }}
+}
+`
+
+// input: connectEndpointData
+const connectHandlerT = `{{ printf "New%sConnectHandler creates a HTTP handler which serves the %q service %q gRPC method using the Connect and gRPC-Web protocols." .Method.VarName .ServiceName .Method.Name | comment }}
+func New{{ .Method.VarName }}ConnectHandler(srv *Server, interceptors ...grpc.{{ if .ServerStream }}Stream{{ else }}Unary{{ end }}ServerInterceptor) http.Handler {
+{{- if .ServerStream }}
+	info := &grpc.StreamServerInfo{FullMethod: {{ printf "%q" .FullMethod }}, IsClientStream: {{ .IsClientStream }}, IsServerStream: {{ .IsServerStream }}}
+	return goagrpc.NewConnectStreamHandler(info, func(stream grpc.ServerStream) error {
+	{{- if .Method.StreamingPayload }}
+		return srv.{{ .Method.VarName }}(&{{ .StreamStruct }}{stream})
+	{{- else }}
+		message := &{{ .RequestType }}{}
+		if err := stream.RecvMsg(message); err != nil {
+			return err
+		}
+		return srv.{{ .Method.VarName }}(message, &{{ .StreamStruct }}{stream})
+	{{- end }}
+	}, interceptors...)
+{{- else }}
+	return goagrpc.NewConnectUnaryHandler({{ printf "%q" .FullMethod }},
+		func() proto.Message { return &{{ .RequestType }}{} },
+		func(ctx context.Context, message proto.Message) (proto.Message, error) {
+			return srv.{{ .Method.VarName }}(ctx, message.({{ .Request.Message.Ref }}))
+		}, interceptors...)
+{{- end }}
+}
+`
+
+// input: connectEndpointData
+const connectStreamT = `{{ printf "%s implements the %s interface using a Connect or gRPC-Web stream." .StreamStruct .ServerStream.Interface | comment }}
+type {{ .StreamStruct }} struct {
+	grpc.ServerStream
+}
+
+{{ printf "%s writes instances of %q to the stream." .SendName .ResponseType | comment }}
+func (s *{{ .StreamStruct }}) {{ .SendName }}(m {{ .Response.Message.Ref }}) error {
+	return s.SendMsg(m)
+}
+{{- if .Method.StreamingPayload }}
+
+{{ printf "Recv reads instances of %q from the stream." .RequestType | comment }}
+func (s *{{ .StreamStruct }}) Recv() ({{ .Request.Message.Ref }}, error) {
+	m := &{{ .RequestType }}{}
+	if err := s.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+{{- end }}
+`
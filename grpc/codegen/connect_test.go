@@ -0,0 +1,45 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/grpc/codegen/testdata"
+)
+
+func TestServerConnect(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Section string
+		Code    string
+	}{
+		{"mount", "server-connect-mount", testdata.ConnectMountCode},
+		{"handler", "server-connect-handler", testdata.ConnectHandlerCode},
+		{"stream", "server-connect-stream", testdata.ConnectStreamCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunGRPCDSL(t, testdata.ConnectDSL)
+			fs := ServerFiles("", expr.Root)
+			if len(fs) != 3 {
+				t.Fatalf("got %d files, expected three", len(fs))
+			}
+			sections := fs[2].Section(c.Section)
+			if len(sections) == 0 {
+				t.Fatalf("got zero sections, expected at least one")
+			}
+			code := codegen.SectionsCode(t, sections)
+			if code != c.Code {
+				t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", c.Name, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestServerConnectDisabled(t *testing.T) {
+	RunGRPCDSL(t, testdata.UnaryRPCsDSL)
+	if fs := ServerFiles("", expr.Root); len(fs) != 2 {
+		t.Errorf("got %d files, expected two", len(fs))
+	}
+}
@@ -6,8 +6,16 @@ compiler (protoc) generated clients and servers.
 The code generator uses "proto3" syntax for generating the proto files.
 
 The code generator compiles the proto files using the protocol buffer compiler
-(protoc) with the gRPC in Go plugin. It hooks up the generated protocol buffer
-types to the goa generated types as follows:
+(protoc) with the gRPC in Go plugin. Alternatively the "protoc:builtin" meta
+makes the code generator build the file descriptors from the design and
+generate the Go protocol buffer types and gRPC stubs with the protoc-gen-go
+plugin of the google.golang.org/protobuf module so that protoc and its
+plugins do not need to be installed. The "grpc:reflection" meta adds
+the design documentation and validations to the descriptors as custom options
+for the clients that use the gRPC server reflection service. The "grpc:connect"
+meta generates HTTP handlers that serve the gRPC methods using the Connect and
+gRPC-Web protocols. It hooks up the generated protocol
+buffer types to the goa generated types as follows:
 
 	* It generates a server that implements the protoc-generated gRPC server interface.
 	* It generates a client that invokes the protoc-generated gRPC client.
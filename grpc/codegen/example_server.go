@@ -53,6 +53,7 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 			codegen.GoaNamedImport("grpc", "goagrpc"),
 			codegen.GoaNamedImport("grpc/middleware", "grpcmdlwr"),
 			{Path: "google.golang.org/grpc"},
+			{Path: "google.golang.org/grpc/health/grpc_health_v1"},
 			{Path: "google.golang.org/grpc/reflection"},
 		}
 		for _, svc := range root.API.GRPC.Services {
@@ -124,6 +125,7 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 				FuncMap: map[string]any{
 					"goify":      codegen.Goify,
 					"needStream": needStream,
+					"needHealth": needHealth,
 				},
 			}, {
 				Name:   "server-grpc-end",
@@ -150,6 +152,17 @@ func needStream(data []*ServiceData) bool {
 	return false
 }
 
+// needHealth returns true if at least one of the services defines health
+// checks.
+func needHealth(data []*ServiceData) bool {
+	for _, svc := range data {
+		if svc.Service.Health != nil {
+			return true
+		}
+	}
+	return false
+}
+
 const (
 	// input: map[string]any{"Services":[]*ServiceData}
 	grpcSvrStartT = `{{ comment "handleGRPCServer starts configures and starts a gRPC server on the given URL. It shuts down the server if any error is received in the error channel." }}
@@ -208,6 +221,17 @@ func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	{{- range .Services }}
 	{{ .PkgName }}.Register{{ goify .Service.VarName true }}Server(srv, {{ .Service.VarName }}Server)
 	{{- end }}
+{{- if needHealth .Services }}
+
+	// Register the health checks with the standard gRPC health service.
+	hs := goagrpc.NewHealthServer()
+	{{- range .Services }}
+		{{- if .Service.Health }}
+	{{ .Service.PkgName }}svr.RegisterHealth(hs, {{ .Service.VarName }}Endpoints)
+		{{- end }}
+	{{- end }}
+	grpc_health_v1.RegisterHealthServer(srv, hs)
+{{- end }}
 
 	for svc, info := range srv.GetServiceInfo() {
 		for _, m := range info.Methods {
@@ -9,6 +9,7 @@ import (
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
+	goapb "goa.design/goa/v3/grpc/pb"
 	goa "goa.design/goa/v3/pkg"
 )
 
@@ -68,6 +69,33 @@ func protoFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 			Name:   "grpc-service",
 			Source: serviceT,
 			Data:   data,
+			FuncMap: map[string]any{
+				"serviceOptions": func() []string {
+					var opts []string
+					if expr.DeprecationOf(svc.ServiceExpr.Meta) != nil {
+						opts = append(opts, "deprecated = true")
+					}
+					if data.Reflection {
+						if opt := protoOptionText(goapb.E_Service, protoServiceOptions(svc)); opt != "" {
+							opts = append(opts, opt)
+						}
+					}
+					return opts
+				},
+				"methodOptions": func(name string) []string {
+					e := svc.Endpoint(name)
+					var opts []string
+					if expr.DeprecationOf(e.MethodExpr.Meta) != nil {
+						opts = append(opts, "deprecated = true")
+					}
+					if data.Reflection {
+						if opt := protoOptionText(goapb.E_Method, protoMethodOptions(e)); opt != "" {
+							opts = append(opts, opt)
+						}
+					}
+					return opts
+				},
+			},
 		},
 	}
 
@@ -79,6 +107,22 @@ func protoFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 	runProtoc := func(path string) error {
 		includes := svc.ServiceExpr.Meta["protoc:include"]
 		includes = append(includes, expr.Root.API.Meta["protoc:include"]...)
+		if protocBuiltin(svc) {
+			return compileProto(path, svc, includes)
+		}
+		if data.Reflection {
+			// make the file that defines the Goa options available to protoc.
+			dir, err := os.MkdirTemp("", "goa-proto")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(dir)
+			fname := filepath.Join(dir, goapb.File_goadesign_goa_options_proto.Path())
+			if err := os.WriteFile(fname, goapb.OptionsProto, 0644); err != nil {
+				return err
+			}
+			includes = append(includes, dir)
+		}
 		return protoc(path, includes)
 	}
 
@@ -149,11 +193,20 @@ import "{{ . }}";
 	serviceT = `
 {{ .Description | comment }}
 service {{ .Name }} {
+	{{- range serviceOptions }}
+	option {{ . }};
+	{{- end }}
 	{{- range .Endpoints }}
 	{{ if .Method.Description }}{{ .Method.Description | comment }}{{ end }}
 	{{- $serverStream := or (eq .Method.StreamKind 3) (eq .Method.StreamKind 4) }}
 	{{- $clientStream := or (eq .Method.StreamKind 2) (eq .Method.StreamKind 4) }}
-	rpc {{ .Method.VarName }} ({{ if $clientStream }}stream {{ end }}{{ .Request.Message.VarName }}) returns ({{ if $serverStream }}stream {{ end }}{{ .Response.Message.VarName }});
+	rpc {{ .Method.VarName }} ({{ if $clientStream }}stream {{ end }}{{ .Request.Message.VarName }}) returns ({{ if $serverStream }}stream {{ end }}{{ .Response.Message.VarName }})
+	{{- with methodOptions .Method.Name }} {
+		{{- range . }}
+		option {{ . }};
+		{{- end }}
+	}
+	{{- else }};{{ end }}
 	{{- end }}
 }
 `
@@ -0,0 +1,505 @@
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"goa.design/goa/v3/expr"
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+type (
+	// grpcStubsData is the data used to render the gRPC stubs.
+	grpcStubsData struct {
+		// Header is the comment that precedes the package clause.
+		Header string
+		// Source is the path of the .proto file.
+		Source string
+		// GoPackage is the name of the generated Go package.
+		GoPackage string
+		// Services lists the services defined in the .proto file.
+		Services []*grpcStubsServiceData
+	}
+
+	// grpcStubsServiceData describes a service defined in the .proto file.
+	grpcStubsServiceData struct {
+		// GoName is the Go name of the service.
+		GoName string
+		// FullName is the fully qualified protocol buffer name of the
+		// service.
+		FullName string
+		// Methods lists the service methods.
+		Methods []*grpcStubsMethodData
+	}
+
+	// grpcStubsMethodData describes a service method.
+	grpcStubsMethodData struct {
+		// Service is the Go name of the service.
+		Service string
+		// Name is the protocol buffer name of the method.
+		Name string
+		// GoName is the Go name of the method.
+		GoName string
+		// Comments is the method leading comment.
+		Comments string
+		// Input is the reference to the Go type of the request message.
+		Input string
+		// Output is the reference to the Go type of the response message.
+		Output string
+		// ClientStream is true if the client streams the requests.
+		ClientStream bool
+		// ServerStream is true if the server streams the responses.
+		ServerStream bool
+		// StreamIndex is the index of the method in the streams of the
+		// service descriptor if the method is a streaming method.
+		StreamIndex int
+	}
+)
+
+// protocBuiltin returns true if the .proto file of the given service must be
+// compiled in-process rather than by running protoc, see the "protoc:builtin"
+// meta. The service meta takes precedence over the API meta.
+func protocBuiltin(svc *expr.GRPCServiceExpr) bool {
+	for _, meta := range []expr.MetaExpr{svc.ServiceExpr.Meta, expr.Root.API.Meta} {
+		if v, ok := meta["protoc:builtin"]; ok {
+			return len(v) == 0 || v[len(v)-1] != "false"
+		}
+	}
+	return false
+}
+
+// protocGenGo is the package of the protoc-gen-go plugin used to generate
+// the Go protocol buffer types.
+const protocGenGo = "google.golang.org/protobuf/cmd/protoc-gen-go"
+
+// compileProto generates the Go protocol buffer types (*.pb.go) and gRPC
+// stubs (*_grpc.pb.go) of the .proto file generated for the given service
+// at path without running protoc. The file descriptor is built from the
+// service expressions and the imported files are resolved using the given
+// include paths, see protoDependencies. The generated code is equivalent to
+// the code generated by protoc-gen-go and protoc-gen-go-grpc with the
+// "paths=source_relative" option. The protocol buffer types are generated by
+// the protoc-gen-go plugin of the google.golang.org/protobuf module required
+// by the current module, see runProtocGenGo, which makes the generation
+// reproducible.
+func compileProto(path string, svc *expr.GRPCServiceExpr, includes []string) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	sd := GRPCServices.Get(svc.Name())
+	fd, err := protoFileDescriptor(filepath.Base(path), protoHeaderComment(string(src)), svc, sd)
+	if err != nil {
+		return fmt.Errorf("failed to compile %s: %w", path, err)
+	}
+	dir := filepath.Dir(path)
+	deps, err := protoDependencies(fd, dir, includes)
+	if err != nil {
+		return fmt.Errorf("failed to compile %s: %w", path, err)
+	}
+	if err := resolveProtoTypes(fd, deps); err != nil {
+		return fmt.Errorf("failed to compile %s: %w", path, err)
+	}
+	files, err := generateProtoGo(fd, deps)
+	if err != nil {
+		return fmt.Errorf("failed to compile %s: %w", path, err)
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(f.GetName())), []byte(f.GetContent()), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateProtoGo generates the Go code of the file described by fd. The
+// protocol buffer types are generated by protoc-gen-go and the gRPC stubs
+// using protogen. deps contains the descriptors of the files fd depends on.
+func generateProtoGo(fd *descriptorpb.FileDescriptorProto, deps []*descriptorpb.FileDescriptorProto) ([]*pluginpb.CodeGeneratorResponse_File, error) {
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{fd.GetName()},
+		Parameter:      proto.String("paths=source_relative"),
+		ProtoFile:      append(append([]*descriptorpb.FileDescriptorProto(nil), deps...), fd),
+	}
+	files, err := runProtocGenGo(req)
+	if err != nil {
+		return nil, err
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := generateGRPCStubs(gen, gen.FilesByPath[fd.GetName()]); err != nil {
+		return nil, err
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		return nil, errors.New(resp.GetError())
+	}
+	return append(files, resp.File...), nil
+}
+
+// runProtocGenGo runs the protoc-gen-go plugin with the given request and
+// returns the generated files. The plugin is run with "go run" so that its
+// version is the version of the google.golang.org/protobuf module required
+// by the current module, i.e. the version of the runtime the generated code
+// is compiled with.
+func runProtocGenGo(req *pluginpb.CodeGeneratorRequest) ([]*pluginpb.CodeGeneratorResponse_File, error) {
+	in, err := proto.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", "run", protocGenGo)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w\n%s", protocGenGo, err, stderr.String())
+	}
+	var resp pluginpb.CodeGeneratorResponse
+	if err := proto.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("invalid %s response: %w", protocGenGo, err)
+	}
+	if resp.Error != nil {
+		return nil, errors.New(resp.GetError())
+	}
+	return resp.File, nil
+}
+
+// generateGRPCStubs generates the gRPC client and server stubs of the services
+// defined in f. The generated code is compatible with the code generated by
+// protoc-gen-go-grpc.
+func generateGRPCStubs(gen *protogen.Plugin, f *protogen.File) error {
+	if len(f.Services) == 0 {
+		return nil
+	}
+	g := gen.NewGeneratedFile(f.GeneratedFilenamePrefix+"_grpc.pb.go", f.GoImportPath)
+	data := &grpcStubsData{
+		Source:    f.Desc.Path(),
+		GoPackage: string(f.GoPackageName),
+	}
+	{
+		var header strings.Builder
+		loc := f.Desc.SourceLocations().ByPath([]int32{12}) // syntax
+		for _, c := range loc.LeadingDetachedComments {
+			header.WriteString(protogen.Comments(c).String() + "\n")
+		}
+		data.Header = header.String()
+	}
+	for _, s := range f.Services {
+		svc := &grpcStubsServiceData{GoName: s.GoName, FullName: string(s.Desc.FullName())}
+		var streams int
+		for _, m := range s.Methods {
+			md := &grpcStubsMethodData{
+				Service:      s.GoName,
+				Name:         string(m.Desc.Name()),
+				GoName:       m.GoName,
+				Comments:     m.Comments.Leading.String(),
+				Input:        g.QualifiedGoIdent(m.Input.GoIdent),
+				Output:       g.QualifiedGoIdent(m.Output.GoIdent),
+				ClientStream: m.Desc.IsStreamingClient(),
+				ServerStream: m.Desc.IsStreamingServer(),
+			}
+			if md.ClientStream || md.ServerStream {
+				md.StreamIndex = streams
+				streams++
+			}
+			svc.Methods = append(svc.Methods, md)
+		}
+		data.Services = append(data.Services, svc)
+	}
+	ident := func(pkg protogen.GoImportPath) func(string) string {
+		return func(name string) string { return g.QualifiedGoIdent(pkg.Ident(name)) }
+	}
+	funcs := template.FuncMap{
+		"context":  ident("context"),
+		"grpc":     ident("google.golang.org/grpc"),
+		"codes":    ident("google.golang.org/grpc/codes"),
+		"status":   ident("google.golang.org/grpc/status"),
+		"unexport": func(s string) string { return strings.ToLower(s[:1]) + s[1:] },
+		"version":  goa.Version,
+	}
+	t, err := template.New("grpc-stubs").Funcs(funcs).Parse(grpcStubsT)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return err
+	}
+	_, err = g.Write(buf.Bytes())
+	return err
+}
+
+// protoHeaderComment returns the comment that precedes the first statement
+// of the given .proto file formatted like protoc formats the leading
+// detached comments.
+func protoHeaderComment(src string) string {
+	var c strings.Builder
+	for _, l := range strings.Split(src, "\n") {
+		if !strings.HasPrefix(l, "//") {
+			break
+		}
+		c.WriteString(strings.TrimPrefix(l, "//") + "\n")
+	}
+	return c.String()
+}
+
+// input: grpcStubsData
+const grpcStubsT = `{{ .Header }}// Code generated by goa. DO NOT EDIT.
+// versions:
+// - goa {{ version }}
+// source: {{ .Source }}
+
+package {{ .GoPackage }}
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = {{ grpc "SupportPackageIsVersion7" }}
+{{- range .Services }}
+{{- $svc := . }}
+
+const (
+{{- range .Methods }}
+	{{ $svc.GoName }}_{{ .GoName }}_FullMethodName = "/{{ $svc.FullName }}/{{ .Name }}"
+{{- end }}
+)
+
+// {{ .GoName }}Client is the client API for {{ .GoName }} service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type {{ .GoName }}Client interface {
+{{- range .Methods }}
+	{{ .Comments }}{{ template "client-signature" . }}
+{{- end }}
+}
+
+type {{ unexport .GoName }}Client struct {
+	cc {{ grpc "ClientConnInterface" }}
+}
+
+func New{{ .GoName }}Client(cc {{ grpc "ClientConnInterface" }}) {{ .GoName }}Client {
+	return &{{ unexport .GoName }}Client{cc}
+}
+{{- range .Methods }}
+
+func (c *{{ unexport .Service }}Client) {{ template "client-signature" . }} {
+	{{- if not (or .ClientStream .ServerStream) }}
+	out := new({{ .Output }})
+	err := c.cc.Invoke(ctx, {{ .Service }}_{{ .GoName }}_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+	{{- else }}
+	stream, err := c.cc.NewStream(ctx, &{{ .Service }}_ServiceDesc.Streams[{{ .StreamIndex }}], {{ .Service }}_{{ .GoName }}_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &{{ unexport .Service }}{{ .GoName }}Client{stream}
+	{{- if not .ClientStream }}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	{{- end }}
+	return x, nil
+}
+
+type {{ .Service }}_{{ .GoName }}Client interface {
+	{{- if .ClientStream }}
+	Send(*{{ .Input }}) error
+	{{- end }}
+	{{- if .ServerStream }}
+	Recv() (*{{ .Output }}, error)
+	{{- else }}
+	CloseAndRecv() (*{{ .Output }}, error)
+	{{- end }}
+	{{ grpc "ClientStream" }}
+}
+
+type {{ unexport .Service }}{{ .GoName }}Client struct {
+	{{ grpc "ClientStream" }}
+}
+	{{- if .ClientStream }}
+
+func (x *{{ unexport .Service }}{{ .GoName }}Client) Send(m *{{ .Input }}) error {
+	return x.ClientStream.SendMsg(m)
+}
+	{{- end }}
+	{{- if .ServerStream }}
+
+func (x *{{ unexport .Service }}{{ .GoName }}Client) Recv() (*{{ .Output }}, error) {
+	m := new({{ .Output }})
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+	{{- else }}
+
+func (x *{{ unexport .Service }}{{ .GoName }}Client) CloseAndRecv() (*{{ .Output }}, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new({{ .Output }})
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+	{{- end }}
+	{{- end }}
+{{- end }}
+
+// {{ .GoName }}Server is the server API for {{ .GoName }} service.
+// All implementations must embed Unimplemented{{ .GoName }}Server
+// for forward compatibility
+type {{ .GoName }}Server interface {
+{{- range .Methods }}
+	{{ .Comments }}{{ template "server-signature" . }}
+{{- end }}
+	mustEmbedUnimplemented{{ .GoName }}Server()
+}
+
+// Unimplemented{{ .GoName }}Server must be embedded to have forward compatible implementations.
+type Unimplemented{{ .GoName }}Server struct {
+}
+{{ range .Methods }}
+func (Unimplemented{{ .Service }}Server) {{ template "server-signature" . }} {
+	return {{ if not (or .ClientStream .ServerStream) }}nil, {{ end }}{{ status "Errorf" }}({{ codes "Unimplemented" }}, "method {{ .GoName }} not implemented")
+}
+{{- end }}
+func (Unimplemented{{ .GoName }}Server) mustEmbedUnimplemented{{ .GoName }}Server() {}
+
+// Unsafe{{ .GoName }}Server may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to {{ .GoName }}Server will
+// result in compilation errors.
+type Unsafe{{ .GoName }}Server interface {
+	mustEmbedUnimplemented{{ .GoName }}Server()
+}
+
+func Register{{ .GoName }}Server(s {{ grpc "ServiceRegistrar" }}, srv {{ .GoName }}Server) {
+	s.RegisterService(&{{ .GoName }}_ServiceDesc, srv)
+}
+{{- range .Methods }}
+	{{- if not (or .ClientStream .ServerStream) }}
+
+func _{{ .Service }}_{{ .GoName }}_Handler(srv interface{}, ctx {{ context "Context" }}, dec func(interface{}) error, interceptor {{ grpc "UnaryServerInterceptor" }}) (interface{}, error) {
+	in := new({{ .Input }})
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.({{ .Service }}Server).{{ .GoName }}(ctx, in)
+	}
+	info := &{{ grpc "UnaryServerInfo" }}{
+		Server:     srv,
+		FullMethod: {{ .Service }}_{{ .GoName }}_FullMethodName,
+	}
+	handler := func(ctx {{ context "Context" }}, req interface{}) (interface{}, error) {
+		return srv.({{ .Service }}Server).{{ .GoName }}(ctx, req.(*{{ .Input }}))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+	{{- else }}
+
+func _{{ .Service }}_{{ .GoName }}_Handler(srv interface{}, stream {{ grpc "ServerStream" }}) error {
+	{{- if .ClientStream }}
+	return srv.({{ .Service }}Server).{{ .GoName }}(&{{ unexport .Service }}{{ .GoName }}Server{stream})
+	{{- else }}
+	m := new({{ .Input }})
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.({{ .Service }}Server).{{ .GoName }}(m, &{{ unexport .Service }}{{ .GoName }}Server{stream})
+	{{- end }}
+}
+
+type {{ .Service }}_{{ .GoName }}Server interface {
+	{{- if .ServerStream }}
+	Send(*{{ .Output }}) error
+	{{- else }}
+	SendAndClose(*{{ .Output }}) error
+	{{- end }}
+	{{- if .ClientStream }}
+	Recv() (*{{ .Input }}, error)
+	{{- end }}
+	{{ grpc "ServerStream" }}
+}
+
+type {{ unexport .Service }}{{ .GoName }}Server struct {
+	{{ grpc "ServerStream" }}
+}
+
+func (x *{{ unexport .Service }}{{ .GoName }}Server) {{ if .ServerStream }}Send{{ else }}SendAndClose{{ end }}(m *{{ .Output }}) error {
+	return x.ServerStream.SendMsg(m)
+}
+		{{- if .ClientStream }}
+
+func (x *{{ unexport .Service }}{{ .GoName }}Server) Recv() (*{{ .Input }}, error) {
+	m := new({{ .Input }})
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+		{{- end }}
+	{{- end }}
+{{- end }}
+
+// {{ .GoName }}_ServiceDesc is the {{ grpc "ServiceDesc" }} for {{ .GoName }} service.
+// It's only intended for direct use with {{ grpc "RegisterService" }},
+// and not to be introspected or modified (even as a copy)
+var {{ .GoName }}_ServiceDesc = {{ grpc "ServiceDesc" }}{
+	ServiceName: "{{ .FullName }}",
+	HandlerType: (*{{ .GoName }}Server)(nil),
+	Methods: []{{ grpc "MethodDesc" }}{
+	{{- range .Methods }}
+		{{- if not (or .ClientStream .ServerStream) }}
+		{
+			MethodName: "{{ .Name }}",
+			Handler:    _{{ .Service }}_{{ .GoName }}_Handler,
+		},
+		{{- end }}
+	{{- end }}
+	},
+	Streams: []{{ grpc "StreamDesc" }}{
+	{{- range .Methods }}
+		{{- if or .ClientStream .ServerStream }}
+		{
+			StreamName:    "{{ .Name }}",
+			Handler:       _{{ .Service }}_{{ .GoName }}_Handler,
+			{{- if .ServerStream }}
+			ServerStreams: true,
+			{{- end }}
+			{{- if .ClientStream }}
+			ClientStreams: true,
+			{{- end }}
+		},
+		{{- end }}
+	{{- end }}
+	},
+	Metadata: "{{ $.Source }}",
+}
+{{- end }}
+
+{{- define "client-signature" }}{{ .GoName }}(ctx {{ context "Context" }}{{ if not .ClientStream }}, in *{{ .Input }}{{ end }}, opts ...{{ grpc "CallOption" }}) ({{ if or .ClientStream .ServerStream }}{{ .Service }}_{{ .GoName }}Client{{ else }}*{{ .Output }}{{ end }}, error){{ end }}
+
+{{- define "server-signature" }}{{ .GoName }}({{ if not (or .ClientStream .ServerStream) }}{{ context "Context" }}, {{ end }}{{ if not .ClientStream }}*{{ .Input }}{{ end }}{{ if or .ClientStream .ServerStream }}{{ if not .ClientStream }}, {{ end }}{{ .Service }}_{{ .GoName }}Server{{ end }}) {{ if not (or .ClientStream .ServerStream) }}(*{{ .Output }}, error){{ else }}error{{ end }}{{ end }}
+`
@@ -0,0 +1,204 @@
+package codegen
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/grpc/codegen/testdata"
+)
+
+func TestCompileProto(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"unary-rpcs", testdata.UnaryRPCsDSL},
+		{"unary-rpc-no-payload", testdata.UnaryRPCNoPayloadDSL},
+		{"unary-rpc-no-result", testdata.UnaryRPCNoResultDSL},
+		{"server-streaming-rpc", testdata.ServerStreamingRPCDSL},
+		{"client-streaming-rpc", testdata.ClientStreamingRPCDSL},
+		{"bidirectional-streaming-rpc", testdata.BidirectionalStreamingRPCDSL},
+		{"same-service-and-message-name", testdata.MessageWithServiceNameDSL},
+		{"method-with-reserved-proto-name", testdata.MethodWithReservedNameDSL},
+		{"multiple-methods-same-return-type", testdata.MultipleMethodsSameResultCollectionDSL},
+		{"method-with-acronym", testdata.MethodWithAcronymDSL},
+		{"custom-package-name", testdata.ServiceWithPackageDSL},
+		{"struct-meta-type", testdata.StructMetaTypeDSL},
+		{"default-fields", testdata.DefaultFieldsDSL},
+		{"well-known-types", testdata.WellKnownTypesDSL},
+	}
+	// The generated packages are created in the module so that they can be
+	// compiled against its dependencies.
+	root, err := os.MkdirTemp("testdata", "compile")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(root) })
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunGRPCDSL(t, c.DSL)
+			dir := filepath.Join(root, c.Name)
+			require.NoError(t, os.Mkdir(dir, 0777))
+			path := renderProtoFile(t, dir)
+
+			svc := expr.Root.API.GRPC.Services[0]
+			require.NoError(t, compileProto(path, svc, nil))
+
+			name := GRPCServices.Get(svc.Name()).Name
+			prefix := strings.TrimSuffix(path, ".proto")
+			pb := parseGoFile(t, prefix+".pb.go")
+			stubs := parseGoFile(t, prefix+"_grpc.pb.go")
+			assert.Equal(t, strings.SplitN(pb, "\n", 2)[0], strings.SplitN(stubs, "\n", 2)[0])
+			for _, sym := range []string{
+				"func New" + name + "Client(",
+				"func Register" + name + "Server(",
+				"type Unimplemented" + name + "Server struct",
+				"var " + name + "_ServiceDesc = grpc.ServiceDesc{",
+			} {
+				assert.Contains(t, stubs, sym)
+			}
+		})
+	}
+	out, err := exec.Command("go", "build", "./"+filepath.ToSlash(root)+"/...").CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestCompileProtoImports(t *testing.T) {
+	include := t.TempDir()
+	files := map[string]string{
+		"ext/money.proto": `syntax = "proto3";
+package ext.v1;
+import "ext/currency.proto";
+option go_package = "example.com/extpb";
+message Money { Currency currency = 1; }
+enum Color { COLOR_UNSPECIFIED = 0; }
+`,
+		"ext/currency.proto": `syntax = "proto3";
+package ext.v1;
+import public "google/protobuf/timestamp.proto";
+option go_package = "example.com/extpb";
+message Currency { string code = 1; }
+`,
+		"other/thing.proto": `syntax = "proto3";
+package other.v1;
+option go_package = "example.com/otherpb";
+message Thing {}
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(include, filepath.Dir(name)), 0777))
+		require.NoError(t, os.WriteFile(filepath.Join(include, name), []byte(content), 0600))
+	}
+	RunGRPCDSL(t, testdata.ProtoImportsDSL)
+	path := renderProtoFile(t, t.TempDir())
+
+	require.NoError(t, compileProto(path, expr.Root.API.GRPC.Services[0], []string{include}))
+
+	pb := parseGoFile(t, strings.TrimSuffix(path, ".proto")+".pb.go")
+	assert.Contains(t, pb, `extpb "example.com/extpb"`)
+	assert.Contains(t, pb, `otherpb "example.com/otherpb"`)
+	assert.Contains(t, pb, "Price *extpb.Money ")
+	assert.Contains(t, pb, "Color *extpb.Color ")
+	assert.Contains(t, pb, "Thing *otherpb.Thing ")
+
+	RunGRPCDSL(t, testdata.ProtoImportsDSL)
+	path = renderProtoFile(t, t.TempDir())
+	err := compileProto(path, expr.Root.API.GRPC.Services[0], nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `import "ext/money.proto" not found`)
+	assert.Contains(t, err.Error(), `"protoc:include"`)
+
+	require.NoError(t, os.Remove(filepath.Join(include, "ext", "currency.proto")))
+	err = compileProto(path, expr.Root.API.GRPC.Services[0], []string{include})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `ext/money.proto: import "ext/currency.proto" not found`)
+}
+
+func TestScanProtoFile(t *testing.T) {
+	const src = `// Comment with a { brace.
+syntax = "proto3";
+
+package foo.v1;
+
+import "google/protobuf/timestamp.proto";
+
+option go_package = "example.com/foo;foopb";
+
+/* message Commented {} */
+message Outer {
+	message Inner {
+		enum Kind { KIND_UNSPECIFIED = 0; }
+	}
+	oneof choice { string a = 1; int32 b = 2; }
+	map<string, Inner> inners = 3 [deprecated = true];
+	reserved 10 to 12;
+}
+
+enum Color {
+	option allow_alias = true;
+	COLOR_UNSPECIFIED = 0;
+	RED = 1;
+	CRIMSON = 1 [deprecated = true];
+	BLACK = -1;
+}
+
+service Ignored {
+	rpc Method (Outer) returns (Outer);
+}
+`
+	fd, err := scanProtoFile("foo/foo.proto", src)
+	require.NoError(t, err)
+	assert.Equal(t, "foo/foo.proto", fd.GetName())
+	assert.Equal(t, "proto3", fd.GetSyntax())
+	assert.Equal(t, "foo.v1", fd.GetPackage())
+	assert.Equal(t, "example.com/foo;foopb", fd.GetOptions().GetGoPackage())
+	symbols := make(map[string]descriptorpb.FieldDescriptorProto_Type)
+	addProtoSymbols(symbols, fd.GetPackage(), fd.MessageType, fd.EnumType)
+	assert.Equal(t, map[string]descriptorpb.FieldDescriptorProto_Type{
+		"foo.v1.Outer":            descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+		"foo.v1.Outer.Inner":      descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+		"foo.v1.Outer.Inner.Kind": descriptorpb.FieldDescriptorProto_TYPE_ENUM,
+		"foo.v1.Color":            descriptorpb.FieldDescriptorProto_TYPE_ENUM,
+	}, symbols)
+	require.Len(t, fd.EnumType, 1)
+	color := fd.EnumType[0]
+	assert.True(t, color.GetOptions().GetAllowAlias())
+	var values []string
+	for _, v := range color.Value {
+		values = append(values, v.GetName())
+	}
+	assert.Equal(t, []string{"COLOR_UNSPECIFIED", "RED", "CRIMSON", "BLACK"}, values)
+	assert.Equal(t, int32(-1), color.Value[3].GetNumber())
+}
+
+// renderProtoFile writes the .proto file generated for the first service of
+// the current design in dir and returns its path.
+func renderProtoFile(t *testing.T, dir string) string {
+	t.Helper()
+	fs := ProtoFiles("", expr.Root)
+	require.Len(t, fs, 1)
+	path := filepath.Join(dir, filepath.Base(fs[0].Path))
+	require.NoError(t, os.WriteFile(path, []byte(sectionCode(t, fs[0].SectionTemplates...)), 0600))
+	return path
+}
+
+// parseGoFile makes sure the file at path contains valid Go code and returns
+// its content without the comments preceding the package clause.
+func parseGoFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), path, b, parser.AllErrors)
+	require.NoError(t, err, codegen.Diff(t, "", string(b)))
+	code := string(b)
+	return code[strings.Index(code, "\npackage ")+1:]
+}
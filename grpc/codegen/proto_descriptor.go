@@ -0,0 +1,341 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	goapb "goa.design/goa/v3/grpc/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+type (
+	// descriptorBuilder builds the descriptor of the .proto file generated
+	// for a service from the service expressions. The descriptor is
+	// equivalent to the one produced by protoc when compiling the file.
+	descriptorBuilder struct {
+		// sd is the service data.
+		sd *ServiceData
+		// locations lists the source locations that hold the comments.
+		locations []*descriptorpb.SourceCodeInfo_Location
+	}
+)
+
+// protoScalarTypes maps the protocol buffer scalar type names to their
+// descriptor types.
+var protoScalarTypes = map[string]descriptorpb.FieldDescriptorProto_Type{
+	"double":   descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+	"float":    descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+	"int64":    descriptorpb.FieldDescriptorProto_TYPE_INT64,
+	"uint64":   descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+	"int32":    descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	"fixed64":  descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
+	"fixed32":  descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+	"bool":     descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+	"string":   descriptorpb.FieldDescriptorProto_TYPE_STRING,
+	"bytes":    descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+	"uint32":   descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	"sfixed32": descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
+	"sfixed64": descriptorpb.FieldDescriptorProto_TYPE_SFIXED64,
+	"sint32":   descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+	"sint64":   descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+}
+
+// protoFileDescriptor returns the descriptor of the .proto file with the
+// given name generated for the given service. It mirrors the definitions
+// rendered by the "grpc-service" and "grpc-message" templates. The names of
+// the message types referenced by the fields and methods are relative and
+// must be resolved once the imported files are known, see resolveProtoTypes.
+// header is the comment that precedes the syntax statement.
+func protoFileDescriptor(fname, header string, svc *expr.GRPCServiceExpr, sd *ServiceData) (*descriptorpb.FileDescriptorProto, error) {
+	pkg := pkgName(svc, sd.Service.PathName)
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String(fname),
+		Package:    proto.String(pkg),
+		Dependency: append([]string(nil), sd.ProtoImports...),
+		Options:    &descriptorpb.FileOptions{GoPackage: proto.String("/" + pkg + "pb")},
+		Syntax:     proto.String(ProtoVersion),
+	}
+	b := &descriptorBuilder{sd: sd}
+	if header != "" {
+		b.locations = append(b.locations, &descriptorpb.SourceCodeInfo_Location{
+			Path:                    []int32{12}, // syntax
+			Span:                    []int32{0, 0, 0},
+			LeadingDetachedComments: []string{header},
+		})
+	}
+
+	s := &descriptorpb.ServiceDescriptorProto{Name: proto.String(sd.Name)}
+	if sd.Reflection {
+		s.Options = protoOptions(&descriptorpb.ServiceOptions{}, goapb.E_Service, protoServiceOptions(svc))
+	}
+	if expr.DeprecationOf(svc.ServiceExpr.Meta) != nil {
+		if s.Options == nil {
+			s.Options = &descriptorpb.ServiceOptions{}
+		}
+		s.Options.Deprecated = proto.Bool(true)
+	}
+	b.comment([]int32{6, 0}, sd.Description)
+	for i, e := range sd.Endpoints {
+		if e.Request.Message == nil || e.Response.Message == nil {
+			return nil, fmt.Errorf("missing request or response message for method %q", e.Method.Name)
+		}
+		m := &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(e.Method.VarName),
+			InputType:  proto.String(e.Request.Message.VarName),
+			OutputType: proto.String(e.Response.Message.VarName),
+		}
+		if k := e.Method.StreamKind; k == expr.ClientStreamKind || k == expr.BidirectionalStreamKind {
+			m.ClientStreaming = proto.Bool(true)
+		}
+		if k := e.Method.StreamKind; k == expr.ServerStreamKind || k == expr.BidirectionalStreamKind {
+			m.ServerStreaming = proto.Bool(true)
+		}
+		if sd.Reflection {
+			m.Options = protoOptions(&descriptorpb.MethodOptions{}, goapb.E_Method, protoMethodOptions(svc.Endpoint(e.Method.Name)))
+		}
+		if expr.DeprecationOf(svc.Endpoint(e.Method.Name).MethodExpr.Meta) != nil {
+			if m.Options == nil {
+				m.Options = &descriptorpb.MethodOptions{}
+			}
+			m.Options.Deprecated = proto.Bool(true)
+		}
+		b.comment([]int32{6, 0, 2, int32(i)}, e.Method.Description)
+		s.Method = append(s.Method, m)
+	}
+	fd.Service = []*descriptorpb.ServiceDescriptorProto{s}
+
+	for i, m := range sd.Messages {
+		path := []int32{4, int32(i)}
+		msg, err := b.message(m.VarName, userTypeAttribute(m.Type), path)
+		if err != nil {
+			return nil, err
+		}
+		b.comment(path, m.Description)
+		fd.MessageType = append(fd.MessageType, msg)
+	}
+	fd.SourceCodeInfo = &descriptorpb.SourceCodeInfo{Location: b.locations}
+	return fd, nil
+}
+
+// message returns the descriptor of the message with the given name and
+// attribute. It mirrors protoBufMessageDef.
+func (b *descriptorBuilder) message(name string, att *expr.AttributeExpr, path []int32) (*descriptorpb.DescriptorProto, error) {
+	msg := &descriptorpb.DescriptorProto{Name: proto.String(name)}
+	obj := expr.AsObject(att.Type)
+	if obj == nil {
+		return msg, nil
+	}
+	if b.sd.Reflection {
+		msg.Options = protoOptions(&descriptorpb.MessageOptions{}, goapb.E_Message, protoMessageOptions(att))
+	}
+	if expr.DeprecationOf(att.Meta) != nil {
+		if msg.Options == nil {
+			msg.Options = &descriptorpb.MessageOptions{}
+		}
+		msg.Options.Deprecated = proto.Bool(true)
+	}
+	var optionals []*descriptorpb.FieldDescriptorProto
+	for _, nat := range *obj {
+		if u, ok := nat.Attribute.Type.(*expr.Union); ok {
+			idx := int32(len(msg.OneofDecl))
+			msg.OneofDecl = append(msg.OneofDecl, &descriptorpb.OneofDescriptorProto{
+				Name: proto.String(codegen.SnakeCase(protoBufify(u.Name(), false, false))),
+			})
+			for _, v := range u.Values {
+				f, err := b.field(msg, v.Name, v.Attribute, path)
+				if err != nil {
+					return nil, err
+				}
+				f.OneofIndex = proto.Int32(idx)
+			}
+			continue
+		}
+		f, err := b.field(msg, nat.Name, nat.Attribute, path)
+		if err != nil {
+			return nil, err
+		}
+		if !att.IsRequired(nat.Name) && expr.IsPrimitive(nat.Attribute.Type) && protoWellKnownType(nat.Attribute) == nil {
+			f.Proto3Optional = proto.Bool(true)
+			optionals = append(optionals, f)
+		}
+	}
+	// protoc adds the synthetic oneofs of proto3 optional fields after the
+	// declared oneofs.
+	for _, f := range optionals {
+		f.OneofIndex = proto.Int32(int32(len(msg.OneofDecl)))
+		msg.OneofDecl = append(msg.OneofDecl, &descriptorpb.OneofDescriptorProto{Name: proto.String("_" + f.GetName())})
+	}
+	return msg, nil
+}
+
+// field adds the descriptor of the field with the given name and attribute to
+// msg and returns it.
+func (b *descriptorBuilder) field(msg *descriptorpb.DescriptorProto, name string, att *expr.AttributeExpr, path []int32) (*descriptorpb.FieldDescriptorProto, error) {
+	fn := codegen.SnakeCase(protoBufify(name, false, false))
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(fn),
+		Number:   proto.Int32(int32(rpcTag(att))),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		JsonName: proto.String(protoJSONName(fn)),
+	}
+	if b.sd.Reflection {
+		f.Options = protoOptions(&descriptorpb.FieldOptions{}, goapb.E_Field, protoFieldOptions(att))
+	}
+	if expr.DeprecationOf(att.Meta) != nil {
+		if f.Options == nil {
+			f.Options = &descriptorpb.FieldOptions{}
+		}
+		f.Options.Deprecated = proto.Bool(true)
+	}
+	b.comment(append(append([]int32(nil), path...), 2, int32(len(msg.Field))), att.Description)
+	msg.Field = append(msg.Field, f)
+	if prim := getPrimitive(att); prim != nil {
+		att = prim
+	}
+	if _, ok := att.Meta["struct:field:proto"]; ok {
+		return f, b.setType(f, att)
+	}
+	switch actual := att.Type.(type) {
+	case *expr.Array:
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		return f, b.setType(f, actual.ElemType)
+	case *expr.Map:
+		entry := &descriptorpb.DescriptorProto{
+			Name:    proto.String(protoMapEntryName(fn)),
+			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		}
+		for i, at := range []*expr.AttributeExpr{actual.KeyType, actual.ElemType} {
+			n := []string{"key", "value"}[i]
+			ef := &descriptorpb.FieldDescriptorProto{
+				Name:     proto.String(n),
+				Number:   proto.Int32(int32(i + 1)),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				JsonName: proto.String(n),
+			}
+			if err := b.setType(ef, at); err != nil {
+				return nil, err
+			}
+			entry.Field = append(entry.Field, ef)
+		}
+		msg.NestedType = append(msg.NestedType, entry)
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		f.TypeName = entry.Name
+		return f, nil
+	default:
+		return f, b.setType(f, att)
+	}
+}
+
+// setType sets the type of f to the scalar or message type corresponding to
+// att. It mirrors protoType.
+func (b *descriptorBuilder) setType(f *descriptorpb.FieldDescriptorProto, att *expr.AttributeExpr) error {
+	var name string
+	if protos := att.Meta["struct:field:proto"]; len(protos) > 0 {
+		name = protos[0]
+	} else {
+		switch att.Type.(type) {
+		case expr.Primitive:
+			name = protoNativeType(att.Type)
+		case expr.UserType:
+			if prim := getPrimitive(att); prim != nil {
+				name = protoNativeType(prim.Type)
+			} else {
+				name = protoBufMessageName(att, b.sd.Scope)
+			}
+		default:
+			return fmt.Errorf("field %q: unsupported protocol buffer type %s", f.GetName(), att.Type.Name())
+		}
+	}
+	if t, ok := protoScalarTypes[name]; ok {
+		f.Type = t.Enum()
+		return nil
+	}
+	f.TypeName = proto.String(name)
+	return nil
+}
+
+// comment records the leading comment of the declaration with the given
+// source path. The comment is computed the same way as in the .proto file.
+func (b *descriptorBuilder) comment(path []int32, desc string) {
+	if desc == "" {
+		return
+	}
+	// Only the last comment block is attached to the declaration.
+	lines := strings.Split(codegen.Comment(desc), "\n")
+	start := 0
+	for i, l := range lines {
+		if !strings.HasPrefix(l, "//") {
+			start = i + 1
+		}
+	}
+	if start == len(lines) {
+		return
+	}
+	var c strings.Builder
+	for _, l := range lines[start:] {
+		c.WriteString(strings.TrimPrefix(l, "//"))
+		c.WriteByte('\n')
+	}
+	b.locations = append(b.locations, &descriptorpb.SourceCodeInfo_Location{
+		Path:            path,
+		Span:            []int32{0, 0, 0},
+		LeadingComments: proto.String(c.String()),
+	})
+}
+
+// protoOptions sets the extension xt of opts to v and returns opts. It returns
+// nil if v is nil.
+func protoOptions[T proto.Message](opts T, xt protoreflect.ExtensionType, v proto.Message) T {
+	var zero T
+	if v == nil || !v.ProtoReflect().IsValid() {
+		return zero
+	}
+	proto.SetExtension(opts, xt, v)
+	return opts
+}
+
+// protoJSONName returns the JSON name protoc computes for the field with the
+// given name.
+func protoJSONName(name string) string {
+	var (
+		res   strings.Builder
+		upper bool
+	)
+	for _, c := range name {
+		switch {
+		case c == '_':
+			upper = true
+		case upper:
+			res.WriteString(strings.ToUpper(string(c)))
+			upper = false
+		default:
+			res.WriteRune(c)
+		}
+	}
+	return res.String()
+}
+
+// protoMapEntryName returns the name of the message protoc synthesizes for
+// the map field with the given name.
+func protoMapEntryName(name string) string {
+	var (
+		res   strings.Builder
+		upper = true
+	)
+	for _, c := range name {
+		switch {
+		case c == '_':
+			upper = true
+		case upper:
+			res.WriteString(strings.ToUpper(string(c)))
+			upper = false
+		default:
+			res.WriteRune(c)
+		}
+	}
+	return res.String() + "Entry"
+}
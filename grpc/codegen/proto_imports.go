@@ -0,0 +1,452 @@
+package codegen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	// Register the well-known types so that the files that import them can
+	// be compiled without looking up the include paths.
+	_ "google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/fieldmaskpb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// protoScanner extracts the declarations needed to reference the types
+// defined in a .proto file: the imports, the package, the Go package and the
+// message and enum names. It does not validate the file.
+type protoScanner struct {
+	toks []string
+	pos  int
+}
+
+// protoDependencies returns the descriptors of the files imported by fd and
+// of their own dependencies, dependencies first. The imported files are
+// looked up in the registry of the linked protocol buffer packages (which
+// contains the well-known types) then in dir and in the include paths.
+// Relative include paths are relative to dir like when running protoc. It
+// returns an error if an imported file cannot be found.
+func protoDependencies(fd *descriptorpb.FileDescriptorProto, dir string, includes []string) ([]*descriptorpb.FileDescriptorProto, error) {
+	var (
+		deps []*descriptorpb.FileDescriptorProto
+		seen = make(map[string]struct{})
+	)
+	var addRegistered func(f protoreflect.FileDescriptor)
+	addRegistered = func(f protoreflect.FileDescriptor) {
+		if _, ok := seen[f.Path()]; ok {
+			return
+		}
+		seen[f.Path()] = struct{}{}
+		imps := f.Imports()
+		for i := 0; i < imps.Len(); i++ {
+			addRegistered(imps.Get(i).FileDescriptor)
+		}
+		deps = append(deps, protodesc.ToFileDescriptorProto(f))
+	}
+	paths := append([]string{dir}, includes...)
+	for i, p := range paths {
+		if !filepath.IsAbs(p) {
+			paths[i] = filepath.Join(dir, p)
+		}
+	}
+	var add func(imp string) error
+	add = func(imp string) error {
+		if _, ok := seen[imp]; ok {
+			return nil
+		}
+		if f, err := protoregistry.GlobalFiles.FindFileByPath(imp); err == nil {
+			addRegistered(f)
+			return nil
+		}
+		seen[imp] = struct{}{}
+		dep, err := findProtoFile(imp, paths)
+		if err != nil {
+			return err
+		}
+		if dep == nil {
+			return fmt.Errorf("import %q not found in %s, use the \"protoc:include\" meta to specify the include paths", imp, strings.Join(paths, ", "))
+		}
+		for _, i := range dep.Dependency {
+			if err := add(i); err != nil {
+				return fmt.Errorf("%s: %w", imp, err)
+			}
+		}
+		deps = append(deps, dep)
+		return nil
+	}
+	for _, imp := range fd.Dependency {
+		if err := add(imp); err != nil {
+			return nil, err
+		}
+	}
+	return deps, nil
+}
+
+// findProtoFile looks up the file with the given import path in the given
+// directories and returns its descriptor. It returns nil if the file cannot
+// be found.
+func findProtoFile(imp string, dirs []string) (*descriptorpb.FileDescriptorProto, error) {
+	for _, dir := range dirs {
+		b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(imp)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		fd, err := scanProtoFile(imp, string(b))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Join(dir, imp), err)
+		}
+		return fd, nil
+	}
+	return nil, nil
+}
+
+// resolveProtoTypes replaces the relative type names of the fields and
+// methods defined in fd with fully qualified names using the protocol
+// buffer scoping rules. It also sets the type of the fields that refer to
+// enums.
+func resolveProtoTypes(fd *descriptorpb.FileDescriptorProto, deps []*descriptorpb.FileDescriptorProto) error {
+	symbols := make(map[string]descriptorpb.FieldDescriptorProto_Type)
+	for _, f := range append(deps, fd) {
+		addProtoSymbols(symbols, f.GetPackage(), f.MessageType, f.EnumType)
+	}
+	resolve := func(scope, name string) (string, descriptorpb.FieldDescriptorProto_Type, error) {
+		if strings.HasPrefix(name, ".") {
+			if t, ok := symbols[name[1:]]; ok {
+				return name, t, nil
+			}
+			return "", 0, fmt.Errorf("unknown type %q", name)
+		}
+		for {
+			full := name
+			if scope != "" {
+				full = scope + "." + name
+			}
+			if t, ok := symbols[full]; ok {
+				return "." + full, t, nil
+			}
+			if scope == "" {
+				return "", 0, fmt.Errorf("unknown type %q", name)
+			}
+			if i := strings.LastIndexByte(scope, '.'); i >= 0 {
+				scope = scope[:i]
+			} else {
+				scope = ""
+			}
+		}
+	}
+	var resolveMessages func(scope string, msgs []*descriptorpb.DescriptorProto) error
+	resolveMessages = func(scope string, msgs []*descriptorpb.DescriptorProto) error {
+		for _, m := range msgs {
+			ms := qualifiedProtoName(scope, m.GetName())
+			for _, f := range m.Field {
+				if f.TypeName == nil {
+					continue
+				}
+				n, t, err := resolve(ms, f.GetTypeName())
+				if err != nil {
+					return fmt.Errorf("field %q of message %q: %w", f.GetName(), ms, err)
+				}
+				f.TypeName, f.Type = proto.String(n), t.Enum()
+			}
+			if err := resolveMessages(ms, m.NestedType); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := resolveMessages(fd.GetPackage(), fd.MessageType); err != nil {
+		return err
+	}
+	for _, s := range fd.Service {
+		for _, m := range s.Method {
+			for _, tn := range []*string{m.InputType, m.OutputType} {
+				n, t, err := resolve(fd.GetPackage(), *tn)
+				if err != nil {
+					return fmt.Errorf("method %q: %w", m.GetName(), err)
+				}
+				if t != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+					return fmt.Errorf("method %q: %q is not a message", m.GetName(), *tn)
+				}
+				*tn = n
+			}
+		}
+	}
+	return nil
+}
+
+// addProtoSymbols adds the fully qualified names of the given messages and
+// enums and of their nested declarations to symbols.
+func addProtoSymbols(symbols map[string]descriptorpb.FieldDescriptorProto_Type, scope string, msgs []*descriptorpb.DescriptorProto, enums []*descriptorpb.EnumDescriptorProto) {
+	for _, e := range enums {
+		symbols[qualifiedProtoName(scope, e.GetName())] = descriptorpb.FieldDescriptorProto_TYPE_ENUM
+	}
+	for _, m := range msgs {
+		n := qualifiedProtoName(scope, m.GetName())
+		symbols[n] = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+		addProtoSymbols(symbols, n, m.NestedType, m.EnumType)
+	}
+}
+
+// qualifiedProtoName returns the name qualified with the given scope.
+func qualifiedProtoName(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// scanProtoFile returns a descriptor of the .proto file with the given import
+// path and content that holds the imports, the package, the Go package and
+// the messages and enums defined in the file. The message fields are omitted.
+func scanProtoFile(imp, src string) (*descriptorpb.FileDescriptorProto, error) {
+	s := &protoScanner{toks: protoTokens(src)}
+	fd := &descriptorpb.FileDescriptorProto{Name: proto.String(imp)}
+	for s.peek() != "" {
+		switch s.next() {
+		case "syntax":
+			s.next() // =
+			if v := protoUnquote(s.next()); v == "proto3" {
+				fd.Syntax = proto.String(v)
+			}
+			s.skipStatement()
+		case "import":
+			switch s.peek() {
+			case "public":
+				s.next()
+				fd.PublicDependency = append(fd.PublicDependency, int32(len(fd.Dependency)))
+			case "weak":
+				s.next()
+				fd.WeakDependency = append(fd.WeakDependency, int32(len(fd.Dependency)))
+			}
+			fd.Dependency = append(fd.Dependency, protoUnquote(s.next()))
+			s.skipStatement()
+		case "package":
+			fd.Package = proto.String(s.next())
+			s.skipStatement()
+		case "option":
+			if s.next() == "go_package" {
+				s.next() // =
+				fd.Options = &descriptorpb.FileOptions{GoPackage: proto.String(protoUnquote(s.next()))}
+			}
+			s.skipStatement()
+		case "message":
+			m, err := s.message()
+			if err != nil {
+				return nil, err
+			}
+			fd.MessageType = append(fd.MessageType, m)
+		case "enum":
+			e, err := s.enum()
+			if err != nil {
+				return nil, err
+			}
+			fd.EnumType = append(fd.EnumType, e)
+		case ";":
+		default:
+			s.skipStatement()
+		}
+	}
+	return fd, nil
+}
+
+// message scans the message declaration that follows the "message" keyword.
+func (s *protoScanner) message() (*descriptorpb.DescriptorProto, error) {
+	name := s.next()
+	if s.next() != "{" {
+		return nil, fmt.Errorf("invalid declaration of message %q", name)
+	}
+	m := &descriptorpb.DescriptorProto{Name: proto.String(name)}
+	for {
+		switch s.peek() {
+		case "":
+			return nil, fmt.Errorf("unexpected end of file in message %q", name)
+		case "}":
+			s.next()
+			return m, nil
+		case "message":
+			s.next()
+			nested, err := s.message()
+			if err != nil {
+				return nil, err
+			}
+			m.NestedType = append(m.NestedType, nested)
+		case "enum":
+			s.next()
+			e, err := s.enum()
+			if err != nil {
+				return nil, err
+			}
+			m.EnumType = append(m.EnumType, e)
+		default:
+			s.skipStatement()
+		}
+	}
+}
+
+// enum scans the enum declaration that follows the "enum" keyword.
+func (s *protoScanner) enum() (*descriptorpb.EnumDescriptorProto, error) {
+	name := s.next()
+	if s.next() != "{" {
+		return nil, fmt.Errorf("invalid declaration of enum %q", name)
+	}
+	e := &descriptorpb.EnumDescriptorProto{Name: proto.String(name)}
+	for {
+		switch t := s.next(); t {
+		case "":
+			return nil, fmt.Errorf("unexpected end of file in enum %q", name)
+		case "}":
+			return e, nil
+		case ";":
+		case "option":
+			if s.peek() == "allow_alias" {
+				s.next()
+				s.next() // =
+				if s.next() == "true" {
+					e.Options = &descriptorpb.EnumOptions{AllowAlias: proto.Bool(true)}
+				}
+			}
+			s.skipStatement()
+		case "reserved":
+			s.skipStatement()
+		default:
+			if s.next() != "=" {
+				return nil, fmt.Errorf("invalid value %q of enum %q", t, name)
+			}
+			n := s.next()
+			if n == "-" {
+				n += s.next()
+			}
+			v, err := strconv.ParseInt(n, 0, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number of value %q of enum %q: %w", t, name, err)
+			}
+			e.Value = append(e.Value, &descriptorpb.EnumValueDescriptorProto{Name: proto.String(t), Number: proto.Int32(int32(v))})
+			s.skipStatement()
+		}
+	}
+}
+
+// skipStatement skips the tokens up to the end of the current statement or
+// block.
+func (s *protoScanner) skipStatement() {
+	depth := 0
+	for {
+		switch s.peek() {
+		case "":
+			return
+		case "{":
+			depth++
+		case "}":
+			if depth == 0 {
+				// End of the enclosing block.
+				return
+			}
+			depth--
+			if depth == 0 {
+				s.next()
+				return
+			}
+		case ";":
+			if depth == 0 {
+				s.next()
+				return
+			}
+		}
+		s.next()
+	}
+}
+
+func (s *protoScanner) peek() string {
+	if s.pos >= len(s.toks) {
+		return ""
+	}
+	return s.toks[s.pos]
+}
+
+func (s *protoScanner) next() string {
+	t := s.peek()
+	if t != "" {
+		s.pos++
+	}
+	return t
+}
+
+// protoTokens splits the given .proto source into tokens. Comments are
+// skipped, identifiers, numbers and quoted strings are returned as single
+// tokens and any other character is returned as a token of its own.
+func protoTokens(src string) []string {
+	var toks []string
+	isIdent := func(c byte) bool {
+		return c == '_' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+	}
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			if j := strings.IndexByte(src[i:], '\n'); j >= 0 {
+				i += j + 1
+			} else {
+				i = len(src)
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			if j := strings.Index(src[i+2:], "*/"); j >= 0 {
+				i += j + 4
+			} else {
+				i = len(src)
+			}
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(src) && src[j] != c {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j < len(src) {
+				j++
+			}
+			toks = append(toks, src[i:j])
+			i = j
+		case isIdent(c):
+			j := i + 1
+			for j < len(src) && isIdent(src[j]) {
+				j++
+			}
+			toks = append(toks, src[i:j])
+			i = j
+		default:
+			toks = append(toks, string(c))
+			i++
+		}
+	}
+	return toks
+}
+
+// protoUnquote returns the value of the given quoted string token.
+func protoUnquote(tok string) string {
+	if len(tok) < 2 {
+		return tok
+	}
+	v := tok[1 : len(tok)-1]
+	if s, err := strconv.Unquote(`"` + v + `"`); err == nil {
+		return s
+	}
+	return v
+}
@@ -0,0 +1,256 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	goapb "goa.design/goa/v3/grpc/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// protoReflection returns true if the descriptors generated for the given
+// service must include the Goa custom options defined in the
+// goadesign_goa_options.proto file of the goapb package, see the
+// "grpc:reflection" meta. The service meta takes precedence over the API
+// meta.
+func protoReflection(svc *expr.GRPCServiceExpr) bool {
+	for _, meta := range []expr.MetaExpr{svc.ServiceExpr.Meta, expr.Root.API.Meta} {
+		if v, ok := meta["grpc:reflection"]; ok {
+			return len(v) == 0 || v[len(v)-1] != "false"
+		}
+	}
+	return false
+}
+
+// protoServiceOptions returns the Goa options of the given service. It
+// returns nil if there is nothing to describe.
+func protoServiceOptions(svc *expr.GRPCServiceExpr) *goapb.ServiceOptions {
+	if svc.Description() == "" {
+		return nil
+	}
+	return &goapb.ServiceOptions{Description: svc.Description()}
+}
+
+// protoMethodOptions returns the Goa options of the given endpoint. It
+// returns nil if there is nothing to describe.
+func protoMethodOptions(e *expr.GRPCEndpointExpr) *goapb.MethodOptions {
+	opts := &goapb.MethodOptions{Description: e.MethodExpr.Description}
+	for _, er := range e.GRPCErrors {
+		opts.Errors = append(opts.Errors, &goapb.Error{
+			Name:        er.Name,
+			Code:        uint32(er.Response.StatusCode),
+			Description: er.ErrorExpr.Description,
+		})
+	}
+	if opts.Description == "" && len(opts.Errors) == 0 {
+		return nil
+	}
+	return opts
+}
+
+// protoMessageOptions returns the Goa options of the message generated for
+// the given attribute. It returns nil if there is nothing to describe.
+func protoMessageOptions(att *expr.AttributeExpr) *goapb.MessageOptions {
+	opts := &goapb.MessageOptions{Description: att.Description}
+	if att.Validation != nil {
+		for _, n := range att.Validation.Required {
+			opts.Required = append(opts.Required, codegen.SnakeCase(protoBufify(n, false, false)))
+		}
+	}
+	if opts.Description == "" && len(opts.Required) == 0 {
+		return nil
+	}
+	return opts
+}
+
+// protoFieldOptions returns the Goa options of the field generated for the
+// given attribute. It returns nil if there is nothing to describe.
+func protoFieldOptions(att *expr.AttributeExpr) *goapb.FieldOptions {
+	opts := &goapb.FieldOptions{Description: att.Description}
+	for _, ex := range att.UserExamples {
+		if v, ok := protoJSONValue(ex.Value); ok {
+			opts.Examples = append(opts.Examples, v)
+		}
+	}
+	if att.DefaultValue != nil {
+		opts.DefaultValue, _ = protoJSONValue(att.DefaultValue)
+	}
+	v := att.Validation
+	if prim := getPrimitive(att); prim != nil && v == nil {
+		v = prim.Validation
+	}
+	if v != nil {
+		val := &goapb.Validation{
+			Format:           string(v.Format),
+			Pattern:          v.Pattern,
+			Minimum:          v.Minimum,
+			ExclusiveMinimum: v.ExclusiveMinimum,
+			Maximum:          v.Maximum,
+			ExclusiveMaximum: v.ExclusiveMaximum,
+		}
+		for _, e := range v.Values {
+			if j, ok := protoJSONValue(e); ok {
+				val.Values = append(val.Values, j)
+			}
+		}
+		if v.MinLength != nil {
+			val.MinLength = proto.Int64(int64(*v.MinLength))
+		}
+		if v.MaxLength != nil {
+			val.MaxLength = proto.Int64(int64(*v.MaxLength))
+		}
+		if proto.Size(val) > 0 {
+			opts.Validation = val
+		}
+	}
+	if proto.Size(opts) == 0 {
+		return nil
+	}
+	return opts
+}
+
+// protoFieldOptionsDef returns the options of the field generated for the
+// given attribute as rendered in the .proto file: the deprecated option if the
+// attribute is deprecated and the Goa options if the service descriptors
+// include them. It returns an empty string if there is no option.
+func protoFieldOptionsDef(att *expr.AttributeExpr, sd *ServiceData) string {
+	var opts []string
+	if expr.DeprecationOf(att.Meta) != nil {
+		opts = append(opts, "deprecated = true")
+	}
+	if sd.Reflection {
+		if opt := protoOptionText(goapb.E_Field, protoFieldOptions(att)); opt != "" {
+			opts = append(opts, opt)
+		}
+	}
+	if len(opts) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(opts, ", ") + "]"
+}
+
+// protoJSONValue returns the JSON representation of the given design value.
+func protoJSONValue(v any) (string, bool) {
+	b, err := json.Marshal(jsonCompatible(v))
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+// jsonCompatible converts the maps with non-string keys that the design
+// values may contain into maps that can be encoded in JSON.
+func jsonCompatible(v any) any {
+	switch actual := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(actual))
+		for k, e := range actual {
+			m[fmt.Sprint(k)] = jsonCompatible(e)
+		}
+		return m
+	case map[string]any:
+		m := make(map[string]any, len(actual))
+		for k, e := range actual {
+			m[k] = jsonCompatible(e)
+		}
+		return m
+	case []any:
+		s := make([]any, len(actual))
+		for i, e := range actual {
+			s[i] = jsonCompatible(e)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// protoOptionText returns the text format representation of the option with
+// the given extension and value as used in a .proto file, for example
+// (goapb.field) = { description: "The ID" }. It returns an empty string if
+// m is nil.
+func protoOptionText(xt protoreflect.ExtensionType, m proto.Message) string {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return ""
+	}
+	return fmt.Sprintf("(%s) = %s", xt.TypeDescriptor().FullName(), protoTextMessage(m.ProtoReflect()))
+}
+
+// protoTextMessage returns the single line text format representation of m.
+// Unlike prototext the output is stable so that it can be used in the
+// generated files.
+func protoTextMessage(m protoreflect.Message) string {
+	var (
+		fields = m.Descriptor().Fields()
+		elems  []string
+	)
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !m.Has(fd) {
+			continue
+		}
+		v := m.Get(fd)
+		if fd.IsList() {
+			l := v.List()
+			vals := make([]string, l.Len())
+			for j := 0; j < l.Len(); j++ {
+				vals[j] = protoTextValue(fd, l.Get(j))
+			}
+			elems = append(elems, fmt.Sprintf("%s: [%s]", fd.Name(), strings.Join(vals, ", ")))
+			continue
+		}
+		elems = append(elems, fmt.Sprintf("%s: %s", fd.Name(), protoTextValue(fd, v)))
+	}
+	if len(elems) == 0 {
+		return "{}"
+	}
+	return "{ " + strings.Join(elems, " ") + " }"
+}
+
+// protoTextValue returns the text format representation of the value v of
+// the field fd.
+func protoTextValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		return protoTextMessage(v.Message())
+	case protoreflect.StringKind:
+		return protoTextString(v.String())
+	case protoreflect.DoubleKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	default:
+		return v.String()
+	}
+}
+
+// protoTextString returns the quoted text format representation of s.
+func protoTextString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == utf8.RuneError && n == 1, r < ' ', r == 0x7f:
+			fmt.Fprintf(&b, `\%03o`, s[i])
+		default:
+			b.WriteString(s[i : i+n])
+		}
+		i += n
+	}
+	b.WriteByte('"')
+	return b.String()
+}
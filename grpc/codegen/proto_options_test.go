@@ -0,0 +1,108 @@
+package codegen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/grpc/codegen/testdata"
+	goapb "goa.design/goa/v3/grpc/pb"
+)
+
+func TestProtoReflection(t *testing.T) {
+	RunGRPCDSL(t, testdata.ReflectionDSL)
+	fs := ProtoFiles("", expr.Root)
+	require.Len(t, fs, 1)
+	code := sectionCode(t, fs[0].SectionTemplates[1:]...)
+	if code != testdata.ReflectionPackageCode {
+		t.Errorf("got\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ReflectionPackageCode))
+	}
+
+	path := renderProtoFile(t, t.TempDir())
+	svc := expr.Root.API.GRPC.Services[0]
+	require.NoError(t, compileProto(path, svc, nil))
+	pb := parseGoFile(t, strings.TrimSuffix(path, ".proto")+".pb.go")
+	assert.Contains(t, pb, `_ "goa.design/goa/v3/grpc/pb"`)
+
+	fd, err := protoFileDescriptor(filepath.Base(path), "", svc, GRPCServices.Get(svc.Name()))
+	require.NoError(t, err)
+	s := fd.Service[0]
+	assert.Equal(t, "Service that exposes the design in its descriptors.", proto.GetExtension(s.Options, goapb.E_Service).(*goapb.ServiceOptions).GetDescription())
+	m := proto.GetExtension(s.Method[0].Options, goapb.E_Method).(*goapb.MethodOptions)
+	require.Len(t, m.GetErrors(), 1)
+	assert.Equal(t, "not_found", m.GetErrors()[0].GetName())
+	assert.Equal(t, uint32(5), m.GetErrors()[0].GetCode())
+	req := fd.MessageType[0]
+	assert.Equal(t, []string{"name"}, proto.GetExtension(req.Options, goapb.E_Message).(*goapb.MessageOptions).GetRequired())
+	name := proto.GetExtension(req.Field[0].Options, goapb.E_Field).(*goapb.FieldOptions)
+	assert.Equal(t, "Name of the widget", name.GetDescription())
+	assert.Equal(t, []string{`"widget"`}, name.GetExamples())
+	assert.Equal(t, int64(1), name.GetValidation().GetMinLength())
+	assert.Nil(t, req.Field[4].Options)
+}
+
+func TestProtoReflectionProtoc(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found")
+	}
+	RunGRPCDSL(t, testdata.ReflectionDSL)
+	fs := ProtoFiles("", expr.Root)
+	require.Len(t, fs, 1)
+	path := filepath.Join(t.TempDir(), filepath.Base(fs[0].Path))
+	require.NoError(t, os.WriteFile(path, []byte(sectionCode(t, fs[0].SectionTemplates...)), 0600))
+	require.NoError(t, fs[0].FinalizeFunc(path))
+	parseGoFile(t, strings.TrimSuffix(path, ".proto")+".pb.go")
+}
+
+func TestProtoDeprecated(t *testing.T) {
+	RunGRPCDSL(t, testdata.DeprecatedDSL)
+	fs := ProtoFiles("", expr.Root)
+	require.Len(t, fs, 1)
+	code := sectionCode(t, fs[0].SectionTemplates[1:]...)
+	if code != testdata.DeprecatedPackageCode {
+		t.Errorf("got\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.DeprecatedPackageCode))
+	}
+
+	path := renderProtoFile(t, t.TempDir())
+	svc := expr.Root.API.GRPC.Services[0]
+	require.NoError(t, compileProto(path, svc, nil))
+
+	fd, err := protoFileDescriptor(filepath.Base(path), "", svc, GRPCServices.Get(svc.Name()))
+	require.NoError(t, err)
+	s := fd.Service[0]
+	assert.True(t, s.GetOptions().GetDeprecated())
+	assert.True(t, s.Method[0].GetOptions().GetDeprecated())
+	for _, msg := range fd.MessageType {
+		switch msg.GetName() {
+		case "MethodRequest":
+			assert.False(t, msg.Field[0].GetOptions().GetDeprecated())
+			assert.True(t, msg.Field[1].GetOptions().GetDeprecated())
+		case "Widget":
+			assert.True(t, msg.GetOptions().GetDeprecated())
+		}
+	}
+}
+
+func TestProtoTextString(t *testing.T) {
+	cases := map[string]string{
+		"":            `""`,
+		"plain":       `"plain"`,
+		`a "b" \c`:    `"a \"b\" \\c"`,
+		"line\nnext":  `"line\nnext"`,
+		"tab\there":   `"tab\there"`,
+		"ctrl\x01":    `"ctrl\001"`,
+		"invalid\xff": `"invalid\377"`,
+		"café":        `"café"`,
+	}
+	for s, expected := range cases {
+		assert.Equal(t, expected, protoTextString(s), s)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"goa.design/goa/v3/expr"
+	goapb "goa.design/goa/v3/grpc/pb"
 
 	"goa.design/goa/v3/codegen"
 )
@@ -16,6 +17,60 @@ type (
 	protoBufScope struct {
 		scope *codegen.NameScope
 	}
+
+	// wellKnownType describes a protocol buffer well-known type used to
+	// represent a Goa type that has no protocol buffer scalar equivalent.
+	wellKnownType struct {
+		// Name is the protocol buffer message name.
+		Name string
+		// Import is the path to the proto file defining the message.
+		Import string
+		// GoName is the name of the Go type generated by protoc.
+		GoName string
+		// GoImport is the import path of the Go package generated by
+		// protoc.
+		GoImport string
+		// ToProto is the name of the goagrpc function that converts a
+		// service type value to the well-known type.
+		ToProto string
+		// FromProto is the name of the goagrpc function that converts a
+		// well-known type value to the service type.
+		FromProto string
+	}
+)
+
+var (
+	// timestampType maps strings with the "date-time" format.
+	timestampType = &wellKnownType{
+		Name:      "google.protobuf.Timestamp",
+		Import:    "google/protobuf/timestamp.proto",
+		GoName:    "Timestamp",
+		GoImport:  "google.golang.org/protobuf/types/known/timestamppb",
+		ToProto:   "NewTimestamp",
+		FromProto: "TimestampString",
+	}
+	// durationType maps strings with the "duration" format.
+	durationType = &wellKnownType{
+		Name:      "google.protobuf.Duration",
+		Import:    "google/protobuf/duration.proto",
+		GoName:    "Duration",
+		GoImport:  "google.golang.org/protobuf/types/known/durationpb",
+		ToProto:   "NewDuration",
+		FromProto: "DurationString",
+	}
+	// valueType maps the Any type. Note that maps of strings to Any are
+	// represented with map<string, google.protobuf.Value> rather than
+	// google.protobuf.Struct: both have the same JSON mapping and the map
+	// field keeps the key and value definitions of the design (e.g.
+	// validations) without wrapping the map in an additional message.
+	valueType = &wellKnownType{
+		Name:      "google.protobuf.Value",
+		Import:    "google/protobuf/struct.proto",
+		GoName:    "Value",
+		GoImport:  "google.golang.org/protobuf/types/known/structpb",
+		ToProto:   "NewValue",
+		FromProto: "ValueInterface",
+	}
 )
 
 // Name returns the protocol buffer type name.
@@ -67,6 +122,7 @@ func makeProtoBufMessage(att *expr.AttributeExpr, tname string, sd *ServiceData)
 		return att
 	case expr.IsPrimitive(att.Type):
 		wrapAttr(att, tname, true, sd)
+		setWellKnownType(unwrapAttr(att))
 		return att
 	case isut:
 		if expr.IsArray(ut) {
@@ -113,6 +169,7 @@ func makeProtoBufMessageR(att *expr.AttributeExpr, tname *string, sd *ServiceDat
 
 	switch {
 	case expr.IsPrimitive(att.Type):
+		setWellKnownType(att)
 		return
 	case isut:
 		if expr.IsArray(ut) {
@@ -280,7 +337,7 @@ func protoBufMessageDef(att *expr.AttributeExpr, sd *ServiceData) string {
 			if d := nat.Attribute.Description; d != "" {
 				desc = codegen.Comment(d) + "\n\t"
 			}
-			def += fmt.Sprintf("\n\t\t%s%s %s = %d;", desc, typ, fn, fnum)
+			def += fmt.Sprintf("\n\t\t%s%s %s = %d%s;", desc, typ, fn, fnum, protoFieldOptionsDef(nat.Attribute, sd))
 		}
 		def += "\n\t}"
 		return def
@@ -295,6 +352,14 @@ func protoBufMessageDef(att *expr.AttributeExpr, sd *ServiceData) string {
 	case *expr.Object:
 		var ss []string
 		ss = append(ss, " {")
+		if expr.DeprecationOf(att.Meta) != nil {
+			ss = append(ss, "\toption deprecated = true;")
+		}
+		if sd.Reflection {
+			if opt := protoOptionText(goapb.E_Message, protoMessageOptions(att)); opt != "" {
+				ss = append(ss, "\toption "+opt+";")
+			}
+		}
 		for _, nat := range *actual {
 			if expr.IsUnion(nat.Attribute.Type) {
 				ss = append(ss, protoBufMessageDef(nat.Attribute, sd))
@@ -315,14 +380,14 @@ func protoBufMessageDef(att *expr.AttributeExpr, sd *ServiceData) string {
 				} else {
 					typ = protoType(nat.Attribute, sd)
 				}
-				if !att.IsRequired(nat.Name) && expr.IsPrimitive(nat.Attribute.Type) {
+				if !att.IsRequired(nat.Name) && expr.IsPrimitive(nat.Attribute.Type) && protoWellKnownType(nat.Attribute) == nil {
 					opt = "optional "
 				}
 				if nat.Attribute.Description != "" {
 					desc = codegen.Comment(nat.Attribute.Description) + "\n\t"
 				}
 			}
-			ss = append(ss, fmt.Sprintf("\t%s%s%s %s = %d;", desc, opt, typ, fn, fnum))
+			ss = append(ss, fmt.Sprintf("\t%s%s%s %s = %d%s;", desc, opt, typ, fn, fnum, protoFieldOptionsDef(nat.Attribute, sd)))
 		}
 		ss = append(ss, "}")
 		return strings.Join(ss, "\n")
@@ -336,12 +401,66 @@ func protoBufMessageDef(att *expr.AttributeExpr, sd *ServiceData) string {
 // (in *.pb.go) for the given attribute.
 func protoBufGoFullTypeRef(att *expr.AttributeExpr, pkg string, s *codegen.NameScope) string {
 	name := protoBufGoFullTypeName(att, pkg, s)
-	if expr.IsObject(att.Type) || expr.IsUnion(att.Type) {
+	if expr.IsObject(att.Type) || expr.IsUnion(att.Type) || protoWellKnownType(att) != nil {
 		return "*" + name
 	}
 	return name
 }
 
+// setWellKnownType sets the "struct:field:proto" meta of the given attribute
+// so that it gets represented with a protocol buffer well-known type if the
+// attribute is of type Any (google.protobuf.Value) or if it is a string with
+// the "date-time" (google.protobuf.Timestamp) or "duration"
+// (google.protobuf.Duration) format. The validations of the value (format,
+// pattern, length, enum) are removed as they do not apply to the well-known
+// type message. The required validations are defined by the parent attribute
+// and are left untouched, see wellKnownTypeRequiredCode. setWellKnownType does
+// nothing if the attribute already defines the "struct:field:proto" meta.
+func setWellKnownType(att *expr.AttributeExpr) {
+	if _, ok := att.Meta["struct:field:proto"]; ok {
+		return
+	}
+	var wkt *wellKnownType
+	switch {
+	case att.Type == expr.Any:
+		wkt = valueType
+	case att.Type == expr.String && att.Validation != nil:
+		switch att.Validation.Format {
+		case expr.FormatDateTime:
+			wkt = timestampType
+		case expr.FormatDuration:
+			wkt = durationType
+		}
+	}
+	if wkt == nil {
+		return
+	}
+	if att.Meta == nil {
+		att.Meta = expr.MetaExpr{}
+	}
+	att.Meta["struct:field:proto"] = []string{wkt.Name, wkt.Import, wkt.GoName, wkt.GoImport}
+	att.Validation = nil
+}
+
+// protoWellKnownType returns the well-known type used to represent the given
+// primitive attribute in protocol buffer types, nil if the attribute is not
+// represented with a well-known type.
+func protoWellKnownType(att *expr.AttributeExpr) *wellKnownType {
+	if _, ok := att.Type.(expr.Primitive); !ok {
+		return nil
+	}
+	proto := att.Meta["struct:field:proto"]
+	if len(proto) == 0 {
+		return nil
+	}
+	for _, wkt := range []*wellKnownType{timestampType, durationType, valueType} {
+		if proto[0] == wkt.Name {
+			return wkt
+		}
+	}
+	return nil
+}
+
 var digits = regexp.MustCompile("[0-9]+")
 
 // protoBufify makes a valid protocol buffer identifier out of any string.
@@ -55,7 +55,7 @@ var (
 
 // NOTE: can't initialize inline because https://github.com/golang/go/issues/1817
 func init() {
-	fm := template.FuncMap{"transformAttribute": transformAttribute, "convertType": convertType}
+	fm := template.FuncMap{"transformAttribute": transformAttribute, "convertType": convertType, "fallible": fallibleToProto}
 	transformGoArrayT = template.Must(template.New("transformGoArray").Funcs(fm).Parse(transformGoArrayTmpl))
 	transformGoMapT = template.Must(template.New("transformGoMap").Funcs(fm).Parse(transformGoMapTmpl))
 	transformGoUnionToProtoT = template.Must(template.New("transformGoUnionToProto").Funcs(fm).Parse(transformGoUnionToProtoTmpl))
@@ -179,7 +179,14 @@ func transformAttribute(source, target *expr.AttributeExpr, sourceVar, targetVar
 				assign = ":="
 			}
 			srcField := convertType(source, target, false, false, sourceVar, ta)
-			code = fmt.Sprintf("%s %s %s\n", targetVar, assign, srcField)
+			switch {
+			case !ta.proto || !fallibleToProto(target):
+				code = fmt.Sprintf("%s %s %s\n", targetVar, assign, srcField)
+			case newVar:
+				code = fmt.Sprintf("%s, err := %s\nif err != nil {\n\treturn nil, err\n}\n", targetVar, srcField)
+			default:
+				code = "{\n" + fallibleAssign(targetVar, srcField) + "}\n"
+			}
 		}
 	}
 	if err != nil {
@@ -206,12 +213,23 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 				exp          string
 				srcField     = sourceVar + "." + ta.SourceCtx.Scope.Field(srcc, srcMatt.ElemName(n), true)
 				tgtField     = ta.TargetCtx.Scope.Field(tgtc, tgtMatt.ElemName(n), true)
-				srcPtr       = ta.SourceCtx.IsPrimitivePointer(n, srcMatt.AttributeExpr)
-				tgtPtr       = ta.TargetCtx.IsPrimitivePointer(n, tgtMatt.AttributeExpr)
+				srcPtr       = ta.SourceCtx.IsPrimitivePointer(n, srcMatt.AttributeExpr) && protoWellKnownType(srcc) == nil
+				tgtPtr       = ta.TargetCtx.IsPrimitivePointer(n, tgtMatt.AttributeExpr) && protoWellKnownType(tgtc) == nil
 				srcFieldConv = convertType(srcc, tgtc, srcPtr, tgtPtr, srcField, ta)
 				_, isSrcUT   = srcc.Type.(expr.UserType)
 				_, isTgtUT   = tgtc.Type.(expr.UserType)
 			)
+			if ta.proto && protoWellKnownType(tgtc) != nil {
+				// The conversion to a well-known type may fail, assign the
+				// field after initializing the struct.
+				code := fallibleAssign(targetVar+"."+tgtField, srcFieldConv)
+				if srcPtr && !srcMatt.IsRequired(n) {
+					postInitCode += fmt.Sprintf("if %s != nil {\n%s}\n", srcField, code)
+				} else {
+					postInitCode += "{\n" + code + "}\n"
+				}
+				return
+			}
 			switch {
 			case isSrcUT || isTgtUT || (srcField != srcFieldConv):
 				var deref string
@@ -236,7 +254,13 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 					return
 				} else if tgtPtr {
 					tmp := codegen.Goify(tgtMatt.ElemName(n), false)
-					postInitCode += fmt.Sprintf("%s := %s\n%s.%s = &%s\n", tmp, exp, targetVar, tgtField, tmp)
+					code := fmt.Sprintf("%s := %s\n%s.%s = &%s\n", tmp, exp, targetVar, tgtField, tmp)
+					if protoWellKnownType(srcc) != nil {
+						// well-known types are messages, leave the
+						// target nil if the source message is nil.
+						code = fmt.Sprintf("if %s != nil {\n%s}\n", srcField, code)
+					}
+					postInitCode += code
 					return
 				}
 			case srcPtr && !tgtPtr:
@@ -308,7 +332,12 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 					tgtVar = targetVar + ".(" + ref + ")." + codegen.GoifyAtt(tgtc, tgtMatt.ElemName(n), true)
 				}
 				if !expr.IsPrimitive(srcc.Type) {
-					code = fmt.Sprintf("%s = %s(%s)\n", tgtVar, transformHelperName(srcc, tgtc, ta), srcVar)
+					exp := fmt.Sprintf("%s(%s)", transformHelperName(srcc, tgtc, ta), srcVar)
+					if ta.proto && fallibleToProto(tgtc) {
+						code = fallibleAssign(tgtVar, exp)
+					} else {
+						code = fmt.Sprintf("%s = %s\n", tgtVar, exp)
+					}
 				}
 			case expr.IsObject(srcc.Type):
 				code, err = transformAttribute(srcc, tgtc, srcVar, tgtVar, false, ta)
@@ -339,8 +368,9 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 
 		// Default value handling. We need to handle default values if the target
 		// type uses default values (i.e. attributes with default values are
-		// non-pointers) and has a default value set.
-		if tdef := tgtMatt.GetDefault(n); tdef != nil && ta.TargetCtx.UseDefault && !ta.TargetCtx.Pointer && !srcMatt.IsRequired(n) {
+		// non-pointers) and has a default value set. Well-known type targets
+		// are left nil so that the receiver applies the default value.
+		if tdef := tgtMatt.GetDefault(n); tdef != nil && ta.TargetCtx.UseDefault && !ta.TargetCtx.Pointer && !srcMatt.IsRequired(n) && protoWellKnownType(tgtc) == nil {
 			switch {
 			case ta.SourceCtx.IsPrimitivePointer(n, srcMatt.AttributeExpr) || !expr.IsPrimitive(srcc.Type):
 				// source attribute is a primitive pointer or not a primitive
@@ -606,6 +636,17 @@ func transformUnionFromProto(source, target *expr.AttributeExpr, sourceVar, targ
 // convertType produces code to initialize a target type from a source type
 // held by sourceVar.
 func convertType(src, tgt *expr.AttributeExpr, srcPtr bool, tgtPtr bool, srcVar string, ta *transformAttrs) string {
+	if ta.proto {
+		if wkt := protoWellKnownType(tgt); wkt != nil {
+			if srcPtr {
+				srcVar = "*" + srcVar
+			}
+			return fmt.Sprintf("goagrpc.%s(%s)", wkt.ToProto, srcVar)
+		}
+	} else if wkt := protoWellKnownType(src); wkt != nil {
+		return fmt.Sprintf("goagrpc.%s(%s)", wkt.FromProto, srcVar)
+	}
+
 	if expr.IsAlias(src.Type) || expr.IsAlias(tgt.Type) {
 		srcp, tgtp := unAlias(src), unAlias(tgt)
 		if srcp.Type == tgtp.Type {
@@ -841,14 +882,20 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *transformA
 			if err != nil {
 				return nil, err
 			}
+			fallible := ta.proto && fallibleToProto(target)
 			if !req {
-				code = "if v == nil {\n\treturn nil\n}\n" + code
+				if fallible {
+					code = "if v == nil {\n\treturn nil, nil\n}\n" + code
+				} else {
+					code = "if v == nil {\n\treturn nil\n}\n" + code
+				}
 			}
 			tfd := &codegen.TransformFunctionData{
 				Name:          name,
 				ParamTypeRef:  ta.SourceCtx.Scope.Ref(source, ta.SourceCtx.Pkg(source)),
 				ResultTypeRef: ta.TargetCtx.Scope.Ref(target, ta.TargetCtx.Pkg(target)),
 				Code:          code,
+				ReturnsError:  fallible,
 			}
 			seen[name] = tfd
 			data = append(data, tfd)
@@ -927,6 +974,27 @@ func transformHelperName(source, target *expr.AttributeExpr, ta *transformAttrs)
 	return codegen.Goify(prefix+sname+"To"+tname, false)
 }
 
+// fallibleToProto returns true if the code initializing the protocol buffer
+// type described by att from a service type may fail, that is if att is or
+// contains attributes represented with well-known types.
+func fallibleToProto(att *expr.AttributeExpr) bool {
+	var fallible bool
+	codegen.Walk(att, func(a *expr.AttributeExpr) error { // nolint: errcheck
+		if protoWellKnownType(a) != nil {
+			fallible = true
+		}
+		return nil
+	})
+	return fallible
+}
+
+// fallibleAssign returns the code assigning the result of the fallible
+// conversion exp to target. The generated code returns the conversion error
+// so it must be used in functions returning a pointer and an error.
+func fallibleAssign(target, exp string) string {
+	return fmt.Sprintf("cv, err := %s\nif err != nil {\n\treturn nil, err\n}\n%s = cv\n", exp, target)
+}
+
 // unAlias returns the base AttributeExpr of an aliased one.
 func unAlias(at *expr.AttributeExpr) *expr.AttributeExpr {
 	if prim := getPrimitive(at); prim != nil {
@@ -983,7 +1051,15 @@ for key, val := range {{ .SourceVar }} {
 {{- range $i, $ref := .SourceValueTypeRefs }}
 case {{ . }}:
 		{{- $val := (convertType (index $.SourceValues $i).Attribute (index $.TargetValues $i).Attribute false false "src" $.TransformAttrs) }}
+	{{- if fallible (index $.TargetValues $i).Attribute }}
+		cv, err := {{ $val }}
+		if err != nil {
+			return nil, err
+		}
+		{{ $.TargetVar }} = &{{ index $.TargetValueTypeNames $i }}{ {{ (index $.TargetFieldNames $i) }}: cv }
+	{{- else }}
 		{{ $.TargetVar }} = &{{ index $.TargetValueTypeNames $i }}{ {{ (index $.TargetFieldNames $i) }}: {{ $val }} }
+	{{- end }}
 {{- end }}
 }
 `
@@ -37,6 +37,7 @@ func TestProtoBufTransform(t *testing.T) {
 		customField = root.UserType("CompositeWithCustomField")
 		optional    = root.UserType("Optional")
 		defaults    = root.UserType("WithDefaults")
+		wellKnown   = root.UserType("WellKnown")
 
 		resultType = root.UserType("ResultType")
 		rtCol      = root.UserType("ResultTypeCollection")
@@ -53,17 +54,6 @@ func TestProtoBufTransform(t *testing.T) {
 		pbCtx  = protoBufTypeContext("proto", sd.Scope, true)
 	)
 
-	// gRPC does not support any
-	obj := expr.AsObject(defaults)
-	for _, nat := range *obj {
-		if nat.Name == "any" {
-			nat.Attribute.Type = expr.String
-		}
-		if nat.Name == "required_any" {
-			nat.Attribute.Type = expr.String
-		}
-	}
-
 	tc := map[string][]struct {
 		Name    string
 		Source  expr.DataType
@@ -104,6 +94,7 @@ func TestProtoBufTransform(t *testing.T) {
 			{"result-type-collection-to-result-type-collection", rtCol, rtCol, true, svcCtx, rtColSvcToRTColProtoCode},
 			{"optional-to-optional", optional, optional, true, svcCtx, optionalSvcToOptionalProtoCode},
 			{"defaults-to-defaults", defaults, defaults, true, svcCtx, defaultsSvcToDefaultsProtoCode},
+			{"well-known-to-well-known", wellKnown, wellKnown, true, svcCtx, wellKnownSvcToWellKnownProtoCode},
 
 			// oneofs
 			{"oneof-to-oneof", simpleOneOf, simpleOneOf, true, svcCtx, oneOfSvcToOneOfProtoCode},
@@ -146,6 +137,7 @@ func TestProtoBufTransform(t *testing.T) {
 			{"result-type-collection-to-result-type-collection", rtCol, rtCol, false, svcCtx, rtColProtoToRTColSvcCode},
 			{"optional-to-optional", optional, optional, false, svcCtx, optionalProtoToOptionalSvcCode},
 			{"defaults-to-defaults", defaults, defaults, false, svcCtx, defaultsProtoToDefaultsSvcCode},
+			{"well-known-to-well-known", wellKnown, wellKnown, false, svcCtx, wellKnownProtoToWellKnownSvcCode},
 
 			// oneofs
 			{"oneof-to-oneof", simpleOneOf, simpleOneOf, false, svcCtx, oneOfProtoToOneOfSvcCode},
@@ -620,7 +612,6 @@ const (
 		Float_:  source.Float,
 		String_: source.String,
 		Bytes_:  source.Bytes,
-		Any:     source.Any,
 	}
 	if source.Int != nil {
 		int_ := int32(*source.Int)
@@ -630,6 +621,13 @@ const (
 		uint_ := uint32(*source.Uint)
 		target.Uint = &uint_
 	}
+	{
+		cv, err := goagrpc.NewValue(source.Any)
+		if err != nil {
+			return nil, err
+		}
+		target.Any = cv
+	}
 	if source.Array != nil {
 		target.Array = make([]string, len(source.Array))
 		for i, val := range source.Array {
@@ -645,7 +643,11 @@ const (
 		}
 	}
 	if source.UserType != nil {
-		target.UserType = svcProtoOptionalToProtoOptional(source.UserType)
+		cv, err := svcProtoOptionalToProtoOptional(source.UserType)
+		if err != nil {
+			return nil, err
+		}
+		target.UserType = cv
 	}
 }
 `
@@ -659,8 +661,20 @@ const (
 		RequiredString: source.RequiredString,
 		Bytes_:         source.Bytes,
 		RequiredBytes:  source.RequiredBytes,
-		Any:            source.Any,
-		RequiredAny:    source.RequiredAny,
+	}
+	{
+		cv, err := goagrpc.NewValue(source.Any)
+		if err != nil {
+			return nil, err
+		}
+		target.Any = cv
+	}
+	{
+		cv, err := goagrpc.NewValue(source.RequiredAny)
+		if err != nil {
+			return nil, err
+		}
+		target.RequiredAny = cv
 	}
 	{
 		var zero int32
@@ -686,12 +700,6 @@ const (
 			target.Bytes_ = []byte{0x66, 0x6f, 0x6f, 0x62, 0x61, 0x72}
 		}
 	}
-	{
-		var zero string
-		if target.Any == zero {
-			target.Any = "something"
-		}
-	}
 	if source.Array != nil {
 		target.Array = make([]string, len(source.Array))
 		for i, val := range source.Array {
@@ -1143,7 +1151,7 @@ const (
 		Float:  source.Float_,
 		String: source.String_,
 		Bytes:  source.Bytes_,
-		Any:    source.Any,
+		Any:    goagrpc.ValueInterface(source.Any),
 	}
 	if source.Int != nil {
 		int_ := int(*source.Int)
@@ -1182,8 +1190,8 @@ const (
 		RequiredString: source.RequiredString,
 		Bytes:          source.Bytes_,
 		RequiredBytes:  source.RequiredBytes,
-		Any:            source.Any,
-		RequiredAny:    source.RequiredAny,
+		Any:            goagrpc.ValueInterface(source.Any),
+		RequiredAny:    goagrpc.ValueInterface(source.RequiredAny),
 	}
 	{
 		var zero int
@@ -1210,7 +1218,7 @@ const (
 		}
 	}
 	{
-		var zero string
+		var zero any
 		if target.Any == zero {
 			target.Any = "something"
 		}
@@ -1311,5 +1319,91 @@ const (
 		target.WithOverride = protobufProtoWithOverrideToTypesWithOverride(source.WithOverride)
 	}
 }
+`
+
+	wellKnownSvcToWellKnownProtoCode = `func transform() {
+	target := &proto.WellKnown{}
+	if source.DateTime != nil {
+		cv, err := goagrpc.NewTimestamp(*source.DateTime)
+		if err != nil {
+			return nil, err
+		}
+		target.DateTime = cv
+	}
+	{
+		cv, err := goagrpc.NewTimestamp(source.RequiredDateTime)
+		if err != nil {
+			return nil, err
+		}
+		target.RequiredDateTime = cv
+	}
+	if source.Duration != nil {
+		cv, err := goagrpc.NewDuration(*source.Duration)
+		if err != nil {
+			return nil, err
+		}
+		target.Duration = cv
+	}
+	{
+		cv, err := goagrpc.NewValue(source.Any)
+		if err != nil {
+			return nil, err
+		}
+		target.Any = cv
+	}
+	if source.Array != nil {
+		target.Array = make([]*timestamppb.Timestamp, len(source.Array))
+		for i, val := range source.Array {
+			{
+				cv, err := goagrpc.NewTimestamp(val)
+				if err != nil {
+					return nil, err
+				}
+				target.Array[i] = cv
+			}
+		}
+	}
+	if source.Map != nil {
+		target.Map_ = make(map[string]*structpb.Value, len(source.Map))
+		for key, val := range source.Map {
+			tk := key
+			tv, err := goagrpc.NewValue(val)
+			if err != nil {
+				return nil, err
+			}
+			target.Map_[tk] = tv
+		}
+	}
+}
+`
+
+	wellKnownProtoToWellKnownSvcCode = `func transform() {
+	target := &proto.WellKnown{
+		RequiredDateTime: goagrpc.TimestampString(source.RequiredDateTime),
+		Any:              goagrpc.ValueInterface(source.Any),
+	}
+	if source.DateTime != nil {
+		dateTime := goagrpc.TimestampString(source.DateTime)
+		target.DateTime = &dateTime
+	}
+	if source.Duration != nil {
+		duration := goagrpc.DurationString(source.Duration)
+		target.Duration = &duration
+	}
+	if source.Array != nil {
+		target.Array = make([]string, len(source.Array))
+		for i, val := range source.Array {
+			target.Array[i] = goagrpc.TimestampString(val)
+		}
+	}
+	if source.Map_ != nil {
+		target.Map = make(map[string]any, len(source.Map_))
+		for key, val := range source.Map_ {
+			tk := key
+			tv := goagrpc.ValueInterface(val)
+			target.Map[tk] = tv
+		}
+	}
+}
 `
 )
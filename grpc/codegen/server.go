@@ -13,7 +13,9 @@ import (
 // ServerFiles returns all the server files for every gRPC service. The files
 // contain the server which implements the generated gRPC server interface and
 // encoders and decoders to transform protocol buffer types and gRPC metadata
-// into goa types and vice versa.
+// into goa types and vice versa. The files also contain the HTTP handlers
+// that serve the gRPC methods using the Connect and gRPC-Web protocols for
+// the services that enable them with the "grpc:connect" meta.
 func ServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 	svcLen := len(root.API.GRPC.Services)
 	fw := make([]*codegen.File, 2*svcLen)
@@ -23,6 +25,11 @@ func ServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 	for i, svc := range root.API.GRPC.Services {
 		fw[i+svcLen] = serverEncodeDecode(genpkg, svc)
 	}
+	for _, svc := range root.API.GRPC.Services {
+		if f := connectServerFile(genpkg, svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
 	return fw
 }
 
@@ -66,6 +73,16 @@ func serverFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 			Source: serverInitT,
 			Data:   data,
 		})
+		if data.Service.Health != nil {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "server-health",
+				Source: serverHealthT,
+				Data: map[string]any{
+					"Service":  data.Service,
+					"FullName": pkgName(svc, svcName) + "." + data.Name,
+				},
+			})
+		}
 		for _, e := range data.Endpoints {
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "grpc-handler-init",
@@ -218,6 +235,13 @@ func {{ .ServerInit }}(e *{{ .Service.PkgName }}.Endpoints{{ if .HasUnaryEndpoin
 }
 `
 
+// input: map[string]any{"Service":*service.Data, "FullName":string}
+const serverHealthT = `{{ printf "RegisterHealth registers the health checks of the %s service with the given gRPC health server under the name %q." .Service.Name .FullName | comment }}
+func RegisterHealth(hs *goagrpc.HealthServer, e *{{ .Service.PkgName }}.Endpoints) {
+	hs.Register({{ printf "%q" .FullName }}{{ if .Service.Health.Checks }}, e.Health...{{ end }})
+}
+`
+
 // input: EndpointData
 const handlerInitT = `{{ printf "New%sHandler creates a gRPC handler which serves the %q service %q endpoint." .Method.VarName .ServiceName .Method.Name | comment }}
 func New{{ .Method.VarName }}Handler(endpoint goa.Endpoint, h goagrpc.{{ if .ServerStream }}Stream{{ else }}Unary{{ end }}Handler) goagrpc.{{ if .ServerStream }}Stream{{ else }}Unary{{ end }}Handler {
@@ -268,8 +292,17 @@ func (s *{{ .ServerStruct }}) {{ .Method.VarName }}(
 				{{- if .Response.ServerConvert }}
 					var er {{ .Response.ServerConvert.SrcRef }}
 					errors.As(err, &er)
+					{{- if .Response.ServerConvert.Init.ReturnsError }}
+					details, derr := {{ .Response.ServerConvert.Init.Name }}({{ range .Response.ServerConvert.Init.Args }}{{ .Name }}, {{ end }})
+					if derr != nil {
+						return {{ if not $.ServerStream }}nil, {{ end }}derr
+					}
+					return {{ if not $.ServerStream }}nil, {{ end }}goagrpc.NewStatusError({{ .Response.StatusCode }}, err, details)
+					{{- end }}
 				{{- end }}
+				{{- if not (and .Response.ServerConvert .Response.ServerConvert.Init.ReturnsError) }}
 				return {{ if not $.ServerStream }}nil, {{ end }}goagrpc.NewStatusError({{ .Response.StatusCode }}, err, {{ if .Response.ServerConvert }}{{ .Response.ServerConvert.Init.Name }}({{ range .Response.ServerConvert.Init.Args }}{{ .Name }}, {{ end }}){{ else }}goagrpc.NewErrorResponse(err){{ end }})
+				{{- end }}
 		{{- end }}
 			}
 		}
@@ -408,7 +441,14 @@ func Encode{{ .Method.VarName }}Response(ctx context.Context, v any, hdr, trlr *
 		return nil, goagrpc.ErrInvalidType("{{ .ServiceName }}", "{{ .Method.Name }}", "{{ .ResultRef }}", v)
 	}
 {{- end }}
+{{- if .Response.ServerConvert.Init.ReturnsError }}
+	resp, err := {{ .Response.ServerConvert.Init.Name }}({{ range .Response.ServerConvert.Init.Args }}{{ .Name }}, {{ end }})
+	if err != nil {
+		return nil, err
+	}
+{{- else }}
 	resp := {{ .Response.ServerConvert.Init.Name }}({{ range .Response.ServerConvert.Init.Args }}{{ .Name }}, {{ end }})
+{{- end }}
 {{- range .Response.Headers }}
 	{{ template "metadata_encoder" (metadataEncodeDecodeData . "(*hdr)") }}
 {{- end }}
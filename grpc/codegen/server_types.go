@@ -75,6 +75,7 @@ func serverType(genpkg string, svc *expr.GRPCServiceExpr, _ map[string]struct{})
 		imports := []*codegen.ImportSpec{
 			{Path: "unicode/utf8"},
 			codegen.GoaImport(""),
+			codegen.GoaNamedImport("grpc", "goagrpc"),
 			{Path: path.Join(genpkg, svcName), Name: sd.Service.PkgName},
 			{Path: path.Join(genpkg, svcName, "views"), Name: sd.Service.ViewsPkg},
 			{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: sd.PkgName},
@@ -125,8 +126,8 @@ func serverType(genpkg string, svc *expr.GRPCServiceExpr, _ map[string]struct{})
 
 // input: TransformFunctionData
 const transformHelperT = `{{ printf "%s builds a value of type %s from a value of type %s." .Name .ResultTypeRef .ParamTypeRef | comment }}
-func {{ .Name }}(v {{ .ParamTypeRef }}) {{ .ResultTypeRef }} {
+func {{ .Name }}(v {{ .ParamTypeRef }}) {{ if .ReturnsError }}({{ .ResultTypeRef }}, error){{ else }}{{ .ResultTypeRef }}{{ end }} {
   {{ .Code }}
-  return res
+  return res{{ if .ReturnsError }}, nil{{ end }}
 }
 `
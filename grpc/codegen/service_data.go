@@ -7,6 +7,7 @@ import (
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
+	goapb "goa.design/goa/v3/grpc/pb"
 )
 
 // GRPCServices holds the data computed from the design needed to generate the
@@ -27,6 +28,9 @@ type (
 		PkgName string
 		// ProtoImports is the list of proto package imports.
 		ProtoImports []string
+		// Reflection is true if the .proto file and the descriptors of the
+		// service include the Goa options, see the "grpc:reflection" meta.
+		Reflection bool
 		// Name is the service name.
 		Name string
 		// Description is the service description.
@@ -116,6 +120,9 @@ type (
 		ClientInterface string
 		// ClientStream is the client stream data.
 		ClientStream *StreamData
+		// Retry contains the data needed to generate the client retry
+		// policy if any.
+		Retry *service.RetryData
 	}
 
 	// MetadataData describes a gRPC metadata field.
@@ -280,6 +287,11 @@ type (
 		ReturnIsStruct bool
 		// Code is the transformation code.
 		Code string
+		// ReturnsError is true if the constructor returns an error in
+		// addition to the constructed value. This is the case when the
+		// constructor initializes protocol buffer well-known types from
+		// service types as the conversion may fail.
+		ReturnsError bool
 	}
 
 	// InitArgData represents a single constructor argument.
@@ -453,6 +465,12 @@ func (ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 			Scope:               scope,
 		}
 		seen, imported = make(map[string]struct{}), make(map[string]struct{})
+		if protoReflection(gs) {
+			imp := goapb.File_goadesign_goa_options_proto.Path()
+			sd.Reflection = true
+			sd.ProtoImports = append(sd.ProtoImports, imp)
+			imported[imp] = struct{}{}
+		}
 	}
 	for _, e := range gs.GRPCEndpoints {
 		// convert request and response types to protocol buffer message types
@@ -637,6 +655,9 @@ func (ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 			ClientStruct:     sd.ClientStruct,
 			ClientInterface:  sd.ClientInterface,
 		}
+		if md.Retry != nil && !md.Retry.IdempotentOnly {
+			ed.Retry = md.Retry
+		}
 		sd.Endpoints = append(sd.Endpoints, ed)
 		if e.MethodExpr.IsStreaming() {
 			ed.ServerStream = buildStreamData(e, sd, true)
@@ -652,21 +673,18 @@ func collectMessages(at *expr.AttributeExpr, sd *ServiceData, seen map[string]st
 		return
 	}
 	if proto := at.Meta["struct:field:proto"]; len(proto) > 1 {
-		if len(proto) > 1 {
-			imp := proto[1]
+		imports = append(imports, proto[1])
+		if len(proto) > 3 {
 			found := false
 			for _, i := range sd.Service.ProtoImports {
-				if i.Path == imp {
+				if i.Path == proto[3] {
 					found = true
 					break
 				}
 			}
 			if !found {
-				imports = append(imports, imp)
-				if len(proto) > 3 {
-					elems := strings.Split(proto[3], "/")
-					sd.Service.ProtoImports = append(sd.Service.ProtoImports, &codegen.ImportSpec{Path: proto[3], Name: elems[len(elems)-1]})
-				}
+				elems := strings.Split(proto[3], "/")
+				sd.Service.ProtoImports = append(sd.Service.ProtoImports, &codegen.ImportSpec{Path: proto[3], Name: elems[len(elems)-1]})
 			}
 		}
 	}
@@ -744,7 +762,9 @@ func addValidation(att *expr.AttributeExpr, attName string, sd *ServiceData, req
 		}
 	}
 	vtx := protoBufTypeContext(sd.PkgName, sd.Scope, false)
-	if def := codegen.ValidationCode(att, ut, vtx, true, expr.IsAlias(att.Type), attName); def != "" {
+	def := codegen.ValidationCode(att, ut, vtx, true, expr.IsAlias(att.Type), attName)
+	def = joinValidationCode(def, wellKnownTypeRequiredCode(att, vtx, attName, attName))
+	if def != "" {
 		v := &ValidationData{
 			Name:    "Validate" + name,
 			Def:     def,
@@ -775,6 +795,7 @@ func collectValidations(att *expr.AttributeExpr, attName string, ctx *codegen.At
 		}
 		vtx := protoBufTypeContext(sd.PkgName, sd.Scope, false)
 		def := codegen.AttributeValidationCode(att, dt, vtx, true, false, gattName, attName)
+		def = joinValidationCode(def, wellKnownTypeRequiredCode(userTypeAttribute(dt), vtx, gattName, attName))
 		name := protoBufMessageName(att, sd.Scope)
 		kind := validateClient
 		if req {
@@ -818,6 +839,43 @@ func collectValidations(att *expr.AttributeExpr, attName string, ctx *codegen.At
 	}
 }
 
+// wellKnownTypeRequiredCode returns the code that checks that the required
+// attributes of the given object represented with protocol buffer well-known
+// types are set. The generic validation code does not check required
+// primitive attributes as protocol buffer scalars cannot be nil however
+// well-known types are messages.
+//
+// target is the variable holding the object and context is used to produce
+// the error message.
+func wellKnownTypeRequiredCode(att *expr.AttributeExpr, ctx *codegen.AttributeContext, target, context string) string {
+	obj := expr.AsObject(att.Type)
+	if obj == nil || att.Validation == nil {
+		return ""
+	}
+	var checks []string
+	for _, req := range att.Validation.Required {
+		ratt := obj.Attribute(req)
+		if ratt == nil || ratt.Type == expr.Any || protoWellKnownType(ratt) == nil {
+			// Any attributes are already checked by the generic code.
+			continue
+		}
+		checks = append(checks, fmt.Sprintf("if %s.%s == nil {\n\terr = goa.MergeErrors(err, goa.MissingFieldError(%q, %q))\n}",
+			target, ctx.Scope.Field(ratt, req, true), req, context))
+	}
+	return strings.Join(checks, "\n")
+}
+
+// joinValidationCode concatenates the given validation code snippets.
+func joinValidationCode(codes ...string) string {
+	var res []string
+	for _, c := range codes {
+		if c != "" {
+			res = append(res, c)
+		}
+	}
+	return strings.Join(res, "\n")
+}
+
 // userTypeAttribute returns the attribute of the given user type.
 func userTypeAttribute(ut expr.UserType) *expr.AttributeExpr {
 	att := ut.Attribute()
@@ -1027,13 +1085,14 @@ func buildInitData(source, target *expr.AttributeExpr, sourceVar, targetVar stri
 		if _, ok := source.Type.(expr.UserType); ok && usesrc {
 			name += protoBufGoTypeName(source, sd.Scope)
 		}
-		n := protoBufGoTypeName(target, sd.Scope)
-		if !isStruct {
-			// If target is array, map, or primitive the name will be suffixed with
-			// the definition (e.g int, []string, map[int]string) which is incorrect.
-			n = protoBufGoTypeName(source, sd.Scope)
+		if isStruct {
+			name += protoBufGoTypeName(target, sd.Scope)
+		} else {
+			// If target is array, map, or primitive the name would be suffixed
+			// with the definition (e.g int, []string, map[int]string) which is
+			// incorrect.
+			name += protoBufGoTypeName(source, sd.Scope)
 		}
-		name += n
 		code, helpers, err = protoBufTransform(source, target, sourceVar, targetVar, srcCtx, tgtCtx, proto, true)
 		if err != nil {
 			panic(err) // bug
@@ -1057,6 +1116,7 @@ func buildInitData(source, target *expr.AttributeExpr, sourceVar, targetVar stri
 		ReturnTypePkg:  tgtCtx.Pkg(target),
 		Code:           code,
 		Args:           args,
+		ReturnsError:   proto && fallibleToProto(target),
 	}
 }
 
@@ -1371,7 +1431,7 @@ func isEmpty(dt expr.DataType) bool {
 
 // input: InitData
 const typeInitT = `{{ comment .Description }}
-func {{ .Name }}({{ range .Args }}{{ .Name }} {{ .TypeRef }}, {{ end }}) {{ .ReturnTypeRef }} {
+func {{ .Name }}({{ range .Args }}{{ .Name }} {{ .TypeRef }}, {{ end }}) {{ if .ReturnsError }}({{ .ReturnTypeRef }}, error){{ else }}{{ .ReturnTypeRef }}{{ end }} {
 	{{ .Code }}
 {{- if .ReturnIsStruct }}
 	{{- range .Args }}
@@ -1380,7 +1440,7 @@ func {{ .Name }}({{ range .Args }}{{ .Name }} {{ .TypeRef }}, {{ end }}) {{ .Ret
 		{{- end }}
 	{{- end }}
 {{- end }}
-	return {{ .ReturnVarName }}
+	return {{ .ReturnVarName }}{{ if .ReturnsError }}, nil{{ end }}
 }
 `
 
@@ -1416,7 +1476,14 @@ func (s *{{ .VarName }}) {{ .SendName }}(res {{ .SendRef }}) error {
 		vres := {{ .Endpoint.ServicePkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(res, s.view)
 	{{- end }}
 {{- end }}
+{{- if .SendConvert.Init.ReturnsError }}
+	v, err := {{ .SendConvert.Init.Name }}({{ if and .Endpoint.Method.ViewedResult (eq .Type "server") }}vres.Projected{{ else }}res{{ end }})
+	if err != nil {
+		return err
+	}
+{{- else }}
 	v := {{ .SendConvert.Init.Name }}({{ if and .Endpoint.Method.ViewedResult (eq .Type "server") }}vres.Projected{{ else }}res{{ end }})
+{{- end }}
 	return s.stream.{{ .SendName }}(v)
 }
 `
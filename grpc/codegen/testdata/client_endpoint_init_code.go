@@ -4,6 +4,8 @@ const UnaryRPCsClientEndpointInitCode = `// MethodUnaryRPCA calls the "MethodUna
 // service_unary_rp_cspb.ServiceUnaryRPCsClient interface.
 func (c *Client) MethodUnaryRPCA() goa.Endpoint {
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCA")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCs")
 		inv := goagrpc.NewInvoker(
 			BuildMethodUnaryRPCAFunc(c.grpccli, c.opts...),
 			EncodeMethodUnaryRPCARequest,
@@ -20,6 +22,8 @@ func (c *Client) MethodUnaryRPCA() goa.Endpoint {
 // service_unary_rp_cspb.ServiceUnaryRPCsClient interface.
 func (c *Client) MethodUnaryRPCB() goa.Endpoint {
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCB")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCs")
 		inv := goagrpc.NewInvoker(
 			BuildMethodUnaryRPCBFunc(c.grpccli, c.opts...),
 			EncodeMethodUnaryRPCBRequest,
@@ -37,6 +41,8 @@ const UnaryRPCNoPayloadClientEndpointInitCode = `// MethodUnaryRPCNoPayload call
 // service_unary_rpc_no_payloadpb.ServiceUnaryRPCNoPayloadClient interface.
 func (c *Client) MethodUnaryRPCNoPayload() goa.Endpoint {
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCNoPayload")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCNoPayload")
 		inv := goagrpc.NewInvoker(
 			BuildMethodUnaryRPCNoPayloadFunc(c.grpccli, c.opts...),
 			nil,
@@ -54,6 +60,8 @@ const UnaryRPCNoResultClientEndpointInitCode = `// MethodUnaryRPCNoResult calls
 // service_unary_rpc_no_resultpb.ServiceUnaryRPCNoResultClient interface.
 func (c *Client) MethodUnaryRPCNoResult() goa.Endpoint {
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCNoResult")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCNoResult")
 		inv := goagrpc.NewInvoker(
 			BuildMethodUnaryRPCNoResultFunc(c.grpccli, c.opts...),
 			EncodeMethodUnaryRPCNoResultRequest,
@@ -71,6 +79,8 @@ const UnaryRPCWithErrorsClientEndpointInitCode = `// MethodUnaryRPCWithErrors ca
 // service_unary_rpc_with_errorspb.ServiceUnaryRPCWithErrorsClient interface.
 func (c *Client) MethodUnaryRPCWithErrors() goa.Endpoint {
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCWithErrors")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCWithErrors")
 		inv := goagrpc.NewInvoker(
 			BuildMethodUnaryRPCWithErrorsFunc(c.grpccli, c.opts...),
 			EncodeMethodUnaryRPCWithErrorsRequest,
@@ -106,6 +116,8 @@ const UnaryRPCAcronymClientEndpointInitCode = `// MethodUnaryRPCAcronymJWT calls
 // service_unary_rpc_acronympb.ServiceUnaryRPCAcronymClient interface.
 func (c *Client) MethodUnaryRPCAcronymJWT() goa.Endpoint {
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCAcronym_jwt")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCAcronym")
 		inv := goagrpc.NewInvoker(
 			BuildMethodUnaryRPCAcronymJWTFunc(c.grpccli, c.opts...),
 			nil,
@@ -123,6 +135,8 @@ const ServerStreamingRPCClientEndpointInitCode = `// MethodServerStreamingRPC ca
 // service_server_streaming_rpcpb.ServiceServerStreamingRPCClient interface.
 func (c *Client) MethodServerStreamingRPC() goa.Endpoint {
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodServerStreamingRPC")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceServerStreamingRPC")
 		inv := goagrpc.NewInvoker(
 			BuildMethodServerStreamingRPCFunc(c.grpccli, c.opts...),
 			EncodeMethodServerStreamingRPCRequest,
@@ -140,6 +154,8 @@ const ClientStreamingRPCClientEndpointInitCode = `// MethodClientStreamingRPC ca
 // service_client_streaming_rpcpb.ServiceClientStreamingRPCClient interface.
 func (c *Client) MethodClientStreamingRPC() goa.Endpoint {
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodClientStreamingRPC")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceClientStreamingRPC")
 		inv := goagrpc.NewInvoker(
 			BuildMethodClientStreamingRPCFunc(c.grpccli, c.opts...),
 			nil,
@@ -159,6 +175,8 @@ const ClientStreamingNoResultClientEndpointInitCode = `// MethodClientStreamingN
 // interface.
 func (c *Client) MethodClientStreamingNoResult() goa.Endpoint {
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodClientStreamingNoResult")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceClientStreamingNoResult")
 		inv := goagrpc.NewInvoker(
 			BuildMethodClientStreamingNoResultFunc(c.grpccli, c.opts...),
 			nil,
@@ -178,6 +196,8 @@ const ClientStreamingRPCWithPayloadClientEndpointInitCode = `// MethodClientStre
 // interface.
 func (c *Client) MethodClientStreamingRPCWithPayload() goa.Endpoint {
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodClientStreamingRPCWithPayload")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceClientStreamingRPCWithPayload")
 		inv := goagrpc.NewInvoker(
 			BuildMethodClientStreamingRPCWithPayloadFunc(c.grpccli, c.opts...),
 			EncodeMethodClientStreamingRPCWithPayloadRequest,
@@ -197,6 +217,8 @@ const BidirectionalStreamingRPCClientEndpointInitCode = `// MethodBidirectionalS
 // interface.
 func (c *Client) MethodBidirectionalStreamingRPC() goa.Endpoint {
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodBidirectionalStreamingRPC")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceBidirectionalStreamingRPC")
 		inv := goagrpc.NewInvoker(
 			BuildMethodBidirectionalStreamingRPCFunc(c.grpccli, c.opts...),
 			nil,
@@ -216,6 +238,8 @@ const BidirectionalStreamingRPCWithPayloadClientEndpointInitCode = `// MethodBid
 // interface.
 func (c *Client) MethodBidirectionalStreamingRPCWithPayload() goa.Endpoint {
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodBidirectionalStreamingRPCWithPayload")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceBidirectionalStreamingRPCWithPayload")
 		inv := goagrpc.NewInvoker(
 			BuildMethodBidirectionalStreamingRPCWithPayloadFunc(c.grpccli, c.opts...),
 			EncodeMethodBidirectionalStreamingRPCWithPayloadRequest,
@@ -235,6 +259,8 @@ const BidirectionalStreamingRPCWithErrorsClientEndpointInitCode = `// MethodBidi
 // interface.
 func (c *Client) MethodBidirectionalStreamingRPCWithErrors() goa.Endpoint {
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodBidirectionalStreamingRPCWithErrors")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceBidirectionalStreamingRPCWithErrors")
 		inv := goagrpc.NewInvoker(
 			BuildMethodBidirectionalStreamingRPCWithErrorsFunc(c.grpccli, c.opts...),
 			nil,
@@ -253,3 +279,34 @@ func (c *Client) MethodBidirectionalStreamingRPCWithErrors() goa.Endpoint {
 	}
 }
 `
+
+const UnaryRPCWithRetryClientEndpointInitCode = `// MethodUnaryRPCWithRetry calls the "MethodUnaryRPCWithRetry" function in
+// service_unary_rpc_with_retrypb.ServiceUnaryRPCWithRetryClient interface.
+func (c *Client) MethodUnaryRPCWithRetry() goa.Endpoint {
+	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCWithRetry")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCWithRetry")
+		inv := goagrpc.NewInvoker(
+			BuildMethodUnaryRPCWithRetryFunc(c.grpccli, c.opts...),
+			EncodeMethodUnaryRPCWithRetryRequest,
+			DecodeMethodUnaryRPCWithRetryResponse)
+		retry := goa.Retry(&goa.RetryPolicy{
+			MaxAttempts:    5,
+			InitialBackoff: 10 * time.Millisecond,
+			MaxBackoff:     time.Second,
+			Retryable:      goagrpc.RetryableError([]string{"conflict", "unavailable"}),
+		})
+		res, err := retry(inv.Invoke)(ctx, v)
+		if err != nil {
+			resp := goagrpc.DecodeError(err)
+			switch message := resp.(type) {
+			case *goapb.ErrorResponse:
+				return nil, goagrpc.NewServiceError(message)
+			default:
+				return nil, goa.Fault(err.Error())
+			}
+		}
+		return res, nil
+	}
+}
+`
@@ -0,0 +1,117 @@
+package testdata
+
+const ConnectMountCode = `// MountConnect configures the mux to serve the "Connect" service gRPC methods
+// using the Connect and gRPC-Web protocols. The handlers run the given
+// interceptors the same way as a gRPC server configured with
+// grpc.ChainUnaryInterceptor(unary...) and
+// grpc.ChainStreamInterceptor(stream...).
+func MountConnect(mux goahttp.Muxer, srv *Server, unary []grpc.UnaryServerInterceptor, stream []grpc.StreamServerInterceptor) {
+	mux.Handle("POST", "/connect.Connect/Unary", NewUnaryConnectHandler(srv, unary...).ServeHTTP)
+	mux.Handle("POST", "/connect.Connect/ServerStream", NewServerStreamConnectHandler(srv, stream...).ServeHTTP)
+	mux.Handle("POST", "/connect.Connect/ClientStream", NewClientStreamConnectHandler(srv, stream...).ServeHTTP)
+	mux.Handle("POST", "/connect.Connect/Bidirectional", NewBidirectionalConnectHandler(srv, stream...).ServeHTTP)
+}
+`
+
+const ConnectHandlerCode = `// NewUnaryConnectHandler creates a HTTP handler which serves the "Connect"
+// service "Unary" gRPC method using the Connect and gRPC-Web protocols.
+func NewUnaryConnectHandler(srv *Server, interceptors ...grpc.UnaryServerInterceptor) http.Handler {
+	return goagrpc.NewConnectUnaryHandler("/connect.Connect/Unary",
+		func() proto.Message { return &connectpb.UnaryRequest{} },
+		func(ctx context.Context, message proto.Message) (proto.Message, error) {
+			return srv.Unary(ctx, message.(*connectpb.UnaryRequest))
+		}, interceptors...)
+}
+
+// NewServerStreamConnectHandler creates a HTTP handler which serves the
+// "Connect" service "ServerStream" gRPC method using the Connect and gRPC-Web
+// protocols.
+func NewServerStreamConnectHandler(srv *Server, interceptors ...grpc.StreamServerInterceptor) http.Handler {
+	info := &grpc.StreamServerInfo{FullMethod: "/connect.Connect/ServerStream", IsClientStream: false, IsServerStream: true}
+	return goagrpc.NewConnectStreamHandler(info, func(stream grpc.ServerStream) error {
+		message := &connectpb.ServerStreamRequest{}
+		if err := stream.RecvMsg(message); err != nil {
+			return err
+		}
+		return srv.ServerStream(message, &serverStreamConnectServerStream{stream})
+	}, interceptors...)
+}
+
+// NewClientStreamConnectHandler creates a HTTP handler which serves the
+// "Connect" service "ClientStream" gRPC method using the Connect and gRPC-Web
+// protocols.
+func NewClientStreamConnectHandler(srv *Server, interceptors ...grpc.StreamServerInterceptor) http.Handler {
+	info := &grpc.StreamServerInfo{FullMethod: "/connect.Connect/ClientStream", IsClientStream: true, IsServerStream: false}
+	return goagrpc.NewConnectStreamHandler(info, func(stream grpc.ServerStream) error {
+		return srv.ClientStream(&clientStreamConnectServerStream{stream})
+	}, interceptors...)
+}
+
+// NewBidirectionalConnectHandler creates a HTTP handler which serves the
+// "Connect" service "Bidirectional" gRPC method using the Connect and gRPC-Web
+// protocols.
+func NewBidirectionalConnectHandler(srv *Server, interceptors ...grpc.StreamServerInterceptor) http.Handler {
+	info := &grpc.StreamServerInfo{FullMethod: "/connect.Connect/Bidirectional", IsClientStream: true, IsServerStream: true}
+	return goagrpc.NewConnectStreamHandler(info, func(stream grpc.ServerStream) error {
+		return srv.Bidirectional(&bidirectionalConnectServerStream{stream})
+	}, interceptors...)
+}
+`
+
+const ConnectStreamCode = `// serverStreamConnectServerStream implements the
+// connectpb.Connect_ServerStreamServer interface using a Connect or gRPC-Web
+// stream.
+type serverStreamConnectServerStream struct {
+	grpc.ServerStream
+}
+
+// Send writes instances of "connectpb.ServerStreamResponse" to the stream.
+func (s *serverStreamConnectServerStream) Send(m *connectpb.ServerStreamResponse) error {
+	return s.SendMsg(m)
+}
+
+// clientStreamConnectServerStream implements the
+// connectpb.Connect_ClientStreamServer interface using a Connect or gRPC-Web
+// stream.
+type clientStreamConnectServerStream struct {
+	grpc.ServerStream
+}
+
+// SendAndClose writes instances of "connectpb.ClientStreamResponse" to the
+// stream.
+func (s *clientStreamConnectServerStream) SendAndClose(m *connectpb.ClientStreamResponse) error {
+	return s.SendMsg(m)
+}
+
+// Recv reads instances of "connectpb.ClientStreamStreamingRequest" from the
+// stream.
+func (s *clientStreamConnectServerStream) Recv() (*connectpb.ClientStreamStreamingRequest, error) {
+	m := &connectpb.ClientStreamStreamingRequest{}
+	if err := s.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// bidirectionalConnectServerStream implements the
+// connectpb.Connect_BidirectionalServer interface using a Connect or gRPC-Web
+// stream.
+type bidirectionalConnectServerStream struct {
+	grpc.ServerStream
+}
+
+// Send writes instances of "connectpb.BidirectionalResponse" to the stream.
+func (s *bidirectionalConnectServerStream) Send(m *connectpb.BidirectionalResponse) error {
+	return s.SendMsg(m)
+}
+
+// Recv reads instances of "connectpb.BidirectionalStreamingRequest" from the
+// stream.
+func (s *bidirectionalConnectServerStream) Recv() (*connectpb.BidirectionalStreamingRequest, error) {
+	m := &connectpb.BidirectionalStreamingRequest{}
+	if err := s.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+`
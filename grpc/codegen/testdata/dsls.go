@@ -52,6 +52,28 @@ var UnaryRPCNoResultDSL = func() {
 	})
 }
 
+var UnaryRPCWithRetryDSL = func() {
+	Service("ServiceUnaryRPCWithRetry", func() {
+		Error("unavailable", func() {
+			Temporary()
+		})
+		Method("MethodUnaryRPCWithRetry", func() {
+			Payload(String)
+			Result(String)
+			Error("conflict")
+			Retry(func() {
+				MaxAttempts(5)
+				Backoff("10ms", "1s")
+				RetryOn("conflict")
+			})
+			GRPC(func() {
+				Response("unavailable", CodeUnavailable)
+				Response("conflict", CodeAborted)
+			})
+		})
+	})
+}
+
 var UnaryRPCWithErrorsDSL = func() {
 	var ErrorType = Type("ErrorType", func() {
 		Attribute("a", String)
@@ -880,6 +902,26 @@ var PayloadWithValidationsDSL = func() {
 	})
 }
 
+var WellKnownTypesDSL = func() {
+	Service("WellKnownTypes", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Field(1, "created_at", String, func() {
+					Format(FormatDateTime)
+				})
+				Field(2, "timeout", String, func() {
+					Format(FormatDuration)
+				})
+				Field(3, "data", Any)
+				Field(4, "labels", MapOf(String, Any))
+				Required("created_at")
+			})
+			Result(Any)
+			GRPC(func() {})
+		})
+	})
+}
+
 var StructMetaTypeDSL = func() {
 	Service("UsingMetaTypes", func() {
 		Method("Method", func() {
@@ -947,3 +989,113 @@ var DefaultFieldsDSL = func() {
 		})
 	})
 }
+
+var ProtoImportsDSL = func() {
+	Service("ProtoImports", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Field(1, "price", String, func() {
+					Meta("struct:field:proto", "ext.v1.Money", "ext/money.proto", "Money", "example.com/extpb")
+				})
+				Field(2, "color", String, func() {
+					Meta("struct:field:proto", "ext.v1.Color", "ext/money.proto", "Color", "example.com/extpb")
+				})
+				Field(3, "thing", String, func() {
+					Meta("struct:field:proto", "other.v1.Thing", "other/thing.proto", "Thing", "example.com/otherpb")
+				})
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var ReflectionDSL = func() {
+	Service("Reflection", func() {
+		Meta("grpc:reflection")
+		Description("Service that exposes the design in its descriptors.")
+		Method("Method", func() {
+			Description("Method creates a widget.")
+			Payload(func() {
+				Field(1, "name", String, "Name of the widget", func() {
+					MinLength(1)
+					Pattern("^[a-z]+$")
+					Example("widget")
+				})
+				Field(2, "size", Int, func() {
+					Enum(1, 2, 3)
+					Default(1)
+				})
+				Field(3, "weight", Float64, func() {
+					Minimum(0)
+					ExclusiveMaximum(100.5)
+				})
+				OneOf("value", func() {
+					Field(4, "str", String, "String value")
+					Field(5, "num", Int64)
+				})
+				Required("name")
+			})
+			Result(String)
+			Error("not_found", func() {
+				Description("Widget not found")
+			})
+			GRPC(func() {
+				Response("not_found", CodeNotFound)
+			})
+		})
+	})
+}
+
+var DeprecatedDSL = func() {
+	var Widget = Type("Widget", func() {
+		Deprecated("Use Gadget instead.")
+		Field(1, "id", String)
+	})
+	Service("Deprecated", func() {
+		Deprecated("Use the Widgets service instead.", "2030-01-01")
+		Method("Method", func() {
+			Deprecated("Use Create instead.")
+			Payload(func() {
+				Field(1, "name", String)
+				Field(2, "legacy_name", String, func() {
+					Deprecated("Use name instead.")
+				})
+			})
+			Result(func() {
+				Field(1, "widget", Widget)
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var ConnectDSL = func() {
+	var Entry = Type("Entry", func() {
+		Field(1, "message", String)
+	})
+	Service("Connect", func() {
+		Meta("grpc:connect")
+		Method("Unary", func() {
+			Payload(func() {
+				Field(1, "a", Int)
+			})
+			Result(Int)
+			GRPC(func() {})
+		})
+		Method("ServerStream", func() {
+			Payload(Int)
+			StreamingResult(Entry)
+			GRPC(func() {})
+		})
+		Method("ClientStream", func() {
+			StreamingPayload(Entry)
+			Result(Int)
+			GRPC(func() {})
+		})
+		Method("Bidirectional", func() {
+			StreamingPayload(Entry)
+			StreamingResult(Entry)
+			GRPC(func() {})
+		})
+	})
+}
@@ -472,6 +472,34 @@ message MethodResponse {
 }
 `
 
+const WellKnownTypesPackageCode = `
+syntax = "proto3";
+
+package well_known_types;
+
+option go_package = "/well_known_typespb";
+import "google/protobuf/timestamp.proto";
+import "google/protobuf/duration.proto";
+import "google/protobuf/struct.proto";
+
+// Service is the WellKnownTypes service interface.
+service WellKnownTypes {
+	// Method implements Method.
+	rpc Method (MethodRequest) returns (MethodResponse);
+}
+
+message MethodRequest {
+	google.protobuf.Timestamp created_at = 1;
+	google.protobuf.Duration timeout = 2;
+	google.protobuf.Value data = 3;
+	map<string, google.protobuf.Value> labels = 4;
+}
+
+message MethodResponse {
+	google.protobuf.Value field = 1;
+}
+`
+
 const DefaultFieldsPackageCode = `
 syntax = "proto3";
 
@@ -504,3 +532,70 @@ message MethodRequest {
 message MethodResponse {
 }
 `
+
+const ReflectionPackageCode = `
+syntax = "proto3";
+
+package reflection;
+
+option go_package = "/reflectionpb";
+import "goadesign_goa_options.proto";
+
+// Service that exposes the design in its descriptors.
+service Reflection {
+	option (goapb.service) = { description: "Service that exposes the design in its descriptors." };
+	// Method creates a widget.
+	rpc Method (MethodRequest) returns (MethodResponse) {
+		option (goapb.method) = { description: "Method creates a widget." errors: [{ name: "not_found" code: 5 description: "Widget not found" }] };
+	}
+}
+
+message MethodRequest {
+	option (goapb.message) = { required: ["name"] };
+	// Name of the widget
+	string name = 1 [(goapb.field) = { description: "Name of the widget" examples: ["\"widget\""] validation: { pattern: "^[a-z]+$" min_length: 1 } }];
+	optional sint32 size = 2 [(goapb.field) = { default_value: "1" validation: { values: ["1", "2", "3"] } }];
+	optional double weight = 3 [(goapb.field) = { validation: { minimum: 0 exclusive_maximum: 100.5 } }];
+	oneof value {
+		// String value
+	string str = 4 [(goapb.field) = { description: "String value" }];
+		sint64 num = 5;
+	}
+}
+
+message MethodResponse {
+	option (goapb.message) = { required: ["field"] };
+	string field = 1;
+}
+`
+
+const DeprecatedPackageCode = `
+syntax = "proto3";
+
+package deprecated;
+
+option go_package = "/deprecatedpb";
+
+// Service is the Deprecated service interface.
+service Deprecated {
+	option deprecated = true;
+	// Method implements Method.
+	rpc Method (MethodRequest) returns (MethodResponse) {
+		option deprecated = true;
+	}
+}
+
+message MethodRequest {
+	optional string name = 1;
+	optional string legacy_name = 2 [deprecated = true];
+}
+
+message MethodResponse {
+	Widget widget = 1;
+}
+
+message Widget {
+	option deprecated = true;
+	optional string id = 1;
+}
+`
@@ -554,3 +554,48 @@ func NewProtoMethodResponse() *default_fieldspb.MethodResponse {
 	return message
 }
 `
+
+const WellKnownTypesServerTypeCode = `// NewMethodPayload builds the payload of the "Method" endpoint of the
+// "WellKnownTypes" service from the gRPC request type.
+func NewMethodPayload(message *well_known_typespb.MethodRequest) *wellknowntypes.MethodPayload {
+	v := &wellknowntypes.MethodPayload{
+		CreatedAt: goagrpc.TimestampString(message.CreatedAt),
+		Data:      goagrpc.ValueInterface(message.Data),
+	}
+	if message.Timeout != nil {
+		timeout := goagrpc.DurationString(message.Timeout)
+		v.Timeout = &timeout
+	}
+	if message.Labels != nil {
+		v.Labels = make(map[string]any, len(message.Labels))
+		for key, val := range message.Labels {
+			tk := key
+			tv := goagrpc.ValueInterface(val)
+			v.Labels[tk] = tv
+		}
+	}
+	return v
+}
+
+// NewProtoMethodResponse builds the gRPC response type from the result of the
+// "Method" endpoint of the "WellKnownTypes" service.
+func NewProtoMethodResponse(result any) (*well_known_typespb.MethodResponse, error) {
+	message := &well_known_typespb.MethodResponse{}
+	{
+		cv, err := goagrpc.NewValue(result)
+		if err != nil {
+			return nil, err
+		}
+		message.Field = cv
+	}
+	return message, nil
+}
+
+// ValidateMethodRequest runs the validations defined on MethodRequest.
+func ValidateMethodRequest(message *well_known_typespb.MethodRequest) (err error) {
+	if message.CreatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("created_at", "message"))
+	}
+	return
+}
+`
@@ -0,0 +1,732 @@
+package grpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	goahttp "goa.design/goa/v3/http"
+)
+
+type (
+	// ConnectUnaryFunc invokes a unary gRPC method with the given request
+	// message and returns the response message.
+	ConnectUnaryFunc func(ctx context.Context, req proto.Message) (proto.Message, error)
+
+	// ConnectStreamFunc invokes a streaming gRPC method with the given
+	// stream.
+	ConnectStreamFunc func(stream grpc.ServerStream) error
+
+	// connectHandler serves a gRPC method using the Connect and gRPC-Web
+	// protocols.
+	connectHandler struct {
+		method     string
+		newRequest func() proto.Message
+		unary      ConnectUnaryFunc
+		stream     ConnectStreamFunc
+		// unaryChain invokes unary through the unary interceptors.
+		unaryChain grpc.UnaryHandler
+		// streamChain invokes stream through the stream interceptors.
+		streamChain grpc.StreamHandler
+	}
+
+	// connectStream implements grpc.ServerStream on top of a HTTP request
+	// and response.
+	connectStream struct {
+		ctx         context.Context
+		w           http.ResponseWriter
+		body        io.Reader
+		protocol    connectProtocol
+		codec       connectCodec
+		contentType string
+		header      metadata.MD
+		trailer     metadata.MD
+		wroteHeader bool
+	}
+
+	// connectTransport implements grpc.ServerTransportStream so that
+	// grpc.SetHeader, grpc.SendHeader and grpc.SetTrailer may be used by
+	// the unary handlers.
+	connectTransport struct {
+		method string
+		s      *connectStream
+	}
+
+	// connectCodec marshals and unmarshals protocol buffer messages.
+	connectCodec interface {
+		Marshal(proto.Message) ([]byte, error)
+		Unmarshal([]byte, proto.Message) error
+	}
+
+	// connectProtocol identifies the protocol used by a request.
+	connectProtocol int
+
+	// base64Reader decodes a gRPC-Web text request body, that is one or
+	// more concatenated base64 encoded chunks.
+	base64Reader struct {
+		r   *bufio.Reader
+		buf []byte
+	}
+
+	// connectError is the JSON representation of an error in the Connect
+	// protocol.
+	connectError struct {
+		Code    string                `json:"code"`
+		Message string                `json:"message,omitempty"`
+		Details []*connectErrorDetail `json:"details,omitempty"`
+	}
+
+	// connectErrorDetail is the JSON representation of an error detail in
+	// the Connect protocol.
+	connectErrorDetail struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+
+	// connectEndStream is the message that ends a Connect streaming
+	// response.
+	connectEndStream struct {
+		Error    *connectError       `json:"error,omitempty"`
+		Metadata map[string][]string `json:"metadata,omitempty"`
+	}
+
+	protoCodec struct{}
+	jsonCodec  struct{}
+)
+
+const (
+	// protocolConnectUnary is the Connect protocol for unary methods.
+	protocolConnectUnary connectProtocol = iota + 1
+	// protocolConnectStream is the Connect protocol for streaming methods.
+	protocolConnectStream
+	// protocolGRPCWeb is the gRPC-Web protocol.
+	protocolGRPCWeb
+	// protocolGRPCWebText is the gRPC-Web protocol with base64 encoded
+	// request and response bodies.
+	protocolGRPCWebText
+)
+
+const (
+	// ConnectMaxMessageSize is the maximum size in bytes of the request
+	// messages read by the Connect and gRPC-Web handlers.
+	ConnectMaxMessageSize = 4 << 20
+
+	// flagCompressed is the envelope flag set when the message is
+	// compressed.
+	flagCompressed = 0x01
+	// flagConnectEndStream is the envelope flag set on the message that
+	// ends a Connect streaming response.
+	flagConnectEndStream = 0x02
+	// flagGRPCWebTrailer is the envelope flag set on the gRPC-Web frame
+	// that contains the trailers.
+	flagGRPCWebTrailer = 0x80
+)
+
+// connectCodeNames lists the names of the gRPC codes in the Connect
+// protocol.
+var connectCodeNames = map[codes.Code]string{
+	codes.Canceled:           "canceled",
+	codes.Unknown:            "unknown",
+	codes.InvalidArgument:    "invalid_argument",
+	codes.DeadlineExceeded:   "deadline_exceeded",
+	codes.NotFound:           "not_found",
+	codes.AlreadyExists:      "already_exists",
+	codes.PermissionDenied:   "permission_denied",
+	codes.ResourceExhausted:  "resource_exhausted",
+	codes.FailedPrecondition: "failed_precondition",
+	codes.Aborted:            "aborted",
+	codes.OutOfRange:         "out_of_range",
+	codes.Unimplemented:      "unimplemented",
+	codes.Internal:           "internal",
+	codes.Unavailable:        "unavailable",
+	codes.DataLoss:           "data_loss",
+	codes.Unauthenticated:    "unauthenticated",
+}
+
+// connectHTTPStatus lists the HTTP status codes of the Connect unary error
+// responses.
+var connectHTTPStatus = map[codes.Code]int{
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// NewConnectUnaryHandler returns a HTTP handler that serves the unary gRPC
+// method with the given full name (e.g. "/calc.Calc/Add") using the Connect
+// protocol (application/proto and application/json request bodies) and the
+// gRPC-Web protocol (application/grpc-web, application/grpc-web+proto,
+// application/grpc-web+json, application/grpc-web-text and
+// application/grpc-web-text+proto request bodies). newRequest returns a new
+// request message and handle invokes the method. The handler runs the given
+// interceptors in order before invoking the method the same way
+// grpc.ChainUnaryInterceptor does, the Server field of the interceptor info
+// is nil. The handler makes the request headers available as the incoming
+// gRPC metadata and sends the headers and trailers set with grpc.SetHeader,
+// grpc.SendHeader and grpc.SetTrailer.
+func NewConnectUnaryHandler(method string, newRequest func() proto.Message, handle ConnectUnaryFunc, interceptors ...grpc.UnaryServerInterceptor) http.Handler {
+	h := &connectHandler{method: method, newRequest: newRequest, unary: handle}
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	chain := grpc.UnaryHandler(func(ctx context.Context, req any) (any, error) {
+		return handle(ctx, req.(proto.Message))
+	})
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], chain
+		chain = func(ctx context.Context, req any) (any, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+	h.unaryChain = chain
+	return h
+}
+
+// NewConnectStreamHandler returns a HTTP handler that serves the streaming
+// gRPC method described by info (e.g. "/chat.Chat/Listen") using the Connect
+// streaming protocol (application/connect+proto and application/connect+json
+// request bodies) and the gRPC-Web protocol. handle invokes the method with a
+// stream that reads the request messages from the request body and writes the
+// response messages to the response body. The handler runs the given
+// interceptors in order before invoking the method the same way
+// grpc.ChainStreamInterceptor does, the srv argument of the interceptors is
+// nil. The gRPC-Web protocol and HTTP/1.1 only support server streaming,
+// client and bidirectional streaming require HTTP/2.
+func NewConnectStreamHandler(info *grpc.StreamServerInfo, handle ConnectStreamFunc, interceptors ...grpc.StreamServerInterceptor) http.Handler {
+	h := &connectHandler{method: info.FullMethod, stream: handle}
+	chain := grpc.StreamHandler(func(_ any, stream grpc.ServerStream) error {
+		return handle(stream)
+	})
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], chain
+		chain = func(srv any, stream grpc.ServerStream) error {
+			return interceptor(srv, stream, info, next)
+		}
+	}
+	h.streamChain = chain
+	return h
+}
+
+// ServeHTTP serves the gRPC method using the protocol identified by the
+// request content type.
+func (h *connectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	ct := r.Header.Get("Content-Type")
+	protocol, codec := negotiateConnect(ct)
+	if protocol == 0 ||
+		(protocol == protocolConnectUnary && h.unary == nil) ||
+		(protocol == protocolConnectStream && h.stream == nil) {
+		http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+		return
+	}
+	ctx := r.Context()
+	if timeout, ok := connectTimeout(r.Header, protocol); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var body io.Reader = r.Body
+	if protocol == protocolGRPCWebText {
+		body = &base64Reader{r: bufio.NewReader(r.Body)}
+	}
+	s := &connectStream{
+		w:           w,
+		body:        body,
+		protocol:    protocol,
+		codec:       codec,
+		contentType: ct,
+		header:      metadata.MD{},
+		trailer:     metadata.MD{},
+	}
+	ctx = metadata.NewIncomingContext(ctx, incomingMetadata(r.Header))
+	s.ctx = grpc.NewContextWithServerTransportStream(ctx, &connectTransport{method: h.method, s: s})
+
+	switch {
+	case protocol == protocolConnectUnary:
+		h.serveConnectUnary(s, r)
+	case h.unary != nil:
+		req := h.newRequest()
+		err := s.RecvMsg(req)
+		if err == nil {
+			var resp proto.Message
+			if resp, err = h.invokeUnary(s.ctx, req); err == nil {
+				err = s.SendMsg(resp)
+			}
+		}
+		s.finish(err)
+	default:
+		if r.ProtoMajor == 1 {
+			// Keep reading the request body after writing the response
+			// (HTTP/1.1), this is a no-op for HTTP/2 requests.
+			goahttp.EnableFullDuplex(w) // nolint: errcheck
+		}
+		s.finish(h.streamChain(nil, s))
+	}
+}
+
+// invokeUnary invokes the unary method through the interceptors.
+func (h *connectHandler) invokeUnary(ctx context.Context, req proto.Message) (proto.Message, error) {
+	resp, err := h.unaryChain(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "invalid response type %T", resp)
+	}
+	return msg, nil
+}
+
+// serveConnectUnary serves a unary method using the Connect unary protocol:
+// the request and response bodies contain a single message and errors are
+// written as JSON objects.
+func (h *connectHandler) serveConnectUnary(s *connectStream, r *http.Request) {
+	if enc := r.Header.Get("Content-Encoding"); enc != "" && enc != "identity" {
+		s.writeConnectUnaryError(status.Errorf(codes.Unimplemented, "unsupported content encoding %q", enc))
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(s.body, ConnectMaxMessageSize+1))
+	if err != nil {
+		s.writeConnectUnaryError(status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+	if len(body) > ConnectMaxMessageSize {
+		s.writeConnectUnaryError(status.Errorf(codes.ResourceExhausted, "message larger than %d bytes", ConnectMaxMessageSize))
+		return
+	}
+	req := h.newRequest()
+	if err := s.codec.Unmarshal(body, req); err != nil {
+		s.writeConnectUnaryError(status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+	resp, err := h.invokeUnary(s.ctx, req)
+	if err != nil {
+		s.writeConnectUnaryError(err)
+		return
+	}
+	b, err := s.codec.Marshal(resp)
+	if err != nil {
+		s.writeConnectUnaryError(status.Error(codes.Internal, err.Error()))
+		return
+	}
+	s.writeConnectUnaryHeader(s.contentType, http.StatusOK)
+	s.w.Write(b) // nolint: errcheck
+}
+
+// Context returns the request context.
+func (s *connectStream) Context() context.Context {
+	return s.ctx
+}
+
+// SetHeader sets the header metadata sent with the response headers.
+func (s *connectStream) SetHeader(md metadata.MD) error {
+	if s.wroteHeader {
+		return status.Error(codes.Internal, "SetHeader called after the headers were sent")
+	}
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// SendHeader sends the response headers with the header metadata.
+func (s *connectStream) SendHeader(md metadata.MD) error {
+	if err := s.SetHeader(md); err != nil {
+		return err
+	}
+	if s.protocol != protocolConnectUnary {
+		s.writeHeader()
+	}
+	return nil
+}
+
+// SetTrailer sets the trailer metadata sent when the method returns.
+func (s *connectStream) SetTrailer(md metadata.MD) {
+	s.trailer = metadata.Join(s.trailer, md)
+}
+
+// SendMsg writes the enveloped message to the response body and flushes it.
+func (s *connectStream) SendMsg(m any) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "invalid message type %T", m)
+	}
+	b, err := s.codec.Marshal(msg)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	s.writeHeader()
+	return s.writeEnvelope(0, b)
+}
+
+// RecvMsg reads the next enveloped message from the request body. It returns
+// io.EOF when there are no more messages.
+func (s *connectStream) RecvMsg(m any) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "invalid message type %T", m)
+	}
+	var prefix [5]byte
+	if _, err := io.ReadFull(s.body, prefix[:]); err != nil {
+		if err == io.EOF {
+			return io.EOF
+		}
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if prefix[0]&flagCompressed != 0 {
+		return status.Error(codes.Unimplemented, "compressed messages are not supported")
+	}
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > ConnectMaxMessageSize {
+		return status.Errorf(codes.ResourceExhausted, "message larger than %d bytes", ConnectMaxMessageSize)
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(s.body, b); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.codec.Unmarshal(b, msg); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// finish ends an enveloped response with the status of err and the trailer
+// metadata.
+func (s *connectStream) finish(err error) {
+	s.writeHeader()
+	if s.protocol == protocolConnectStream {
+		end := connectEndStream{Metadata: outgoingMetadata(s.trailer)}
+		if err != nil {
+			end.Error = newConnectError(err)
+		}
+		b, _ := json.Marshal(end)
+		s.writeEnvelope(flagConnectEndStream, b) // nolint: errcheck
+		return
+	}
+	st := status.Convert(err)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "grpc-status: %d\r\n", st.Code())
+	if msg := st.Message(); msg != "" {
+		fmt.Fprintf(&buf, "grpc-message: %s\r\n", encodeGRPCMessage(msg))
+	}
+	if len(st.Details()) > 0 {
+		if b, err := proto.Marshal(st.Proto()); err == nil {
+			fmt.Fprintf(&buf, "grpc-status-details-bin: %s\r\n", base64.RawStdEncoding.EncodeToString(b))
+		}
+	}
+	for k, vals := range outgoingMetadata(s.trailer) {
+		for _, v := range vals {
+			fmt.Fprintf(&buf, "%s: %s\r\n", k, v)
+		}
+	}
+	s.writeEnvelope(flagGRPCWebTrailer, buf.Bytes()) // nolint: errcheck
+}
+
+// writeHeader writes the status code and headers of an enveloped response
+// once.
+func (s *connectStream) writeHeader() {
+	if s.wroteHeader {
+		return
+	}
+	s.wroteHeader = true
+	h := s.w.Header()
+	h.Set("Content-Type", s.contentType)
+	for k, vals := range outgoingMetadata(s.header) {
+		for _, v := range vals {
+			h.Add(k, v)
+		}
+	}
+	s.w.WriteHeader(http.StatusOK)
+}
+
+// writeEnvelope writes the enveloped message to the response body and
+// flushes it. The envelope is base64 encoded for the gRPC-Web text protocol.
+func (s *connectStream) writeEnvelope(flags byte, b []byte) error {
+	var prefix [5]byte
+	prefix[0] = flags
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(b)))
+	if s.protocol == protocolGRPCWebText {
+		frame := append(prefix[:], b...)
+		if _, err := io.WriteString(s.w, base64.StdEncoding.EncodeToString(frame)); err != nil {
+			return err
+		}
+	} else {
+		if _, err := s.w.Write(prefix[:]); err != nil {
+			return err
+		}
+		if _, err := s.w.Write(b); err != nil {
+			return err
+		}
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// writeConnectUnaryHeader writes the status code and headers of a Connect
+// unary response. The trailer metadata is sent in headers prefixed with
+// "Trailer-".
+func (s *connectStream) writeConnectUnaryHeader(ct string, code int) {
+	s.wroteHeader = true
+	h := s.w.Header()
+	h.Set("Content-Type", ct)
+	for k, vals := range outgoingMetadata(s.header) {
+		for _, v := range vals {
+			h.Add(k, v)
+		}
+	}
+	for k, vals := range outgoingMetadata(s.trailer) {
+		for _, v := range vals {
+			h.Add("Trailer-"+k, v)
+		}
+	}
+	s.w.WriteHeader(code)
+}
+
+// writeConnectUnaryError writes the Connect unary error response
+// corresponding to err.
+func (s *connectStream) writeConnectUnaryError(err error) {
+	cerr := newConnectError(err)
+	code, ok := connectHTTPStatus[status.Code(err)]
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+	s.writeConnectUnaryHeader("application/json", code)
+	json.NewEncoder(s.w).Encode(cerr) // nolint: errcheck
+}
+
+// Method returns the full name of the gRPC method.
+func (t *connectTransport) Method() string {
+	return t.method
+}
+
+// SetHeader sets the header metadata sent with the response headers.
+func (t *connectTransport) SetHeader(md metadata.MD) error {
+	return t.s.SetHeader(md)
+}
+
+// SendHeader sends the response headers with the header metadata.
+func (t *connectTransport) SendHeader(md metadata.MD) error {
+	return t.s.SendHeader(md)
+}
+
+// SetTrailer sets the trailer metadata sent when the method returns.
+func (t *connectTransport) SetTrailer(md metadata.MD) error {
+	t.s.SetTrailer(md)
+	return nil
+}
+
+// Marshal encodes m using the protocol buffer binary format.
+func (protoCodec) Marshal(m proto.Message) ([]byte, error) {
+	return proto.Marshal(m)
+}
+
+// Unmarshal decodes b using the protocol buffer binary format.
+func (protoCodec) Unmarshal(b []byte, m proto.Message) error {
+	return proto.Unmarshal(b, m)
+}
+
+// Marshal encodes m using the protocol buffer JSON mapping.
+func (jsonCodec) Marshal(m proto.Message) ([]byte, error) {
+	return protojson.Marshal(m)
+}
+
+// Unmarshal decodes b using the protocol buffer JSON mapping, unknown fields
+// are ignored.
+func (jsonCodec) Unmarshal(b []byte, m proto.Message) error {
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(b, m)
+}
+
+// negotiateConnect returns the protocol and codec identified by the given
+// request content type. It returns 0 if the content type is not supported.
+func negotiateConnect(ct string) (connectProtocol, connectCodec) {
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return 0, nil
+	}
+	switch mt {
+	case "application/proto":
+		return protocolConnectUnary, protoCodec{}
+	case "application/json":
+		return protocolConnectUnary, jsonCodec{}
+	case "application/connect+proto":
+		return protocolConnectStream, protoCodec{}
+	case "application/connect+json":
+		return protocolConnectStream, jsonCodec{}
+	case "application/grpc-web", "application/grpc-web+proto":
+		return protocolGRPCWeb, protoCodec{}
+	case "application/grpc-web+json":
+		return protocolGRPCWeb, jsonCodec{}
+	case "application/grpc-web-text", "application/grpc-web-text+proto":
+		return protocolGRPCWebText, protoCodec{}
+	}
+	return 0, nil
+}
+
+// Read decodes the request body. Each chunk of four base64 characters is
+// decoded separately so that the body may contain padded chunks.
+func (b *base64Reader) Read(p []byte) (int, error) {
+	for len(b.buf) == 0 {
+		var (
+			chunk   [4]byte
+			decoded [3]byte
+		)
+		n, err := io.ReadFull(b.r, chunk[:])
+		switch {
+		case err == io.EOF:
+			return 0, io.EOF
+		case err == io.ErrUnexpectedEOF:
+			// Last chunk without padding.
+			n, err = base64.RawStdEncoding.Decode(decoded[:], chunk[:n])
+		case err == nil:
+			n, err = base64.StdEncoding.Decode(decoded[:], chunk[:])
+		}
+		if err != nil {
+			return 0, err
+		}
+		b.buf = decoded[:n]
+	}
+	n := copy(p, b.buf)
+	b.buf = b.buf[n:]
+	return n, nil
+}
+
+// connectTimeout returns the timeout set by the client in the
+// Connect-Timeout-Ms header (Connect) or in the grpc-timeout header
+// (gRPC-Web).
+func connectTimeout(h http.Header, protocol connectProtocol) (time.Duration, bool) {
+	if protocol != protocolGRPCWeb && protocol != protocolGRPCWebText {
+		ms, err := strconv.ParseInt(h.Get("Connect-Timeout-Ms"), 10, 64)
+		if err != nil || ms <= 0 {
+			return 0, false
+		}
+		return time.Duration(ms) * time.Millisecond, true
+	}
+	v := h.Get("Grpc-Timeout")
+	if len(v) < 2 {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(v[:len(v)-1], 10, 64)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	units := map[byte]time.Duration{
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+		'm': time.Millisecond,
+		'u': time.Microsecond,
+		'n': time.Nanosecond,
+	}
+	unit, ok := units[v[len(v)-1]]
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(n) * unit, true
+}
+
+// incomingMetadata returns the gRPC metadata corresponding to the given
+// request headers. The values of the binary headers (suffixed with "-bin")
+// are base64 decoded.
+func incomingMetadata(h http.Header) metadata.MD {
+	md := make(metadata.MD, len(h))
+	for k, vals := range h {
+		key := strings.ToLower(k)
+		if !strings.HasSuffix(key, "-bin") {
+			md[key] = append(md[key], vals...)
+			continue
+		}
+		for _, v := range vals {
+			b, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				b, err = base64.RawStdEncoding.DecodeString(v)
+			}
+			if err == nil {
+				md[key] = append(md[key], string(b))
+			}
+		}
+	}
+	return md
+}
+
+// outgoingMetadata returns the header values corresponding to the given gRPC
+// metadata. The values of the binary keys (suffixed with "-bin") are base64
+// encoded.
+func outgoingMetadata(md metadata.MD) map[string][]string {
+	if len(md) == 0 {
+		return nil
+	}
+	res := make(map[string][]string, len(md))
+	for k, vals := range md {
+		if !strings.HasSuffix(k, "-bin") {
+			res[k] = vals
+			continue
+		}
+		for _, v := range vals {
+			res[k] = append(res[k], base64.RawStdEncoding.EncodeToString([]byte(v)))
+		}
+	}
+	return res
+}
+
+// newConnectError returns the Connect representation of err.
+func newConnectError(err error) *connectError {
+	st := status.Convert(err)
+	cerr := &connectError{Code: connectCodeNames[st.Code()], Message: st.Message()}
+	if cerr.Code == "" {
+		cerr.Code = connectCodeNames[codes.Unknown]
+	}
+	for _, d := range st.Proto().GetDetails() {
+		cerr.Details = append(cerr.Details, &connectErrorDetail{
+			Type:  strings.TrimPrefix(d.GetTypeUrl(), "type.googleapis.com/"),
+			Value: base64.RawStdEncoding.EncodeToString(d.GetValue()),
+		})
+	}
+	return cerr
+}
+
+// encodeGRPCMessage percent-encodes the bytes of the given gRPC status
+// message that are not printable ASCII characters as required by the
+// grpc-message trailer.
+func encodeGRPCMessage(msg string) string {
+	var sb strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c < 0x20 || c > 0x7e || c == '%' {
+			fmt.Fprintf(&sb, "%%%02X", c)
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
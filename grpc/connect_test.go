@@ -0,0 +1,322 @@
+package grpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// healthWatchServer implements grpc_health_v1.Health_WatchServer the same
+// way as the stream types generated for the Connect handlers.
+type healthWatchServer struct {
+	grpc.ServerStream
+}
+
+func (s *healthWatchServer) Send(m *grpc_health_v1.HealthCheckResponse) error {
+	return s.SendMsg(m)
+}
+
+func newHealthConnectHandlers(t *testing.T) (unary, stream http.Handler) {
+	hs := NewHealthServer()
+	hs.Register("svc.OK", &goa.HealthCheck{Name: "db", Check: func(context.Context) error { return nil }})
+	hs.WatchInterval = 10 * time.Millisecond
+	unary = NewConnectUnaryHandler("/grpc.health.v1.Health/Check",
+		func() proto.Message { return &grpc_health_v1.HealthCheckRequest{} },
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			if err := grpc.SetHeader(ctx, metadata.Pairs("x-request-id", strings.Join(md.Get("x-request-id"), ""))); err != nil {
+				t.Errorf("failed to set header: %s", err)
+			}
+			if err := grpc.SetTrailer(ctx, metadata.Pairs("x-checked", "true")); err != nil {
+				t.Errorf("failed to set trailer: %s", err)
+			}
+			return hs.Check(ctx, req.(*grpc_health_v1.HealthCheckRequest))
+		})
+	info := &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch", IsServerStream: true}
+	stream = NewConnectStreamHandler(info, func(stream grpc.ServerStream) error {
+		m := &grpc_health_v1.HealthCheckRequest{}
+		if err := stream.RecvMsg(m); err != nil {
+			return err
+		}
+		return hs.Watch(m, &healthWatchServer{stream})
+	})
+	return
+}
+
+func TestConnectUnary(t *testing.T) {
+	unary, _ := newHealthConnectHandlers(t)
+	cases := []struct {
+		Name        string
+		Body        string
+		Status      int
+		ContentType string
+		Expected    string
+		Metadata    bool
+	}{
+		{"ok", `{"service":"svc.OK"}`, http.StatusOK, "application/json", `{"status":"SERVING"}`, true},
+		{"not found", `{"service":"svc.Unknown"}`, http.StatusNotFound, "application/json", `{"code":"not_found","message":"unknownservice\"svc.Unknown\""}`, true},
+		{"invalid", `{"service":1}`, http.StatusBadRequest, "application/json", `"code":"invalid_argument"`, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/grpc.health.v1.Health/Check", strings.NewReader(c.Body))
+			r.Header.Set("Content-Type", "application/json")
+			r.Header.Set("X-Request-Id", "42")
+			w := httptest.NewRecorder()
+			unary.ServeHTTP(w, r)
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != c.ContentType {
+				t.Errorf("got content type %q, expected %q", ct, c.ContentType)
+			}
+			if got := w.Body.String(); !strings.Contains(strings.ReplaceAll(got, " ", ""), c.Expected) {
+				t.Errorf("got body %q, expected %q", got, c.Expected)
+			}
+			if !c.Metadata {
+				return
+			}
+			if id := w.Header().Get("X-Request-Id"); id != "42" {
+				t.Errorf("got header x-request-id %q, expected %q", id, "42")
+			}
+			if v := w.Header().Get("Trailer-X-Checked"); v != "true" {
+				t.Errorf("got trailer x-checked %q, expected %q", v, "true")
+			}
+		})
+	}
+}
+
+func TestConnectUnsupported(t *testing.T) {
+	unary, stream := newHealthConnectHandlers(t)
+	cases := []struct {
+		Name        string
+		Handler     http.Handler
+		Method      string
+		ContentType string
+		Status      int
+	}{
+		{"get", unary, "GET", "application/json", http.StatusMethodNotAllowed},
+		{"unknown content type", unary, "POST", "text/plain", http.StatusUnsupportedMediaType},
+		{"grpc-web text json", unary, "POST", "application/grpc-web-text+json", http.StatusUnsupportedMediaType},
+		{"unary stream", unary, "POST", "application/connect+json", http.StatusUnsupportedMediaType},
+		{"stream unary", stream, "POST", "application/json", http.StatusUnsupportedMediaType},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest(c.Method, "/", strings.NewReader("{}"))
+			r.Header.Set("Content-Type", c.ContentType)
+			w := httptest.NewRecorder()
+			c.Handler.ServeHTTP(w, r)
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+		})
+	}
+}
+
+func TestGRPCWebUnary(t *testing.T) {
+	unary, _ := newHealthConnectHandlers(t)
+	req, err := proto.Marshal(&grpc_health_v1.HealthCheckRequest{Service: "svc.OK"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest("POST", "/grpc.health.v1.Health/Check", bytes.NewReader(envelope(0, req)))
+	r.Header.Set("Content-Type", "application/grpc-web+proto")
+	w := httptest.NewRecorder()
+	unary.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d, expected %d", w.Code, http.StatusOK)
+	}
+	frames := readEnvelopes(t, w.Body.Bytes())
+	if len(frames) != 2 {
+		t.Fatalf("got %d frames, expected 2", len(frames))
+	}
+	var res grpc_health_v1.HealthCheckResponse
+	if err := proto.Unmarshal(frames[0].data, &res); err != nil {
+		t.Fatalf("failed to decode response: %s", err)
+	}
+	if res.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("got status %s, expected SERVING", res.Status)
+	}
+	if frames[1].flags != flagGRPCWebTrailer {
+		t.Errorf("got flags %x, expected %x", frames[1].flags, flagGRPCWebTrailer)
+	}
+	expected := "grpc-status: 0\r\nx-checked: true\r\n"
+	if got := string(frames[1].data); got != expected {
+		t.Errorf("got trailers %q, expected %q", got, expected)
+	}
+}
+
+func TestGRPCWebTextUnary(t *testing.T) {
+	unary, _ := newHealthConnectHandlers(t)
+	req, err := proto.Marshal(&grpc_health_v1.HealthCheckRequest{Service: "svc.OK"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Encode the request in two padded chunks.
+	env := envelope(0, req)
+	body := base64.StdEncoding.EncodeToString(env[:4]) + base64.StdEncoding.EncodeToString(env[4:])
+	r := httptest.NewRequest("POST", "/grpc.health.v1.Health/Check", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/grpc-web-text")
+	w := httptest.NewRecorder()
+	unary.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d, expected %d", w.Code, http.StatusOK)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/grpc-web-text" {
+		t.Errorf("got content type %q, expected %q", ct, "application/grpc-web-text")
+	}
+	resp, err := io.ReadAll(&base64Reader{r: bufio.NewReader(w.Body)})
+	if err != nil {
+		t.Fatalf("failed to decode response body %q: %s", w.Body.String(), err)
+	}
+	frames := readEnvelopes(t, resp)
+	if len(frames) != 2 {
+		t.Fatalf("got %d frames, expected 2", len(frames))
+	}
+	var res grpc_health_v1.HealthCheckResponse
+	if err := proto.Unmarshal(frames[0].data, &res); err != nil {
+		t.Fatalf("failed to decode response: %s", err)
+	}
+	if res.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("got status %s, expected SERVING", res.Status)
+	}
+	if frames[1].flags != flagGRPCWebTrailer {
+		t.Errorf("got flags %x, expected %x", frames[1].flags, flagGRPCWebTrailer)
+	}
+}
+
+func TestConnectInterceptors(t *testing.T) {
+	var calls []string
+	unaryInterceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			calls = append(calls, name+" "+info.FullMethod)
+			return handler(ctx, req)
+		}
+	}
+	unary := NewConnectUnaryHandler("/grpc.health.v1.Health/Check",
+		func() proto.Message { return &grpc_health_v1.HealthCheckRequest{} },
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			calls = append(calls, "method")
+			return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+		}, unaryInterceptor("first"), unaryInterceptor("second"))
+	r := httptest.NewRequest("POST", "/grpc.health.v1.Health/Check", strings.NewReader("{}"))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	unary.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d, expected %d", w.Code, http.StatusOK)
+	}
+	expected := "first /grpc.health.v1.Health/Check, second /grpc.health.v1.Health/Check, method"
+	if got := strings.Join(calls, ", "); got != expected {
+		t.Errorf("got unary calls %q, expected %q", got, expected)
+	}
+
+	calls = nil
+	denied := status.Error(codes.PermissionDenied, "denied")
+	streamInterceptor := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		calls = append(calls, info.FullMethod)
+		if !info.IsServerStream || info.IsClientStream {
+			t.Errorf("got stream info %+v, expected server stream", info)
+		}
+		return denied
+	}
+	info := &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch", IsServerStream: true}
+	stream := NewConnectStreamHandler(info, func(grpc.ServerStream) error {
+		calls = append(calls, "method")
+		return nil
+	}, streamInterceptor)
+	r = httptest.NewRequest("POST", "/grpc.health.v1.Health/Watch", bytes.NewReader(envelope(0, []byte("{}"))))
+	r.Header.Set("Content-Type", "application/connect+json")
+	w = httptest.NewRecorder()
+	stream.ServeHTTP(w, r)
+	if got := strings.Join(calls, ", "); got != "/grpc.health.v1.Health/Watch" {
+		t.Errorf("got stream calls %q, expected %q", got, "/grpc.health.v1.Health/Watch")
+	}
+	frames := readEnvelopes(t, w.Body.Bytes())
+	var end connectEndStream
+	if err := json.Unmarshal(frames[len(frames)-1].data, &end); err != nil {
+		t.Fatalf("failed to decode end of stream: %s", err)
+	}
+	if end.Error == nil || end.Error.Code != "permission_denied" {
+		t.Errorf("got end of stream error %v, expected permission_denied", end.Error)
+	}
+}
+
+func TestConnectStream(t *testing.T) {
+	_, stream := newHealthConnectHandlers(t)
+	r := httptest.NewRequest("POST", "/grpc.health.v1.Health/Watch", bytes.NewReader(envelope(0, []byte(`{"service":"svc.OK"}`))))
+	r.Header.Set("Content-Type", "application/connect+json")
+	r.Header.Set("Connect-Timeout-Ms", "50")
+	w := httptest.NewRecorder()
+	stream.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d, expected %d", w.Code, http.StatusOK)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/connect+json" {
+		t.Errorf("got content type %q, expected %q", ct, "application/connect+json")
+	}
+	frames := readEnvelopes(t, w.Body.Bytes())
+	if len(frames) != 2 {
+		t.Fatalf("got %d frames, expected 2", len(frames))
+	}
+	if got := strings.ReplaceAll(string(frames[0].data), " ", ""); got != `{"status":"SERVING"}` {
+		t.Errorf("got message %q, expected %q", got, `{"status":"SERVING"}`)
+	}
+	if frames[1].flags != flagConnectEndStream {
+		t.Errorf("got flags %x, expected %x", frames[1].flags, flagConnectEndStream)
+	}
+	var end connectEndStream
+	if err := json.Unmarshal(frames[1].data, &end); err != nil {
+		t.Fatalf("failed to decode end of stream: %s", err)
+	}
+	if end.Error == nil || end.Error.Code != "deadline_exceeded" {
+		t.Errorf("got end of stream error %v, expected deadline_exceeded", end.Error)
+	}
+}
+
+type frame struct {
+	flags byte
+	data  []byte
+}
+
+func envelope(flags byte, b []byte) []byte {
+	prefix := make([]byte, 5, 5+len(b))
+	prefix[0] = flags
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(b)))
+	return append(prefix, b...)
+}
+
+func readEnvelopes(t *testing.T, b []byte) []*frame {
+	t.Helper()
+	var frames []*frame
+	for len(b) > 0 {
+		if len(b) < 5 {
+			t.Fatalf("invalid envelope %q", b)
+		}
+		n := int(binary.BigEndian.Uint32(b[1:5]))
+		if len(b) < 5+n {
+			t.Fatalf("invalid envelope %q", b)
+		}
+		frames = append(frames, &frame{flags: b[0], data: b[5 : 5+n]})
+		b = b[5+n:]
+	}
+	return frames
+}
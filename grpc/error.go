@@ -5,10 +5,12 @@ import (
 
 	goapb "goa.design/goa/v3/grpc/pb"
 	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type (
@@ -76,7 +78,10 @@ func NewStatusError(code codes.Code, err error, details ...protoiface.MessageV1)
 // EncodeError returns a gRPC status error from the given error with the error
 // response encoded in the status details. If error is a goa ServiceError type
 // it implements a heuristic to compute the status code from the Timeout,
-// Fault, and Temporary characteristics of the ServiceError. If error is not a
+// Fault, and Temporary characteristics of the ServiceError. Errors returned by
+// the goa.RateLimiter middleware use the ResourceExhausted code and include a
+// RetryInfo detail when the retry delay is known. Errors returned by
+// goa.CheckPreconditions use the FailedPrecondition code. If error is not a
 // ServiceError or a gRPC status error it returns a gRPC status error with
 // Unknown code and Fault characteristic set.
 func EncodeError(err error) error {
@@ -101,6 +106,16 @@ func EncodeError(err error) error {
 			if gerr.Temporary {
 				code = codes.Unavailable
 			}
+			if gerr.Name == goa.RateLimited {
+				code = codes.ResourceExhausted
+			}
+			if gerr.Name == goa.PreconditionFailed {
+				code = codes.FailedPrecondition
+			}
+		}
+		if retryAfter, ok := goa.RetryAfter(err); ok && retryAfter > 0 {
+			info := &errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}
+			return NewStatusError(code, err, NewErrorResponse(err), info)
 		}
 		return NewStatusError(code, err, NewErrorResponse(err))
 	}
@@ -124,6 +139,41 @@ func DecodeError(err error) proto.Message {
 	return details[0].(proto.Message)
 }
 
+// RetryableError returns a function that classifies the errors returned by the
+// gRPC client invokers for use with the goa.Retry endpoint middleware. The
+// function returns true for gRPC status errors with the Unavailable code or
+// one of the given codes and for errors whose ErrorResponse detail is flagged
+// as temporary or timeout or has one of the given names.
+func RetryableError(names []string, retryCodes ...codes.Code) func(error) bool {
+	return func(err error) bool {
+		st, ok := status.FromError(err)
+		if !ok {
+			return false
+		}
+		if st.Code() == codes.Unavailable {
+			return true
+		}
+		for _, code := range retryCodes {
+			if code == st.Code() {
+				return true
+			}
+		}
+		resp, ok := DecodeError(err).(*goapb.ErrorResponse)
+		if !ok {
+			return false
+		}
+		if resp.Temporary || resp.Timeout {
+			return true
+		}
+		for _, name := range names {
+			if name == resp.Name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 // ErrInvalidType is the error returned when the wrong type is given to a
 // encoder or decoder.
 func ErrInvalidType(svc, m, expected string, actual any) error {
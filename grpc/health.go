@@ -0,0 +1,121 @@
+package grpc
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// HealthServer implements the standard gRPC health checking protocol
+// (grpc.health.v1.Health) by running the checks registered for the requested
+// service. The generated servers of the services that define health checks
+// provide a RegisterHealth function that registers the checks of the service.
+// Register the server with grpc_health_v1.RegisterHealthServer.
+type HealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	// WatchInterval is the interval at which the checks are run to update
+	// the status sent to the clients of Watch, 10 seconds by default.
+	WatchInterval time.Duration
+
+	mu     sync.RWMutex
+	checks map[string][]*goa.HealthCheck
+}
+
+// NewHealthServer returns a health server with no registered service.
+func NewHealthServer() *HealthServer {
+	return &HealthServer{
+		WatchInterval: 10 * time.Second,
+		checks:        make(map[string][]*goa.HealthCheck),
+	}
+}
+
+// Register registers the checks of the service with the given fully
+// qualified gRPC name (e.g. "calc.Calc"). Requests made for the empty service
+// name run the checks of all the registered services.
+func (s *HealthServer) Register(service string, checks ...*goa.HealthCheck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checks[service] = append(s.checks[service], checks...)
+}
+
+// Check runs the checks of the requested service with a timeout of
+// goa.DefaultHealthTimeout. It returns an error with code NotFound if the
+// service is not registered.
+func (s *HealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	checks, ok := s.serviceChecks(req.GetService())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	ctx, cancel := context.WithTimeout(ctx, goa.DefaultHealthTimeout)
+	defer cancel()
+	return &grpc_health_v1.HealthCheckResponse{Status: healthStatus(goa.CheckHealth(ctx, checks...))}, nil
+}
+
+// Watch sends the status of the requested service and sends the new status
+// each time it changes until the client cancels the request. The status of
+// services that are not registered is SERVICE_UNKNOWN.
+func (s *HealthServer) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	ctx := stream.Context()
+	interval := s.WatchInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := grpc_health_v1.HealthCheckResponse_ServingStatus(-1)
+	for {
+		st := grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN
+		if checks, ok := s.serviceChecks(req.GetService()); ok {
+			cctx, cancel := context.WithTimeout(ctx, interval)
+			st = healthStatus(goa.CheckHealth(cctx, checks...))
+			cancel()
+		}
+		if st != last {
+			if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: st}); err != nil {
+				return err
+			}
+			last = st
+		}
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// serviceChecks returns the checks of the given service, all the checks if
+// service is empty. The second return value is false if the service is not
+// registered.
+func (s *HealthServer) serviceChecks(service string) ([]*goa.HealthCheck, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if service != "" {
+		checks, ok := s.checks[service]
+		return checks, ok
+	}
+	names := make([]string, 0, len(s.checks))
+	for name := range s.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var checks []*goa.HealthCheck
+	for _, name := range names {
+		checks = append(checks, s.checks[name]...)
+	}
+	return checks, true
+}
+
+// healthStatus returns the gRPC serving status corresponding to report.
+func healthStatus(report *goa.HealthReport) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if report.OK() {
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+}
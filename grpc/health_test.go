@@ -0,0 +1,121 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestHealthServerCheck(t *testing.T) {
+	var (
+		ok   = &goa.HealthCheck{Name: "db", Check: func(context.Context) error { return nil }}
+		fail = &goa.HealthCheck{Name: "cache", Check: func(context.Context) error { return errors.New("unavailable") }}
+		hs   = NewHealthServer()
+	)
+	hs.Register("svc.OK", ok)
+	hs.Register("svc.Fail", ok, fail)
+	hs.Register("svc.NoCheck")
+	cases := []struct {
+		Name     string
+		Service  string
+		Expected grpc_health_v1.HealthCheckResponse_ServingStatus
+		Code     codes.Code
+	}{
+		{"ok", "svc.OK", grpc_health_v1.HealthCheckResponse_SERVING, codes.OK},
+		{"fail", "svc.Fail", grpc_health_v1.HealthCheckResponse_NOT_SERVING, codes.OK},
+		{"no check", "svc.NoCheck", grpc_health_v1.HealthCheckResponse_SERVING, codes.OK},
+		{"all", "", grpc_health_v1.HealthCheckResponse_NOT_SERVING, codes.OK},
+		{"unknown", "svc.Unknown", grpc_health_v1.HealthCheckResponse_UNKNOWN, codes.NotFound},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			res, err := hs.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: c.Service})
+			if code := status.Code(err); code != c.Code {
+				t.Fatalf("got code %s, expected %s", code, c.Code)
+			}
+			if res.GetStatus() != c.Expected {
+				t.Errorf("got status %s, expected %s", res.GetStatus(), c.Expected)
+			}
+		})
+	}
+}
+
+func TestHealthServerCheckTimeout(t *testing.T) {
+	defer func(d time.Duration) { goa.DefaultHealthTimeout = d }(goa.DefaultHealthTimeout)
+	goa.DefaultHealthTimeout = 10 * time.Millisecond
+	var (
+		slow = &goa.HealthCheck{Name: "db", Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}}
+		hs = NewHealthServer()
+	)
+	hs.Register("svc.Slow", slow)
+	res, err := hs.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "svc.Slow"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.GetStatus() != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("got status %s, expected %s", res.GetStatus(), grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+func TestHealthServerWatch(t *testing.T) {
+	var (
+		healthy = true
+		check   = &goa.HealthCheck{Name: "db", Check: func(context.Context) error {
+			if !healthy {
+				return errors.New("unavailable")
+			}
+			return nil
+		}}
+		hs          = NewHealthServer()
+		ctx, cancel = context.WithCancel(context.Background())
+		stream      = &watchStream{ctx: ctx}
+	)
+	hs.WatchInterval = time.Millisecond
+	hs.Register("svc", check)
+	stream.send = func(res *grpc_health_v1.HealthCheckResponse) error {
+		stream.statuses = append(stream.statuses, res.Status)
+		if len(stream.statuses) == 2 {
+			cancel()
+		}
+		healthy = false
+		return nil
+	}
+	err := hs.Watch(&grpc_health_v1.HealthCheckRequest{Service: "svc"}, stream)
+	if code := status.Code(err); code != codes.Canceled {
+		t.Errorf("got code %s, expected %s", code, codes.Canceled)
+	}
+	expected := []grpc_health_v1.HealthCheckResponse_ServingStatus{
+		grpc_health_v1.HealthCheckResponse_SERVING,
+		grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+	}
+	if len(stream.statuses) != len(expected) {
+		t.Fatalf("got %d statuses, expected %d", len(stream.statuses), len(expected))
+	}
+	for i, s := range stream.statuses {
+		if s != expected[i] {
+			t.Errorf("got status %s at index %d, expected %s", s, i, expected[i])
+		}
+	}
+}
+
+// watchStream implements grpc_health_v1.Health_WatchServer for tests.
+type watchStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	send     func(*grpc_health_v1.HealthCheckResponse) error
+	statuses []grpc_health_v1.HealthCheckResponse_ServingStatus
+}
+
+func (s *watchStream) Context() context.Context { return s.ctx }
+
+func (s *watchStream) Send(res *grpc_health_v1.HealthCheckResponse) error { return s.send(res) }
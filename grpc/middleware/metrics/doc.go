@@ -0,0 +1,13 @@
+/*
+Package metrics contains unary and streaming server and client interceptors
+that record Prometheus compatible metrics from the gRPC requests and
+responses.
+
+The interceptors record the number of requests, their duration, the message
+sizes and the number of requests in flight. The metrics are labeled with the
+Goa service and method and the gRPC status code. The server interceptors read
+the service and method from the Endpoint middleware, which should be mounted
+on the service endpoints, and fall back to the gRPC full method name
+otherwise. Use the HTTP metrics package Handler to serve the recorded metrics.
+*/
+package metrics
@@ -0,0 +1,241 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	grpcm "goa.design/goa/v3/grpc/middleware"
+	"goa.design/goa/v3/middleware/metrics"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// metricsStreamClientWrapper records the client metrics once the stream is
+// done.
+type metricsStreamClientWrapper struct {
+	grpc.ClientStream
+	end      func(error)
+	mu       sync.Mutex
+	finished bool
+}
+
+// UnaryServer returns a server interceptor that records the number of
+// requests, their duration, the message sizes and the number of requests in
+// flight. The metrics are labeled with the Goa service and method set by the
+// metrics.Endpoint endpoint middleware or, if the endpoints do not use it, the
+// service and method of the gRPC full method name. The number of requests in
+// flight is recorded only when the endpoints use the Endpoint middleware.
+//
+// Example:
+//
+//	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(metrics.UnaryServer()))
+//	endpoints.Use(metrics.Endpoint())
+func UnaryServer(opts ...metrics.Option) grpc.UnaryServerInterceptor {
+	ins := metrics.NewOptions(opts...).Instruments("grpc", "server", "service", "method")
+	return grpc.UnaryServerInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, labels := serverLabels(ctx, ins)
+		resp, err := handler(ctx, req)
+		svc, meth := endServer(ins, labels, info.FullMethod)
+		record(ins, start, svc, meth, err, messageSize(req), messageSize(resp))
+		return resp, err
+	})
+}
+
+// StreamServer is similar to UnaryServer except it is used for streaming
+// endpoints. It does not record message sizes.
+func StreamServer(opts ...metrics.Option) grpc.StreamServerInterceptor {
+	ins := metrics.NewOptions(opts...).Instruments("grpc", "server", "service", "method")
+	return grpc.StreamServerInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, labels := serverLabels(ss.Context(), ins)
+		err := handler(srv, grpcm.NewWrappedServerStream(ctx, ss))
+		svc, meth := endServer(ins, labels, info.FullMethod)
+		record(ins, start, svc, meth, err, -1, -1)
+		return err
+	})
+}
+
+// UnaryClient returns a client interceptor that records the number of
+// requests, their duration, the message sizes and the number of requests in
+// flight. The metrics are labeled with the Goa service and method stored in
+// the request context by the generated clients or, if not set, the service
+// and method of the gRPC full method name.
+//
+// Example:
+//
+//	conn, err := grpc.Dial(addr, metrics.DialOptions()...)
+func UnaryClient(opts ...metrics.Option) grpc.UnaryClientInterceptor {
+	ins := metrics.NewOptions(opts...).Instruments("grpc", "client", "service", "method")
+	return grpc.UnaryClientInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		svc, meth := clientLabels(ctx, method)
+		ins.InFlight.Inc(svc, meth)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		ins.InFlight.Dec(svc, meth)
+		record(ins, start, svc, meth, err, messageSize(req), messageSize(reply))
+		return err
+	})
+}
+
+// StreamClient is the streaming endpoint middleware equivalent for
+// UnaryClient. The metrics are recorded when the stream is closed by the
+// server or fails.
+func StreamClient(opts ...metrics.Option) grpc.StreamClientInterceptor {
+	ins := metrics.NewOptions(opts...).Instruments("grpc", "client", "service", "method")
+	return grpc.StreamClientInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		svc, meth := clientLabels(ctx, method)
+		ins.InFlight.Inc(svc, meth)
+		end := func(err error) {
+			ins.InFlight.Dec(svc, meth)
+			record(ins, start, svc, meth, err, -1, -1)
+		}
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			end(err)
+			return cs, err
+		}
+		return &metricsStreamClientWrapper{ClientStream: cs, end: end}, nil
+	})
+}
+
+// DialOptions returns the gRPC dial options that configure a client
+// connection with the metrics unary and stream client interceptors.
+func DialOptions(opts ...metrics.Option) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(UnaryClient(opts...)),
+		grpc.WithChainStreamInterceptor(StreamClient(opts...)),
+	}
+}
+
+// Endpoint is a wrapper for the top-level Endpoint.
+func Endpoint() func(goa.Endpoint) goa.Endpoint {
+	return metrics.Endpoint()
+}
+
+// WithRegistry is a wrapper for the top-level WithRegistry.
+func WithRegistry(r *metrics.Registry) metrics.Option {
+	return metrics.WithRegistry(r)
+}
+
+// WithNamespace is a wrapper for the top-level WithNamespace.
+func WithNamespace(ns string) metrics.Option {
+	return metrics.WithNamespace(ns)
+}
+
+// WithDurationBuckets is a wrapper for the top-level WithDurationBuckets.
+func WithDurationBuckets(buckets ...float64) metrics.Option {
+	return metrics.WithDurationBuckets(buckets...)
+}
+
+// WithSizeBuckets is a wrapper for the top-level WithSizeBuckets.
+func WithSizeBuckets(buckets ...float64) metrics.Option {
+	return metrics.WithSizeBuckets(buckets...)
+}
+
+func (c *metricsStreamClientWrapper) SendMsg(m any) error {
+	if err := c.ClientStream.SendMsg(m); err != nil {
+		c.finish(err)
+		return err
+	}
+	return nil
+}
+
+func (c *metricsStreamClientWrapper) RecvMsg(m any) error {
+	if err := c.ClientStream.RecvMsg(m); err != nil {
+		c.finish(err)
+		return err
+	}
+	return nil
+}
+
+func (c *metricsStreamClientWrapper) Header() (metadata.MD, error) {
+	h, err := c.ClientStream.Header()
+	if err != nil {
+		c.finish(err)
+	}
+	return h, err
+}
+
+// finish records the metrics the first time it is called.
+func (c *metricsStreamClientWrapper) finish(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.finished {
+		return
+	}
+	c.finished = true
+	// io.EOF is normal grpc stream close, not error.
+	if err == io.EOF {
+		err = nil
+	}
+	c.end(err)
+}
+
+// serverLabels stores labels in the context that increment the number of
+// requests in flight when set by the Endpoint middleware.
+func serverLabels(ctx context.Context, ins *metrics.Instruments) (context.Context, *metrics.Labels) {
+	return metrics.ContextWithLabels(ctx, func(svc, meth string) {
+		ins.InFlight.Inc(svc, meth)
+	})
+}
+
+// endServer decrements the number of requests in flight if it was
+// incremented and returns the service and method labels.
+func endServer(ins *metrics.Instruments, labels *metrics.Labels, fullMethod string) (string, string) {
+	svc, meth := labels.Get()
+	if svc != "" || meth != "" {
+		ins.InFlight.Dec(svc, meth)
+		return svc, meth
+	}
+	return splitMethod(fullMethod)
+}
+
+// clientLabels returns the service and method labels of a client request.
+func clientLabels(ctx context.Context, fullMethod string) (string, string) {
+	if svc, meth := metrics.ServiceMethod(ctx); svc != "" || meth != "" {
+		return svc, meth
+	}
+	return splitMethod(fullMethod)
+}
+
+// splitMethod returns the service and method of the given gRPC full method
+// name of the form "/package.Service/Method".
+func splitMethod(fullMethod string) (string, string) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+// record records the request count and duration and, if not negative, the
+// message sizes.
+func record(ins *metrics.Instruments, start time.Time, svc, meth string, err error, reqSize, respSize int) {
+	code := status.Code(err).String()
+	ins.Requests.Inc(svc, meth, code)
+	ins.Duration.Observe(time.Since(start).Seconds(), svc, meth, code)
+	if reqSize >= 0 {
+		ins.RequestSize.Observe(float64(reqSize), svc, meth)
+	}
+	if respSize >= 0 {
+		ins.ResponseSize.Observe(float64(respSize), svc, meth)
+	}
+}
+
+// messageSize returns the size of the protobuf encoded message or -1 if msg
+// is not a protobuf message.
+func messageSize(msg any) int {
+	if m, ok := msg.(proto.Message); ok {
+		return proto.Size(m)
+	}
+	return -1
+}
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"goa.design/goa/v3/middleware/metrics"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	testServerStream struct {
+		grpc.ServerStream
+		ctx context.Context
+	}
+
+	testClientStream struct {
+		grpc.ClientStream
+	}
+)
+
+const fullMethod = "/goa.Test/Method"
+
+func TestUnaryServer(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Endpoint bool
+		Error    error
+		Expected []string
+	}{
+		{"no-endpoint", false, nil, []string{
+			`grpc_server_requests_total{service="goa.Test",method="Method",code="OK"} 1`,
+			`grpc_server_request_size_bytes_sum{service="goa.Test",method="Method"} 7`,
+			`grpc_server_response_size_bytes_sum{service="goa.Test",method="Method"} 8`,
+		}},
+		{"endpoint", true, nil, []string{
+			`grpc_server_requests_total{service="Test",method="Method",code="OK"} 1`,
+			`grpc_server_requests_in_flight{service="Test",method="Method"} 0`,
+		}},
+		{"error", true, status.Error(codes.InvalidArgument, "invalid"), []string{
+			`grpc_server_requests_total{service="Test",method="Method",code="InvalidArgument"} 1`,
+			`grpc_server_request_duration_seconds_count{service="Test",method="Method",code="InvalidArgument"} 1`,
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := metrics.NewRegistry()
+			handler := func(ctx context.Context, req any) (any, error) {
+				if c.Endpoint {
+					ctx = context.WithValue(ctx, goa.ServiceKey, "Test")
+					ctx = context.WithValue(ctx, goa.MethodKey, "Method")
+					Endpoint()(func(context.Context, any) (any, error) { return nil, nil })(ctx, nil) // nolint: errcheck
+				}
+				if c.Error != nil {
+					return nil, c.Error
+				}
+				return wrapperspb.String("result"), nil
+			}
+
+			_, err := UnaryServer(WithRegistry(r))(context.Background(), wrapperspb.String("hello"), &grpc.UnaryServerInfo{FullMethod: fullMethod}, handler)
+
+			if err != c.Error {
+				t.Errorf("got error %v, expected %v", err, c.Error)
+			}
+			assertMetrics(t, r, c.Expected)
+		})
+	}
+}
+
+func TestStreamServer(t *testing.T) {
+	r := metrics.NewRegistry()
+	handler := func(srv any, stream grpc.ServerStream) error {
+		ctx := context.WithValue(stream.Context(), goa.ServiceKey, "Test")
+		ctx = context.WithValue(ctx, goa.MethodKey, "Method")
+		_, err := Endpoint()(func(context.Context, any) (any, error) { return nil, nil })(ctx, nil)
+		return err
+	}
+
+	err := StreamServer(WithRegistry(r))(nil, &testServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: fullMethod}, handler)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertMetrics(t, r, []string{
+		`grpc_server_requests_total{service="Test",method="Method",code="OK"} 1`,
+		`grpc_server_requests_in_flight{service="Test",method="Method"} 0`,
+	})
+}
+
+func TestUnaryClient(t *testing.T) {
+	r := metrics.NewRegistry()
+	ctx := context.WithValue(context.Background(), goa.ServiceKey, "Test")
+	ctx = context.WithValue(ctx, goa.MethodKey, "Method")
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+
+	err := UnaryClient(WithRegistry(r))(ctx, fullMethod, wrapperspb.String("hello"), wrapperspb.String(""), nil, invoker)
+
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("got error %v, expected code %v", err, codes.Unavailable)
+	}
+	assertMetrics(t, r, []string{
+		`grpc_client_requests_total{service="Test",method="Method",code="Unavailable"} 1`,
+		`grpc_client_request_size_bytes_sum{service="Test",method="Method"} 7`,
+		`grpc_client_requests_in_flight{service="Test",method="Method"} 0`,
+	})
+}
+
+func TestStreamClient(t *testing.T) {
+	r := metrics.NewRegistry()
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &testClientStream{}, nil
+	}
+
+	cs, err := StreamClient(WithRegistry(r))(context.Background(), &grpc.StreamDesc{}, nil, fullMethod, streamer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertMetrics(t, r, []string{`grpc_client_requests_in_flight{service="goa.Test",method="Method"} 1`})
+	if err := cs.RecvMsg(nil); err != io.EOF {
+		t.Fatalf("got error %v, expected io.EOF", err)
+	}
+	cs.RecvMsg(nil) // nolint: errcheck
+
+	assertMetrics(t, r, []string{
+		`grpc_client_requests_total{service="goa.Test",method="Method",code="OK"} 1`,
+		`grpc_client_requests_in_flight{service="goa.Test",method="Method"} 0`,
+	})
+}
+
+func assertMetrics(t *testing.T, r *metrics.Registry, expected []string) {
+	t.Helper()
+	var sb strings.Builder
+	r.WriteText(&sb) // nolint: errcheck
+	for _, e := range expected {
+		if !strings.Contains(sb.String(), e) {
+			t.Errorf("metric %q not found in:\n%s", e, sb.String())
+		}
+	}
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testClientStream) RecvMsg(m any) error {
+	return io.EOF
+}
@@ -0,0 +1,15 @@
+/*
+Package otel contains unary and streaming server and client interceptors that
+create OpenTelemetry spans and metrics from the gRPC requests and responses.
+
+The server interceptors extract the trace context from the request metadata
+(W3C "traceparent" by default), create a server span and store it in the RPC
+context. They also record the request duration and message sizes. Mount the
+Endpoint middleware on the service endpoints to name the span after the Goa
+service and method.
+
+The client interceptors create a client span for each request and inject the
+trace context in the outgoing request metadata. Use DialOptions to configure a
+client connection with both interceptors.
+*/
+package otel
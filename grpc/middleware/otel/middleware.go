@@ -0,0 +1,320 @@
+package otel
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	grpcm "goa.design/goa/v3/grpc/middleware"
+	"goa.design/goa/v3/middleware/otel"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// rpcMetrics holds the RPC metric instruments.
+	rpcMetrics struct {
+		duration     metric.Float64Histogram
+		requestSize  metric.Float64Histogram
+		responseSize metric.Float64Histogram
+	}
+
+	// metadataCarrier adapts gRPC metadata to the OpenTelemetry
+	// propagation.TextMapCarrier interface.
+	metadataCarrier metadata.MD
+
+	// otelStreamClientWrapper ends the client span once the stream is
+	// done.
+	otelStreamClientWrapper struct {
+		grpc.ClientStream
+		end      func(error)
+		mu       sync.Mutex
+		finished bool
+	}
+)
+
+// UnaryServer returns a server interceptor that creates an OpenTelemetry
+// server span for each request and records the request duration and message
+// sizes. The trace context is extracted from the request metadata using the
+// W3C "traceparent" key by default.
+//
+// The span is named after the gRPC full method name. Mount the otel.Endpoint
+// endpoint middleware to rename the span after the Goa service and method.
+//
+// Example:
+//
+//	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(otel.UnaryServer(otel.WithTracerProvider(tp))))
+func UnaryServer(opts ...otel.Option) grpc.UnaryServerInterceptor {
+	o := otel.NewOptions(opts...)
+	metrics := newRPCMetrics(o, "server")
+	return grpc.UnaryServerInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, span, attrs := startServerSpan(ctx, o, info.FullMethod)
+		defer span.End()
+		resp, err := handler(ctx, req)
+		attrs = endServerSpan(span, attrs, err)
+		metrics.record(ctx, start, attrs, messageSize(req), messageSize(resp))
+		return resp, err
+	})
+}
+
+// StreamServer is similar to UnaryServer except it is used for streaming
+// endpoints. It does not record message sizes.
+func StreamServer(opts ...otel.Option) grpc.StreamServerInterceptor {
+	o := otel.NewOptions(opts...)
+	metrics := newRPCMetrics(o, "server")
+	return grpc.StreamServerInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, span, attrs := startServerSpan(ss.Context(), o, info.FullMethod)
+		defer span.End()
+		err := handler(srv, grpcm.NewWrappedServerStream(ctx, ss))
+		attrs = endServerSpan(span, attrs, err)
+		metrics.record(ctx, start, attrs, -1, -1)
+		return err
+	})
+}
+
+// UnaryClient returns a client interceptor that creates an OpenTelemetry
+// client span for each request and injects the trace context in the outgoing
+// request metadata so that the downstream service may continue the trace.
+//
+// Example:
+//
+//	conn, err := grpc.Dial(addr, otel.DialOptions(otel.WithTracerProvider(tp))...)
+func UnaryClient(opts ...otel.Option) grpc.UnaryClientInterceptor {
+	o := otel.NewOptions(opts...)
+	metrics := newRPCMetrics(o, "client")
+	return grpc.UnaryClientInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		ctx, span, attrs := startClientSpan(ctx, o, method)
+		defer span.End()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		attrs = endClientSpan(span, attrs, err)
+		metrics.record(ctx, start, attrs, messageSize(req), messageSize(reply))
+		return err
+	})
+}
+
+// StreamClient is the streaming endpoint middleware equivalent for UnaryClient.
+// The span ends when the stream is closed by the server or fails.
+func StreamClient(opts ...otel.Option) grpc.StreamClientInterceptor {
+	o := otel.NewOptions(opts...)
+	metrics := newRPCMetrics(o, "client")
+	return grpc.StreamClientInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		ctx, span, attrs := startClientSpan(ctx, o, method)
+		end := func(err error) {
+			metrics.record(ctx, start, endClientSpan(span, attrs, err), -1, -1)
+			span.End()
+		}
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			end(err)
+			return cs, err
+		}
+		return &otelStreamClientWrapper{ClientStream: cs, end: end}, nil
+	})
+}
+
+// DialOptions returns the gRPC dial options that configure a client
+// connection with the OpenTelemetry unary and stream client interceptors.
+func DialOptions(opts ...otel.Option) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(UnaryClient(opts...)),
+		grpc.WithChainStreamInterceptor(StreamClient(opts...)),
+	}
+}
+
+// Endpoint is a wrapper for the top-level Endpoint.
+func Endpoint(opts ...otel.Option) func(goa.Endpoint) goa.Endpoint {
+	return otel.Endpoint(opts...)
+}
+
+// WithTracerProvider is a wrapper for the top-level WithTracerProvider.
+func WithTracerProvider(p trace.TracerProvider) otel.Option {
+	return otel.WithTracerProvider(p)
+}
+
+// WithMeterProvider is a wrapper for the top-level WithMeterProvider.
+func WithMeterProvider(p metric.MeterProvider) otel.Option {
+	return otel.WithMeterProvider(p)
+}
+
+// WithPropagator is a wrapper for the top-level WithPropagator.
+func WithPropagator(p propagation.TextMapPropagator) otel.Option {
+	return otel.WithPropagator(p)
+}
+
+func (c *otelStreamClientWrapper) SendMsg(m any) error {
+	if err := c.ClientStream.SendMsg(m); err != nil {
+		c.finish(err)
+		return err
+	}
+	return nil
+}
+
+func (c *otelStreamClientWrapper) RecvMsg(m any) error {
+	if err := c.ClientStream.RecvMsg(m); err != nil {
+		c.finish(err)
+		return err
+	}
+	return nil
+}
+
+func (c *otelStreamClientWrapper) Header() (metadata.MD, error) {
+	h, err := c.ClientStream.Header()
+	if err != nil {
+		c.finish(err)
+	}
+	return h, err
+}
+
+// finish ends the span the first time it is called.
+func (c *otelStreamClientWrapper) finish(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.finished {
+		return
+	}
+	c.finished = true
+	// io.EOF is normal grpc stream close, not error.
+	if err == io.EOF {
+		err = nil
+	}
+	c.end(err)
+}
+
+// Get returns the first value associated with the given key.
+func (c metadataCarrier) Get(key string) string {
+	return grpcm.MetadataValue(metadata.MD(c), key)
+}
+
+// Set sets the value associated with the given key.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys lists the keys stored in the carrier.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// startServerSpan extracts the trace context from the incoming metadata and
+// starts the server span.
+func startServerSpan(ctx context.Context, o *otel.Options, fullMethod string) (context.Context, trace.Span, []attribute.KeyValue) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	ctx = o.Propagator().Extract(ctx, metadataCarrier(md))
+	attrs := rpcAttributes(fullMethod)
+	ctx, span := o.Tracer().Start(ctx, strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...),
+	)
+	return ctx, span, attrs
+}
+
+// startClientSpan starts the client span and injects the trace context in the
+// outgoing metadata.
+func startClientSpan(ctx context.Context, o *otel.Options, fullMethod string) (context.Context, trace.Span, []attribute.KeyValue) {
+	attrs := rpcAttributes(fullMethod)
+	ctx, span := o.Tracer().Start(ctx, strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	o.Propagator().Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md), span, attrs
+}
+
+// endServerSpan records the gRPC status code of the response in the span and
+// returns the metric attributes. Only server errors set the span status to
+// error. The error itself is recorded by the otel.Endpoint middleware.
+func endServerSpan(span trace.Span, attrs []attribute.KeyValue, err error) []attribute.KeyValue {
+	code := status.Code(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+	switch code {
+	case grpccodes.Unknown, grpccodes.DeadlineExceeded, grpccodes.Unimplemented,
+		grpccodes.Internal, grpccodes.Unavailable, grpccodes.DataLoss:
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return append(attrs, semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+}
+
+// endClientSpan records the gRPC status code of the response in the span and
+// returns the metric attributes.
+func endClientSpan(span trace.Span, attrs []attribute.KeyValue, err error) []attribute.KeyValue {
+	code := status.Code(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return append(attrs, semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+}
+
+// rpcAttributes returns the span attributes for the given gRPC full method
+// name of the form "/package.Service/Method".
+func rpcAttributes(fullMethod string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+	name := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		attrs = append(attrs, semconv.RPCService(name[:i]), semconv.RPCMethod(name[i+1:]))
+	}
+	return attrs
+}
+
+// newRPCMetrics creates the RPC metric instruments for the given side
+// ("server" or "client").
+func newRPCMetrics(o *otel.Options, side string) *rpcMetrics {
+	return &rpcMetrics{
+		duration:     o.Histogram("rpc."+side+".duration", "ms", "Duration of gRPC "+side+" requests."),
+		requestSize:  o.Histogram("rpc."+side+".request.size", "By", "Size of gRPC "+side+" request messages."),
+		responseSize: o.Histogram("rpc."+side+".response.size", "By", "Size of gRPC "+side+" response messages."),
+	}
+}
+
+// record records the request duration and, if not negative, the message
+// sizes.
+func (m *rpcMetrics) record(ctx context.Context, start time.Time, attrs []attribute.KeyValue, reqSize, respSize int) {
+	set := metric.WithAttributes(attrs...)
+	m.duration.Record(ctx, float64(time.Since(start))/float64(time.Millisecond), set)
+	if reqSize >= 0 {
+		m.requestSize.Record(ctx, float64(reqSize), set)
+	}
+	if respSize >= 0 {
+		m.responseSize.Record(ctx, float64(respSize), set)
+	}
+}
+
+// messageSize returns the size of the protobuf encoded message or -1 if msg
+// is not a protobuf message.
+func messageSize(msg any) int {
+	if m, ok := msg.(proto.Message); ok {
+		return proto.Size(m)
+	}
+	return -1
+}
@@ -0,0 +1,246 @@
+package otel
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type (
+	testServerStream struct {
+		grpc.ServerStream
+		ctx context.Context
+	}
+
+	testClientStream struct {
+		grpc.ClientStream
+	}
+)
+
+const (
+	traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	spanID      = "00f067aa0ba902b7"
+	traceparent = "00-" + traceID + "-" + spanID + "-01"
+	fullMethod  = "/goa.Test/Method"
+)
+
+func TestUnaryServer(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Traceparent string
+		Error       error
+		SpanStatus  otelcodes.Code
+	}{
+		{"no-parent", "", nil, otelcodes.Unset},
+		{"parent", traceparent, nil, otelcodes.Unset},
+		{"client-error", "", status.Error(codes.InvalidArgument, "invalid"), otelcodes.Unset},
+		{"server-error", "", status.Error(codes.Internal, "internal"), otelcodes.Error},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			exporter, tp, reader, mp := newProviders()
+			ctx := context.Background()
+			if c.Traceparent != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("traceparent", c.Traceparent))
+			}
+			handler := func(ctx context.Context, req any) (any, error) {
+				if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+					t.Error("span not found in request context")
+				}
+				return wrapperspb.String("response"), c.Error
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: fullMethod}
+
+			_, err := UnaryServer(WithTracerProvider(tp), WithMeterProvider(mp))(ctx, wrapperspb.String("request"), info, handler)
+
+			if err != c.Error {
+				t.Errorf("got error %v, expected %v", err, c.Error)
+			}
+			span := assertSpan(t, exporter, trace.SpanKindServer, c.SpanStatus, status.Code(c.Error))
+			if c.Traceparent != "" {
+				if span.SpanContext.TraceID().String() != traceID {
+					t.Errorf("got trace ID %q, expected %q", span.SpanContext.TraceID(), traceID)
+				}
+				if span.Parent.SpanID().String() != spanID {
+					t.Errorf("got parent span ID %q, expected %q", span.Parent.SpanID(), spanID)
+				}
+			}
+			assertHistograms(t, reader, "rpc.server.duration", "rpc.server.request.size", "rpc.server.response.size")
+		})
+	}
+}
+
+func TestStreamServer(t *testing.T) {
+	exporter, tp, reader, mp := newProviders()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", traceparent))
+	handler := func(srv any, stream grpc.ServerStream) error {
+		if !trace.SpanFromContext(stream.Context()).SpanContext().IsValid() {
+			t.Error("span not found in stream context")
+		}
+		return nil
+	}
+	info := &grpc.StreamServerInfo{FullMethod: fullMethod}
+
+	if err := StreamServer(WithTracerProvider(tp), WithMeterProvider(mp))(nil, &testServerStream{ctx: ctx}, info, handler); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+
+	span := assertSpan(t, exporter, trace.SpanKindServer, otelcodes.Unset, codes.OK)
+	if span.Parent.SpanID().String() != spanID {
+		t.Errorf("got parent span ID %q, expected %q", span.Parent.SpanID(), spanID)
+	}
+	assertHistograms(t, reader, "rpc.server.duration")
+}
+
+func TestUnaryClient(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Error      error
+		SpanStatus otelcodes.Code
+	}{
+		{"ok", nil, otelcodes.Unset},
+		{"error", status.Error(codes.InvalidArgument, "invalid"), otelcodes.Error},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			exporter, tp, reader, mp := newProviders()
+			var md metadata.MD
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				md, _ = metadata.FromOutgoingContext(ctx)
+				return c.Error
+			}
+
+			err := UnaryClient(WithTracerProvider(tp), WithMeterProvider(mp))(context.Background(), fullMethod, wrapperspb.String("request"), wrapperspb.String(""), nil, invoker)
+
+			if err != c.Error {
+				t.Errorf("got error %v, expected %v", err, c.Error)
+			}
+			span := assertSpan(t, exporter, trace.SpanKindClient, c.SpanStatus, status.Code(c.Error))
+			assertTraceparent(t, md, span)
+			assertHistograms(t, reader, "rpc.client.duration", "rpc.client.request.size", "rpc.client.response.size")
+		})
+	}
+}
+
+func TestStreamClient(t *testing.T) {
+	exporter, tp, reader, mp := newProviders()
+	var md metadata.MD
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return &testClientStream{}, nil
+	}
+
+	cs, err := StreamClient(WithTracerProvider(tp), WithMeterProvider(mp))(context.Background(), &grpc.StreamDesc{}, nil, fullMethod, streamer)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if n := len(exporter.GetSpans()); n != 0 {
+		t.Errorf("got %d spans before the end of the stream, expected 0", n)
+	}
+	if err := cs.RecvMsg(nil); err != io.EOF {
+		t.Errorf("got error %v, expected EOF", err)
+	}
+	cs.RecvMsg(nil) // nolint: errcheck
+
+	span := assertSpan(t, exporter, trace.SpanKindClient, otelcodes.Unset, codes.OK)
+	assertTraceparent(t, md, span)
+	assertHistograms(t, reader, "rpc.client.duration")
+}
+
+func newProviders() (*tracetest.InMemoryExporter, *sdktrace.TracerProvider, *sdkmetric.ManualReader, *sdkmetric.MeterProvider) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	return exporter, tp, reader, mp
+}
+
+// assertSpan checks that exactly one span was exported with the expected
+// name, kind, status and attributes and returns it.
+func assertSpan(t *testing.T, exporter *tracetest.InMemoryExporter, kind trace.SpanKind, code otelcodes.Code, grpcCode codes.Code) tracetest.SpanStub {
+	t.Helper()
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, expected 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "goa.Test/Method" {
+		t.Errorf("got span name %q, expected %q", span.Name, "goa.Test/Method")
+	}
+	if span.SpanKind != kind {
+		t.Errorf("got span kind %v, expected %v", span.SpanKind, kind)
+	}
+	if span.Status.Code != code {
+		t.Errorf("got span status %v, expected %v", span.Status.Code, code)
+	}
+	attrs := attribute.NewSet(span.Attributes...)
+	expected := []attribute.KeyValue{
+		semconv.RPCSystemGRPC,
+		semconv.RPCService("goa.Test"),
+		semconv.RPCMethod("Method"),
+		semconv.RPCGRPCStatusCodeKey.Int(int(grpcCode)),
+	}
+	for _, kv := range expected {
+		if v, _ := attrs.Value(kv.Key); v != kv.Value {
+			t.Errorf("got attribute %s=%v, expected %v", kv.Key, v.Emit(), kv.Value.Emit())
+		}
+	}
+	return span
+}
+
+// assertTraceparent checks that the outgoing metadata contains the trace
+// context of span.
+func assertTraceparent(t *testing.T, md metadata.MD, span tracetest.SpanStub) {
+	t.Helper()
+	expected := "00-" + span.SpanContext.TraceID().String() + "-" + span.SpanContext.SpanID().String() + "-01"
+	if got := md.Get("traceparent"); len(got) != 1 || got[0] != expected {
+		t.Errorf("got traceparent metadata %v, expected %q", got, expected)
+	}
+}
+
+// assertHistograms checks that the reader collected one data point for each
+// of the given histograms.
+func assertHistograms(t *testing.T, reader sdkmetric.Reader, names ...string) {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %s", err)
+	}
+	found := make(map[string]uint64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if h, ok := m.Data.(metricdata.Histogram[float64]); ok {
+				for _, dp := range h.DataPoints {
+					found[m.Name] += dp.Count
+				}
+			}
+		}
+	}
+	for _, name := range names {
+		if found[name] != 1 {
+			t.Errorf("got %d %s data points, expected 1", found[name], name)
+		}
+	}
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testClientStream) RecvMsg(any) error {
+	return io.EOF
+}
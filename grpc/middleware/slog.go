@@ -0,0 +1,97 @@
+//go:build go1.21
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"goa.design/goa/v3/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnaryServerSlog returns a middleware that logs the unary gRPC requests using
+// a log/slog logger. The middleware logs a single entry per request once the
+// handler returns. The entry includes the request ID, trace and span IDs
+// found in the request context, the gRPC method, the response status code,
+// message length and duration. Mount the middleware.SlogEndpoint endpoint
+// middleware to add the Goa service and method.
+//
+// The entry is logged at the error level for server errors and at the
+// request level otherwise. A second entry containing the request metadata and
+// the JSON representation of the request and response messages is logged
+// when the body level is enabled. Use middleware.WithRedactedHeaders and
+// middleware.WithRedactedFields to redact sensitive values from this entry.
+func UnaryServerSlog(l *slog.Logger, opts ...middleware.SlogOption) grpc.UnaryServerInterceptor {
+	o := middleware.NewSlogOptions(opts...)
+	return grpc.UnaryServerInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		started := time.Now()
+		ctx, la := middleware.ContextWithLogAttrs(ctx)
+		resp, err := handler(ctx, req)
+		logRPC(ctx, l, o, la, info.FullMethod, err, started,
+			slog.Int64("bytes", messageLength(resp)))
+		if l.Enabled(ctx, o.BodyLevel()) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			l.LogAttrs(ctx, o.BodyLevel(), "request body",
+				append(o.ContextAttrs(ctx),
+					o.HeadersAttr("request_metadata", md),
+					o.BodyAttr("request_body", messageJSON(req)),
+					o.BodyAttr("response_body", messageJSON(resp)))...)
+		}
+		return resp, err
+	})
+}
+
+// StreamServerSlog returns a middleware that logs the streaming gRPC requests
+// using a log/slog logger similarly to UnaryServerSlog. The messages are not
+// logged.
+func StreamServerSlog(l *slog.Logger, opts ...middleware.SlogOption) grpc.StreamServerInterceptor {
+	o := middleware.NewSlogOptions(opts...)
+	return grpc.StreamServerInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		started := time.Now()
+		ctx, la := middleware.ContextWithLogAttrs(ss.Context())
+		err := handler(srv, NewWrappedServerStream(ctx, ss))
+		logRPC(ctx, l, o, la, info.FullMethod, err, started)
+		return err
+	})
+}
+
+// logRPC logs the request entry.
+func logRPC(ctx context.Context, l *slog.Logger, o *middleware.SlogOptions, la *middleware.LogAttrs, fullMethod string, err error, started time.Time, extra ...slog.Attr) {
+	code := status.Code(err)
+	level := o.Level()
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented,
+		codes.Internal, codes.Unavailable, codes.DataLoss:
+		level = o.ErrorLevel()
+	}
+	attrs := o.ContextAttrs(ctx)
+	attrs = append(attrs, la.Attrs()...)
+	attrs = append(attrs, slog.String("rpc", fullMethod), slog.String("status", code.String()))
+	attrs = append(attrs, extra...)
+	attrs = append(attrs, slog.Duration("duration", time.Since(started)))
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	l.LogAttrs(ctx, level, "request", attrs...)
+}
+
+// messageJSON returns the JSON representation of msg if it is a protobuf
+// message, nil otherwise.
+func messageJSON(msg any) []byte {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return nil
+	}
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return nil
+	}
+	return b
+}
@@ -0,0 +1,129 @@
+//go:build go1.21
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"goa.design/goa/v3/middleware"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func TestUnaryServerSlog(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Level   slog.Level
+		Error   error
+		Entries []map[string]any
+	}{
+		{"info", slog.LevelInfo, nil, []map[string]any{
+			{"level": "INFO", "msg": "request", "id": "req", "service": "Test", "method": "Method", "rpc": "/goa.Test/Method", "status": "OK"},
+		}},
+		{"client-error", slog.LevelInfo, status.Error(codes.InvalidArgument, "invalid"), []map[string]any{
+			{"level": "INFO", "status": "InvalidArgument", "error": "rpc error: code = InvalidArgument desc = invalid"},
+		}},
+		{"server-error", slog.LevelInfo, status.Error(codes.Internal, "internal"), []map[string]any{
+			{"level": "ERROR", "status": "Internal"},
+		}},
+		{"debug", slog.LevelDebug, nil, []map[string]any{
+			{"level": "INFO", "msg": "request"},
+			{"level": "DEBUG", "msg": "request body",
+				"request_body":     `{"password":"[REDACTED]","user":"goa"}`,
+				"response_body":    `{"token":"[REDACTED]"}`,
+				"request_metadata": map[string]any{"authorization": middleware.Redacted}},
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: c.Level}))
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token"))
+			ctx = context.WithValue(ctx, middleware.RequestIDKey, "req")
+			req, _ := structpb.NewStruct(map[string]any{"user": "goa", "password": "secret"})
+			handler := func(ctx context.Context, req any) (any, error) {
+				ctx = context.WithValue(ctx, goa.ServiceKey, "Test")
+				ctx = context.WithValue(ctx, goa.MethodKey, "Method")
+				middleware.SlogEndpoint()(func(context.Context, any) (any, error) { return nil, nil })(ctx, nil) // nolint: errcheck
+				if c.Error != nil {
+					return nil, c.Error
+				}
+				return structpb.NewStruct(map[string]any{"token": "abc"})
+			}
+			interceptor := UnaryServerSlog(logger, middleware.WithRedactedHeaders("Authorization"), middleware.WithRedactedFields("password", "token"))
+
+			_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: "/goa.Test/Method"}, handler)
+
+			if err != c.Error {
+				t.Errorf("got error %v, expected %v", err, c.Error)
+			}
+			assertEntries(t, buf.String(), c.Entries)
+		})
+	}
+}
+
+func TestStreamServerSlog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	handler := func(srv any, stream grpc.ServerStream) error {
+		middleware.AddLogAttrs(stream.Context(), slog.String("service", "Test"))
+		return nil
+	}
+
+	err := StreamServerSlog(logger)(nil, &testServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/goa.Test/Stream"}, handler)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertEntries(t, buf.String(), []map[string]any{
+		{"level": "INFO", "msg": "request", "service": "Test", "rpc": "/goa.Test/Stream", "status": "OK"},
+	})
+}
+
+// assertEntries checks that the logged entries contain the expected values.
+func assertEntries(t *testing.T, logged string, entries []map[string]any) {
+	t.Helper()
+	lines := strings.Split(strings.TrimSpace(logged), "\n")
+	if len(lines) != len(entries) {
+		t.Fatalf("got %d entries, expected %d:\n%s", len(lines), len(entries), logged)
+	}
+	for i, expected := range entries {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(lines[i]), &entry); err != nil {
+			t.Fatalf("invalid log entry %q: %v", lines[i], err)
+		}
+		for k, v := range expected {
+			if group, ok := v.(map[string]any); ok {
+				actual, _ := entry[k].(map[string]any)
+				for gk, gv := range group {
+					if actual[gk] != gv {
+						t.Errorf("got %s.%s=%v, expected %v", k, gk, actual[gk], gv)
+					}
+				}
+				continue
+			}
+			if entry[k] != v {
+				t.Errorf("got %s=%v, expected %v", k, entry[k], v)
+			}
+		}
+	}
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -1,3 +1,4 @@
 // Package goapb contains protocol buffer message types used by the code
-// generation logic.
+// generation logic and the options that describe the design in the generated
+// descriptors.
 package goapb
@@ -0,0 +1,11 @@
+package goapb
+
+import _ "embed"
+
+// OptionsProto is the content of the goadesign_goa_options.proto file that
+// defines the options describing the Goa design in the protocol buffer
+// descriptors. The code generation logic makes it available to protoc when
+// the generated .proto files import it.
+//
+//go:embed goadesign_goa_options.proto
+var OptionsProto []byte
@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"encoding/json"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The functions below convert between the service types and the protocol
+// buffer well-known types. They are used by the generated code to transform
+// attributes of type Any and string attributes with the "date-time" and
+// "duration" formats.
+
+// NewTimestamp returns the protocol buffer timestamp corresponding to the
+// given RFC3339 date time. It returns an error if s is not a valid RFC3339
+// date time or is outside the range supported by protocol buffer timestamps.
+func NewTimestamp(s string) (*timestamppb.Timestamp, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	ts := timestamppb.New(t)
+	if err := ts.CheckValid(); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// TimestampString returns the RFC3339 representation of ts in UTC. Protocol
+// buffer timestamps do not record the offset of the original date time so
+// that a date time sent with a non-zero offset is received as the same
+// instant in UTC. TimestampString returns an empty string if ts is nil.
+func TimestampString(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Format(time.RFC3339Nano)
+}
+
+// NewDuration returns the protocol buffer duration corresponding to the given
+// duration string as accepted by time.ParseDuration. It returns an error if s
+// is not a valid duration.
+func NewDuration(s string) (*durationpb.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return nil, err
+	}
+	return durationpb.New(d), nil
+}
+
+// DurationString returns the string representation of d as produced by
+// time.Duration. It returns an empty string if d is nil.
+func DurationString(d *durationpb.Duration) string {
+	if d == nil {
+		return ""
+	}
+	return d.AsDuration().String()
+}
+
+// NewValue returns the protocol buffer value corresponding to v. Values that
+// structpb.NewValue does not support (e.g. structs) are converted using their
+// JSON representation. NewValue returns a null value if v is nil so that the
+// value may be used in maps and lists (protojson rejects nil values) and an
+// error if v cannot be represented.
+func NewValue(v any) (*structpb.Value, error) {
+	if v == nil {
+		return structpb.NewNullValue(), nil
+	}
+	if val, err := structpb.NewValue(v); err == nil {
+		return val, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var j any
+	if err := json.Unmarshal(b, &j); err != nil {
+		return nil, err
+	}
+	return structpb.NewValue(j)
+}
+
+// ValueInterface returns the Go value corresponding to v. Numbers are returned
+// as float64, lists as []any and structs as map[string]any. It returns nil if
+// v is nil.
+func ValueInterface(v *structpb.Value) any {
+	if v == nil {
+		return nil
+	}
+	return v.AsInterface()
+}
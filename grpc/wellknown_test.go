@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestNewTimestamp(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    string
+		Expected string
+		Error    bool
+	}{
+		{"utc", "2024-01-02T03:04:05Z", "2024-01-02T03:04:05Z", false},
+		{"offset", "2024-01-02T03:04:05+02:00", "2024-01-02T01:04:05Z", false},
+		{"invalid", "not a date", "", true},
+		{"out of range", "0000-01-01T00:00:00Z", "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ts, err := NewTimestamp(c.Value)
+			if c.Error {
+				if err == nil {
+					t.Errorf("got no error, expected one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := TimestampString(ts); got != c.Expected {
+				t.Errorf("got %q, expected %q", got, c.Expected)
+			}
+		})
+	}
+}
+
+func TestNewDuration(t *testing.T) {
+	d, err := NewDuration("1m30s")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := DurationString(d); got != "1m30s" {
+		t.Errorf("got %q, expected %q", got, "1m30s")
+	}
+	if _, err := NewDuration("forever"); err == nil {
+		t.Errorf("got no error for invalid duration")
+	}
+}
+
+func TestNewValue(t *testing.T) {
+	type point struct{ X, Y int }
+	v, err := NewValue(point{1, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, ok := ValueInterface(v).(map[string]any)
+	if !ok || got["X"] != 1.0 || got["Y"] != 2.0 {
+		t.Errorf("got %v, expected map with X=1 and Y=2", ValueInterface(v))
+	}
+	if v, err := NewValue(nil); err != nil || v.GetKind() == nil || ValueInterface(v) != nil {
+		t.Errorf("got %v, %v for nil value, expected null value", v, err)
+	}
+	if _, err := NewValue(make(chan int)); err == nil {
+		t.Errorf("got no error for unsupported value")
+	}
+}
+
+func TestNewValueNilJSON(t *testing.T) {
+	labels := map[string]any{"set": "value", "unset": nil}
+	s := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(labels))}
+	for k, v := range labels {
+		val, err := NewValue(v)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		s.Fields[k] = val
+	}
+	list := &structpb.ListValue{}
+	for _, v := range []any{1, nil} {
+		val, err := NewValue(v)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		list.Values = append(list.Values, val)
+	}
+	for _, m := range []proto.Message{s, list} {
+		b, err := protojson.Marshal(m)
+		if err != nil {
+			t.Fatalf("failed to marshal %v: %v", m, err)
+		}
+		if !strings.Contains(string(b), "null") {
+			t.Errorf("got %s, expected a null value", b)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -9,6 +10,8 @@ import (
 	"os"
 	"sort"
 	"strings"
+
+	goa "goa.design/goa/v3/pkg"
 )
 
 type (
@@ -49,6 +52,9 @@ type (
 		Timeout bool
 		// Is the error a server-side fault?
 		Fault bool
+		// StatusCode is the HTTP status code of the response for
+		// invalid_response errors.
+		StatusCode int
 		// The original error if any
 		Err error
 	}
@@ -59,7 +65,9 @@ func (c ClientError) Unwrap() error {
 }
 
 // NewDebugDoer wraps the given doer and captures the request and response so
-// they can be printed.
+// they can be printed. The values of the headers, query string parameters and
+// JSON body fields that the requested endpoint defines as sensitive are
+// redacted from the printed details.
 func NewDebugDoer(d Doer) DebugDoer {
 	return &debugDoer{Doer: d}
 }
@@ -99,8 +107,9 @@ func (dd *debugDoer) Fprint(w io.Writer) {
 	if dd.Request == nil {
 		return
 	}
+	sensitive := goa.SensitiveFromContext(dd.Request.Context())
 	buf := &bytes.Buffer{}
-	buf.WriteString(fmt.Sprintf("> %s %s", dd.Request.Method, dd.Request.URL.String())) // nolint: errcheck
+	buf.WriteString(fmt.Sprintf("> %s %s", dd.Request.Method, sensitive.RedactQuery(dd.Request.URL).String())) // nolint: errcheck
 
 	keys := make([]string, len(dd.Request.Header))
 	i := 0
@@ -110,14 +119,14 @@ func (dd *debugDoer) Fprint(w io.Writer) {
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		buf.WriteString(fmt.Sprintf("\n> %s: %s", k, strings.Join(dd.Request.Header[k], ", "))) // nolint: errcheck
+		buf.WriteString(fmt.Sprintf("\n> %s: %s", k, debugHeaderValue(sensitive, k, dd.Request.Header[k]))) // nolint: errcheck
 	}
 
 	b, _ := io.ReadAll(dd.Request.Body)
 	if len(b) > 0 {
 		dd.Request.Body = io.NopCloser(bytes.NewBuffer(b)) // reset the request body
 		buf.WriteByte('\n')                                // nolint: errcheck
-		buf.Write(b)                                       // nolint: errcheck
+		buf.Write(sensitive.RedactJSON(b))                 // nolint: errcheck
 	}
 
 	if dd.Response == nil {
@@ -134,19 +143,28 @@ func (dd *debugDoer) Fprint(w io.Writer) {
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		buf.WriteString(fmt.Sprintf("\n< %s: %s", k, strings.Join(dd.Response.Header[k], ", "))) // nolint: errcheck
+		buf.WriteString(fmt.Sprintf("\n< %s: %s", k, debugHeaderValue(sensitive, k, dd.Response.Header[k]))) // nolint: errcheck
 	}
 
 	rb, _ := io.ReadAll(dd.Response.Body) // this is reading from a memory buffer so safe to ignore errors
 	if len(rb) > 0 {
 		dd.Response.Body = io.NopCloser(bytes.NewBuffer(rb)) // reset the response body
 		buf.WriteByte('\n')                                  // nolint: errcheck
-		buf.Write(rb)                                        // nolint: errcheck
+		buf.Write(sensitive.RedactJSON(rb))                  // nolint: errcheck
 	}
 	w.Write(buf.Bytes())  // nolint: errcheck
 	w.Write([]byte{'\n'}) // nolint: errcheck
 }
 
+// debugHeaderValue returns the value of the given header or goa.Redacted if
+// the header is sensitive.
+func debugHeaderValue(sensitive *goa.SensitiveNames, name string, vals []string) string {
+	if sensitive.IsSensitive(name) {
+		return goa.Redacted
+	}
+	return strings.Join(vals, ", ")
+}
+
 // Error builds an error message.
 func (c ClientError) Error() string {
 	return fmt.Sprintf("[%s %s]: %s", c.Service, c.Method, c.Message)
@@ -209,7 +227,7 @@ func ErrInvalidResponse(svc, m string, code int, body string) error {
 		code == http.StatusBadGateway
 
 	return &ClientError{Name: "invalid_response", Message: msg, Service: svc, Method: m,
-		Temporary: temporary, Timeout: timeout, Fault: fault}
+		Temporary: temporary, Timeout: timeout, Fault: fault, StatusCode: code}
 }
 
 // ErrRequestError is the error returned when the request fails to be sent.
@@ -222,3 +240,29 @@ func ErrRequestError(svc, m string, err error) error {
 	return &ClientError{Name: "request_error", Message: err.Error(), Service: svc, Method: m,
 		Temporary: temporary, Timeout: timeout, Err: err}
 }
+
+// RetryableError returns a function that classifies the errors returned by the
+// HTTP client endpoints for use with the goa.Retry endpoint middleware. The
+// function returns true for request errors (e.g. connection failures), for
+// client errors flagged as temporary or timeouts and for invalid responses
+// whose status code is one of the given status codes.
+func RetryableError(statusCodes ...int) func(error) bool {
+	return func(err error) bool {
+		var cerr *ClientError
+		if !errors.As(err, &cerr) {
+			return false
+		}
+		if cerr.Temporary || cerr.Timeout || cerr.Name == "request_error" {
+			return true
+		}
+		if cerr.Name != "invalid_response" {
+			return false
+		}
+		for _, code := range statusCodes {
+			if code == cerr.StatusCode {
+				return true
+			}
+		}
+		return false
+	}
+}
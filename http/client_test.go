@@ -53,3 +53,27 @@ func TestClientError_Unwrap(t *testing.T) {
 		)
 	}
 }
+
+func TestRetryableError(t *testing.T) {
+	retryable := RetryableError(502)
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"request error", ErrRequestError("svc", "method", errors.New("connection refused")), true},
+		{"temporary response", ErrInvalidResponse("svc", "method", 503, ""), true},
+		{"timeout response", ErrInvalidResponse("svc", "method", 408, ""), true},
+		{"listed status code", ErrInvalidResponse("svc", "method", 502, ""), true},
+		{"other status code", ErrInvalidResponse("svc", "method", 500, ""), false},
+		{"decoding error", ErrDecodingError("svc", "method", errors.New("invalid")), false},
+		{"other error", errors.New("error"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(tt.err); got != tt.expected {
+				t.Errorf("got %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// Codec describes how to encode and decode HTTP bodies for a given media
+	// type. Codecs are registered with RegisterCodec and used by the default
+	// RequestDecoder, ResponseEncoder, RequestEncoder and ResponseDecoder
+	// functions.
+	Codec struct {
+		// NewEncoder creates an encoder that writes to w. NewEncoder may
+		// be nil if the codec only supports decoding.
+		NewEncoder func(w io.Writer) Encoder
+		// NewDecoder creates a decoder that reads from r. NewDecoder may
+		// be nil if the codec only supports encoding.
+		NewDecoder func(r io.Reader) Decoder
+		// Suffix is the optional structured syntax suffix (RFC 6839)
+		// handled by the codec, e.g. "json" for media types of the form
+		// "application/vnd.example+json".
+		Suffix string
+	}
+
+	// codecRegistry stores the registered codecs.
+	codecRegistry struct {
+		sync.RWMutex
+		// codecs indexes the codecs by media type.
+		codecs map[string]*Codec
+		// suffixes indexes the media types by structured syntax suffix.
+		suffixes map[string]string
+		// order lists the media types in registration order, it is used
+		// to resolve wildcard media ranges deterministically.
+		order []string
+	}
+
+	// mediaRange is a media range parsed from an Accept header.
+	mediaRange struct {
+		mediaType string
+		q         float64
+	}
+)
+
+// codecs is the default codec registry.
+var codecs = newCodecRegistry()
+
+// RegisterCodec registers codec for the given media type, e.g.
+// "application/msgpack". Registering a codec for a media type that already
+// has one replaces it. The default registry contains codecs for
+// application/json, application/xml, application/gob, text/plain and
+// text/html.
+//
+// RegisterCodec is typically called from an init function so that a single
+// registration makes the format available to all generated servers and
+// clients:
+//
+//	func init() {
+//	    goahttp.RegisterCodec("application/msgpack", &goahttp.Codec{
+//	        NewEncoder: func(w io.Writer) goahttp.Encoder { return msgpack.NewEncoder(w) },
+//	        NewDecoder: func(r io.Reader) goahttp.Decoder { return msgpack.NewDecoder(r) },
+//	    })
+//	}
+func RegisterCodec(mediaType string, codec *Codec) {
+	codecs.register(mediaType, codec)
+}
+
+// LookupCodec returns the codec registered for the given media type if any.
+// Media type parameters are ignored. Media types with a structured syntax
+// suffix (e.g. "application/vnd.example+json") resolve to the codec
+// registered for the suffix if there is no codec registered for the media
+// type itself.
+func LookupCodec(mediaType string) (*Codec, bool) {
+	c, _ := codecs.lookup(mediaType)
+	return c, c != nil
+}
+
+// NegotiateCodec returns the registered codec and media type that best match
+// the given Accept header value. The media ranges are considered in order of
+// decreasing quality value ("q" parameter), ranges with a quality value of 0
+// are ignored. The wildcard ranges "*/*" and "type/*" match the first codec
+// registered for a matching media type. NegotiateCodec returns nil if no
+// registered codec can encode any of the accepted media types.
+func NegotiateCodec(accept string) (*Codec, string) {
+	return codecs.negotiate(accept)
+}
+
+func newCodecRegistry() *codecRegistry {
+	r := &codecRegistry{codecs: make(map[string]*Codec), suffixes: make(map[string]string)}
+	r.register("application/json", &Codec{
+		NewEncoder: func(w io.Writer) Encoder { return json.NewEncoder(w) },
+		NewDecoder: func(r io.Reader) Decoder { return json.NewDecoder(r) },
+		Suffix:     "json",
+	})
+	r.register("application/xml", &Codec{
+		NewEncoder: func(w io.Writer) Encoder { return xml.NewEncoder(w) },
+		NewDecoder: func(r io.Reader) Decoder { return xml.NewDecoder(r) },
+		Suffix:     "xml",
+	})
+	r.register("application/gob", &Codec{
+		NewEncoder: func(w io.Writer) Encoder { return gob.NewEncoder(w) },
+		NewDecoder: func(r io.Reader) Decoder { return gob.NewDecoder(r) },
+		Suffix:     "gob",
+	})
+	r.register("text/plain", textCodec("text/plain", "txt"))
+	r.register("text/html", textCodec("text/html", "html"))
+	return r
+}
+
+// textCodec returns a codec for strings and byte slices.
+func textCodec(ct, suffix string) *Codec {
+	return &Codec{
+		NewEncoder: func(w io.Writer) Encoder { return newTextEncoder(w, ct) },
+		NewDecoder: func(r io.Reader) Decoder { return newTextDecoder(r, ct) },
+		Suffix:     suffix,
+	}
+}
+
+func (r *codecRegistry) register(mediaType string, codec *Codec) {
+	mediaType = normalizeMediaType(mediaType)
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.codecs[mediaType]; !ok {
+		r.order = append(r.order, mediaType)
+	}
+	r.codecs[mediaType] = codec
+	if codec.Suffix != "" {
+		r.suffixes[codec.Suffix] = mediaType
+	}
+}
+
+// lookup returns the codec for the given media type and the normalized media
+// type.
+func (r *codecRegistry) lookup(mediaType string) (*Codec, string) {
+	mediaType = normalizeMediaType(mediaType)
+	r.RLock()
+	defer r.RUnlock()
+	if c, ok := r.codecs[mediaType]; ok {
+		return c, mediaType
+	}
+	if i := strings.LastIndexByte(mediaType, '+'); i >= 0 {
+		if mt, ok := r.suffixes[mediaType[i+1:]]; ok {
+			return r.codecs[mt], mediaType
+		}
+	}
+	return nil, mediaType
+}
+
+func (r *codecRegistry) negotiate(accept string) (*Codec, string) {
+	for _, mr := range parseAccept(accept) {
+		if !strings.HasSuffix(mr.mediaType, "/*") {
+			if strings.ContainsRune(mr.mediaType, '*') {
+				continue // invalid media range
+			}
+			if c, mt := r.lookup(mr.mediaType); c != nil && c.NewEncoder != nil {
+				return c, mt
+			}
+			continue
+		}
+		prefix := strings.TrimSuffix(mr.mediaType, "*")
+		if prefix == "*/" {
+			prefix = ""
+		}
+		r.RLock()
+		for _, mt := range r.order {
+			if c := r.codecs[mt]; strings.HasPrefix(mt, prefix) && c.NewEncoder != nil {
+				r.RUnlock()
+				return c, mt
+			}
+		}
+		r.RUnlock()
+	}
+	return nil, ""
+}
+
+// parseAccept parses the media ranges listed in the given Accept header value
+// and returns them sorted by decreasing quality value. Media ranges with
+// identical quality values keep their relative order. Media ranges with a
+// quality value of 0 or that cannot be parsed are omitted.
+func parseAccept(accept string) []*mediaRange {
+	var ranges []*mediaRange
+	for _, s := range strings.Split(accept, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		mt, params, err := mime.ParseMediaType(s)
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+		if q == 0 {
+			continue
+		}
+		ranges = append(ranges, &mediaRange{mediaType: mt, q: q})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+	return ranges
+}
+
+// normalizeMediaType removes the parameters from the given media type and
+// lowercases it.
+func normalizeMediaType(mediaType string) string {
+	if mt, _, err := mime.ParseMediaType(mediaType); err == nil {
+		return mt
+	}
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
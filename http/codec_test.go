@@ -0,0 +1,169 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testEncoder is a codec encoder that writes a fixed prefix before the
+// value.
+type testEncoder struct{ w io.Writer }
+
+// testDecoder is a codec decoder that reads the encoded value back.
+type testDecoder struct{ r io.Reader }
+
+func TestNegotiateCodec(t *testing.T) {
+	cases := []struct {
+		name      string
+		accept    string
+		mediaType string
+	}{
+		{"empty", "", ""},
+		{"json", "application/json", "application/json"},
+		{"params", "application/xml; charset=utf-8", "application/xml"},
+		{"suffix", "application/vnd.goa+json", "application/vnd.goa+json"},
+		{"unknown", "application/unknown", ""},
+		{"list", "application/unknown, application/gob", "application/gob"},
+		{"q values", "application/json;q=0.5, application/xml", "application/xml"},
+		{"q values order", "text/plain;q=0.8, application/gob;q=0.8, application/json;q=0.1", "text/plain"},
+		{"q zero", "application/xml;q=0, application/gob;q=0.1", "application/gob"},
+		{"invalid q", "application/xml;q=2, application/gob", "application/gob"},
+		{"type wildcard", "text/*", "text/plain"},
+		{"application wildcard", "application/*", "application/json"},
+		{"wildcard", "*/*", "application/json"},
+		{"wildcard low q", "*/*;q=0.1, text/html", "text/html"},
+		{"unknown wildcard", "image/*", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			codec, mt := NegotiateCodec(c.accept)
+			if mt != c.mediaType {
+				t.Errorf("got media type %q, expected %q", mt, c.mediaType)
+			}
+			if (codec == nil) != (c.mediaType == "") {
+				t.Errorf("got codec %v for media type %q", codec, mt)
+			}
+		})
+	}
+}
+
+func TestLookupCodec(t *testing.T) {
+	cases := []struct {
+		name      string
+		mediaType string
+		found     bool
+	}{
+		{"json", "application/json", true},
+		{"case", "Application/JSON", true},
+		{"params", "text/html; charset=utf-8", true},
+		{"suffix", "application/vnd.goa+xml", true},
+		{"suffix only", "+gob", true},
+		{"unknown", "application/msgpack", false},
+		{"unknown suffix", "application/vnd.goa+cbor", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, ok := LookupCodec(c.mediaType); ok != c.found {
+				t.Errorf("got found %v, expected %v", ok, c.found)
+			}
+		})
+	}
+}
+
+func TestRegisterCodec(t *testing.T) {
+	defer func(r *codecRegistry) { codecs = r }(codecs)
+	codecs = newCodecRegistry()
+	RegisterCodec("application/x-test", &Codec{
+		NewEncoder: func(w io.Writer) Encoder { return &testEncoder{w} },
+		NewDecoder: func(r io.Reader) Decoder { return &testDecoder{r} },
+		Suffix:     "test",
+	})
+	const encoded = "test:value"
+
+	t.Run("request decoder", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", strings.NewReader(encoded))
+		r.Header.Set("Content-Type", "application/x-test")
+		var v string
+		if err := RequestDecoder(r).Decode(&v); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if v != "value" {
+			t.Errorf("got %q, expected %q", v, "value")
+		}
+	})
+
+	t.Run("response encoder", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), AcceptTypeKey, "application/json;q=0.5, application/*+test")
+		w := httptest.NewRecorder()
+		if fmt.Sprintf("%T", ResponseEncoder(ctx, w)) == "*http.testEncoder" {
+			t.Errorf("wildcard with suffix should not match the test codec")
+		}
+		ctx = context.WithValue(context.Background(), AcceptTypeKey, "application/json;q=0.5, application/x-test")
+		w = httptest.NewRecorder()
+		if err := ResponseEncoder(ctx, w).Encode("value"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got := w.Body.String(); got != encoded {
+			t.Errorf("got body %q, expected %q", got, encoded)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/x-test" {
+			t.Errorf("got Content-Type %q, expected %q", ct, "application/x-test")
+		}
+	})
+
+	t.Run("response encoder content type", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), ContentTypeKey, "application/vnd.goa+test")
+		w := httptest.NewRecorder()
+		if got := fmt.Sprintf("%T", ResponseEncoder(ctx, w)); got != "*http.testEncoder" {
+			t.Errorf("got encoder type %s, expected *http.testEncoder", got)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/vnd.goa+test" {
+			t.Errorf("got Content-Type %q, expected %q", ct, "application/vnd.goa+test")
+		}
+	})
+
+	t.Run("request encoder", func(t *testing.T) {
+		r := &http.Request{Header: http.Header{"Content-Type": {"application/x-test"}}}
+		if err := RequestEncoder(r).Encode("value"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		b, _ := io.ReadAll(r.Body)
+		if string(b) != encoded {
+			t.Errorf("got body %q, expected %q", string(b), encoded)
+		}
+	})
+
+	t.Run("response decoder", func(t *testing.T) {
+		resp := &http.Response{
+			Header: http.Header{"Content-Type": {"application/x-test; charset=utf-8"}},
+			Body:   io.NopCloser(strings.NewReader(encoded)),
+		}
+		var v string
+		if err := ResponseDecoder(resp).Decode(&v); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if v != "value" {
+			t.Errorf("got %q, expected %q", v, "value")
+		}
+	})
+}
+
+func (e *testEncoder) Encode(v any) error {
+	_, err := fmt.Fprintf(e.w, "test:%v", v)
+	return err
+}
+
+func (d *testDecoder) Decode(v any) error {
+	b, err := io.ReadAll(d.r)
+	if err != nil {
+		return err
+	}
+	*(v.(*string)) = string(bytes.TrimPrefix(b, []byte("test:")))
+	return nil
+}
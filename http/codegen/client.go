@@ -17,6 +17,12 @@ func ClientFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 		if f := websocketClientFile(genpkg, svc); f != nil {
 			files = append(files, f)
 		}
+		if f := sseClientFile(genpkg, svc); f != nil {
+			files = append(files, f)
+		}
+		if f := ndjsonClientFile(genpkg, svc); f != nil {
+			files = append(files, f)
+		}
 	}
 	for _, svc := range root.API.HTTP.Services {
 		if f := clientEncodeDecodeFile(genpkg, svc); f != nil {
@@ -80,7 +86,11 @@ func clientFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 			Data:   e,
 			FuncMap: map[string]any{
 				"isWebSocketEndpoint": isWebSocketEndpoint,
+				"webSocketConfig":     webSocketConfig,
+				"isSSEEndpoint":       isSSEEndpoint,
+				"isNDJSONEndpoint":    isNDJSONEndpoint,
 				"responseStructPkg":   responseStructPkg,
+				"statusCode":          statusCodeToHTTPConst,
 			},
 		})
 	}
@@ -292,12 +302,16 @@ func New{{ .ClientStruct }}(
 	dialer goahttp.Dialer,
 	cfn *ConnConfigurer,
 	{{- end }}
+	opts ...goahttp.ClientOption,
 ) *{{ .ClientStruct }} {
 {{- if hasWebSocket . }}
 	if cfn == nil {
 		cfn = &ConnConfigurer{}
 	}
 {{- end }}
+	for _, opt := range opts {
+		doer = opt(doer)
+	}
 	return &{{ .ClientStruct }}{
 		{{- range .Endpoints }}
 		{{ .Method.VarName }}Doer: doer,
@@ -327,8 +341,33 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 			{{- end }}
 		{{- end }}
 		decodeResponse = {{ .ResponseDecoder }}(c.decoder, c.RestoreResponseBody)
+		{{- if and (isWebSocketEndpoint .) .ClientWebSocket.Config }}
+		configure      = goahttp.ConfigureWebSocket({{ webSocketConfig .ClientWebSocket.Config }}, c.configurer.{{ .Method.VarName }}Fn)
+		{{- end }}
+		{{- if .Retry }}
+		retry = goa.Retry(&goa.RetryPolicy{
+			MaxAttempts: {{ .Retry.MaxAttempts }},
+			InitialBackoff: {{ .Retry.InitialBackoff }},
+			MaxBackoff: {{ .Retry.MaxBackoff }},
+			{{- if .Retry.Jitter }}
+			Jitter: {{ .Retry.Jitter }},
+			{{- end }}
+			{{- if .Retry.HedgeDelay }}
+			HedgeDelay: {{ .Retry.HedgeDelay }},
+			{{- end }}
+			{{- if .Retry.Errors }}
+			Errors: []string{ {{ range $i, $e := .Retry.Errors }}{{ if $i }}, {{ end }}{{ printf "%q" $e }}{{ end }} },
+			{{- end }}
+			Retryable: goahttp.RetryableError({{ range $i, $c := .Retry.StatusCodes }}{{ if $i }}, {{ end }}{{ statusCode $c }}{{ end }}),
+		})
+		{{- end }}
 	)
-	return func(ctx context.Context, v any) (any, error) {
+	return {{ if .Idempotent }}goahttp.IdempotentEndpoint({{ end }}{{ if .Retry }}retry({{ end }}func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
+		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+	{{- if .SensitiveNames }}
+		ctx = goa.WithSensitive(ctx, {{ range $i, $n := .SensitiveNames }}{{ if $i }}, {{ end }}{{ printf "%q" $n }}{{ end }})
+	{{- end }}
 		req, err := c.{{ .RequestInit.Name }}(ctx, {{ range .RequestInit.ClientArgs }}{{ .Ref }}, {{ end }})
 		if err != nil {
 			return nil, err
@@ -339,6 +378,12 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 			return nil, err
 		}
 	{{- end }}
+	{{- if .Idempotent }}
+		goahttp.SetIdempotencyKey(ctx, req)
+	{{- end }}
+	{{- if .Conditional }}
+		goahttp.SetPreconditions(ctx, req)
+	{{- end }}
 
 	{{- if isWebSocketEndpoint . }}
 		conn, resp, err := c.dialer.DialContext(ctx, req.URL.String(), req.Header)
@@ -348,13 +393,40 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 			}
 			return nil, goahttp.ErrRequestError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
 		}
-		if c.configurer.{{ .Method.VarName }}Fn != nil {
+		{{- $configure := printf "c.configurer.%sFn" .Method.VarName }}
+		{{- if .ClientWebSocket.Config }}{{ $configure = "configure" }}{{ end }}
+		{{- if .ClientWebSocket.Reconnect }}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		if {{ $configure }} != nil {
+			conn = {{ $configure }}(conn, cancel)
+		}
+		stream := &{{ .ClientWebSocket.VarName }}{conn: conn}
+		stream.reconnect = goahttp.NewWebSocketReconnect(ctx, conn, &goa.RetryPolicy{
+			MaxAttempts: {{ .ClientWebSocket.Reconnect.MaxAttempts }},
+			InitialBackoff: {{ .ClientWebSocket.Reconnect.InitialBackoff }},
+			MaxBackoff: {{ .ClientWebSocket.Reconnect.MaxBackoff }},
+			{{- if .ClientWebSocket.Reconnect.Jitter }}
+			Jitter: {{ .ClientWebSocket.Reconnect.Jitter }},
+			{{- end }}
+		}, func(ctx context.Context) (*websocket.Conn, error) {
+			conn, _, err := c.dialer.DialContext(ctx, req.URL.String(), req.Header)
+			if err != nil {
+				return nil, err
+			}
+			if {{ $configure }} != nil {
+				conn = {{ $configure }}(conn, cancel)
+			}
+			return conn, nil
+		})
+		{{- else }}
+		if {{ $configure }} != nil {
 			{{- if eq .ClientWebSocket.SendName "" }}
 			var cancel context.CancelFunc
 			ctx, cancel = context.WithCancel(ctx)
-			conn = c.configurer.{{ .Method.VarName }}Fn(conn, cancel)
+			conn = {{ $configure }}(conn, cancel)
 			{{- else }}
-			conn = c.configurer.{{ .Method.VarName }}Fn(conn, nil)
+			conn = {{ $configure }}(conn, nil)
 			{{- end }}
 		}
 		{{- if eq .ClientWebSocket.SendName "" }}
@@ -369,6 +441,7 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 		}()
 		{{- end }}
 		stream := &{{ .ClientWebSocket.VarName }}{conn: conn}
+		{{- end }}
 		{{- if .Method.ViewedResult }}
 			{{- if not .Method.ViewedResult.ViewName }}
 		view := resp.Header.Get("goa-view")
@@ -376,6 +449,42 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 			{{- end }}
 		{{- end }}
 		return stream, nil
+	{{- else if isSSEEndpoint . }}
+		req.Header.Set("Accept", "text/event-stream")
+		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
+		}
+		if resp.StatusCode != {{ .ClientSSE.Response.StatusCode }} {
+			return decodeResponse(resp)
+		}
+		stream := &{{ .ClientSSE.VarName }}{body: resp.Body, reader: goahttp.NewServerSentEventReader(resp.Body)}
+		{{- if .Method.ViewedResult }}
+			{{- if not .Method.ViewedResult.ViewName }}
+		stream.SetView(resp.Header.Get("goa-view"))
+			{{- end }}
+		{{- end }}
+		return stream, nil
+	{{- else if isNDJSONEndpoint . }}
+		req.Header.Set("Accept", goahttp.NDJSONContentType)
+		{{- if .ClientNDJSON.SendTypeRef }}
+		return &{{ .ClientNDJSON.VarName }}{req: goahttp.NewNDJSONRequest(c.{{ .Method.VarName }}Doer, req), decode: decodeResponse}, nil
+		{{- else }}
+		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
+		}
+		if resp.StatusCode != {{ .ClientNDJSON.Response.StatusCode }} {
+			return decodeResponse(resp)
+		}
+		stream := &{{ .ClientNDJSON.VarName }}{body: resp.Body}
+			{{- if .Method.ViewedResult }}
+				{{- if not .Method.ViewedResult.ViewName }}
+		stream.SetView(resp.Header.Get("goa-view"))
+				{{- end }}
+			{{- end }}
+		return stream, nil
+		{{- end }}
 	{{- else }}
 		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
 		if err != nil {
@@ -392,7 +501,7 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 		return decodeResponse(resp)
 		{{- end }}
 	{{- end }}
-	}
+	}{{ if .Retry }}){{ end }}{{ if .Idempotent }}){{ end }}
 }
 `
 
@@ -783,7 +892,7 @@ const singleResponseT = ` {{- if .ClientBody }}
 		{{- range .Headers }}
 
 		{{- if (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
-			{{ .VarName }}Raw := resp.Header.Get("{{ .CanonicalName }}")
+			{{ .VarName }}Raw := {{ if and $.Conditional (eq .CanonicalName "Etag") }}goahttp.UnquoteETag(resp.Header.Get("{{ .CanonicalName }}")){{ else }}resp.Header.Get("{{ .CanonicalName }}"){{ end }}
 			{{- if .Required }}
 				if {{ .VarName }}Raw == "" {
 					err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "header"))
@@ -84,7 +84,7 @@ func ClientCLIFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 }
 
 func buildSubcommandData(sd *ServiceData, e *EndpointData) *subcommandData {
-	flags, buildFunction := buildFlags(sd, e)
+	flags, buildFunction := BuildFlags(sd, e)
 
 	sub := &subcommandData{
 		SubcommandData: cli.BuildSubcommandData(sd.Service.Name, e.Method, buildFunction, flags),
@@ -187,7 +187,9 @@ func payloadBuilders(genpkg string, svc *expr.HTTPServiceExpr, data *cli.Command
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
-func buildFlags(svc *ServiceData, e *EndpointData) ([]*cli.FlagData, *cli.BuildFunctionData) {
+// BuildFlags returns the command line flags and the data of the function that
+// builds the payload from the flag values for the given endpoint.
+func BuildFlags(svc *ServiceData, e *EndpointData) ([]*cli.FlagData, *cli.BuildFunctionData) {
 	var (
 		flags         []*cli.FlagData
 		buildFunction *cli.BuildFunctionData
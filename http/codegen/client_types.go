@@ -19,6 +19,17 @@ func ClientTypeFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 // clientType return the file containing the type definitions used by the HTTP
 // transport for the given service client. seen keeps track of the names of the
 // types that have already been generated to prevent duplicate code generation.
+func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct{}) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	path := filepath.Join(codegen.Gendir, "http", data.Service.PathName, "client", "types.go")
+	return ClientTypeFile(genpkg, svc, data, path, svc.Name()+" HTTP client types", seen)
+}
+
+// ClientTypeFile returns the file with the given path and title containing the
+// client type definitions computed in data for the given service. seen keeps
+// track of the names of the types that have already been generated to prevent
+// duplicate code generation. It makes it possible for transports built on top
+// of the HTTP transport data such as JSON-RPC to generate their types.
 //
 // Below are the rules governing whether values are pointers or not. Note that
 // the rules only applies to values that hold primitive types, values that hold
@@ -41,13 +52,8 @@ func ClientTypeFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 //   * Response header variables hold pointers when not required and have no
 //     default value.
 //
-func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct{}) *codegen.File {
-	var (
-		path    string
-		data    = HTTPServices.Get(svc.Name())
-		svcName = data.Service.PathName
-	)
-	path = filepath.Join(codegen.Gendir, "http", svcName, "client", "types.go")
+func ClientTypeFile(genpkg string, svc *expr.HTTPServiceExpr, data *ServiceData, path, title string, seen map[string]struct{}) *codegen.File {
+	svcName := data.Service.PathName
 	imports := []*codegen.ImportSpec{
 		{Path: "encoding/json"},
 		{Path: "unicode/utf8"},
@@ -56,7 +62,7 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 		codegen.GoaImport(""),
 	}
 	imports = append(imports, data.Service.UserTypeImports...)
-	header := codegen.Header(svc.Name()+" HTTP client types", "client", imports)
+	header := codegen.Header(title, "client", imports)
 
 	var (
 		initData       []*InitData
@@ -87,25 +93,23 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 				validatedTypes = append(validatedTypes, data)
 			}
 		}
-		if adata.ClientWebSocket != nil {
-			if data := adata.ClientWebSocket.Payload; data != nil {
-				if _, ok := seen[data.Name]; ok {
-					continue
-				}
-				seen[data.Name] = struct{}{}
-				if data.Def != "" {
-					sections = append(sections, &codegen.SectionTemplate{
-						Name:   "client-request-body",
-						Source: typeDeclT,
-						Data:   data,
-					})
-				}
-				if data.Init != nil {
-					initData = append(initData, data.Init)
-				}
-				if data.ValidateDef != "" {
-					validatedTypes = append(validatedTypes, data)
-				}
+		if data := clientStreamingPayload(adata); data != nil {
+			if _, ok := seen[data.Name]; ok {
+				continue
+			}
+			seen[data.Name] = struct{}{}
+			if data.Def != "" {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-request-body",
+					Source: typeDeclT,
+					Data:   data,
+				})
+			}
+			if data.Init != nil {
+				initData = append(initData, data.Init)
+			}
+			if data.ValidateDef != "" {
+				validatedTypes = append(validatedTypes, data)
 			}
 		}
 	}
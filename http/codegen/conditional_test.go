@@ -0,0 +1,59 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/codegentest"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestServerConditional(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"etag-last-modified", testdata.ConditionalDSL, testdata.ConditionalServerInitCode},
+		{"hash-etag", testdata.ConditionalHashDSL, testdata.ConditionalHashServerInitCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles("gen", expr.Root)
+			sections := codegentest.Sections(fs, filepath.Join("", "server.go"), "server-init")
+			if len(sections) == 0 {
+				t.Fatalf("section server-init missing from /server.go")
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestClientConditional(t *testing.T) {
+	cases := []*testCase{
+		{"conditional", testdata.ConditionalDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.ConditionalClientEndpointInitCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ClientFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
+
+func TestClientConditionalDecode(t *testing.T) {
+	RunHTTPDSL(t, testdata.ConditionalDSL)
+	fs := ClientFiles("gen", expr.Root)
+	sections := codegentest.Sections(fs, filepath.Join("client", "encode_decode.go"), "response-decoder")
+	if len(sections) == 0 {
+		t.Fatalf("section response-decoder missing from client/encode_decode.go")
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.ConditionalClientResponseDecoderCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ConditionalClientResponseDecoderCode))
+	}
+}
@@ -0,0 +1,257 @@
+package codegen
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// CORSData contains the data needed to initialize the goahttp.CORSOrigin
+	// values used by the generated server code.
+	CORSData struct {
+		// Origin is the allowed origin or the regular expression matching
+		// the allowed origins if Regexp is true.
+		Origin string
+		// Regexp is true if Origin is a regular expression.
+		Regexp bool
+		// Methods lists the allowed HTTP methods.
+		Methods []string
+		// Headers lists the allowed request headers.
+		Headers []string
+		// Expose lists the exposed response headers.
+		Expose []string
+		// MaxAge is the preflight results max age in seconds.
+		MaxAge int
+		// Credentials is true if credentials are allowed.
+		Credentials bool
+	}
+
+	// CORSPreflightData contains the data needed to mount the handler of
+	// the CORS preflight requests sent to a given path.
+	CORSPreflightData struct {
+		// Path is the request path.
+		Path string
+		// Origins lists the CORS policies of the endpoints served under
+		// the path.
+		Origins []*CORSData
+	}
+)
+
+// initCORSData initializes the CORS data of the endpoint from the CORS
+// policies that apply to it.
+func initCORSData(ed *EndpointData, e *expr.HTTPEndpointExpr) {
+	ed.CORS = corsData(e)
+}
+
+// corsData returns the CORS data built from the CORS policies that apply to
+// the given endpoint. The allowed methods default to the HTTP methods of the
+// endpoint routes.
+func corsData(e *expr.HTTPEndpointExpr) []*CORSData {
+	origins := e.CORSOrigins()
+	if len(origins) == 0 {
+		return nil
+	}
+	var verbs []string
+	for _, r := range e.Routes {
+		verbs = appendUnique(verbs, strings.ToUpper(r.Method))
+	}
+	data := make([]*CORSData, len(origins))
+	for i, o := range origins {
+		methods := o.Methods
+		if len(methods) == 0 {
+			methods = verbs
+		}
+		data[i] = &CORSData{
+			Origin:      o.Origin,
+			Regexp:      o.Regexp,
+			Methods:     methods,
+			Headers:     o.Headers,
+			Expose:      o.Expose,
+			MaxAge:      o.MaxAge,
+			Credentials: o.Credentials,
+		}
+	}
+	return data
+}
+
+// buildCORSPreflights returns the data needed to mount the CORS preflight
+// handlers of the given service. The services served by the same server share
+// the same mux so the handler of a path is mounted once by the first of these
+// services that serves the path and merges the policies of all the endpoints
+// served under the path. Paths that already have an OPTIONS route are skipped.
+func buildCORSPreflights(hs *expr.HTTPServiceExpr) []*CORSPreflightData {
+	var (
+		peers      = corsPeers(hs)
+		options    = make(map[string]bool)
+		owners     = make(map[string]*expr.HTTPServiceExpr)
+		preflights []*CORSPreflightData
+		byPath     = make(map[string]*CORSPreflightData)
+	)
+	for _, svc := range peers {
+		for _, e := range svc.HTTPEndpoints {
+			cors := len(e.CORSOrigins()) > 0
+			for _, r := range e.Routes {
+				for _, p := range r.FullPaths() {
+					if strings.ToUpper(r.Method) == "OPTIONS" {
+						options[p] = true
+					}
+					if _, ok := owners[p]; !ok && cors {
+						owners[p] = svc
+					}
+				}
+			}
+		}
+	}
+	for _, svc := range peers {
+		for _, e := range svc.HTTPEndpoints {
+			cors := corsData(e)
+			if len(cors) == 0 {
+				continue
+			}
+			for _, r := range e.Routes {
+				for _, path := range r.FullPaths() {
+					if owners[path] != hs || options[path] {
+						continue
+					}
+					p, ok := byPath[path]
+					if !ok {
+						p = &CORSPreflightData{Path: path}
+						byPath[path] = p
+						preflights = append(preflights, p)
+					}
+					p.Origins = mergeCORSOrigins(p.Origins, cors)
+				}
+			}
+		}
+	}
+	return preflights
+}
+
+// corsPeers returns the HTTP services that are served by a server that also
+// serves the given service including the service itself.
+func corsPeers(hs *expr.HTTPServiceExpr) []*expr.HTTPServiceExpr {
+	names := map[string]bool{hs.Name(): true}
+	for _, s := range expr.Root.API.Servers {
+		for _, n := range s.Services {
+			if n == hs.Name() {
+				for _, n := range s.Services {
+					names[n] = true
+				}
+				break
+			}
+		}
+	}
+	var (
+		peers []*expr.HTTPServiceExpr
+		found bool
+	)
+	if expr.Root.API.HTTP != nil {
+		for _, svc := range expr.Root.API.HTTP.Services {
+			if names[svc.Name()] {
+				peers = append(peers, svc)
+				found = found || svc == hs
+			}
+		}
+	}
+	if !found {
+		peers = append(peers, hs)
+	}
+	return peers
+}
+
+// mergeCORSOrigins merges the given policies into origins. The allowed
+// methods of the policies that share the same origin are merged.
+func mergeCORSOrigins(origins, cors []*CORSData) []*CORSData {
+origins:
+	for _, o := range cors {
+		for _, existing := range origins {
+			if existing.Origin == o.Origin && existing.Regexp == o.Regexp {
+				for _, m := range o.Methods {
+					existing.Methods = appendUnique(existing.Methods, m)
+				}
+				continue origins
+			}
+		}
+		dup := *o
+		dup.Methods = append([]string(nil), o.Methods...)
+		origins = append(origins, &dup)
+	}
+	return origins
+}
+
+// hasCORS returns true if the service mounts CORS preflight handlers.
+func hasCORS(data *ServiceData) bool {
+	return len(data.CORSPreflights) > 0
+}
+
+// corsSections returns the sections that implement the CORS preflight
+// handlers of the given service.
+func corsSections(data *ServiceData) []*codegen.SectionTemplate {
+	if !hasCORS(data) {
+		return nil
+	}
+	return []*codegen.SectionTemplate{
+		{Name: "server-cors-init", Source: corsHandlerInitT, Data: data},
+		{Name: "server-cors", Source: corsMountT, Data: data},
+	}
+}
+
+// appendUnique appends s to the given slice if it is not already in it.
+func appendUnique(slice []string, s string) []string {
+	for _, v := range slice {
+		if v == s {
+			return slice
+		}
+	}
+	return append(slice, s)
+}
+
+// input: ServiceData
+const corsHandlerInitT = `{{ printf "NewCORSHandler creates a HTTP handler which returns a simple 204 response to the CORS preflight requests sent to the %s service." .Service.Name | comment }}
+func NewCORSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+`
+
+// input: ServiceData
+const corsMountT = `{{ printf "MountCORSHandler configures the mux to serve the CORS preflight requests sent to the %s service endpoints." .Service.Name | comment }}
+func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
+	{{- range .CORSPreflights }}
+	mux.Handle("OPTIONS", "{{ .Path }}", goahttp.HandleCORS(h,
+		{{- range .Origins }}
+		{{ template "cors_origin" . }},
+		{{- end }}
+	))
+	{{- end }}
+}
+` + corsOriginT
+
+// input: CORSData
+const corsOriginT = `{{ define "cors_origin" -}}
+&goahttp.CORSOrigin{
+	{{- if .Regexp }}
+	Regexp: regexp.MustCompile({{ printf "%q" .Origin }}),
+	{{- else }}
+	Origin: {{ printf "%q" .Origin }},
+	{{- end }}
+	{{- if .Methods }}
+	Methods: []string{ {{ range $i, $m := .Methods }}{{ if $i }}, {{ end }}{{ printf "%q" $m }}{{ end }} },
+	{{- end }}
+	{{- if .Headers }}
+	Headers: []string{ {{ range $i, $h := .Headers }}{{ if $i }}, {{ end }}{{ printf "%q" $h }}{{ end }} },
+	{{- end }}
+	{{- if .Expose }}
+	Expose: []string{ {{ range $i, $h := .Expose }}{{ if $i }}, {{ end }}{{ printf "%q" $h }}{{ end }} },
+	{{- end }}
+	{{- if .MaxAge }}
+	MaxAge: {{ .MaxAge }},
+	{{- end }}
+	{{- if .Credentials }}
+	Credentials: true,
+	{{- end }}
+}
+{{- end }}`
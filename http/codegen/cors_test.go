@@ -0,0 +1,81 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/codegentest"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestServerCORS(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name        string
+		DSL         func()
+		Code        string
+		SectionNum  int
+		SectionName string
+	}{
+		{"server struct", testdata.CORSDSL, testdata.CORSServerStructCode, 0, "server-struct"},
+		{"server init", testdata.CORSDSL, testdata.CORSServerInitCode, 0, "server-init"},
+		{"server use", testdata.CORSDSL, testdata.CORSServerUseCode, 0, "server-use"},
+		{"server mount", testdata.CORSDSL, testdata.CORSServerMountCode, 0, "server-mount"},
+		{"service origin handler", testdata.CORSDSL, testdata.CORSServerHandlerCode, 0, "server-handler"},
+		{"endpoint origin handler", testdata.CORSDSL, testdata.CORSServerHandlerOverrideCode, 2, "server-handler"},
+		{"preflight handler init", testdata.CORSDSL, testdata.CORSServerCORSInitCode, 0, "server-cors-init"},
+		{"preflight handler mount", testdata.CORSDSL, testdata.CORSServerCORSCode, 0, "server-cors"},
+		{"api origin preflight handler mount", testdata.CORSAPIDSL, testdata.CORSAPIServerCORSCode, 0, "server-cors"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles(genpkg, expr.Root)
+			sections := codegentest.Sections(fs, filepath.Join("", "server.go"), c.SectionName)
+			if c.SectionNum >= len(sections) {
+				t.Fatalf("section %#v missing from /server.go", c.SectionName)
+			}
+			code := codegen.SectionCode(t, sections[c.SectionNum])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestServerNoCORS(t *testing.T) {
+	RunHTTPDSL(t, testdata.NoCORSDSL)
+	fs := ServerFiles("gen", expr.Root)
+	for _, name := range []string{"server-cors-init", "server-cors"} {
+		if sections := codegentest.Sections(fs, filepath.Join("", "server.go"), name); len(sections) > 0 {
+			t.Errorf("got %d %s sections, expected 0", len(sections), name)
+		}
+	}
+}
+
+func TestServerCORSMultipleServices(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Service string
+		Code    string
+	}{
+		{"first", "service_cors_first", testdata.CORSMultipleServicesFirstCORSCode},
+		{"second", "service_cors_second", testdata.CORSMultipleServicesSecondCORSCode},
+	}
+	RunHTTPDSL(t, testdata.CORSMultipleServicesDSL)
+	fs := ServerFiles("gen", expr.Root)
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			sections := codegentest.Sections(fs, filepath.Join(c.Service, "server", "server.go"), "server-cors")
+			if len(sections) != 1 {
+				t.Fatalf("got %d server-cors sections, expected 1", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
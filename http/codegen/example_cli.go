@@ -62,11 +62,36 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 		{Path: rootPath, Name: apiPkg},
 	}
 
-	var svcData []*ServiceData
+	var (
+		svcData []*ServiceData
+		rpcData []*jsonrpcExampleData
+	)
 	for _, svc := range svr.Services {
 		if data := HTTPServices.Get(svc); data != nil {
 			svcData = append(svcData, data)
 		}
+		if data := jsonrpcExample(svc); data != nil {
+			rpcData = append(rpcData, data)
+		}
+	}
+	if len(svcData) == 0 && len(rpcData) > 0 {
+		// The server only exposes JSON-RPC services, use the JSON-RPC
+		// client CLI support package to make the requests.
+		specs[len(specs)-2].Path = genpkg + "/jsonrpc/cli/" + svrdata.Dir
+		return &codegen.File{
+			Path: path,
+			SectionTemplates: []*codegen.SectionTemplate{
+				codegen.Header("", "main", specs),
+				{Name: "cli-http-start", Source: httpCLIStartT},
+				{
+					Name:   "cli-jsonrpc-end",
+					Source: jsonrpcCLIEndT,
+					Data:   map[string]any{"NeedStream": jsonrpcNeedStream(rpcData)},
+				},
+				{Name: "cli-http-usage", Source: httpCLIUsageT},
+			},
+			SkipExist: true,
+		}
 	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header("", "main", specs),
@@ -157,6 +182,18 @@ const (
 		{{- end }}
 	)
 }
+`
+
+	// input: map[string]any{"NeedStream": bool}
+	jsonrpcCLIEndT = `return cli.ParseEndpoint(
+		scheme,
+		host,
+		doer,
+		{{- if .NeedStream }}
+		websocket.DefaultDialer,
+		{{- end }}
+	)
+}
 `
 
 	httpCLIUsageT = `
@@ -8,6 +8,7 @@ import (
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/example"
+	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
 )
 
@@ -39,6 +40,7 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 		{Path: "os"},
 		{Path: "sync"},
 		{Path: "time"},
+		codegen.GoaImport(""),
 		codegen.GoaNamedImport("http", "goahttp"),
 		codegen.GoaNamedImport("http/middleware", "httpmdlwr"),
 		codegen.GoaImport("middleware"),
@@ -58,6 +60,19 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 			Name: scope.Unique(sd.Service.PkgName),
 		})
 	}
+	for _, svc := range root.API.JSONRPC.Services {
+		sd := service.Services.Get(svc.Name())
+		specs = append(specs, &codegen.ImportSpec{
+			Path: path.Join(genpkg, "jsonrpc", sd.PathName, "server"),
+			Name: scope.Unique(sd.PkgName + "jsonrpcsvr"),
+		})
+		if root.API.HTTP.Service(svc.Name()) == nil {
+			specs = append(specs, &codegen.ImportSpec{
+				Path: path.Join(genpkg, sd.PathName),
+				Name: scope.Unique(sd.PkgName),
+			})
+		}
+	}
 
 	var (
 		rootPath string
@@ -74,11 +89,41 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 	}
 	specs = append(specs, &codegen.ImportSpec{Path: rootPath, Name: apiPkg})
 
-	var svcdata []*ServiceData
+	var (
+		svcdata     []*ServiceData
+		rpcdata     []*jsonrpcExampleData
+		endpointsvc []*service.Data
+	)
 	for _, svc := range svr.Services {
-		if data := HTTPServices.Get(svc); data != nil {
+		data := HTTPServices.Get(svc)
+		if data != nil {
 			svcdata = append(svcdata, data)
 		}
+		rpc := jsonrpcExample(svc)
+		if rpc != nil {
+			rpcdata = append(rpcdata, rpc)
+		}
+		switch {
+		case data != nil:
+			endpointsvc = append(endpointsvc, data.Service)
+		case rpc != nil:
+			endpointsvc = append(endpointsvc, rpc.Service)
+		}
+	}
+
+	// The probes of the health checks shared by the API are mounted once
+	// and run the checks of all the services that inherit them.
+	var (
+		health    *expr.HealthExpr
+		healthsvc []*service.Data
+	)
+	for _, data := range svcdata {
+		if h := data.Service.Health; h != nil && h.Shared {
+			health = root.API.Health
+			if len(data.Service.Methods) > 0 {
+				healthsvc = append(healthsvc, data.Service)
+			}
+		}
 	}
 
 	sections := []*codegen.SectionTemplate{
@@ -87,35 +132,84 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 			Name:   "server-http-start",
 			Source: httpSvrStartT,
 			Data: map[string]any{
-				"Services": svcdata,
+				"Services": endpointsvc,
 			},
 		},
 		{Name: "server-http-logger", Source: httpSvrLoggerT},
-		{Name: "server-http-encoding", Source: httpSvrEncodingT},
+	}
+	if len(svcdata) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-http-encoding", Source: httpSvrEncodingT})
+	}
+	sections = append(sections, []*codegen.SectionTemplate{
 		{Name: "server-http-mux", Source: httpSvrMuxT},
 		{
 			Name:   "server-http-init",
 			Source: httpSvrInitT,
 			Data: map[string]any{
-				"Services": svcdata,
-				"APIPkg":   apiPkg,
+				"Services":        svcdata,
+				"JSONRPCServices": rpcdata,
+				"APIPkg":          apiPkg,
+				"Health":          health,
+				"HealthServices":  healthsvc,
 			},
-			FuncMap: map[string]any{"needStream": needStream, "hasWebSocket": hasWebSocket},
+			FuncMap: map[string]any{"needStream": needStream, "hasWebSocket": hasWebSocket, "jsonrpcNeedStream": jsonrpcNeedStream},
 		},
 		{Name: "server-http-middleware", Source: httpSvrMiddlewareT},
 		{
 			Name:   "server-http-end",
 			Source: httpSvrEndT,
 			Data: map[string]any{
-				"Services": svcdata,
+				"Services":        svcdata,
+				"JSONRPCServices": rpcdata,
 			},
 		},
 		{Name: "server-http-errorhandler", Source: httpSvrErrorHandlerT},
-	}
+	}...)
 
 	return &codegen.File{Path: fpath, SectionTemplates: sections, SkipExist: true}
 }
 
+// jsonrpcExampleData contains the data needed to mount the JSON-RPC server of
+// a service in the example HTTP server. JSON-RPC requests are sent over HTTP
+// so the JSON-RPC servers share the mux of the HTTP servers.
+type jsonrpcExampleData struct {
+	// Service is the service data.
+	Service *service.Data
+	// HasStreams is true if the service defines streaming methods served
+	// via websocket connections.
+	HasStreams bool
+}
+
+// jsonrpcExample returns the data needed to mount the JSON-RPC server of the
+// service with the given name, nil if the service is not a JSON-RPC service.
+func jsonrpcExample(name string) *jsonrpcExampleData {
+	if expr.Root.API.JSONRPC == nil {
+		return nil
+	}
+	svc := expr.Root.API.JSONRPC.Service(name)
+	if svc == nil {
+		return nil
+	}
+	data := &jsonrpcExampleData{Service: service.Services.Get(name)}
+	for _, e := range svc.HTTPEndpoints {
+		if e.IsWebSocket() {
+			data.HasStreams = true
+		}
+	}
+	return data
+}
+
+// jsonrpcNeedStream returns true if at least one of the given JSON-RPC
+// services defines streaming methods.
+func jsonrpcNeedStream(data []*jsonrpcExampleData) bool {
+	for _, d := range data {
+		if d.HasStreams {
+			return true
+		}
+	}
+	return false
+}
+
 // dummyMultipartFile returns a dummy implementation of the multipart decoders
 // and encoders.
 func dummyMultipartFile(genpkg string, root *expr.RootExpr, svc *expr.HTTPServiceExpr) *codegen.File {
@@ -198,9 +292,9 @@ func {{ .FuncName }}(mw *multipart.Writer, p {{ .Payload.Ref }}) error {
 }
 `
 
-	// input: map[string]any{"Services":[]*ServiceData}
+	// input: map[string]any{"Services":[]*service.Data}
 	httpSvrStartT = `{{ comment "handleHTTPServer starts configures and starts a HTTP server on the given URL. It shuts down the server if any error is received in the error channel." }}
-func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if .Service.Methods }}, {{ .Service.VarName }}Endpoints *{{ .Service.PkgName }}.Endpoints{{ end }}{{ end }}, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if .Methods }}, {{ .VarName }}Endpoints *{{ .PkgName }}.Endpoints{{ end }}{{ end }}, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
 `
 
 	httpSvrLoggerT = `
@@ -233,7 +327,7 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	}
 `
 
-	// input: map[string]any{"APIPkg":string, "Services":[]*ServiceData}
+	// input: map[string]any{"APIPkg":string, "Services":[]*ServiceData, "JSONRPCServices":[]*jsonrpcExampleData, "Health":*expr.HealthExpr, "HealthServices":[]*service.Data}
 	httpSvrInitT = `
 	// Wrap the endpoints with the transport specific layers. The generated
 	// server packages contains code generated from the design which maps
@@ -243,10 +337,13 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	{{- range .Services }}
 		{{ .Service.VarName }}Server *{{.Service.PkgName}}svr.Server
 	{{- end }}
+	{{- range .JSONRPCServices }}
+		{{ .Service.VarName }}JSONRPCServer *{{ .Service.PkgName }}jsonrpcsvr.Server
+	{{- end }}
 	)
 	{
 		eh := errorHandler(logger)
-	{{- if needStream .Services }}
+	{{- if or (needStream .Services) (jsonrpcNeedStream .JSONRPCServices) }}
 		upgrader := &websocket.Upgrader{}
 	{{- end }}
 	{{- range $svc := .Services }}
@@ -256,12 +353,18 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New(nil, mux, dec, enc, eh, nil{{ range .FileServers }}, nil{{ end }})
 		{{-  end }}
 	{{- end }}
-	{{- if .Services }}
+	{{- range .JSONRPCServices }}
+		{{ .Service.VarName }}JSONRPCServer = {{ .Service.PkgName }}jsonrpcsvr.New({{ .Service.VarName }}Endpoints, eh{{ if .HasStreams }}, upgrader, nil{{ end }})
+	{{- end }}
+	{{- if or .Services .JSONRPCServices }}
 		if debug {
 			servers := goahttp.Servers{
 				{{- range $svc := .Services }}
 				{{ .Service.VarName }}Server,
 				{{- end }}
+				{{- range .JSONRPCServices }}
+				{{ .Service.VarName }}JSONRPCServer,
+				{{- end }}
 			}
 			servers.Use(httpmdlwr.Debug(mux, os.Stdout))
 		}
@@ -271,6 +374,25 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	{{- range .Services }}
 		{{ .Service.PkgName }}svr.Mount(mux, {{ .Service.VarName }}Server)
 	{{- end }}
+	{{- range .JSONRPCServices }}
+		{{ .Service.PkgName }}jsonrpcsvr.Mount(mux, {{ .Service.VarName }}JSONRPCServer)
+	{{- end }}
+	{{- with .Health }}
+
+	// Mount the probes of the API health checks once for all the services.
+	mux.Handle("GET", "{{ .LivenessPath }}", goahttp.HandleLiveness().ServeHTTP)
+	{{- if and .Checks $.HealthServices }}
+	{
+		var checks []*goa.HealthCheck
+		{{- range $.HealthServices }}
+		checks = append(checks, {{ .VarName }}Endpoints.Health...)
+		{{- end }}
+		mux.Handle("GET", "{{ .ReadinessPath }}", goahttp.HandleReadiness(checks...).ServeHTTP)
+	}
+	{{- else }}
+	mux.Handle("GET", "{{ .ReadinessPath }}", goahttp.HandleReadiness().ServeHTTP)
+	{{- end }}
+	{{- end }}
 `
 
 	httpSvrMiddlewareT = `
@@ -283,7 +405,7 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	}
 `
 
-	// input: map[string]any{"Services":[]*ServiceData}
+	// input: map[string]any{"Services":[]*ServiceData, "JSONRPCServices":[]*jsonrpcExampleData}
 	httpSvrEndT = `
 	// Start HTTP server using default configuration, change the code to
 	// configure the server as required by your service.
@@ -294,6 +416,11 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 			logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 		}
 	{{- end }}
+	{{- range .JSONRPCServices }}
+		for _, m := range {{ .Service.VarName }}JSONRPCServer.Mounts {
+			logger.Printf("JSON-RPC %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+		}
+	{{- end }}
 
 	(*wg).Add(1)
 	go func() {
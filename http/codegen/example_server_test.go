@@ -73,6 +73,7 @@ func TestExampleServerFiles(t *testing.T) {
 			{"server-hosting-service-subset", ctestdata.ServerHostingServiceSubsetDSL, testdata.ServerHostingServiceSubsetServerHandleCode},
 			{"server-hosting-multiple-services", ctestdata.ServerHostingMultipleServicesDSL, testdata.ServerHostingMultipleServicesServerHandleCode},
 			{"streaming", testdata.StreamingMultipleServicesDSL, testdata.StreamingServerHandleCode},
+			{"shared-health", testdata.SharedHealthDSL, testdata.SharedHealthServerHandleCode},
 		}
 		for _, c := range cases {
 			t.Run(c.Name, func(t *testing.T) {
@@ -76,3 +76,9 @@ var statusCodeToConst = map[int]string{
 	http.StatusNotExtended:                   "StatusNotExtended",
 	http.StatusNetworkAuthenticationRequired: "StatusNetworkAuthenticationRequired",
 }
+
+// isIdempotentMethod returns true if requests made with the given HTTP method
+// are idempotent (RFC 7231 section 4.2.2).
+func isIdempotentMethod(method string) bool {
+	return method != http.MethodPost && method != http.MethodPatch
+}
@@ -25,6 +25,8 @@ func TestHandlerInit(t *testing.T) {
 		{"payload result", testdata.ServerPayloadResultDSL, testdata.ServerPayloadResultHandlerConstructorCode},
 		{"payload result error", testdata.ServerPayloadResultErrorDSL, testdata.ServerPayloadResultErrorHandlerConstructorCode},
 		{"skip response body encode decode", testdata.ServerSkipResponseBodyEncodeDecodeDSL, testdata.ServerSkipResponseBodyEncodeDecodeCode},
+		{"cursor pagination", testdata.ServerCursorPaginationDSL, testdata.ServerCursorPaginationCode},
+		{"offset pagination", testdata.ServerOffsetPaginationDSL, testdata.ServerOffsetPaginationCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -0,0 +1,35 @@
+package codegen
+
+import (
+	"goa.design/goa/v3/codegen"
+)
+
+// hasHealth returns true if the service serves its own health probes. The
+// probes of the health checks shared by the API are mounted once by the
+// example server.
+func hasHealth(data *ServiceData) bool {
+	return data.Service.Health != nil && !data.Service.Health.Shared
+}
+
+// healthSections returns the sections that mount the health probe handlers
+// of the given service.
+func healthSections(data *ServiceData) []*codegen.SectionTemplate {
+	if !hasHealth(data) {
+		return nil
+	}
+	return []*codegen.SectionTemplate{
+		{Name: "server-health", Source: healthMountT, Data: data},
+	}
+}
+
+// input: ServiceData
+const healthMountT = `{{ printf "MountLivenessHandler configures the mux to serve the liveness probes of the %s service." .Service.Name | comment }}
+func MountLivenessHandler(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("GET", "{{ .Service.Health.LivenessPath }}", h.ServeHTTP)
+}
+
+{{ printf "MountReadinessHandler configures the mux to serve the readiness probes of the %s service." .Service.Name | comment }}
+func MountReadinessHandler(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("GET", "{{ .Service.Health.ReadinessPath }}", h.ServeHTTP)
+}
+`
@@ -0,0 +1,56 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/codegentest"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestServerHealth(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name        string
+		DSL         func()
+		Code        string
+		SectionName string
+	}{
+		{"server struct", testdata.HealthDSL, testdata.HealthServerStructCode, "server-struct"},
+		{"server init", testdata.HealthDSL, testdata.HealthServerInitCode, "server-init"},
+		{"server mount", testdata.HealthDSL, testdata.HealthServerMountCode, "server-mount"},
+		{"probe handlers mount", testdata.HealthDSL, testdata.HealthServerHealthCode, "server-health"},
+		{"no check server init", testdata.HealthNoCheckDSL, testdata.HealthNoCheckServerInitCode, "server-init"},
+		{"no check probe handlers mount", testdata.HealthNoCheckDSL, testdata.HealthNoCheckServerHealthCode, "server-health"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles(genpkg, expr.Root)
+			sections := codegentest.Sections(fs, filepath.Join("", "server.go"), c.SectionName)
+			if len(sections) == 0 {
+				t.Fatalf("section %#v missing from /server.go", c.SectionName)
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestServerSharedHealth(t *testing.T) {
+	RunHTTPDSL(t, testdata.SharedHealthDSL)
+	fs := ServerFiles("gen", expr.Root)
+	for _, name := range []string{"service1", "service2"} {
+		path := filepath.Join(name, "server", "server.go")
+		if len(codegentest.Sections(fs, path, "server-mount")) == 0 {
+			t.Fatalf("section %q missing from %s", "server-mount", path)
+		}
+		if sections := codegentest.Sections(fs, path, "server-health"); len(sections) > 0 {
+			t.Errorf("got probe handlers mount in %s, expected the shared probes to be mounted by the server", path)
+		}
+	}
+}
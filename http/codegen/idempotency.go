@@ -0,0 +1,45 @@
+package codegen
+
+import (
+	"goa.design/goa/v3/codegen"
+)
+
+// hasIdempotent returns true if at least one of the service endpoints honors
+// the "Idempotency-Key" request header.
+func hasIdempotent(data *ServiceData) bool {
+	for _, e := range data.Endpoints {
+		if e.Idempotent {
+			return true
+		}
+	}
+	return false
+}
+
+// idempotencySections returns the sections that configure the store, the
+// request scope and the maximum body size used by the idempotent endpoint
+// handlers of the given service.
+func idempotencySections(data *ServiceData) []*codegen.SectionTemplate {
+	if !hasIdempotent(data) {
+		return nil
+	}
+	return []*codegen.SectionTemplate{
+		{Name: "server-idempotency", Source: idempotencyStoreT, Data: data},
+	}
+}
+
+// input: ServiceData
+const idempotencyStoreT = `{{ printf "UseIdempotencyStore configures the handlers of the idempotent %s endpoints to record responses in the given store. The handlers record responses in memory by default." .Service.Name | comment }}
+func (s *{{ .ServerStruct }}) UseIdempotencyStore(store goahttp.IdempotencyStore) {
+	s.idempotency.SetStore(store)
+}
+
+{{ printf "UseIdempotencyScope configures the handlers of the idempotent %s endpoints to compute the scope of the requests with the given function. Requests made with the same idempotency key but different scopes are processed independently. The scope is the value of the Authorization header by default." .Service.Name | comment }}
+func (s *{{ .ServerStruct }}) UseIdempotencyScope(scope func(*http.Request) string) {
+	s.idempotency.SetScope(scope)
+}
+
+{{ printf "UseIdempotencyMaxBodySize sets the maximum size in bytes of the bodies of the requests made to the idempotent %s endpoints with an idempotency key, goahttp.DefaultIdempotencyMaxBodySize by default." .Service.Name | comment }}
+func (s *{{ .ServerStruct }}) UseIdempotencyMaxBodySize(n int64) {
+	s.idempotency.SetMaxBodySize(n)
+}
+`
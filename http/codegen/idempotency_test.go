@@ -0,0 +1,51 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/codegentest"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestServerIdempotent(t *testing.T) {
+	cases := []struct {
+		Name        string
+		DSL         func()
+		Code        string
+		SectionName string
+	}{
+		{"server struct", testdata.IdempotentDSL, testdata.IdempotentServerStructCode, "server-struct"},
+		{"server init", testdata.IdempotentDSL, testdata.IdempotentServerInitCode, "server-init"},
+		{"server idempotency store", testdata.IdempotentDSL, testdata.IdempotentServerStoreCode, "server-idempotency"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles("gen", expr.Root)
+			sections := codegentest.Sections(fs, filepath.Join("", "server.go"), c.SectionName)
+			if len(sections) == 0 {
+				t.Fatalf("section %#v missing from /server.go", c.SectionName)
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestClientIdempotent(t *testing.T) {
+	cases := []*testCase{
+		{"idempotent", testdata.IdempotentDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.IdempotentClientEndpointInitCode},
+		}},
+		{"idempotent-retry", testdata.IdempotentRetryDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.IdempotentRetryClientEndpointInitCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ClientFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
@@ -0,0 +1,531 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// NDJSONData contains the data needed to render the struct types that
+	// implement the server and client stream interfaces using newline
+	// delimited JSON request and response bodies.
+	NDJSONData struct {
+		// VarName is the name of the struct.
+		VarName string
+		// Type is type of the stream (server or client).
+		Type string
+		// Interface is the fully qualified name of the interface that
+		// the struct implements.
+		Interface string
+		// Endpoint is endpoint data that defines streaming
+		// payload/result.
+		Endpoint *EndpointData
+		// Payload is the streaming payload type sent via the stream if
+		// any.
+		Payload *TypeData
+		// Response is the successful response data for the streaming
+		// endpoint.
+		Response *ResponseData
+		// SendName is the name of the send function.
+		SendName string
+		// SendDesc is the description for the send function.
+		SendDesc string
+		// SendTypeName is the fully qualified type name sent through
+		// the stream.
+		SendTypeName string
+		// SendTypeRef is the fully qualified type ref sent through the
+		// stream.
+		SendTypeRef string
+		// RecvName is the name of the receive function.
+		RecvName string
+		// RecvDesc is the description for the recv function.
+		RecvDesc string
+		// RecvTypeName is the fully qualified type name received from
+		// the stream.
+		RecvTypeName string
+		// RecvTypeRef is the fully qualified type ref received from the
+		// stream.
+		RecvTypeRef string
+		// MustClose indicates whether to generate the Close() function
+		// for the stream.
+		MustClose bool
+		// FullDuplex is true if the stream sends and receives messages
+		// concurrently (bidirectional streams).
+		FullDuplex bool
+		// PkgName is the service package name.
+		PkgName string
+	}
+)
+
+// initNDJSONData initializes the NDJSON related data in ed.
+func initNDJSONData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData) {
+	var (
+		svrRecvTypeName string
+		svrRecvTypeRef  string
+		svrSendDesc     string
+		svrRecvDesc     string
+		svrPayload      *TypeData
+		cliSendDesc     string
+		cliRecvDesc     string
+		cliPayload      *TypeData
+
+		md  = ed.Method
+		svc = sd.Service
+	)
+	{
+		svrSendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint NDJSON response.", md.ServerStream.SendName, ed.Result.Name, md.Name)
+		cliRecvDesc = fmt.Sprintf("%s reads instances of %q from the %q endpoint NDJSON response.", md.ClientStream.RecvName, ed.Result.Name, md.Name)
+		if e.MethodExpr.IsPayloadStreaming() {
+			svrRecvTypeName = sd.Scope.GoFullTypeName(e.MethodExpr.StreamingPayload, svc.PkgName)
+			svrRecvTypeRef = sd.Scope.GoFullTypeRef(e.MethodExpr.StreamingPayload, svc.PkgName)
+			svrPayload, cliPayload = buildStreamingPayloadData(e, sd)
+			if e.MethodExpr.Stream == expr.ClientStreamKind {
+				svrSendDesc = fmt.Sprintf("%s writes the %q endpoint response and closes the stream.", md.ServerStream.SendName, md.Name)
+				cliRecvDesc = fmt.Sprintf("%s closes the %q endpoint NDJSON request body and reads the %q response.", md.ClientStream.RecvName, md.Name, ed.Result.Name)
+			}
+			svrRecvDesc = fmt.Sprintf("%s reads instances of %q from the %q endpoint NDJSON request body, it returns io.EOF once the client closes the body.", md.ServerStream.RecvName, svrRecvTypeName, md.Name)
+			cliSendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint NDJSON request body.", md.ClientStream.SendName, svrRecvTypeName, md.Name)
+		}
+	}
+	ed.ServerNDJSON = &NDJSONData{
+		VarName:      md.ServerStream.VarName,
+		Interface:    fmt.Sprintf("%s.%s", svc.PkgName, md.ServerStream.Interface),
+		Endpoint:     ed,
+		Payload:      svrPayload,
+		Response:     ed.Result.Responses[0],
+		PkgName:      svc.PkgName,
+		Type:         "server",
+		SendName:     md.ServerStream.SendName,
+		SendDesc:     svrSendDesc,
+		SendTypeName: ed.Result.Name,
+		SendTypeRef:  ed.Result.Ref,
+		RecvName:     md.ServerStream.RecvName,
+		RecvDesc:     svrRecvDesc,
+		RecvTypeName: svrRecvTypeName,
+		RecvTypeRef:  svrRecvTypeRef,
+		MustClose:    md.ServerStream.MustClose,
+		FullDuplex:   e.MethodExpr.Stream == expr.BidirectionalStreamKind,
+	}
+	ed.ClientNDJSON = &NDJSONData{
+		VarName:      md.ClientStream.VarName,
+		Interface:    fmt.Sprintf("%s.%s", svc.PkgName, md.ClientStream.Interface),
+		Endpoint:     ed,
+		Payload:      cliPayload,
+		Response:     ed.Result.Responses[0],
+		PkgName:      svc.PkgName,
+		Type:         "client",
+		SendName:     md.ClientStream.SendName,
+		SendDesc:     cliSendDesc,
+		SendTypeName: svrRecvTypeName,
+		SendTypeRef:  svrRecvTypeRef,
+		RecvName:     md.ClientStream.RecvName,
+		RecvDesc:     cliRecvDesc,
+		RecvTypeName: ed.Result.Name,
+		RecvTypeRef:  ed.Result.Ref,
+		MustClose:    md.ClientStream.MustClose,
+		FullDuplex:   e.MethodExpr.Stream == expr.BidirectionalStreamKind,
+	}
+}
+
+// ndjsonServerFile returns the file implementing the NDJSON server streaming
+// implementation if any.
+func ndjsonServerFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	if !hasNDJSON(data) {
+		return nil
+	}
+	svcName := data.Service.PathName
+	title := fmt.Sprintf("%s NDJSON server streaming", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", []*codegen.ImportSpec{
+			{Path: "encoding/json"},
+			{Path: "net/http"},
+			{Path: "sync"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
+		}),
+	}
+	for _, e := range data.Endpoints {
+		d := e.ServerNDJSON
+		if d == nil {
+			continue
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "server-ndjson-struct-type",
+			Source: ndjsonStructTypeT,
+			Data:   d,
+		})
+		if d.SendTypeRef != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:    "server-ndjson-send",
+				Source:  ndjsonSendT,
+				Data:    d,
+				FuncMap: map[string]any{"viewedServerBody": viewedServerBody},
+			})
+		}
+		if d.RecvTypeRef != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "server-ndjson-recv",
+				Source: ndjsonRecvT,
+				Data:   d,
+			})
+		}
+		if d.MustClose {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "server-ndjson-close",
+				Source: ndjsonCloseT,
+				Data:   d,
+			})
+		}
+		if e.Method.ViewedResult != nil && e.Method.ViewedResult.ViewName == "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "server-ndjson-set-view",
+				Source: ndjsonSetViewT,
+				Data:   d,
+			})
+		}
+	}
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", svcName, "server", "ndjson.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// ndjsonClientFile returns the file implementing the NDJSON client streaming
+// implementation if any.
+func ndjsonClientFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	if !hasNDJSON(data) {
+		return nil
+	}
+	svcName := data.Service.PathName
+	title := fmt.Sprintf("%s NDJSON client streaming", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{
+			{Path: "encoding/json"},
+			{Path: "io"},
+			{Path: "net/http"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
+			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
+		}),
+	}
+	for _, e := range data.Endpoints {
+		d := e.ClientNDJSON
+		if d == nil {
+			continue
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-ndjson-struct-type",
+			Source: ndjsonStructTypeT,
+			Data:   d,
+		})
+		if d.SendTypeRef != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:    "client-ndjson-send",
+				Source:  ndjsonSendT,
+				Data:    d,
+				FuncMap: map[string]any{"viewedServerBody": viewedServerBody},
+			})
+		}
+		if d.RecvTypeRef != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "client-ndjson-recv",
+				Source: ndjsonRecvT,
+				Data:   d,
+			})
+		}
+		if d.MustClose {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "client-ndjson-close",
+				Source: ndjsonCloseT,
+				Data:   d,
+			})
+		}
+		if e.Method.ViewedResult != nil && e.Method.ViewedResult.ViewName == "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "client-ndjson-set-view",
+				Source: ndjsonSetViewT,
+				Data:   d,
+			})
+		}
+	}
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", svcName, "client", "ndjson.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// hasNDJSON returns true if at least one of the endpoints in the service
+// streams using NDJSON.
+func hasNDJSON(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if isNDJSONEndpoint(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNDJSONEndpoint returns true if the endpoint streams its payload or result
+// using NDJSON.
+func isNDJSONEndpoint(ed *EndpointData) bool {
+	return ed.ServerNDJSON != nil || ed.ClientNDJSON != nil
+}
+
+const (
+	// ndjsonStructTypeT renders the server and client struct types that
+	// implements the client and server stream interfaces.
+	// input: NDJSONData
+	ndjsonStructTypeT = `{{ printf "%s implements the %s interface." .VarName .Interface | comment }}
+type {{ .VarName }} struct {
+{{- if eq .Type "server" }}
+	once sync.Once
+	{{ comment "w is the HTTP response writer used to stream the results." }}
+	w http.ResponseWriter
+	{{- if .RecvTypeRef }}
+	{{ comment "r is the HTTP request whose body streams the payloads." }}
+	r *http.Request
+	{{ comment "dec decodes the payloads read from the request body." }}
+	dec *json.Decoder
+	{{- end }}
+	{{ comment "started is true once the response headers have been written." }}
+	started bool
+{{- else }}
+	{{- if .SendTypeRef }}
+	{{ comment "req is the HTTP request whose body streams the payloads." }}
+	req *goahttp.NDJSONRequest
+	{{ comment "decode decodes the responses that do not use the success status code." }}
+	decode func(*http.Response) (any, error)
+	{{- end }}
+	{{- if .RecvTypeRef }}
+	{{ comment "body is the HTTP response body." }}
+	body io.ReadCloser
+	{{ comment "dec decodes the results read from the response body." }}
+	dec *json.Decoder
+	{{- end }}
+{{- end }}
+	{{- if .Endpoint.Method.ViewedResult }}
+		{{- if not .Endpoint.Method.ViewedResult.ViewName }}
+	{{ printf "view is the view to render %s result type before sending to the stream." .Endpoint.Result.Name | comment }}
+	view string
+		{{- end }}
+	{{- end }}
+}
+`
+
+	// ndjsonSendT renders the function implementing the Send method in
+	// stream interface.
+	// input: NDJSONData
+	ndjsonSendT = `{{ comment .SendDesc }}
+func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
+{{- if eq .Type "server" }}
+	{{- template "ndjson_start" . }}
+	{{- if .Endpoint.Method.ViewedResult }}
+		{{- if .Endpoint.Method.ViewedResult.ViewName }}
+	res := {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(v, {{ printf "%q" .Endpoint.Method.ViewedResult.ViewName }})
+		{{- else }}
+	res := {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(v, s.view)
+		{{- end }}
+	{{- else }}
+	res := v
+	{{- end }}
+	{{- $servBodyLen := len .Response.ServerBody }}
+	{{- if and (gt $servBodyLen 0) (index .Response.ServerBody 0).Init }}
+		{{- if .Endpoint.Method.ViewedResult }}
+			{{- if .Endpoint.Method.ViewedResult.ViewName }}
+				{{- $vsb := (viewedServerBody $.Response.ServerBody .Endpoint.Method.ViewedResult.ViewName) }}
+	body := {{ $vsb.Init.Name }}({{ range $vsb.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+			{{- else }}
+	var body any
+	switch s.view {
+				{{- range .Endpoint.Method.ViewedResult.Views }}
+	case {{ printf "%q" .Name }}{{ if eq .Name "default" }}, ""{{ end }}:
+					{{- $vsb := (viewedServerBody $.Response.ServerBody .Name) }}
+		body = {{ $vsb.Init.Name }}({{ range $vsb.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+				{{- end }}
+	}
+			{{- end }}
+		{{- else }}
+	body := {{ (index .Response.ServerBody 0).Init.Name }}({{ range (index .Response.ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
+		{{- end }}
+	return goahttp.WriteNDJSON(s.w, body)
+	{{- else }}
+	return goahttp.WriteNDJSON(s.w, res)
+	{{- end }}
+{{- else }}
+	{{- if .Payload.Init }}
+	body := {{ .Payload.Init.Name }}(v)
+	return s.req.Send(body)
+	{{- else }}
+	return s.req.Send(v)
+	{{- end }}
+{{- end }}
+}
+` + ndjsonStartT
+
+	// ndjsonRecvT renders the function implementing the Recv method in
+	// stream interface.
+	// input: NDJSONData
+	ndjsonRecvT = `{{ comment .RecvDesc }}
+func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
+	var (
+		rv {{ .RecvTypeRef }}
+	{{- if eq .Type "server" }}
+		body {{ .Payload.VarName }}
+	{{- else }}
+		body {{ .Response.ClientBody.VarName }}
+	{{- end }}
+		err error
+	)
+{{- if eq .Type "server" }}
+	if s.dec == nil {
+		s.dec = json.NewDecoder(s.r.Body)
+	}
+	if err = s.dec.Decode(&body); err != nil {
+		return rv, err
+	}
+	{{- if .Payload.ValidateRef }}
+	{{ .Payload.ValidateRef }}
+	if err != nil {
+		return rv, err
+	}
+	{{- end }}
+	{{- if .Payload.Init }}
+	return {{ .Payload.Init.Name }}({{ range .Payload.Init.ServerArgs }}{{ .Ref }}{{ end }}), nil
+	{{- else }}
+	return body, nil
+	{{- end }}
+{{- else }} {{/* client side code */}}
+	{{- if eq .RecvName "CloseAndRecv" }}
+	if err = s.req.CloseSend(); err != nil {
+		return rv, err
+	}
+	{{- end }}
+	if s.dec == nil {
+	{{- if .SendTypeRef }}
+		resp, err := s.req.Response()
+		if err != nil {
+			return rv, goahttp.ErrRequestError("{{ .Endpoint.ServiceName }}", "{{ .Endpoint.Method.Name }}", err)
+		}
+		if resp.StatusCode != {{ .Response.StatusCode }} {
+			_, err = s.decode(resp)
+			return rv, err
+		}
+		{{- if .Endpoint.Method.ViewedResult }}
+			{{- if not .Endpoint.Method.ViewedResult.ViewName }}
+		s.view = resp.Header.Get("goa-view")
+			{{- end }}
+		{{- end }}
+		s.body = resp.Body
+	{{- end }}
+		s.dec = json.NewDecoder(s.body)
+	}
+	{{- if eq .RecvName "CloseAndRecv" }}
+	defer s.body.Close()
+	{{- end }}
+	err = s.dec.Decode(&body)
+	if err == io.EOF {
+	{{- if ne .RecvName "CloseAndRecv" }}
+		s.body.Close()
+	{{- end }}
+		return rv, io.EOF
+	}
+	if err != nil {
+		return rv, goahttp.ErrDecodingError("{{ .Endpoint.ServiceName }}", "{{ .Endpoint.Method.Name }}", err)
+	}
+	{{- if and .Response.ClientBody.ValidateRef (not .Endpoint.Method.ViewedResult) }}
+	{{ .Response.ClientBody.ValidateRef }}
+	if err != nil {
+		return rv, err
+	}
+	{{- end }}
+	{{- if .Response.ResultInit }}
+	res := {{ .Response.ResultInit.Name }}({{ range .Response.ResultInit.ClientArgs }}{{ .Ref }},{{ end }})
+		{{- if .Endpoint.Method.ViewedResult }}{{ with .Endpoint.Method.ViewedResult }}
+	vres := {{ if not .IsCollection }}&{{ end }}{{ .ViewsPkg }}.{{ .VarName }}{Projected: res, View: {{ if .ViewName }}{{ printf "%q" .ViewName }}{{ else }}s.view{{ end }}}
+	if err := {{ .ViewsPkg }}.Validate{{ $.Endpoint.Method.Result }}(vres); err != nil {
+		return rv, goahttp.ErrValidationError("{{ $.Endpoint.ServiceName }}", "{{ $.Endpoint.Method.Name }}", err)
+	}
+	return {{ $.PkgName }}.{{ .ResultInit.Name }}(vres){{ end }}, nil
+		{{- else }}
+	return res, nil
+		{{- end }}
+	{{- else }}
+	return body, nil
+	{{- end }}
+{{- end }}
+}
+`
+
+	// ndjsonStartT renders the code that writes the response headers the
+	// first time the server stream is used.
+	ndjsonStartT = `{{- define "ndjson_start" }}
+	{{ comment "Write the response headers only once so that authorization logic in the endpoint is executed before the stream starts." }}
+	s.once.Do(func() {
+	{{- if .FullDuplex }}
+		{{ comment "Keep reading the request body after writing the response (HTTP/1.1)." }}
+		goahttp.EnableFullDuplex(s.w) // nolint: errcheck
+	{{- end }}
+	{{- if .Endpoint.Method.ViewedResult }}
+		{{- if not .Endpoint.Method.ViewedResult.ViewName }}
+		s.w.Header().Set("goa-view", s.view)
+		{{- end }}
+	{{- end }}
+	{{- if .SendTypeRef }}
+		s.w.Header().Set("Content-Type", goahttp.NDJSONContentType)
+	{{- end }}
+		s.w.WriteHeader({{ .Response.StatusCode }})
+		s.started = true
+	})
+{{- end }}
+`
+
+	// ndjsonCloseT renders the function implementing the Close method in
+	// stream interface.
+	// input: NDJSONData
+	ndjsonCloseT = `{{- if eq .Type "server" }}
+{{- printf "Close closes the %q endpoint NDJSON response." .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) Close() error {
+	{{- template "ndjson_start" . }}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+{{- else }}
+{{- printf "Close closes the %q endpoint NDJSON request body." .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) Close() error {
+	{{- if .RecvTypeRef }}
+	return s.req.CloseSend()
+	{{- else }}
+	if err := s.req.CloseSend(); err != nil {
+		return err
+	}
+	resp, err := s.req.Response()
+	if err != nil {
+		return goahttp.ErrRequestError("{{ .Endpoint.ServiceName }}", "{{ .Endpoint.Method.Name }}", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != {{ .Response.StatusCode }} {
+		_, err = s.decode(resp)
+		return err
+	}
+	return nil
+	{{- end }}
+}
+{{- end }}
+` + ndjsonStartT
+
+	// ndjsonSetViewT renders the function implementing the SetView method in
+	// server stream interface.
+	// input: NDJSONData
+	ndjsonSetViewT = `{{ printf "SetView sets the view to render the %s type before sending to the %q endpoint NDJSON stream." .Endpoint.Result.Name .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) SetView(view string) {
+	s.view = view
+}
+`
+)
@@ -0,0 +1,52 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestServerNDJSON(t *testing.T) {
+	cases := []*testCase{
+		{"ndjson-result", testdata.NDJSONResultDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.NDJSONResultServerHandlerInitCode},
+			{"server-ndjson-send", &testdata.NDJSONResultServerNDJSONSendCode},
+			{"server-ndjson-recv", nil},
+			{"server-ndjson-close", &testdata.NDJSONResultServerNDJSONCloseCode},
+		}},
+		{"ndjson-payload", testdata.NDJSONPayloadDSL, []*sectionExpectation{
+			{"server-ndjson-send", &testdata.NDJSONPayloadServerNDJSONSendCode},
+			{"server-ndjson-recv", &testdata.NDJSONPayloadServerNDJSONRecvCode},
+			{"server-ndjson-close", nil},
+		}},
+		{"ndjson-bidirectional", testdata.NDJSONBidirectionalDSL, []*sectionExpectation{
+			{"server-ndjson-send", &testdata.NDJSONBidirectionalServerNDJSONSendCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
+
+func TestClientNDJSON(t *testing.T) {
+	cases := []*testCase{
+		{"client-ndjson-result", testdata.NDJSONResultDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.NDJSONResultClientEndpointInitCode},
+			{"client-ndjson-recv", &testdata.NDJSONResultClientNDJSONRecvCode},
+			{"client-ndjson-send", nil},
+		}},
+		{"client-ndjson-payload", testdata.NDJSONPayloadDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.NDJSONPayloadClientEndpointInitCode},
+			{"client-ndjson-send", &testdata.NDJSONPayloadClientNDJSONSendCode},
+			{"client-ndjson-recv", &testdata.NDJSONPayloadClientNDJSONRecvCode},
+			{"client-ndjson-close", nil},
+		}},
+		{"client-ndjson-bidirectional", testdata.NDJSONBidirectionalDSL, []*sectionExpectation{
+			{"client-ndjson-recv", &testdata.NDJSONBidirectionalClientNDJSONRecvCode},
+			{"client-ndjson-close", &testdata.NDJSONBidirectionalClientNDJSONCloseCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ClientFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
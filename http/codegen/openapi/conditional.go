@@ -0,0 +1,38 @@
+package openapi
+
+import (
+	"goa.design/goa/v3/expr"
+)
+
+// ConditionalHeader describes a conditional request header supported by the
+// generated HTTP servers.
+type ConditionalHeader struct {
+	// Name is the header name.
+	Name string
+	// Description is the header description.
+	Description string
+}
+
+// ConditionalHeaders returns the conditional request headers supported by the
+// given endpoint, nil if the endpoint design does not use the ETag or
+// LastModified DSLs.
+func ConditionalHeaders(e *expr.HTTPEndpointExpr) []*ConditionalHeader {
+	c := e.Conditional
+	if c == nil {
+		return nil
+	}
+	var headers []*ConditionalHeader
+	if c.ETagField != "" || c.HashETag {
+		headers = append(headers,
+			&ConditionalHeader{"If-None-Match", "Entity tags of the cached representations, the response is 304 Not Modified if one matches the current entity tag."},
+			&ConditionalHeader{"If-Match", "Entity tags the current entity tag must match, the response is 412 Precondition Failed otherwise."},
+		)
+	}
+	if c.LastModifiedField != "" {
+		headers = append(headers,
+			&ConditionalHeader{"If-Modified-Since", "HTTP date, the response is 304 Not Modified if the resource was not modified since."},
+			&ConditionalHeader{"If-Unmodified-Since", "HTTP date, the response is 412 Precondition Failed if the resource was modified since."},
+		)
+	}
+	return headers
+}
@@ -55,3 +55,41 @@ func extensionsFromExprWithPrefix(mdata expr.MetaExpr, prefix string) map[string
 	}
 	return extensions
 }
+
+// CORSExtension adds the "x-cors" extension describing the CORS policies that
+// apply to the given endpoint to extensions and returns the result. The
+// extensions are returned unchanged if no CORS policy applies.
+func CORSExtension(extensions map[string]any, e *expr.HTTPEndpointExpr) map[string]any {
+	origins := e.CORSOrigins()
+	if len(origins) == 0 {
+		return extensions
+	}
+	cors := make([]map[string]any, len(origins))
+	for i, o := range origins {
+		c := map[string]any{"origin": o.Origin}
+		if o.Regexp {
+			c["regexp"] = true
+		}
+		if len(o.Methods) > 0 {
+			c["methods"] = o.Methods
+		}
+		if len(o.Headers) > 0 {
+			c["headers"] = o.Headers
+		}
+		if len(o.Expose) > 0 {
+			c["exposeHeaders"] = o.Expose
+		}
+		if o.MaxAge > 0 {
+			c["maxAge"] = o.MaxAge
+		}
+		if o.Credentials {
+			c["credentials"] = true
+		}
+		cors[i] = c
+	}
+	if extensions == nil {
+		extensions = make(map[string]any)
+	}
+	extensions["x-cors"] = cors
+	return extensions
+}
@@ -0,0 +1,100 @@
+package openapi
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/expr"
+)
+
+// HealthProbe describes a health probe served by the generated HTTP servers.
+type HealthProbe struct {
+	// Name is the probe name used to build the operation ID, liveness
+	// or readiness.
+	Name string
+	// Path is the probe path.
+	Path string
+	// Summary is the probe summary.
+	Summary string
+	// Description is the probe description.
+	Description string
+	// Readiness is true if the probe runs the dependency checks.
+	Readiness bool
+}
+
+// HealthProbes returns the liveness and readiness probes of the given HTTP
+// service, nil if the service does not define its own health checks.
+func HealthProbes(svc *expr.HTTPServiceExpr) []*HealthProbe {
+	h := svc.ServiceExpr.Health
+	if h == nil || h.Shared() {
+		return nil
+	}
+	return healthProbes(h, fmt.Sprintf("the %s service", svc.Name()))
+}
+
+// SharedHealthProbes returns the liveness and readiness probes of the health
+// checks defined in the API, nil if no service served over HTTP inherits
+// them. The shared probes are served once for all the services.
+func SharedHealthProbes(root *expr.RootExpr) []*HealthProbe {
+	h := root.SharedHTTPHealth()
+	if h == nil {
+		return nil
+	}
+	return healthProbes(h, fmt.Sprintf("the %s API", root.API.Name))
+}
+
+// healthProbes returns the probes of h, owner describes the API or service
+// that defines the checks.
+func healthProbes(h *expr.HealthExpr, owner string) []*HealthProbe {
+	desc := "Runs the dependency checks of " + owner + "."
+	if len(h.Checks) > 0 {
+		desc = "Runs the following dependency checks:"
+		for _, c := range h.Checks {
+			desc += "\n- " + c.Name
+			if c.Description != "" {
+				desc += ": " + c.Description
+			}
+		}
+	}
+	return []*HealthProbe{{
+		Name:        "liveness",
+		Path:        h.LivenessPath,
+		Summary:     "Liveness probe of " + owner,
+		Description: "Responds with status 200 (OK) as long as the server is running.",
+	}, {
+		Name:        "readiness",
+		Path:        h.ReadinessPath,
+		Summary:     "Readiness probe of " + owner,
+		Description: desc,
+		Readiness:   true,
+	}}
+}
+
+// HealthReportSchema returns the JSON schema of the health reports written by
+// the health probes.
+func HealthReportSchema() *Schema {
+	status := func(desc string) *Schema {
+		return &Schema{
+			Type:        String,
+			Description: desc,
+			Enum:        []any{"ok", "fail"},
+		}
+	}
+	check := &Schema{
+		Type: Object,
+		Properties: map[string]*Schema{
+			"name":        {Type: String, Description: "Name of the checked dependency"},
+			"status":      status("Result of the check"),
+			"error":       {Type: String, Description: "Error returned by the check if any"},
+			"duration_ns": {Type: Integer, Description: "Duration of the check in nanoseconds"},
+		},
+		Required: []string{"name", "status", "duration_ns"},
+	}
+	return &Schema{
+		Type: Object,
+		Properties: map[string]*Schema{
+			"status": status("ok if all the checks succeeded, fail otherwise"),
+			"checks": {Type: Array, Items: check, Description: "Results of the dependency checks"},
+		},
+		Required: []string{"status"},
+	}
+}
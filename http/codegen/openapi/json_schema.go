@@ -24,6 +24,7 @@ type (
 		Description  string             `json:"description,omitempty" yaml:"description,omitempty"`
 		DefaultValue any                `json:"default,omitempty" yaml:"default,omitempty"`
 		Example      any                `json:"example,omitempty" yaml:"example,omitempty"`
+		Deprecated   bool               `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 
 		// Hyper schema
 		Media     *Media  `json:"media,omitempty" yaml:"media,omitempty"`
@@ -425,6 +426,7 @@ func (s *Schema) Dup() *Schema {
 		Schema:               s.Schema,
 		Type:                 s.Type,
 		DefaultValue:         s.DefaultValue,
+		Deprecated:           s.Deprecated,
 		Title:                s.Title,
 		Media:                s.Media,
 		ReadOnly:             s.ReadOnly,
@@ -465,6 +467,7 @@ func buildAttributeSchema(api *expr.APIExpr, s *Schema, at *expr.AttributeExpr)
 	}
 	s.DefaultValue = ToStringMap(at.DefaultValue)
 	s.Description = at.Description
+	s.Deprecated = expr.DeprecationOf(at.Meta) != nil
 	s.Example = at.Example(api.ExampleGenerator)
 	s.Extensions = ExtensionsFromExpr(at.Meta)
 	initAttributeValidation(s, at)
@@ -51,6 +51,7 @@ func (s *Schema) createMergeItems(other *Schema) mergeItems {
 		{&s.Title, other.Title, s.Title == ""},
 		{&s.Media, other.Media, s.Media == nil},
 		{&s.ReadOnly, other.ReadOnly, !s.ReadOnly},
+		{&s.Deprecated, other.Deprecated, !s.Deprecated},
 		{&s.PathStart, other.PathStart, s.PathStart == ""},
 		{&s.Enum, other.Enum, s.Enum == nil},
 		{&s.Format, other.Format, s.Format == ""},
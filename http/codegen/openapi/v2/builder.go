@@ -72,6 +72,7 @@ func NewV2(root *expr.RootExpr, h *expr.HostExpr) (*V2, error) {
 			}
 			buildPathFromFileServer(s, root, fs)
 		}
+		buildPathsFromHealth(s, root, openapi.HealthProbes(res), res.Name())
 		for _, a := range res.HTTPEndpoints {
 			if !mustGenerate(a.Meta) || !mustGenerate(a.MethodExpr.Meta) {
 				continue
@@ -81,6 +82,7 @@ func NewV2(root *expr.RootExpr, h *expr.HostExpr) (*V2, error) {
 			}
 		}
 	}
+	buildPathsFromHealth(s, root, openapi.SharedHealthProbes(root), root.API.Name)
 	if len(openapi.Definitions) > 0 {
 		s.Definitions = make(map[string]*openapi.Schema)
 		for n, d := range openapi.Definitions {
@@ -223,6 +225,9 @@ func hasAbsoluteRoutes(root *expr.RootExpr) bool {
 			hasAbsoluteRoutes = true
 			break
 		}
+		if res.ServiceExpr.Health != nil {
+			hasAbsoluteRoutes = true
+		}
 		for _, a := range res.HTTPEndpoints {
 			if !mustGenerate(a.Meta) || !mustGenerate(a.MethodExpr.Meta) {
 				continue
@@ -331,9 +336,43 @@ func paramsFromHeaders(endpoint *expr.HTTPEndpointExpr) []*Parameter {
 		})
 	}
 
+	// Add the idempotency key header of idempotent endpoints unless the
+	// design maps it explicitly.
+	if endpoint.Idempotent && !hasParam(params, "Idempotency-Key") {
+		params = append(params, &Parameter{
+			In:          "header",
+			Name:        "Idempotency-Key",
+			Description: "Unique key used to safely retry the request, the response to the first request made with the key is replayed.",
+			Type:        "string",
+		})
+	}
+
+	// Add the conditional request headers of endpoints that use the ETag
+	// or LastModified DSLs unless the design maps them explicitly.
+	for _, h := range openapi.ConditionalHeaders(endpoint) {
+		if !hasParam(params, h.Name) {
+			params = append(params, &Parameter{
+				In:          "header",
+				Name:        h.Name,
+				Description: h.Description,
+				Type:        "string",
+			})
+		}
+	}
+
 	return params
 }
 
+// hasParam returns true if params contains a parameter with the given name.
+func hasParam(params []*Parameter, name string) bool {
+	for _, p := range params {
+		if strings.EqualFold(p.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
 func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter {
 	alias := at
 	if expr.IsAlias(at.Type) {
@@ -509,6 +548,44 @@ func buildPathFromFileServer(s *V2, root *expr.RootExpr, fs *expr.HTTPFileServer
 	}
 }
 
+// buildPathsFromHealth adds the given health probes to the specification, tag
+// is the name of the API or service that defines the probes.
+func buildPathsFromHealth(s *V2, root *expr.RootExpr, probes []*openapi.HealthProbe, tag string) {
+	schemes := root.API.Schemes()
+	// remove grpc and grpcs from schemes since it is not a valid scheme in
+	// openapi.
+	for i := len(schemes) - 1; i >= 0; i-- {
+		if schemes[i] == "grpc" || schemes[i] == "grpcs" {
+			schemes = append(schemes[:i], schemes[i+1:]...)
+		}
+	}
+	for _, probe := range probes {
+		responses := map[string]*Response{
+			"200": {Description: "OK response.", Schema: openapi.HealthReportSchema()},
+		}
+		if probe.Readiness {
+			responses["503"] = &Response{Description: "At least one dependency check failed.", Schema: openapi.HealthReportSchema()}
+		}
+		operation := &Operation{
+			Summary:     probe.Summary,
+			Description: probe.Description,
+			OperationID: fmt.Sprintf("%s#%s", tag, probe.Name),
+			Produces:    []string{"application/json"},
+			Responses:   responses,
+			Schemes:     schemes,
+			Tags:        []string{tag},
+			// The probes do not require authentication.
+			Security: []map[string][]string{{}},
+		}
+		path, ok := s.Paths[probe.Path]
+		if !ok {
+			path = new(Path)
+			s.Paths[probe.Path] = path
+		}
+		path.(*Path).Get = operation
+	}
+}
+
 func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr.RouteExpr, basePath string) {
 	endpoint := route.Endpoint
 
@@ -527,7 +604,7 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 
 		responses := make(map[string]*Response, len(endpoint.Responses))
 		for _, r := range endpoint.Responses {
-			if endpoint.MethodExpr.IsStreaming() {
+			if endpoint.IsWebSocket() {
 				// A streaming endpoint allows at most one successful response
 				// definition. So it is okay to change the first successful
 				// response to a HTTP 101 response for openapi docs.
@@ -537,6 +614,18 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 				}
 			}
 			resp := responseSpecFromExpr(s, root, r, endpoint.Service.Name())
+			if endpoint.PaginationParam() != "" && r.StatusCode < 300 {
+				if resp.Headers == nil {
+					resp.Headers = make(map[string]*Header)
+				}
+				resp.Headers["Link"] = &Header{Description: "Link to the next page, omitted on the last page.", Type: "string"}
+			}
+			if endpoint.Conditional != nil && endpoint.Conditional.HashETag && r.StatusCode < 300 {
+				if resp.Headers == nil {
+					resp.Headers = make(map[string]*Header)
+				}
+				resp.Headers["ETag"] = &Header{Description: "Entity tag of the response content.", Type: "string"}
+			}
 			responses[strconv.Itoa(r.StatusCode)] = resp
 			if r.ContentType != "" {
 				foundCT := false
@@ -555,11 +644,31 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 			resp := responseSpecFromExpr(s, root, er.Response, endpoint.Service.Name())
 			responses[strconv.Itoa(er.Response.StatusCode)] = resp
 		}
+		if endpoint.Conditional != nil {
+			if route.Method == "GET" || route.Method == "HEAD" {
+				if _, ok := responses["304"]; !ok {
+					responses["304"] = &Response{Description: "Not Modified response."}
+				}
+			}
+			if _, ok := responses["412"]; !ok {
+				responses["412"] = &Response{Description: "Precondition Failed response."}
+			}
+		}
 
 		var consumes []string
 		if endpoint.MultipartRequest {
 			consumes = []string{"multipart/form-data"}
 		}
+		if endpoint.NDJSON && endpoint.StreamingBody != nil {
+			consumes = []string{expr.NDJSONContentType}
+			params = append(params, &Parameter{
+				Name:        endpoint.StreamingBody.Type.Name(),
+				In:          "body",
+				Description: endpoint.StreamingBody.Description,
+				Required:    true,
+				Schema:      openapi.AttributeTypeSchemaWithPrefix(root.API, endpoint.StreamingBody, codegen.Goify(endpoint.Service.Name(), true)),
+			})
+		}
 
 		if endpoint.Body.Type != expr.Empty {
 			in := "body"
@@ -598,7 +707,7 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 		}
 
 		// replace http with ws for streaming endpoints
-		if endpoint.MethodExpr.IsStreaming() {
+		if endpoint.IsWebSocket() {
 			for i := len(schemes) - 1; i >= 0; i-- {
 				if schemes[i] == "http" {
 					news := append([]string{"ws"}, schemes[i+1:]...)
@@ -649,8 +758,8 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 			Produces:     produces,
 			Responses:    responses,
 			Schemes:      schemes,
-			Deprecated:   false,
-			Extensions:   openapi.ExtensionsFromExpr(endpoint.MethodExpr.Meta),
+			Deprecated:   endpoint.MethodExpr.Deprecation() != nil,
+			Extensions:   openapi.CORSExtension(openapi.ExtensionsFromExpr(endpoint.MethodExpr.Meta), endpoint),
 			Security:     requirements,
 		}
 
@@ -40,6 +40,13 @@ func TestSections(t *testing.T) {
 		{"with-spaces", testdata.WithSpacesDSL},
 		{"with-map", testdata.WithMapDSL},
 		{"path-with-wildcards", testdata.PathWithWildcardDSL},
+		{"health", testdata.HealthDSL},
+		{"shared-health", testdata.SharedHealthDSL},
+		{"idempotent", testdata.IdempotentDSL},
+		{"conditional", testdata.ConditionalDSL},
+		{"conditional-hash", testdata.ConditionalHashDSL},
+		{"deprecated", testdata.DeprecatedDSL},
+		{"ndjson", testdata.NDJSONDSL},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -48,6 +48,7 @@ func New(root *expr.RootExpr) *OpenAPI {
 		security = buildSecurityRequirements(root.API.Requirements)
 		tags     = buildTags(root.API)
 	)
+	addHealthPaths(paths, openapi.SharedHealthProbes(root), root.API.Name, root.API, nil)
 
 	return &OpenAPI{
 		OpenAPI:    OpenAPIVersion,
@@ -190,10 +191,59 @@ func buildPaths(h *expr.HTTPExpr, bodies map[string]map[string]*EndpointBodies,
 				path.Get = operation
 			}
 		}
+
+		// health probes
+		addHealthPaths(paths, openapi.HealthProbes(svc), svc.Name(), api, svc.ServiceExpr.Meta)
 	}
 	return paths
 }
 
+// addHealthPaths adds the given health probes to paths. tag is the name of
+// the API or service that defines the probes and meta the service meta if
+// any.
+func addHealthPaths(paths map[string]*PathItem, probes []*openapi.HealthProbe, tag string, api *expr.APIExpr, meta expr.MetaExpr) {
+	for _, probe := range probes {
+		path, ok := paths[probe.Path]
+		if !ok {
+			path = new(PathItem)
+			paths[probe.Path] = path
+		}
+		path.Get = buildHealthOperation(probe, tag, api, meta)
+	}
+}
+
+// buildHealthOperation builds the OpenAPI Operation object for the given
+// health probe.
+func buildHealthOperation(probe *openapi.HealthProbe, tag string, api *expr.APIExpr, meta expr.MetaExpr) *Operation {
+	response := func(desc string) *ResponseRef {
+		return &ResponseRef{Value: &Response{
+			Description: &desc,
+			Content: map[string]*MediaType{
+				"application/json": {Schema: openapi.HealthReportSchema()},
+			},
+		}}
+	}
+	responses := map[string]*ResponseRef{"200": response("OK response.")}
+	if probe.Readiness {
+		responses["503"] = response("At least one dependency check failed.")
+	}
+	operationIDFormat := defaultOperationIDFormat
+	for _, m := range []expr.MetaExpr{api.Meta, meta} {
+		if v, ok := m["openapi:operationId"]; ok && len(v) > 0 {
+			operationIDFormat = v[0]
+		}
+	}
+	return &Operation{
+		OperationID: parseOperationIDTemplate(operationIDFormat, tag, probe.Name, 0),
+		Summary:     probe.Summary,
+		Description: probe.Description,
+		Responses:   responses,
+		Tags:        []string{tag},
+		// The probes do not require authentication.
+		Security: []map[string][]string{{}},
+	}
+}
+
 // buildOperation builds the OpenAPI Operation object for the given path.
 func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand *expr.ExampleGenerator) *Operation {
 	e := r.Endpoint
@@ -254,6 +304,16 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 			Content:     map[string]*MediaType{ct: mt},
 			Extensions:  openapi.ExtensionsFromExpr(e.Body.Meta),
 		}}
+	} else if e.NDJSON && e.StreamingBody != nil {
+		// The request body streams the payloads, one JSON value per line.
+		mt := &MediaType{Schema: bodies.RequestBody}
+		initExamples(mt, e.StreamingBody, rand)
+		requestBody = &RequestBodyRef{Value: &RequestBody{
+			Description: e.StreamingBody.Description,
+			Required:    true,
+			Content:     map[string]*MediaType{expr.NDJSONContentType: mt},
+			Extensions:  openapi.ExtensionsFromExpr(e.StreamingBody.Meta),
+		}}
 	}
 
 	// parameters
@@ -289,7 +349,7 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 	{
 		responses = make(map[string]*ResponseRef, len(e.Responses))
 		for _, r := range e.Responses {
-			if e.MethodExpr.IsStreaming() {
+			if e.IsWebSocket() {
 				// A streaming endpoint allows at most one successful response
 				// definition. So it is okay to change the first successful
 				// response to a HTTP 101 response for openapi docs.
@@ -302,6 +362,24 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 				}
 			}
 			resp := responseFromExpr(r, bodies.ResponseBodies, rand)
+			if e.PaginationParam() != "" && r.StatusCode < 300 {
+				if resp.Headers == nil {
+					resp.Headers = make(map[string]*HeaderRef)
+				}
+				resp.Headers["Link"] = &HeaderRef{Value: &Header{
+					Description: "Link to the next page, omitted on the last page.",
+					Schema:      &openapi.Schema{Type: openapi.String},
+				}}
+			}
+			if e.Conditional != nil && e.Conditional.HashETag && r.StatusCode < 300 {
+				if resp.Headers == nil {
+					resp.Headers = make(map[string]*HeaderRef)
+				}
+				resp.Headers["ETag"] = &HeaderRef{Value: &Header{
+					Description: "Entity tag of the response content.",
+					Schema:      &openapi.Schema{Type: openapi.String},
+				}}
+			}
 			responses[strconv.Itoa(r.StatusCode)] = &ResponseRef{Value: resp}
 		}
 		for _, er := range e.HTTPErrors {
@@ -321,6 +399,18 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 			}
 			responses[strconv.Itoa(er.Response.StatusCode)] = &ResponseRef{Value: resp}
 		}
+		if e.Conditional != nil {
+			if r.Method == "GET" || r.Method == "HEAD" {
+				if _, ok := responses["304"]; !ok {
+					desc := "Not Modified response."
+					responses["304"] = &ResponseRef{Value: &Response{Description: &desc}}
+				}
+			}
+			if _, ok := responses["412"]; !ok {
+				desc := "Precondition Failed response."
+				responses["412"] = &ResponseRef{Value: &Response{Description: &desc}}
+			}
+		}
 	}
 
 	// tag names
@@ -352,9 +442,9 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 		RequestBody:  requestBody,
 		Responses:    responses,
 		Security:     buildSecurityRequirements(e.Requirements),
-		Deprecated:   false,
+		Deprecated:   m.Deprecation() != nil,
 		ExternalDocs: openapi.DocsFromExpr(m.Docs, m.Meta),
-		Extensions:   openapi.ExtensionsFromExpr(m.Meta),
+		Extensions:   openapi.CORSExtension(openapi.ExtensionsFromExpr(m.Meta), e),
 	}
 }
 
@@ -45,6 +45,13 @@ func TestFiles(t *testing.T) {
 		{"with-tags", testdata.WithTagsDSL},
 		{"with-tags-swagger", testdata.WithTagsSwaggerDSL},
 		{"typename", testdata.TypenameDSL},
+		{"health", testdata.HealthDSL},
+		{"shared-health", testdata.SharedHealthDSL},
+		{"idempotent", testdata.IdempotentDSL},
+		{"conditional", testdata.ConditionalDSL},
+		{"conditional-hash", testdata.ConditionalHashDSL},
+		{"deprecated", testdata.DeprecatedDSL},
+		{"ndjson", testdata.NDJSONDSL},
 		// TestEndpoints
 		{"endpoint", testdata.ExtensionDSL},
 		{"endpoint-swagger", testdata.ExtensionSwaggerDSL},
@@ -52,9 +52,43 @@ func paramsFromHeadersAndCookies(endpoint *expr.HTTPEndpointExpr, rand *expr.Exa
 		return nil
 	})
 
+	// Add the idempotency key header of idempotent endpoints unless the
+	// design maps it explicitly.
+	if endpoint.Idempotent && !hasParam(params, "Idempotency-Key") {
+		params = append(params, &Parameter{
+			Name:        "Idempotency-Key",
+			In:          "header",
+			Description: "Unique key used to safely retry the request, the response to the first request made with the key is replayed.",
+			Schema:      &openapi.Schema{Type: "string"},
+		})
+	}
+
+	// Add the conditional request headers of endpoints that use the ETag
+	// or LastModified DSLs unless the design maps them explicitly.
+	for _, h := range openapi.ConditionalHeaders(endpoint) {
+		if !hasParam(params, h.Name) {
+			params = append(params, &Parameter{
+				Name:        h.Name,
+				In:          "header",
+				Description: h.Description,
+				Schema:      &openapi.Schema{Type: "string"},
+			})
+		}
+	}
+
 	return params
 }
 
+// hasParam returns true if params contains a parameter with the given name.
+func hasParam(params []*Parameter, name string) bool {
+	for _, p := range params {
+		if strings.EqualFold(p.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // paramFor converts the given attribute into a OpenAPI spec parameter.
 func paramFor(att *expr.AttributeExpr, name, in string, required bool, rand *expr.ExampleGenerator) *Parameter {
 	param := &Parameter{
@@ -63,6 +97,7 @@ func paramFor(att *expr.AttributeExpr, name, in string, required bool, rand *exp
 		Description:     att.Description,
 		AllowEmptyValue: in != "path",
 		Required:        required,
+		Deprecated:      expr.DeprecationOf(att.Meta) != nil,
 		Schema:          newSchemafier(rand).schemafy(att),
 		Extensions:      openapi.ExtensionsFromExpr(att.Meta),
 	}
@@ -89,7 +89,10 @@ func buildBodyTypes(api *expr.APIExpr) (map[string]map[string]*EndpointBodies, m
 			}
 
 			req := sf.schemafy(e.Body)
-			if e.StreamingBody != nil {
+			if e.NDJSON && e.StreamingBody != nil {
+				// The request body is the stream of payloads.
+				req = sf.schemafy(e.StreamingBody)
+			} else if e.StreamingBody != nil {
 				sreq := sf.schemafy(e.StreamingBody)
 				var note string
 				if sreq.Ref != "" {
@@ -247,6 +250,7 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 		panic(fmt.Sprintf("unknown type %T", t)) // bug
 	}
 	s.Description = attr.Description
+	s.Deprecated = expr.DeprecationOf(attr.Meta) != nil
 	if note != "" {
 		s.Description += "\n" + note
 	}
@@ -0,0 +1,25 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestClientRetry(t *testing.T) {
+	cases := []*testCase{
+		{"retry", testdata.RetryDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.RetryClientEndpointInitCode},
+		}},
+		{"retry-hedge", testdata.RetryHedgeDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.RetryHedgeClientEndpointInitCode},
+		}},
+		{"retry-idempotent-only", testdata.RetryIdempotentOnlyDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.RetryIdempotentOnlyClientEndpointInitCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ClientFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
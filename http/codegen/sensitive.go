@@ -0,0 +1,82 @@
+package codegen
+
+import (
+	"sort"
+
+	"goa.design/goa/v3/expr"
+)
+
+// sensitiveNames returns the names of the body fields, headers, cookies and
+// query parameters of the endpoint that hold sensitive values. The generated
+// handlers and clients store the names in the request context so that the
+// debug middleware and debug doer may redact the values.
+func sensitiveNames(e *expr.HTTPEndpointExpr) []string {
+	names := make(map[string]struct{})
+	seen := make(map[string]struct{})
+	add := func(n string) { names[n] = struct{}{} }
+	m := e.MethodExpr
+	collectSensitiveNames(m.Payload, add, seen)
+	collectSensitiveNames(m.StreamingPayload, add, seen)
+	collectSensitiveNames(m.Result, add, seen)
+	for _, er := range m.Errors {
+		collectSensitiveNames(er.AttributeExpr, add, seen)
+	}
+	if m.Payload.HasTag("security:password") {
+		add("Authorization")
+	}
+	mapped := []*expr.MappedAttributeExpr{e.Params, e.Headers, e.Cookies}
+	for _, r := range e.Responses {
+		mapped = append(mapped, r.Headers, r.Cookies)
+	}
+	for _, ma := range mapped {
+		if ma == nil {
+			continue
+		}
+		obj := expr.AsObject(ma.Type)
+		if obj == nil {
+			continue
+		}
+		for _, nat := range *obj {
+			if nat.Attribute.IsSensitive() || m.Payload.Find(nat.Name).IsSensitive() || m.Result.Find(nat.Name).IsSensitive() {
+				add(ma.ElemName(nat.Name))
+			}
+		}
+	}
+	res := make([]string, 0, len(names))
+	for n := range names {
+		res = append(res, n)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// collectSensitiveNames calls add with the names of the sensitive attributes
+// found in att.
+func collectSensitiveNames(att *expr.AttributeExpr, add func(string), seen map[string]struct{}) {
+	if att == nil {
+		return
+	}
+	switch dt := att.Type.(type) {
+	case expr.UserType:
+		if _, ok := seen[dt.ID()]; ok {
+			return
+		}
+		seen[dt.ID()] = struct{}{}
+		collectSensitiveNames(dt.Attribute(), add, seen)
+	case *expr.Object:
+		for _, nat := range *dt {
+			if nat.Attribute.IsSensitive() {
+				add(nat.Name)
+			}
+			collectSensitiveNames(nat.Attribute, add, seen)
+		}
+	case *expr.Array:
+		collectSensitiveNames(dt.ElemType, add, seen)
+	case *expr.Map:
+		collectSensitiveNames(dt.ElemType, add, seen)
+	case *expr.Union:
+		for _, nat := range dt.Values {
+			collectSensitiveNames(nat.Attribute, add, seen)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package codegen
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/codegentest"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestSensitiveNames(t *testing.T) {
+	RunHTTPDSL(t, testdata.SensitiveDSL)
+	expected := []string{"X-API-Key", "access_token", "email", "key", "number", "token"}
+	got := sensitiveNames(expr.Root.API.HTTP.Services[0].HTTPEndpoints[0])
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+}
+
+func TestServerHandlerInitSensitive(t *testing.T) {
+	RunHTTPDSL(t, testdata.SensitiveDSL)
+	fs := ServerFiles("gen", expr.Root)
+	sections := codegentest.Sections(fs, filepath.Join("", "server.go"), "server-handler-init")
+	if len(sections) == 0 {
+		t.Fatalf("section server-handler-init missing from /server.go")
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.SensitiveServerHandlerInitCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.SensitiveServerHandlerInitCode))
+	}
+}
+
+func TestClientEndpointInitSensitive(t *testing.T) {
+	RunHTTPDSL(t, testdata.SensitiveDSL)
+	fs := ClientFiles("gen", expr.Root)
+	sections := codegentest.Sections(fs, filepath.Join("", "client.go"), "client-endpoint-init")
+	if len(sections) == 0 {
+		t.Fatalf("section client-endpoint-init missing from /client.go")
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.SensitiveClientEndpointInitCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.SensitiveClientEndpointInitCode))
+	}
+}
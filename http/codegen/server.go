@@ -19,6 +19,12 @@ func ServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 		if f := websocketServerFile(genpkg, svc); f != nil {
 			files = append(files, f)
 		}
+		if f := sseServerFile(genpkg, svc); f != nil {
+			files = append(files, f)
+		}
+		if f := ndjsonServerFile(genpkg, svc); f != nil {
+			files = append(files, f)
+		}
 	}
 	for _, svc := range root.API.HTTP.Services {
 		if f := serverEncodeDecodeFile(genpkg, svc); f != nil {
@@ -38,10 +44,17 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		"join":                    strings.Join,
 		"hasWebSocket":            hasWebSocket,
 		"isWebSocketEndpoint":     isWebSocketEndpoint,
+		"webSocketConfig":         webSocketConfig,
+		"isSSEEndpoint":           isSSEEndpoint,
+		"isNDJSONEndpoint":        isNDJSONEndpoint,
+		"isStreamingEndpoint":     isStreamingEndpoint,
 		"viewedServerBody":        viewedServerBody,
 		"mustDecodeRequest":       mustDecodeRequest,
 		"addLeadingSlash":         addLeadingSlash,
 		"removeTrailingIndexHTML": removeTrailingIndexHTML,
+		"hasCORS":                 hasCORS,
+		"hasHealth":               hasHealth,
+		"hasIdempotent":           hasIdempotent,
 	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header(title, "server", []*codegen.ImportSpec{
@@ -52,7 +65,10 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 			{Path: "mime/multipart"},
 			{Path: "net/http"},
 			{Path: "path"},
+			{Path: "regexp"},
+			{Path: "strconv"},
 			{Path: "strings"},
+			{Path: "time"},
 			{Path: "github.com/gorilla/websocket"},
 			codegen.GoaImport(""),
 			codegen.GoaNamedImport("http", "goahttp"),
@@ -61,7 +77,7 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		}),
 	}
 
-	sections = append(sections, &codegen.SectionTemplate{Name: "server-struct", Source: serverStructT, Data: data})
+	sections = append(sections, &codegen.SectionTemplate{Name: "server-struct", Source: serverStructT, Data: data, FuncMap: funcs})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-mountpoint", Source: mountPointStructT, Data: data})
 
 	for _, e := range data.Endpoints {
@@ -76,7 +92,7 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-init", Source: serverInitT, Data: data, FuncMap: funcs})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-service", Source: serverServiceT, Data: data})
-	sections = append(sections, &codegen.SectionTemplate{Name: "server-use", Source: serverUseT, Data: data})
+	sections = append(sections, &codegen.SectionTemplate{Name: "server-use", Source: serverUseT, Data: data, FuncMap: funcs})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-method-names", Source: serverMethodNamesT, Data: data})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-mount", Source: serverMountT, Data: data, FuncMap: funcs})
 
@@ -87,6 +103,9 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 	for _, s := range data.FileServers {
 		sections = append(sections, &codegen.SectionTemplate{Name: "server-files", Source: fileServerT, FuncMap: funcs, Data: s})
 	}
+	sections = append(sections, corsSections(data)...)
+	sections = append(sections, healthSections(data)...)
+	sections = append(sections, idempotencySections(data)...)
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
@@ -118,7 +137,7 @@ func serverEncodeDecodeFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.F
 	sections := []*codegen.SectionTemplate{codegen.Header(title, "server", imports)}
 
 	for _, e := range data.Endpoints {
-		if e.Redirect == nil && !isWebSocketEndpoint(e) {
+		if e.Redirect == nil && !isStreamingEndpoint(e) {
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:    "response-encoder",
 				FuncMap: transTmplFuncs(svc),
@@ -277,6 +296,16 @@ type {{ .ServerStruct }} struct {
 	{{- range .FileServers }}
 	{{ .VarName }} http.Handler
 	{{- end }}
+	{{- if hasCORS . }}
+	CORS http.Handler
+	{{- end }}
+	{{- if hasHealth . }}
+	Liveness http.Handler
+	Readiness http.Handler
+	{{- end }}
+	{{- if hasIdempotent . }}
+	idempotency *goahttp.Idempotency
+	{{- end }}
 }
 `
 
@@ -324,6 +353,9 @@ func {{ .ServerInit }}(
 		{{ .ArgName }} = http.Dir(".")
 	}
 	{{- end }}
+	{{- if hasIdempotent . }}
+	idempotency := goahttp.NewIdempotency(nil)
+	{{- end }}
 	return &{{ .ServerStruct }}{
 		Mounts: []*{{ .MountPointStruct }}{
 			{{- range $e := .Endpoints }}
@@ -337,13 +369,30 @@ func {{ .ServerInit }}(
 			{"{{ $filepath }}", "GET", "{{ . }}"},
 				{{- end }}
 			{{- end }}
+			{{- range .CORSPreflights }}
+			{"CORS", "OPTIONS", "{{ .Path }}"},
+			{{- end }}
+			{{- if hasHealth . }}
+			{"Liveness", "GET", "{{ .Service.Health.LivenessPath }}"},
+			{"Readiness", "GET", "{{ .Service.Health.ReadinessPath }}"},
+			{{- end }}
 		},
 		{{- range .Endpoints }}
-		{{ .Method.VarName }}: {{ .HandlerInit }}(e.{{ .Method.VarName }}, mux, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}){{ else }}decoder{{ end }}, encoder, errhandler, formatter{{ if isWebSocketEndpoint . }}, upgrader, configurer.{{ .Method.VarName }}Fn{{ end }}),
+		{{ .Method.VarName }}: {{ if .Deprecated }}goahttp.HandleDeprecated({{ end }}{{ if .Idempotent }}idempotency.Handler({{ printf "%s.%s" $.Service.Name .Method.Name | printf "%q" }}, {{ end }}{{ if .Conditional }}goahttp.HandleConditional({{ end }}{{ .HandlerInit }}(e.{{ .Method.VarName }}, mux, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}){{ else }}decoder{{ end }}, encoder, errhandler, formatter{{ if isWebSocketEndpoint . }}, upgrader, configurer.{{ .Method.VarName }}Fn{{ end }}){{ if .Conditional }}, {{ if .HashETag }}goahttp.HashETag{{ else }}nil{{ end }}){{ end }}{{ if .Idempotent }}, goahttp.ErrorEncoder(encoder, formatter), errhandler){{ end }}{{ if .Deprecated }}, {{ printf "%q" .Deprecation }}, {{ printf "%q" .Sunset }}){{ end }},
 		{{- end }}
 		{{- range .FileServers }}
 		{{ .VarName }}: http.FileServer({{ .ArgName }}),
 		{{- end }}
+		{{- if hasCORS . }}
+		CORS: NewCORSHandler(),
+		{{- end }}
+		{{- if hasHealth . }}
+		Liveness: goahttp.HandleLiveness(),
+		Readiness: goahttp.HandleReadiness({{ if .Service.Health.Checks }}e.Health...{{ end }}),
+		{{- end }}
+		{{- if hasIdempotent . }}
+		idempotency: idempotency,
+		{{- end }}
 	}
 }
 `
@@ -364,6 +413,9 @@ func (s *{{ .ServerStruct }}) Use(m func(http.Handler) http.Handler) {
 {{- range .Endpoints }}
 	s.{{ .Method.VarName }} = m(s.{{ .Method.VarName }})
 {{- end }}
+{{- if hasCORS . }}
+	s.CORS = m(s.CORS)
+{{- end }}
 }
 `
 
@@ -386,6 +438,13 @@ func {{ .MountServer }}(mux goahttp.Muxer, h *{{ .ServerStruct }}) {
 	{{ .MountHandler }}(mux, {{ range .RequestPaths }}{{if ne . $filepath }}goahttp.Replace("", "{{ $filepath }}", {{ end }}{{ end }}h.{{ .VarName }}){{ range .RequestPaths }}{{ if ne . $filepath }}){{ end}}{{ end }}
 		{{- end }}
 	{{- end }}
+	{{- if hasCORS . }}
+	MountCORSHandler(mux, h.CORS)
+	{{- end }}
+	{{- if hasHealth . }}
+	MountLivenessHandler(mux, h.Liveness)
+	MountReadinessHandler(mux, h.Readiness)
+	{{- end }}
 }
 
 {{ printf "%s configures the mux to serve the %s endpoints." .MountServer .Service.Name | comment }}
@@ -397,17 +456,25 @@ func (s *{{ .ServerStruct }}) {{ .MountServer }}(mux goahttp.Muxer) {
 // input: EndpointData
 const serverHandlerT = `{{ printf "%s configures the mux to serve the %q service %q endpoint." .MountHandler .ServiceName .Method.Name | comment }}
 func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
+	{{- if .CORS }}
+	f := goahttp.HandleCORS(h,
+		{{- range .CORS }}
+		{{ template "cors_origin" . }},
+		{{- end }}
+	)
+	{{- else }}
 	f, ok := h.(http.HandlerFunc)
 	if !ok {
 		f = func(w http.ResponseWriter, r *http.Request) {
 			h.ServeHTTP(w, r)
 		}
 	}
+	{{- end }}
 	{{- range .Routes }}
 	mux.Handle("{{ .Verb }}", "{{ .Path }}", f)
 	{{- end }}
 }
-`
+` + corsOriginT
 
 // input: FileServerData
 const fileServerT = `{{ printf "%s configures the mux to serve GET request made to %q." .MountHandler (join .RequestPaths ", ") | comment }}
@@ -439,25 +506,31 @@ func {{ .HandlerInit }}(
 	configurer goahttp.ConnConfigureFunc,
 	{{- end }}
 ) http.Handler {
-	{{- if (or (mustDecodeRequest .) (not (or .Redirect (isWebSocketEndpoint .))) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
+	{{- if (or (mustDecodeRequest .) (not (or .Redirect (isStreamingEndpoint .))) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
 	var (
 	{{- end }}
 		{{- if mustDecodeRequest . }}
 		decodeRequest  = {{ .RequestDecoder }}(mux, decoder)
 		{{- end }}
-		{{- if not (or .Redirect (isWebSocketEndpoint .)) }}
+		{{- if not (or .Redirect (isStreamingEndpoint .)) }}
 		encodeResponse = {{ .ResponseEncoder }}(encoder)
 		{{- end }}
 		{{- if (or (mustDecodeRequest .) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
 		encodeError    = {{ if .Errors }}{{ .ErrorEncoder }}{{ else }}goahttp.ErrorEncoder{{ end }}(encoder, formatter)
 		{{- end }}
-	{{- if (or (mustDecodeRequest .) (not (or .Redirect (isWebSocketEndpoint .))) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
+	{{- if (or (mustDecodeRequest .) (not (or .Redirect (isStreamingEndpoint .))) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
 	)
 	{{- end }}
+	{{- if and (isWebSocketEndpoint .) .ServerWebSocket.Config }}
+	configurer = goahttp.ConfigureWebSocket({{ webSocketConfig .ServerWebSocket.Config }}, configurer)
+	{{- end }}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+	{{- if .SensitiveNames }}
+		ctx = goa.WithSensitive(ctx, {{ range $i, $n := .SensitiveNames }}{{ if $i }}, {{ end }}{{ printf "%q" $n }}{{ end }})
+	{{- end }}
 
 	{{- if mustDecodeRequest . }}
 		{{ if .Redirect }}_{{ else }}payload{{ end }}, err := decodeRequest(r)
@@ -486,6 +559,22 @@ func {{ .HandlerInit }}(
 		{{- end }}
 		}
 		_, err = endpoint(ctx, v)
+	{{- else if isSSEEndpoint . }}
+		v := &{{ .ServicePkgName }}.{{ .Method.ServerStream.EndpointStruct }}{
+			Stream: &{{ .ServerSSE.VarName }}{w: w},
+		{{- if .Payload.Ref }}
+			Payload: payload.({{ .Payload.Ref }}),
+		{{- end }}
+		}
+		_, err = endpoint(ctx, v)
+	{{- else if isNDJSONEndpoint . }}
+		v := &{{ .ServicePkgName }}.{{ .Method.ServerStream.EndpointStruct }}{
+			Stream: &{{ .ServerNDJSON.VarName }}{w: w{{ if .ServerNDJSON.RecvTypeRef }}, r: r{{ end }}},
+		{{- if .Payload.Ref }}
+			Payload: payload.({{ .Payload.Ref }}),
+		{{- end }}
+		}
+		_, err = endpoint(ctx, v)
 	{{- else if .Method.SkipRequestBodyEncodeDecode }}
 		data := &{{ .ServicePkgName }}.{{ .Method.RequestStruct }}{ {{ if .Payload.Ref }}Payload: payload.({{ .Payload.Ref }}), {{ end }}Body: r.Body }
 		res, err := endpoint(ctx, data)
@@ -502,6 +591,18 @@ func {{ .HandlerInit }}(
 				errhandler(ctx, w, err)
 				return
 			}
+			{{- else if isSSEEndpoint . }}
+			if v.Stream.(*{{ .ServerSSE.VarName }}).started {
+				// Response headers have already been written, do not encode the error
+				errhandler(ctx, w, err)
+				return
+			}
+			{{- else if isNDJSONEndpoint . }}
+			if v.Stream.(*{{ .ServerNDJSON.VarName }}).started {
+				// Response headers have already been written, do not encode the error
+				errhandler(ctx, w, err)
+				return
+			}
 			{{- end }}
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
@@ -521,7 +622,10 @@ func {{ .HandlerInit }}(
 			return
 		}
 	{{- end }}
-	{{- if not (or .Redirect (isWebSocketEndpoint .)) }}
+	{{- if .PaginationParam }}
+		{{- template "pagination_link" . }}
+	{{- end }}
+	{{- if not (or .Redirect (isStreamingEndpoint .)) }}
 		if err := encodeResponse(ctx, w, {{ if and .Method.SkipResponseBodyEncodeDecode .Result.Ref }}o.Result{{ else }}res{{ end }}); err != nil {
 			errhandler(ctx, w, err)
 			{{- if .Method.SkipResponseBodyEncodeDecode }}
@@ -539,6 +643,39 @@ func {{ .HandlerInit }}(
 	{{- end }}
 	})
 }
+` + paginationLinkT
+
+// input: EndpointData
+const paginationLinkT = `{{ define "pagination_link" }}
+		if pr, ok := res.({{ .Result.Ref }}); ok {
+	{{- with .Method.Pagination }}
+		{{- if .Cursor }}
+			{{- if .NextCursorPointer }}
+			if pr.{{ .NextCursorField }} != nil && *pr.{{ .NextCursorField }} != "" {
+				w.Header().Set("Link", goahttp.NextPageLink(r, {{ printf "%q" $.PaginationParam }}, *pr.{{ .NextCursorField }}))
+			}
+			{{- else }}
+			if pr.{{ .NextCursorField }} != "" {
+				w.Header().Set("Link", goahttp.NextPageLink(r, {{ printf "%q" $.PaginationParam }}, pr.{{ .NextCursorField }}))
+			}
+			{{- end }}
+		{{- else }}
+			p := payload.({{ $.Payload.Ref }})
+			if n := len(pr.{{ .ItemsField }}); n > 0{{ if .LimitField }} && ({{ if .LimitPointer }}p.{{ .LimitField }} == nil || n >= *p.{{ .LimitField }}{{ else }}n >= p.{{ .LimitField }}{{ end }}){{ end }} {
+				offset := n
+			{{- if .OffsetPointer }}
+				if p.{{ .OffsetField }} != nil {
+					offset += *p.{{ .OffsetField }}
+				}
+			{{- else }}
+				offset += p.{{ .OffsetField }}
+			{{- end }}
+				w.Header().Set("Link", goahttp.NextPageLink(r, {{ printf "%q" $.PaginationParam }}, strconv.Itoa(offset)))
+			}
+		{{- end }}
+	{{- end }}
+		}
+{{- end }}
 `
 
 // input: TransformFunctionData
@@ -18,8 +18,17 @@ func ServerTypeFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 }
 
 // serverType return the file containing the type definitions used by the HTTP
-// transport for the given service server. seen keeps track of the names of the
-// types that have already been generated to prevent duplicate code generation.
+// transport for the given service server.
+func serverType(genpkg string, svc *expr.HTTPServiceExpr, _ map[string]struct{}) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	path := filepath.Join(codegen.Gendir, "http", data.Service.PathName, "server", "types.go")
+	return ServerTypeFile(genpkg, svc, data, path, svc.Name()+" HTTP server types")
+}
+
+// ServerTypeFile returns the file with the given path and title containing the
+// server type definitions computed in data for the given service. It makes it
+// possible for transports built on top of the HTTP transport data such as
+// JSON-RPC to generate their types.
 //
 // Below are the rules governing whether values are pointers or not. Note that
 // the rules only applies to values that hold primitive types, values that hold
@@ -41,13 +50,8 @@ func ServerTypeFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 //
 //   - Response body fields (if the body is a struct) and header variables hold
 //     pointers when not required and have no default value.
-func serverType(genpkg string, svc *expr.HTTPServiceExpr, _ map[string]struct{}) *codegen.File {
-	var (
-		path    string
-		data    = HTTPServices.Get(svc.Name())
-		svcName = data.Service.PathName
-	)
-	path = filepath.Join(codegen.Gendir, "http", svcName, "server", "types.go")
+func ServerTypeFile(genpkg string, svc *expr.HTTPServiceExpr, data *ServiceData, path, title string) *codegen.File {
+	svcName := data.Service.PathName
 	imports := []*codegen.ImportSpec{
 		{Path: "encoding/json"},
 		{Path: "unicode/utf8"},
@@ -56,7 +60,7 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, _ map[string]struct{})
 		{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
 	}
 	imports = append(imports, data.Service.UserTypeImports...)
-	header := codegen.Header(svc.Name()+" HTTP server types", "server", imports)
+	header := codegen.Header(title, "server", imports)
 
 	var (
 		initData       []*InitData
@@ -80,18 +84,16 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, _ map[string]struct{})
 				validatedTypes = append(validatedTypes, data)
 			}
 		}
-		if adata.ServerWebSocket != nil {
-			if data := adata.ServerWebSocket.Payload; data != nil {
-				if data.Def != "" {
-					sections = append(sections, &codegen.SectionTemplate{
-						Name:   "request-stream-payload-type-decl",
-						Source: typeDeclT,
-						Data:   data,
-					})
-				}
-				if data.ValidateDef != "" {
-					validatedTypes = append(validatedTypes, data)
-				}
+		if data := serverStreamingPayload(adata); data != nil {
+			if data.Def != "" {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "request-stream-payload-type-decl",
+					Source: typeDeclT,
+					Data:   data,
+				})
+			}
+			if data.ValidateDef != "" {
+				validatedTypes = append(validatedTypes, data)
 			}
 		}
 	}
@@ -179,8 +181,8 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, _ map[string]struct{})
 				FuncMap: map[string]any{"fieldCode": fieldCode},
 			})
 		}
-		if isWebSocketEndpoint(adata) && adata.ServerWebSocket.Payload != nil {
-			if init := adata.ServerWebSocket.Payload.Init; init != nil {
+		if data := serverStreamingPayload(adata); data != nil {
+			if init := data.Init; init != nil {
 				sections = append(sections, &codegen.SectionTemplate{
 					Name:    "server-payload-init",
 					Source:  serverTypeInitT,
@@ -46,6 +46,9 @@ type (
 		Endpoints []*EndpointData
 		// FileServers lists the file servers for this service.
 		FileServers []*FileServerData
+		// CORSPreflights lists the paths that serve CORS preflight
+		// requests.
+		CORSPreflights []*CORSPreflightData
 		// ServerStruct is the name of the HTTP server struct.
 		ServerStruct string
 		// MountPointStruct is the name of the mount point struct.
@@ -124,6 +127,9 @@ type (
 		// Requirements contains the security requirements for the
 		// method.
 		Requirements service.RequirementsData
+		// SensitiveNames lists the names of the body fields, headers,
+		// cookies and parameters that hold sensitive values.
+		SensitiveNames []string
 
 		// server
 
@@ -144,6 +150,12 @@ type (
 		// ServerWebSocket holds the data to render the server struct which
 		// implements the server stream interface.
 		ServerWebSocket *WebSocketData
+		// ServerSSE holds the data to render the server struct which
+		// implements the server stream interface using Server-Sent Events.
+		ServerSSE *SSEData
+		// ServerNDJSON holds the data to render the server struct which
+		// implements the server stream interface using NDJSON.
+		ServerNDJSON *NDJSONData
 		// Redirect defines a redirect for the endpoint.
 		Redirect *RedirectData
 
@@ -166,9 +178,44 @@ type (
 		// ClientWebSocket holds the data to render the client struct which
 		// implements the client stream interface.
 		ClientWebSocket *WebSocketData
+		// ClientSSE holds the data to render the client struct which
+		// implements the client stream interface using Server-Sent Events.
+		ClientSSE *SSEData
+		// ClientNDJSON holds the data to render the client struct which
+		// implements the client stream interface using NDJSON.
+		ClientNDJSON *NDJSONData
 		// BuildStreamPayload is the name of the function used to create the
 		// payload for endpoints that use SkipRequestBodyEncodeDecode.
 		BuildStreamPayload string
+		// Retry contains the data needed to generate the client retry
+		// policy if any.
+		Retry *service.RetryData
+		// CORS lists the CORS policies that apply to the endpoint if
+		// any.
+		CORS []*CORSData
+		// PaginationParam is the name of the query string parameter that
+		// holds the page cursor or offset if the endpoint is paginated.
+		// The server sets the "Link" header of the responses using the
+		// parameter.
+		PaginationParam string
+		// Idempotent is true if the endpoint honors the "Idempotency-Key"
+		// request header.
+		Idempotent bool
+		// Conditional is true if the endpoint supports conditional
+		// requests.
+		Conditional bool
+		// HashETag is true if the entity tag of the endpoint responses is
+		// computed from the hash of the encoded response body.
+		HashETag bool
+		// Deprecated is true if the endpoint method or service is
+		// deprecated, the server sets the "Deprecation" response header.
+		Deprecated bool
+		// Deprecation is the value of the "Deprecation" response header
+		// set by the server if the deprecation date is known.
+		Deprecation string
+		// Sunset is the value of the "Sunset" response header set by the
+		// server if any.
+		Sunset string
 	}
 
 	// FileServerData lists the data needed to generate file servers.
@@ -563,8 +610,19 @@ func (d ServicesData) Get(name string) *ServiceData {
 	if service == nil {
 		return nil
 	}
-	d[name] = d.analyze(service)
-	return d[name]
+	return d.GetService(service)
+}
+
+// GetService retrieves the transport data for the given service computing it
+// if needed. Contrary to Get the service does not have to be part of the HTTP
+// API expression, this makes it possible to compute the data of the JSON-RPC
+// services.
+func (d ServicesData) GetService(hs *expr.HTTPServiceExpr) *ServiceData {
+	if data, ok := d[hs.Name()]; ok {
+		return data
+	}
+	d[hs.Name()] = d.analyze(hs)
+	return d[hs.Name()]
 }
 
 // Endpoint returns the service method transport data for the endpoint with the
@@ -790,7 +848,7 @@ func (ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 				"Args":         args,
 				"PathInit":     routes[0].PathInit,
 				"Verb":         routes[0].Verb,
-				"IsStreaming":  a.MethodExpr.IsStreaming(),
+				"IsStreaming":  a.IsWebSocket(),
 			}
 			if a.SkipRequestBodyEncodeDecode {
 				data["RequestStruct"] = pkg + "." + ep.RequestStruct
@@ -832,8 +890,13 @@ func (ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			RequestEncoder:  requestEncoder,
 			ResponseDecoder: fmt.Sprintf("Decode%sResponse", ep.VarName),
 			Requirements:    reqs,
+			SensitiveNames:  sensitiveNames(a),
 		}
-		if a.MethodExpr.IsStreaming() {
+		if a.SSE != nil {
+			initSSEData(ad, a, rd)
+		} else if a.NDJSON {
+			initNDJSONData(ad, a, rd)
+		} else if a.MethodExpr.IsStreaming() {
 			initWebSocketData(ad, a, rd)
 		}
 
@@ -860,6 +923,12 @@ func (ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			ad.BuildStreamPayload = scope.Unique("Build" + codegen.Goify(ep.Name, true) + "StreamPayload")
 		}
 
+		if r := ep.Retry; r != nil && !a.SkipRequestBodyEncodeDecode {
+			if !r.IdempotentOnly || a.Idempotent || isIdempotentMethod(a.Routes[0].Method) {
+				ad.Retry = r
+			}
+		}
+
 		if a.Redirect != nil {
 			ad.Redirect = &RedirectData{
 				URL:        a.Redirect.URL,
@@ -867,8 +936,27 @@ func (ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			}
 		}
 
+		initCORSData(ad, a)
+
+		ad.PaginationParam = a.PaginationParam()
+		ad.Idempotent = a.Idempotent
+		if c := a.Conditional; c != nil {
+			ad.Conditional = true
+			ad.HashETag = c.HashETag
+		}
+		if d := a.MethodExpr.Deprecation(); d != nil {
+			ad.Deprecated = true
+			if !d.Date.IsZero() {
+				ad.Deprecation = fmt.Sprintf("@%d", d.Date.Unix())
+			}
+			if !d.Sunset.IsZero() {
+				ad.Sunset = d.Sunset.Format(http.TimeFormat)
+			}
+		}
+
 		rd.Endpoints = append(rd.Endpoints, ad)
 	}
+	rd.CORSPreflights = buildCORSPreflights(hs)
 
 	for _, a := range hs.HTTPEndpoints {
 		collectUserTypes(a.Body.Type, func(ut expr.UserType) {
@@ -1874,10 +1962,10 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 				clientBodyData = buildResponseBodyType(v.Response.Body, v.ErrorExpr.AttributeExpr, errorLoc, e, false, nil, sd)
 				if clientBodyData != nil {
 					sd.ClientTypeNames[clientBodyData.Name] = false
-					clientBodyData.Description = fmt.Sprintf("%s is the type of the %q service %q endpoint HTTP response body for the %q error.",
-						clientBodyData.VarName, svc.Name, e.Name(), v.Name)
-					serverBodyData[0].Description = fmt.Sprintf("%s is the type of the %q service %q endpoint HTTP response body for the %q error.",
-						serverBodyData[0].VarName, svc.Name, e.Name(), v.Name)
+					clientBodyData.Description = fmt.Sprintf("%s is the type of the %q service %q endpoint %s for the %q error.",
+						clientBodyData.VarName, svc.Name, e.Name(), errorBodyDesc(e), v.Name)
+					serverBodyData[0].Description = fmt.Sprintf("%s is the type of the %q service %q endpoint %s for the %q error.",
+						serverBodyData[0].VarName, svc.Name, e.Name(), errorBodyDesc(e), v.Name)
 				}
 			}
 
@@ -1994,8 +2082,8 @@ func buildRequestBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointExp
 		if ut, ok := body.Type.(expr.UserType); ok {
 			varname = codegen.Goify(ut.Name(), true)
 			def = goTypeDef(sd.Scope, ut.Attribute(), svr, !svr)
-			desc = fmt.Sprintf("%s is the type of the %q service %q endpoint HTTP request body.",
-				varname, svc.Name, e.Name())
+			desc = fmt.Sprintf("%s is the type of the %q service %q endpoint %s.",
+				varname, svc.Name, e.Name(), requestBodyDesc(e))
 			if svr {
 				// generate validation code for unmarshaled type (server-side).
 				validateDef = codegen.ValidationCode(ut.Attribute(), ut, httpctx, true, expr.IsAlias(ut), "body")
@@ -2033,8 +2121,8 @@ func buildRequestBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointExp
 			)
 			{
 				name = fmt.Sprintf("New%s", codegen.Goify(sd.Scope.GoTypeName(body), true))
-				desc = fmt.Sprintf("%s builds the HTTP request body from the payload of the %q endpoint of the %q service.",
-					name, e.Name(), svc.Name)
+				desc = fmt.Sprintf("%s builds the %s from the payload of the %q endpoint of the %q service.",
+					name, requestBodyDesc(e), e.Name(), svc.Name)
 				src := sourceVar
 				srcAtt := att
 				// If design uses Body("name") syntax then need to use payload attribute
@@ -2144,8 +2232,8 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, loc *codegen.Location,
 			// response body is a user type.
 			varname = codegen.Goify(ut.Name(), true)
 			def = goTypeDef(sd.Scope, ut.Attribute(), !svr, svr)
-			desc = fmt.Sprintf("%s is the type of the %q service %q endpoint HTTP response body.",
-				varname, svc.Name, e.Name())
+			desc = fmt.Sprintf("%s is the type of the %q service %q endpoint %s.",
+				varname, svc.Name, e.Name(), responseBodyDesc(e))
 			if !svr && view == nil {
 				// generate validation code for unmarshaled type (client-side).
 				validateDef = codegen.ValidationCode(body, ut, httpctx, true, expr.IsAlias(body.Type), "body")
@@ -2162,8 +2250,8 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, loc *codegen.Location,
 			// response body is an array or map type.
 			name = codegen.Goify(e.Name(), true) + "ResponseBody"
 			varname = name
-			desc = fmt.Sprintf("%s is the type of the %q service %q endpoint HTTP response body.",
-				varname, svc.Name, e.Name())
+			desc = fmt.Sprintf("%s is the type of the %q service %q endpoint %s.",
+				varname, svc.Name, e.Name(), responseBodyDesc(e))
 			def = goTypeDef(sd.Scope, body, !svr, svr)
 			validateRef = codegen.ValidationCode(body, nil, httpctx, true, expr.IsAlias(body.Type), "body")
 		} else {
@@ -2215,8 +2303,8 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, loc *codegen.Location,
 					rtref = sd.Scope.GoTypeRef(body)
 				}
 				name = fmt.Sprintf("New%s", rtname)
-				desc = fmt.Sprintf("%s builds the HTTP response body from the result of the %q endpoint of the %q service.",
-					name, e.Name(), svc.Name)
+				desc = fmt.Sprintf("%s builds the %s from the result of the %q endpoint of the %q service.",
+					name, responseBodyDesc(e), e.Name(), svc.Name)
 				if view != nil {
 					svcctx = viewContext(sd.Service.ViewsPkg, sd.Service.ViewScope)
 				}
@@ -2279,6 +2367,31 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, loc *codegen.Location,
 	}
 }
 
+// requestBodyDesc describes the request body of e in generated comments.
+func requestBodyDesc(e *expr.HTTPEndpointExpr) string {
+	if e.Service.IsJSONRPC() {
+		return "JSON-RPC request params"
+	}
+	return "HTTP request body"
+}
+
+// responseBodyDesc describes the response body of e in generated comments.
+func responseBodyDesc(e *expr.HTTPEndpointExpr) string {
+	if e.Service.IsJSONRPC() {
+		return "JSON-RPC response result"
+	}
+	return "HTTP response body"
+}
+
+// errorBodyDesc describes the error response body of e in generated
+// comments.
+func errorBodyDesc(e *expr.HTTPEndpointExpr) string {
+	if e.Service.IsJSONRPC() {
+		return "JSON-RPC error data"
+	}
+	return "HTTP response body"
+}
+
 func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, scope *codegen.NameScope) []*ParamData {
 	var params []*ParamData
 	codegen.WalkMappedAttr(a, func(name, elem string, _ bool, c *expr.AttributeExpr) error { // nolint: errcheck
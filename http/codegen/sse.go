@@ -0,0 +1,438 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// SSEData contains the data needed to render the struct types that
+	// implement the server and client stream interfaces using Server-Sent
+	// Events.
+	SSEData struct {
+		// VarName is the name of the struct.
+		VarName string
+		// Type is type of the stream (server or client).
+		Type string
+		// Interface is the fully qualified name of the interface that
+		// the struct implements.
+		Interface string
+		// Endpoint is endpoint data that defines streaming result.
+		Endpoint *EndpointData
+		// Response is the successful response data for the streaming
+		// endpoint.
+		Response *ResponseData
+		// SendName is the name of the send function.
+		SendName string
+		// SendDesc is the description for the send function.
+		SendDesc string
+		// SendTypeName is the fully qualified type name sent through
+		// the stream.
+		SendTypeName string
+		// SendTypeRef is the fully qualified type ref sent through the
+		// stream.
+		SendTypeRef string
+		// RecvName is the name of the receive function.
+		RecvName string
+		// RecvDesc is the description for the recv function.
+		RecvDesc string
+		// RecvTypeName is the fully qualified type name received from
+		// the stream.
+		RecvTypeName string
+		// RecvTypeRef is the fully qualified type ref received from the
+		// stream.
+		RecvTypeRef string
+		// MustClose indicates whether to generate the Close() function
+		// for the stream.
+		MustClose bool
+		// PkgName is the service package name.
+		PkgName string
+		// ID describes the result field used to set the event ID if any.
+		ID *SSEFieldData
+		// Event describes the result field used to set the event type if
+		// any.
+		Event *SSEFieldData
+		// Retry describes the result field used to set the event retry
+		// if any.
+		Retry *SSEFieldData
+	}
+
+	// SSEFieldData describes a result field used to initialize a
+	// Server-Sent Events field.
+	SSEFieldData struct {
+		// FieldName is the name of the result struct field.
+		FieldName string
+		// Pointer is true if the result struct field is a pointer.
+		Pointer bool
+	}
+)
+
+// initSSEData initializes the Server-Sent Events related data in ed.
+func initSSEData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData) {
+	var (
+		id, event, retry *SSEFieldData
+
+		md  = ed.Method
+		svc = sd.Service
+	)
+	{
+		field := func(name string) *SSEFieldData {
+			if name == "" {
+				return nil
+			}
+			res := e.MethodExpr.Result
+			return &SSEFieldData{
+				FieldName: codegen.GoifyAtt(res.Find(name), name, true),
+				Pointer:   res.IsPrimitivePointer(name, true),
+			}
+		}
+		id = field(e.SSE.IDField)
+		event = field(e.SSE.EventField)
+		retry = field(e.SSE.RetryField)
+	}
+	ed.ServerSSE = &SSEData{
+		VarName:      md.ServerStream.VarName,
+		Interface:    fmt.Sprintf("%s.%s", svc.PkgName, md.ServerStream.Interface),
+		Endpoint:     ed,
+		Response:     ed.Result.Responses[0],
+		PkgName:      svc.PkgName,
+		Type:         "server",
+		SendName:     md.ServerStream.SendName,
+		SendDesc:     fmt.Sprintf("%s streams instances of %q to the %q endpoint Server-Sent Events stream.", md.ServerStream.SendName, ed.Result.Name, md.Name),
+		SendTypeName: ed.Result.Name,
+		SendTypeRef:  ed.Result.Ref,
+		MustClose:    md.ServerStream.MustClose,
+		ID:           id,
+		Event:        event,
+		Retry:        retry,
+	}
+	ed.ClientSSE = &SSEData{
+		VarName:      md.ClientStream.VarName,
+		Interface:    fmt.Sprintf("%s.%s", svc.PkgName, md.ClientStream.Interface),
+		Endpoint:     ed,
+		Response:     ed.Result.Responses[0],
+		PkgName:      svc.PkgName,
+		Type:         "client",
+		RecvName:     md.ClientStream.RecvName,
+		RecvDesc:     fmt.Sprintf("%s reads instances of %q from the %q endpoint Server-Sent Events stream.", md.ClientStream.RecvName, ed.Result.Name, md.Name),
+		RecvTypeName: ed.Result.Name,
+		RecvTypeRef:  ed.Result.Ref,
+		MustClose:    md.ClientStream.MustClose,
+	}
+}
+
+// sseServerFile returns the file implementing the Server-Sent Events server
+// streaming implementation if any.
+func sseServerFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	if !hasSSE(data) {
+		return nil
+	}
+	svcName := data.Service.PathName
+	title := fmt.Sprintf("%s Server-Sent Events server streaming", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", []*codegen.ImportSpec{
+			{Path: "encoding/json"},
+			{Path: "net/http"},
+			{Path: "sync"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
+		}),
+	}
+	for _, e := range data.Endpoints {
+		if e.ServerSSE == nil {
+			continue
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "server-sse-struct-type",
+			Source: sseStructTypeT,
+			Data:   e.ServerSSE,
+		})
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "server-sse-send",
+			Source:  sseSendT,
+			Data:    e.ServerSSE,
+			FuncMap: map[string]any{"viewedServerBody": viewedServerBody},
+		})
+		if e.ServerSSE.MustClose {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "server-sse-close",
+				Source: sseCloseT,
+				Data:   e.ServerSSE,
+			})
+		}
+		if e.Method.ViewedResult != nil && e.Method.ViewedResult.ViewName == "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "server-sse-set-view",
+				Source: sseSetViewT,
+				Data:   e.ServerSSE,
+			})
+		}
+	}
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", svcName, "server", "sse.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// sseClientFile returns the file implementing the Server-Sent Events client
+// streaming implementation if any.
+func sseClientFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	if !hasSSE(data) {
+		return nil
+	}
+	svcName := data.Service.PathName
+	title := fmt.Sprintf("%s Server-Sent Events client streaming", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{
+			{Path: "encoding/json"},
+			{Path: "io"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
+			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
+		}),
+	}
+	for _, e := range data.Endpoints {
+		if e.ClientSSE == nil {
+			continue
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-sse-struct-type",
+			Source: sseStructTypeT,
+			Data:   e.ClientSSE,
+		})
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-sse-recv",
+			Source: sseRecvT,
+			Data:   e.ClientSSE,
+		})
+		if e.Method.ViewedResult != nil && e.Method.ViewedResult.ViewName == "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "client-sse-set-view",
+				Source: sseSetViewT,
+				Data:   e.ClientSSE,
+			})
+		}
+	}
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", svcName, "client", "sse.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// hasSSE returns true if at least one of the endpoints in the service streams
+// its results using Server-Sent Events.
+func hasSSE(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if isSSEEndpoint(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSSEEndpoint returns true if the endpoint streams its results using
+// Server-Sent Events.
+func isSSEEndpoint(ed *EndpointData) bool {
+	return ed.ServerSSE != nil || ed.ClientSSE != nil
+}
+
+// isStreamingEndpoint returns true if the endpoint streams its payload or
+// result using either WebSocket, Server-Sent Events or NDJSON.
+func isStreamingEndpoint(ed *EndpointData) bool {
+	return isWebSocketEndpoint(ed) || isSSEEndpoint(ed) || isNDJSONEndpoint(ed)
+}
+
+const (
+	// sseStructTypeT renders the server and client struct types that
+	// implements the client and server stream interfaces.
+	// input: SSEData
+	sseStructTypeT = `{{ printf "%s implements the %s interface." .VarName .Interface | comment }}
+type {{ .VarName }} struct {
+{{- if eq .Type "server" }}
+	once sync.Once
+	{{ comment "w is the HTTP response writer used to stream the events." }}
+	w http.ResponseWriter
+	{{ comment "started is true once the response headers have been written." }}
+	started bool
+{{- else }}
+	{{ comment "body is the HTTP response body." }}
+	body io.ReadCloser
+	{{ comment "reader reads the events from the response body." }}
+	reader *goahttp.ServerSentEventReader
+{{- end }}
+	{{- if .Endpoint.Method.ViewedResult }}
+		{{- if not .Endpoint.Method.ViewedResult.ViewName }}
+	{{ printf "view is the view to render %s result type before sending to the stream." .Endpoint.Result.Name | comment }}
+	view string
+		{{- end }}
+	{{- end }}
+}
+`
+
+	// sseSendT renders the function implementing the Send method in server
+	// stream interface.
+	// input: SSEData
+	sseSendT = `{{ comment .SendDesc }}
+func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
+	{{- template "sse_start" . }}
+	{{- if .Endpoint.Method.ViewedResult }}
+		{{- if .Endpoint.Method.ViewedResult.ViewName }}
+	res := {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(v, {{ printf "%q" .Endpoint.Method.ViewedResult.ViewName }})
+		{{- else }}
+	res := {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(v, s.view)
+		{{- end }}
+	{{- else }}
+	res := v
+	{{- end }}
+	{{- $servBodyLen := len .Response.ServerBody }}
+	{{- if and (gt $servBodyLen 0) (index .Response.ServerBody 0).Init }}
+		{{- if .Endpoint.Method.ViewedResult }}
+			{{- if .Endpoint.Method.ViewedResult.ViewName }}
+				{{- $vsb := (viewedServerBody $.Response.ServerBody .Endpoint.Method.ViewedResult.ViewName) }}
+	body := {{ $vsb.Init.Name }}({{ range $vsb.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+			{{- else }}
+	var body any
+	switch s.view {
+				{{- range .Endpoint.Method.ViewedResult.Views }}
+	case {{ printf "%q" .Name }}{{ if eq .Name "default" }}, ""{{ end }}:
+					{{- $vsb := (viewedServerBody $.Response.ServerBody .Name) }}
+		body = {{ $vsb.Init.Name }}({{ range $vsb.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+				{{- end }}
+	}
+			{{- end }}
+		{{- else }}
+	body := {{ (index .Response.ServerBody 0).Init.Name }}({{ range (index .Response.ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
+		{{- end }}
+	data, err := json.Marshal(body)
+	{{- else }}
+	data, err := json.Marshal(res)
+	{{- end }}
+	if err != nil {
+		return err
+	}
+	ev := &goahttp.ServerSentEvent{Data: data}
+	{{- with .ID }}
+		{{- if .Pointer }}
+	if v.{{ .FieldName }} != nil {
+		ev.ID = string(*v.{{ .FieldName }})
+	}
+		{{- else }}
+	ev.ID = string(v.{{ .FieldName }})
+		{{- end }}
+	{{- end }}
+	{{- with .Event }}
+		{{- if .Pointer }}
+	if v.{{ .FieldName }} != nil {
+		ev.Event = string(*v.{{ .FieldName }})
+	}
+		{{- else }}
+	ev.Event = string(v.{{ .FieldName }})
+		{{- end }}
+	{{- end }}
+	{{- with .Retry }}
+		{{- if .Pointer }}
+	if v.{{ .FieldName }} != nil {
+		ev.Retry = int(*v.{{ .FieldName }})
+	}
+		{{- else }}
+	ev.Retry = int(v.{{ .FieldName }})
+		{{- end }}
+	{{- end }}
+	if err := goahttp.WriteServerSentEvent(s.w, ev); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+` + sseStartT
+
+	// sseRecvT renders the function implementing the Recv method in client
+	// stream interface.
+	// input: SSEData
+	sseRecvT = `{{ comment .RecvDesc }}
+func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
+	var (
+		rv {{ .RecvTypeRef }}
+		body {{ .Response.ClientBody.VarName }}
+	)
+	ev, err := s.reader.Next()
+	if err == io.EOF {
+		s.body.Close()
+		return rv, io.EOF
+	}
+	if err != nil {
+		return rv, err
+	}
+	if err = json.Unmarshal(ev.Data, &body); err != nil {
+		return rv, goahttp.ErrDecodingError("{{ .Endpoint.ServiceName }}", "{{ .Endpoint.Method.Name }}", err)
+	}
+	{{- if and .Response.ClientBody.ValidateRef (not .Endpoint.Method.ViewedResult) }}
+	{{ .Response.ClientBody.ValidateRef }}
+	if err != nil {
+		return rv, err
+	}
+	{{- end }}
+	{{- if .Response.ResultInit }}
+	res := {{ .Response.ResultInit.Name }}({{ range .Response.ResultInit.ClientArgs }}{{ .Ref }},{{ end }})
+		{{- if .Endpoint.Method.ViewedResult }}{{ with .Endpoint.Method.ViewedResult }}
+	vres := {{ if not .IsCollection }}&{{ end }}{{ .ViewsPkg }}.{{ .VarName }}{Projected: res, View: {{ if .ViewName }}{{ printf "%q" .ViewName }}{{ else }}s.view{{ end }}}
+	if err := {{ .ViewsPkg }}.Validate{{ $.Endpoint.Method.Result }}(vres); err != nil {
+		return rv, goahttp.ErrValidationError("{{ $.Endpoint.ServiceName }}", "{{ $.Endpoint.Method.Name }}", err)
+	}
+	return {{ $.PkgName }}.{{ .ResultInit.Name }}(vres){{ end }}, nil
+		{{- else }}
+	return res, nil
+		{{- end }}
+	{{- else }}
+	return body, nil
+	{{- end }}
+}
+`
+
+	// sseStartT renders the code that writes the Server-Sent Events response
+	// headers the first time the stream is used.
+	sseStartT = `{{- define "sse_start" }}
+	{{ comment "Write the response headers only once so that authorization logic in the endpoint is executed before the stream starts." }}
+	s.once.Do(func() {
+	{{- if .Endpoint.Method.ViewedResult }}
+		{{- if not .Endpoint.Method.ViewedResult.ViewName }}
+		s.w.Header().Set("goa-view", s.view)
+		{{- end }}
+	{{- end }}
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader({{ .Response.StatusCode }})
+		s.started = true
+	})
+{{- end }}
+`
+
+	// sseCloseT renders the function implementing the Close method in server
+	// stream interface.
+	// input: SSEData
+	sseCloseT = `{{ printf "Close closes the %q endpoint Server-Sent Events stream." .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) Close() error {
+	{{- template "sse_start" . }}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+` + sseStartT
+
+	// sseSetViewT renders the function implementing the SetView method in
+	// server stream interface.
+	// input: SSEData
+	sseSetViewT = `{{ printf "SetView sets the view to render the %s type before sending to the %q endpoint Server-Sent Events stream." .Endpoint.Result.Name .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) SetView(view string) {
+	s.view = view
+}
+`
+)
@@ -0,0 +1,47 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestServerSSE(t *testing.T) {
+	cases := []*testCase{
+		{"sse-result", testdata.SSEResultDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.SSEResultServerHandlerInitCode},
+			{"server-sse-send", &testdata.SSEResultServerSSESendCode},
+			{"server-sse-close", &testdata.SSEResultServerSSECloseCode},
+			{"server-sse-set-view", nil},
+		}},
+		{"sse-result-with-fields", testdata.SSEResultWithFieldsDSL, []*sectionExpectation{
+			{"server-sse-send", &testdata.SSEResultWithFieldsServerSSESendCode},
+		}},
+		{"sse-result-with-views", testdata.SSEResultWithViewsDSL, []*sectionExpectation{
+			{"server-sse-send", &testdata.SSEResultWithViewsServerSSESendCode},
+			{"server-sse-set-view", &testdata.SSEResultWithViewsServerSSESetViewCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
+
+func TestClientSSE(t *testing.T) {
+	cases := []*testCase{
+		{"client-sse-result", testdata.SSEResultDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.SSEResultClientEndpointInitCode},
+			{"client-sse-recv", &testdata.SSEResultClientSSERecvCode},
+			{"client-sse-set-view", nil},
+		}},
+		{"client-sse-result-with-fields", testdata.SSEResultWithFieldsDSL, []*sectionExpectation{
+			{"client-sse-recv", &testdata.SSEResultWithFieldsClientSSERecvCode},
+		}},
+		{"client-sse-result-with-views", testdata.SSEResultWithViewsDSL, []*sectionExpectation{
+			{"client-sse-recv", &testdata.SSEResultWithViewsClientSSERecvCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ClientFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
@@ -10,7 +10,11 @@ func NewClient(
 	enc func(*http.Request) goahttp.Encoder,
 	dec func(*http.Response) goahttp.Decoder,
 	restoreBody bool,
+	opts ...goahttp.ClientOption,
 ) *Client {
+	for _, opt := range opts {
+		doer = opt(doer)
+	}
 	return &Client{
 		MethodMultiEndpoints1Doer: doer,
 		MethodMultiEndpoints2Doer: doer,
@@ -34,10 +38,14 @@ func NewClient(
 	restoreBody bool,
 	dialer goahttp.Dialer,
 	cfn *ConnConfigurer,
+	opts ...goahttp.ClientOption,
 ) *Client {
 	if cfn == nil {
 		cfn = &ConnConfigurer{}
 	}
+	for _, opt := range opts {
+		doer = opt(doer)
+	}
 	return &Client{
 		StreamingResultMethodDoer: doer,
 		RestoreResponseBody:       restoreBody,
@@ -0,0 +1,133 @@
+package testdata
+
+const ConditionalServerInitCode = `// New instantiates HTTP handlers for all the Documents service endpoints using
+// the provided encoder and decoder. The handlers are mounted on the given mux
+// using the HTTP verb and path defined in the design. errhandler is called
+// whenever a response fails to be encoded. formatter is used to format errors
+// returned by the service methods prior to encoding. Both errhandler and
+// formatter are optional and can be nil.
+func New(
+	e *documents.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"Show", "GET", "/documents/{id}"},
+			{"Update", "PUT", "/documents/{id}"},
+		},
+		Show:   goahttp.HandleConditional(NewShowHandler(e.Show, mux, decoder, encoder, errhandler, formatter), nil),
+		Update: goahttp.HandleConditional(NewUpdateHandler(e.Update, mux, decoder, encoder, errhandler, formatter), nil),
+	}
+}
+`
+
+const ConditionalHashServerInitCode = `// New instantiates HTTP handlers for all the Documents service endpoints using
+// the provided encoder and decoder. The handlers are mounted on the given mux
+// using the HTTP verb and path defined in the design. errhandler is called
+// whenever a response fails to be encoded. formatter is used to format errors
+// returned by the service methods prior to encoding. Both errhandler and
+// formatter are optional and can be nil.
+func New(
+	e *documents.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"List", "GET", "/documents"},
+		},
+		List: goahttp.HandleConditional(NewListHandler(e.List, mux, decoder, encoder, errhandler, formatter), goahttp.HashETag),
+	}
+}
+`
+
+var ConditionalClientEndpointInitCode = `// Show returns an endpoint that makes HTTP requests to the Documents service
+// Show server.
+func (c *Client) Show() goa.Endpoint {
+	var (
+		decodeResponse = DecodeShowResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "Show")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "Documents")
+		req, err := c.BuildShowRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		goahttp.SetPreconditions(ctx, req)
+		resp, err := c.ShowDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("Documents", "Show", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+`
+
+const ConditionalClientResponseDecoderCode = `// DecodeShowResponse returns a decoder for responses returned by the Documents
+// Show endpoint. restoreBody controls whether the response body should be
+// restored after having been read.
+func DecodeShowResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body ShowResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("Documents", "Show", err)
+			}
+			var (
+				version   *string
+				updatedAt *string
+			)
+			versionRaw := goahttp.UnquoteETag(resp.Header.Get("Etag"))
+			if versionRaw != "" {
+				version = &versionRaw
+			}
+			updatedAtRaw := resp.Header.Get("Last-Modified")
+			if updatedAtRaw != "" {
+				updatedAt = &updatedAtRaw
+			}
+			if updatedAt != nil {
+				err = goa.MergeErrors(err, goa.ValidateFormat("updated_at", *updatedAt, goa.FormatRFC1123))
+			}
+			if err != nil {
+				return nil, goahttp.ErrValidationError("Documents", "Show", err)
+			}
+			p := NewShowDocumentOK(&body, version, updatedAt)
+			view := "default"
+			vres := &documentsviews.Document{Projected: p, View: view}
+			if err = documentsviews.ValidateDocument(vres); err != nil {
+				return nil, goahttp.ErrValidationError("Documents", "Show", err)
+			}
+			res := documents.NewDocument(vres)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("Documents", "Show", resp.StatusCode, string(body))
+		}
+	}
+}
+`
@@ -0,0 +1,56 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ConditionalDSL = func() {
+	var Document = ResultType("application/vnd.document", func() {
+		TypeName("Document")
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("version", String)
+			Attribute("updated_at", String, func() {
+				Format(FormatRFC1123)
+			})
+		})
+	})
+	Service("Documents", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(Document)
+			HTTP(func() {
+				GET("/documents/{id}")
+				ETag("version")
+				LastModified("updated_at")
+			})
+		})
+		Method("Update", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("title", String)
+			})
+			Result(Document)
+			HTTP(func() {
+				PUT("/documents/{id}")
+				ETag("version")
+			})
+		})
+	})
+}
+
+var ConditionalHashDSL = func() {
+	Service("Documents", func() {
+		Method("List", func() {
+			Result(func() {
+				Attribute("ids", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/documents")
+				ETag()
+			})
+		})
+	})
+}
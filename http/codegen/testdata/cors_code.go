@@ -0,0 +1,165 @@
+package testdata
+
+const CORSServerStructCode = `// Server lists the ServiceCORS service endpoint HTTP handlers.
+type Server struct {
+	Mounts []*MountPoint
+	List   http.Handler
+	Create http.Handler
+	Show   http.Handler
+	CORS   http.Handler
+}
+`
+
+const CORSServerInitCode = `// New instantiates HTTP handlers for all the ServiceCORS service endpoints
+// using the provided encoder and decoder. The handlers are mounted on the
+// given mux using the HTTP verb and path defined in the design. errhandler is
+// called whenever a response fails to be encoded. formatter is used to format
+// errors returned by the service methods prior to encoding. Both errhandler
+// and formatter are optional and can be nil.
+func New(
+	e *servicecors.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"List", "GET", "/"},
+			{"Create", "POST", "/"},
+			{"Show", "GET", "/{id}"},
+			{"CORS", "OPTIONS", "/"},
+			{"CORS", "OPTIONS", "/{id}"},
+		},
+		List:   NewListHandler(e.List, mux, decoder, encoder, errhandler, formatter),
+		Create: NewCreateHandler(e.Create, mux, decoder, encoder, errhandler, formatter),
+		Show:   NewShowHandler(e.Show, mux, decoder, encoder, errhandler, formatter),
+		CORS:   NewCORSHandler(),
+	}
+}
+`
+
+const CORSServerUseCode = `// Use wraps the server handlers with the given middleware.
+func (s *Server) Use(m func(http.Handler) http.Handler) {
+	s.List = m(s.List)
+	s.Create = m(s.Create)
+	s.Show = m(s.Show)
+	s.CORS = m(s.CORS)
+}
+`
+
+const CORSServerMountCode = `// Mount configures the mux to serve the ServiceCORS endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountListHandler(mux, h.List)
+	MountCreateHandler(mux, h.Create)
+	MountShowHandler(mux, h.Show)
+	MountCORSHandler(mux, h.CORS)
+}
+
+// Mount configures the mux to serve the ServiceCORS endpoints.
+func (s *Server) Mount(mux goahttp.Muxer) {
+	Mount(mux, s)
+}
+`
+
+const CORSServerHandlerCode = `// MountListHandler configures the mux to serve the "ServiceCORS" service
+// "List" endpoint.
+func MountListHandler(mux goahttp.Muxer, h http.Handler) {
+	f := goahttp.HandleCORS(h,
+		&goahttp.CORSOrigin{
+			Regexp:      regexp.MustCompile("^https://.*\\.example\\.com$"),
+			Methods:     []string{"GET"},
+			Headers:     []string{"X-Request-Id"},
+			Expose:      []string{"X-Time"},
+			MaxAge:      600,
+			Credentials: true,
+		},
+	)
+	mux.Handle("GET", "/", f)
+}
+`
+
+const CORSServerHandlerOverrideCode = `// MountShowHandler configures the mux to serve the "ServiceCORS" service
+// "Show" endpoint.
+func MountShowHandler(mux goahttp.Muxer, h http.Handler) {
+	f := goahttp.HandleCORS(h,
+		&goahttp.CORSOrigin{
+			Origin:  "*",
+			Methods: []string{"GET", "HEAD"},
+		},
+	)
+	mux.Handle("GET", "/{id}", f)
+}
+`
+
+const CORSServerCORSInitCode = `// NewCORSHandler creates a HTTP handler which returns a simple 204 response to
+// the CORS preflight requests sent to the ServiceCORS service.
+func NewCORSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+`
+
+const CORSServerCORSCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// sent to the ServiceCORS service endpoints.
+func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("OPTIONS", "/", goahttp.HandleCORS(h,
+		&goahttp.CORSOrigin{
+			Regexp:      regexp.MustCompile("^https://.*\\.example\\.com$"),
+			Methods:     []string{"GET", "POST"},
+			Headers:     []string{"X-Request-Id"},
+			Expose:      []string{"X-Time"},
+			MaxAge:      600,
+			Credentials: true,
+		},
+	))
+	mux.Handle("OPTIONS", "/{id}", goahttp.HandleCORS(h,
+		&goahttp.CORSOrigin{
+			Origin:  "*",
+			Methods: []string{"GET", "HEAD"},
+		},
+	))
+}
+`
+
+const CORSAPIServerCORSCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// sent to the ServiceCORSAPI service endpoints.
+func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("OPTIONS", "/", goahttp.HandleCORS(h,
+		&goahttp.CORSOrigin{
+			Origin:  "https://example.com",
+			Methods: []string{"GET"},
+		},
+	))
+}
+`
+
+var CORSMultipleServicesFirstCORSCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// sent to the ServiceCORSFirst service endpoints.
+func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("OPTIONS", "/items", goahttp.HandleCORS(h,
+		&goahttp.CORSOrigin{
+			Origin:  "https://first.example.com",
+			Methods: []string{"GET"},
+		},
+		&goahttp.CORSOrigin{
+			Origin:  "https://second.example.com",
+			Methods: []string{"POST"},
+		},
+	))
+}
+`
+
+var CORSMultipleServicesSecondCORSCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// sent to the ServiceCORSSecond service endpoints.
+func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("OPTIONS", "/items/{id}", goahttp.HandleCORS(h,
+		&goahttp.CORSOrigin{
+			Origin:  "https://second.example.com",
+			Methods: []string{"GET"},
+		},
+	))
+}
+`
@@ -0,0 +1,90 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CORSDSL = func() {
+	Service("ServiceCORS", func() {
+		HTTP(func() {
+			Origin(`/^https://.*\.example\.com$/`, func() {
+				AllowHeaders("X-Request-Id")
+				ExposeHeaders("X-Time")
+				MaxAge(600)
+				AllowCredentials()
+			})
+		})
+		Method("List", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("Create", func() {
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/{id}")
+				Origin("*", func() {
+					AllowMethods("GET", "HEAD")
+				})
+			})
+		})
+	})
+}
+
+var CORSAPIDSL = func() {
+	API("test", func() {
+		Origin("https://example.com")
+	})
+	Service("ServiceCORSAPI", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var NoCORSDSL = func() {
+	Service("ServiceNoCORS", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var CORSMultipleServicesDSL = func() {
+	Service("ServiceCORSFirst", func() {
+		Method("List", func() {
+			HTTP(func() {
+				GET("/items")
+				Origin("https://first.example.com")
+			})
+		})
+	})
+	Service("ServiceCORSSecond", func() {
+		Method("Create", func() {
+			HTTP(func() {
+				POST("/items")
+				Origin("https://second.example.com")
+			})
+		})
+		Method("Show", func() {
+			HTTP(func() {
+				GET("/items/{id}")
+				Origin("https://second.example.com")
+			})
+			Payload(func() {
+				Attribute("id", String)
+			})
+		})
+	})
+}
@@ -0,0 +1,28 @@
+package testdata
+
+const DeprecatedServerInitCode = `// New instantiates HTTP handlers for all the Catalog service endpoints using
+// the provided encoder and decoder. The handlers are mounted on the given mux
+// using the HTTP verb and path defined in the design. errhandler is called
+// whenever a response fails to be encoded. formatter is used to format errors
+// returned by the service methods prior to encoding. Both errhandler and
+// formatter are optional and can be nil.
+func New(
+	e *catalog.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"Show", "GET", "/items/{id}"},
+			{"Obsolete", "GET", "/obsolete"},
+			{"Get", "GET", "/items"},
+		},
+		Show:     goahttp.HandleDeprecated(NewShowHandler(e.Show, mux, decoder, encoder, errhandler, formatter), "@1735689600", "Tue, 01 Jan 2030 00:00:00 GMT"),
+		Obsolete: goahttp.HandleDeprecated(NewObsoleteHandler(e.Obsolete, mux, decoder, encoder, errhandler, formatter), "", ""),
+		Get:      NewGetHandler(e.Get, mux, decoder, encoder, errhandler, formatter),
+	}
+}
+`
@@ -0,0 +1,43 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var DeprecatedDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("id", String)
+		Attribute("label", String, func() {
+			Deprecated("Use id instead.")
+		})
+	})
+	Service("Catalog", func() {
+		Method("Show", func() {
+			Deprecated("Use Get instead.", "2030-01-01")
+			DeprecatedSince("2025-01-01")
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("legacy", Boolean, func() {
+					Deprecated("Ignored.")
+				})
+			})
+			Result(Item)
+			HTTP(func() {
+				GET("/items/{id}")
+				Param("legacy")
+			})
+		})
+		Method("Obsolete", func() {
+			Deprecated("Use Get instead.")
+			HTTP(func() {
+				GET("/obsolete")
+			})
+		})
+		Method("Get", func() {
+			Result(ArrayOf(Item))
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+	})
+}
@@ -292,3 +292,100 @@ func NewMethodSkipResponseBodyEncodeDecodeHandler(
 	})
 }
 `
+
+var ServerCursorPaginationCode = `// NewMethodCursorPaginationHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceCursorPagination" service
+// "MethodCursorPagination" endpoint.
+func NewMethodCursorPaginationHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodCursorPaginationRequest(mux, decoder)
+		encodeResponse = EncodeMethodCursorPaginationResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodCursorPagination")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceCursorPagination")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if pr, ok := res.(*servicecursorpagination.MethodCursorPaginationResult); ok {
+			if pr.NextCursor != nil && *pr.NextCursor != "" {
+				w.Header().Set("Link", goahttp.NextPageLink(r, "cursor", *pr.NextCursor))
+			}
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerOffsetPaginationCode = `// NewMethodOffsetPaginationHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceOffsetPagination" service
+// "MethodOffsetPagination" endpoint.
+func NewMethodOffsetPaginationHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodOffsetPaginationRequest(mux, decoder)
+		encodeResponse = EncodeMethodOffsetPaginationResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodOffsetPagination")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceOffsetPagination")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if pr, ok := res.(*serviceoffsetpagination.MethodOffsetPaginationResult); ok {
+			p := payload.(*serviceoffsetpagination.MethodOffsetPaginationPayload)
+			if n := len(pr.Items); n > 0 && (p.Limit == nil || n >= *p.Limit) {
+				offset := n
+				if p.Offset != nil {
+					offset += *p.Offset
+				}
+				w.Header().Set("Link", goahttp.NextPageLink(r, "offset", strconv.Itoa(offset)))
+			}
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
@@ -0,0 +1,103 @@
+package testdata
+
+const HealthServerStructCode = `// Server lists the ServiceHealth service endpoint HTTP handlers.
+type Server struct {
+	Mounts    []*MountPoint
+	List      http.Handler
+	Liveness  http.Handler
+	Readiness http.Handler
+}
+`
+
+const HealthServerInitCode = `// New instantiates HTTP handlers for all the ServiceHealth service endpoints
+// using the provided encoder and decoder. The handlers are mounted on the
+// given mux using the HTTP verb and path defined in the design. errhandler is
+// called whenever a response fails to be encoded. formatter is used to format
+// errors returned by the service methods prior to encoding. Both errhandler
+// and formatter are optional and can be nil.
+func New(
+	e *servicehealth.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"List", "GET", "/"},
+			{"Liveness", "GET", "/healthz"},
+			{"Readiness", "GET", "/readyz"},
+		},
+		List:      NewListHandler(e.List, mux, decoder, encoder, errhandler, formatter),
+		Liveness:  goahttp.HandleLiveness(),
+		Readiness: goahttp.HandleReadiness(e.Health...),
+	}
+}
+`
+
+const HealthServerMountCode = `// Mount configures the mux to serve the ServiceHealth endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountListHandler(mux, h.List)
+	MountLivenessHandler(mux, h.Liveness)
+	MountReadinessHandler(mux, h.Readiness)
+}
+
+// Mount configures the mux to serve the ServiceHealth endpoints.
+func (s *Server) Mount(mux goahttp.Muxer) {
+	Mount(mux, s)
+}
+`
+
+const HealthServerHealthCode = `// MountLivenessHandler configures the mux to serve the liveness probes of the
+// ServiceHealth service.
+func MountLivenessHandler(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("GET", "/healthz", h.ServeHTTP)
+}
+
+// MountReadinessHandler configures the mux to serve the readiness probes of
+// the ServiceHealth service.
+func MountReadinessHandler(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("GET", "/readyz", h.ServeHTTP)
+}
+`
+
+const HealthNoCheckServerInitCode = `// New instantiates HTTP handlers for all the ServiceHealthNoCheck service
+// endpoints using the provided encoder and decoder. The handlers are mounted
+// on the given mux using the HTTP verb and path defined in the design.
+// errhandler is called whenever a response fails to be encoded. formatter is
+// used to format errors returned by the service methods prior to encoding.
+// Both errhandler and formatter are optional and can be nil.
+func New(
+	e *servicehealthnocheck.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"List", "GET", "/"},
+			{"Liveness", "GET", "/live"},
+			{"Readiness", "GET", "/ready"},
+		},
+		List:      NewListHandler(e.List, mux, decoder, encoder, errhandler, formatter),
+		Liveness:  goahttp.HandleLiveness(),
+		Readiness: goahttp.HandleReadiness(),
+	}
+}
+`
+
+const HealthNoCheckServerHealthCode = `// MountLivenessHandler configures the mux to serve the liveness probes of the
+// ServiceHealthNoCheck service.
+func MountLivenessHandler(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("GET", "/live", h.ServeHTTP)
+}
+
+// MountReadinessHandler configures the mux to serve the readiness probes of
+// the ServiceHealthNoCheck service.
+func MountReadinessHandler(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("GET", "/ready", h.ServeHTTP)
+}
+`
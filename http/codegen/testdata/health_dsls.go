@@ -0,0 +1,54 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var HealthDSL = func() {
+	Service("ServiceHealth", func() {
+		Health(func() {
+			Check("database")
+		})
+		Method("List", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var HealthNoCheckDSL = func() {
+	Service("ServiceHealthNoCheck", func() {
+		Health(func() {
+			Liveness("/live")
+			Readiness("/ready")
+		})
+		Method("List", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var SharedHealthDSL = func() {
+	API("API", func() {
+		Health(func() {
+			Check("database")
+		})
+	})
+	Service("Service1", func() {
+		Method("List", func() {
+			HTTP(func() {
+				GET("/1")
+			})
+		})
+	})
+	Service("Service2", func() {
+		Method("List", func() {
+			HTTP(func() {
+				GET("/2")
+			})
+		})
+	})
+}
@@ -0,0 +1,123 @@
+package testdata
+
+const IdempotentServerStructCode = `// Server lists the Payments service endpoint HTTP handlers.
+type Server struct {
+	Mounts      []*MountPoint
+	Charge      http.Handler
+	List        http.Handler
+	idempotency *goahttp.Idempotency
+}
+`
+
+const IdempotentServerInitCode = `// New instantiates HTTP handlers for all the Payments service endpoints using
+// the provided encoder and decoder. The handlers are mounted on the given mux
+// using the HTTP verb and path defined in the design. errhandler is called
+// whenever a response fails to be encoded. formatter is used to format errors
+// returned by the service methods prior to encoding. Both errhandler and
+// formatter are optional and can be nil.
+func New(
+	e *payments.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) *Server {
+	idempotency := goahttp.NewIdempotency(nil)
+	return &Server{
+		Mounts: []*MountPoint{
+			{"Charge", "POST", "/charges"},
+			{"List", "GET", "/charges"},
+		},
+		Charge:      idempotency.Handler("Payments.Charge", NewChargeHandler(e.Charge, mux, decoder, encoder, errhandler, formatter), goahttp.ErrorEncoder(encoder, formatter), errhandler),
+		List:        NewListHandler(e.List, mux, decoder, encoder, errhandler, formatter),
+		idempotency: idempotency,
+	}
+}
+`
+
+const IdempotentServerStoreCode = `// UseIdempotencyStore configures the handlers of the idempotent Payments
+// endpoints to record responses in the given store. The handlers record
+// responses in memory by default.
+func (s *Server) UseIdempotencyStore(store goahttp.IdempotencyStore) {
+	s.idempotency.SetStore(store)
+}
+
+// UseIdempotencyScope configures the handlers of the idempotent Payments
+// endpoints to compute the scope of the requests with the given function.
+// Requests made with the same idempotency key but different scopes are
+// processed independently. The scope is the value of the Authorization header
+// by default.
+func (s *Server) UseIdempotencyScope(scope func(*http.Request) string) {
+	s.idempotency.SetScope(scope)
+}
+
+// UseIdempotencyMaxBodySize sets the maximum size in bytes of the bodies of
+// the requests made to the idempotent Payments endpoints with an idempotency
+// key, goahttp.DefaultIdempotencyMaxBodySize by default.
+func (s *Server) UseIdempotencyMaxBodySize(n int64) {
+	s.idempotency.SetMaxBodySize(n)
+}
+`
+
+var IdempotentClientEndpointInitCode = `// Charge returns an endpoint that makes HTTP requests to the Payments service
+// Charge server.
+func (c *Client) Charge() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeChargeRequest(c.encoder)
+		decodeResponse = DecodeChargeResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return goahttp.IdempotentEndpoint(func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "Charge")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "Payments")
+		req, err := c.BuildChargeRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		goahttp.SetIdempotencyKey(ctx, req)
+		resp, err := c.ChargeDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("Payments", "Charge", err)
+		}
+		return decodeResponse(resp)
+	})
+}
+`
+
+var IdempotentRetryClientEndpointInitCode = `// Charge returns an endpoint that makes HTTP requests to the Payments service
+// Charge server.
+func (c *Client) Charge() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeChargeRequest(c.encoder)
+		decodeResponse = DecodeChargeResponse(c.decoder, c.RestoreResponseBody)
+		retry          = goa.Retry(&goa.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     10 * time.Second,
+			Retryable:      goahttp.RetryableError(),
+		})
+	)
+	return goahttp.IdempotentEndpoint(retry(func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "Charge")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "Payments")
+		req, err := c.BuildChargeRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		goahttp.SetIdempotencyKey(ctx, req)
+		resp, err := c.ChargeDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("Payments", "Charge", err)
+		}
+		return decodeResponse(resp)
+	}))
+}
+`
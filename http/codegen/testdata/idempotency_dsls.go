@@ -0,0 +1,45 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var IdempotentDSL = func() {
+	Service("Payments", func() {
+		Method("Charge", func() {
+			Payload(func() {
+				Attribute("amount", Int)
+			})
+			Result(String)
+			HTTP(func() {
+				POST("/charges")
+				Idempotent()
+			})
+		})
+		Method("List", func() {
+			Result(ArrayOf(String))
+			HTTP(func() {
+				GET("/charges")
+			})
+		})
+	})
+}
+
+var IdempotentRetryDSL = func() {
+	Service("Payments", func() {
+		Retry(func() {
+			MaxAttempts(3)
+			IdempotentOnly()
+		})
+		Method("Charge", func() {
+			Payload(func() {
+				Attribute("amount", Int)
+			})
+			Result(String)
+			HTTP(func() {
+				POST("/charges")
+				Idempotent()
+			})
+		})
+	})
+}
@@ -0,0 +1,281 @@
+package testdata
+
+var NDJSONResultServerHandlerInitCode = `// NewTailHandler creates a HTTP handler which loads the HTTP request and calls
+// the "NDJSONResult" service "Tail" endpoint.
+func NewTailHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest = DecodeTailRequest(mux, decoder)
+		encodeError   = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "Tail")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "NDJSONResult")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		v := &ndjsonresult.TailEndpointInput{
+			Stream:  &TailServerStream{w: w},
+			Payload: payload.(*ndjsonresult.TailPayload),
+		}
+		_, err = endpoint(ctx, v)
+		if err != nil {
+			if v.Stream.(*TailServerStream).started {
+				// Response headers have already been written, do not encode the error
+				errhandler(ctx, w, err)
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+	})
+}
+`
+
+var NDJSONResultServerNDJSONSendCode = `// Send streams instances of "int" to the "Tail" endpoint NDJSON response.
+func (s *TailServerStream) Send(v int) error {
+	// Write the response headers only once so that authorization logic in the
+	// endpoint is executed before the stream starts.
+	s.once.Do(func() {
+		s.w.Header().Set("Content-Type", goahttp.NDJSONContentType)
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	})
+	res := v
+	return goahttp.WriteNDJSON(s.w, res)
+}
+`
+
+var NDJSONResultServerNDJSONCloseCode = `// Close closes the "Tail" endpoint NDJSON response.
+func (s *TailServerStream) Close() error {
+	// Write the response headers only once so that authorization logic in the
+	// endpoint is executed before the stream starts.
+	s.once.Do(func() {
+		s.w.Header().Set("Content-Type", goahttp.NDJSONContentType)
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	})
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+`
+
+var NDJSONResultClientEndpointInitCode = `// Tail returns an endpoint that makes HTTP requests to the NDJSONResult
+// service Tail server.
+func (c *Client) Tail() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeTailRequest(c.encoder)
+		decodeResponse = DecodeTailResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "Tail")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "NDJSONResult")
+		req, err := c.BuildTailRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", goahttp.NDJSONContentType)
+		resp, err := c.TailDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("NDJSONResult", "Tail", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return decodeResponse(resp)
+		}
+		stream := &TailClientStream{body: resp.Body}
+		return stream, nil
+	}
+}
+`
+
+var NDJSONResultClientNDJSONRecvCode = `// Recv reads instances of "int" from the "Tail" endpoint NDJSON response.
+func (s *TailClientStream) Recv() (int, error) {
+	var (
+		rv   int
+		body int
+		err  error
+	)
+	if s.dec == nil {
+		s.dec = json.NewDecoder(s.body)
+	}
+	err = s.dec.Decode(&body)
+	if err == io.EOF {
+		s.body.Close()
+		return rv, io.EOF
+	}
+	if err != nil {
+		return rv, goahttp.ErrDecodingError("NDJSONResult", "Tail", err)
+	}
+	return body, nil
+}
+`
+
+var NDJSONPayloadServerNDJSONSendCode = `// SendAndClose writes the "Ingest" endpoint response and closes the stream.
+func (s *IngestServerStream) SendAndClose(v int) error {
+	// Write the response headers only once so that authorization logic in the
+	// endpoint is executed before the stream starts.
+	s.once.Do(func() {
+		s.w.Header().Set("Content-Type", goahttp.NDJSONContentType)
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	})
+	res := v
+	return goahttp.WriteNDJSON(s.w, res)
+}
+`
+
+var NDJSONPayloadServerNDJSONRecvCode = `// Recv reads instances of "ndjsonpayload.Entry" from the "Ingest" endpoint
+// NDJSON request body, it returns io.EOF once the client closes the body.
+func (s *IngestServerStream) Recv() (*ndjsonpayload.Entry, error) {
+	var (
+		rv   *ndjsonpayload.Entry
+		body IngestStreamingBody
+		err  error
+	)
+	if s.dec == nil {
+		s.dec = json.NewDecoder(s.r.Body)
+	}
+	if err = s.dec.Decode(&body); err != nil {
+		return rv, err
+	}
+	err = ValidateIngestStreamingBody(&body)
+	if err != nil {
+		return rv, err
+	}
+	return NewIngestStreamingBody(&body), nil
+}
+`
+
+var NDJSONPayloadClientEndpointInitCode = `// Ingest returns an endpoint that makes HTTP requests to the NDJSONPayload
+// service Ingest server.
+func (c *Client) Ingest() goa.Endpoint {
+	var (
+		decodeResponse = DecodeIngestResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "Ingest")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "NDJSONPayload")
+		req, err := c.BuildIngestRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", goahttp.NDJSONContentType)
+		return &IngestClientStream{req: goahttp.NewNDJSONRequest(c.IngestDoer, req), decode: decodeResponse}, nil
+	}
+}
+`
+
+var NDJSONPayloadClientNDJSONSendCode = `// Send streams instances of "ndjsonpayload.Entry" to the "Ingest" endpoint
+// NDJSON request body.
+func (s *IngestClientStream) Send(v *ndjsonpayload.Entry) error {
+	body := NewIngestStreamingBody(v)
+	return s.req.Send(body)
+}
+`
+
+var NDJSONPayloadClientNDJSONRecvCode = `// CloseAndRecv closes the "Ingest" endpoint NDJSON request body and reads the
+// "int" response.
+func (s *IngestClientStream) CloseAndRecv() (int, error) {
+	var (
+		rv   int
+		body int
+		err  error
+	)
+	if err = s.req.CloseSend(); err != nil {
+		return rv, err
+	}
+	if s.dec == nil {
+		resp, err := s.req.Response()
+		if err != nil {
+			return rv, goahttp.ErrRequestError("NDJSONPayload", "Ingest", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			_, err = s.decode(resp)
+			return rv, err
+		}
+		s.body = resp.Body
+		s.dec = json.NewDecoder(s.body)
+	}
+	defer s.body.Close()
+	err = s.dec.Decode(&body)
+	if err == io.EOF {
+		return rv, io.EOF
+	}
+	if err != nil {
+		return rv, goahttp.ErrDecodingError("NDJSONPayload", "Ingest", err)
+	}
+	return body, nil
+}
+`
+
+var NDJSONBidirectionalServerNDJSONSendCode = `// Send streams instances of "string" to the "Echo" endpoint NDJSON response.
+func (s *EchoServerStream) Send(v string) error {
+	// Write the response headers only once so that authorization logic in the
+	// endpoint is executed before the stream starts.
+	s.once.Do(func() {
+		// Keep reading the request body after writing the response (HTTP/1.1).
+		goahttp.EnableFullDuplex(s.w) // nolint: errcheck
+		s.w.Header().Set("Content-Type", goahttp.NDJSONContentType)
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	})
+	res := v
+	return goahttp.WriteNDJSON(s.w, res)
+}
+`
+
+var NDJSONBidirectionalClientNDJSONRecvCode = `// Recv reads instances of "string" from the "Echo" endpoint NDJSON response.
+func (s *EchoClientStream) Recv() (string, error) {
+	var (
+		rv   string
+		body string
+		err  error
+	)
+	if s.dec == nil {
+		resp, err := s.req.Response()
+		if err != nil {
+			return rv, goahttp.ErrRequestError("NDJSONBidirectional", "Echo", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			_, err = s.decode(resp)
+			return rv, err
+		}
+		s.body = resp.Body
+		s.dec = json.NewDecoder(s.body)
+	}
+	err = s.dec.Decode(&body)
+	if err == io.EOF {
+		s.body.Close()
+		return rv, io.EOF
+	}
+	if err != nil {
+		return rv, goahttp.ErrDecodingError("NDJSONBidirectional", "Echo", err)
+	}
+	return body, nil
+}
+`
+
+var NDJSONBidirectionalClientNDJSONCloseCode = `// Close closes the "Echo" endpoint NDJSON request body.
+func (s *EchoClientStream) Close() error {
+	return s.req.CloseSend()
+}
+`
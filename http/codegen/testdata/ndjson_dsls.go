@@ -0,0 +1,79 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var NDJSONResultDSL = func() {
+	Service("NDJSONResult", func() {
+		Method("Tail", func() {
+			Payload(func() {
+				Attribute("filter", String)
+			})
+			StreamingResult(Int)
+			HTTP(func() {
+				POST("/")
+				NDJSON()
+			})
+		})
+	})
+}
+
+var NDJSONPayloadDSL = func() {
+	var Entry = Type("Entry", func() {
+		Attribute("message", String, func() {
+			MinLength(1)
+		})
+		Required("message")
+	})
+	Service("NDJSONPayload", func() {
+		Method("Ingest", func() {
+			StreamingPayload(Entry)
+			Result(Int)
+			HTTP(func() {
+				POST("/")
+				NDJSON()
+			})
+		})
+	})
+}
+
+var NDJSONBidirectionalDSL = func() {
+	Service("NDJSONBidirectional", func() {
+		Method("Echo", func() {
+			StreamingPayload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				POST("/")
+				NDJSON()
+			})
+		})
+	})
+}
+
+var NDJSONDSL = func() {
+	var Entry = Type("Entry", func() {
+		Attribute("message", String)
+		Required("message")
+	})
+	Service("NDJSON", func() {
+		Method("Tail", func() {
+			Payload(func() {
+				Attribute("filter", String)
+			})
+			StreamingResult(Entry)
+			HTTP(func() {
+				POST("/tail")
+				NDJSON()
+			})
+		})
+		Method("Ingest", func() {
+			StreamingPayload(Entry)
+			Result(Int)
+			HTTP(func() {
+				POST("/ingest")
+				NDJSON()
+			})
+		})
+	})
+}
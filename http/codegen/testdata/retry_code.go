@@ -0,0 +1,82 @@
+package testdata
+
+var RetryClientEndpointInitCode = `// Method returns an endpoint that makes HTTP requests to the Retry service
+// Method server.
+func (c *Client) Method() goa.Endpoint {
+	var (
+		decodeResponse = DecodeMethodResponse(c.decoder, c.RestoreResponseBody)
+		retry          = goa.Retry(&goa.RetryPolicy{
+			MaxAttempts:    4,
+			InitialBackoff: 50 * time.Millisecond,
+			MaxBackoff:     2 * time.Second,
+			Jitter:         0.25,
+			Errors:         []string{"conflict", "unavailable"},
+			Retryable:      goahttp.RetryableError(http.StatusBadGateway, http.StatusServiceUnavailable),
+		})
+	)
+	return retry(func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "Method")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "Retry")
+		req, err := c.BuildMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.MethodDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("Retry", "Method", err)
+		}
+		return decodeResponse(resp)
+	})
+}
+`
+
+var RetryHedgeClientEndpointInitCode = `// Method returns an endpoint that makes HTTP requests to the RetryHedge
+// service Method server.
+func (c *Client) Method() goa.Endpoint {
+	var (
+		decodeResponse = DecodeMethodResponse(c.decoder, c.RestoreResponseBody)
+		retry          = goa.Retry(&goa.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     10 * time.Second,
+			HedgeDelay:     100 * time.Millisecond,
+			Retryable:      goahttp.RetryableError(),
+		})
+	)
+	return retry(func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "Method")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "RetryHedge")
+		req, err := c.BuildMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.MethodDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("RetryHedge", "Method", err)
+		}
+		return decodeResponse(resp)
+	})
+}
+`
+
+var RetryIdempotentOnlyClientEndpointInitCode = `// Method returns an endpoint that makes HTTP requests to the
+// RetryIdempotentOnly service Method server.
+func (c *Client) Method() goa.Endpoint {
+	var (
+		decodeResponse = DecodeMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "Method")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "RetryIdempotentOnly")
+		req, err := c.BuildMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.MethodDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("RetryIdempotentOnly", "Method", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+`
@@ -0,0 +1,56 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var RetryDSL = func() {
+	Service("Retry", func() {
+		Error("unavailable", func() {
+			Temporary()
+		})
+		Retry(func() {
+			MaxAttempts(4)
+			Backoff("50ms", "2s")
+			Jitter(0.25)
+			RetryOn("conflict")
+			RetryOnStatus(StatusBadGateway, StatusServiceUnavailable)
+		})
+		Method("Method", func() {
+			Payload(String)
+			Result(String)
+			Error("conflict")
+			HTTP(func() {
+				GET("/{p}")
+				Response("unavailable", StatusServiceUnavailable)
+				Response("conflict", StatusConflict)
+			})
+		})
+	})
+}
+
+var RetryHedgeDSL = func() {
+	Service("RetryHedge", func() {
+		Method("Method", func() {
+			Retry(func() {
+				Hedge("100ms")
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var RetryIdempotentOnlyDSL = func() {
+	Service("RetryIdempotentOnly", func() {
+		Retry(func() {
+			IdempotentOnly()
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
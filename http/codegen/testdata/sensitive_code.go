@@ -0,0 +1,70 @@
+package testdata
+
+const SensitiveServerHandlerInitCode = `// NewMethodSensitiveHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceSensitive" service "MethodSensitive" endpoint.
+func NewMethodSensitiveHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodSensitiveRequest(mux, decoder)
+		encodeResponse = EncodeMethodSensitiveResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodSensitive")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceSensitive")
+		ctx = goa.WithSensitive(ctx, "X-API-Key", "access_token", "email", "key", "number", "token")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
+
+const SensitiveClientEndpointInitCode = `// MethodSensitive returns an endpoint that makes HTTP requests to the
+// ServiceSensitive service MethodSensitive server.
+func (c *Client) MethodSensitive() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeMethodSensitiveRequest(c.encoder)
+		decodeResponse = DecodeMethodSensitiveResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodSensitive")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceSensitive")
+		ctx = goa.WithSensitive(ctx, "X-API-Key", "access_token", "email", "key", "number", "token")
+		req, err := c.BuildMethodSensitiveRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.MethodSensitiveDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("ServiceSensitive", "MethodSensitive", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+`
@@ -0,0 +1,39 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var SensitiveDSL = func() {
+	var Card = Type("Card", func() {
+		Attribute("number", String, func() {
+			Sensitive()
+		})
+		Attribute("holder", String)
+	})
+	Service("ServiceSensitive", func() {
+		Method("MethodSensitive", func() {
+			Payload(func() {
+				Attribute("key", String, func() {
+					Sensitive()
+				})
+				Attribute("token", String, func() {
+					Redact()
+				})
+				Attribute("page", Int)
+				Attribute("card", Card)
+			})
+			Result(func() {
+				Attribute("email", String, func() {
+					Sensitive()
+				})
+			})
+			HTTP(func() {
+				POST("/")
+				Header("key:X-API-Key")
+				Param("token:access_token")
+				Param("page")
+			})
+		})
+	})
+}
@@ -282,3 +282,33 @@ var ServerSkipResponseBodyEncodeDecodeDSL = func() {
 		})
 	})
 }
+
+var ServerCursorPaginationDSL = func() {
+	Service("ServiceCursorPagination", func() {
+		Method("MethodCursorPagination", func() {
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+			})
+			Paginated()
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ServerOffsetPaginationDSL = func() {
+	Service("ServiceOffsetPagination", func() {
+		Method("MethodOffsetPagination", func() {
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+			})
+			Paginated(func() {
+				Offset("offset")
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,263 @@
+package testdata
+
+var SSEResultServerHandlerInitCode = `// NewSubscribeHandler creates a HTTP handler which loads the HTTP request and
+// calls the "SSEResult" service "Subscribe" endpoint.
+func NewSubscribeHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		encodeError = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "Subscribe")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "SSEResult")
+		var err error
+		v := &sseresult.SubscribeEndpointInput{
+			Stream: &SubscribeServerStream{w: w},
+		}
+		_, err = endpoint(ctx, v)
+		if err != nil {
+			if v.Stream.(*SubscribeServerStream).started {
+				// Response headers have already been written, do not encode the error
+				errhandler(ctx, w, err)
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+	})
+}
+`
+
+var SSEResultServerSSESendCode = `// Send streams instances of "int" to the "Subscribe" endpoint Server-Sent
+// Events stream.
+func (s *SubscribeServerStream) Send(v int) error {
+	// Write the response headers only once so that authorization logic in the
+	// endpoint is executed before the stream starts.
+	s.once.Do(func() {
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	})
+	res := v
+	data, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	ev := &goahttp.ServerSentEvent{Data: data}
+	if err := goahttp.WriteServerSentEvent(s.w, ev); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+`
+
+var SSEResultServerSSECloseCode = `// Close closes the "Subscribe" endpoint Server-Sent Events stream.
+func (s *SubscribeServerStream) Close() error {
+	// Write the response headers only once so that authorization logic in the
+	// endpoint is executed before the stream starts.
+	s.once.Do(func() {
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	})
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+`
+
+var SSEResultClientEndpointInitCode = `// Subscribe returns an endpoint that makes HTTP requests to the SSEResult
+// service Subscribe server.
+func (c *Client) Subscribe() goa.Endpoint {
+	var (
+		decodeResponse = DecodeSubscribeResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "Subscribe")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "SSEResult")
+		req, err := c.BuildSubscribeRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "text/event-stream")
+		resp, err := c.SubscribeDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("SSEResult", "Subscribe", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return decodeResponse(resp)
+		}
+		stream := &SubscribeClientStream{body: resp.Body, reader: goahttp.NewServerSentEventReader(resp.Body)}
+		return stream, nil
+	}
+}
+`
+
+var SSEResultClientSSERecvCode = `// Recv reads instances of "int" from the "Subscribe" endpoint Server-Sent
+// Events stream.
+func (s *SubscribeClientStream) Recv() (int, error) {
+	var (
+		rv   int
+		body int
+	)
+	ev, err := s.reader.Next()
+	if err == io.EOF {
+		s.body.Close()
+		return rv, io.EOF
+	}
+	if err != nil {
+		return rv, err
+	}
+	if err = json.Unmarshal(ev.Data, &body); err != nil {
+		return rv, goahttp.ErrDecodingError("SSEResult", "Subscribe", err)
+	}
+	return body, nil
+}
+`
+
+var SSEResultWithFieldsServerSSESendCode = `// Send streams instances of "sseresultwithfields.Event" to the "Subscribe"
+// endpoint Server-Sent Events stream.
+func (s *SubscribeServerStream) Send(v *sseresultwithfields.Event) error {
+	// Write the response headers only once so that authorization logic in the
+	// endpoint is executed before the stream starts.
+	s.once.Do(func() {
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	})
+	res := v
+	body := NewSubscribeResponseBody(res)
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	ev := &goahttp.ServerSentEvent{Data: data}
+	ev.ID = string(v.ID)
+	ev.Event = string(v.Kind)
+	if v.Retry != nil {
+		ev.Retry = int(*v.Retry)
+	}
+	if err := goahttp.WriteServerSentEvent(s.w, ev); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+`
+
+var SSEResultWithFieldsClientSSERecvCode = `// Recv reads instances of "sseresultwithfields.Event" from the "Subscribe"
+// endpoint Server-Sent Events stream.
+func (s *SubscribeClientStream) Recv() (*sseresultwithfields.Event, error) {
+	var (
+		rv   *sseresultwithfields.Event
+		body SubscribeResponseBody
+	)
+	ev, err := s.reader.Next()
+	if err == io.EOF {
+		s.body.Close()
+		return rv, io.EOF
+	}
+	if err != nil {
+		return rv, err
+	}
+	if err = json.Unmarshal(ev.Data, &body); err != nil {
+		return rv, goahttp.ErrDecodingError("SSEResultWithFields", "Subscribe", err)
+	}
+	err = ValidateSubscribeResponseBody(&body)
+	if err != nil {
+		return rv, err
+	}
+	res := NewSubscribeEventOK(&body)
+	return res, nil
+}
+`
+
+var SSEResultWithViewsServerSSESendCode = `// Send streams instances of "sseresultwithviews.Event" to the "Subscribe"
+// endpoint Server-Sent Events stream.
+func (s *SubscribeServerStream) Send(v *sseresultwithviews.Event) error {
+	// Write the response headers only once so that authorization logic in the
+	// endpoint is executed before the stream starts.
+	s.once.Do(func() {
+		s.w.Header().Set("goa-view", s.view)
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	})
+	res := sseresultwithviews.NewViewedEvent(v, s.view)
+	var body any
+	switch s.view {
+	case "default", "":
+		body = NewSubscribeResponseBody(res.Projected)
+	case "tiny":
+		body = NewSubscribeResponseBodyTiny(res.Projected)
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	ev := &goahttp.ServerSentEvent{Data: data}
+	if v.ID != nil {
+		ev.ID = string(*v.ID)
+	}
+	if err := goahttp.WriteServerSentEvent(s.w, ev); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+`
+
+var SSEResultWithViewsServerSSESetViewCode = `// SetView sets the view to render the sseresultwithviews.Event type before
+// sending to the "Subscribe" endpoint Server-Sent Events stream.
+func (s *SubscribeServerStream) SetView(view string) {
+	s.view = view
+}
+`
+
+var SSEResultWithViewsClientSSERecvCode = `// Recv reads instances of "sseresultwithviews.Event" from the "Subscribe"
+// endpoint Server-Sent Events stream.
+func (s *SubscribeClientStream) Recv() (*sseresultwithviews.Event, error) {
+	var (
+		rv   *sseresultwithviews.Event
+		body SubscribeResponseBody
+	)
+	ev, err := s.reader.Next()
+	if err == io.EOF {
+		s.body.Close()
+		return rv, io.EOF
+	}
+	if err != nil {
+		return rv, err
+	}
+	if err = json.Unmarshal(ev.Data, &body); err != nil {
+		return rv, goahttp.ErrDecodingError("SSEResultWithViews", "Subscribe", err)
+	}
+	res := NewSubscribeEventOK(&body)
+	vres := &sseresultwithviewsviews.Event{Projected: res, View: s.view}
+	if err := sseresultwithviewsviews.ValidateEvent(vres); err != nil {
+		return rv, goahttp.ErrValidationError("SSEResultWithViews", "Subscribe", err)
+	}
+	return sseresultwithviews.NewEvent(vres), nil
+}
+`
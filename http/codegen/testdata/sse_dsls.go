@@ -0,0 +1,72 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var SSEResultDSL = func() {
+	Service("SSEResult", func() {
+		Method("Subscribe", func() {
+			StreamingResult(Int)
+			HTTP(func() {
+				GET("/")
+				ServerSentEvents()
+			})
+		})
+	})
+}
+
+var SSEResultWithFieldsDSL = func() {
+	var Event = Type("Event", func() {
+		Attribute("id", String)
+		Attribute("kind", String)
+		Attribute("retry", Int)
+		Attribute("message", String)
+		Required("id", "kind", "message")
+	})
+	Service("SSEResultWithFields", func() {
+		Method("Subscribe", func() {
+			Payload(func() {
+				Attribute("topic", String)
+				Attribute("last_event_id", String)
+			})
+			StreamingResult(Event)
+			HTTP(func() {
+				GET("/{topic}")
+				ServerSentEvents(func() {
+					SSEEventID("id")
+					SSEEventType("kind")
+					SSEEventRetry("retry")
+					SSERequestID("last_event_id")
+				})
+			})
+		})
+	})
+}
+
+var SSEResultWithViewsDSL = func() {
+	var Event = ResultType("application/vnd.event", func() {
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("message", String)
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("message")
+		})
+		View("tiny", func() {
+			Attribute("id")
+		})
+	})
+	Service("SSEResultWithViews", func() {
+		Method("Subscribe", func() {
+			StreamingResult(Event)
+			HTTP(func() {
+				GET("/")
+				ServerSentEvents(func() {
+					SSEEventID("id")
+				})
+			})
+		})
+	})
+}
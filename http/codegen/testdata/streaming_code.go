@@ -216,6 +216,8 @@ func (c *Client) StreamingResultMethod() goa.Endpoint {
 		decodeResponse = DecodeStreamingResultMethodResponse(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultService")
 		req, err := c.BuildStreamingResultMethodRequest(ctx, v)
 		if err != nil {
 			return nil, err
@@ -294,6 +296,8 @@ func (c *Client) StreamingResultWithViewsMethod() goa.Endpoint {
 		decodeResponse = DecodeStreamingResultWithViewsMethodResponse(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultWithViewsMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultWithViewsService")
 		req, err := c.BuildStreamingResultWithViewsMethodRequest(ctx, v)
 		if err != nil {
 			return nil, err
@@ -367,6 +371,8 @@ func (c *Client) StreamingResultWithExplicitViewMethod() goa.Endpoint {
 		decodeResponse = DecodeStreamingResultWithExplicitViewMethodResponse(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultWithExplicitViewMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultWithExplicitViewService")
 		req, err := c.BuildStreamingResultWithExplicitViewMethodRequest(ctx, v)
 		if err != nil {
 			return nil, err
@@ -568,6 +574,8 @@ func (c *Client) StreamingResultCollectionWithExplicitViewMethod() goa.Endpoint
 		decodeResponse = DecodeStreamingResultCollectionWithExplicitViewMethodResponse(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultCollectionWithExplicitViewMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultCollectionWithExplicitViewService")
 		req, err := c.BuildStreamingResultCollectionWithExplicitViewMethodRequest(ctx, v)
 		if err != nil {
 			return nil, err
@@ -870,6 +878,8 @@ func (c *Client) StreamingResultNoPayloadMethod() goa.Endpoint {
 		decodeResponse = DecodeStreamingResultNoPayloadMethodResponse(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultNoPayloadMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultNoPayloadService")
 		req, err := c.BuildStreamingResultNoPayloadMethodRequest(ctx, v)
 		if err != nil {
 			return nil, err
@@ -1011,6 +1021,8 @@ func (c *Client) StreamingPayloadMethod() goa.Endpoint {
 		decodeResponse = DecodeStreamingPayloadMethodResponse(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingPayloadMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingPayloadService")
 		req, err := c.BuildStreamingPayloadMethodRequest(ctx, v)
 		if err != nil {
 			return nil, err
@@ -1126,6 +1138,8 @@ func (c *Client) StreamingPayloadNoPayloadMethod() goa.Endpoint {
 		decodeResponse = DecodeStreamingPayloadNoPayloadMethodResponse(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingPayloadNoPayloadMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingPayloadNoPayloadService")
 		req, err := c.BuildStreamingPayloadNoPayloadMethodRequest(ctx, v)
 		if err != nil {
 			return nil, err
@@ -2192,6 +2206,8 @@ func (c *Client) BidirectionalStreamingMethod() goa.Endpoint {
 		decodeResponse = DecodeBidirectionalStreamingMethodResponse(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "BidirectionalStreamingMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "BidirectionalStreamingService")
 		req, err := c.BuildBidirectionalStreamingMethodRequest(ctx, v)
 		if err != nil {
 			return nil, err
@@ -2331,6 +2347,8 @@ func (c *Client) BidirectionalStreamingNoPayloadMethod() goa.Endpoint {
 		decodeResponse = DecodeBidirectionalStreamingNoPayloadMethodResponse(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "BidirectionalStreamingNoPayloadMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "BidirectionalStreamingNoPayloadService")
 		req, err := c.BuildBidirectionalStreamingNoPayloadMethodRequest(ctx, v)
 		if err != nil {
 			return nil, err
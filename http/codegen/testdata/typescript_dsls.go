@@ -0,0 +1,165 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var TypeScriptDSL = func() {
+	var JWTAuth = JWTSecurity("jwt", func() {
+		Scope("api:read")
+	})
+	var BasicAuth = BasicAuthSecurity("basic")
+	var Operands = Type("Operands", func() {
+		Attribute("a", Int, "Left operand")
+		Attribute("b", Int, "Right operand", func() {
+			Minimum(-100)
+			Maximum(100)
+		})
+		Token("token", String)
+		Required("a", "b")
+	})
+	var Bottle = ResultType("application/vnd.bottle", func() {
+		TypeName("Bottle")
+		Attributes(func() {
+			Attribute("id", String, func() {
+				Pattern("^[a-z0-9]+$")
+			})
+			Attribute("name", String, func() {
+				MinLength(1)
+				Pattern("(?i)^[a-z ]+$")
+			})
+			Attribute("vintage", Int)
+			Attribute("tags", ArrayOf(String))
+			OneOf("content", func() {
+				Attribute("text", String)
+				Attribute("score", Int)
+			})
+			Required("id", "name", "vintage")
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("name")
+			Attribute("vintage")
+			Attribute("tags")
+			Attribute("content")
+		})
+		View("tiny", func() {
+			Attribute("id")
+			Attribute("name")
+		})
+	})
+	Service("calc", func() {
+		Error("unauthorized", String)
+		Method("div", func() {
+			Description("Div divides a by b.")
+			Security(JWTAuth)
+			Payload(Operands)
+			Result(Int)
+			Error("div_by_zero")
+			HTTP(func() {
+				GET("/div/{a}")
+				Param("b")
+				Response(StatusOK)
+				Response("div_by_zero", StatusBadRequest)
+				Response("unauthorized", StatusUnauthorized)
+			})
+		})
+		Method("login", func() {
+			Security(BasicAuth)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+				Required("user", "pass")
+			})
+			Result(String)
+			HTTP(func() {
+				POST("/login")
+				Response("unauthorized", StatusUnauthorized)
+			})
+		})
+	})
+	Service("store", func() {
+		Error("not_found", func() {
+			Attribute("id", String)
+			Attribute("message", String)
+			Required("id", "message")
+		})
+		Method("show", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("view", String, func() {
+					Enum("default", "tiny")
+				})
+				Required("id")
+			})
+			Result(Bottle)
+			Error("not_found")
+			HTTP(func() {
+				GET("/bottles/{id}")
+				Param("view")
+				Response(StatusOK)
+				Response("not_found", StatusNotFound)
+			})
+		})
+		Method("list", func() {
+			Result(CollectionOf(Bottle), func() {
+				View("tiny")
+			})
+			HTTP(func() {
+				GET("/bottles")
+			})
+		})
+		Method("create", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("vintage", Int)
+				Attribute("request_id", String)
+				Required("name", "vintage")
+			})
+			Result(func() {
+				Attribute("id", String)
+				Attribute("location", String)
+				Required("id", "location")
+			})
+			HTTP(func() {
+				POST("/bottles")
+				Header("request_id:X-Request-ID")
+				Response(StatusCreated, func() {
+					Header("location:Location")
+				})
+			})
+		})
+		Method("remove", func() {
+			Payload(String)
+			HTTP(func() {
+				DELETE("/bottles/{id}")
+				Response(StatusNoContent)
+			})
+		})
+		Method("label", func() {
+			Payload(String)
+			HTTP(func() {
+				GET("/bottles/{id}/label")
+				SkipResponseBodyEncodeDecode()
+			})
+		})
+	})
+}
+
+var TypeScriptDisabledDSL = func() {
+	Service("Enabled", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+	Service("Disabled", func() {
+		Meta("typescript:generate", "false")
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,203 @@
+package testdata
+
+var WebSocketSettingsServerHandlerInitCode = `// NewSubscribeHandler creates a HTTP handler which loads the HTTP request and
+// calls the "Ticker" service "Subscribe" endpoint.
+func NewSubscribeHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	upgrader goahttp.Upgrader,
+	configurer goahttp.ConnConfigureFunc,
+) http.Handler {
+	var (
+		encodeError = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	configurer = goahttp.ConfigureWebSocket(&goahttp.WebSocketConfig{PingInterval: 15 * time.Second, PongTimeout: 5 * time.Second}, configurer)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "Subscribe")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "Ticker")
+		var err error
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		v := &ticker.SubscribeEndpointInput{
+			Stream: &SubscribeServerStream{
+				upgrader:   upgrader,
+				configurer: configurer,
+				cancel:     cancel,
+				w:          w,
+				r:          r,
+			},
+		}
+		_, err = endpoint(ctx, v)
+		if err != nil {
+			if v.Stream.(*SubscribeServerStream).conn != nil {
+				// Response writer has been hijacked, do not encode the error
+				errhandler(ctx, w, err)
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+	})
+}
+`
+
+var WebSocketSettingsServerStreamSendCode = `// Send streams instances of "string" to the "Subscribe" endpoint websocket
+// connection.
+func (s *SubscribeServerStream) Send(v string) error {
+	var err error
+	// Upgrade the HTTP connection to a websocket connection only once. Connection
+	// upgrade is done here so that authorization logic in the endpoint is executed
+	// before calling the actual service method which may call Send().
+	s.once.Do(func() {
+		var conn *websocket.Conn
+		conn, err = s.upgrader.Upgrade(s.w, s.r, nil)
+		if err != nil {
+			return
+		}
+		if s.configurer != nil {
+			conn = s.configurer(conn, s.cancel)
+		}
+		s.conn = conn
+	})
+	if err != nil {
+		return err
+	}
+	res := v
+	return s.conn.WriteJSON(res)
+}
+`
+
+var WebSocketSettingsServerStreamRecvCode = `// Recv reads instances of "string" from the "Chat" endpoint websocket
+// connection.
+func (s *ChatServerStream) Recv() (string, error) {
+	var (
+		rv  string
+		msg *string
+		err error
+	)
+	// Upgrade the HTTP connection to a websocket connection only once. Connection
+	// upgrade is done here so that authorization logic in the endpoint is executed
+	// before calling the actual service method which may call Recv().
+	s.once.Do(func() {
+		var conn *websocket.Conn
+		conn, err = s.upgrader.Upgrade(s.w, s.r, nil)
+		if err != nil {
+			return
+		}
+		if s.configurer != nil {
+			conn = s.configurer(conn, s.cancel)
+		}
+		s.conn = conn
+	})
+	if err != nil {
+		return rv, err
+	}
+	if err = s.conn.SetReadDeadline(time.Now().Add(40 * time.Second)); err != nil {
+		return rv, err
+	}
+	if err = s.conn.ReadJSON(&msg); err != nil {
+		return rv, err
+	}
+	if msg == nil {
+		return rv, io.EOF
+	}
+	return *msg, nil
+}
+`
+
+var WebSocketSettingsClientEndpointInitCode = `// Subscribe returns an endpoint that makes HTTP requests to the Ticker service
+// Subscribe server.
+func (c *Client) Subscribe() goa.Endpoint {
+	var (
+		decodeResponse = DecodeSubscribeResponse(c.decoder, c.RestoreResponseBody)
+		configure      = goahttp.ConfigureWebSocket(&goahttp.WebSocketConfig{PingInterval: 15 * time.Second, PongTimeout: 5 * time.Second}, c.configurer.SubscribeFn)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "Subscribe")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "Ticker")
+		req, err := c.BuildSubscribeRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		conn, resp, err := c.dialer.DialContext(ctx, req.URL.String(), req.Header)
+		if err != nil {
+			if resp != nil {
+				return decodeResponse(resp)
+			}
+			return nil, goahttp.ErrRequestError("Ticker", "Subscribe", err)
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		if configure != nil {
+			conn = configure(conn, cancel)
+		}
+		stream := &SubscribeClientStream{conn: conn}
+		stream.reconnect = goahttp.NewWebSocketReconnect(ctx, conn, &goa.RetryPolicy{
+			MaxAttempts:    5,
+			InitialBackoff: 500 * time.Millisecond,
+			MaxBackoff:     30 * time.Second,
+		}, func(ctx context.Context) (*websocket.Conn, error) {
+			conn, _, err := c.dialer.DialContext(ctx, req.URL.String(), req.Header)
+			if err != nil {
+				return nil, err
+			}
+			if configure != nil {
+				conn = configure(conn, cancel)
+			}
+			return conn, nil
+		})
+		return stream, nil
+	}
+}
+`
+
+var WebSocketSettingsClientStreamStructCode = `// SubscribeClientStream implements the ticker.SubscribeClientStream interface.
+type SubscribeClientStream struct {
+	// conn is the underlying websocket connection.
+	conn *websocket.Conn
+	// reconnect re-dials the connection when it drops.
+	reconnect *goahttp.WebSocketReconnect
+}
+`
+
+var WebSocketSettingsClientStreamRecvCode = `// Recv reads instances of "string" from the "Subscribe" endpoint websocket
+// connection.
+func (s *SubscribeClientStream) Recv() (string, error) {
+	var (
+		rv   string
+		body string
+		err  error
+	)
+	s.conn, err = s.reconnect.Read(s.conn, func(conn *websocket.Conn) error {
+		if err := conn.SetReadDeadline(time.Now().Add(20 * time.Second)); err != nil {
+			return err
+		}
+		return conn.ReadJSON(&body)
+	})
+	if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		s.conn.Close()
+		return rv, io.EOF
+	}
+	if err != nil {
+		return rv, err
+	}
+	return body, nil
+}
+`
+
+var WebSocketSettingsClientStreamSendCode = `// Send streams instances of "string" to the "Chat" endpoint websocket
+// connection.
+func (s *ChatClientStream) Send(v string) error {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		return err
+	}
+	return s.conn.WriteJSON(v)
+}
+`
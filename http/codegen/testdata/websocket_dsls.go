@@ -0,0 +1,39 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var WebSocketSettingsDSL = func() {
+	Service("Ticker", func() {
+		HTTP(func() {
+			WebSocket(func() {
+				PingInterval("30s")
+				PongTimeout("10s")
+				MaxMessageSize(1024)
+				WriteTimeout("5s")
+			})
+		})
+		Method("Subscribe", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/ticks")
+				WebSocket(func() {
+					PingInterval("15s")
+					PongTimeout("5s")
+					Reconnect(func() {
+						MaxAttempts(5)
+						Backoff("500ms", "30s")
+					})
+				})
+			})
+		})
+		Method("Chat", func() {
+			StreamingPayload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/chat")
+			})
+		})
+	})
+}
@@ -0,0 +1,617 @@
+package typescript
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// serviceData contains the data needed to render the client of a
+	// service.
+	serviceData struct {
+		// Name is the name of the service.
+		Name string
+		// ClientName is the name of the client class.
+		ClientName string
+		// Comment is the JSDoc comment of the client class.
+		Comment string
+		// Types lists the declarations of the inline method payload and
+		// result types.
+		Types []*typeData
+		// Errors lists the error classes.
+		Errors []*errorData
+		// Methods lists the client methods.
+		Methods []*methodData
+		// Skipped lists the methods that the client does not support.
+		Skipped []*skippedData
+	}
+
+	// skippedData describes a method that the client does not support.
+	skippedData struct {
+		// Name is the name of the method in the design.
+		Name string
+		// Reason explains why the method is not supported.
+		Reason string
+	}
+
+	// methodData contains the data needed to render a client method.
+	methodData struct {
+		// Name is the name of the method in the design.
+		Name string
+		// FuncName is the name of the client method.
+		FuncName string
+		// Comment is the JSDoc comment of the method.
+		Comment string
+		// PayloadRef is the TypeScript type of the payload, empty if the
+		// method has no payload.
+		PayloadRef string
+		// ResultRef is the TypeScript type of the result.
+		ResultRef string
+		// Verb is the HTTP method.
+		Verb string
+		// Path is the TypeScript template literal that builds the request
+		// path.
+		Path string
+		// Query lists the query string parameters.
+		Query []*paramData
+		// Headers lists the request headers.
+		Headers []*paramData
+		// Cookies lists the request cookies.
+		Cookies []*paramData
+		// Body is the TypeScript expression that builds the request body,
+		// empty if the request has no body.
+		Body string
+		// Responses lists the success responses.
+		Responses []*responseData
+		// Errors lists the errors returned by the method.
+		Errors []*errorData
+		// Statuses maps the HTTP status codes used by a single error to the
+		// name of the error.
+		Statuses []*statusData
+	}
+
+	// paramData describes a request parameter, header or cookie.
+	paramData struct {
+		// Key is the object key of the parameter.
+		Key string
+		// Value is the TypeScript expression that computes the value.
+		Value string
+	}
+
+	// responseData describes a success response.
+	responseData struct {
+		// StatusCode is the HTTP status code.
+		StatusCode int
+		// HasBody is true if the response has a body.
+		HasBody bool
+		// Value is the TypeScript expression that builds the result, empty
+		// if the method has no result.
+		Value string
+		// Guard is the TypeScript expression that validates "result",
+		// empty if there is nothing to validate.
+		Guard string
+	}
+
+	// errorData describes a method error.
+	errorData struct {
+		// Name is the name of the error in the design.
+		Name string
+		// Key is the object key of the error name.
+		Key string
+		// ClassName is the name of the error class.
+		ClassName string
+		// Comment is the JSDoc comment of the error class.
+		Comment string
+		// TypeRef is the TypeScript type of the error body.
+		TypeRef string
+		// Value is the TypeScript expression that builds the error value.
+		Value string
+	}
+
+	// statusData maps a HTTP status code to an error.
+	statusData struct {
+		// StatusCode is the HTTP status code.
+		StatusCode int
+		// Name is the name of the error in the design.
+		Name string
+	}
+
+	// importData describes an import statement.
+	importData struct {
+		// Names lists the imported names.
+		Names []string
+		// Path is the path of the imported module.
+		Path string
+	}
+)
+
+// serviceFile returns the file that implements the client of the given
+// service.
+func serviceFile(svc *expr.HTTPServiceExpr, reg *registry) *codegen.File {
+	data := buildServiceData(svc, reg.local())
+	fname := codegen.SnakeCase(codegen.Goify(svc.Name(), true)) + ".ts"
+	sections := []*codegen.SectionTemplate{
+		header(fmt.Sprintf("%s TypeScript client", svc.Name())),
+	}
+	var body []*codegen.SectionTemplate
+	for _, t := range data.Types {
+		body = append(body, &codegen.SectionTemplate{Name: "typescript-type", Source: typeT, Data: t})
+	}
+	for _, e := range data.Errors {
+		body = append(body, &codegen.SectionTemplate{Name: "typescript-error", Source: errorT, Data: e})
+	}
+	body = append(body, &codegen.SectionTemplate{Name: "typescript-client", Source: clientT, Data: data})
+	code := render(body)
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:    "typescript-imports",
+		Source:  importsT,
+		FuncMap: map[string]any{"join": strings.Join},
+		Data: []*importData{
+			{Names: used(code, runtimeNames), Path: "./client"},
+			{Names: used(code, typeNames(reg)), Path: "./types"},
+		},
+	})
+	sections = append(sections, body...)
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", "typescript", fname),
+		SectionTemplates: sections,
+	}
+}
+
+// buildServiceData computes the data needed to render the client of the given
+// service. reg is used to register the inline payload and result types.
+func buildServiceData(svc *expr.HTTPServiceExpr, reg *registry) *serviceData {
+	name := reg.scope.Unique(codegen.Goify(svc.Name(), true) + "Client")
+	desc := fmt.Sprintf("%s is the client of the %q service.", name, svc.Name())
+	if d := svc.Description(); d != "" {
+		desc += "\n\n" + d
+	}
+	data := &serviceData{
+		Name:       svc.Name(),
+		ClientName: name,
+		Comment:    comment(desc, expr.DeprecationOf(svc.ServiceExpr.Meta), ""),
+	}
+	errors := make(map[string]*errorData)
+	for _, e := range svc.HTTPEndpoints {
+		if !mustGenerate(e.MethodExpr.Meta) {
+			continue
+		}
+		if reason := unsupported(e); reason != "" {
+			data.Skipped = append(data.Skipped, &skippedData{Name: e.MethodExpr.Name, Reason: reason})
+			continue
+		}
+		m := e.MethodExpr
+		payload := m.Payload
+		if isMethodType(m, payload) {
+			ut := reg.registerLocal(payload, codegen.Goify(m.Name, true)+"Payload")
+			payload = &expr.AttributeExpr{Type: ut, Meta: payload.Meta}
+			data.Types = append(data.Types, reg.typeData(ut))
+		}
+		result := m.Result
+		if isMethodType(m, result) {
+			ut := reg.registerLocal(result, codegen.Goify(m.Name, true)+"Result")
+			result = &expr.AttributeExpr{Type: ut, Meta: result.Meta}
+			data.Types = append(data.Types, reg.typeData(ut))
+		}
+		md := &methodData{
+			Name:      m.Name,
+			FuncName:  codegen.Goify(m.Name, false),
+			Comment:   comment(methodDescription(m), m.Deprecation(), "\t"),
+			ResultRef: "void",
+			Verb:      e.Routes[0].Method,
+			Path:      path(e, payload),
+		}
+		if payload.Type != expr.Empty {
+			md.PayloadRef = reg.typeRef(payload)
+		}
+		if result.Type != expr.Empty {
+			md.ResultRef = reg.resultRef(result)
+		}
+		buildRequest(md, e, payload, reg)
+		seen := make(map[int]bool)
+		for _, resp := range e.Responses {
+			if seen[resp.StatusCode] {
+				continue
+			}
+			seen[resp.StatusCode] = true
+			rd := &responseData{
+				StatusCode: resp.StatusCode,
+				HasBody:    resp.Body != nil && resp.Body.Type != expr.Empty,
+			}
+			if result.Type != expr.Empty {
+				rd.Value = responseValue(result, resp, reg)
+				if g := reg.resultGuard(result, "result"); g != "true" {
+					rd.Guard = g
+				}
+			}
+			md.Responses = append(md.Responses, rd)
+		}
+		statuses := make(map[int][]string)
+		for _, he := range e.HTTPErrors {
+			ed, ok := errors[he.Name]
+			if !ok {
+				cname := reg.scope.Unique(codegen.Goify(he.Name, true) + "Error")
+				ed = &errorData{
+					Name:      he.Name,
+					Key:       quoteKey(he.Name),
+					ClassName: cname,
+					Comment:   comment(errorDescription(cname, svc.Name(), he.ErrorExpr), nil, ""),
+					TypeRef:   reg.typeRef(he.AttributeExpr),
+					Value:     responseValue(he.AttributeExpr, he.Response, reg),
+				}
+				errors[he.Name] = ed
+				data.Errors = append(data.Errors, ed)
+			}
+			md.Errors = append(md.Errors, ed)
+			statuses[he.Response.StatusCode] = append(statuses[he.Response.StatusCode], he.Name)
+		}
+		for code, names := range statuses {
+			if len(names) == 1 {
+				md.Statuses = append(md.Statuses, &statusData{StatusCode: code, Name: names[0]})
+			}
+		}
+		sort.Slice(md.Statuses, func(i, j int) bool { return md.Statuses[i].StatusCode < md.Statuses[j].StatusCode })
+		data.Methods = append(data.Methods, md)
+	}
+	return data
+}
+
+// unsupported returns the reason why the TypeScript client does not support
+// the given endpoint, the empty string if it does. The client does not support
+// streaming, multipart requests and endpoints that bypass the body encoding
+// and decoding.
+func unsupported(e *expr.HTTPEndpointExpr) string {
+	switch {
+	case e.SSE != nil:
+		return "server-sent events are not supported"
+	case e.MethodExpr.IsStreaming():
+		return "streaming is not supported"
+	case e.MultipartRequest:
+		return "multipart requests are not supported"
+	case e.SkipRequestBodyEncodeDecode:
+		return "raw request bodies are not supported"
+	case e.SkipResponseBodyEncodeDecode:
+		return "raw response bodies are not supported"
+	case e.Redirect != nil:
+		return "redirects are not supported"
+	case len(e.Routes) == 0:
+		return "the method does not define a route"
+	}
+	return ""
+}
+
+// path returns the TypeScript template literal that builds the request path
+// of the first route of the given endpoint.
+func path(e *expr.HTTPEndpointExpr, payload *expr.AttributeExpr) string {
+	p := e.Routes[0].FullPaths()[0]
+	for _, w := range expr.ExtractHTTPWildcards(p) {
+		v := payloadValue(payload, e.Params.KeyName(w))
+		enc := "encodeURIComponent"
+		pattern := "{" + w + "}"
+		if !strings.Contains(p, pattern) {
+			enc = "encodeURI"
+			pattern = "{*" + w + "}"
+		}
+		p = strings.Replace(p, pattern, fmt.Sprintf("${%s(String(%s))}", enc, v), 1)
+	}
+	return "`" + p + "`"
+}
+
+// buildRequest initializes the query string parameters, headers, cookies and
+// body of the given method data.
+func buildRequest(md *methodData, e *expr.HTTPEndpointExpr, payload *expr.AttributeExpr, reg *registry) {
+	wildcards := make(map[string]bool)
+	for _, w := range expr.ExtractHTTPWildcards(e.Routes[0].FullPaths()[0]) {
+		wildcards[w] = true
+	}
+	_ = codegen.WalkMappedAttr(e.Params, func(name, elem string, _ bool, _ *expr.AttributeExpr) error {
+		if !wildcards[elem] {
+			md.Query = append(md.Query, &paramData{Key: quoteKey(elem), Value: payloadValue(payload, name)})
+		}
+		return nil
+	})
+	if e.MapQueryParams != nil {
+		v := "p"
+		if *e.MapQueryParams != "" {
+			v = payloadValue(payload, *e.MapQueryParams)
+		}
+		md.Query = append(md.Query, &paramData{Key: "...", Value: v})
+	}
+	var (
+		p      = payload
+		token  = expr.TaggedAttribute(p, "security:token")
+		access = expr.TaggedAttribute(p, "security:accesstoken")
+	)
+	_ = codegen.WalkMappedAttr(e.Headers, func(name, elem string, _ bool, _ *expr.AttributeExpr) error {
+		v := payloadValue(payload, name)
+		if elem == "Authorization" && name != "" && (name == token || name == access) {
+			v = "bearer(" + v + ")"
+		}
+		md.Headers = append(md.Headers, &paramData{Key: quoteKey(elem), Value: v})
+		return nil
+	})
+	for _, req := range e.Requirements {
+		for _, sch := range req.Schemes {
+			if sch.Kind != expr.BasicAuthKind {
+				continue
+			}
+			user := payloadValue(payload, expr.TaggedAttribute(p, "security:username"))
+			pass := payloadValue(payload, expr.TaggedAttribute(p, "security:password"))
+			md.Headers = append(md.Headers, &paramData{Key: "Authorization", Value: fmt.Sprintf("basicAuth(%s, %s)", user, pass)})
+		}
+	}
+	_ = codegen.WalkMappedAttr(e.Cookies, func(name, elem string, _ bool, _ *expr.AttributeExpr) error {
+		md.Cookies = append(md.Cookies, &paramData{Key: quoteKey(elem), Value: payloadValue(payload, name)})
+		return nil
+	})
+	md.Body = requestBody(e, payload, reg)
+}
+
+// requestBody returns the TypeScript expression that builds the request body
+// of the given endpoint, empty if the request has no body.
+func requestBody(e *expr.HTTPEndpointExpr, payload *expr.AttributeExpr, reg *registry) string {
+	body := e.Body
+	if body == nil || body.Type == expr.Empty {
+		return ""
+	}
+	if o, ok := body.Meta["origin:attribute"]; ok {
+		return reg.encode(payload.Find(o[0]), payloadValue(payload, o[0]))
+	}
+	bobj := expr.AsObject(body.Type)
+	pobj := expr.AsObject(payload.Type)
+	if bobj == nil || pobj == nil || expr.IsUnion(payload.Type) {
+		return reg.encode(payload, "p")
+	}
+	if len(*bobj) == len(*pobj) {
+		return reg.encode(payload, "p")
+	}
+	fields := make([]string, 0, len(*bobj))
+	for _, nat := range *bobj {
+		att := payload.Find(nat.Name)
+		if att == nil {
+			continue
+		}
+		fields = append(fields, fmt.Sprintf("%s: %s", key(att, nat.Name), reg.encode(att, payloadValue(payload, nat.Name))))
+	}
+	return "{ " + strings.Join(fields, ", ") + " }"
+}
+
+// responseValue returns the TypeScript expression that builds the value of the
+// given method result or error from the response body and headers.
+func responseValue(att *expr.AttributeExpr, resp *expr.HTTPResponseExpr, reg *registry) string {
+	hasBody := resp.Body != nil && resp.Body.Type != expr.Empty
+	var fields []string
+	_ = codegen.WalkMappedAttr(resp.Headers, func(name, elem string, _ bool, a *expr.AttributeExpr) error {
+		fields = append(fields, fmt.Sprintf("%s: %s", key(a, name), headerValue(elem, a)))
+		return nil
+	})
+	if resp.Tag[0] != "" {
+		if a := att.Find(resp.Tag[0]); a != nil {
+			fields = append(fields, fmt.Sprintf("%s: %q", key(a, resp.Tag[0]), resp.Tag[1]))
+		}
+	}
+	if expr.AsObject(att.Type) == nil || expr.IsUnion(att.Type) {
+		switch {
+		case hasBody:
+			return reg.decode(att, "body")
+		case len(fields) > 0:
+			return fields[0][strings.Index(fields[0], ": ")+2:]
+		}
+		return "undefined"
+	}
+	if hasBody {
+		if o, ok := resp.Body.Meta["origin:attribute"]; ok {
+			a := att.Find(o[0])
+			fields = append([]string{fmt.Sprintf("%s: %s", key(a, o[0]), reg.decode(a, "body"))}, fields...)
+		} else {
+			if len(fields) == 0 {
+				return reg.decode(att, "body")
+			}
+			fields = append([]string{"..." + reg.decode(att, "body")}, fields...)
+		}
+	}
+	if len(fields) == 0 {
+		return "{}"
+	}
+	return "{ " + strings.Join(fields, ", ") + " }"
+}
+
+// headerValue returns the TypeScript expression that reads the value of the
+// given response header.
+func headerValue(name string, att *expr.AttributeExpr) string {
+	array := ""
+	dt := att.Type
+	if arr := expr.AsArray(dt); arr != nil {
+		array = ", true"
+		dt = arr.ElemType.Type
+	}
+	kind := "string"
+	switch dt.Kind() {
+	case expr.BooleanKind:
+		kind = "boolean"
+	case expr.IntKind, expr.Int32Kind, expr.Int64Kind, expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind:
+		kind = "integer"
+	case expr.Float32Kind, expr.Float64Kind:
+		kind = "number"
+	}
+	return fmt.Sprintf("header(res, %q, %q%s)", name, kind, array)
+}
+
+// payloadValue returns the TypeScript expression that reads the given payload
+// attribute.
+func payloadValue(payload *expr.AttributeExpr, name string) string {
+	if expr.AsObject(payload.Type) == nil {
+		return "p"
+	}
+	if att := payload.Find(name); att != nil {
+		return access("p", jsonName(att, name))
+	}
+	return access("p", name)
+}
+
+// quoteKey quotes the given object key if it is not a valid identifier.
+func quoteKey(k string) string {
+	if identRegex.MatchString(k) {
+		return k
+	}
+	return strconv.Quote(k)
+}
+
+// methodDescription returns the description of a client method.
+func methodDescription(m *expr.MethodExpr) string {
+	if m.Description == "" {
+		return fmt.Sprintf("%s calls the %q method.", codegen.Goify(m.Name, false), m.Name)
+	}
+	return m.Description
+}
+
+// errorDescription returns the description of an error class.
+func errorDescription(name, svc string, e *expr.ErrorExpr) string {
+	desc := fmt.Sprintf("%s is the %q error of the %q service.", name, e.Name, svc)
+	if e.Description != "" {
+		desc += "\n\n" + e.Description
+	}
+	return desc
+}
+
+// runtimeNames lists the names exported by the client runtime that the
+// generated clients may use. Type-only names are prefixed with "type " so
+// that the imports are erased when the code is compiled.
+var runtimeNames = []string{
+	"type ClientOptions", "ServiceError", "ValidationError", "basicAuth", "bearer",
+	"decodeError", "decodeUnion", "encodeUnion", "header", "isRecord",
+	"mapValues", "readBody", "request",
+}
+
+// typeNames returns the names exported by the types module.
+func typeNames(reg *registry) []string {
+	var names []string
+	for _, ut := range reg.types {
+		data := reg.typeData(ut)
+		names = append(names, "type "+data.Name, "is"+data.Name)
+		for _, v := range data.Views {
+			names = append(names, "type "+v.Name, "is"+v.Name)
+		}
+		if data.Encode != "" {
+			names = append(names, "encode"+data.Name, "decode"+data.Name)
+		}
+	}
+	return names
+}
+
+// render returns the code rendered by the given sections.
+func render(sections []*codegen.SectionTemplate) string {
+	var b strings.Builder
+	for _, s := range sections {
+		if err := s.Write(&b); err != nil {
+			panic(err) // bug
+		}
+	}
+	return b.String()
+}
+
+// used returns the names in the given list that are used by code sorted
+// alphabetically. The "type " prefix of type-only names is ignored when
+// matching and sorting.
+func used(code string, names []string) []string {
+	var res []string
+	for _, n := range names {
+		name := strings.TrimPrefix(n, "type ")
+		if regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`).MatchString(code) {
+			res = append(res, n)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return strings.TrimPrefix(res[i], "type ") < strings.TrimPrefix(res[j], "type ")
+	})
+	return res
+}
+
+const (
+	// input: *errorData
+	errorT = `
+{{ .Comment }}
+export class {{ .ClassName }} extends ServiceError<{{ .TypeRef }}> {
+	constructor(status: number, body: {{ .TypeRef }}) {
+		super({{ printf "%q" .Name }}, status, body);
+	}
+}
+`
+
+	// input: *serviceData
+	clientT = `
+{{ .Comment }}
+export class {{ .ClientName }} {
+	private readonly options: ClientOptions;
+
+	constructor(options: ClientOptions) {
+		this.options = options;
+	}
+{{- range .Methods }}
+	{{- $method := . }}
+
+{{ .Comment }}
+	async {{ .FuncName }}({{ if .PayloadRef }}p: {{ .PayloadRef }}{{ end }}): Promise<{{ .ResultRef }}> {
+		const res = await request(this.options, {
+			method: {{ printf "%q" .Verb }},
+			path: {{ .Path }},
+	{{- if .Query }}
+			query: { {{ range $i, $q := .Query }}{{ if $i }}, {{ end }}{{ if eq .Key "..." }}...{{ .Value }}{{ else }}{{ .Key }}: {{ .Value }}{{ end }}{{ end }} },
+	{{- end }}
+	{{- if .Headers }}
+			headers: { {{ range $i, $h := .Headers }}{{ if $i }}, {{ end }}{{ .Key }}: {{ .Value }}{{ end }} },
+	{{- end }}
+	{{- if .Cookies }}
+			cookies: { {{ range $i, $c := .Cookies }}{{ if $i }}, {{ end }}{{ .Key }}: {{ .Value }}{{ end }} },
+	{{- end }}
+	{{- if .Body }}
+			body: {{ .Body }},
+	{{- end }}
+		});
+	{{- range .Responses }}
+		if (res.status === {{ .StatusCode }}) {
+		{{- if .Value }}
+			{{- if .HasBody }}
+			const body = await readBody(res);
+			{{- end }}
+			const result = {{ .Value }};
+			{{- if .Guard }}
+			if (!({{ .Guard }})) {
+				throw new ValidationError({{ printf "%q" $.Name }}, {{ printf "%q" $method.Name }}, result);
+			}
+			{{- end }}
+			return result;
+		{{- else }}
+			return;
+		{{- end }}
+		}
+	{{- end }}
+	{{- if .Errors }}
+		throw await decodeError(res, {{ printf "%q" $.Name }}, {{ printf "%q" .Name }}, {
+		{{- range .Errors }}
+			{{ .Key }}: (body, res) => new {{ .ClassName }}(res.status, {{ .Value }}),
+		{{- end }}
+		}, {
+		{{- range .Statuses }}
+			{{ .StatusCode }}: {{ printf "%q" .Name }},
+		{{- end }}
+		});
+	{{- else }}
+		throw await decodeError(res, {{ printf "%q" $.Name }}, {{ printf "%q" .Name }}, {}, {});
+	{{- end }}
+	}
+{{- end }}
+{{- range .Skipped }}
+
+	// The {{ printf "%q" .Name }} method is not available: {{ .Reason }}.
+{{- end }}
+}
+`
+)
@@ -0,0 +1,7 @@
+/*
+Package typescript generates a TypeScript client SDK for the HTTP services of a
+Goa design. The SDK consists of a runtime module, a module declaring the
+interfaces and type guards of the user types and one module per service
+defining a fetch based client together with typed error classes.
+*/
+package typescript
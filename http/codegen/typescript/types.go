@@ -0,0 +1,821 @@
+package typescript
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// registry assigns TypeScript names to the design user types and renders
+	// the TypeScript type references, type guards and union codecs.
+	registry struct {
+		// scope makes sure the TypeScript type names are unique.
+		scope *codegen.NameScope
+		// names maps the user type IDs to their TypeScript names.
+		names map[string]string
+		// types lists the registered user types in order of registration.
+		types []expr.UserType
+		// codecs caches whether a user type requires a codec.
+		codecs map[string]bool
+	}
+
+	// typeData describes a TypeScript type declaration.
+	typeData struct {
+		// Name is the TypeScript type name.
+		Name string
+		// Comment is the JSDoc comment of the declaration.
+		Comment string
+		// Fields lists the interface fields if the type is an object.
+		Fields []*fieldData
+		// Def is the type definition if the type is not an object.
+		Def string
+		// Views lists the view types of result types that define more
+		// than the default view.
+		Views []*viewData
+		// Guard is the type guard expression that validates "v".
+		Guard string
+		// Encode is the expression that converts "v" to its JSON
+		// representation, empty if the type requires no codec.
+		Encode string
+		// Decode is the expression that converts the JSON representation
+		// "v" to the type, empty if the type requires no codec.
+		Decode string
+	}
+
+	// fieldData describes an interface field.
+	fieldData struct {
+		// Key is the field key, quoted if it is not a valid identifier.
+		Key string
+		// Comment is the JSDoc comment of the field.
+		Comment string
+		// Type is the field TypeScript type.
+		Type string
+		// Optional is true if the field is not required.
+		Optional bool
+	}
+
+	// viewData describes the type of a result type view.
+	viewData struct {
+		// Name is the TypeScript type name of the view.
+		Name string
+		// Comment is the JSDoc comment of the view type.
+		Comment string
+		// Def is the type definition.
+		Def string
+		// Guard is the type guard expression that validates "v".
+		Guard string
+	}
+)
+
+// identRegex matches the keys that do not need quoting.
+var identRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// quantifierRegex matches the repetition quantifiers of a regular expression.
+var quantifierRegex = regexp.MustCompile(`^\{\d+(,\d*)?\}`)
+
+// reserved lists the names of the TypeScript global types and of the client
+// runtime which may not be used to name the design types. "Error", "Record"
+// and "Union" would otherwise shadow the decodeError, isRecord, encodeUnion
+// and decodeUnion runtime functions.
+var reserved = []string{
+	"Array", "Boolean", "ClientOptions", "Date", "Error", "Function",
+	"Headers", "Map", "Number", "Object", "Omit", "Partial", "Pick", "Promise",
+	"Record", "Request", "RequestData", "RequestInit", "Response",
+	"ResponseError", "ServiceError", "Set", "String", "Union",
+	"ValidationError",
+}
+
+// newRegistry returns a registry that holds the user types of the given root
+// as well as the user types used by the methods of the given services.
+func newRegistry(root *expr.RootExpr, svcs []*expr.HTTPServiceExpr) *registry {
+	r := &registry{
+		scope:  codegen.NewNameScope(),
+		names:  make(map[string]string),
+		codecs: make(map[string]bool),
+	}
+	for _, n := range reserved {
+		r.scope.Unique(n)
+	}
+	for _, ut := range root.Types {
+		r.register(ut)
+	}
+	for _, ut := range root.ResultTypes {
+		r.register(ut)
+	}
+	for _, svc := range svcs {
+		for _, e := range svc.HTTPEndpoints {
+			m := e.MethodExpr
+			for _, att := range []*expr.AttributeExpr{m.Payload, m.Result} {
+				if isMethodType(m, att) {
+					// Declared in the service file, see local.
+					if ut, ok := att.Type.(expr.UserType); ok {
+						att = ut.Attribute()
+					}
+				}
+				r.registerAttribute(att)
+			}
+			for _, er := range m.Errors {
+				r.registerAttribute(er.AttributeExpr)
+			}
+		}
+	}
+	return r
+}
+
+// isMethodType returns true if the given method payload or result is an
+// object defined inline in the method or the corresponding user type created
+// by the service code generator.
+func isMethodType(m *expr.MethodExpr, att *expr.AttributeExpr) bool {
+	switch actual := att.Type.(type) {
+	case *expr.Object:
+		return true
+	case *expr.UserTypeExpr:
+		prefix := m.Service.Name + "#" + codegen.Goify(m.Name, true)
+		return actual.ID() == prefix+"Payload" || actual.ID() == prefix+"Result"
+	}
+	return false
+}
+
+// local returns a copy of r that may be used to register the inline types of
+// the methods of a service without affecting r.
+func (r *registry) local() *registry {
+	l := &registry{
+		scope:  codegen.NewNameScope(),
+		names:  make(map[string]string, len(r.names)),
+		types:  nil,
+		codecs: r.codecs,
+	}
+	for _, n := range reserved {
+		l.scope.Unique(n)
+	}
+	for _, ut := range r.types {
+		l.scope.Unique(r.names[ut.ID()])
+	}
+	for k, v := range r.names {
+		l.names[k] = v
+	}
+	return l
+}
+
+// register assigns a TypeScript name to the given user type and to the user
+// types it uses recursively.
+func (r *registry) register(ut expr.UserType) {
+	if ut == expr.Empty {
+		return
+	}
+	if _, ok := r.names[ut.ID()]; ok {
+		return
+	}
+	name := codegen.Goify(ut.Name(), true)
+	if ut == expr.ErrorResult {
+		name = "ErrorResult"
+	}
+	suffix := "Type"
+	if _, ok := ut.(*expr.ResultTypeExpr); ok {
+		suffix = "Result"
+	}
+	r.names[ut.ID()] = r.scope.Unique(name, suffix)
+	r.types = append(r.types, ut)
+	_ = codegen.WalkType(ut, func(att *expr.AttributeExpr) error {
+		if u, ok := att.Type.(expr.UserType); ok {
+			r.register(u)
+		}
+		return nil
+	})
+}
+
+// registerAttribute registers the user types used by the given attribute.
+func (r *registry) registerAttribute(att *expr.AttributeExpr) {
+	if att == nil {
+		return
+	}
+	_ = codegen.Walk(att, func(a *expr.AttributeExpr) error {
+		if u, ok := a.Type.(expr.UserType); ok {
+			r.register(u)
+		}
+		return nil
+	})
+}
+
+// registerLocal registers the given method payload or result type with the
+// given name, see isMethodType. It returns the corresponding user type.
+func (r *registry) registerLocal(att *expr.AttributeExpr, name string) expr.UserType {
+	ut, ok := att.Type.(expr.UserType)
+	if !ok {
+		ut = &expr.UserTypeExpr{AttributeExpr: att, TypeName: name}
+	}
+	r.names[ut.ID()] = r.scope.Unique(name)
+	r.types = append(r.types, ut)
+	return ut
+}
+
+// name returns the TypeScript name of the given user type.
+func (r *registry) name(ut expr.UserType) string {
+	if n, ok := r.names[ut.ID()]; ok {
+		return n
+	}
+	r.register(ut)
+	return r.names[ut.ID()]
+}
+
+// typeData returns the data needed to render the declaration of the given
+// user type.
+func (r *registry) typeData(ut expr.UserType) *typeData {
+	att := ut.Attribute()
+	name := r.name(ut)
+	data := &typeData{
+		Name:    name,
+		Comment: comment(typeDescription(name, att.Description), expr.DeprecationOf(att.Meta), ""),
+		Guard:   r.guard(att, "v", 0),
+	}
+	if obj := expr.AsObject(att.Type); obj != nil && !expr.IsUnion(att.Type) {
+		for _, nat := range *obj {
+			data.Fields = append(data.Fields, &fieldData{
+				Key:      key(nat.Attribute, nat.Name),
+				Comment:  comment(nat.Attribute.Description, expr.DeprecationOf(nat.Attribute.Meta), "\t"),
+				Type:     r.typeRef(nat.Attribute),
+				Optional: !att.IsRequired(nat.Name),
+			})
+		}
+	} else {
+		data.Def = r.typeDef(att)
+	}
+	if r.needsCodec(att) {
+		data.Encode = r.encodeUserType(att, true)
+		data.Decode = r.encodeUserType(att, false)
+	}
+	data.Views = r.viewsData(ut)
+	return data
+}
+
+// viewsData returns the view types of the given result type, nil if the type
+// is not a result type or defines no other view than the default view.
+func (r *registry) viewsData(ut expr.UserType) []*viewData {
+	rt, ok := ut.(*expr.ResultTypeExpr)
+	if !ok || len(rt.Views) < 2 {
+		return nil
+	}
+	name := r.name(ut)
+	if arr := expr.AsArray(rt.Type); arr != nil {
+		elem, ok := arr.ElemType.Type.(*expr.ResultTypeExpr)
+		if !ok {
+			return nil
+		}
+		ename := r.name(elem)
+		views := make([]*viewData, len(rt.Views))
+		for i, v := range rt.Views {
+			vname := viewName(name, v.Name)
+			evname := viewName(ename, v.Name)
+			views[i] = &viewData{
+				Name:    vname,
+				Comment: comment(fmt.Sprintf("%s is the type of the %q view of %s.", vname, v.Name, name), nil, ""),
+				Def:     evname + "[]",
+				Guard:   fmt.Sprintf("Array.isArray(v) && v.every((e0: any) => is%s(e0))", evname),
+			}
+		}
+		return views
+	}
+	views := make([]*viewData, len(rt.Views))
+	for i, v := range rt.Views {
+		vname := viewName(name, v.Name)
+		obj := expr.AsObject(v.Type)
+		keys := make([]string, 0, len(*obj))
+		for _, nat := range *obj {
+			if att := rt.Find(nat.Name); att != nil {
+				keys = append(keys, strconv.Quote(jsonName(att, nat.Name)))
+			}
+		}
+		def := name
+		if len(keys) < len(*expr.AsObject(rt.Type)) {
+			def = fmt.Sprintf("Pick<%s, %s>", name, strings.Join(keys, " | "))
+		}
+		views[i] = &viewData{
+			Name:    vname,
+			Comment: comment(fmt.Sprintf("%s is the type of the %q view of %s.", vname, v.Name, name), nil, ""),
+			Def:     def,
+			Guard:   r.viewGuard(rt, v),
+		}
+	}
+	return views
+}
+
+// viewGuard returns the type guard expression of the given view of a result
+// type.
+func (r *registry) viewGuard(rt *expr.ResultTypeExpr, v *expr.ViewExpr) string {
+	obj := expr.Object{}
+	val := &expr.ValidationExpr{}
+	for _, nat := range *expr.AsObject(v.Type) {
+		att := rt.Find(nat.Name)
+		if att == nil {
+			continue
+		}
+		obj.Set(nat.Name, att)
+		if rt.IsRequired(nat.Name) {
+			val.AddRequired(nat.Name)
+		}
+	}
+	return r.guard(&expr.AttributeExpr{Type: &obj, Validation: val}, "v", 0)
+}
+
+// resultRef returns the TypeScript type of the given method result taking
+// into account the view selected in the design if any.
+func (r *registry) resultRef(att *expr.AttributeExpr) string {
+	rt, ok := att.Type.(*expr.ResultTypeExpr)
+	if !ok || len(rt.Views) < 2 {
+		return r.typeRef(att)
+	}
+	name := r.name(rt)
+	if v, ok := att.Meta.Last("view"); ok {
+		return viewName(name, v)
+	}
+	names := make([]string, len(rt.Views))
+	for i, v := range rt.Views {
+		names[i] = viewName(name, v.Name)
+	}
+	return strings.Join(names, " | ")
+}
+
+// resultGuard returns the type guard expression of the given method result
+// using the value v taking into account the view selected in the design if
+// any.
+func (r *registry) resultGuard(att *expr.AttributeExpr, v string) string {
+	rt, ok := att.Type.(*expr.ResultTypeExpr)
+	if !ok || len(rt.Views) < 2 {
+		return r.guard(att, v, 0)
+	}
+	name := r.name(rt)
+	if view, ok := att.Meta.Last("view"); ok {
+		return fmt.Sprintf("is%s(%s)", viewName(name, view), v)
+	}
+	guards := make([]string, len(rt.Views))
+	for i, view := range rt.Views {
+		guards[i] = fmt.Sprintf("is%s(%s)", viewName(name, view.Name), v)
+	}
+	return strings.Join(guards, " || ")
+}
+
+// typeRef returns the TypeScript type used to reference the given attribute.
+func (r *registry) typeRef(att *expr.AttributeExpr) string {
+	if ut, ok := att.Type.(expr.UserType); ok {
+		return r.name(ut)
+	}
+	return r.typeDef(att)
+}
+
+// typeDef returns the TypeScript definition of the given attribute type.
+func (r *registry) typeDef(att *expr.AttributeExpr) string {
+	switch actual := att.Type.(type) {
+	case expr.UserType:
+		return r.typeDef(actual.Attribute())
+	case expr.Primitive:
+		return primitive(actual)
+	case *expr.Array:
+		elem := r.typeRef(actual.ElemType)
+		if strings.Contains(elem, "|") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case *expr.Map:
+		return "Record<string, " + r.typeRef(actual.ElemType) + ">"
+	case *expr.Object:
+		fields := make([]string, len(*actual))
+		for i, nat := range *actual {
+			opt := "?"
+			if att.IsRequired(nat.Name) {
+				opt = ""
+			}
+			fields[i] = fmt.Sprintf("%s%s: %s", key(nat.Attribute, nat.Name), opt, r.typeRef(nat.Attribute))
+		}
+		return "{ " + strings.Join(fields, "; ") + " }"
+	case *expr.Union:
+		values := make([]string, len(actual.Values))
+		for i, nat := range actual.Values {
+			values[i] = fmt.Sprintf("{ Type: %q; Value: %s }", nat.Name, r.typeRef(nat.Attribute))
+		}
+		return strings.Join(values, " | ")
+	}
+	return "unknown"
+}
+
+// guard returns the TypeScript expression that validates the value v against
+// the given attribute type and validations. depth is used to name the
+// variables of nested closures.
+func (r *registry) guard(att *expr.AttributeExpr, v string, depth int) string {
+	var conds []string
+	switch actual := att.Type.(type) {
+	case expr.UserType:
+		conds = append(conds, fmt.Sprintf("is%s(%s)", r.name(actual), v))
+	case expr.Primitive:
+		switch actual.Kind() {
+		case expr.BooleanKind:
+			conds = append(conds, fmt.Sprintf("typeof %s === \"boolean\"", v))
+		case expr.IntKind, expr.Int32Kind, expr.Int64Kind, expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind:
+			conds = append(conds, fmt.Sprintf("Number.isInteger(%s)", v))
+		case expr.Float32Kind, expr.Float64Kind:
+			conds = append(conds, fmt.Sprintf("typeof %s === \"number\"", v))
+		case expr.StringKind, expr.BytesKind:
+			conds = append(conds, fmt.Sprintf("typeof %s === \"string\"", v))
+		}
+	case *expr.Array:
+		e := fmt.Sprintf("e%d", depth)
+		conds = append(conds, fmt.Sprintf("Array.isArray(%s)", v))
+		if g := r.guard(actual.ElemType, e, depth+1); g != "true" {
+			conds = append(conds, fmt.Sprintf("%s.every((%s: any) => %s)", v, e, g))
+		}
+	case *expr.Map:
+		e := fmt.Sprintf("e%d", depth)
+		conds = append(conds, fmt.Sprintf("isRecord(%s)", v))
+		if g := r.guard(actual.ElemType, e, depth+1); g != "true" {
+			conds = append(conds, fmt.Sprintf("Object.values(%s).every((%s: any) => %s)", v, e, g))
+		}
+	case *expr.Object:
+		conds = append(conds, fmt.Sprintf("isRecord(%s)", v))
+		for _, nat := range *actual {
+			f := access(v, jsonName(nat.Attribute, nat.Name))
+			g := r.guard(nat.Attribute, f, depth)
+			switch {
+			case att.IsRequired(nat.Name):
+				conds = append(conds, fmt.Sprintf("%s !== undefined", f))
+				if g != "true" {
+					conds = append(conds, paren(g))
+				}
+			case g != "true":
+				conds = append(conds, fmt.Sprintf("(%s === undefined || %s)", f, g))
+			}
+		}
+	case *expr.Union:
+		values := make([]string, len(actual.Values))
+		for i, nat := range actual.Values {
+			c := fmt.Sprintf("%s.Type === %q", v, nat.Name)
+			if g := r.guard(nat.Attribute, v+".Value", depth); g != "true" {
+				c += " && " + paren(g)
+			}
+			values[i] = c
+		}
+		conds = append(conds, fmt.Sprintf("isRecord(%s)", v), "("+strings.Join(values, " || ")+")")
+	}
+	if _, ok := att.Type.(expr.UserType); !ok {
+		conds = append(conds, validations(att, v)...)
+	}
+	if len(conds) == 0 {
+		return "true"
+	}
+	return strings.Join(conds, " && ")
+}
+
+// validations returns the TypeScript expressions that check the validations
+// of the given attribute against the value v. Patterns that JavaScript does
+// not support are not checked, the server validates them anyway.
+func validations(att *expr.AttributeExpr, v string) []string {
+	val := att.Validation
+	if val == nil {
+		return nil
+	}
+	var conds []string
+	if len(val.Values) > 0 {
+		js, err := json.Marshal(val.Values)
+		if err == nil {
+			conds = append(conds, fmt.Sprintf("%s.includes(%s)", js, v))
+		}
+	}
+	if val.Pattern != "" {
+		if src, flags, ok := jsPattern(val.Pattern); ok {
+			if flags != "" {
+				conds = append(conds, fmt.Sprintf("new RegExp(%s, %q).test(%s)", strconv.Quote(src), flags, v))
+			} else {
+				conds = append(conds, fmt.Sprintf("new RegExp(%s).test(%s)", strconv.Quote(src), v))
+			}
+		}
+	}
+	length := v + ".length"
+	switch {
+	case expr.IsMap(att.Type):
+		length = fmt.Sprintf("Object.keys(%s).length", v)
+	case !expr.IsArray(att.Type):
+		length = fmt.Sprintf("[...%s].length", v)
+	}
+	if val.MinLength != nil {
+		conds = append(conds, fmt.Sprintf("%s >= %d", length, *val.MinLength))
+	}
+	if val.MaxLength != nil {
+		conds = append(conds, fmt.Sprintf("%s <= %d", length, *val.MaxLength))
+	}
+	if val.Minimum != nil {
+		conds = append(conds, fmt.Sprintf("%s >= %s", v, number(*val.Minimum)))
+	}
+	if val.ExclusiveMinimum != nil {
+		conds = append(conds, fmt.Sprintf("%s > %s", v, number(*val.ExclusiveMinimum)))
+	}
+	if val.Maximum != nil {
+		conds = append(conds, fmt.Sprintf("%s <= %s", v, number(*val.Maximum)))
+	}
+	if val.ExclusiveMaximum != nil {
+		conds = append(conds, fmt.Sprintf("%s < %s", v, number(*val.ExclusiveMaximum)))
+	}
+	return conds
+}
+
+// jsPattern returns the JavaScript regular expression source and flags
+// equivalent to the given Go (RE2) pattern. It rewrites the named groups
+// (?P<name>...) to (?<name>...) and uses the "u" flag for the Unicode classes
+// (\p{...}). It returns false if the pattern is invalid or uses RE2 syntax that
+// JavaScript does not support or interprets differently: flags, \A, \z,
+// \Q...\E, \C, \a, octal escapes, \x{...}, one letter Unicode classes (\pL),
+// POSIX classes ([[:alpha:]]) and character classes starting with "]".
+func jsPattern(pattern string) (string, string, bool) {
+	if _, err := syntax.Parse(pattern, syntax.Perl); err != nil {
+		return "", "", false
+	}
+	var (
+		b       strings.Builder
+		unicode bool // pattern uses Unicode classes
+		loose   bool // pattern is invalid with the "u" flag
+		inClass bool
+	)
+	for i := 0; i < len(pattern); i++ {
+		c, rest := pattern[i], pattern[i:]
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			n := pattern[i+1]
+			switch {
+			case strings.IndexByte("AzQECa0123456789", n) >= 0,
+				n == 'x' && strings.HasPrefix(rest[2:], "{"),
+				(n == 'p' || n == 'P') && !strings.HasPrefix(rest[2:], "{"):
+				return "", "", false
+			case n == 'p' || n == 'P':
+				unicode = true
+				class := rest[:strings.IndexByte(rest, '}')+1]
+				b.WriteString(class)
+				i += len(class) - 1
+				continue
+			case !isAlnum(n):
+				// The "u" flag only allows escaping the syntax
+				// characters and "-" in character classes.
+				loose = loose || !strings.ContainsRune(`^$\.*+?()[]{}|/`, rune(n)) && (n != '-' || !inClass)
+			}
+			b.WriteString(rest[:2])
+			i++
+			continue
+		case inClass:
+			if c == ']' {
+				inClass = false
+			} else if strings.HasPrefix(rest, "[:") {
+				return "", "", false
+			}
+		case c == '[':
+			open := "["
+			if strings.HasPrefix(rest, "[^") {
+				open = "[^"
+			}
+			if strings.HasPrefix(rest[len(open):], "]") || strings.HasPrefix(rest[len(open):], "[:") {
+				return "", "", false
+			}
+			inClass = true
+			b.WriteString(open)
+			i += len(open) - 1
+			continue
+		case c == '{':
+			if q := quantifierRegex.FindString(rest); q != "" {
+				b.WriteString(q)
+				i += len(q) - 1
+				continue
+			}
+			loose = true
+		case c == '}' || c == ']':
+			loose = true
+		case strings.HasPrefix(rest, "(?P<"):
+			b.WriteString("(?<")
+			i += 3
+			continue
+		case strings.HasPrefix(rest, "(?") && !strings.HasPrefix(rest, "(?:") && !strings.HasPrefix(rest, "(?<"):
+			return "", "", false
+		}
+		b.WriteByte(c)
+	}
+	if !unicode {
+		return b.String(), "", true
+	}
+	if loose {
+		return "", "", false
+	}
+	return b.String(), "u", true
+}
+
+// isAlnum returns true if c is an ASCII letter or digit.
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// needsCodec returns true if the JSON representation of the given attribute
+// differs from its TypeScript type, that is if it uses unions which are
+// serialized as objects whose "Value" field holds the JSON encoded value.
+func (r *registry) needsCodec(att *expr.AttributeExpr) bool {
+	return r.hasUnion(att, make(map[string]bool))
+}
+
+func (r *registry) hasUnion(att *expr.AttributeExpr, seen map[string]bool) bool {
+	switch actual := att.Type.(type) {
+	case expr.UserType:
+		if c, ok := r.codecs[actual.ID()]; ok {
+			return c
+		}
+		if seen[actual.ID()] {
+			return false
+		}
+		seen[actual.ID()] = true
+		c := r.hasUnion(actual.Attribute(), seen)
+		r.codecs[actual.ID()] = c
+		return c
+	case *expr.Array:
+		return r.hasUnion(actual.ElemType, seen)
+	case *expr.Map:
+		return r.hasUnion(actual.ElemType, seen)
+	case *expr.Object:
+		for _, nat := range *actual {
+			if r.hasUnion(nat.Attribute, seen) {
+				return true
+			}
+		}
+	case *expr.Union:
+		return true
+	}
+	return false
+}
+
+// encodeUserType returns the body of the codec function of a user type with
+// the given attribute.
+func (r *registry) encodeUserType(att *expr.AttributeExpr, encode bool) string {
+	return r.codec(att, "v", 0, encode, true)
+}
+
+// encode returns the TypeScript expression that converts the value v of the
+// given attribute type to its JSON representation.
+func (r *registry) encode(att *expr.AttributeExpr, v string) string {
+	return r.codec(att, v, 0, true, false)
+}
+
+// decode returns the TypeScript expression that converts the JSON
+// representation v of the given attribute type to the TypeScript type.
+func (r *registry) decode(att *expr.AttributeExpr, v string) string {
+	return r.codec(att, v, 0, false, false)
+}
+
+func (r *registry) codec(att *expr.AttributeExpr, v string, depth int, encode, top bool) string {
+	if !r.needsCodec(att) {
+		return v
+	}
+	fn := "decode"
+	if encode {
+		fn = "encode"
+	}
+	e := fmt.Sprintf("e%d", depth)
+	switch actual := att.Type.(type) {
+	case expr.UserType:
+		if top {
+			return r.codec(actual.Attribute(), v, depth, encode, false)
+		}
+		return fmt.Sprintf("%s%s(%s)", fn, r.name(actual), v)
+	case *expr.Array:
+		return fmt.Sprintf("%s?.map((%s: any) => %s)", v, e, r.codec(actual.ElemType, e, depth+1, encode, false))
+	case *expr.Map:
+		return fmt.Sprintf("mapValues(%s, (%s: any) => %s)", v, e, r.codec(actual.ElemType, e, depth+1, encode, false))
+	case *expr.Object:
+		var fields []string
+		for _, nat := range *actual {
+			if !r.needsCodec(nat.Attribute) {
+				continue
+			}
+			k := key(nat.Attribute, nat.Name)
+			fields = append(fields, fmt.Sprintf("%s: %s", k, r.codec(nat.Attribute, access(v, jsonName(nat.Attribute, nat.Name)), depth, encode, false)))
+		}
+		return fmt.Sprintf("%s == null ? %s : { ...%s, %s }", v, v, v, strings.Join(fields, ", "))
+	case *expr.Union:
+		var codecs []string
+		for _, nat := range actual.Values {
+			if !r.needsCodec(nat.Attribute) {
+				continue
+			}
+			codecs = append(codecs, fmt.Sprintf("%s: (%s: any) => %s", key(nat.Attribute, nat.Name), e, r.codec(nat.Attribute, e, depth+1, encode, false)))
+		}
+		if len(codecs) == 0 {
+			return fmt.Sprintf("%sUnion(%s)", fn, v)
+		}
+		return fmt.Sprintf("%sUnion(%s, { %s })", fn, v, strings.Join(codecs, ", "))
+	}
+	return v
+}
+
+// primitive returns the TypeScript type of the given primitive.
+func primitive(p expr.Primitive) string {
+	switch p.Kind() {
+	case expr.BooleanKind:
+		return "boolean"
+	case expr.IntKind, expr.Int32Kind, expr.Int64Kind, expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind,
+		expr.Float32Kind, expr.Float64Kind:
+		return "number"
+	case expr.StringKind, expr.BytesKind:
+		return "string"
+	}
+	return "unknown"
+}
+
+// jsonName returns the name of the JSON field that holds the given attribute.
+func jsonName(att *expr.AttributeExpr, name string) string {
+	if tag, ok := att.Meta.Last("struct:tag:json"); ok {
+		if n := strings.Split(tag, ",")[0]; n != "" && n != "-" {
+			return n
+		}
+	}
+	return name
+}
+
+// key returns the TypeScript object key of the given attribute.
+func key(att *expr.AttributeExpr, name string) string {
+	n := jsonName(att, name)
+	if identRegex.MatchString(n) {
+		return n
+	}
+	return strconv.Quote(n)
+}
+
+// access returns the TypeScript expression that reads the field with the
+// given JSON name from v.
+func access(v, name string) string {
+	if identRegex.MatchString(name) {
+		return v + "." + name
+	}
+	return fmt.Sprintf("%s[%s]", v, strconv.Quote(name))
+}
+
+// viewName returns the name of the TypeScript type of the given view.
+func viewName(typeName, view string) string {
+	return typeName + codegen.Goify(view, true)
+}
+
+// typeDescription returns the description of a TypeScript type declaration.
+func typeDescription(name, desc string) string {
+	if desc == "" {
+		return name + " is the type of the design type " + name + "."
+	}
+	return desc
+}
+
+// paren wraps the given expression in parentheses if it contains a logical
+// operator.
+func paren(s string) string {
+	if strings.Contains(s, "||") || strings.Contains(s, "&&") {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// number formats the given float as a TypeScript number literal.
+func number(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// comment returns the JSDoc comment for the given text and deprecation
+// indented with the given prefix, empty if there is nothing to document.
+func comment(text string, d *expr.Deprecation, indent string) string {
+	var lines []string
+	if text != "" {
+		lines = append(lines, strings.Split(codegen.WrapText(strings.TrimSpace(text), 76), "\n")...)
+	}
+	if d != nil {
+		dep := "@deprecated"
+		if d.Reason != "" {
+			dep += " " + d.Reason
+		}
+		if !d.Sunset.IsZero() {
+			if d.Reason != "" {
+				dep = codegen.EndSentence(dep)
+			}
+			dep += " Sunset date: " + d.Sunset.Format("2006-01-02") + "."
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, dep)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(indent + "/**\n")
+	for _, l := range lines {
+		if l == "" {
+			b.WriteString(indent + " *\n")
+			continue
+		}
+		b.WriteString(indent + " * " + l + "\n")
+	}
+	b.WriteString(indent + " */")
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package typescript
+
+import (
+	"testing"
+	"time"
+
+	"goa.design/goa/v3/expr"
+)
+
+func TestJSPattern(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Pattern  string
+		Expected string
+		Flags    string
+		OK       bool
+	}{
+		{"simple", `^[a-z0-9]+$`, `^[a-z0-9]+$`, "", true},
+		{"quantifier", `^\d{3,}-\w{2}$`, `^\d{3,}-\w{2}$`, "", true},
+		{"non-capturing", `^(?:ab)+$`, `^(?:ab)+$`, "", true},
+		{"named-group", `^(?P<year>\d{4})$`, `^(?<year>\d{4})$`, "", true},
+		{"escapes", `^\#\.[\-\]]$`, `^\#\.[\-\]]$`, "", true},
+		{"unicode", `^\p{Greek}+\.$`, `^\p{Greek}+\.$`, "u", true},
+		{"unicode-class-dash", `^[\p{L}\-]+$`, `^[\p{L}\-]+$`, "u", true},
+		{"unicode-loose-escape", `^\p{L}\#$`, "", "", false},
+		{"unicode-literal-brace", `^\p{L}{$`, "", "", false},
+		{"flags", `(?i)^abc$`, "", "", false},
+		{"flags-group", `^(?s:.)$`, "", "", false},
+		{"begin-text", `\Aabc`, "", "", false},
+		{"end-text", `abc\z`, "", "", false},
+		{"quote", `\Q.*\E`, "", "", false},
+		{"bell", `\a`, "", "", false},
+		{"octal", `\101`, "", "", false},
+		{"hex-braces", `\x{41}`, "", "", false},
+		{"hex", `\x41`, `\x41`, "", true},
+		{"one-letter-class", `\pL`, "", "", false},
+		{"posix-class", `[[:alpha:]]`, "", "", false},
+		{"posix-class-inside", `[a[:digit:]]`, "", "", false},
+		{"leading-bracket", `[]a]`, "", "", false},
+		{"negated-leading-bracket", `[^]a]`, "", "", false},
+		{"invalid", `(`, "", "", false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			src, flags, ok := jsPattern(c.Pattern)
+			if ok != c.OK {
+				t.Fatalf("got ok %t, expected %t", ok, c.OK)
+			}
+			if src != c.Expected {
+				t.Errorf("got source %q, expected %q", src, c.Expected)
+			}
+			if flags != c.Flags {
+				t.Errorf("got flags %q, expected %q", flags, c.Flags)
+			}
+		})
+	}
+}
+
+func TestCommentDeprecated(t *testing.T) {
+	sunset := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		Name        string
+		Deprecation *expr.Deprecation
+		Expected    string
+	}{
+		{"reason", &expr.Deprecation{Reason: "Use Bar instead"}, "/**\n * @deprecated Use Bar instead\n */"},
+		{"sunset", &expr.Deprecation{Reason: "Use Bar instead.", Sunset: sunset}, "/**\n * @deprecated Use Bar instead. Sunset date: 2030-01-02.\n */"},
+		{"sunset-no-period", &expr.Deprecation{Reason: "Use Bar instead", Sunset: sunset}, "/**\n * @deprecated Use Bar instead. Sunset date: 2030-01-02.\n */"},
+		{"sunset-no-reason", &expr.Deprecation{Sunset: sunset}, "/**\n * @deprecated Sunset date: 2030-01-02.\n */"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if got := comment("", c.Deprecation, ""); got != c.Expected {
+				t.Errorf("got %q, expected %q", got, c.Expected)
+			}
+		})
+	}
+}
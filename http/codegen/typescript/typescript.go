@@ -0,0 +1,454 @@
+package typescript
+
+import (
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Files returns the files of the TypeScript client SDK of the HTTP services
+// defined in the given root: the client runtime, the declarations of the
+// design types and a client per service. The SDK is opt-in: it returns nil
+// unless the API defines the "typescript:generate" meta with a value other
+// than "false". It also returns nil if the design does not define HTTP
+// services.
+func Files(root *expr.RootExpr) []*codegen.File {
+	if root.API == nil || root.API.HTTP == nil || !enabled(root.API.Meta) {
+		return nil
+	}
+	var svcs []*expr.HTTPServiceExpr
+	for _, svc := range root.API.HTTP.Services {
+		if mustGenerate(svc.ServiceExpr.Meta) {
+			svcs = append(svcs, svc)
+		}
+	}
+	if len(svcs) == 0 {
+		return nil
+	}
+	reg := newRegistry(root, svcs)
+	files := []*codegen.File{runtimeFile(), typesFile(reg)}
+	for _, svc := range svcs {
+		files = append(files, serviceFile(svc, reg))
+	}
+	return files
+}
+
+// enabled returns true if the API meta enables the generation of the
+// TypeScript client SDK.
+func enabled(meta expr.MetaExpr) bool {
+	v, ok := meta["typescript:generate"]
+	return ok && (len(v) == 0 || v[len(v)-1] != "false")
+}
+
+// mustGenerate returns true if the meta indicates that the TypeScript client
+// code should be generated, false otherwise.
+func mustGenerate(meta expr.MetaExpr) bool {
+	if m, ok := meta.Last("typescript:generate"); ok && m == "false" {
+		return false
+	}
+	return true
+}
+
+// runtimeFile returns the file that implements the functions used by the
+// generated clients to make the requests and decode the responses.
+func runtimeFile() *codegen.File {
+	return &codegen.File{
+		Path: filepath.Join(codegen.Gendir, "http", "typescript", "client.ts"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			header("TypeScript client runtime"),
+			{Name: "typescript-runtime", Source: runtimeT},
+		},
+	}
+}
+
+// typesFile returns the file that declares the TypeScript types of the design
+// user types together with their type guards and codecs.
+func typesFile(reg *registry) *codegen.File {
+	var body []*codegen.SectionTemplate
+	for _, ut := range reg.types {
+		body = append(body, &codegen.SectionTemplate{
+			Name:   "typescript-type",
+			Source: typeT,
+			Data:   reg.typeData(ut),
+		})
+	}
+	sections := []*codegen.SectionTemplate{
+		header("TypeScript types"),
+		{
+			Name:    "typescript-imports",
+			Source:  importsT,
+			FuncMap: map[string]any{"join": strings.Join},
+			Data: []*importData{{
+				Names: used(render(body), []string{"decodeUnion", "encodeUnion", "isRecord", "mapValues"}),
+				Path:  "./client",
+			}},
+		},
+	}
+	sections = append(sections, body...)
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", "typescript", "types.ts"),
+		SectionTemplates: sections,
+	}
+}
+
+// header returns the header section of a TypeScript file.
+func header(title string) *codegen.SectionTemplate {
+	return &codegen.SectionTemplate{
+		Name:   "typescript-header",
+		Source: headerT,
+		Data: map[string]any{
+			"Title":       title,
+			"ToolVersion": goa.Version(),
+		},
+	}
+}
+
+const (
+	// input: map[string]any{"Title": string, "ToolVersion": string}
+	headerT = `// Code generated by goa {{ .ToolVersion }}, DO NOT EDIT.
+//
+// {{ .Title }}
+//
+// Command:
+{{ comment commandLine }}
+`
+
+	// input: []*importData
+	importsT = `{{ range . }}{{ if .Names }}
+import { {{ join .Names ", " }} } from "{{ .Path }}";
+{{- end }}{{ end }}
+`
+
+	// input: *typeData
+	typeT = `
+{{- if .Comment }}
+{{ .Comment }}
+{{- end }}
+{{- if .Fields }}
+export interface {{ .Name }} {
+	{{- range .Fields }}
+		{{- if .Comment }}
+{{ .Comment }}
+		{{- end }}
+	{{ .Key }}{{ if .Optional }}?{{ end }}: {{ .Type }};
+	{{- end }}
+}
+{{- else }}
+export type {{ .Name }} = {{ .Def }};
+{{- end }}
+
+/**
+ * is{{ .Name }} returns true if v is a valid {{ .Name }}.
+ */
+export function is{{ .Name }}(v: any): v is {{ .Name }} {
+	return {{ .Guard }};
+}
+{{- range .Views }}
+
+{{ .Comment }}
+export type {{ .Name }} = {{ .Def }};
+
+/**
+ * is{{ .Name }} returns true if v is a valid {{ .Name }}.
+ */
+export function is{{ .Name }}(v: any): v is {{ .Name }} {
+	return {{ .Guard }};
+}
+{{- end }}
+{{- if .Encode }}
+
+/**
+ * encode{{ .Name }} returns the JSON representation of v.
+ */
+export function encode{{ .Name }}(v: {{ .Name }}): any {
+	return {{ .Encode }};
+}
+
+/**
+ * decode{{ .Name }} returns the {{ .Name }} represented by the JSON value v.
+ */
+export function decode{{ .Name }}(v: any): {{ .Name }} {
+	return {{ .Decode }};
+}
+{{- end }}
+`
+
+	runtimeT = `
+/**
+ * ClientOptions configures the generated service clients.
+ */
+export interface ClientOptions {
+	/**
+	 * baseURL is the scheme, host and base path of the server, for example
+	 * "https://api.example.com".
+	 */
+	baseURL: string;
+	/**
+	 * fetch is the function used to make the requests, defaults to the
+	 * global fetch function.
+	 */
+	fetch?: typeof fetch;
+	/**
+	 * headers are added to all the requests.
+	 */
+	headers?: Record<string, string>;
+	/**
+	 * init is merged into the options given to fetch, for example to set the
+	 * credentials mode or an abort signal.
+	 */
+	init?: RequestInit;
+}
+
+/**
+ * RequestData describes a request made by a generated client. Undefined
+ * parameters, headers and cookies are omitted and arrays are sent as multiple
+ * query string parameters or as comma separated header values.
+ */
+export interface RequestData {
+	method: string;
+	path: string;
+	query?: Record<string, unknown>;
+	headers?: Record<string, unknown>;
+	cookies?: Record<string, unknown>;
+	body?: unknown;
+}
+
+/**
+ * ServiceError is the base class of the errors defined in the design. errorName
+ * is the name of the error in the design and body the decoded error response.
+ */
+export class ServiceError<T = unknown> extends Error {
+	readonly errorName: string;
+	readonly status: number;
+	readonly body: T;
+
+	constructor(errorName: string, status: number, body: T) {
+		super(errorMessage(errorName, body));
+		Object.setPrototypeOf(this, new.target.prototype);
+		this.name = new.target.name;
+		this.errorName = errorName;
+		this.status = status;
+		this.body = body;
+	}
+}
+
+/**
+ * ResponseError is the error returned when the server responds with a status
+ * code that does not correspond to a result or error defined in the design.
+ */
+export class ResponseError extends Error {
+	readonly status: number;
+	readonly body: unknown;
+
+	constructor(service: string, method: string, status: number, body: unknown) {
+		super(service + "." + method + ": unexpected response status " + status);
+		Object.setPrototypeOf(this, new.target.prototype);
+		this.name = "ResponseError";
+		this.status = status;
+		this.body = body;
+	}
+}
+
+/**
+ * ValidationError is the error returned when a response does not validate
+ * against the design.
+ */
+export class ValidationError extends Error {
+	readonly value: unknown;
+
+	constructor(service: string, method: string, value: unknown) {
+		super(service + "." + method + ": invalid response");
+		Object.setPrototypeOf(this, new.target.prototype);
+		this.name = "ValidationError";
+		this.value = value;
+	}
+}
+
+/**
+ * request makes the HTTP request described by data.
+ */
+export async function request(options: ClientOptions, data: RequestData): Promise<Response> {
+	const url = new URL(options.baseURL.replace(/\/+$/, "") + data.path);
+	for (const [name, value] of Object.entries(data.query ?? {})) {
+		if (value === undefined || value === null) {
+			continue;
+		}
+		for (const v of Array.isArray(value) ? value : [value]) {
+			url.searchParams.append(name, String(v));
+		}
+	}
+	const headers = new Headers(options.headers);
+	new Headers(options.init?.headers).forEach((value, name) => headers.set(name, value));
+	for (const [name, value] of Object.entries(data.headers ?? {})) {
+		if (value === undefined || value === null) {
+			continue;
+		}
+		headers.set(name, Array.isArray(value) ? value.map(String).join(",") : String(value));
+	}
+	const cookies: string[] = [];
+	for (const [name, value] of Object.entries(data.cookies ?? {})) {
+		if (value !== undefined && value !== null) {
+			cookies.push(name + "=" + encodeURIComponent(String(value)));
+		}
+	}
+	if (cookies.length > 0) {
+		headers.set("Cookie", cookies.join("; "));
+	}
+	const init: RequestInit = { ...options.init, method: data.method, headers };
+	if (data.body !== undefined) {
+		headers.set("Content-Type", "application/json");
+		init.body = JSON.stringify(data.body);
+	}
+	return (options.fetch ?? fetch)(url.toString(), init);
+}
+
+/**
+ * readBody returns the decoded JSON body of the response, undefined if the
+ * body is empty.
+ */
+export async function readBody(res: Response): Promise<any> {
+	const text = await res.text();
+	if (text === "") {
+		return undefined;
+	}
+	try {
+		return JSON.parse(text);
+	} catch {
+		return text;
+	}
+}
+
+/**
+ * decodeError returns the error corresponding to the response. errors maps the
+ * names of the errors defined in the design to the functions that build them,
+ * statuses maps the HTTP status codes to the names of the errors for the
+ * status codes used by a single error.
+ */
+export async function decodeError(
+	res: Response,
+	service: string,
+	method: string,
+	errors: Record<string, (body: any, res: Response) => Error>,
+	statuses: Record<number, string>,
+): Promise<Error> {
+	const body = await readBody(res);
+	const name = res.headers.get("goa-error") ?? statuses[res.status];
+	const build = name === undefined ? undefined : errors[name];
+	if (build !== undefined) {
+		return build(body, res);
+	}
+	return new ResponseError(service, method, res.status, body);
+}
+
+/**
+ * header returns the value of the given response header converted to kind,
+ * undefined if the header is not set.
+ */
+export function header(res: Response, name: string, kind: "string" | "number" | "integer" | "boolean", array = false): any {
+	const value = res.headers.get(name);
+	if (value === null) {
+		return undefined;
+	}
+	const convert = (v: string): unknown => {
+		switch (kind) {
+			case "number":
+			case "integer":
+				return Number(v);
+			case "boolean":
+				return v === "true";
+			default:
+				return v;
+		}
+	};
+	if (array) {
+		return value.split(",").map((v) => convert(v.trim()));
+	}
+	return convert(value);
+}
+
+/**
+ * basicAuth returns the value of the Authorization header that uses the basic
+ * authentication scheme with the given credentials.
+ */
+export function basicAuth(username: string | undefined, password: string | undefined): string | undefined {
+	if (username === undefined && password === undefined) {
+		return undefined;
+	}
+	const bytes = new TextEncoder().encode((username ?? "") + ":" + (password ?? ""));
+	let binary = "";
+	bytes.forEach((b) => {
+		binary += String.fromCharCode(b);
+	});
+	return "Basic " + btoa(binary);
+}
+
+/**
+ * bearer adds the Bearer scheme to the given token unless it already specifies
+ * a scheme.
+ */
+export function bearer(token: string | undefined): string | undefined {
+	if (token === undefined || token.includes(" ")) {
+		return token;
+	}
+	return "Bearer " + token;
+}
+
+/**
+ * isRecord returns true if v is a non null object that is not an array.
+ */
+export function isRecord(v: unknown): v is Record<string, any> {
+	return typeof v === "object" && v !== null && !Array.isArray(v);
+}
+
+/**
+ * mapValues returns a copy of the map v where each value is converted with fn.
+ */
+export function mapValues(v: Record<string, any> | undefined, fn: (e: any) => any): any {
+	if (v === undefined || v === null) {
+		return v;
+	}
+	const res: Record<string, any> = {};
+	for (const [k, e] of Object.entries(v)) {
+		res[k] = fn(e);
+	}
+	return res;
+}
+
+/**
+ * encodeUnion returns the JSON representation of the union value v: an object
+ * whose Type field is the name of the value type and whose Value field is the
+ * JSON encoded value. codecs maps the names of the value types that require
+ * conversion to the functions that convert them.
+ */
+export function encodeUnion(v: { Type: string; Value: unknown } | undefined, codecs: Record<string, (e: any) => any> = {}): any {
+	if (v === undefined || v === null) {
+		return v;
+	}
+	const codec = codecs[v.Type];
+	return { Type: v.Type, Value: JSON.stringify(codec ? codec(v.Value) : v.Value) };
+}
+
+/**
+ * decodeUnion returns the union value represented by the JSON value v, see
+ * encodeUnion.
+ */
+export function decodeUnion(v: any, codecs: Record<string, (e: any) => any> = {}): any {
+	if (!isRecord(v) || typeof v.Value !== "string") {
+		return v;
+	}
+	const value = JSON.parse(v.Value);
+	const codec = codecs[v.Type];
+	return { Type: v.Type, Value: codec ? codec(value) : value };
+}
+
+function errorMessage(name: string, body: unknown): string {
+	if (isRecord(body) && typeof body.message === "string") {
+		return body.message;
+	}
+	return name;
+}
+`
+)
@@ -0,0 +1,99 @@
+package typescript_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	httpgen "goa.design/goa/v3/http/codegen"
+	"goa.design/goa/v3/http/codegen/testdata"
+	"goa.design/goa/v3/http/codegen/typescript"
+)
+
+var update = flag.Bool("update", false, "update .golden files")
+
+func TestFiles(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"typescript", testdata.TypeScriptDSL},
+		{"disabled", testdata.TypeScriptDisabledDSL},
+		{"multiple-views", testdata.MultipleViewsDSL},
+		{"security", testdata.SecurityDSL},
+		{"union", testdata.ResultBodyUnionDSL},
+		{"deprecated", testdata.DeprecatedDSL},
+		{"path-with-wildcards", testdata.PathWithWildcardDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := httpgen.RunHTTPDSL(t, c.DSL)
+			if root.API.Meta == nil {
+				root.API.Meta = expr.MetaExpr{}
+			}
+			root.API.Meta["typescript:generate"] = []string{"true"}
+			files := typescript.Files(root)
+			for _, f := range files {
+				name := filepath.Base(f.Path)
+				if name == "client.ts" && c.Name != "typescript" {
+					// The runtime does not depend on the design.
+					continue
+				}
+				t.Run(name, func(t *testing.T) {
+					var buf bytes.Buffer
+					// Skip the header which contains the command line.
+					for _, s := range f.SectionTemplates[1:] {
+						if err := s.Write(&buf); err != nil {
+							t.Fatalf("failed to render template: %s", err)
+						}
+					}
+					golden := filepath.Join("testdata", "golden", fmt.Sprintf("%s_%s.golden", c.Name, name))
+					if *update {
+						if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+							t.Fatalf("failed to update golden file: %s", err)
+						}
+					}
+					want, err := os.ReadFile(golden)
+					if err != nil {
+						t.Fatalf("failed to read golden file: %s", err)
+					}
+					want = bytes.ReplaceAll(want, []byte{'\r', '\n'}, []byte{'\n'})
+					if !bytes.Equal(buf.Bytes(), want) {
+						diff := codegen.Diff(t, buf.String(), string(want))
+						t.Errorf("result does not match the golden file, got vs. expected:\n%s\n", diff)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestFilesOptIn(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Meta     []string
+		Expected bool
+	}{
+		{"no-meta", nil, false},
+		{"disabled", []string{"false"}, false},
+		{"no-value", []string{}, true},
+		{"enabled", []string{"true"}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := httpgen.RunHTTPDSL(t, testdata.TypeScriptDisabledDSL)
+			if c.Meta != nil {
+				root.API.Meta = expr.MetaExpr{"typescript:generate": c.Meta}
+			}
+			files := typescript.Files(root)
+			if got := len(files) > 0; got != c.Expected {
+				t.Errorf("got files %t, expected %t", got, c.Expected)
+			}
+		})
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
 )
 
@@ -61,6 +63,32 @@ type (
 		// Kind is the kind of the stream (payload, result or
 		// bidirectional).
 		Kind expr.StreamKind
+		// Config contains the connection settings defined with the
+		// WebSocket DSL if any.
+		Config *WebSocketConfigData
+		// Reconnect contains the policy used by the client stream to
+		// re-dial dropped connections if any.
+		Reconnect *service.RetryData
+	}
+
+	// WebSocketConfigData contains the data needed to render the
+	// goahttp.WebSocketConfig initialization code and to apply the read and
+	// write deadlines in the stream methods.
+	WebSocketConfigData struct {
+		// PingInterval is the Go code for the interval between two
+		// pings if any.
+		PingInterval string
+		// PongTimeout is the Go code for the pong timeout if any.
+		PongTimeout string
+		// MaxMessageSize is the maximum size of the messages read from
+		// the connection if not zero.
+		MaxMessageSize int64
+		// WriteTimeout is the Go code for the write timeout if any.
+		WriteTimeout string
+		// ReadTimeout is the Go code for the read deadline set before
+		// each read, that is the ping interval plus the pong timeout, if
+		// any.
+		ReadTimeout string
 	}
 )
 
@@ -78,9 +106,8 @@ func initWebSocketData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceDa
 		cliRecvDesc     string
 		cliPayload      *TypeData
 
-		md     = ed.Method
-		svc    = sd.Service
-		svcctx = serviceContext(sd.Service.PkgName, sd.Service.Scope)
+		md  = ed.Method
+		svc = sd.Service
 	)
 	{
 		svrSendTypeName = ed.Result.Name
@@ -90,89 +117,7 @@ func initWebSocketData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceDa
 		if e.MethodExpr.Stream == expr.ClientStreamKind || e.MethodExpr.Stream == expr.BidirectionalStreamKind {
 			svrRecvTypeName = sd.Scope.GoFullTypeName(e.MethodExpr.StreamingPayload, svc.PkgName)
 			svrRecvTypeRef = sd.Scope.GoFullTypeRef(e.MethodExpr.StreamingPayload, svc.PkgName)
-			svrPayload = buildRequestBodyType(e.StreamingBody, e.MethodExpr.StreamingPayload, e, true, sd)
-			if needInit(e.MethodExpr.StreamingPayload.Type) {
-				makeHTTPType(e.StreamingBody)
-				body := e.StreamingBody.Type
-				// generate constructor function to transform request body,
-				// into the method streaming payload type
-				var (
-					name       string
-					desc       string
-					serverArgs []*InitArgData
-					serverCode string
-					err        error
-				)
-				{
-					n := codegen.Goify(e.MethodExpr.Name, true)
-					p := codegen.Goify(svrPayload.Name, true)
-					// Raw payload object has type name prefixed with endpoint name. No need to
-					// prefix the type name again.
-					if strings.HasPrefix(p, n) {
-						name = fmt.Sprintf("New%s", p)
-					} else {
-						name = fmt.Sprintf("New%s%s", n, p)
-					}
-					desc = fmt.Sprintf("%s builds a %s service %s endpoint payload.", name, svc.Name, e.MethodExpr.Name)
-					if body != expr.Empty {
-						var (
-							ref    string
-							svcode string
-						)
-						{
-							ref = "body"
-							if expr.IsObject(body) {
-								ref = "&body"
-							}
-							if ut, ok := body.(expr.UserType); ok {
-								if val := ut.Attribute().Validation; val != nil {
-									httpctx := httpContext("", sd.Scope, true, true)
-									svcode = codegen.ValidationCode(ut.Attribute(), ut, httpctx, true, expr.IsAlias(ut), "body")
-								}
-							}
-						}
-						serverArgs = []*InitArgData{{
-							Ref: ref,
-							AttributeData: &AttributeData{
-								Name:     "payload",
-								VarName:  "body",
-								TypeName: sd.Scope.GoTypeName(e.StreamingBody),
-								TypeRef:  sd.Scope.GoTypeRef(e.StreamingBody),
-								Type:     e.StreamingBody.Type,
-								Required: true,
-								Example:  e.Body.Example(expr.Root.API.ExampleGenerator),
-								Validate: svcode,
-							},
-						}}
-					}
-					if body != expr.Empty {
-						var helpers []*codegen.TransformFunctionData
-						httpctx := httpContext("", sd.Scope, true, true)
-						serverCode, helpers, err = marshal(e.StreamingBody, e.MethodExpr.StreamingPayload, "body", "v", httpctx, svcctx)
-						if err == nil {
-							sd.ServerTransformHelpers = codegen.AppendHelpers(sd.ServerTransformHelpers, helpers)
-						}
-					}
-					if err != nil {
-						fmt.Println(err.Error()) // TBD validate DSL so errors are not possible
-					}
-				}
-				svrPayload.Init = &InitData{
-					Name:           name,
-					Description:    desc,
-					ServerArgs:     serverArgs,
-					ReturnTypeName: svc.Scope.GoFullTypeName(e.MethodExpr.StreamingPayload, svc.PkgName),
-					ReturnTypeRef:  svc.Scope.GoFullTypeRef(e.MethodExpr.StreamingPayload, svc.PkgName),
-					ReturnIsStruct: expr.IsObject(e.MethodExpr.StreamingPayload.Type),
-					ReturnTypePkg:  svc.PkgName,
-					ServerCode:     serverCode,
-				}
-			}
-			cliPayload = buildRequestBodyType(e.StreamingBody, e.MethodExpr.StreamingPayload, e, false, sd)
-			if cliPayload != nil {
-				sd.ClientTypeNames[cliPayload.Name] = false
-				sd.ServerTypeNames[cliPayload.Name] = false
-			}
+			svrPayload, cliPayload = buildStreamingPayloadData(e, sd)
 			if e.MethodExpr.Stream == expr.ClientStreamKind {
 				svrSendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint websocket connection and closes the connection.", md.ServerStream.SendName, svrSendTypeName, md.Name)
 				cliRecvDesc = fmt.Sprintf("%s stops sending messages to the %q endpoint websocket connection and reads instances of %q from the connection.", md.ClientStream.RecvName, md.Name, svrSendTypeName)
@@ -181,6 +126,21 @@ func initWebSocketData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceDa
 			cliSendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint websocket connection.", md.ClientStream.SendName, svrRecvTypeName, md.Name)
 		}
 	}
+	var (
+		config    *WebSocketConfigData
+		reconnect *service.RetryData
+	)
+	if ws := e.WebSocketSettings(); ws != nil {
+		config = buildWebSocketConfigData(ws)
+		if r := ws.Reconnect; r != nil && e.MethodExpr.Stream == expr.ServerStreamKind {
+			reconnect = &service.RetryData{
+				MaxAttempts:    r.MaxAttempts,
+				InitialBackoff: service.DurationCode(r.InitialBackoff),
+				MaxBackoff:     service.DurationCode(r.MaxBackoff),
+				Jitter:         r.Jitter,
+			}
+		}
+	}
 	ed.ServerWebSocket = &WebSocketData{
 		VarName:           md.ServerStream.VarName,
 		Interface:         fmt.Sprintf("%s.%s", svc.PkgName, md.ServerStream.Interface),
@@ -200,6 +160,7 @@ func initWebSocketData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceDa
 		RecvTypeRef:       svrRecvTypeRef,
 		RecvTypeIsPointer: expr.IsArray(e.MethodExpr.StreamingPayload.Type) || expr.IsMap(e.MethodExpr.StreamingPayload.Type),
 		MustClose:         md.ServerStream.MustClose,
+		Config:            config,
 	}
 	ed.ClientWebSocket = &WebSocketData{
 		VarName:      md.ClientStream.VarName,
@@ -219,9 +180,146 @@ func initWebSocketData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceDa
 		RecvTypeName: svrSendTypeName,
 		RecvTypeRef:  svrSendTypeRef,
 		MustClose:    md.ClientStream.MustClose,
+		Config:       config,
+		Reconnect:    reconnect,
 	}
 }
 
+// buildStreamingPayloadData builds the server and client data of the body
+// types used to stream the payloads of the given endpoint, the server data
+// includes the constructor that transforms the body into the method streaming
+// payload if needed.
+func buildStreamingPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) (svrPayload, cliPayload *TypeData) {
+	var (
+		svc    = sd.Service
+		svcctx = serviceContext(sd.Service.PkgName, sd.Service.Scope)
+	)
+	svrPayload = buildRequestBodyType(e.StreamingBody, e.MethodExpr.StreamingPayload, e, true, sd)
+	if needInit(e.MethodExpr.StreamingPayload.Type) {
+		makeHTTPType(e.StreamingBody)
+		body := e.StreamingBody.Type
+		// generate constructor function to transform request body,
+		// into the method streaming payload type
+		var (
+			name       string
+			desc       string
+			serverArgs []*InitArgData
+			serverCode string
+			err        error
+		)
+		{
+			n := codegen.Goify(e.MethodExpr.Name, true)
+			p := codegen.Goify(svrPayload.Name, true)
+			// Raw payload object has type name prefixed with endpoint name. No need to
+			// prefix the type name again.
+			if strings.HasPrefix(p, n) {
+				name = fmt.Sprintf("New%s", p)
+			} else {
+				name = fmt.Sprintf("New%s%s", n, p)
+			}
+			desc = fmt.Sprintf("%s builds a %s service %s endpoint payload.", name, svc.Name, e.MethodExpr.Name)
+			if body != expr.Empty {
+				var (
+					ref    string
+					svcode string
+				)
+				{
+					ref = "body"
+					if expr.IsObject(body) {
+						ref = "&body"
+					}
+					if ut, ok := body.(expr.UserType); ok {
+						if val := ut.Attribute().Validation; val != nil {
+							httpctx := httpContext("", sd.Scope, true, true)
+							svcode = codegen.ValidationCode(ut.Attribute(), ut, httpctx, true, expr.IsAlias(ut), "body")
+						}
+					}
+				}
+				serverArgs = []*InitArgData{{
+					Ref: ref,
+					AttributeData: &AttributeData{
+						Name:     "payload",
+						VarName:  "body",
+						TypeName: sd.Scope.GoTypeName(e.StreamingBody),
+						TypeRef:  sd.Scope.GoTypeRef(e.StreamingBody),
+						Type:     e.StreamingBody.Type,
+						Required: true,
+						Example:  e.Body.Example(expr.Root.API.ExampleGenerator),
+						Validate: svcode,
+					},
+				}}
+			}
+			if body != expr.Empty {
+				var helpers []*codegen.TransformFunctionData
+				httpctx := httpContext("", sd.Scope, true, true)
+				serverCode, helpers, err = marshal(e.StreamingBody, e.MethodExpr.StreamingPayload, "body", "v", httpctx, svcctx)
+				if err == nil {
+					sd.ServerTransformHelpers = codegen.AppendHelpers(sd.ServerTransformHelpers, helpers)
+				}
+			}
+			if err != nil {
+				fmt.Println(err.Error()) // TBD validate DSL so errors are not possible
+			}
+		}
+		svrPayload.Init = &InitData{
+			Name:           name,
+			Description:    desc,
+			ServerArgs:     serverArgs,
+			ReturnTypeName: svc.Scope.GoFullTypeName(e.MethodExpr.StreamingPayload, svc.PkgName),
+			ReturnTypeRef:  svc.Scope.GoFullTypeRef(e.MethodExpr.StreamingPayload, svc.PkgName),
+			ReturnIsStruct: expr.IsObject(e.MethodExpr.StreamingPayload.Type),
+			ReturnTypePkg:  svc.PkgName,
+			ServerCode:     serverCode,
+		}
+	}
+	cliPayload = buildRequestBodyType(e.StreamingBody, e.MethodExpr.StreamingPayload, e, false, sd)
+	if cliPayload != nil {
+		sd.ClientTypeNames[cliPayload.Name] = false
+		sd.ServerTypeNames[cliPayload.Name] = false
+	}
+	return
+}
+
+// buildWebSocketConfigData builds the data needed to render the connection
+// settings defined by the given WebSocket expression.
+func buildWebSocketConfigData(ws *expr.HTTPWebSocketExpr) *WebSocketConfigData {
+	code := func(d time.Duration) string {
+		if d == 0 {
+			return ""
+		}
+		return service.DurationCode(d)
+	}
+	data := &WebSocketConfigData{
+		PingInterval:   code(ws.PingInterval),
+		PongTimeout:    code(ws.PongTimeout),
+		MaxMessageSize: ws.MaxMessageSize,
+		WriteTimeout:   code(ws.WriteTimeout),
+	}
+	if ws.PingInterval > 0 && ws.PongTimeout > 0 {
+		data.ReadTimeout = service.DurationCode(ws.PingInterval + ws.PongTimeout)
+	}
+	return data
+}
+
+// webSocketConfig returns the Go code that initializes the
+// goahttp.WebSocketConfig value described by c.
+func webSocketConfig(c *WebSocketConfigData) string {
+	var fields []string
+	if c.PingInterval != "" {
+		fields = append(fields, "PingInterval: "+c.PingInterval)
+	}
+	if c.PongTimeout != "" {
+		fields = append(fields, "PongTimeout: "+c.PongTimeout)
+	}
+	if c.MaxMessageSize > 0 {
+		fields = append(fields, fmt.Sprintf("MaxMessageSize: %d", c.MaxMessageSize))
+	}
+	if c.WriteTimeout != "" {
+		fields = append(fields, "WriteTimeout: "+c.WriteTimeout)
+	}
+	return "&goahttp.WebSocketConfig{" + strings.Join(fields, ", ") + "}"
+}
+
 // websocketServerFile returns the file implementing the WebSocket server
 // streaming implementation if any.
 func websocketServerFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
@@ -451,6 +549,30 @@ func isWebSocketEndpoint(ed *EndpointData) bool {
 	return ed.ServerWebSocket != nil || ed.ClientWebSocket != nil
 }
 
+// serverStreamingPayload returns the server type data of the body used to
+// stream the endpoint payloads over WebSocket or NDJSON if any.
+func serverStreamingPayload(ed *EndpointData) *TypeData {
+	switch {
+	case ed.ServerWebSocket != nil:
+		return ed.ServerWebSocket.Payload
+	case ed.ServerNDJSON != nil:
+		return ed.ServerNDJSON.Payload
+	}
+	return nil
+}
+
+// clientStreamingPayload returns the client type data of the body used to
+// stream the endpoint payloads over WebSocket or NDJSON if any.
+func clientStreamingPayload(ed *EndpointData) *TypeData {
+	switch {
+	case ed.ClientWebSocket != nil:
+		return ed.ClientWebSocket.Payload
+	case ed.ClientNDJSON != nil:
+		return ed.ClientNDJSON.Payload
+	}
+	return nil
+}
+
 const (
 	// webSocketStructTypeT renders the server and client struct types that
 	// implements the client and server stream interfaces. The data to render
@@ -472,6 +594,10 @@ type {{ .VarName }} struct {
 {{- end }}
 	{{ comment "conn is the underlying websocket connection." }}
 	conn *websocket.Conn
+{{- if .Reconnect }}
+	{{ comment "reconnect re-dials the connection when it drops." }}
+	reconnect *goahttp.WebSocketReconnect
+{{- end }}
 	{{- if .Endpoint.Method.ViewedResult }}
 		{{- if not .Endpoint.Method.ViewedResult.ViewName }}
 	{{ printf "view is the view to render %s result type before sending to the websocket connection." .SendTypeName | comment }}
@@ -522,6 +648,7 @@ func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
 	{{- else }} {{/* SendAndClose */}}
 		defer s.conn.Close()
 	{{- end }}
+	{{- template "websocket_write_deadline" . }}
 	{{- if .Endpoint.Method.ViewedResult }}
 		{{- if .Endpoint.Method.ViewedResult.ViewName }}
 			res := {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(v, {{ printf "%q" .Endpoint.Method.ViewedResult.ViewName }})
@@ -559,6 +686,7 @@ func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
 		return s.conn.WriteJSON(res)
 	{{- end }}
 {{- else }}
+	{{- template "websocket_write_deadline" . }}
 	{{- if .Payload.Init }}
 		body := {{ .Payload.Init.Name }}(v)
 		return s.conn.WriteJSON(body)
@@ -567,7 +695,7 @@ func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
 	{{- end }}
 {{- end }}
 }
-` + upgradeT
+` + upgradeT + deadlineT
 
 	// webSocketRecvT renders the function implementing the Recv method in
 	// stream interface.
@@ -589,6 +717,7 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 	)
 {{- if eq .Type "server" }}
 	{{- template "websocket_upgrade" (upgradeParams .Endpoint .RecvName) }}
+	{{- template "websocket_read_deadline" . }}
 	{{- if .RecvTypeIsPointer }}
 	if err = s.conn.ReadJSON(&body); err != nil {
 	{{- else }}
@@ -625,7 +754,19 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 			return rv, err
 		}
 	{{- end }}
+	{{- if .Reconnect }}
+	s.conn, err = s.reconnect.Read(s.conn, func(conn *websocket.Conn) error {
+		{{- if and .Config .Config.ReadTimeout }}
+		if err := conn.SetReadDeadline(time.Now().Add({{ .Config.ReadTimeout }})); err != nil {
+			return err
+		}
+		{{- end }}
+		return conn.ReadJSON(&body)
+	})
+	{{- else }}
+	{{- template "websocket_read_deadline" . }}
 	err = s.conn.ReadJSON(&body)
+	{{- end }}
 	if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
 		{{- if not .MustClose }}
 			s.conn.Close()
@@ -657,7 +798,7 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 	{{- end }}
 {{- end }}
 }
-` + upgradeT
+` + upgradeT + deadlineT
 
 	// upgradeT renders the code to upgrade the HTTP connection to a gorilla
 	// websocket connection.
@@ -696,6 +837,25 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 		return {{ if eq .Function "Recv" }}rv, {{ end }}err
 	}
 {{- end }}
+`
+
+	// deadlineT renders the code that sets the read and write deadlines of
+	// the websocket connection before reading or writing a message when
+	// the design defines a pong timeout or a write timeout.
+	deadlineT = `{{- define "websocket_write_deadline" }}
+	{{- if and .Config .Config.WriteTimeout }}
+	if err := s.conn.SetWriteDeadline(time.Now().Add({{ .Config.WriteTimeout }})); err != nil {
+		return err
+	}
+	{{- end }}
+{{- end }}
+{{- define "websocket_read_deadline" }}
+	{{- if and .Config .Config.ReadTimeout }}
+	if err = s.conn.SetReadDeadline(time.Now().Add({{ .Config.ReadTimeout }})); err != nil {
+		return rv, err
+	}
+	{{- end }}
+{{- end }}
 `
 
 	// webSocketCloseT renders the function implementing the Close method in
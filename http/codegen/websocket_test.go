@@ -0,0 +1,34 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestServerWebSocketSettings(t *testing.T) {
+	cases := []*testCase{
+		{"websocket-settings", testdata.WebSocketSettingsDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.WebSocketSettingsServerHandlerInitCode},
+			{"server-websocket-send", &testdata.WebSocketSettingsServerStreamSendCode},
+			{"server-websocket-recv", &testdata.WebSocketSettingsServerStreamRecvCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
+
+func TestClientWebSocketSettings(t *testing.T) {
+	cases := []*testCase{
+		{"client-websocket-settings", testdata.WebSocketSettingsDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.WebSocketSettingsClientEndpointInitCode},
+			{"client-websocket-struct-type", &testdata.WebSocketSettingsClientStreamStructCode},
+			{"client-websocket-recv", &testdata.WebSocketSettingsClientStreamRecvCode},
+			{"client-websocket-send", &testdata.WebSocketSettingsClientStreamSendCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ClientFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
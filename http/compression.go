@@ -0,0 +1,309 @@
+package http
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// Compressor describes how to compress and decompress HTTP bodies for a
+	// given content coding. Compressors are registered with
+	// RegisterCompressor and used by the compression middleware, by
+	// ResponseDecoder and by the client option returned by WithCompression.
+	Compressor struct {
+		// NewWriter creates a writer that compresses the data written to
+		// it and writes the result to w. Closing the writer flushes any
+		// pending data, it does not close w.
+		NewWriter func(w io.Writer) (io.WriteCloser, error)
+		// NewReader creates a reader that decompresses the data read
+		// from r.
+		NewReader func(r io.Reader) (io.ReadCloser, error)
+	}
+
+	// ClientOption customizes the HTTP clients created by the generated
+	// New<Service>Client functions. A client option wraps the doer used to
+	// make the requests.
+	ClientOption func(Doer) Doer
+
+	// compressorRegistry stores the registered compressors.
+	compressorRegistry struct {
+		sync.RWMutex
+		// compressors indexes the compressors by content coding.
+		compressors map[string]*Compressor
+		// order lists the content codings in registration order.
+		order []string
+	}
+
+	// compressionDoer is a Doer that compresses request bodies and
+	// decompresses response bodies.
+	compressionDoer struct {
+		Doer
+		// encoding is the content coding used to compress the request
+		// bodies, empty if request bodies are not compressed.
+		encoding string
+	}
+
+	// decompressReader lazily creates the decompressing reader so that
+	// invalid compressed data is reported by the first call to Read.
+	decompressReader struct {
+		body io.ReadCloser
+		c    *Compressor
+		r    io.ReadCloser
+		err  error
+	}
+)
+
+// compressors is the default compressor registry.
+var compressors = newCompressorRegistry()
+
+// RegisterCompressor registers compressor for the given content coding, e.g.
+// "br". Registering a compressor for a content coding that already has one
+// replaces it. The default registry contains compressors for gzip and deflate
+// using the default compression level.
+//
+// RegisterCompressor is typically called from an init function:
+//
+//	func init() {
+//	    goahttp.RegisterCompressor("br", &goahttp.Compressor{
+//	        NewWriter: func(w io.Writer) (io.WriteCloser, error) { return brotli.NewWriter(w), nil },
+//	        NewReader: func(r io.Reader) (io.ReadCloser, error) { return io.NopCloser(brotli.NewReader(r)), nil },
+//	    })
+//	}
+func RegisterCompressor(encoding string, c *Compressor) {
+	compressors.register(encoding, c)
+}
+
+// LookupCompressor returns the compressor registered for the given content
+// coding if any.
+func LookupCompressor(encoding string) (*Compressor, bool) {
+	return compressors.lookup(encoding)
+}
+
+// NegotiateEncoding returns the content coding that best matches the given
+// Accept-Encoding header value. The candidates are the given content codings
+// in order of preference or all the registered content codings in
+// registration order if none is given. Candidates with no registered
+// compressor are ignored. NegotiateEncoding returns an empty string if no
+// candidate is acceptable in which case the response should not be
+// compressed.
+func NegotiateEncoding(acceptEncoding string, encodings ...string) string {
+	if len(encodings) == 0 {
+		compressors.RLock()
+		encodings = append(encodings, compressors.order...)
+		compressors.RUnlock()
+	}
+	accepted := parseAcceptEncoding(acceptEncoding)
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, enc := range encodings {
+		enc = strings.ToLower(enc)
+		if _, ok := compressors.lookup(enc); !ok {
+			continue
+		}
+		q, ok := accepted[enc]
+		if !ok {
+			q = accepted["*"]
+		}
+		if q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+// WithCompression returns a client option that sets the Accept-Encoding
+// header of the requests to the registered content codings and decompresses
+// the response bodies accordingly. If encoding is not empty the request
+// bodies are also compressed using the compressor registered for encoding.
+// WithCompression panics if encoding is not empty and has no registered
+// compressor.
+//
+// Example:
+//
+//	c := client.NewClient(scheme, host, doer, enc, dec, false, goahttp.WithCompression("gzip"))
+func WithCompression(encoding string) ClientOption {
+	encoding = strings.ToLower(encoding)
+	if encoding != "" {
+		if _, ok := LookupCompressor(encoding); !ok {
+			panic(fmt.Sprintf("goa: no compressor registered for content coding %q", encoding))
+		}
+	}
+	return func(d Doer) Doer {
+		return &compressionDoer{Doer: d, encoding: encoding}
+	}
+}
+
+// DecompressBody returns a reader that decompresses body using the compressor
+// registered for the given Content-Encoding header value. DecompressBody
+// returns body unchanged if encoding is empty or "identity" and an error if
+// there is no compressor registered for encoding.
+func DecompressBody(body io.ReadCloser, encoding string) (io.ReadCloser, error) {
+	encoding = strings.ToLower(strings.TrimSpace(encoding))
+	if encoding == "" || encoding == "identity" {
+		return body, nil
+	}
+	c, ok := LookupCompressor(encoding)
+	if !ok {
+		return nil, fmt.Errorf("unsupported content coding %q", encoding)
+	}
+	return &decompressReader{body: body, c: c}, nil
+}
+
+// Do compresses the request body if needed, makes the request and
+// decompresses the response body.
+func (d *compressionDoer) Do(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		compressors.RLock()
+		req.Header.Set("Accept-Encoding", strings.Join(compressors.order, ", "))
+		compressors.RUnlock()
+	}
+	if d.encoding != "" && req.Body != nil && req.Body != http.NoBody && req.Header.Get("Content-Encoding") == "" {
+		if err := compressRequest(req, d.encoding); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := d.Doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	enc := resp.Header.Get("Content-Encoding")
+	if enc == "" {
+		return resp, nil
+	}
+	body, err := DecompressBody(resp.Body, enc)
+	if err != nil {
+		resp.Body.Close() // nolint: errcheck
+		return nil, err
+	}
+	resp.Body = body
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// compressRequest replaces the request body with a reader that streams the
+// compressed content of the original body. The compressed body may only be
+// read again if the original request defines GetBody.
+func compressRequest(req *http.Request, encoding string) error {
+	c, _ := LookupCompressor(encoding)
+	body, err := compressBody(c, req.Body)
+	if err != nil {
+		req.Body.Close() // nolint: errcheck
+		return err
+	}
+	req.Body = body
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return compressBody(c, body)
+		}
+	}
+	req.ContentLength = -1
+	req.Header.Del("Content-Length")
+	req.Header.Set("Content-Encoding", encoding)
+	return nil
+}
+
+// compressBody returns a reader that compresses body as it is read. The
+// returned reader closes body once fully read or closed.
+func compressBody(c *Compressor, body io.ReadCloser) (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	w, err := c.NewWriter(pw)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer body.Close() // nolint: errcheck
+		if _, err := io.Copy(w, body); err != nil {
+			pw.CloseWithError(err) // nolint: errcheck
+			return
+		}
+		pw.CloseWithError(w.Close()) // nolint: errcheck
+	}()
+	return pr, nil
+}
+
+// Read decompresses the underlying body.
+func (r *decompressReader) Read(p []byte) (int, error) {
+	if r.r == nil && r.err == nil {
+		r.r, r.err = r.c.NewReader(r.body)
+	}
+	if r.err != nil {
+		return 0, r.err
+	}
+	return r.r.Read(p)
+}
+
+// Close closes the decompressing reader and the underlying body.
+func (r *decompressReader) Close() error {
+	if r.r != nil {
+		r.r.Close() // nolint: errcheck
+	}
+	return r.body.Close()
+}
+
+func newCompressorRegistry() *compressorRegistry {
+	r := &compressorRegistry{compressors: make(map[string]*Compressor)}
+	r.register("gzip", &Compressor{
+		NewWriter: func(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil },
+		NewReader: func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) },
+	})
+	r.register("deflate", &Compressor{
+		NewWriter: func(w io.Writer) (io.WriteCloser, error) { return flate.NewWriter(w, flate.DefaultCompression) },
+		NewReader: func(r io.Reader) (io.ReadCloser, error) { return flate.NewReader(r), nil },
+	})
+	return r
+}
+
+func (r *compressorRegistry) register(encoding string, c *Compressor) {
+	encoding = strings.ToLower(strings.TrimSpace(encoding))
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.compressors[encoding]; !ok {
+		r.order = append(r.order, encoding)
+	}
+	r.compressors[encoding] = c
+}
+
+func (r *compressorRegistry) lookup(encoding string) (*Compressor, bool) {
+	r.RLock()
+	defer r.RUnlock()
+	c, ok := r.compressors[strings.ToLower(strings.TrimSpace(encoding))]
+	return c, ok
+}
+
+// parseAcceptEncoding returns the quality values of the content codings
+// listed in the given Accept-Encoding header value indexed by lowercase
+// content coding. Entries that cannot be parsed are omitted.
+func parseAcceptEncoding(acceptEncoding string) map[string]float64 {
+	accepted := make(map[string]float64)
+	for _, s := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(s, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		if k, v, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(k) == "q" {
+			var err error
+			if q, err = strconv.ParseFloat(strings.TrimSpace(v), 64); err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+		accepted[name] = q
+	}
+	return accepted
+}
@@ -0,0 +1,151 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	cases := map[string]struct {
+		accept    string
+		encodings []string
+		expected  string
+	}{
+		"empty":            {"", nil, ""},
+		"gzip":             {"gzip", nil, "gzip"},
+		"deflate":          {"deflate, br", nil, "deflate"},
+		"quality":          {"gzip;q=0.5, deflate", nil, "deflate"},
+		"server order":     {"deflate, gzip", nil, "gzip"},
+		"wildcard":         {"*", nil, "gzip"},
+		"excluded":         {"*, gzip;q=0", nil, "deflate"},
+		"identity":         {"identity", nil, ""},
+		"unregistered":     {"br", []string{"br"}, ""},
+		"preferred":        {"gzip, deflate", []string{"deflate", "gzip"}, "deflate"},
+		"case insensitive": {"GZIP", nil, "gzip"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			if got := NegotiateEncoding(c.accept, c.encodings...); got != c.expected {
+				t.Errorf("got %q, expected %q", got, c.expected)
+			}
+		})
+	}
+}
+
+func TestCompressionDoer(t *testing.T) {
+	const payload = `{"name":"goa"}`
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		if got := req.Header.Get("Accept-Encoding"); got != "gzip, deflate" {
+			t.Errorf("got Accept-Encoding %q, expected %q", got, "gzip, deflate")
+		}
+		if got := req.Header.Get("Content-Encoding"); got != "gzip" {
+			t.Errorf("got Content-Encoding %q, expected %q", got, "gzip")
+		}
+		if req.ContentLength != -1 {
+			t.Errorf("got ContentLength %d, expected -1", req.ContentLength)
+		}
+		gr, err := gzip.NewReader(req.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(gr)
+		if string(b) != payload {
+			t.Errorf("got request body %q, expected %q", string(b), payload)
+		}
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		gw.Write(b) // nolint: errcheck
+		gw.Close()  // nolint: errcheck
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Encoding": {"gzip"}, "Content-Type": {"application/json"}},
+			Body:       io.NopCloser(&buf),
+		}, nil
+	})
+	d := WithCompression("gzip")(doer)
+	req, _ := http.NewRequest("POST", "http://example.com", strings.NewReader(payload))
+	resp, err := d.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Header.Get("Content-Encoding"); got != "" {
+		t.Errorf("got response Content-Encoding %q, expected none", got)
+	}
+	var v struct{ Name string }
+	if err := ResponseDecoder(resp).Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != "goa" {
+		t.Errorf("got name %q, expected %q", v.Name, "goa")
+	}
+}
+
+func TestCompressRequestGetBody(t *testing.T) {
+	const payload = "goa"
+	cases := []struct {
+		Name    string
+		Body    io.Reader
+		GetBody bool
+	}{
+		{"replayable", strings.NewReader(payload), true},
+		{"stream", io.NopCloser(strings.NewReader(payload)), false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "http://example.com", c.Body)
+			if err := compressRequest(req, "gzip"); err != nil {
+				t.Fatal(err)
+			}
+			if (req.GetBody != nil) != c.GetBody {
+				t.Fatalf("got GetBody %v, expected %v", req.GetBody != nil, c.GetBody)
+			}
+			bodies := []io.ReadCloser{req.Body}
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					t.Fatal(err)
+				}
+				bodies = append(bodies, body)
+			}
+			for _, body := range bodies {
+				gr, err := gzip.NewReader(body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				b, err := io.ReadAll(gr)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(b) != payload {
+					t.Errorf("got body %q, expected %q", string(b), payload)
+				}
+			}
+		})
+	}
+}
+
+func TestResponseDecoderDecompress(t *testing.T) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	gw.Write([]byte(`"goa"`)) // nolint: errcheck
+	gw.Close()                // nolint: errcheck
+	resp := &http.Response{
+		Header: http.Header{"Content-Encoding": {"gzip"}},
+		Body:   io.NopCloser(&buf),
+	}
+	var v string
+	if err := ResponseDecoder(resp).Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	if v != "goa" {
+		t.Errorf("got %q, expected %q", v, "goa")
+	}
+}
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
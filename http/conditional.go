@@ -0,0 +1,401 @@
+package http
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// CachedResponse is a response recorded by a response cache.
+	CachedResponse struct {
+		// Status is the response status code.
+		Status int
+		// Header contains the response headers.
+		Header http.Header
+		// Body is the response body.
+		Body []byte
+	}
+
+	// ResponseCache is the interface implemented by the caches used by the
+	// client option returned by WithResponseCache.
+	ResponseCache interface {
+		// Get returns the response cached under key if any.
+		Get(key string) (*CachedResponse, bool)
+		// Set caches resp under key.
+		Set(key string, resp *CachedResponse)
+	}
+
+	// MemoryResponseCache is a ResponseCache that keeps the most recently
+	// used responses in memory.
+	MemoryResponseCache struct {
+		mu      sync.Mutex
+		max     int
+		entries map[string]*list.Element
+		lru     *list.List
+	}
+
+	// cacheEntry is an element of the MemoryResponseCache LRU list.
+	cacheEntry struct {
+		key  string
+		resp *CachedResponse
+	}
+
+	// cachingDoer is a Doer that makes conditional requests using the
+	// validators of the cached responses.
+	cachingDoer struct {
+		Doer
+		cache ResponseCache
+	}
+
+	// bufferedWriter is a http.ResponseWriter that buffers the response
+	// until the conditional request headers are evaluated.
+	bufferedWriter struct {
+		http.ResponseWriter
+		status int
+		body   bytes.Buffer
+	}
+)
+
+// HashETag returns a strong entity tag computed from the SHA-256 hash of the
+// given response body.
+func HashETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// HandleConditional returns a handler that implements conditional requests
+// (RFC 7232) for the responses written by h. The handler stores the request
+// preconditions in the request context so that service methods may check them
+// with goa.CheckPreconditions. It then buffers the response written by h and,
+// for GET and HEAD requests with a 2xx response, evaluates the preconditions
+// against the "ETag" and "Last-Modified" response headers: it writes a 304
+// (Not Modified) response if the "If-None-Match" or "If-Modified-Since"
+// conditions are not met and a 412 (Precondition Failed) response if the
+// "If-Match" or "If-Unmodified-Since" conditions are not met. If etag is not
+// nil and h does not set the "ETag" header then the handler sets it to the
+// value returned by etag given the response body. Unquoted "ETag" header
+// values are quoted.
+func HandleConditional(h http.Handler, etag func([]byte) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pre := parsePreconditions(r.Header)
+		if pre != nil {
+			r = r.WithContext(goa.WithPreconditions(r.Context(), pre))
+		}
+		bw := &bufferedWriter{ResponseWriter: w}
+		h.ServeHTTP(bw, r)
+		if bw.status == 0 {
+			bw.status = http.StatusOK
+		}
+		if bw.status < 200 || bw.status >= 300 {
+			bw.flush()
+			return
+		}
+		hdr := w.Header()
+		tag := hdr.Get("ETag")
+		if tag == "" && etag != nil {
+			tag = etag(bw.body.Bytes())
+		}
+		if tag != "" {
+			tag = quoteETag(tag)
+			hdr.Set("ETag", tag)
+		}
+		if pre == nil || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+			bw.flush()
+			return
+		}
+		var lastModified time.Time
+		if lm := hdr.Get("Last-Modified"); lm != "" {
+			lastModified, _ = http.ParseTime(lm)
+		}
+		if len(pre.IfMatch) > 0 {
+			if !goa.MatchETag(pre.IfMatch, tag, false) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+		} else if !pre.IfUnmodifiedSince.IsZero() && !lastModified.IsZero() {
+			if lastModified.Truncate(time.Second).After(pre.IfUnmodifiedSince) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+		}
+		if len(pre.IfNoneMatch) > 0 {
+			if goa.MatchETag(pre.IfNoneMatch, tag, true) {
+				writeNotModified(w)
+				return
+			}
+		} else if !pre.IfModifiedSince.IsZero() && !lastModified.IsZero() {
+			if !lastModified.Truncate(time.Second).After(pre.IfModifiedSince) {
+				writeNotModified(w)
+				return
+			}
+		}
+		bw.flush()
+	})
+}
+
+// SetPreconditions sets the conditional headers of req from the
+// preconditions held by ctx. The generated clients call SetPreconditions for
+// the endpoints whose design uses the ETag or LastModified DSL.
+func SetPreconditions(ctx context.Context, req *http.Request) {
+	p := goa.ContextPreconditions(ctx)
+	if p == nil {
+		return
+	}
+	if len(p.IfMatch) > 0 {
+		req.Header.Set("If-Match", joinETags(p.IfMatch))
+	}
+	if len(p.IfNoneMatch) > 0 {
+		req.Header.Set("If-None-Match", joinETags(p.IfNoneMatch))
+	}
+	if !p.IfModifiedSince.IsZero() {
+		req.Header.Set("If-Modified-Since", p.IfModifiedSince.UTC().Format(http.TimeFormat))
+	}
+	if !p.IfUnmodifiedSince.IsZero() {
+		req.Header.Set("If-Unmodified-Since", p.IfUnmodifiedSince.UTC().Format(http.TimeFormat))
+	}
+}
+
+// WithResponseCache returns a client option that caches the responses to GET
+// requests that include an "ETag" or "Last-Modified" header. Subsequent
+// requests made to the same URL send the corresponding "If-None-Match" and
+// "If-Modified-Since" headers and the cached response is returned when the
+// server responds with 304 (Not Modified). Requests that set conditional
+// headers explicitly are not modified.
+//
+// Example:
+//
+//	c := client.NewClient(scheme, host, doer, enc, dec, false, goahttp.WithResponseCache(goahttp.NewMemoryResponseCache(1000)))
+func WithResponseCache(cache ResponseCache) ClientOption {
+	return func(d Doer) Doer {
+		return &cachingDoer{Doer: d, cache: cache}
+	}
+}
+
+// NewMemoryResponseCache returns a response cache that keeps up to max
+// responses in memory, evicting the least recently used responses first.
+func NewMemoryResponseCache(max int) *MemoryResponseCache {
+	return &MemoryResponseCache{
+		max:     max,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get implements ResponseCache.
+func (c *MemoryResponseCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	return el.Value.(*cacheEntry).resp, true
+}
+
+// Set implements ResponseCache.
+func (c *MemoryResponseCache) Set(key string, resp *CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*cacheEntry).resp = resp
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, resp: resp})
+	for c.max > 0 && c.lru.Len() > c.max {
+		el := c.lru.Back()
+		c.lru.Remove(el)
+		delete(c.entries, el.Value.(*cacheEntry).key)
+	}
+}
+
+// Do makes a conditional request if a response to req is cached.
+func (d *cachingDoer) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return d.Doer.Do(req)
+	}
+	for _, h := range []string{"If-Match", "If-None-Match", "If-Modified-Since", "If-Unmodified-Since"} {
+		if req.Header.Get(h) != "" {
+			return d.Doer.Do(req)
+		}
+	}
+	key := req.URL.String()
+	if accept := req.Header.Get("Accept"); accept != "" {
+		key += " " + accept
+	}
+	cached, ok := d.cache.Get(key)
+	if ok {
+		if tag := cached.Header.Get("ETag"); tag != "" {
+			req.Header.Set("If-None-Match", tag)
+		}
+		if lm := cached.Header.Get("Last-Modified"); lm != "" {
+			req.Header.Set("If-Modified-Since", lm)
+		}
+	}
+	resp, err := d.Doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if ok && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		header := cached.Header.Clone()
+		for k, v := range resp.Header {
+			header[k] = v
+		}
+		return &http.Response{
+			Status:        strconv.Itoa(cached.Status) + " " + http.StatusText(cached.Status),
+			StatusCode:    cached.Status,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+			ContentLength: int64(len(cached.Body)),
+			Request:       req,
+		}, nil
+	}
+	if resp.StatusCode != http.StatusOK || (resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "") {
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	d.cache.Set(key, &CachedResponse{Status: resp.StatusCode, Header: resp.Header.Clone(), Body: body})
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// WriteHeader records the status code.
+func (w *bufferedWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+// Write buffers the body.
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+// flush writes the buffered response to the underlying writer.
+func (w *bufferedWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write(w.body.Bytes()) // nolint:errcheck
+}
+
+// writeNotModified writes a 304 response, removing the headers that describe
+// the body.
+func writeNotModified(w http.ResponseWriter) {
+	h := w.Header()
+	h.Del("Content-Type")
+	h.Del("Content-Length")
+	h.Del("Content-Encoding")
+	w.WriteHeader(http.StatusNotModified)
+}
+
+// parsePreconditions returns the preconditions defined by the conditional
+// headers of a request, nil if there is none.
+func parsePreconditions(h http.Header) *goa.Preconditions {
+	var p goa.Preconditions
+	found := false
+	if v := h.Get("If-Match"); v != "" {
+		p.IfMatch = splitETags(v)
+		found = true
+	}
+	if v := h.Get("If-None-Match"); v != "" {
+		p.IfNoneMatch = splitETags(v)
+		found = true
+	}
+	if v := h.Get("If-Modified-Since"); v != "" {
+		if t, err := http.ParseTime(v); err == nil {
+			p.IfModifiedSince = t
+			found = true
+		}
+	}
+	if v := h.Get("If-Unmodified-Since"); v != "" {
+		if t, err := http.ParseTime(v); err == nil {
+			p.IfUnmodifiedSince = t
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &p
+}
+
+// splitETags splits a comma separated list of entity tags.
+func splitETags(v string) []string {
+	var (
+		tags   []string
+		quoted bool
+		start  int
+	)
+	for i := 0; i < len(v); i++ {
+		switch v[i] {
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				if t := strings.TrimSpace(v[start:i]); t != "" {
+					tags = append(tags, t)
+				}
+				start = i + 1
+			}
+		}
+	}
+	if t := strings.TrimSpace(v[start:]); t != "" {
+		tags = append(tags, t)
+	}
+	return tags
+}
+
+// joinETags quotes the given entity tags if needed and joins them with commas.
+func joinETags(tags []string) string {
+	quoted := make([]string, len(tags))
+	for i, t := range tags {
+		if t == "*" {
+			quoted[i] = t
+			continue
+		}
+		quoted[i] = quoteETag(t)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// UnquoteETag returns the given strong entity tag without the surrounding
+// quotes so that it matches the value of the result attribute that the server
+// quoted to set the "ETag" header. Weak entity tags are returned unchanged. The
+// generated clients use UnquoteETag to decode the "ETag" header of the
+// endpoints whose design uses the ETag DSL.
+func UnquoteETag(tag string) string {
+	if len(tag) > 1 && strings.HasPrefix(tag, `"`) && strings.HasSuffix(tag, `"`) {
+		return tag[1 : len(tag)-1]
+	}
+	return tag
+}
+
+// quoteETag quotes the given entity tag unless it is already quoted.
+func quoteETag(tag string) string {
+	if strings.HasSuffix(tag, `"`) && (strings.HasPrefix(tag, `"`) || strings.HasPrefix(tag, `W/"`)) && len(tag) > 1 {
+		return tag
+	}
+	return `"` + tag + `"`
+}
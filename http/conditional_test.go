@@ -0,0 +1,201 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestHandleConditional(t *testing.T) {
+	lastModified := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/doc" {
+			w.Header().Set("ETag", "v1")
+			w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+		}
+		w.Write([]byte(`{"id":"1"}`)) // nolint:errcheck
+	})
+	hashed := HashETag([]byte(`{"id":"1"}`))
+	cases := []struct {
+		Name           string
+		Method         string
+		Path           string
+		Header         map[string]string
+		ExpectedStatus int
+		ExpectedETag   string
+	}{
+		{"no-precondition", "GET", "/doc", nil, http.StatusOK, `"v1"`},
+		{"if-none-match", "GET", "/doc", map[string]string{"If-None-Match": `"v0", "v1"`}, http.StatusNotModified, `"v1"`},
+		{"if-none-match-weak", "GET", "/doc", map[string]string{"If-None-Match": `W/"v1"`}, http.StatusNotModified, `"v1"`},
+		{"if-none-match-changed", "GET", "/doc", map[string]string{"If-None-Match": `"v0"`}, http.StatusOK, `"v1"`},
+		{"if-modified-since", "GET", "/doc", map[string]string{"If-Modified-Since": lastModified.Format(http.TimeFormat)}, http.StatusNotModified, `"v1"`},
+		{"if-modified-since-changed", "GET", "/doc", map[string]string{"If-Modified-Since": lastModified.Add(-time.Hour).Format(http.TimeFormat)}, http.StatusOK, `"v1"`},
+		{"if-match", "GET", "/doc", map[string]string{"If-Match": `"v0"`}, http.StatusPreconditionFailed, `"v1"`},
+		{"if-unmodified-since", "GET", "/doc", map[string]string{"If-Unmodified-Since": lastModified.Add(-time.Hour).Format(http.TimeFormat)}, http.StatusPreconditionFailed, `"v1"`},
+		{"unsafe-method", "PUT", "/doc", map[string]string{"If-Match": `"v0"`}, http.StatusOK, `"v1"`},
+		{"hash", "GET", "/list", nil, http.StatusOK, hashed},
+		{"hash-if-none-match", "GET", "/list", map[string]string{"If-None-Match": hashed}, http.StatusNotModified, hashed},
+		{"error", "GET", "/missing", map[string]string{"If-None-Match": "*"}, http.StatusNotFound, ""},
+	}
+	handler := HandleConditional(h, HashETag)
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := httptest.NewRequest(c.Method, c.Path, nil)
+			for k, v := range c.Header {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != c.ExpectedStatus {
+				t.Errorf("got status %d, expected %d", w.Code, c.ExpectedStatus)
+			}
+			if got := w.Header().Get("ETag"); got != c.ExpectedETag {
+				t.Errorf("got ETag %q, expected %q", got, c.ExpectedETag)
+			}
+			if w.Code == http.StatusNotModified && (w.Body.Len() > 0 || w.Header().Get("Content-Type") != "") {
+				t.Errorf("got body %q and content type %q, expected none", w.Body.String(), w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestHandleConditionalContext(t *testing.T) {
+	var pre *goa.Preconditions
+	h := HandleConditional(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pre = goa.ContextPreconditions(r.Context())
+	}), nil)
+	req := httptest.NewRequest("PUT", "/", nil)
+	req.Header.Set("If-Match", `"a", W/"b,c"`)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if pre == nil || len(pre.IfMatch) != 2 || pre.IfMatch[0] != `"a"` || pre.IfMatch[1] != `W/"b,c"` {
+		t.Errorf("got preconditions %+v, expected If-Match tags", pre)
+	}
+}
+
+func TestSetPreconditions(t *testing.T) {
+	since := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	ctx := goa.WithPreconditions(context.Background(), &goa.Preconditions{
+		IfMatch:         []string{"a", `W/"b"`},
+		IfNoneMatch:     []string{"*"},
+		IfModifiedSince: since,
+	})
+	req := httptest.NewRequest("GET", "/", nil)
+	SetPreconditions(ctx, req)
+	expected := map[string]string{
+		"If-Match":            `"a", W/"b"`,
+		"If-None-Match":       "*",
+		"If-Modified-Since":   since.Format(http.TimeFormat),
+		"If-Unmodified-Since": "",
+	}
+	for h, v := range expected {
+		if got := req.Header.Get(h); got != v {
+			t.Errorf("got %s %q, expected %q", h, got, v)
+		}
+	}
+}
+
+func TestWithResponseCache(t *testing.T) {
+	var requests []*http.Request
+	srv := httptest.NewServer(HandleConditional(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		w.Write([]byte("content")) // nolint:errcheck
+	}), HashETag))
+	defer srv.Close()
+	doer := WithResponseCache(NewMemoryResponseCache(10))(http.DefaultClient)
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", srv.URL, nil)
+		resp, err := doer.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || string(body) != "content" {
+			t.Errorf("request %d: got status %d body %q", i, resp.StatusCode, body)
+		}
+	}
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, expected 2", len(requests))
+	}
+	if got := requests[1].Header.Get("If-None-Match"); got != HashETag([]byte("content")) {
+		t.Errorf("got If-None-Match %q, expected cached entity tag", got)
+	}
+}
+
+func TestMemoryResponseCacheEviction(t *testing.T) {
+	c := NewMemoryResponseCache(2)
+	c.Set("a", &CachedResponse{})
+	c.Set("b", &CachedResponse{})
+	c.Get("a")
+	c.Set("c", &CachedResponse{})
+	if _, ok := c.Get("b"); ok {
+		t.Error("got b, expected least recently used response to be evicted")
+	}
+	for _, k := range []string{"a", "c"} {
+		if _, ok := c.Get(k); !ok {
+			t.Errorf("%s missing", k)
+		}
+	}
+}
+
+func TestConditionalETagRoundTrip(t *testing.T) {
+	const version = "v1"
+	srv := httptest.NewServer(HandleConditional(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The generated server sets the header to the result attribute.
+		w.Header().Set("ETag", version)
+		w.Write([]byte(`{"id":"1"}`)) // nolint:errcheck
+	}), nil))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	// The generated client decodes the header into the result attribute.
+	got := UnquoteETag(resp.Header.Get("ETag"))
+	if got != version {
+		t.Fatalf("got entity tag %q, expected %q", got, version)
+	}
+
+	ctx := goa.WithPreconditions(context.Background(), &goa.Preconditions{IfNoneMatch: []string{got}})
+	req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+	SetPreconditions(ctx, req)
+	if h := req.Header.Get("If-None-Match"); h != `"v1"` {
+		t.Errorf("got If-None-Match %q, expected %q", h, `"v1"`)
+	}
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("got status %d, expected %d", resp.StatusCode, http.StatusNotModified)
+	}
+}
+
+func TestUnquoteETag(t *testing.T) {
+	cases := map[string]string{
+		`"v1"`:   "v1",
+		"v1":     "v1",
+		`W/"v1"`: `W/"v1"`,
+		`"`:      `"`,
+		"":       "",
+	}
+	for tag, expected := range cases {
+		if got := UnquoteETag(tag); got != expected {
+			t.Errorf("got %q for %q, expected %q", got, tag, expected)
+		}
+	}
+}
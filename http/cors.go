@@ -0,0 +1,121 @@
+package http
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CORSOrigin describes the Cross-Origin Resource Sharing (CORS) policy that
+// applies to requests made from a given origin. The generated servers
+// initialize CORS origins from the Origin DSL.
+type CORSOrigin struct {
+	// Origin is the allowed origin, "*" allows any origin. Origin is
+	// ignored if Regexp is not nil.
+	Origin string
+	// Regexp matches the allowed origins if not nil.
+	Regexp *regexp.Regexp
+	// Methods lists the HTTP methods allowed when accessing the resource.
+	Methods []string
+	// Headers lists the request headers allowed when accessing the
+	// resource, "*" allows any header.
+	Headers []string
+	// Expose lists the response headers that browsers are allowed to
+	// access.
+	Expose []string
+	// MaxAge is the number of seconds the results of a preflight request
+	// can be cached if not zero.
+	MaxAge int
+	// Credentials indicates whether the resource can be accessed using
+	// credentials (cookies, authorization headers or TLS client
+	// certificates).
+	Credentials bool
+}
+
+// HandleCORS returns a handler that sets the CORS response headers for
+// requests whose "Origin" header matches one of the given origins before
+// calling h. Preflight requests (OPTIONS requests with a
+// "Access-Control-Request-Method" header) only match origins that allow the
+// requested method. Requests that do not match any origin are served by h
+// without CORS headers which causes browsers to block the response.
+func HandleCORS(h http.Handler, origins ...*CORSOrigin) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Origin")
+		method := r.Method
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		if preflight {
+			method = r.Header.Get("Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+		}
+		for _, o := range origins {
+			if !o.Match(origin) || !o.allows(method) {
+				continue
+			}
+			o.setHeaders(w, r, origin, preflight)
+			break
+		}
+		h.ServeHTTP(w, r)
+	}
+}
+
+// Match returns true if the given request origin matches o.
+func (o *CORSOrigin) Match(origin string) bool {
+	if o.Regexp != nil {
+		return o.Regexp.MatchString(origin)
+	}
+	return o.Origin == "*" || strings.EqualFold(o.Origin, origin)
+}
+
+// allows returns true if the given HTTP method is allowed by o.
+func (o *CORSOrigin) allows(method string) bool {
+	if len(o.Methods) == 0 {
+		return true
+	}
+	for _, m := range o.Methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// setHeaders writes the CORS response headers.
+func (o *CORSOrigin) setHeaders(w http.ResponseWriter, r *http.Request, origin string, preflight bool) {
+	h := w.Header()
+	if o.Origin == "*" && o.Regexp == nil && !o.Credentials {
+		h.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		h.Set("Access-Control-Allow-Origin", origin)
+	}
+	if o.Credentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+	if !preflight {
+		if len(o.Expose) > 0 {
+			h.Set("Access-Control-Expose-Headers", strings.Join(o.Expose, ", "))
+		}
+		return
+	}
+	if len(o.Methods) > 0 {
+		h.Set("Access-Control-Allow-Methods", strings.Join(o.Methods, ", "))
+	} else {
+		h.Set("Access-Control-Allow-Methods", r.Header.Get("Access-Control-Request-Method"))
+	}
+	if len(o.Headers) == 1 && o.Headers[0] == "*" {
+		if reqHeaders := r.Header.Get("Access-Control-Request-Headers"); reqHeaders != "" {
+			h.Set("Access-Control-Allow-Headers", reqHeaders)
+		}
+	} else if len(o.Headers) > 0 {
+		h.Set("Access-Control-Allow-Headers", strings.Join(o.Headers, ", "))
+	}
+	if o.MaxAge > 0 {
+		h.Set("Access-Control-Max-Age", strconv.Itoa(o.MaxAge))
+	}
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestHandleCORS(t *testing.T) {
+	var (
+		example = &CORSOrigin{
+			Origin:      "https://example.com",
+			Methods:     []string{"GET", "POST"},
+			Headers:     []string{"X-Request-Id"},
+			Expose:      []string{"X-Time"},
+			MaxAge:      600,
+			Credentials: true,
+		}
+		sub      = &CORSOrigin{Regexp: regexp.MustCompile(`^https://.*\.example\.org$`), Headers: []string{"*"}}
+		wildcard = &CORSOrigin{Origin: "*", Methods: []string{"GET"}}
+	)
+	cases := map[string]struct {
+		method  string
+		headers map[string]string
+		origins []*CORSOrigin
+		// expected response headers, an empty value means the header must
+		// not be set.
+		expected map[string]string
+	}{
+		"no origin": {
+			method:   "GET",
+			origins:  []*CORSOrigin{example},
+			expected: map[string]string{"Access-Control-Allow-Origin": "", "Vary": ""},
+		},
+		"actual request": {
+			method:  "GET",
+			headers: map[string]string{"Origin": "https://example.com"},
+			origins: []*CORSOrigin{example},
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":      "https://example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "X-Time",
+				"Access-Control-Allow-Methods":     "",
+				"Vary":                             "Origin",
+			},
+		},
+		"preflight request": {
+			method: "OPTIONS",
+			headers: map[string]string{
+				"Origin":                         "https://example.com",
+				"Access-Control-Request-Method":  "POST",
+				"Access-Control-Request-Headers": "X-Request-Id",
+			},
+			origins: []*CORSOrigin{example},
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":   "https://example.com",
+				"Access-Control-Allow-Methods":  "GET, POST",
+				"Access-Control-Allow-Headers":  "X-Request-Id",
+				"Access-Control-Max-Age":        "600",
+				"Access-Control-Expose-Headers": "",
+			},
+		},
+		"preflight method not allowed": {
+			method: "OPTIONS",
+			headers: map[string]string{
+				"Origin":                        "https://example.com",
+				"Access-Control-Request-Method": "DELETE",
+			},
+			origins:  []*CORSOrigin{example},
+			expected: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		"regexp preflight": {
+			method: "OPTIONS",
+			headers: map[string]string{
+				"Origin":                         "https://api.example.org",
+				"Access-Control-Request-Method":  "PUT",
+				"Access-Control-Request-Headers": "X-Foo, X-Bar",
+			},
+			origins: []*CORSOrigin{example, sub},
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":  "https://api.example.org",
+				"Access-Control-Allow-Methods": "PUT",
+				"Access-Control-Allow-Headers": "X-Foo, X-Bar",
+				"Access-Control-Max-Age":       "",
+			},
+		},
+		"wildcard": {
+			method:  "GET",
+			headers: map[string]string{"Origin": "https://other.com"},
+			origins: []*CORSOrigin{example, wildcard},
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":      "*",
+				"Access-Control-Allow-Credentials": "",
+			},
+		},
+		"no match": {
+			method:   "GET",
+			headers:  map[string]string{"Origin": "https://other.com"},
+			origins:  []*CORSOrigin{example, sub},
+			expected: map[string]string{"Access-Control-Allow-Origin": "", "Vary": "Origin"},
+		},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			var called bool
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true })
+			req := httptest.NewRequest(tc.method, "/", nil)
+			for n, v := range tc.headers {
+				req.Header.Set(n, v)
+			}
+			w := httptest.NewRecorder()
+			HandleCORS(h, tc.origins...).ServeHTTP(w, req)
+			if !called {
+				t.Error("handler not called")
+			}
+			for n, v := range tc.expected {
+				if got := w.Header().Get(n); got != v {
+					t.Errorf("got header %s %q, expected %q", n, got, v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DeprecationHeader is the name of the HTTP response header that
+	// signals that the endpoint is deprecated (RFC 9745).
+	DeprecationHeader = "Deprecation"
+
+	// SunsetHeader is the name of the HTTP response header that holds the
+	// date after which the endpoint is expected to become unavailable (RFC
+	// 8594).
+	SunsetHeader = "Sunset"
+)
+
+// HandleDeprecated returns a handler that sets the "Deprecation" header of
+// the responses written by h to deprecation and their "Sunset" header to
+// sunset unless empty. deprecation must be formatted as a RFC 9745 date, that
+// is "@" followed by the number of seconds since the Unix epoch, see
+// DeprecationDate. The header value is "true" if deprecation is empty, this is
+// the format defined by the earlier drafts of RFC 9745 for deprecations whose
+// date is unknown. sunset must be formatted as a HTTP date. The generated
+// servers wrap the handlers of the endpoints whose design uses the Deprecated
+// DSL.
+func HandleDeprecated(h http.Handler, deprecation, sunset string) http.Handler {
+	if deprecation == "" {
+		deprecation = "true"
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(DeprecationHeader, deprecation)
+		if sunset != "" {
+			w.Header().Set(SunsetHeader, sunset)
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// DeprecationDate formats t as a "Deprecation" header value (RFC 9745).
+func DeprecationDate(t time.Time) string {
+	return "@" + strconv.FormatInt(t.Unix(), 10)
+}
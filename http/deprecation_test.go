@@ -0,0 +1,47 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandleDeprecated(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	cases := []struct {
+		Name                string
+		Deprecation         string
+		Sunset              string
+		ExpectedDeprecation string
+		ExpectedSunset      string
+	}{
+		{"no-date", "", "", "true", ""},
+		{"date", "@1735689600", "", "@1735689600", ""},
+		{"sunset", "@1735689600", "Tue, 01 Jan 2030 00:00:00 GMT", "@1735689600", "Tue, 01 Jan 2030 00:00:00 GMT"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			HandleDeprecated(h, c.Deprecation, c.Sunset).ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+			if w.Code != http.StatusNoContent {
+				t.Errorf("got status %d, expected %d", w.Code, http.StatusNoContent)
+			}
+			if got := w.Header().Get(DeprecationHeader); got != c.ExpectedDeprecation {
+				t.Errorf("got Deprecation header %q, expected %q", got, c.ExpectedDeprecation)
+			}
+			if got := w.Header().Get(SunsetHeader); got != c.ExpectedSunset {
+				t.Errorf("got Sunset header %q, expected %q", got, c.ExpectedSunset)
+			}
+		})
+	}
+}
+
+func TestDeprecationDate(t *testing.T) {
+	d := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := DeprecationDate(d); got != "@1735689600" {
+		t.Errorf("got %q, expected %q", got, "@1735689600")
+	}
+}
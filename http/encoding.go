@@ -3,14 +3,15 @@ package http
 import (
 	"bytes"
 	"context"
-	"encoding/gob"
 	"encoding/json"
-	"encoding/xml"
 	"fmt"
 	"io"
-	"mime"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+
+	goa "goa.design/goa/v3/pkg"
 )
 
 const (
@@ -49,7 +50,9 @@ type (
 )
 
 // RequestDecoder returns a HTTP request body decoder suitable for the given
-// request. The decoder handles the following mime types:
+// request. The decoder is created by the codec registered for the request
+// "Content-Type" header media type, see RegisterCodec. The default codecs
+// handle the following mime types:
 //
 //   - application/json using package encoding/json
 //   - application/xml using package encoding/xml
@@ -57,36 +60,24 @@ type (
 //   - text/html and text/plain for strings
 //
 // RequestDecoder defaults to the JSON decoder if the request "Content-Type"
-// header does not match any of the supported mime type or is missing
+// header does not match any of the registered mime types or is missing
 // altogether.
 func RequestDecoder(r *http.Request) Decoder {
 	contentType := r.Header.Get("Content-Type")
-	if contentType == "" {
-		// default to JSON
-		contentType = "application/json"
-	} else {
-		// sanitize
-		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
-			contentType = mediaType
+	if contentType != "" {
+		if c, ok := LookupCodec(contentType); ok && c.NewDecoder != nil {
+			return c.NewDecoder(r.Body)
 		}
 	}
-	switch contentType {
-	case "application/json":
-		return json.NewDecoder(r.Body)
-	case "application/gob":
-		return gob.NewDecoder(r.Body)
-	case "application/xml":
-		return xml.NewDecoder(r.Body)
-	case "text/html", "text/plain":
-		return newTextDecoder(r.Body, contentType)
-	default:
-		return json.NewDecoder(r.Body)
-	}
+	return json.NewDecoder(r.Body)
 }
 
 // ResponseEncoder returns a HTTP response encoder leveraging the mime type
 // set in the context under the AcceptTypeKey or the ContentTypeKey if any.
-// The encoder supports the following mime types:
+// The encoder is created by the registered codec that best matches the
+// content type or, if no content type is set, the accepted media types
+// taking into account quality values and wildcards (see RegisterCodec and
+// NegotiateCodec). The default codecs handle the following mime types:
 //
 //   - application/json using package encoding/json
 //   - application/xml using package encoding/xml
@@ -94,23 +85,9 @@ func RequestDecoder(r *http.Request) Decoder {
 //   - text/html and text/plain for strings
 //
 // ResponseEncoder defaults to the JSON encoder if the context AcceptTypeKey or
-// ContentTypeKey value does not match any of the supported mime types or is
+// ContentTypeKey value does not match any of the registered mime types or is
 // missing altogether.
 func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
-	negotiate := func(a string) (Encoder, string) {
-		switch a {
-		case "", "application/json":
-			// default to JSON
-			return json.NewEncoder(w), "application/json"
-		case "application/xml":
-			return xml.NewEncoder(w), "application/xml"
-		case "application/gob":
-			return gob.NewEncoder(w), "application/gob"
-		case "text/html", "text/plain":
-			return newTextEncoder(w, a), a
-		}
-		return nil, ""
-	}
 	var accept string
 	{
 		if a := ctx.Value(AcceptTypeKey); a != nil {
@@ -126,86 +103,75 @@ func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
 	var (
 		enc Encoder
 		mt  string
-		err error
 	)
 	{
 		if ct != "" {
 			// If content type explicitly set in the DSL, infer the response encoder
 			// from the content type context key.
-			if mt, _, err = mime.ParseMediaType(ct); err == nil {
-				switch {
-				case mt == "application/json" || strings.HasSuffix(mt, "+json"):
-					enc = json.NewEncoder(w)
-				case mt == "application/xml" || strings.HasSuffix(mt, "+xml"):
-					enc = xml.NewEncoder(w)
-				case mt == "application/gob" || strings.HasSuffix(mt, "+gob"):
-					enc = gob.NewEncoder(w)
-				case mt == "text/html" || mt == "text/plain" ||
-					strings.HasSuffix(mt, "+html") || strings.HasSuffix(mt, "+txt"):
-					enc = newTextEncoder(w, mt)
-				default:
-					enc = json.NewEncoder(w)
-				}
+			var c *Codec
+			if c, mt = codecs.lookup(ct); c != nil && c.NewEncoder != nil {
+				enc = c.NewEncoder(w)
+			} else {
+				enc = json.NewEncoder(w)
 			}
 			SetContentType(w, mt)
 			return enc
 		}
 		// If Accept header exists in the request, infer the response encoder
 		// from the header value.
-		if enc, mt = negotiate(accept); enc == nil {
-			// attempt to normalize
-			if mt, _, err = mime.ParseMediaType(accept); err == nil {
-				enc, mt = negotiate(mt)
-			}
-		}
-		if enc == nil {
-			enc, mt = negotiate("")
+		if c, a := NegotiateCodec(accept); c != nil {
+			enc, mt = c.NewEncoder(w), a
+		} else {
+			// default to JSON
+			enc, mt = json.NewEncoder(w), "application/json"
 		}
 	}
 	SetContentType(w, mt)
 	return enc
 }
 
-// RequestEncoder returns a HTTP request encoder.
-// The encoder uses package encoding/json.
+// RequestEncoder returns a HTTP request encoder. The encoder is created by the
+// codec registered for the request "Content-Type" header media type if any,
+// see RegisterCodec. RequestEncoder defaults to the JSON encoder and sets the
+// "Content-Type" header to "application/json" if it is not set.
 func RequestEncoder(r *http.Request) Encoder {
 	const k = "Content-Type"
+	var buf bytes.Buffer
+	r.Body = io.NopCloser(&buf)
 	if h := r.Header.Get(k); h == "" {
 		r.Header.Set(k, "application/json")
+	} else if c, ok := LookupCodec(h); ok && c.NewEncoder != nil {
+		return c.NewEncoder(&buf)
 	}
-	var buf bytes.Buffer
-	r.Body = io.NopCloser(&buf)
 	return json.NewEncoder(&buf)
 }
 
-// ResponseDecoder returns a HTTP response decoder.
-// The decoder handles the following content types:
+// ResponseDecoder returns a HTTP response decoder. The decoder is created by
+// the codec registered for the response "Content-Type" header media type, see
+// RegisterCodec. The default codecs handle the following content types:
 //
 //   - application/json using package encoding/json (default)
 //   - application/xml using package encoding/xml
 //   - application/gob using package encoding/gob
 //   - text/html and text/plain for strings
+//
+// The decoder decompresses the response body if the response has a
+// "Content-Encoding" header with a registered content coding, see
+// RegisterCompressor.
 func ResponseDecoder(resp *http.Response) Decoder {
-	ct := resp.Header.Get("Content-Type")
-	if ct == "" {
-		return json.NewDecoder(resp.Body)
-	}
-	if mediaType, _, err := mime.ParseMediaType(ct); err == nil {
-		ct = mediaType
+	var body io.Reader = resp.Body
+	if enc := resp.Header.Get("Content-Encoding"); enc != "" {
+		if b, err := DecompressBody(resp.Body, enc); err == nil {
+			body = b
+		}
 	}
-	switch {
-	case ct == "application/json" || strings.HasSuffix(ct, "+json"):
-		return json.NewDecoder(resp.Body)
-	case ct == "application/xml" || strings.HasSuffix(ct, "+xml"):
-		return xml.NewDecoder(resp.Body)
-	case ct == "application/gob" || strings.HasSuffix(ct, "+gob"):
-		return gob.NewDecoder(resp.Body)
-	case ct == "text/html" || ct == "text/plain" ||
-		strings.HasSuffix(ct, "+html") || strings.HasSuffix(ct, "+txt"):
-		return newTextDecoder(resp.Body, ct)
-	default:
-		return json.NewDecoder(resp.Body)
+	ct := resp.Header.Get("Content-Type")
+	if ct != "" {
+		if c, ok := LookupCodec(ct); ok && c.NewDecoder != nil {
+			return c.NewDecoder(body)
+		}
 	}
+	return json.NewDecoder(body)
 }
 
 // ErrorEncoder returns an encoder that encodes errors returned by service
@@ -215,6 +181,10 @@ func ResponseDecoder(resp *http.Response) Decoder {
 // provided encoder. If the error is not a goa ServiceError struct then it is
 // encoded as a permanent internal server error. This behavior as well as the
 // shape of the response can be overridden by providing a non-nil formatter.
+// The encoder sets the "Retry-After" header of the responses to requests
+// rejected by the goa.RateLimiter middleware when the delay after which the
+// request may be retried is known, that is when the rate limit is exceeded.
+// Requests rejected because of the concurrency limit have no such header.
 func ErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, formatter func(ctx context.Context, err error) Statuser) func(context.Context, http.ResponseWriter, error) error {
 	return func(ctx context.Context, w http.ResponseWriter, err error) error {
 		enc := encoder(ctx, w)
@@ -222,6 +192,10 @@ func ErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, fo
 			formatter = NewErrorResponse
 		}
 		resp := formatter(ctx, err)
+		if retryAfter, ok := goa.RetryAfter(err); ok && retryAfter > 0 {
+			secs := int(math.Max(1, math.Ceil(retryAfter.Seconds())))
+			w.Header().Set("Retry-After", strconv.Itoa(secs))
+		}
 		w.WriteHeader(resp.StatusCode())
 		return enc.Encode(resp)
 	}
@@ -3,10 +3,14 @@ package http
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
 )
 
 var (
@@ -104,6 +108,34 @@ func TestResponseEncoder(t *testing.T) {
 	}
 }
 
+func TestErrorEncoder(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		status     int
+		retryAfter string
+	}{
+		{"fault", errors.New("error"), http.StatusInternalServerError, ""},
+		{"temporary", goa.TemporaryError("unavailable", "unavailable"), http.StatusServiceUnavailable, ""},
+		{"rate limited", goa.RateLimitedError(1500*time.Millisecond, "rate limited"), http.StatusTooManyRequests, "2"},
+		{"too many requests", goa.RateLimitedError(0, "too many requests"), http.StatusTooManyRequests, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := ErrorEncoder(ResponseEncoder, nil)(context.Background(), w, c.err); err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != c.status {
+				t.Errorf("got status %d, expected %d", w.Code, c.status)
+			}
+			if got := w.Header().Get("Retry-After"); got != c.retryAfter {
+				t.Errorf("got Retry-After %q, expected %q", got, c.retryAfter)
+			}
+		})
+	}
+}
+
 func TestResponseDecoder(t *testing.T) {
 	cases := []struct {
 		contentType string
@@ -52,9 +52,28 @@ func NewErrorResponse(ctx context.Context, err error) Statuser {
 
 // StatusCode implements a heuristic that computes a HTTP response status code
 // appropriate for the timeout, temporary and fault characteristics of the
-// error. This method is used by the generated server code when the error is not
-// described explicitly in the design.
+// error. Errors returned by the goa.RateLimiter middleware use status code 429
+// (Too Many Requests). Requests that reuse an idempotency key with a different
+// request are rejected with status code 422 (Unprocessable Entity), requests
+// made while the request with the same key is being processed with status
+// code 409 (Conflict) and requests made with an idempotency key whose body is
+// too large with status code 413 (Request Entity Too Large). Errors returned
+// by goa.CheckPreconditions use status code 412 (Precondition Failed). This
+// method is used by the generated server code when the error is not described
+// explicitly in the design.
 func (resp *ErrorResponse) StatusCode() int {
+	switch resp.Name {
+	case goa.RateLimited:
+		return http.StatusTooManyRequests
+	case IdempotencyKeyMismatch:
+		return http.StatusUnprocessableEntity
+	case IdempotencyKeyInUse:
+		return http.StatusConflict
+	case IdempotentRequestTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case goa.PreconditionFailed:
+		return http.StatusPreconditionFailed
+	}
 	if resp.Fault {
 		return http.StatusInternalServerError
 	}
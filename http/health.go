@@ -0,0 +1,41 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// HandleLiveness returns a handler that serves liveness probes. The handler
+// always writes a 200 (OK) response with the JSON body {"status":"ok"}.
+func HandleLiveness() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeHealthReport(w, &goa.HealthReport{Status: goa.HealthStatusOK})
+	}
+}
+
+// HandleReadiness returns a handler that serves readiness probes. The handler
+// runs the given checks with a timeout of goa.DefaultHealthTimeout and writes
+// the resulting goa.HealthReport as JSON. The response status is 200 (OK) if
+// all the checks succeed, 503 (Service Unavailable) otherwise.
+func HandleReadiness(checks ...*goa.HealthCheck) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), goa.DefaultHealthTimeout)
+		defer cancel()
+		writeHealthReport(w, goa.CheckHealth(ctx, checks...))
+	}
+}
+
+// writeHealthReport writes the given report to w.
+func writeHealthReport(w http.ResponseWriter, report *goa.HealthReport) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if report.OK() {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report) // nolint: errcheck
+}
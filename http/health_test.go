@@ -0,0 +1,51 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// durationRegexp matches the check durations which vary between runs.
+var durationRegexp = regexp.MustCompile(`"duration_ns":\d+`)
+
+func TestHealthHandlers(t *testing.T) {
+	var (
+		ok   = &goa.HealthCheck{Name: "db", Check: func(context.Context) error { return nil }}
+		fail = &goa.HealthCheck{Name: "cache", Check: func(context.Context) error { return errors.New("unavailable") }}
+	)
+	cases := []struct {
+		Name    string
+		Handler http.Handler
+		Status  int
+		Body    string
+	}{
+		{"liveness", HandleLiveness(), http.StatusOK, `{"status":"ok"}`},
+		{"readiness no check", HandleReadiness(), http.StatusOK, `{"status":"ok"}`},
+		{"readiness ok", HandleReadiness(ok), http.StatusOK, `{"status":"ok","checks":[{"name":"db","status":"ok","duration_ns":0}]}`},
+		{"readiness fail", HandleReadiness(ok, fail), http.StatusServiceUnavailable, `{"status":"fail","checks":[{"name":"db","status":"ok","duration_ns":0},{"name":"cache","status":"fail","error":"unavailable","duration_ns":0}]}`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("got content type %q, expected application/json", ct)
+			}
+			if cc := w.Header().Get("Cache-Control"); cc != "no-store" {
+				t.Errorf("got cache control %q, expected no-store", cc)
+			}
+			if body := durationRegexp.ReplaceAllString(w.Body.String(), `"duration_ns":0`); body != c.Body+"\n" {
+				t.Errorf("got body %q, expected %q", body, c.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,423 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+const (
+	// IdempotencyKeyHeader is the name of the HTTP request header that
+	// holds the idempotency key of requests made to idempotent endpoints.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is the name of the HTTP response header set
+	// to "true" when the response is replayed from the idempotency store.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// IdempotencyKeyMismatch is the name of the error returned when an
+	// idempotency key is reused with a different request.
+	IdempotencyKeyMismatch = "idempotency_key_mismatch"
+
+	// IdempotencyKeyInUse is the name of the error returned when a request
+	// is made with the idempotency key of a request still being processed.
+	IdempotencyKeyInUse = "idempotency_key_in_use"
+
+	// IdempotentRequestTooLarge is the name of the error returned when the
+	// body of a request made with an idempotency key exceeds the maximum
+	// size.
+	IdempotentRequestTooLarge = "idempotent_request_too_large"
+
+	// DefaultIdempotencyTTL is the duration during which the in-memory
+	// idempotency store keeps responses.
+	DefaultIdempotencyTTL = 24 * time.Hour
+
+	// DefaultIdempotencyMaxBodySize is the default maximum size in bytes
+	// of the bodies of the requests made with an idempotency key. The
+	// bodies are read in memory to compute the request fingerprint.
+	DefaultIdempotencyMaxBodySize = 1 << 20
+)
+
+type (
+	// IdempotentResponse is a response recorded by an idempotency store.
+	IdempotentResponse struct {
+		// Fingerprint identifies the request that produced the response.
+		Fingerprint string
+		// Status is the response status code, zero while the request is
+		// being processed.
+		Status int
+		// Header contains the response headers.
+		Header http.Header
+		// Body is the encoded response body.
+		Body []byte
+	}
+
+	// IdempotencyStore is the interface implemented by the stores that
+	// record the responses of idempotent endpoints. Stores shared by
+	// multiple processes (e.g. backed by Redis) make it possible to
+	// detect duplicate requests across service instances.
+	IdempotencyStore interface {
+		// Reserve records that the request with the given fingerprint
+		// is being processed under key. It returns nil if key was not
+		// recorded yet or the response recorded previously otherwise.
+		Reserve(ctx context.Context, key, fingerprint string) (*IdempotentResponse, error)
+		// Save records the response of the request reserved under key.
+		Save(ctx context.Context, key string, resp *IdempotentResponse) error
+		// Release discards the reservation made under key so that the
+		// request may be retried.
+		Release(ctx context.Context, key string) error
+	}
+
+	// Idempotency makes HTTP handlers honor the "Idempotency-Key" request
+	// header. The generated servers use an Idempotency to wrap the handlers
+	// of the endpoints whose design uses the Idempotent DSL. The responses
+	// are recorded under a key made of the endpoint, the request scope and
+	// the idempotency key so that different endpoints or clients using the
+	// same idempotency key do not get each other's responses.
+	Idempotency struct {
+		mu          sync.RWMutex
+		store       IdempotencyStore
+		scope       func(*http.Request) string
+		maxBodySize int64
+	}
+
+	// MemoryIdempotencyStore is an IdempotencyStore that records responses
+	// in memory.
+	MemoryIdempotencyStore struct {
+		mu        sync.Mutex
+		ttl       time.Duration
+		entries   map[string]*idempotencyEntry
+		lastSweep time.Time
+		now       func() time.Time
+	}
+
+	// idempotencyEntry is a response recorded by MemoryIdempotencyStore.
+	idempotencyEntry struct {
+		resp    *IdempotentResponse
+		expires time.Time
+	}
+
+	// idempotencyKeyCtxKey is the context key used to store the
+	// idempotency key of client requests.
+	idempotencyKeyCtxKey struct{}
+
+	// recordingWriter is a http.ResponseWriter that records the response
+	// written to the underlying writer.
+	recordingWriter struct {
+		http.ResponseWriter
+		status int
+		body   bytes.Buffer
+	}
+)
+
+// NewIdempotency returns an Idempotency that records responses in the given
+// store. It uses an in-memory store if store is nil.
+func NewIdempotency(store IdempotencyStore) *Idempotency {
+	if store == nil {
+		store = NewMemoryIdempotencyStore(DefaultIdempotencyTTL)
+	}
+	return &Idempotency{
+		store:       store,
+		scope:       IdempotencyScope,
+		maxBodySize: DefaultIdempotencyMaxBodySize,
+	}
+}
+
+// SetStore replaces the store used to record responses.
+func (i *Idempotency) SetStore(store IdempotencyStore) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.store = store
+}
+
+// SetScope replaces the function that computes the scope of requests,
+// IdempotencyScope by default. The scope should identify the principal making
+// the request, requests made with the same idempotency key but with
+// different scopes are processed independently.
+func (i *Idempotency) SetScope(scope func(*http.Request) string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.scope = scope
+}
+
+// SetMaxBodySize sets the maximum size in bytes of the bodies of the requests
+// made with an idempotency key, DefaultIdempotencyMaxBodySize by default.
+// Requests whose body exceeds the maximum size are rejected.
+func (i *Idempotency) SetMaxBodySize(n int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.maxBodySize = n
+}
+
+// Handler returns a handler that replays the response recorded for requests
+// made to the given endpoint whose "Idempotency-Key" header and scope match a
+// previous request. Requests that do not set the header are served by h. The
+// first response for a given key is recorded unless its status code denotes a
+// server error so that the request may be retried. encodeError is used to
+// write the error responses to requests that reuse a key with a different
+// method, path or body, while the first request is still being processed or
+// whose body exceeds the maximum size. errhandler is called if encodeError
+// fails.
+func (i *Idempotency) Handler(
+	endpoint string,
+	h http.Handler,
+	encodeError func(context.Context, http.ResponseWriter, error) error,
+	errhandler func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		ctx := r.Context()
+		fail := func(err error) {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+		i.mu.RLock()
+		store, scope, maxBodySize := i.store, i.scope, i.maxBodySize
+		i.mu.RUnlock()
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+		r.Body.Close()
+		if err != nil {
+			var mberr *http.MaxBytesError
+			if errors.As(err, &mberr) {
+				fail(IdempotentRequestTooLargeError(mberr.Limit))
+				return
+			}
+			fail(goa.DecodePayloadError(err.Error()))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := requestFingerprint(r, body)
+		var s string
+		if scope != nil {
+			s = scope(r)
+		}
+		userKey := key
+		key = idempotencyStoreKey(endpoint, s, key)
+
+		prev, err := store.Reserve(ctx, key, fingerprint)
+		if err != nil {
+			fail(goa.Fault("idempotency store: %s", err))
+			return
+		}
+		if prev != nil {
+			switch {
+			case prev.Fingerprint != fingerprint:
+				fail(IdempotencyKeyMismatchError(userKey))
+			case prev.Status == 0:
+				fail(IdempotencyKeyInUseError(userKey))
+			default:
+				for k, v := range prev.Header {
+					w.Header()[k] = append([]string(nil), v...)
+				}
+				w.Header().Set(IdempotentReplayedHeader, "true")
+				w.WriteHeader(prev.Status)
+				w.Write(prev.Body) // nolint:errcheck
+			}
+			return
+		}
+
+		rw := &recordingWriter{ResponseWriter: w}
+		defer func() {
+			if rw.status == 0 || rw.status >= 500 {
+				store.Release(ctx, key) // nolint:errcheck
+				return
+			}
+			resp := &IdempotentResponse{
+				Fingerprint: fingerprint,
+				Status:      rw.status,
+				Header:      w.Header().Clone(),
+				Body:        rw.body.Bytes(),
+			}
+			if err := store.Save(ctx, key, resp); err != nil {
+				store.Release(ctx, key) // nolint:errcheck
+			}
+		}()
+		h.ServeHTTP(rw, r)
+		if rw.status == 0 {
+			rw.status = http.StatusOK
+		}
+	})
+}
+
+// IdempotencyKeyMismatchError returns the error used to reject requests that
+// reuse the given idempotency key with a different method, path or body.
+func IdempotencyKeyMismatchError(key string) *goa.ServiceError {
+	return goa.PermanentError(IdempotencyKeyMismatch, "idempotency key %q was used with a different request", key)
+}
+
+// IdempotencyKeyInUseError returns the error used to reject requests made
+// with the given idempotency key while the first request made with the key is
+// still being processed.
+func IdempotencyKeyInUseError(key string) *goa.ServiceError {
+	return goa.TemporaryError(IdempotencyKeyInUse, "a request with idempotency key %q is being processed", key)
+}
+
+// IdempotentRequestTooLargeError returns the error used to reject requests
+// made with an idempotency key whose body exceeds limit bytes.
+func IdempotentRequestTooLargeError(limit int64) *goa.ServiceError {
+	return goa.PermanentError(IdempotentRequestTooLarge, "request body exceeds the maximum size of %d bytes", limit)
+}
+
+// IdempotencyScope is the default function used to compute the scope of the
+// requests made with an idempotency key. It returns the value of the
+// "Authorization" header so that clients using different credentials do not
+// share keys. Servers that read credentials from other headers, cookies or
+// query string parameters should set a scope function that extracts them with
+// SetScope.
+func IdempotencyScope(r *http.Request) string {
+	return r.Header.Get("Authorization")
+}
+
+// NewIdempotencyKey returns a new random idempotency key.
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithIdempotencyKey returns a copy of ctx that holds the given idempotency
+// key. The generated clients send the key in the "Idempotency-Key" header of
+// the requests made to idempotent endpoints.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+// IdempotencyKey returns the idempotency key held by ctx if any.
+func IdempotencyKey(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	return key, ok && key != ""
+}
+
+// IdempotentEndpoint returns an endpoint that makes sure the context given to
+// e holds an idempotency key, generating a new key if needed. The generated
+// clients wrap the endpoints of idempotent methods so that retries reuse the
+// same key.
+func IdempotentEndpoint(e goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, v any) (any, error) {
+		if _, ok := IdempotencyKey(ctx); !ok {
+			ctx = WithIdempotencyKey(ctx, NewIdempotencyKey())
+		}
+		return e(ctx, v)
+	}
+}
+
+// SetIdempotencyKey sets the "Idempotency-Key" header of req to the key held
+// by ctx unless the header is already set.
+func SetIdempotencyKey(ctx context.Context, req *http.Request) {
+	if req.Header.Get(IdempotencyKeyHeader) != "" {
+		return
+	}
+	if key, ok := IdempotencyKey(ctx); ok {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+}
+
+// NewMemoryIdempotencyStore returns an IdempotencyStore that records
+// responses in memory for the given duration.
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	return &MemoryIdempotencyStore{
+		ttl:     ttl,
+		entries: make(map[string]*idempotencyEntry),
+		now:     time.Now,
+	}
+}
+
+// Reserve implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) Reserve(_ context.Context, key, fingerprint string) (*IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.sweep(now)
+	if e, ok := s.entries[key]; ok && now.Before(e.expires) {
+		return e.resp, nil
+	}
+	s.entries[key] = &idempotencyEntry{
+		resp:    &IdempotentResponse{Fingerprint: fingerprint},
+		expires: now.Add(s.ttl),
+	}
+	return nil, nil
+}
+
+// Save implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) Save(_ context.Context, key string, resp *IdempotentResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = &idempotencyEntry{resp: resp, expires: s.now().Add(s.ttl)}
+	return nil
+}
+
+// Release implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// sweep deletes the expired entries at most once per minute.
+func (s *MemoryIdempotencyStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for k, e := range s.entries {
+		if !now.Before(e.expires) {
+			delete(s.entries, k)
+		}
+	}
+}
+
+// WriteHeader records the status code.
+func (w *recordingWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write records the body.
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// idempotencyStoreKey returns the key used to record the response of the
+// request made to endpoint with the given scope and idempotency key. The
+// scope is hashed so that credentials are not written to the store.
+func idempotencyStoreKey(endpoint, scope, key string) string {
+	h := sha256.Sum256([]byte(scope))
+	return endpoint + ":" + hex.EncodeToString(h[:]) + ":" + key
+}
+
+// requestFingerprint computes a hash of the request method, path, query
+// string and body.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00", r.Method, r.URL.Path, r.URL.RawQuery)
+	h.Write(body) // nolint:errcheck
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,194 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyHandler(t *testing.T) {
+	var calls int32
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		if string(body) == "fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("X-Call", string(rune('0'+n)))
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body) // nolint:errcheck
+	})
+	encodeError := func(ctx context.Context, w http.ResponseWriter, err error) error {
+		resp := NewErrorResponse(ctx, err)
+		w.WriteHeader(resp.StatusCode())
+		return json.NewEncoder(w).Encode(resp)
+	}
+	handler := NewIdempotency(nil).Handler("Payments.Charge", h, encodeError, nil)
+
+	do := func(key, method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/charges", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	first := do("k1", "POST", "a")
+	if first.Code != http.StatusCreated || first.Body.String() != "a" || first.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Fatalf("first: got status %d body %q", first.Code, first.Body.String())
+	}
+	replay := do("k1", "POST", "a")
+	if replay.Code != http.StatusCreated || replay.Body.String() != "a" {
+		t.Errorf("replay: got status %d body %q, expected %d %q", replay.Code, replay.Body.String(), http.StatusCreated, "a")
+	}
+	if replay.Header().Get("X-Call") != "1" || replay.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Errorf("replay: got headers %v, expected recorded headers", replay.Header())
+	}
+	if c := atomic.LoadInt32(&calls); c != 1 {
+		t.Errorf("got %d calls, expected 1", c)
+	}
+
+	mismatch := do("k1", "POST", "b")
+	if mismatch.Code != http.StatusUnprocessableEntity || !strings.Contains(mismatch.Body.String(), IdempotencyKeyMismatch) {
+		t.Errorf("mismatch: got status %d body %q", mismatch.Code, mismatch.Body.String())
+	}
+	if other := do("k1", "PUT", "a"); other.Code != http.StatusUnprocessableEntity {
+		t.Errorf("method mismatch: got status %d, expected %d", other.Code, http.StatusUnprocessableEntity)
+	}
+
+	if failed := do("k2", "POST", "fail"); failed.Code != http.StatusInternalServerError {
+		t.Errorf("failure: got status %d", failed.Code)
+	}
+	do("k2", "POST", "fail")
+	if c := atomic.LoadInt32(&calls); c != 3 {
+		t.Errorf("got %d calls, expected server errors not to be recorded", c)
+	}
+
+	do("", "POST", "a")
+	do("", "POST", "a")
+	if c := atomic.LoadInt32(&calls); c != 5 {
+		t.Errorf("got %d calls, expected requests without key to be served", c)
+	}
+}
+
+func TestIdempotencyKeyInUse(t *testing.T) {
+	store := NewMemoryIdempotencyStore(time.Minute)
+	key := idempotencyStoreKey("Payments.Charge", "", "k")
+	if _, err := store.Reserve(context.Background(), key, requestFingerprint(httptest.NewRequest("POST", "/", nil), nil)); err != nil {
+		t.Fatal(err)
+	}
+	encodeError := func(ctx context.Context, w http.ResponseWriter, err error) error {
+		w.WriteHeader(NewErrorResponse(ctx, err).StatusCode())
+		return nil
+	}
+	h := NewIdempotency(store).Handler("Payments.Charge", http.NotFoundHandler(), encodeError, nil)
+	req := httptest.NewRequest("POST", "/", nil)
+	req.Header.Set(IdempotencyKeyHeader, "k")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusConflict {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusConflict)
+	}
+}
+
+func TestIdempotencyScope(t *testing.T) {
+	var calls int32
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusCreated)
+	})
+	encodeError := func(ctx context.Context, w http.ResponseWriter, err error) error {
+		w.WriteHeader(NewErrorResponse(ctx, err).StatusCode())
+		return nil
+	}
+	idempotency := NewIdempotency(nil)
+	charge := idempotency.Handler("Payments.Charge", h, encodeError, nil)
+	refund := idempotency.Handler("Payments.Refund", h, encodeError, nil)
+	do := func(handler http.Handler, auth, body string) int {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		req.Header.Set(IdempotencyKeyHeader, "k")
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	do(charge, "Bearer alice", "a")
+	do(charge, "Bearer alice", "a")
+	if c := atomic.LoadInt32(&calls); c != 1 {
+		t.Errorf("got %d calls, expected replay for the same principal", c)
+	}
+	do(charge, "Bearer bob", "a")
+	if c := atomic.LoadInt32(&calls); c != 2 {
+		t.Errorf("got %d calls, expected the key to be scoped to the principal", c)
+	}
+	do(refund, "Bearer alice", "a")
+	if c := atomic.LoadInt32(&calls); c != 3 {
+		t.Errorf("got %d calls, expected the key to be scoped to the endpoint", c)
+	}
+
+	idempotency.SetScope(func(r *http.Request) string { return "tenant" })
+	do(charge, "Bearer carol", "a")
+	do(charge, "Bearer dave", "a")
+	if c := atomic.LoadInt32(&calls); c != 4 {
+		t.Errorf("got %d calls, expected the custom scope to be used", c)
+	}
+
+	idempotency.SetMaxBodySize(4)
+	if code := do(charge, "", "too large"); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("got status %d, expected %d", code, http.StatusRequestEntityTooLarge)
+	}
+	if c := atomic.LoadInt32(&calls); c != 4 {
+		t.Errorf("got %d calls, expected request with large body to be rejected", c)
+	}
+}
+
+func TestMemoryIdempotencyStoreExpiry(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryIdempotencyStore(time.Minute)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+	store.Reserve(ctx, "k", "f")                                                       // nolint:errcheck
+	store.Save(ctx, "k", &IdempotentResponse{Fingerprint: "f", Status: http.StatusOK}) // nolint:errcheck
+	if prev, _ := store.Reserve(ctx, "k", "f"); prev == nil || prev.Status != http.StatusOK {
+		t.Fatalf("got %v, expected recorded response", prev)
+	}
+	now = now.Add(2 * time.Minute)
+	if prev, _ := store.Reserve(ctx, "k", "f"); prev != nil {
+		t.Errorf("got %v, expected expired response", prev)
+	}
+}
+
+func TestIdempotentEndpoint(t *testing.T) {
+	var keys []string
+	e := IdempotentEndpoint(func(ctx context.Context, v any) (any, error) {
+		for i := 0; i < 2; i++ {
+			req := httptest.NewRequest("POST", "/", nil)
+			SetIdempotencyKey(ctx, req)
+			keys = append(keys, req.Header.Get(IdempotencyKeyHeader))
+		}
+		return nil, nil
+	})
+	e(context.Background(), nil)                               // nolint:errcheck
+	e(WithIdempotencyKey(context.Background(), "custom"), nil) // nolint:errcheck
+	if len(keys) != 4 {
+		t.Fatalf("got %d keys, expected 4", len(keys))
+	}
+	if keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("got keys %q and %q, expected the same generated key", keys[0], keys[1])
+	}
+	if keys[2] != "custom" || keys[3] != "custom" {
+		t.Errorf("got keys %q and %q, expected %q", keys[2], keys[3], "custom")
+	}
+}
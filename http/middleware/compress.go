@@ -0,0 +1,307 @@
+package middleware
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	goahttp "goa.design/goa/v3/http"
+)
+
+type (
+	// CompressOption configures the Compress middleware.
+	CompressOption func(*compressOptions)
+
+	compressOptions struct {
+		// minSize is the minimum size of the response bodies that get
+		// compressed.
+		minSize int
+		// encodings lists the content codings in order of preference.
+		encodings []string
+		// excluded lists the content types that are never compressed,
+		// entries that end with "/" match all the subtypes.
+		excluded []string
+	}
+
+	// compressWriter is a http.ResponseWriter that buffers the beginning
+	// of the response body to decide whether it should be compressed.
+	compressWriter struct {
+		http.ResponseWriter
+		opts     *compressOptions
+		encoding string
+		code     int
+		buf      []byte
+		// decided is true once the response headers have been written.
+		decided bool
+		// cw is the compressing writer, nil if the response is not
+		// compressed.
+		cw io.WriteCloser
+	}
+)
+
+// DefaultCompressMinSize is the default minimum size in bytes of the response
+// bodies compressed by the Compress middleware.
+const DefaultCompressMinSize = 1024
+
+// defaultCompressExcluded lists the content types that are not compressed by
+// default because they are already compressed or because they are streamed.
+var defaultCompressExcluded = []string{
+	"image/",
+	"audio/",
+	"video/",
+	"application/gzip",
+	"application/zip",
+	"application/x-7z-compressed",
+	"application/x-bzip2",
+	"application/x-rar-compressed",
+	"application/zstd",
+	"application/octet-stream",
+	"text/event-stream",
+}
+
+// Compress returns a middleware that compresses the response bodies using the
+// content coding that best matches the request Accept-Encoding header. The
+// middleware buffers the beginning of the response body and only compresses
+// bodies larger than the minimum size, see CompressMinSize. Responses that
+// already have a Content-Encoding header, responses to HEAD requests,
+// responses with no body and responses whose content type is excluded (see
+// CompressExcludedTypes) are not compressed. Requests that upgrade the
+// connection (e.g. websocket handshakes) are served without compression.
+//
+// Compress should be mounted before (i.e. wrap) middlewares that use
+// ResponseCapture so that they capture the uncompressed status and length.
+// Use Decompress to decompress the request bodies.
+//
+// Example:
+//
+//	handler = middleware.Compress(middleware.CompressMinSize(512))(handler)
+func Compress(options ...CompressOption) func(http.Handler) http.Handler {
+	o := &compressOptions{minSize: DefaultCompressMinSize, excluded: defaultCompressExcluded}
+	for _, opt := range options {
+		opt(o)
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+			enc := goahttp.NegotiateEncoding(r.Header.Get("Accept-Encoding"), o.encodings...)
+			if enc == "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+			cw := &compressWriter{ResponseWriter: w, opts: o, encoding: enc, code: http.StatusOK}
+			defer cw.close()
+			h.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// CompressMinSize sets the minimum size in bytes of the response bodies that
+// get compressed, the default is DefaultCompressMinSize.
+func CompressMinSize(size int) CompressOption {
+	return func(o *compressOptions) {
+		o.minSize = size
+	}
+}
+
+// CompressEncodings sets the content codings used to compress the responses
+// in order of preference. The content codings must have a registered
+// compressor, see goahttp.RegisterCompressor. The default is to use all the
+// registered content codings in registration order.
+func CompressEncodings(encodings ...string) CompressOption {
+	return func(o *compressOptions) {
+		o.encodings = encodings
+	}
+}
+
+// CompressExcludedTypes adds content types that are never compressed. A
+// content type that ends with "/" (e.g. "image/") excludes all its subtypes.
+// Images, audio, video, archives, application/octet-stream and
+// text/event-stream are excluded by default.
+func CompressExcludedTypes(contentTypes ...string) CompressOption {
+	return func(o *compressOptions) {
+		excluded := make([]string, 0, len(o.excluded)+len(contentTypes))
+		excluded = append(excluded, o.excluded...)
+		for _, ct := range contentTypes {
+			excluded = append(excluded, strings.ToLower(ct))
+		}
+		o.excluded = excluded
+	}
+}
+
+// Decompress returns a middleware that decompresses the bodies of requests
+// that have a Content-Encoding header so that the request decoders read the
+// uncompressed content. The middleware responds with 415 Unsupported Media
+// Type if there is no compressor registered for the content coding, see
+// goahttp.RegisterCompressor.
+func Decompress() func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			enc := r.Header.Get("Content-Encoding")
+			if enc == "" || r.Body == nil || r.Body == http.NoBody {
+				h.ServeHTTP(w, r)
+				return
+			}
+			body, err := goahttp.DecompressBody(r.Body, enc)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+				return
+			}
+			r = r.Clone(r.Context())
+			r.Body = body
+			r.ContentLength = -1
+			r.Header.Del("Content-Encoding")
+			r.Header.Del("Content-Length")
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// WriteHeader records the status code, the header is written once the
+// middleware has decided whether to compress the response.
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if code >= 100 && code < 200 {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.code = code
+	if code == http.StatusNoContent || code == http.StatusNotModified {
+		w.decide(false)
+	}
+}
+
+// Write buffers b until the buffered body reaches the minimum size and
+// compresses the body afterwards.
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		w.buf = append(w.buf, b...)
+		if len(w.buf) < w.opts.minSize {
+			return len(b), nil
+		}
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	if w.cw != nil {
+		return w.cw.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush writes the buffered data and flushes the underlying response writer
+// if it supports it. Responses flushed before reaching the minimum size are
+// not compressed.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide(len(w.buf) >= w.opts.minSize) // nolint: errcheck
+	}
+	if f, ok := w.cw.(interface{ Flush() error }); ok {
+		f.Flush() // nolint: errcheck
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Push implements the http.Pusher interface if the underlying response
+// writer supports it.
+func (w *compressWriter) Push(target string, opts *http.PushOptions) error {
+	if p, ok := w.ResponseWriter.(http.Pusher); ok {
+		return p.Push(target, opts)
+	}
+	return errors.New("push not supported")
+}
+
+// Hijack supports the http.Hijacker interface. The response is not
+// compressed once the connection is hijacked.
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking: %T", w.ResponseWriter)
+	}
+	w.decided = true
+	return h.Hijack()
+}
+
+// Unwrap returns the underlying response writer, it is used by
+// http.ResponseController.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// decide writes the response headers and the buffered body, compressing it if
+// compress is true and the response is eligible for compression.
+func (w *compressWriter) decide(compress bool) error {
+	w.decided = true
+	h := w.Header()
+	if compress && len(w.buf) > 0 && h.Get("Content-Type") == "" {
+		// Detect the content type before compressing the body, like
+		// net/http would.
+		h.Set("Content-Type", http.DetectContentType(w.buf))
+	}
+	if compress && h.Get("Content-Encoding") == "" && !w.excluded(h.Get("Content-Type")) {
+		c, _ := goahttp.LookupCompressor(w.encoding)
+		cw, err := c.NewWriter(w.ResponseWriter)
+		if err != nil {
+			return err
+		}
+		w.cw = cw
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+	}
+	w.ResponseWriter.WriteHeader(w.code)
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if w.cw != nil {
+		_, err = w.cw.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// close writes any buffered data and flushes the compressing writer.
+func (w *compressWriter) close() {
+	if !w.decided {
+		if w.buf == nil && w.code == http.StatusOK {
+			// Nothing written, let net/http write the default
+			// response.
+			return
+		}
+		w.decide(false) // nolint: errcheck
+	}
+	if w.cw != nil {
+		w.cw.Close() // nolint: errcheck
+	}
+}
+
+// excluded returns true if the given content type must not be compressed.
+func (w *compressWriter) excluded(contentType string) bool {
+	ct := strings.ToLower(contentType)
+	if i := strings.IndexByte(ct, ';'); i >= 0 {
+		ct = ct[:i]
+	}
+	ct = strings.TrimSpace(ct)
+	for _, ex := range w.opts.excluded {
+		if ct == ex || strings.HasSuffix(ex, "/") && strings.HasPrefix(ct, ex) {
+			return true
+		}
+	}
+	return false
+}
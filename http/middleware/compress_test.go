@@ -0,0 +1,138 @@
+package middleware_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpm "goa.design/goa/v3/http/middleware"
+)
+
+func TestCompress(t *testing.T) {
+	var (
+		large = strings.Repeat("goa ", 512)
+		small = "goa"
+	)
+	cases := []struct {
+		name        string
+		accept      string
+		contentType string
+		body        string
+		options     []httpm.CompressOption
+		encoding    string
+	}{
+		{"gzip", "gzip", "text/plain", large, nil, "gzip"},
+		{"deflate", "deflate", "text/plain", large, nil, "deflate"},
+		{"not accepted", "", "text/plain", large, nil, ""},
+		{"too small", "gzip", "text/plain", small, nil, ""},
+		{"min size", "gzip", "text/plain", small, []httpm.CompressOption{httpm.CompressMinSize(1)}, "gzip"},
+		{"excluded", "gzip", "image/png", large, nil, ""},
+		{"custom excluded", "gzip", "application/pdf", large, []httpm.CompressOption{httpm.CompressExcludedTypes("application/pdf")}, ""},
+		{"encodings", "gzip, deflate", "text/plain", large, []httpm.CompressOption{httpm.CompressEncodings("deflate")}, "deflate"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := httpm.Compress(c.options...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", c.contentType)
+				w.WriteHeader(http.StatusCreated)
+				io.WriteString(w, c.body) // nolint: errcheck
+			}))
+			req := httptest.NewRequest("GET", "/", nil)
+			if c.accept != "" {
+				req.Header.Set("Accept-Encoding", c.accept)
+			}
+			rw := httptest.NewRecorder()
+			h.ServeHTTP(rw, req)
+			if rw.Code != http.StatusCreated {
+				t.Errorf("got status %d, expected %d", rw.Code, http.StatusCreated)
+			}
+			if got := rw.Header().Get("Content-Encoding"); got != c.encoding {
+				t.Errorf("got Content-Encoding %q, expected %q", got, c.encoding)
+			}
+			if got := rw.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("got Vary %q, expected %q", got, "Accept-Encoding")
+			}
+			if got := decompress(t, c.encoding, rw.Body.Bytes()); got != c.body {
+				t.Errorf("got body of length %d, expected %d", len(got), len(c.body))
+			}
+		})
+	}
+}
+
+func TestCompressCapture(t *testing.T) {
+	var capture *httpm.ResponseCapture
+	h := httpm.Compress(httpm.CompressMinSize(1))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capture = httpm.CaptureResponse(w)
+		capture.WriteHeader(http.StatusAccepted)
+		io.WriteString(capture, "goa") // nolint: errcheck
+		capture.Flush()
+	}))
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+	if capture.StatusCode != http.StatusAccepted || capture.ContentLength != 3 {
+		t.Errorf("got status %d and length %d, expected %d and 3", capture.StatusCode, capture.ContentLength, http.StatusAccepted)
+	}
+	if !rw.Flushed {
+		t.Error("response was not flushed")
+	}
+	if got := decompress(t, "gzip", rw.Body.Bytes()); got != "goa" {
+		t.Errorf("got body %q, expected %q", got, "goa")
+	}
+}
+
+func TestDecompress(t *testing.T) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	io.WriteString(gw, `{"name":"goa"}`) // nolint: errcheck
+	gw.Close()                           // nolint: errcheck
+	var got string
+	h := httpm.Decompress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got = string(b)
+		if enc := r.Header.Get("Content-Encoding"); enc != "" {
+			t.Errorf("got Content-Encoding %q, expected none", enc)
+		}
+	}))
+
+	req := httptest.NewRequest("POST", "/", &buf)
+	req.Header.Set("Content-Encoding", "gzip")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if got != `{"name":"goa"}` {
+		t.Errorf("got body %q, expected %q", got, `{"name":"goa"}`)
+	}
+
+	req = httptest.NewRequest("POST", "/", strings.NewReader("goa"))
+	req.Header.Set("Content-Encoding", "compress")
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+	if rw.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("got status %d, expected %d", rw.Code, http.StatusUnsupportedMediaType)
+	}
+}
+
+func decompress(t *testing.T, encoding string, b []byte) string {
+	t.Helper()
+	var r io.Reader = bytes.NewReader(b)
+	switch encoding {
+	case "gzip":
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = gr
+	case "deflate":
+		r = flate.NewReader(r)
+	}
+	res, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(res)
+}
@@ -14,6 +14,7 @@ import (
 
 	goahttp "goa.design/goa/v3/http"
 	"goa.design/goa/v3/middleware"
+	goa "goa.design/goa/v3/pkg"
 )
 
 // responseDupper tees the response to a buffer and a response writer.
@@ -25,19 +26,37 @@ type responseDupper struct {
 
 // Debug returns a debug middleware which prints detailed information about
 // incoming requests and outgoing responses including all headers, parameters
-// and bodies.
+// and bodies. The values of the headers, parameters and JSON body fields that
+// the handled endpoint defines as sensitive are redacted.
 func Debug(mux goahttp.Muxer, w io.Writer) func(http.Handler) http.Handler {
 	return func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-			buf := &bytes.Buffer{}
 			// Request ID
 			reqID := r.Context().Value(middleware.RequestIDKey)
 			if reqID == nil {
 				reqID = shortID()
 			}
 
+			// Request parameters
+			params := mux.Vars(r)
+
+			// Request body
+			b, err := io.ReadAll(r.Body)
+			if err != nil {
+				b = []byte("failed to read body: " + err.Error())
+			}
+			r.Body = io.NopCloser(bytes.NewBuffer(b))
+
+			// The endpoint handler adds the names of its sensitive
+			// attributes to the set so the request is printed once it
+			// completes.
+			ctx, sensitive := goa.ContextWithSensitive(r.Context())
+			dupper := &responseDupper{ResponseWriter: rw, Buffer: &bytes.Buffer{}}
+			h.ServeHTTP(dupper, r.WithContext(ctx))
+
 			// Request URL
-			buf.WriteString(fmt.Sprintf("> [%s] %s %s", reqID, r.Method, r.URL.String()))
+			buf := &bytes.Buffer{}
+			buf.WriteString(fmt.Sprintf("> [%s] %s %s", reqID, r.Method, sensitive.RedactQuery(r.URL).String()))
 
 			// Request Headers
 			keys := make([]string, len(r.Header))
@@ -48,11 +67,9 @@ func Debug(mux goahttp.Muxer, w io.Writer) func(http.Handler) http.Handler {
 			}
 			sort.Strings(keys)
 			for _, k := range keys {
-				buf.WriteString(fmt.Sprintf("\n> [%s] %s: %s", reqID, k, strings.Join(r.Header[k], ", ")))
+				buf.WriteString(fmt.Sprintf("\n> [%s] %s: %s", reqID, k, headerValue(sensitive, k, r.Header[k])))
 			}
 
-			// Request parameters
-			params := mux.Vars(r)
 			keys = make([]string, len(params))
 			i = 0
 			for k := range params {
@@ -61,25 +78,20 @@ func Debug(mux goahttp.Muxer, w io.Writer) func(http.Handler) http.Handler {
 			}
 			sort.Strings(keys)
 			for _, k := range keys {
-				buf.WriteString(fmt.Sprintf("\n> [%s] %s: %s", reqID, k, params[k]))
+				v := params[k]
+				if sensitive.IsSensitive(k) {
+					v = goa.Redacted
+				}
+				buf.WriteString(fmt.Sprintf("\n> [%s] %s: %s", reqID, k, v))
 			}
 
-			// Request body
-			b, err := io.ReadAll(r.Body)
-			if err != nil {
-				b = []byte("failed to read body: " + err.Error())
-			}
 			if len(b) > 0 {
 				buf.WriteByte('\n')
-				lines := strings.Split(string(b), "\n")
+				lines := strings.Split(string(sensitive.RedactJSON(b)), "\n")
 				for _, line := range lines {
 					buf.WriteString(fmt.Sprintf("[%s] %s\n", reqID, line))
 				}
 			}
-			r.Body = io.NopCloser(bytes.NewBuffer(b))
-
-			dupper := &responseDupper{ResponseWriter: rw, Buffer: &bytes.Buffer{}}
-			h.ServeHTTP(dupper, r)
 
 			buf.WriteString(fmt.Sprintf("\n< [%s] %s", reqID, http.StatusText(dupper.Status)))
 			keys = make([]string, len(dupper.Header()))
@@ -90,11 +102,11 @@ func Debug(mux goahttp.Muxer, w io.Writer) func(http.Handler) http.Handler {
 			}
 			sort.Strings(keys)
 			for _, k := range keys {
-				buf.WriteString(fmt.Sprintf("\n< [%s] %s: %s", reqID, k, strings.Join(dupper.Header()[k], ", ")))
+				buf.WriteString(fmt.Sprintf("\n< [%s] %s: %s", reqID, k, headerValue(sensitive, k, dupper.Header()[k])))
 			}
 			if dupper.Buffer.Len() > 0 {
 				buf.WriteByte('\n')
-				lines := strings.Split(dupper.Buffer.String(), "\n")
+				lines := strings.Split(string(sensitive.RedactJSON(dupper.Buffer.Bytes())), "\n")
 				for _, line := range lines {
 					buf.WriteString(fmt.Sprintf("[%s] %s\n", reqID, line))
 				}
@@ -125,6 +137,15 @@ func (r *responseDupper) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	return nil, nil, fmt.Errorf("debug middleware: inner ResponseWriter cannot be hijacked: %T", r.ResponseWriter)
 }
 
+// headerValue returns the value of the given header or goa.Redacted if the
+// header is sensitive.
+func headerValue(sensitive *goa.SensitiveNames, name string, vals []string) string {
+	if sensitive.IsSensitive(name) {
+		return goa.Redacted
+	}
+	return strings.Join(vals, ", ")
+}
+
 // shortID produces a " unique" 6 bytes long string.
 // Do not use as a reliable way to get unique IDs, instead use for things like logging.
 func shortID() string {
@@ -0,0 +1,48 @@
+package middleware_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestDebugRedactsEndpointSensitiveNames(t *testing.T) {
+	var buf bytes.Buffer
+	mux := goahttp.NewMuxer()
+	mux.Use(httpm.Debug(mux, &buf))
+	mux.Handle("POST", "/login", func(w http.ResponseWriter, r *http.Request) {
+		goa.WithSensitive(r.Context(), "password", "X-API-Key")
+		io.Copy(io.Discard, r.Body) // nolint: errcheck
+		w.Header().Set("X-API-Key", "response-key")
+		w.Write([]byte(`{"password":"returned"}`)) // nolint: errcheck
+	})
+	mux.Handle("POST", "/echo", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"password":"plain"}`)) // nolint: errcheck
+	})
+
+	req := httptest.NewRequest("POST", "/login", strings.NewReader(`{"password":"secret","user":"joe"}`))
+	req.Header.Set("X-API-Key", "request-key")
+	mux.ServeHTTP(httptest.NewRecorder(), req)
+	got := buf.String()
+	for _, secret := range []string{"secret", "request-key", "response-key", "returned"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("sensitive value %q not redacted:\n%s", secret, got)
+		}
+	}
+	if !strings.Contains(got, "joe") || !strings.Contains(got, goa.Redacted) {
+		t.Errorf("unexpected debug output:\n%s", got)
+	}
+
+	buf.Reset()
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/echo", nil))
+	if got := buf.String(); !strings.Contains(got, "plain") {
+		t.Errorf("value redacted for endpoint without sensitive names:\n%s", got)
+	}
+}
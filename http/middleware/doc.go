@@ -6,6 +6,8 @@ The package contains the following middlewares:
   * Logging server middleware for logging requests and responses.
   * Request ID server middleware to include a unique request ID on receiving
     a HTTP request.
+  * Compression server middlewares that compress the response bodies and
+    decompress the request bodies.
   * Tracing middleware for server and client.
   * AWS X-Ray middleware for server and client that produce X-Ray segments.
 
@@ -0,0 +1,16 @@
+/*
+Package metrics contains middleware that records Prometheus compatible metrics
+from the HTTP requests and responses.
+
+The server middleware records the number of requests, their duration, the
+request and response body sizes and the number of requests in flight. The
+metrics are labeled with the route pattern resolved by the mux and the Goa
+service and method set by the Endpoint middleware, which must be mounted on
+the service endpoints. Handler serves the recorded metrics using the
+Prometheus text exposition format.
+
+The client middleware wraps the client Doer. It records the same metrics
+labeled with the Goa service and method stored in the request context by the
+generated clients.
+*/
+package metrics
@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+	"goa.design/goa/v3/middleware/metrics"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// countingBody is a request body that counts the number of bytes read.
+type countingBody struct {
+	body io.ReadCloser
+	n    int
+}
+
+// New returns a HTTP middleware that records the number of requests, their
+// duration, the request and response body sizes and the number of requests
+// in flight. The metrics are labeled with the Goa service and method, the
+// route pattern and, for the request counts and durations, the response
+// status code.
+//
+// The route pattern is resolved only if mux implements
+// goahttp.ResolverMuxer. The Goa service and method labels are set by the
+// metrics.Endpoint endpoint middleware, they are empty if the endpoints do
+// not use it. mux may be nil, the middleware must be mounted on mux via its
+// Use method for the route pattern to be resolved.
+//
+// Example:
+//
+//	mux := goahttp.NewMuxer()
+//	mux.Use(metrics.New(mux))
+//	mux.Handle("GET", "/metrics", metrics.Handler().ServeHTTP)
+//	endpoints.Use(metrics.Endpoint())
+func New(mux goahttp.Muxer, opts ...metrics.Option) func(http.Handler) http.Handler {
+	ins := metrics.NewOptions(opts...).Instruments("http", "server", "service", "method", "route")
+	resolver, _ := mux.(goahttp.ResolverMuxer)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			var route string
+			if resolver != nil {
+				route = resolver.ResolvePattern(r)
+			}
+			ctx, labels := metrics.ContextWithLabels(r.Context(), func(svc, meth string) {
+				ins.InFlight.Inc(svc, meth, route)
+			})
+			var body *countingBody
+			if r.Body != nil && r.Body != http.NoBody {
+				body = &countingBody{body: r.Body}
+				r.Body = body
+			}
+			rw := httpm.CaptureResponse(w)
+			h.ServeHTTP(rw, r.WithContext(ctx))
+
+			svc, meth := labels.Get()
+			if svc != "" || meth != "" {
+				ins.InFlight.Dec(svc, meth, route)
+			}
+			status := rw.StatusCode
+			if status == 0 {
+				status = http.StatusOK
+			}
+			var reqSize int
+			if body != nil {
+				reqSize = body.n
+			}
+			code := strconv.Itoa(status)
+			ins.Requests.Inc(svc, meth, route, code)
+			ins.Duration.Observe(time.Since(start).Seconds(), svc, meth, route, code)
+			ins.RequestSize.Observe(float64(reqSize), svc, meth, route)
+			ins.ResponseSize.Observe(float64(rw.ContentLength), svc, meth, route)
+		})
+	}
+}
+
+// Handler returns a HTTP handler that serves the metrics of the options
+// registry using the Prometheus text exposition format.
+func Handler(opts ...metrics.Option) http.Handler {
+	registry := metrics.NewOptions(opts...).Registry()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", metrics.ContentType)
+		registry.WriteText(w) // nolint: errcheck
+	})
+}
+
+// Read reads from the underlying body and counts the number of bytes read.
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.n += n
+	return n, err
+}
+
+// Close closes the underlying body.
+func (b *countingBody) Close() error {
+	return b.body.Close()
+}
+
+// Endpoint is a wrapper for the top-level Endpoint.
+func Endpoint() func(goa.Endpoint) goa.Endpoint {
+	return metrics.Endpoint()
+}
+
+// WithRegistry is a wrapper for the top-level WithRegistry.
+func WithRegistry(r *metrics.Registry) metrics.Option {
+	return metrics.WithRegistry(r)
+}
+
+// WithNamespace is a wrapper for the top-level WithNamespace.
+func WithNamespace(ns string) metrics.Option {
+	return metrics.WithNamespace(ns)
+}
+
+// WithDurationBuckets is a wrapper for the top-level WithDurationBuckets.
+func WithDurationBuckets(buckets ...float64) metrics.Option {
+	return metrics.WithDurationBuckets(buckets...)
+}
+
+// WithSizeBuckets is a wrapper for the top-level WithSizeBuckets.
+func WithSizeBuckets(buckets ...float64) metrics.Option {
+	return metrics.WithSizeBuckets(buckets...)
+}
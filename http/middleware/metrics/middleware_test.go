@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware/metrics"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Endpoint   bool
+		StatusCode int
+		Expected   []string
+	}{
+		{"no-endpoint", false, http.StatusOK, []string{
+			`http_server_requests_total{service="",method="",route="/items/{id}",code="200"} 1`,
+			`http_server_request_size_bytes_sum{service="",method="",route="/items/{id}"} 7`,
+			`http_server_response_size_bytes_sum{service="",method="",route="/items/{id}"} 8`,
+		}},
+		{"endpoint", true, http.StatusCreated, []string{
+			`http_server_requests_total{service="Items",method="Create",route="/items/{id}",code="201"} 1`,
+			`http_server_request_duration_seconds_count{service="Items",method="Create",route="/items/{id}",code="201"} 1`,
+			`http_server_requests_in_flight{service="Items",method="Create",route="/items/{id}"} 0`,
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := metrics.NewRegistry()
+			mux := goahttp.NewMuxer()
+			mux.Use(New(mux, WithRegistry(r)))
+			mux.Handle("POST", "/items/{id}", func(w http.ResponseWriter, req *http.Request) {
+				if c.Endpoint {
+					ctx := context.WithValue(req.Context(), goa.ServiceKey, "Items")
+					ctx = context.WithValue(ctx, goa.MethodKey, "Create")
+					Endpoint()(func(context.Context, any) (any, error) { return nil, nil })(ctx, nil) // nolint: errcheck
+				}
+				io.ReadAll(req.Body) // nolint: errcheck
+				w.WriteHeader(c.StatusCode)
+				w.Write([]byte("response")) // nolint: errcheck
+			})
+			mux.Handle("GET", "/metrics", Handler(WithRegistry(r)).ServeHTTP)
+
+			mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/items/42", strings.NewReader("request")))
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+			if ct := w.Header().Get("Content-Type"); ct != metrics.ContentType {
+				t.Errorf("got content type %q, expected %q", ct, metrics.ContentType)
+			}
+			body := w.Body.String()
+			for _, expected := range c.Expected {
+				if !strings.Contains(body, expected) {
+					t.Errorf("metric %q not found in:\n%s", expected, body)
+				}
+			}
+			if strings.Contains(body, "/items/42") {
+				t.Errorf("raw path used as label in:\n%s", body)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware/metrics"
+)
+
+// metricsDoer is a goahttp.Doer middleware that records client request
+// metrics.
+type metricsDoer struct {
+	wrapped     goahttp.Doer
+	instruments *metrics.Instruments
+}
+
+// WrapDoer wraps a goa HTTP Doer and records the number of requests, their
+// duration, the request and response body sizes and the number of requests
+// in flight. The metrics are labeled with the Goa service and method stored
+// in the request context by the generated clients and, for the request
+// counts and durations, the response status code or "error" if the request
+// failed. The body sizes are recorded only when the content length is known.
+//
+// Example:
+//
+//	doer := metrics.WrapDoer(http.DefaultClient)
+//	client := genclient.NewClient(scheme, host, doer, enc, dec, restore)
+func WrapDoer(doer goahttp.Doer, opts ...metrics.Option) goahttp.Doer {
+	ins := metrics.NewOptions(opts...).Instruments("http", "client", "service", "method")
+	return &metricsDoer{wrapped: doer, instruments: ins}
+}
+
+// Do calls through to the wrapped Doer and records the request metrics.
+func (d *metricsDoer) Do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	svc, meth := metrics.ServiceMethod(req.Context())
+	d.instruments.InFlight.Inc(svc, meth)
+	resp, err := d.wrapped.Do(req)
+	d.instruments.InFlight.Dec(svc, meth)
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	d.instruments.Requests.Inc(svc, meth, code)
+	d.instruments.Duration.Observe(time.Since(start).Seconds(), svc, meth, code)
+	if req.ContentLength >= 0 {
+		d.instruments.RequestSize.Observe(float64(req.ContentLength), svc, meth)
+	}
+	if err == nil && resp.ContentLength >= 0 {
+		d.instruments.ResponseSize.Observe(float64(resp.ContentLength), svc, meth)
+	}
+	return resp, err
+}
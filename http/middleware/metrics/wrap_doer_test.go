@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/middleware/metrics"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// testDoer returns a response with the given status code or the given
+// error.
+type testDoer struct {
+	code int
+	err  error
+}
+
+func TestWrapDoer(t *testing.T) {
+	cases := []struct {
+		Name       string
+		StatusCode int
+		Error      error
+		Expected   []string
+	}{
+		{"ok", http.StatusOK, nil, []string{
+			`http_client_requests_total{service="Items",method="Create",code="200"} 1`,
+			`http_client_request_size_bytes_sum{service="Items",method="Create"} 7`,
+			`http_client_response_size_bytes_sum{service="Items",method="Create"} 3`,
+			`http_client_requests_in_flight{service="Items",method="Create"} 0`,
+		}},
+		{"failed-request", http.StatusBadRequest, nil, []string{
+			`http_client_requests_total{service="Items",method="Create",code="400"} 1`,
+		}},
+		{"error", 0, errors.New("error"), []string{
+			`http_client_requests_total{service="Items",method="Create",code="error"} 1`,
+			`http_client_request_duration_seconds_count{service="Items",method="Create",code="error"} 1`,
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := metrics.NewRegistry()
+			ctx := context.WithValue(context.Background(), goa.ServiceKey, "Items")
+			ctx = context.WithValue(ctx, goa.MethodKey, "Create")
+			req, err := http.NewRequestWithContext(ctx, "POST", "http://somehost:80/items", strings.NewReader("request"))
+			if err != nil {
+				t.Fatalf("error creating HTTP request: %v", err)
+			}
+
+			_, err = WrapDoer(&testDoer{code: c.StatusCode, err: c.Error}, WithRegistry(r)).Do(req)
+
+			if err != c.Error {
+				t.Errorf("got error %v, expected %v", err, c.Error)
+			}
+			var sb strings.Builder
+			r.WriteText(&sb) // nolint: errcheck
+			for _, expected := range c.Expected {
+				if !strings.Contains(sb.String(), expected) {
+					t.Errorf("metric %q not found in:\n%s", expected, sb.String())
+				}
+			}
+		})
+	}
+}
+
+func (d *testDoer) Do(req *http.Request) (*http.Response, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	return &http.Response{StatusCode: d.code, ContentLength: 3}, nil
+}
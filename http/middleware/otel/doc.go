@@ -0,0 +1,14 @@
+/*
+Package otel contains middleware that creates OpenTelemetry spans and metrics
+from the HTTP requests and responses.
+
+The server middleware extracts the trace context from the request headers
+(W3C "traceparent" by default), creates a server span and stores it in the
+request context. It also records the request duration and the request and
+response body sizes. Mount the Endpoint middleware on the service endpoints to
+name the span after the Goa service and method.
+
+The client middleware wraps the client Doer. It creates a client span for each
+request and injects the trace context in the request headers.
+*/
+package otel
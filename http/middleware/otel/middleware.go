@@ -0,0 +1,169 @@
+package otel
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+	"goa.design/goa/v3/middleware/otel"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// httpMetrics holds the HTTP metric instruments.
+	httpMetrics struct {
+		duration     metric.Float64Histogram
+		requestSize  metric.Float64Histogram
+		responseSize metric.Float64Histogram
+	}
+
+	// countingBody is a request body that counts the number of bytes read.
+	countingBody struct {
+		body io.ReadCloser
+		n    int
+	}
+)
+
+// New returns a HTTP middleware that creates an OpenTelemetry server span for
+// each request and records the request duration as well as the request and
+// response body sizes. The trace context is extracted from the request headers
+// using the W3C "traceparent" header by default.
+//
+// The span is initially named after the request method and, if mux implements
+// goahttp.ResolverMuxer, the route pattern. Mount the otel.Endpoint endpoint
+// middleware to rename the span after the Goa service and method.
+//
+// mux may be nil, the middleware must be mounted on mux via its Use method for
+// the route pattern to be resolved.
+//
+// Example:
+//
+//	mux := goahttp.NewMuxer()
+//	mux.Use(otel.New(mux, otel.WithTracerProvider(tp)))
+//	endpoints.Use(otel.Endpoint())
+func New(mux goahttp.Muxer, opts ...otel.Option) func(http.Handler) http.Handler {
+	o := otel.NewOptions(opts...)
+	tracer := o.Tracer()
+	metrics := newHTTPMetrics(o, "server")
+	resolver, _ := mux.(goahttp.ResolverMuxer)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ctx := o.Propagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			var route string
+			if resolver != nil {
+				route = resolver.ResolvePattern(r)
+			}
+			attrs := []attribute.KeyValue{
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLScheme(scheme(r)),
+			}
+			name := r.Method
+			if route != "" {
+				name += " " + route
+				attrs = append(attrs, semconv.HTTPRoute(route))
+			}
+			ctx, span := tracer.Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(semconv.URLPath(r.URL.Path)),
+			)
+			defer span.End()
+
+			var body *countingBody
+			if r.Body != nil && r.Body != http.NoBody {
+				body = &countingBody{body: r.Body}
+				r.Body = body
+			}
+			rw := httpm.CaptureResponse(w)
+			h.ServeHTTP(rw, r.WithContext(ctx))
+
+			status := rw.StatusCode
+			if status == 0 {
+				status = http.StatusOK
+			}
+			attrs = append(attrs, semconv.HTTPResponseStatusCode(status))
+			var reqSize int
+			if body != nil {
+				reqSize = body.n
+			}
+			span.SetAttributes(
+				semconv.HTTPResponseStatusCode(status),
+				semconv.HTTPRequestBodySize(reqSize),
+				semconv.HTTPResponseBodySize(rw.ContentLength),
+			)
+			if status >= 500 {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			metrics.record(ctx, start, reqSize, rw.ContentLength, attrs)
+		})
+	}
+}
+
+// newHTTPMetrics creates the HTTP metric instruments for the given side
+// ("server" or "client").
+func newHTTPMetrics(o *otel.Options, side string) *httpMetrics {
+	return &httpMetrics{
+		duration:     o.Histogram("http."+side+".request.duration", "s", "Duration of HTTP "+side+" requests."),
+		requestSize:  o.Histogram("http."+side+".request.body.size", "By", "Size of HTTP "+side+" request bodies."),
+		responseSize: o.Histogram("http."+side+".response.body.size", "By", "Size of HTTP "+side+" response bodies."),
+	}
+}
+
+// record records the request duration and sizes.
+func (m *httpMetrics) record(ctx context.Context, start time.Time, reqSize, respSize int, attrs []attribute.KeyValue) {
+	set := metric.WithAttributes(attrs...)
+	m.duration.Record(ctx, time.Since(start).Seconds(), set)
+	m.requestSize.Record(ctx, float64(reqSize), set)
+	m.responseSize.Record(ctx, float64(respSize), set)
+}
+
+// Read reads from the underlying body and counts the number of bytes read.
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.n += n
+	return n, err
+}
+
+// Close closes the underlying body.
+func (b *countingBody) Close() error {
+	return b.body.Close()
+}
+
+// scheme returns the request URL scheme.
+func scheme(r *http.Request) string {
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// Endpoint is a wrapper for the top-level Endpoint.
+func Endpoint(opts ...otel.Option) func(goa.Endpoint) goa.Endpoint {
+	return otel.Endpoint(opts...)
+}
+
+// WithTracerProvider is a wrapper for the top-level WithTracerProvider.
+func WithTracerProvider(p trace.TracerProvider) otel.Option {
+	return otel.WithTracerProvider(p)
+}
+
+// WithMeterProvider is a wrapper for the top-level WithMeterProvider.
+func WithMeterProvider(p metric.MeterProvider) otel.Option {
+	return otel.WithMeterProvider(p)
+}
+
+// WithPropagator is a wrapper for the top-level WithPropagator.
+func WithPropagator(p propagation.TextMapPropagator) otel.Option {
+	return otel.WithPropagator(p)
+}
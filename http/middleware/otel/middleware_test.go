@@ -0,0 +1,130 @@
+package otel
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	goahttp "goa.design/goa/v3/http"
+)
+
+const (
+	traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	spanID      = "00f067aa0ba902b7"
+	traceparent = "00-" + traceID + "-" + spanID + "-01"
+)
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Traceparent string
+		Body        string
+		StatusCode  int
+		SpanStatus  codes.Code
+	}{
+		{"no-parent", "", "", http.StatusOK, codes.Unset},
+		{"parent", traceparent, "", http.StatusOK, codes.Unset},
+		{"request-body", traceparent, "request", http.StatusCreated, codes.Unset},
+		{"client-error", "", "", http.StatusBadRequest, codes.Unset},
+		{"server-error", "", "", http.StatusInternalServerError, codes.Error},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			mux := goahttp.NewMuxer()
+			mux.Use(New(mux, WithTracerProvider(tp), WithMeterProvider(mp)))
+			mux.Handle("POST", "/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+				if !trace.SpanFromContext(r.Context()).SpanContext().IsValid() {
+					t.Error("span not found in request context")
+				}
+				io.ReadAll(r.Body) // nolint: errcheck
+				w.WriteHeader(c.StatusCode)
+				w.Write([]byte("response")) // nolint: errcheck
+			})
+			req := httptest.NewRequest("POST", "/items/42", strings.NewReader(c.Body))
+			if c.Traceparent != "" {
+				req.Header.Set("traceparent", c.Traceparent)
+			}
+
+			mux.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, expected 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name != "POST /items/{id}" {
+				t.Errorf("got span name %q, expected %q", span.Name, "POST /items/{id}")
+			}
+			if span.SpanKind != trace.SpanKindServer {
+				t.Errorf("got span kind %v, expected %v", span.SpanKind, trace.SpanKindServer)
+			}
+			if c.Traceparent != "" {
+				if span.SpanContext.TraceID().String() != traceID {
+					t.Errorf("got trace ID %q, expected %q", span.SpanContext.TraceID(), traceID)
+				}
+				if span.Parent.SpanID().String() != spanID {
+					t.Errorf("got parent span ID %q, expected %q", span.Parent.SpanID(), spanID)
+				}
+			} else if span.Parent.IsValid() {
+				t.Errorf("got parent span %q, expected none", span.Parent.SpanID())
+			}
+			if span.Status.Code != c.SpanStatus {
+				t.Errorf("got span status %v, expected %v", span.Status.Code, c.SpanStatus)
+			}
+			attrs := attribute.NewSet(span.Attributes...)
+			expected := []attribute.KeyValue{
+				semconv.HTTPRoute("/items/{id}"),
+				semconv.HTTPResponseStatusCode(c.StatusCode),
+				semconv.HTTPRequestBodySize(len(c.Body)),
+				semconv.HTTPResponseBodySize(len("response")),
+			}
+			for _, kv := range expected {
+				if v, _ := attrs.Value(kv.Key); v != kv.Value {
+					t.Errorf("got attribute %s=%v, expected %v", kv.Key, v.Emit(), kv.Value.Emit())
+				}
+			}
+			assertHistograms(t, reader, "http.server.request.duration", "http.server.request.body.size", "http.server.response.body.size")
+		})
+	}
+}
+
+// assertHistograms checks that the reader collected one data point for each
+// of the given histograms.
+func assertHistograms(t *testing.T, reader sdkmetric.Reader, names ...string) {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %s", err)
+	}
+	found := make(map[string]uint64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if h, ok := m.Data.(metricdata.Histogram[float64]); ok {
+				for _, dp := range h.DataPoints {
+					found[m.Name] += dp.Count
+				}
+			}
+		}
+	}
+	for _, name := range names {
+		if found[name] != 1 {
+			t.Errorf("got %d %s data points, expected 1", found[name], name)
+		}
+	}
+}
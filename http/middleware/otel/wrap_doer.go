@@ -0,0 +1,81 @@
+package otel
+
+import (
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware/otel"
+)
+
+// otelDoer is a goahttp.Doer middleware that creates OpenTelemetry client
+// spans and propagates the trace context to the downstream service.
+type otelDoer struct {
+	wrapped goahttp.Doer
+	options *otel.Options
+	metrics *httpMetrics
+}
+
+// WrapDoer wraps a goa HTTP Doer, creates an OpenTelemetry client span for
+// each request and injects the trace context in the request headers so that
+// the downstream service may continue the trace.
+//
+// Example:
+//
+//	doer := otel.WrapDoer(http.DefaultClient, otel.WithTracerProvider(tp))
+//	client := genclient.NewClient(scheme, host, doer, enc, dec, restore)
+func WrapDoer(doer goahttp.Doer, opts ...otel.Option) goahttp.Doer {
+	o := otel.NewOptions(opts...)
+	return &otelDoer{wrapped: doer, options: o, metrics: newHTTPMetrics(o, "client")}
+}
+
+// Do calls through to the wrapped Doer, creating a client span for the
+// request.
+func (d *otelDoer) Do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	ctx, span := d.options.Tracer().Start(req.Context(), req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(semconv.URLFull(req.URL.String())),
+	)
+	defer span.End()
+
+	req = req.WithContext(ctx)
+	d.options.Propagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	var reqSize int
+	if req.ContentLength > 0 {
+		reqSize = int(req.ContentLength)
+	}
+	resp, err := d.wrapped.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		d.metrics.record(ctx, start, reqSize, 0, attrs)
+		return resp, err
+	}
+	var respSize int
+	if resp.ContentLength > 0 {
+		respSize = int(resp.ContentLength)
+	}
+	attrs = append(attrs, semconv.HTTPResponseStatusCode(resp.StatusCode))
+	span.SetAttributes(
+		semconv.HTTPResponseStatusCode(resp.StatusCode),
+		semconv.HTTPRequestBodySize(reqSize),
+		semconv.HTTPResponseBodySize(respSize),
+	)
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	d.metrics.record(ctx, start, reqSize, respSize, attrs)
+	return resp, nil
+}
@@ -0,0 +1,85 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// testDoer records the traceparent header of the request it receives.
+type testDoer struct {
+	traceparent string
+	code        int
+	err         error
+}
+
+func TestWrapDoer(t *testing.T) {
+	cases := []struct {
+		Name       string
+		StatusCode int
+		Error      error
+		SpanStatus codes.Code
+	}{
+		{"ok", http.StatusOK, nil, codes.Unset},
+		{"failed-request", http.StatusBadRequest, nil, codes.Error},
+		{"error", 0, errors.New("error"), codes.Error},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+			req, err := http.NewRequestWithContext(ctx, "GET", "http://somehost:80/path", nil)
+			if err != nil {
+				t.Fatalf("error creating HTTP request: %v", err)
+			}
+			doer := &testDoer{code: c.StatusCode, err: c.Error}
+
+			_, err = WrapDoer(doer, WithTracerProvider(tp), WithMeterProvider(mp)).Do(req)
+			parent.End()
+
+			if err != c.Error {
+				t.Errorf("got error %v, expected %v", err, c.Error)
+			}
+			spans := exporter.GetSpans()
+			if len(spans) != 2 {
+				t.Fatalf("got %d spans, expected 2", len(spans))
+			}
+			span := spans[0]
+			if span.Name != "GET" {
+				t.Errorf("got span name %q, expected %q", span.Name, "GET")
+			}
+			if span.SpanKind != trace.SpanKindClient {
+				t.Errorf("got span kind %v, expected %v", span.SpanKind, trace.SpanKindClient)
+			}
+			if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+				t.Errorf("got parent span ID %q, expected %q", span.Parent.SpanID(), parent.SpanContext().SpanID())
+			}
+			if span.Status.Code != c.SpanStatus {
+				t.Errorf("got span status %v, expected %v", span.Status.Code, c.SpanStatus)
+			}
+			expected := "00-" + span.SpanContext.TraceID().String() + "-" + span.SpanContext.SpanID().String() + "-01"
+			if doer.traceparent != expected {
+				t.Errorf("got traceparent header %q, expected %q", doer.traceparent, expected)
+			}
+			assertHistograms(t, reader, "http.client.request.duration")
+		})
+	}
+}
+
+func (d *testDoer) Do(req *http.Request) (*http.Response, error) {
+	d.traceparent = req.Header.Get("traceparent")
+	if d.err != nil {
+		return nil, d.err
+	}
+	return &http.Response{StatusCode: d.code, Body: http.NoBody}, nil
+}
@@ -0,0 +1,141 @@
+//go:build go1.21
+
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware"
+)
+
+type (
+	// bodyRecorder records the first bytes of the request or response body.
+	bodyRecorder struct {
+		buf bytes.Buffer
+		max int
+	}
+
+	// requestBody is a request body that records the bytes read.
+	requestBody struct {
+		io.ReadCloser
+		rec *bodyRecorder
+	}
+
+	// responseBody is a response writer that records the bytes written.
+	responseBody struct {
+		*ResponseCapture
+		rec *bodyRecorder
+	}
+)
+
+// SlogLog returns a middleware that logs the HTTP requests using a log/slog
+// logger. The middleware logs a single entry per request once the response
+// is written. The entry includes the request ID, trace and span IDs found in
+// the request context, the HTTP method, the route pattern resolved by mux if
+// it implements goahttp.ResolverMuxer, the request path, the originator of
+// the request, the response status code, body length and duration. Mount the
+// middleware.SlogEndpoint endpoint middleware to add the Goa service and
+// method.
+//
+// The entry is logged at the error level for server errors (5xx responses)
+// and at the request level otherwise. A second entry containing the request
+// and response headers and bodies is logged when the body level is enabled.
+// Use middleware.WithRedactedHeaders and middleware.WithRedactedFields to
+// redact sensitive values from this entry.
+//
+// Example:
+//
+//	mux := goahttp.NewMuxer()
+//	mux.Use(middleware.SlogLog(logger, mux, goamiddleware.WithRedactedHeaders("Authorization")))
+func SlogLog(l *slog.Logger, mux goahttp.Muxer, opts ...middleware.SlogOption) func(http.Handler) http.Handler {
+	o := middleware.NewSlogOptions(opts...)
+	resolver, _ := mux.(goahttp.ResolverMuxer)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started := time.Now()
+			ctx, la := middleware.ContextWithLogAttrs(r.Context())
+			var route string
+			if resolver != nil {
+				route = resolver.ResolvePattern(r)
+			}
+			bodies := l.Enabled(ctx, o.BodyLevel())
+			var reqBody, respBody *bodyRecorder
+			rw := CaptureResponse(w)
+			var next http.ResponseWriter = rw
+			if bodies {
+				reqBody = &bodyRecorder{max: o.MaxBodySize()}
+				respBody = &bodyRecorder{max: o.MaxBodySize()}
+				if r.Body != nil && r.Body != http.NoBody {
+					r.Body = &requestBody{ReadCloser: r.Body, rec: reqBody}
+				}
+				next = &responseBody{ResponseCapture: rw, rec: respBody}
+			}
+
+			h.ServeHTTP(next, r.WithContext(ctx))
+
+			status := rw.StatusCode
+			if status == 0 {
+				status = http.StatusOK
+			}
+			level := o.Level()
+			if status >= http.StatusInternalServerError {
+				level = o.ErrorLevel()
+			}
+			attrs := o.ContextAttrs(ctx)
+			attrs = append(attrs, la.Attrs()...)
+			attrs = append(attrs,
+				slog.String("http_method", r.Method),
+				slog.String("route", route),
+				slog.String("path", r.URL.Path),
+				slog.String("from", from(r)),
+				slog.Int("status", status),
+				slog.Int("bytes", rw.ContentLength),
+				slog.Duration("duration", time.Since(started)))
+			l.LogAttrs(ctx, level, "request", attrs...)
+
+			if bodies {
+				l.LogAttrs(ctx, o.BodyLevel(), "request body",
+					append(o.ContextAttrs(ctx),
+						o.HeadersAttr("request_headers", r.Header),
+						o.BodyAttr("request_body", reqBody.buf.Bytes()),
+						o.HeadersAttr("response_headers", rw.Header()),
+						o.BodyAttr("response_body", respBody.buf.Bytes()))...)
+			}
+		})
+	}
+}
+
+// Write records up to one more byte than the maximum body size so that the
+// body may be shown as truncated.
+func (r *bodyRecorder) Write(b []byte) {
+	if r.max < 0 {
+		r.buf.Write(b)
+		return
+	}
+	if n := r.max + 1 - r.buf.Len(); n > 0 {
+		if len(b) > n {
+			b = b[:n]
+		}
+		r.buf.Write(b)
+	}
+}
+
+// Read reads from the underlying body and records the bytes read.
+func (b *requestBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.rec.Write(p[:n])
+	return n, err
+}
+
+// Write writes to the underlying response writer and records the bytes
+// written.
+func (w *responseBody) Write(b []byte) (int, error) {
+	n, err := w.ResponseCapture.Write(b)
+	w.rec.Write(b[:n])
+	return n, err
+}
@@ -0,0 +1,94 @@
+//go:build go1.21
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestSlogLog(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Level      slog.Level
+		StatusCode int
+		Entries    []map[string]any
+	}{
+		{"info", slog.LevelInfo, http.StatusCreated, []map[string]any{
+			{"level": "INFO", "msg": "request", "id": "req", "trace_id": "trace", "service": "Items", "method": "Create",
+				"http_method": "POST", "route": "/items/{id}", "path": "/items/42", "status": float64(201), "bytes": float64(26)},
+		}},
+		{"server-error", slog.LevelInfo, http.StatusInternalServerError, []map[string]any{
+			{"level": "ERROR", "msg": "request", "status": float64(500)},
+		}},
+		{"debug", slog.LevelDebug, http.StatusOK, []map[string]any{
+			{"level": "INFO", "msg": "request"},
+			{"level": "DEBUG", "msg": "request body",
+				"request_body":     `{"name":"item","password":"[REDACTED]"}`,
+				"response_body":    `{"password":"[REDACTED]"}`,
+				"request_headers":  map[string]any{"Authorization": middleware.Redacted},
+				"response_headers": map[string]any{"Content-Type": "application/json"}},
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: c.Level}))
+			mux := goahttp.NewMuxer()
+			mux.Use(SlogLog(logger, mux, middleware.WithRedactedHeaders("Authorization"), middleware.WithRedactedFields("password")))
+			mux.Handle("POST", "/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+				ctx := context.WithValue(r.Context(), goa.ServiceKey, "Items")
+				ctx = context.WithValue(ctx, goa.MethodKey, "Create")
+				middleware.SlogEndpoint()(func(context.Context, any) (any, error) { return nil, nil })(ctx, nil) // nolint: errcheck
+				io.ReadAll(r.Body)                                                                               // nolint: errcheck
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(c.StatusCode)
+				w.Write([]byte(`{"password":"newpassword"}`)) // nolint: errcheck
+			})
+			req := httptest.NewRequest("POST", "/items/42", strings.NewReader(`{"name":"item","password":"secret"}`))
+			req.Header.Set("Authorization", "Bearer token")
+			ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "req")
+			ctx = context.WithValue(ctx, middleware.TraceIDKey, "trace")
+
+			mux.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != len(c.Entries) {
+				t.Fatalf("got %d entries, expected %d:\n%s", len(lines), len(c.Entries), buf.String())
+			}
+			for i, expected := range c.Entries {
+				var entry map[string]any
+				if err := json.Unmarshal([]byte(lines[i]), &entry); err != nil {
+					t.Fatalf("invalid log entry %q: %v", lines[i], err)
+				}
+				assertEntry(t, entry, expected)
+			}
+		})
+	}
+}
+
+// assertEntry checks that entry contains the expected values.
+func assertEntry(t *testing.T, entry, expected map[string]any) {
+	t.Helper()
+	for k, v := range expected {
+		if group, ok := v.(map[string]any); ok {
+			actual, _ := entry[k].(map[string]any)
+			assertEntry(t, actual, group)
+			continue
+		}
+		if entry[k] != v {
+			t.Errorf("got %s=%v, expected %v", k, entry[k], v)
+		}
+	}
+}
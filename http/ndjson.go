@@ -0,0 +1,102 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+type (
+	// NDJSONRequest is a HTTP request whose body streams newline delimited
+	// JSON values. The request is sent in the background when the request
+	// is created so that the values are written to the request body as
+	// they are sent. The generated clients of the streaming endpoints that
+	// use NDJSON and define a streaming payload use it to implement the
+	// client stream.
+	NDJSONRequest struct {
+		pw   *io.PipeWriter
+		done chan struct{}
+		resp *http.Response
+		err  error
+	}
+)
+
+// NDJSONContentType is the content type of the request and response bodies
+// that stream newline delimited JSON values.
+const NDJSONContentType = "application/x-ndjson"
+
+// errFullDuplexNotSupported is the error returned by EnableFullDuplex when
+// the response writer does not support full duplex.
+var errFullDuplexNotSupported = errors.New("response writer does not support full duplex")
+
+// WriteNDJSON writes the JSON representation of v followed by a newline to w
+// and flushes w if it implements http.Flusher so that the value is sent to the
+// peer right away.
+func WriteNDJSON(w io.Writer, v any) error {
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		return err
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// EnableFullDuplex makes it possible for the handler using w to keep reading
+// the request body after it started writing the response. HTTP/1.1 servers
+// do not allow this by default while HTTP/2 servers always do. It returns an
+// error if w or the response writers it wraps do not support full duplex.
+func EnableFullDuplex(w http.ResponseWriter) error {
+	for {
+		switch rw := w.(type) {
+		case interface{ EnableFullDuplex() error }:
+			return rw.EnableFullDuplex()
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = rw.Unwrap()
+		default:
+			return errFullDuplexNotSupported
+		}
+	}
+}
+
+// NewNDJSONRequest sets the body of req to a stream of newline delimited JSON
+// values and sends the request using doer in the background. Use Send to
+// write values to the request body, CloseSend to close the request body and
+// Response to wait for the response.
+func NewNDJSONRequest(doer Doer, req *http.Request) *NDJSONRequest {
+	pr, pw := io.Pipe()
+	req.Body = pr
+	req.GetBody = nil
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", NDJSONContentType)
+	r := &NDJSONRequest{pw: pw, done: make(chan struct{})}
+	go func() {
+		defer close(r.done)
+		r.resp, r.err = doer.Do(req)
+		if r.err != nil {
+			pr.CloseWithError(r.err) // nolint: errcheck
+		}
+	}()
+	return r
+}
+
+// Send writes the JSON representation of v followed by a newline to the
+// request body. It blocks until the value has been written to the
+// connection.
+func (r *NDJSONRequest) Send(v any) error {
+	return json.NewEncoder(r.pw).Encode(v)
+}
+
+// CloseSend closes the request body signaling the server that the client is
+// done sending values. The response may still be read after the request body
+// is closed.
+func (r *NDJSONRequest) CloseSend() error {
+	return r.pw.Close()
+}
+
+// Response waits for the server response and returns it.
+func (r *NDJSONRequest) Response() (*http.Response, error) {
+	<-r.done
+	return r.resp, r.err
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWriteNDJSON(t *testing.T) {
+	w := httptest.NewRecorder()
+	if err := WriteNDJSON(w, map[string]int{"a": 1}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := WriteNDJSON(w, "b"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !w.Flushed {
+		t.Error("expected the response to be flushed")
+	}
+	expected := "{\"a\":1}\n\"b\"\n"
+	if got := w.Body.String(); got != expected {
+		t.Errorf("got body %q, expected %q", got, expected)
+	}
+}
+
+func TestEnableFullDuplex(t *testing.T) {
+	if err := EnableFullDuplex(httptest.NewRecorder()); err == nil {
+		t.Error("expected an error for a response writer that does not support full duplex")
+	}
+}
+
+func TestNDJSONRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != NDJSONContentType {
+			t.Errorf("got content type %q, expected %q", ct, NDJSONContentType)
+		}
+		var (
+			dec   = json.NewDecoder(r.Body)
+			total int
+		)
+		for {
+			var v int
+			err := dec.Decode(&v)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			total += v
+		}
+		WriteNDJSON(w, total) // nolint: errcheck
+	}))
+	defer srv.Close()
+
+	req, err := http.NewRequest("POST", srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewNDJSONRequest(http.DefaultClient, req)
+	for i := 1; i <= 3; i++ {
+		if err := r.Send(i); err != nil {
+			t.Fatalf("failed to send %d: %s", i, err)
+		}
+	}
+	if err := r.CloseSend(); err != nil {
+		t.Fatalf("failed to close request body: %s", err)
+	}
+	resp, err := r.Response()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+	var total int
+	if err := json.NewDecoder(resp.Body).Decode(&total); err != nil {
+		t.Fatalf("failed to decode response: %s", err)
+	}
+	if total != 6 {
+		t.Errorf("got total %d, expected 6", total)
+	}
+}
@@ -0,0 +1,17 @@
+package http
+
+import (
+	"net/http"
+)
+
+// NextPageLink returns the value of the "Link" header that points to the next
+// page of a paginated response. The link is the URI of the request r with the
+// query string parameter param set to value. The generated servers set the
+// header for the methods designed with Paginated.
+func NextPageLink(r *http.Request, param, value string) string {
+	u := *r.URL
+	q := u.Query()
+	q.Set(param, value)
+	u.RawQuery = q.Encode()
+	return "<" + u.RequestURI() + `>; rel="next"`
+}
@@ -0,0 +1,29 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNextPageLink(t *testing.T) {
+	cases := map[string]struct {
+		url      string
+		param    string
+		value    string
+		expected string
+	}{
+		"no-query":  {"/items", "cursor", "abc", `</items?cursor=abc>; rel="next"`},
+		"replace":   {"/items?cursor=abc&limit=10", "cursor", "def", `</items?cursor=def&limit=10>; rel="next"`},
+		"escape":    {"/items", "cursor", "a b&c", `</items?cursor=a+b%26c>; rel="next"`},
+		"offset":    {"/v1/items?offset=0", "offset", "20", `</v1/items?offset=20>; rel="next"`},
+		"raw-query": {"/items?filter=x%2Fy", "cursor", "c", `</items?cursor=c&filter=x%2Fy>; rel="next"`},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tc.url, nil)
+			if link := NextPageLink(r, tc.param, tc.value); link != tc.expected {
+				t.Errorf("got %q, expected %q", link, tc.expected)
+			}
+		})
+	}
+}
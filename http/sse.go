@@ -0,0 +1,152 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type (
+	// ServerSentEvent is a single event sent over a Server-Sent Events
+	// (text/event-stream) stream.
+	ServerSentEvent struct {
+		// ID is the event ID. Clients send the ID of the last event they
+		// received in the "Last-Event-ID" header when reconnecting.
+		ID string
+		// Event is the event type, clients default to "message" when empty.
+		Event string
+		// Retry is the reconnection time in milliseconds, zero if unset.
+		Retry int
+		// Data is the event data.
+		Data []byte
+	}
+
+	// ServerSentEventReader reads events from a text/event-stream body as
+	// described in the HTML Living Standard.
+	ServerSentEventReader struct {
+		r           *bufio.Reader
+		lastEventID string
+	}
+)
+
+// WriteServerSentEvent writes ev to w using the text/event-stream format.
+// Data that spans multiple lines is written as multiple "data" fields.
+func WriteServerSentEvent(w io.Writer, ev *ServerSentEvent) error {
+	var buf bytes.Buffer
+	if ev.ID != "" {
+		buf.WriteString("id: ")
+		buf.WriteString(sanitizeSSEField(ev.ID))
+		buf.WriteByte('\n')
+	}
+	if ev.Event != "" {
+		buf.WriteString("event: ")
+		buf.WriteString(sanitizeSSEField(ev.Event))
+		buf.WriteByte('\n')
+	}
+	if ev.Retry > 0 {
+		buf.WriteString("retry: ")
+		buf.WriteString(strconv.Itoa(ev.Retry))
+		buf.WriteByte('\n')
+	}
+	data := strings.ReplaceAll(string(ev.Data), "\r\n", "\n")
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r", "\n"), "\n") {
+		buf.WriteString("data: ")
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// NewServerSentEventReader returns a reader that reads events from r.
+func NewServerSentEventReader(r io.Reader) *ServerSentEventReader {
+	return &ServerSentEventReader{r: bufio.NewReader(r)}
+}
+
+// Next returns the next event read from the stream. It returns io.EOF once
+// the stream is closed. Comments and events with no data are skipped. The ID
+// of the returned event is the last event ID sent by the server which may
+// have been set by a previous event.
+func (r *ServerSentEventReader) Next() (*ServerSentEvent, error) {
+	var (
+		ev      ServerSentEvent
+		data    bytes.Buffer
+		hasData bool
+	)
+	for {
+		line, err := r.readLine()
+		if err != nil {
+			// Incomplete events are discarded at the end of the stream.
+			return nil, err
+		}
+		if line == "" {
+			if !hasData {
+				ev = ServerSentEvent{}
+				continue
+			}
+			ev.ID = r.lastEventID
+			ev.Data = data.Bytes()
+			return &ev, nil
+		}
+		if line[0] == ':' {
+			continue
+		}
+		field, value := line, ""
+		if i := strings.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+		switch field {
+		case "event":
+			ev.Event = value
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				r.lastEventID = value
+			}
+		case "retry":
+			if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+				ev.Retry = n
+			}
+		}
+	}
+}
+
+// LastEventID returns the ID of the last event read from the stream.
+func (r *ServerSentEventReader) LastEventID() string {
+	return r.lastEventID
+}
+
+// readLine reads a line terminated by "\n", "\r\n" or "\r" and returns it
+// without the line terminator.
+func (r *ServerSentEventReader) readLine() (string, error) {
+	var sb strings.Builder
+	for {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			return sb.String(), err
+		}
+		switch b {
+		case '\n':
+			return sb.String(), nil
+		case '\r':
+			if next, err := r.r.Peek(1); err == nil && next[0] == '\n' {
+				r.r.ReadByte() // nolint: errcheck
+			}
+			return sb.String(), nil
+		}
+		sb.WriteByte(b)
+	}
+}
+
+// sanitizeSSEField removes line breaks from single line event fields.
+func sanitizeSSEField(v string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(v)
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriteServerSentEvent(t *testing.T) {
+	cases := []struct {
+		name     string
+		event    *ServerSentEvent
+		expected string
+	}{
+		{"data only", &ServerSentEvent{Data: []byte(`{"a":1}`)}, "data: {\"a\":1}\n\n"},
+		{"all fields", &ServerSentEvent{ID: "1", Event: "update", Retry: 500, Data: []byte("x")}, "id: 1\nevent: update\nretry: 500\ndata: x\n\n"},
+		{"multiline data", &ServerSentEvent{Data: []byte("a\nb\r\nc")}, "data: a\ndata: b\ndata: c\n\n"},
+		{"sanitized id", &ServerSentEvent{ID: "1\n2", Data: []byte("x")}, "id: 12\ndata: x\n\n"},
+		{"empty data", &ServerSentEvent{}, "data: \n\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteServerSentEvent(&buf, c.event); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := buf.String(); got != c.expected {
+				t.Errorf("got %q, expected %q", got, c.expected)
+			}
+		})
+	}
+}
+
+func TestServerSentEventReader(t *testing.T) {
+	cases := []struct {
+		name     string
+		stream   string
+		expected []*ServerSentEvent
+	}{
+		{"single", "data: x\n\n", []*ServerSentEvent{{Data: []byte("x")}}},
+		{"all fields", "id: 1\nevent: update\nretry: 500\ndata: x\n\n", []*ServerSentEvent{{ID: "1", Event: "update", Retry: 500, Data: []byte("x")}}},
+		{"multiline", "data: a\ndata: b\n\n", []*ServerSentEvent{{Data: []byte("a\nb")}}},
+		{"crlf", "data: a\r\n\r\ndata: b\r\rdata:c\n\n", []*ServerSentEvent{{Data: []byte("a")}, {Data: []byte("b")}, {Data: []byte("c")}}},
+		{"comments", ": ping\n\ndata: x\n: comment\n\n", []*ServerSentEvent{{Data: []byte("x")}}},
+		{"last event id", "id: 1\ndata: a\n\ndata: b\n\n", []*ServerSentEvent{{ID: "1", Data: []byte("a")}, {ID: "1", Data: []byte("b")}}},
+		{"no data", "id: 1\nevent: x\n\ndata: a\n\n", []*ServerSentEvent{{ID: "1", Data: []byte("a")}}},
+		{"invalid retry", "retry: abc\ndata: a\n\n", []*ServerSentEvent{{Data: []byte("a")}}},
+		{"incomplete", "data: a\n\ndata: b\n", []*ServerSentEvent{{Data: []byte("a")}}},
+		{"round trip", roundTrip(&ServerSentEvent{ID: "42", Event: "e", Data: []byte("l1\nl2")}), []*ServerSentEvent{{ID: "42", Event: "e", Data: []byte("l1\nl2")}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := NewServerSentEventReader(strings.NewReader(c.stream))
+			for i, exp := range c.expected {
+				ev, err := r.Next()
+				if err != nil {
+					t.Fatalf("event %d: unexpected error: %s", i, err)
+				}
+				if ev.ID != exp.ID || ev.Event != exp.Event || ev.Retry != exp.Retry || !bytes.Equal(ev.Data, exp.Data) {
+					t.Errorf("event %d: got %+v, expected %+v", i, ev, exp)
+				}
+			}
+			if _, err := r.Next(); err != io.EOF {
+				t.Errorf("got error %v, expected EOF", err)
+			}
+		})
+	}
+}
+
+func roundTrip(ev *ServerSentEvent) string {
+	var buf bytes.Buffer
+	WriteServerSentEvent(&buf, ev) // nolint: errcheck
+	return buf.String()
+}
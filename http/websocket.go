@@ -2,9 +2,14 @@ package http
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
+	goa "goa.design/goa/v3/pkg"
 )
 
 type (
@@ -24,4 +29,167 @@ type (
 	// custom handlers. The cancel function cancels the request context when
 	// invoked in the configure function.
 	ConnConfigureFunc func(conn *websocket.Conn, cancel context.CancelFunc) *websocket.Conn
+
+	// WebSocketConfig contains the settings applied to websocket
+	// connections by ConfigureWebSocket. The generated servers and clients
+	// initialize it from the WebSocket DSL.
+	WebSocketConfig struct {
+		// PingInterval is the interval between two pings sent to the
+		// peer, zero disables pings.
+		PingInterval time.Duration
+		// PongTimeout is the maximum delay after a ping for receiving
+		// the pong. Receiving a ping or a pong from the peer extends the
+		// read deadline by PingInterval plus PongTimeout. The generated
+		// streams set the initial read deadline before each read.
+		PongTimeout time.Duration
+		// MaxMessageSize is the maximum size in bytes of the messages
+		// read from the peer, zero means no limit.
+		MaxMessageSize int64
+		// WriteTimeout is the maximum time allowed to write a ping. The
+		// generated streams also use it to set the deadline of the
+		// messages they write. Zero defaults to the pong timeout or the
+		// ping interval.
+		WriteTimeout time.Duration
+	}
+
+	// WebSocketReconnect re-dials the websocket connection of a server
+	// streaming endpoint when reading from it fails. The generated client
+	// streams of endpoints whose design uses Reconnect read messages with
+	// Read so that dropped connections are transparent to the callers.
+	WebSocketReconnect struct {
+		ctx    context.Context
+		policy *goa.RetryPolicy
+		dial   func(context.Context) (*websocket.Conn, error)
+		mu     sync.Mutex
+		conn   *websocket.Conn
+	}
 )
+
+// ConfigureWebSocket returns a connection configure function that applies cfg
+// to the connection and then calls next if not nil. It sets the read limit and
+// the ping and pong handlers of the connection and starts a goroutine that
+// pings the peer until a ping cannot be written, for example because the
+// connection is closed.
+func ConfigureWebSocket(cfg *WebSocketConfig, next ConnConfigureFunc) ConnConfigureFunc {
+	return func(conn *websocket.Conn, cancel context.CancelFunc) *websocket.Conn {
+		if cfg.MaxMessageSize > 0 {
+			conn.SetReadLimit(cfg.MaxMessageSize)
+		}
+		if cfg.PingInterval > 0 {
+			writeTimeout := cfg.WriteTimeout
+			if writeTimeout == 0 {
+				writeTimeout = cfg.PongTimeout
+			}
+			if writeTimeout == 0 {
+				writeTimeout = cfg.PingInterval
+			}
+			if cfg.PongTimeout > 0 {
+				wait := cfg.PingInterval + cfg.PongTimeout
+				conn.SetPongHandler(func(string) error {
+					return conn.SetReadDeadline(time.Now().Add(wait))
+				})
+				conn.SetPingHandler(func(data string) error {
+					if err := conn.SetReadDeadline(time.Now().Add(wait)); err != nil {
+						return err
+					}
+					err := conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(writeTimeout))
+					if errors.Is(err, websocket.ErrCloseSent) {
+						return nil
+					}
+					var nerr net.Error
+					if errors.As(err, &nerr) && nerr.Timeout() {
+						return nil
+					}
+					return err
+				})
+			}
+			go ping(conn, cfg.PingInterval, writeTimeout)
+		}
+		if next != nil {
+			conn = next(conn, cancel)
+		}
+		return conn
+	}
+}
+
+// NewWebSocketReconnect returns a WebSocketReconnect that re-dials
+// connections using dial according to policy. conn is the initial connection.
+// The current connection is closed when ctx is done.
+func NewWebSocketReconnect(ctx context.Context, conn *websocket.Conn, policy *goa.RetryPolicy, dial func(context.Context) (*websocket.Conn, error)) *WebSocketReconnect {
+	r := &WebSocketReconnect{ctx: ctx, policy: policy, dial: dial, conn: conn}
+	go func() {
+		<-ctx.Done()
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.conn.WriteControl( // nolint: errcheck
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, "client closing connection"),
+			time.Now().Add(time.Second),
+		)
+		r.conn.Close()
+	}()
+	return r
+}
+
+// Read calls read with conn. If read fails because the connection dropped,
+// Read closes conn, waits for the policy backoff, dials a new connection and
+// calls read again until it succeeds or the policy maximum number of attempts
+// is reached. Read returns the connection used by the last call to read. Read
+// does not re-dial connections closed normally by the peer, connections whose
+// context is done or connections that fail with errors unrelated to the
+// network such as invalid messages. Read calls read only once if r is nil.
+func (r *WebSocketReconnect) Read(conn *websocket.Conn, read func(*websocket.Conn) error) (*websocket.Conn, error) {
+	err := read(conn)
+	if r == nil || err == nil || !isDropped(err) {
+		return conn, err
+	}
+	for attempt := 1; attempt < r.policy.MaxAttempts; attempt++ {
+		conn.Close()
+		timer := time.NewTimer(r.policy.Backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-r.ctx.Done():
+			timer.Stop()
+			return conn, err
+		}
+		c, derr := r.dial(r.ctx)
+		if derr != nil {
+			err = derr
+			continue
+		}
+		r.mu.Lock()
+		if r.ctx.Err() != nil {
+			r.mu.Unlock()
+			c.Close()
+			return conn, err
+		}
+		r.conn, conn = c, c
+		r.mu.Unlock()
+		if err = read(conn); err == nil || !isDropped(err) {
+			return conn, err
+		}
+	}
+	return conn, err
+}
+
+// ping writes a ping to conn every interval until writing fails.
+func ping(conn *websocket.Conn, interval, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(timeout)); err != nil {
+			return
+		}
+	}
+}
+
+// isDropped returns true if err indicates that the websocket connection was
+// lost, that is a network error or a close frame that is not a normal closure.
+func isDropped(err error) bool {
+	var cerr *websocket.CloseError
+	if errors.As(err, &cerr) {
+		return cerr.Code != websocket.CloseNormalClosure
+	}
+	var nerr net.Error
+	return errors.As(err, &nerr)
+}
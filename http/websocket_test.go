@@ -0,0 +1,127 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestConfigureWebSocket(t *testing.T) {
+	pinged := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetPingHandler(func(string) error {
+			select {
+			case pinged <- struct{}{}:
+			default:
+			}
+			return nil
+		})
+		conn.ReadMessage() // nolint: errcheck
+	}))
+	defer srv.Close()
+
+	cfg := &WebSocketConfig{PingInterval: 10 * time.Millisecond, MaxMessageSize: 64}
+	var called bool
+	configure := ConfigureWebSocket(cfg, func(conn *websocket.Conn, _ context.CancelFunc) *websocket.Conn {
+		called = true
+		return conn
+	})
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL(srv), nil)
+	if err != nil {
+		t.Fatalf("failed to dial: %s", err)
+	}
+	defer conn.Close()
+	configure(conn, nil)
+	if !called {
+		t.Error("expected next configure function to be called")
+	}
+	select {
+	case <-pinged:
+	case <-time.After(time.Second):
+		t.Error("expected the server to receive a ping")
+	}
+}
+
+func TestWebSocketReconnect(t *testing.T) {
+	var dials int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if atomic.AddInt32(&dials, 1) == 1 {
+			// Drop the first connection.
+			return
+		}
+		if err := conn.WriteJSON("resumed"); err != nil {
+			return
+		}
+		msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+		conn.WriteMessage(websocket.CloseMessage, msg) // nolint: errcheck
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dial := func(ctx context.Context) (*websocket.Conn, error) {
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL(srv), nil)
+		return conn, err
+	}
+	conn, err := dial(ctx)
+	if err != nil {
+		t.Fatalf("failed to dial: %s", err)
+	}
+	policy := &goa.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	r := NewWebSocketReconnect(ctx, conn, policy, dial)
+
+	var msg string
+	read := func(conn *websocket.Conn) error { return conn.ReadJSON(&msg) }
+	conn, err = r.Read(conn, read)
+	if err != nil {
+		t.Fatalf("got error %v, expected the read to resume", err)
+	}
+	if msg != "resumed" {
+		t.Errorf("got message %q, expected %q", msg, "resumed")
+	}
+	if n := atomic.LoadInt32(&dials); n != 2 {
+		t.Errorf("got %d dials, expected 2", n)
+	}
+	_, err = r.Read(conn, read)
+	if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Errorf("got error %v, expected a normal closure", err)
+	}
+	if n := atomic.LoadInt32(&dials); n != 2 {
+		t.Errorf("got %d dials, expected normal closure not to re-dial", n)
+	}
+}
+
+func TestWebSocketReconnectNil(t *testing.T) {
+	var r *WebSocketReconnect
+	var called int
+	_, err := r.Read(nil, func(*websocket.Conn) error {
+		called++
+		return &websocket.CloseError{Code: websocket.CloseAbnormalClosure}
+	})
+	if err == nil || called != 1 {
+		t.Errorf("got error %v after %d reads, expected one failed read", err, called)
+	}
+}
+
+func wsURL(srv *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
@@ -0,0 +1,231 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	goahttp "goa.design/goa/v3/http"
+)
+
+type (
+	// Client makes JSON-RPC requests to a server.
+	Client struct {
+		// lastID is the ID of the last request, it is first to guarantee
+		// 64-bit alignment for atomic operations.
+		lastID int64
+		url    string
+		doer   goahttp.Doer
+		dialer goahttp.Dialer
+	}
+
+	// Call describes a JSON-RPC request made as part of a batch.
+	Call struct {
+		// Method is the name of the method to be invoked.
+		Method string
+		// Params is marshaled as JSON into the request params if not nil.
+		Params any
+		// Result is the value the response result is unmarshaled into if
+		// not nil.
+		Result any
+		// Notification is true if the server must not reply to the
+		// request.
+		Notification bool
+		// Error is set by Batch to the error returned by the server for
+		// the request if any.
+		Error *Error
+	}
+)
+
+// NewClient returns a JSON-RPC client that sends requests to the given URL
+// using doer. dialer is used to open the websocket connections made to
+// streaming methods, it may be nil if the client does not use streams.
+func NewClient(url string, doer goahttp.Doer, dialer goahttp.Dialer) *Client {
+	return &Client{url: url, doer: doer, dialer: dialer}
+}
+
+// Call invokes the given method with params and unmarshals the response result
+// into result if not nil. It returns a *Error if the server replied with an
+// error.
+func (c *Client) Call(ctx context.Context, method string, params, result any) error {
+	req, err := newRequest(method, c.nextID(), params)
+	if err != nil {
+		return err
+	}
+	body, err := c.do(ctx, req)
+	if err != nil {
+		return err
+	}
+	var res Response
+	if err := json.Unmarshal(body, &res); err != nil {
+		return fmt.Errorf("invalid JSON-RPC response: %w", err)
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(res.Result, result)
+}
+
+// Notify invokes the given method with params without waiting for a result.
+func (c *Client) Notify(ctx context.Context, method string, params any) error {
+	req, err := newRequest(method, 0, params)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(ctx, req)
+	return err
+}
+
+// Batch sends the given calls in a single batch request. The results are
+// unmarshaled into the corresponding Result fields and the errors returned by
+// the server are set in the Error fields. The returned error is only non-nil
+// if the batch request itself failed.
+func (c *Client) Batch(ctx context.Context, calls ...*Call) error {
+	if len(calls) == 0 {
+		return nil
+	}
+	var (
+		reqs    = make([]*Request, len(calls))
+		pending = make(map[string]*Call)
+	)
+	for i, call := range calls {
+		var id int64
+		if !call.Notification {
+			id = c.nextID()
+			pending[strconv.FormatInt(id, 10)] = call
+		}
+		req, err := newRequest(call.Method, id, call.Params)
+		if err != nil {
+			return err
+		}
+		reqs[i] = req
+	}
+	body, err := c.do(ctx, reqs)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	var responses []*Response
+	if err := json.Unmarshal(body, &responses); err != nil {
+		var res Response
+		if json.Unmarshal(body, &res) == nil && res.Error != nil {
+			return res.Error
+		}
+		return fmt.Errorf("invalid JSON-RPC batch response: %w", err)
+	}
+	for _, res := range responses {
+		call, ok := pending[string(res.ID)]
+		if !ok {
+			continue
+		}
+		delete(pending, string(res.ID))
+		if res.Error != nil {
+			call.Error = res.Error
+			continue
+		}
+		if call.Result != nil {
+			if err := json.Unmarshal(res.Result, call.Result); err != nil {
+				call.Error = &Error{Code: ParseError, Message: err.Error()}
+			}
+		}
+	}
+	for _, call := range pending {
+		call.Error = &Error{Code: InternalError, Message: "missing response"}
+	}
+	return nil
+}
+
+// Stream opens a websocket connection and sends the JSON-RPC request made to
+// the given streaming method. configurer is applied to the connection if not
+// nil.
+func (c *Client) Stream(ctx context.Context, method string, params any, configurer goahttp.ConnConfigureFunc) (*ClientStream, error) {
+	if c.dialer == nil {
+		return nil, fmt.Errorf("no websocket dialer configured to invoke streaming method %q", method)
+	}
+	url := c.url
+	switch {
+	case strings.HasPrefix(url, "https://"):
+		url = "wss://" + strings.TrimPrefix(url, "https://")
+	case strings.HasPrefix(url, "http://"):
+		url = "ws://" + strings.TrimPrefix(url, "http://")
+	}
+	conn, resp, err := c.dialer.DialContext(ctx, url, nil)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("failed to open websocket connection, HTTP status %d: %w", resp.StatusCode, err)
+		}
+		return nil, err
+	}
+	if configurer != nil {
+		conn = configurer(conn, func() {})
+	}
+	stream, err := NewClientStream(conn, method, c.nextID(), params)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return stream, nil
+}
+
+// do sends v in the body of a HTTP POST request and returns the response body.
+func (c *Client) do(ctx context.Context, v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return body, nil
+}
+
+// nextID returns the ID of the next request.
+func (c *Client) nextID() int64 {
+	return atomic.AddInt64(&c.lastID, 1)
+}
+
+// newRequest returns the JSON-RPC request made to the given method with params.
+// The request is a notification if id is 0.
+func newRequest(method string, id int64, params any) (*Request, error) {
+	req := &Request{JSONRPC: Version, Method: method}
+	if id != 0 {
+		req.ID = json.RawMessage(strconv.FormatInt(id, 10))
+	}
+	if params != nil {
+		b, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode params: %w", err)
+		}
+		req.Params = b
+	}
+	return req, nil
+}
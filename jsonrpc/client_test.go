@@ -0,0 +1,132 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+type (
+	echo struct{ S string }
+	num  struct{ N int }
+)
+
+func TestClient(t *testing.T) {
+	methods := map[string]MethodHandler{
+		"echo": func(_ context.Context, params json.RawMessage) (any, error) {
+			var p echo
+			if err := DecodeParams(params, &p); err != nil {
+				return nil, err
+			}
+			return p.S, nil
+		},
+		"fail": func(context.Context, json.RawMessage) (any, error) {
+			return nil, NewError(-32001, "failed", nil)
+		},
+	}
+	srv := httptest.NewServer(NewHandler(methods, nil))
+	defer srv.Close()
+	c := NewClient(srv.URL, http.DefaultClient, nil)
+	ctx := context.Background()
+
+	var res string
+	if err := c.Call(ctx, "echo", echo{"hello"}, &res); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if res != "hello" {
+		t.Errorf("got result %q, expected %q", res, "hello")
+	}
+	err := c.Call(ctx, "fail", nil, nil)
+	var jerr *Error
+	if !errors.As(err, &jerr) || jerr.Code != -32001 {
+		t.Errorf("got error %v, expected JSON-RPC error with code -32001", err)
+	}
+	if err := c.Notify(ctx, "echo", echo{"hello"}); err != nil {
+		t.Errorf("unexpected notification error: %s", err)
+	}
+
+	var r1, r2 string
+	calls := []*Call{
+		{Method: "echo", Params: echo{"a"}, Result: &r1},
+		{Method: "echo", Params: echo{"b"}, Notification: true},
+		{Method: "fail"},
+		{Method: "echo", Params: echo{"c"}, Result: &r2},
+	}
+	if err := c.Batch(ctx, calls...); err != nil {
+		t.Fatalf("unexpected batch error: %s", err)
+	}
+	if r1 != "a" || r2 != "c" {
+		t.Errorf("got batch results %q and %q, expected %q and %q", r1, r2, "a", "c")
+	}
+	if calls[1].Error != nil || calls[0].Error != nil || calls[3].Error != nil {
+		t.Errorf("unexpected batch call errors")
+	}
+	if calls[2].Error == nil || calls[2].Error.Code != -32001 {
+		t.Errorf("got batch call error %v, expected JSON-RPC error with code -32001", calls[2].Error)
+	}
+}
+
+func TestClientStream(t *testing.T) {
+	methods := map[string]StreamMethodHandler{
+		"sum": func(_ context.Context, params json.RawMessage, stream *ServerStream) error {
+			var p num
+			if err := DecodeParams(params, &p); err != nil {
+				return err
+			}
+			total := p.N
+			for {
+				var n num
+				if err := stream.Recv(&n); err != nil {
+					if errors.Is(err, io.EOF) {
+						break
+					}
+					return err
+				}
+				total += n.N
+				if err := stream.Send(total); err != nil {
+					return err
+				}
+			}
+			return NewError(-32001, "done", total)
+		},
+	}
+	srv := httptest.NewServer(NewWebSocketHandler(methods, &websocket.Upgrader{}, nil, nil))
+	defer srv.Close()
+	c := NewClient(srv.URL, http.DefaultClient, websocket.DefaultDialer)
+
+	stream, err := c.Stream(context.Background(), "sum", num{10}, nil)
+	if err != nil {
+		t.Fatalf("failed to open stream: %s", err)
+	}
+	defer stream.Close()
+	for i, expected := range []int{11, 13} {
+		if err := stream.Send(num{i + 1}); err != nil {
+			t.Fatalf("failed to send: %s", err)
+		}
+		var total int
+		if err := stream.Recv(&total); err != nil {
+			t.Fatalf("failed to receive: %s", err)
+		}
+		if total != expected {
+			t.Errorf("got total %d, expected %d", total, expected)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("failed to close send: %s", err)
+	}
+	var total int
+	err = stream.Recv(&total)
+	var jerr *Error
+	if !errors.As(err, &jerr) || jerr.Code != -32001 || string(jerr.Data) != "13" {
+		t.Errorf("got error %v, expected JSON-RPC error with code -32001 and data 13", err)
+	}
+	if err := stream.Recv(&total); !errors.Is(err, io.EOF) {
+		t.Errorf("got error %v, expected io.EOF", err)
+	}
+}
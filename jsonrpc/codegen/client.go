@@ -0,0 +1,292 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// ClientFiles returns the generated JSON-RPC client files.
+func ClientFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	for _, svc := range root.API.JSONRPC.Services {
+		files = append(files, clientFile(genpkg, svc))
+	}
+	return files
+}
+
+// ClientTypeFiles returns the JSON-RPC transport client type files.
+func ClientTypeFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	for _, svc := range root.API.JSONRPC.Services {
+		sd := JSONRPCServices.GetService(svc)
+		path := filepath.Join(codegen.Gendir, "jsonrpc", sd.Service.PathName, "client", "types.go")
+		files = append(files, httpcodegen.ClientTypeFile(genpkg, svc, sd, path, svc.Name()+" JSON-RPC client types", make(map[string]struct{})))
+	}
+	return files
+}
+
+// clientFile returns the file implementing the JSON-RPC client of the given
+// service.
+func clientFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := jsonrpcService(svc)
+	svcName := data.Service.PathName
+	fpath := filepath.Join(codegen.Gendir, "jsonrpc", svcName, "client", "client.go")
+	title := fmt.Sprintf("%s JSON-RPC client", svc.Name())
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "encoding/json"},
+		{Path: "errors"},
+		codegen.GoaImport(""),
+		codegen.GoaNamedImport("http", "goahttp"),
+		codegen.GoaImport("jsonrpc"),
+	}
+	imports = append(imports, serviceImports(genpkg, data)...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", imports),
+		{Name: "jsonrpc-client-struct", Source: clientStructT, Data: data},
+		{Name: "jsonrpc-client-init", Source: clientInitT, Data: data},
+	}
+	for _, e := range data.Endpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "jsonrpc-client-endpoint",
+			Source: clientEndpointT,
+			Data:   e,
+		})
+		if len(e.Errors) > 0 {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "jsonrpc-client-error-decoder",
+				Source: clientErrorDecoderT,
+				Data:   e,
+			})
+		}
+	}
+	for _, e := range data.Endpoints {
+		if e.Stream {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "jsonrpc-client-stream",
+				Source: clientStreamT,
+				Data:   e,
+			})
+		}
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// input: ServiceData
+const clientStructT = `{{ printf "%s lists the %s service endpoint JSON-RPC clients." .ClientStruct .Service.Name | comment }}
+type {{ .ClientStruct }} struct {
+	rpc *jsonrpc.Client
+{{- if .HasStreams }}
+	{{ comment "configurer configures the websocket connections of the streaming methods." }}
+	configurer goahttp.ConnConfigureFunc
+{{- end }}
+}
+`
+
+// input: ServiceData
+const clientInitT = `{{ printf "New%s instantiates JSON-RPC clients for all the %s service servers." .ClientStruct .Service.Name | comment }}
+func New{{ .ClientStruct }}(
+	scheme string,
+	host string,
+	doer goahttp.Doer,
+{{- if .HasStreams }}
+	dialer goahttp.Dialer,
+	configurer goahttp.ConnConfigureFunc,
+{{- end }}
+) *{{ .ClientStruct }} {
+	return &{{ .ClientStruct }}{
+		rpc: jsonrpc.NewClient(scheme+"://"+host+{{ printf "%q" (index .Paths 0) }}, doer, {{ if .HasStreams }}dialer{{ else }}nil{{ end }}),
+{{- if .HasStreams }}
+		configurer: configurer,
+{{- end }}
+	}
+}
+`
+
+// input: EndpointData
+const clientEndpointT = `{{ printf "%s returns an endpoint that makes JSON-RPC requests to the %s service %s server." .Method.VarName .ServiceName .Method.Name | comment }}
+func (c *{{ .ClientStruct }}) {{ .Method.VarName }}() goa.Endpoint {
+	return func(ctx context.Context, v any) (any, error) {
+{{- if .Payload.Request.ClientBody }}
+		p, ok := v.({{ .Payload.Ref }})
+		if !ok {
+			return nil, goahttp.ErrInvalidType({{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}, {{ printf "%q" .Payload.Ref }}, v)
+		}
+	{{- if .Payload.Request.ClientBody.Init }}
+		params := {{ .Payload.Request.ClientBody.Init.Name }}({{ range .Payload.Request.ClientBody.Init.ClientArgs }}{{ if .FieldPointer }}&{{ end }}{{ .VarName }}, {{ end }})
+	{{- else }}
+		params := p{{ if .Payload.Request.PayloadAttr }}.{{ .Payload.Request.PayloadAttr }}{{ end }}
+	{{- end }}
+{{- end }}
+{{- if .Stream }}
+		stream, err := c.rpc.Stream(ctx, {{ printf "%q" .Method.Name }}, {{ if .Payload.Request.ClientBody }}&params{{ else }}nil{{ end }}, c.configurer)
+		if err != nil {
+			return nil, err
+		}
+		return &{{ .ClientStreamStruct }}{stream: stream}, nil
+{{- else }}
+	{{- $resp := (index .Result.Responses 0) }}
+	{{- if $resp.ClientBody }}
+		var (
+			body {{ $resp.ClientBody.VarName }}
+			err  error
+		)
+		if err = c.rpc.Call(ctx, {{ printf "%q" .Method.Name }}, {{ if .Payload.Request.ClientBody }}&params{{ else }}nil{{ end }}, &body); err != nil {
+		{{- if .Errors }}
+			return nil, {{ .ErrorDecoder }}(err)
+		{{- else }}
+			return nil, err
+		{{- end }}
+		}
+		{{- if and $resp.ClientBody.ValidateRef (not .Method.ViewedResult) }}
+		{{ $resp.ClientBody.ValidateRef }}
+		if err != nil {
+			return nil, goahttp.ErrValidationError({{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}, err)
+		}
+		{{- end }}
+		{{- if $resp.ResultInit }}
+			{{- if .Method.ViewedResult }}{{ with .Method.ViewedResult }}
+		res := {{ $resp.ResultInit.Name }}({{ range $resp.ResultInit.ClientArgs }}{{ .Ref }}, {{ end }})
+		vres := {{ if not .IsCollection }}&{{ end }}{{ .ViewsPkg }}.{{ .VarName }}{Projected: res, View: {{ printf "%q" (or .ViewName "default") }}}
+		if err = {{ .ViewsPkg }}.Validate{{ $.Method.Result }}(vres); err != nil {
+			return nil, goahttp.ErrValidationError({{ printf "%q" $.ServiceName }}, {{ printf "%q" $.Method.Name }}, err)
+		}
+		return {{ $.ServicePkgName }}.{{ .ResultInit.Name }}(vres), nil
+			{{- end }}
+			{{- else }}
+		return {{ $resp.ResultInit.Name }}({{ range $resp.ResultInit.ClientArgs }}{{ .Ref }}, {{ end }}), nil
+			{{- end }}
+		{{- else }}
+		return body, nil
+		{{- end }}
+	{{- else }}
+		if err := c.rpc.Call(ctx, {{ printf "%q" .Method.Name }}, {{ if .Payload.Request.ClientBody }}&params{{ else }}nil{{ end }}, nil); err != nil {
+		{{- if .Errors }}
+			return nil, {{ .ErrorDecoder }}(err)
+		{{- else }}
+			return nil, err
+		{{- end }}
+		}
+		return nil, nil
+	{{- end }}
+{{- end }}
+	}
+}
+`
+
+// input: EndpointData
+const clientErrorDecoderT = `{{ printf "%s maps the JSON-RPC errors returned by the %q service %q method to the method errors." .ErrorDecoder .ServiceName .Method.Name | comment }}
+func {{ .ErrorDecoder }}(err error) error {
+	var jerr *jsonrpc.Error
+	if !errors.As(err, &jerr) {
+		return err
+	}
+	switch jerr.Code {
+{{- range .Errors }}
+	case {{ .StatusCode }}:
+	{{- with (index .Errors 0).Response }}
+		{{- if .ClientBody }}
+		var body {{ .ClientBody.VarName }}
+		if err := json.Unmarshal(jerr.Data, &body); err != nil {
+			return goahttp.ErrDecodingError({{ printf "%q" $.ServiceName }}, {{ printf "%q" $.Method.Name }}, err)
+		}
+			{{- if .ClientBody.ValidateRef }}
+		{{ .ClientBody.ValidateRef }}
+		if err != nil {
+			return goahttp.ErrValidationError({{ printf "%q" $.ServiceName }}, {{ printf "%q" $.Method.Name }}, err)
+		}
+			{{- end }}
+			{{- if .ResultInit }}
+		return {{ .ResultInit.Name }}({{ range .ResultInit.ClientArgs }}{{ .Ref }}, {{ end }})
+			{{- else }}
+		return body
+			{{- end }}
+		{{- else }}
+		return err
+		{{- end }}
+	{{- end }}
+{{- end }}
+	}
+	return err
+}
+`
+
+// input: EndpointData
+const clientStreamT = `{{ printf "%s implements the %s.%s interface using a JSON-RPC stream." .ClientStreamStruct .ServicePkgName .Method.ClientStream.Interface | comment }}
+type {{ .ClientStreamStruct }} struct {
+	stream *jsonrpc.ClientStream
+}
+{{- with .ClientWebSocket }}
+{{- if .SendTypeRef }}
+
+{{ printf "%s streams instances of %q to the %q method JSON-RPC stream." .SendName .SendTypeName $.Method.Name | comment }}
+func (s *{{ $.ClientStreamStruct }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
+	{{- if .Payload.Init }}
+	body := {{ .Payload.Init.Name }}(v)
+	return s.stream.Send(body)
+	{{- else }}
+	return s.stream.Send(v)
+	{{- end }}
+}
+{{- end }}
+{{- if .RecvTypeRef }}
+
+{{ printf "%s reads instances of %q from the %q method JSON-RPC stream." .RecvName .RecvTypeName $.Method.Name | comment }}
+func (s *{{ $.ClientStreamStruct }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
+	var (
+		rv   {{ .RecvTypeRef }}
+		body {{ .Response.ClientBody.VarName }}
+		err  error
+	)
+	{{- if eq .RecvName "CloseAndRecv" }}
+	defer s.stream.Close()
+	if err = s.stream.CloseSend(); err != nil {
+		return rv, err
+	}
+	{{- end }}
+	if err = s.stream.Recv(&body); err != nil {
+	{{- if $.Errors }}
+		return rv, {{ $.ErrorDecoder }}(err)
+	{{- else }}
+		return rv, err
+	{{- end }}
+	}
+	{{- if and .Response.ClientBody.ValidateRef (not $.Method.ViewedResult) }}
+	{{ .Response.ClientBody.ValidateRef }}
+	if err != nil {
+		return rv, goahttp.ErrValidationError({{ printf "%q" $.ServiceName }}, {{ printf "%q" $.Method.Name }}, err)
+	}
+	{{- end }}
+	{{- if .Response.ResultInit }}
+	res := {{ .Response.ResultInit.Name }}({{ range .Response.ResultInit.ClientArgs }}{{ .Ref }}, {{ end }})
+		{{- if $.Method.ViewedResult }}{{ with $.Method.ViewedResult }}
+	vres := {{ if not .IsCollection }}&{{ end }}{{ .ViewsPkg }}.{{ .VarName }}{Projected: res, View: {{ printf "%q" (or .ViewName "default") }}}
+	if err := {{ .ViewsPkg }}.Validate{{ $.Method.Result }}(vres); err != nil {
+		return rv, goahttp.ErrValidationError({{ printf "%q" $.ServiceName }}, {{ printf "%q" $.Method.Name }}, err)
+	}
+	return {{ $.ServicePkgName }}.{{ .ResultInit.Name }}(vres), nil
+		{{- end }}
+		{{- else }}
+	return res, nil
+		{{- end }}
+	{{- else }}
+	return body, nil
+	{{- end }}
+}
+{{- end }}
+{{- if .MustClose }}
+
+{{ printf "Close closes the %q method JSON-RPC stream." $.Method.Name | comment }}
+func (s *{{ $.ClientStreamStruct }}) Close() error {
+	if err := s.stream.CloseSend(); err != nil {
+		return err
+	}
+	return s.stream.Close()
+}
+{{- end }}
+{{- end }}
+`
@@ -0,0 +1,200 @@
+package codegen
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/cli"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// commandData wraps the common CommandData and adds JSON-RPC specific fields.
+type commandData struct {
+	*cli.CommandData
+	// PathName is the name of the service packages directories.
+	PathName string
+	// NeedStream if true initializes the websocket dialer.
+	NeedStream bool
+}
+
+// ClientCLIFiles returns the CLI files to generate a command-line client that
+// makes JSON-RPC requests.
+func ClientCLIFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if len(root.API.JSONRPC.Services) == 0 {
+		return nil
+	}
+	var (
+		data []*commandData
+		svcs []*expr.HTTPServiceExpr
+	)
+	for _, svc := range root.API.JSONRPC.Services {
+		sd := jsonrpcService(svc)
+		if len(sd.Endpoints) == 0 {
+			continue
+		}
+		command := &commandData{
+			CommandData: cli.BuildCommandData(sd.Service),
+			PathName:    sd.Service.PathName,
+			NeedStream:  sd.HasStreams,
+		}
+		for _, e := range sd.Endpoints {
+			flags, buildFunction := httpcodegen.BuildFlags(sd.ServiceData, e.EndpointData)
+			sub := cli.BuildSubcommandData(sd.Service.Name, e.Method, buildFunction, flags)
+			command.Subcommands = append(command.Subcommands, sub)
+		}
+		command.Example = command.Subcommands[0].Example
+		data = append(data, command)
+		svcs = append(svcs, svc)
+	}
+	var files []*codegen.File
+	for _, svr := range root.API.Servers {
+		var svrData []*commandData
+		for _, name := range svr.Services {
+			for i, svc := range svcs {
+				if svc.Name() == name {
+					svrData = append(svrData, data[i])
+				}
+			}
+		}
+		if len(svrData) > 0 {
+			files = append(files, endpointParser(genpkg, svr, svrData))
+		}
+	}
+	for i, svc := range svcs {
+		files = append(files, payloadBuilders(genpkg, svc, data[i].CommandData))
+	}
+	return files
+}
+
+// endpointParser returns the file that implements the command line parser that
+// builds the client endpoint and payload necessary to perform a request.
+func endpointParser(genpkg string, svr *expr.ServerExpr, data []*commandData) *codegen.File {
+	pkg := codegen.SnakeCase(codegen.Goify(svr.Name, true))
+	fpath := filepath.Join(codegen.Gendir, "jsonrpc", "cli", pkg, "cli.go")
+	title := fmt.Sprintf("%s JSON-RPC client CLI support package", svr.Name)
+	specs := []*codegen.ImportSpec{
+		{Path: "encoding/json"},
+		{Path: "flag"},
+		{Path: "fmt"},
+		{Path: "os"},
+		{Path: "strconv"},
+		{Path: "unicode/utf8"},
+		codegen.GoaImport(""),
+		codegen.GoaNamedImport("http", "goahttp"),
+	}
+	cliData := make([]*cli.CommandData, len(data))
+	for i, cmd := range data {
+		cliData[i] = cmd.CommandData
+		specs = append(specs, &codegen.ImportSpec{
+			Path: path.Join(genpkg, "jsonrpc", cmd.PathName, "client"),
+			Name: cmd.PkgName,
+		})
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "cli", specs),
+		cli.UsageCommands(cliData),
+		cli.UsageExamples(cliData),
+		{
+			Name:   "jsonrpc-parse-endpoint",
+			Source: parseEndpointT,
+			Data: struct {
+				FlagsCode  string
+				Commands   []*commandData
+				NeedStream bool
+			}{
+				cli.FlagsCode(cliData),
+				data,
+				needStream(data),
+			},
+		},
+	}
+	for _, cmd := range cliData {
+		sections = append(sections, cli.CommandUsage(cmd))
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// payloadBuilders returns the file that contains the payload constructors that
+// use flag values as arguments.
+func payloadBuilders(genpkg string, svc *expr.HTTPServiceExpr, data *cli.CommandData) *codegen.File {
+	sd := JSONRPCServices.GetService(svc)
+	fpath := filepath.Join(codegen.Gendir, "jsonrpc", sd.Service.PathName, "client", "cli.go")
+	title := fmt.Sprintf("%s JSON-RPC client CLI support package", svc.Name())
+	specs := []*codegen.ImportSpec{
+		{Path: "encoding/json"},
+		{Path: "fmt"},
+		{Path: "strconv"},
+		{Path: "unicode/utf8"},
+		codegen.GoaImport(""),
+		{Path: path.Join(genpkg, sd.Service.PathName), Name: sd.Service.PkgName},
+	}
+	specs = append(specs, sd.Service.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", specs),
+	}
+	for _, sub := range data.Subcommands {
+		if sub.BuildFunction != nil {
+			sections = append(sections, cli.PayloadBuilderSection(sub.BuildFunction))
+		}
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// needStream returns true if at least one of the commands calls a streaming
+// method.
+func needStream(data []*commandData) bool {
+	for _, c := range data {
+		if c.NeedStream {
+			return true
+		}
+	}
+	return false
+}
+
+// input: struct{ FlagsCode string; Commands []*commandData; NeedStream bool }
+const parseEndpointT = `// ParseEndpoint returns the endpoint and payload as specified on the command
+// line.
+func ParseEndpoint(
+	scheme, host string,
+	doer goahttp.Doer,
+{{- if .NeedStream }}
+	dialer goahttp.Dialer,
+{{- end }}
+) (goa.Endpoint, any, error) {
+	{{ .FlagsCode }}
+	var (
+		data     any
+		endpoint goa.Endpoint
+		err      error
+	)
+	{
+		switch svcn {
+	{{- range .Commands }}
+		case "{{ .Name }}":
+			c := {{ .PkgName }}.NewClient(scheme, host, doer{{ if .NeedStream }}, dialer, nil{{ end }})
+			switch epn {
+		{{- $pkgName := .PkgName }}{{ range .Subcommands }}
+			case "{{ .Name }}":
+				endpoint = c.{{ .MethodVarName }}()
+			{{- if .BuildFunction }}
+				data, err = {{ $pkgName }}.{{ .BuildFunction.Name }}({{ range .BuildFunction.ActualParams }}*{{ . }}Flag, {{ end }})
+			{{- else if .Conversion }}
+				{{ .Conversion }}
+			{{- else }}
+				data = nil
+			{{- end }}
+		{{- end }}
+			}
+	{{- end }}
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return endpoint, data, nil
+}
+`
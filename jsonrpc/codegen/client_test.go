@@ -0,0 +1,37 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/jsonrpc/codegen/testdata"
+)
+
+func TestClient(t *testing.T) {
+	cases := []*testCase{
+		{"unary", testdata.UnaryDSL, []*sectionExpectation{
+			{"jsonrpc-client-endpoint", &testdata.UnaryClientEndpointCode},
+			{"jsonrpc-client-error-decoder", &testdata.UnaryClientErrorDecoderCode},
+			{"jsonrpc-client-stream", nil},
+		}},
+		{"streaming", testdata.StreamingDSL, []*sectionExpectation{
+			{"jsonrpc-client-endpoint", &testdata.StreamingClientEndpointCode},
+			{"jsonrpc-client-error-decoder", nil},
+			{"jsonrpc-client-stream", &testdata.StreamingClientStreamCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ClientFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
+
+func TestClientTypes(t *testing.T) {
+	cases := []*testCase{
+		{"unary", testdata.UnaryDSL, []*sectionExpectation{
+			{"client-request-body", &testdata.UnaryClientRequestBodyCode},
+			{"client-error-body", &testdata.UnaryClientErrorBodyCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ClientTypeFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
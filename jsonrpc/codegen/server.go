@@ -0,0 +1,379 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// ServerFiles returns the generated JSON-RPC server files.
+func ServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	for _, svc := range root.API.JSONRPC.Services {
+		files = append(files, serverFile(genpkg, svc))
+	}
+	return files
+}
+
+// ServerTypeFiles returns the JSON-RPC transport server type files.
+func ServerTypeFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	for _, svc := range root.API.JSONRPC.Services {
+		sd := JSONRPCServices.GetService(svc)
+		path := filepath.Join(codegen.Gendir, "jsonrpc", sd.Service.PathName, "server", "types.go")
+		files = append(files, httpcodegen.ServerTypeFile(genpkg, svc, sd, path, svc.Name()+" JSON-RPC server types"))
+	}
+	return files
+}
+
+// serverFile returns the file implementing the JSON-RPC server of the given
+// service.
+func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := jsonrpcService(svc)
+	svcName := data.Service.PathName
+	fpath := filepath.Join(codegen.Gendir, "jsonrpc", svcName, "server", "server.go")
+	title := fmt.Sprintf("%s JSON-RPC server", svc.Name())
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "encoding/json"},
+		{Path: "errors"},
+		{Path: "net/http"},
+		codegen.GoaImport(""),
+		codegen.GoaNamedImport("http", "goahttp"),
+		codegen.GoaImport("jsonrpc"),
+	}
+	imports = append(imports, serviceImports(genpkg, data)...)
+	funcs := map[string]any{"viewedServerBody": viewedServerBody}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", imports),
+		{Name: "jsonrpc-server-struct", Source: serverStructT, Data: data},
+		{Name: "jsonrpc-server-mountpoint", Source: mountPointStructT, Data: data},
+		{Name: "jsonrpc-server-init", Source: serverInitT, Data: data},
+		{Name: "jsonrpc-server-service", Source: serverServiceT, Data: data},
+		{Name: "jsonrpc-server-use", Source: serverUseT, Data: data},
+		{Name: "jsonrpc-server-mount", Source: serverMountT, Data: data},
+	}
+	for _, e := range data.Endpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "jsonrpc-server-handler",
+			Source:  serverHandlerT,
+			Data:    e,
+			FuncMap: funcs,
+		})
+		if len(e.Errors) > 0 {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "jsonrpc-server-error-encoder",
+				Source: serverErrorEncoderT,
+				Data:   e,
+			})
+		}
+	}
+	for _, e := range data.Endpoints {
+		if e.Stream {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:    "jsonrpc-server-stream",
+				Source:  serverStreamT,
+				Data:    e,
+				FuncMap: funcs,
+			})
+		}
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// viewedServerBody returns the server body type data for the given view or
+// the first body if there is no body specific to the view.
+func viewedServerBody(bodies []*httpcodegen.TypeData, view string) *httpcodegen.TypeData {
+	for _, b := range bodies {
+		if b.View == view {
+			return b
+		}
+	}
+	return bodies[0]
+}
+
+// input: ServiceData
+const serverStructT = `{{ printf "%s lists the %s service endpoint JSON-RPC handlers." .ServerStruct .Service.Name | comment }}
+type {{ .ServerStruct }} struct {
+	Mounts []*{{ .MountPointStruct }}
+{{- if .HasUnary }}
+	{{ comment "Handler serves the JSON-RPC requests sent via HTTP POST requests." }}
+	Handler http.Handler
+{{- end }}
+{{- if .HasStreams }}
+	{{ comment "WebSocketHandler serves the JSON-RPC requests made to the streaming methods via websocket connections." }}
+	WebSocketHandler http.Handler
+{{- end }}
+}
+`
+
+// input: ServiceData
+const mountPointStructT = `{{ printf "%s holds information about the mounted endpoints." .MountPointStruct | comment }}
+type {{ .MountPointStruct }} struct {
+	{{ comment "Method is the name of the service method served by the mounted JSON-RPC handler." }}
+	Method string
+	{{ comment "Verb is the HTTP method used to match requests to the mounted handler." }}
+	Verb string
+	{{ comment "Pattern is the HTTP request path pattern used to match requests to the mounted handler." }}
+	Pattern string
+}
+`
+
+// input: ServiceData
+const serverInitT = `{{ printf "%s instantiates the JSON-RPC handlers for all the %s service endpoints." .ServerInit .Service.Name | comment }}
+func {{ .ServerInit }}(
+	e *{{ .Service.PkgName }}.Endpoints,
+	errhandler func(context.Context, http.ResponseWriter, error),
+{{- if .HasStreams }}
+	upgrader goahttp.Upgrader,
+	configurer goahttp.ConnConfigureFunc,
+{{- end }}
+) *{{ .ServerStruct }} {
+	return &{{ .ServerStruct }}{
+		Mounts: []*{{ .MountPointStruct }}{
+		{{- range $e := .Endpoints }}
+			{{- range $.Paths }}
+			{"{{ $e.Method.VarName }}", "{{ if $e.Stream }}GET{{ else }}POST{{ end }}", "{{ . }}"},
+			{{- end }}
+		{{- end }}
+		},
+	{{- if .HasUnary }}
+		Handler: jsonrpc.NewHandler(map[string]jsonrpc.MethodHandler{
+		{{- range .Endpoints }}
+			{{- if not .Stream }}
+			{{ printf "%q" .Method.Name }}: {{ .HandlerInit }}(e.{{ .Method.VarName }}),
+			{{- end }}
+		{{- end }}
+		}, errhandler),
+	{{- end }}
+	{{- if .HasStreams }}
+		WebSocketHandler: jsonrpc.NewWebSocketHandler(map[string]jsonrpc.StreamMethodHandler{
+		{{- range .Endpoints }}
+			{{- if .Stream }}
+			{{ printf "%q" .Method.Name }}: {{ .HandlerInit }}(e.{{ .Method.VarName }}),
+			{{- end }}
+		{{- end }}
+		}, upgrader, configurer, errhandler),
+	{{- end }}
+	}
+}
+`
+
+// input: ServiceData
+const serverServiceT = `{{ printf "%s returns the name of the service served." .ServerService | comment }}
+func (s *{{ .ServerStruct }}) {{ .ServerService }}() string { return "{{ .Service.Name }}" }
+
+{{ comment "MethodNames returns the methods served." }}
+func (s *{{ .ServerStruct }}) MethodNames() []string { return {{ .Service.PkgName }}.MethodNames[:] }
+`
+
+// input: ServiceData
+const serverUseT = `{{ comment "Use wraps the server handlers with the given middleware." }}
+func (s *{{ .ServerStruct }}) Use(m func(http.Handler) http.Handler) {
+{{- if .HasUnary }}
+	s.Handler = m(s.Handler)
+{{- end }}
+{{- if .HasStreams }}
+	s.WebSocketHandler = m(s.WebSocketHandler)
+{{- end }}
+}
+`
+
+// input: ServiceData
+const serverMountT = `{{ printf "%s configures the mux to serve the %s JSON-RPC requests." .MountServer .Service.Name | comment }}
+func {{ .MountServer }}(mux goahttp.Muxer, h *{{ .ServerStruct }}) {
+{{- range .Paths }}
+	{{- if $.HasUnary }}
+	mux.Handle("POST", "{{ . }}", h.Handler.ServeHTTP)
+	{{- end }}
+	{{- if $.HasStreams }}
+	mux.Handle("GET", "{{ . }}", h.WebSocketHandler.ServeHTTP)
+	{{- end }}
+{{- end }}
+}
+
+{{ printf "%s configures the mux to serve the %s JSON-RPC requests." .MountServer .Service.Name | comment }}
+func (s *{{ .ServerStruct }}) {{ .MountServer }}(mux goahttp.Muxer) {
+	{{ .MountServer }}(mux, s)
+}
+`
+
+// input: EndpointData
+const serverHandlerT = `{{ if .Stream }}{{ printf "%s creates a JSON-RPC stream method handler which serves the %q service %q streaming method." .HandlerInit .ServiceName .Method.Name | comment }}
+func {{ .HandlerInit }}(endpoint goa.Endpoint) jsonrpc.StreamMethodHandler {
+	return func(ctx context.Context, params json.RawMessage, stream *jsonrpc.ServerStream) error {
+{{- else }}{{ printf "%s creates a JSON-RPC method handler which serves the %q service %q method." .HandlerInit .ServiceName .Method.Name | comment }}
+func {{ .HandlerInit }}(endpoint goa.Endpoint) jsonrpc.MethodHandler {
+	return func(ctx context.Context, params json.RawMessage) (any, error) {
+{{- end }}
+		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
+		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+{{- if .Payload.Request.ServerBody }}
+		var (
+			body {{ .Payload.Request.ServerBody.VarName }}
+			err  error
+		)
+	{{- if .Payload.Request.MustHaveBody }}
+		if params == nil {
+			return {{ if not .Stream }}nil, {{ end }}goa.MissingPayloadError()
+		}
+	{{- end }}
+		if err = jsonrpc.DecodeParams(params, &body); err != nil {
+			return {{ if not .Stream }}nil, {{ end }}err
+		}
+	{{- if .Payload.Request.ServerBody.ValidateRef }}
+		{{ .Payload.Request.ServerBody.ValidateRef }}
+		if err != nil {
+			return {{ if not .Stream }}nil, {{ end }}err
+		}
+	{{- end }}
+	{{- if .Payload.Request.PayloadInit }}
+		payload := {{ .Payload.Request.PayloadInit.Name }}({{ range .Payload.Request.PayloadInit.ServerArgs }}{{ .Ref }}, {{ end }})
+	{{- else }}
+		payload := body
+	{{- end }}
+{{- end }}
+{{- if .Stream }}
+		v := &{{ .ServicePkgName }}.{{ .Method.ServerStream.EndpointStruct }}{
+			Stream: &{{ .ServerStreamStruct }}{stream: stream},
+	{{- if .Payload.Request.ServerBody }}
+			Payload: payload,
+	{{- end }}
+		}
+		if _, err := endpoint(ctx, v); err != nil {
+	{{- if .Errors }}
+			return {{ .ErrorEncoder }}(err)
+	{{- else }}
+			return err
+	{{- end }}
+		}
+		return nil
+{{- else }}
+		v, err := endpoint(ctx, {{ if .Payload.Request.ServerBody }}payload{{ else }}nil{{ end }})
+		if err != nil {
+	{{- if .Errors }}
+			return nil, {{ .ErrorEncoder }}(err)
+	{{- else }}
+			return nil, err
+	{{- end }}
+		}
+	{{- $resp := (index .Result.Responses 0) }}
+	{{- if .Method.ViewedResult }}
+		res := v.({{ .Method.ViewedResult.FullRef }})
+		{{- $vsb := (viewedServerBody $resp.ServerBody .Method.ViewedResult.ViewName) }}
+		return {{ $vsb.Init.Name }}({{ range $vsb.Init.ServerArgs }}{{ .Ref }}, {{ end }}), nil
+	{{- else if and $resp.ServerBody (index $resp.ServerBody 0).Init }}
+		res, _ := v.({{ .Result.Ref }})
+		return {{ (index $resp.ServerBody 0).Init.Name }}({{ range (index $resp.ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }}), nil
+	{{- else }}
+		return v, nil
+	{{- end }}
+{{- end }}
+	}
+}
+`
+
+// input: EndpointData
+const serverErrorEncoderT = `{{ printf "%s maps the errors returned by the %q service %q method to JSON-RPC errors." .ErrorEncoder .ServiceName .Method.Name | comment }}
+func {{ .ErrorEncoder }}(v error) error {
+	var en goa.GoaErrorNamer
+	if !errors.As(v, &en) {
+		return v
+	}
+	switch en.GoaErrorName() {
+{{- range $gerr := .Errors }}
+	{{- range $err := .Errors }}
+	case {{ printf "%q" .Name }}:
+		var res {{ $err.Ref }}
+		errors.As(v, &res)
+		{{- with .Response }}
+			{{- if .ServerBody }}
+				{{- if (index .ServerBody 0).Init }}
+		body := {{ (index .ServerBody 0).Init.Name }}({{ range (index .ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
+				{{- else }}
+		body := res
+				{{- end }}
+		return jsonrpc.NewError({{ $gerr.StatusCode }}, v.Error(), body)
+			{{- else }}
+		return jsonrpc.NewError({{ $gerr.StatusCode }}, v.Error(), nil)
+			{{- end }}
+		{{- end }}
+	{{- end }}
+{{- end }}
+	}
+	return v
+}
+`
+
+// input: EndpointData
+const serverStreamT = `{{ printf "%s implements the %s.%s interface using a JSON-RPC stream." .ServerStreamStruct .ServicePkgName .Method.ServerStream.Interface | comment }}
+type {{ .ServerStreamStruct }} struct {
+	stream *jsonrpc.ServerStream
+{{- if and .Method.ViewedResult (not .Method.ViewedResult.ViewName) }}
+	{{ comment "view is the view used to render the results." }}
+	view string
+{{- end }}
+}
+{{- with .ServerWebSocket }}
+{{- if .SendTypeRef }}
+
+{{ printf "%s streams instances of %q to the %q method JSON-RPC stream." .SendName .SendTypeName $.Method.Name | comment }}
+func (s *{{ $.ServerStreamStruct }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
+	{{- if $.Method.ViewedResult }}
+	res := {{ .PkgName }}.{{ $.Method.ViewedResult.Init.Name }}(v, {{ if $.Method.ViewedResult.ViewName }}{{ printf "%q" $.Method.ViewedResult.ViewName }}{{ else }}s.view{{ end }})
+		{{- $vsb := (viewedServerBody .Response.ServerBody $.Method.ViewedResult.ViewName) }}
+	body := {{ $vsb.Init.Name }}({{ range $vsb.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+	return s.stream.Send(body)
+	{{- else if and .Response.ServerBody (index .Response.ServerBody 0).Init }}
+	res := v
+	body := {{ (index .Response.ServerBody 0).Init.Name }}({{ range (index .Response.ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
+	return s.stream.Send(body)
+	{{- else }}
+	return s.stream.Send(v)
+	{{- end }}
+}
+{{- end }}
+{{- if .RecvTypeRef }}
+
+{{ printf "%s reads instances of %q from the %q method JSON-RPC stream." .RecvName .RecvTypeName $.Method.Name | comment }}
+func (s *{{ $.ServerStreamStruct }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
+	var (
+		rv   {{ .RecvTypeRef }}
+		body {{ .Payload.VarName }}
+		err  error
+	)
+	if err = s.stream.Recv(&body); err != nil {
+		return rv, err
+	}
+	{{- if .Payload.ValidateRef }}
+	{{ .Payload.ValidateRef }}
+	if err != nil {
+		return rv, err
+	}
+	{{- end }}
+	{{- if .Payload.Init }}
+	return {{ .Payload.Init.Name }}(&body), nil
+	{{- else }}
+	return &body, nil
+	{{- end }}
+}
+{{- end }}
+{{- if .MustClose }}
+
+{{ printf "Close closes the %q method JSON-RPC stream." $.Method.Name | comment }}
+func (s *{{ $.ServerStreamStruct }}) Close() error {
+	return s.stream.Close()
+}
+{{- end }}
+{{- if and $.Method.ViewedResult (not $.Method.ViewedResult.ViewName) }}
+
+{{ printf "SetView sets the view to render the %s type before sending to the %q method JSON-RPC stream." .SendTypeName $.Method.Name | comment }}
+func (s *{{ $.ServerStreamStruct }}) SetView(view string) {
+	s.view = view
+}
+{{- end }}
+{{- end }}
+`
@@ -0,0 +1,82 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/jsonrpc/codegen/testdata"
+)
+
+type (
+	testCase struct {
+		Name     string
+		DSL      func()
+		Sections []*sectionExpectation
+	}
+
+	sectionExpectation struct {
+		Name string
+		Code *string
+	}
+)
+
+func TestServer(t *testing.T) {
+	cases := []*testCase{
+		{"unary", testdata.UnaryDSL, []*sectionExpectation{
+			{"jsonrpc-server-handler", &testdata.UnaryServerHandlerCode},
+			{"jsonrpc-server-error-encoder", &testdata.UnaryServerErrorEncoderCode},
+			{"jsonrpc-server-stream", nil},
+		}},
+		{"streaming", testdata.StreamingDSL, []*sectionExpectation{
+			{"jsonrpc-server-handler", &testdata.StreamingServerHandlerCode},
+			{"jsonrpc-server-error-encoder", nil},
+			{"jsonrpc-server-stream", &testdata.StreamingServerStreamCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
+
+func TestServerTypes(t *testing.T) {
+	cases := []*testCase{
+		{"unary", testdata.UnaryDSL, []*sectionExpectation{
+			{"request-body-type-decl", &testdata.UnaryServerRequestBodyTypeCode},
+			{"error-body-type-decl", &testdata.UnaryServerErrorBodyTypeCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerTypeFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
+
+func runTests(t *testing.T, cases []*testCase, filesFn func() []*codegen.File) {
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunJSONRPCDSL(t, c.DSL)
+			fs := filesFn()
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			f := fs[0]
+			for _, s := range c.Sections {
+				var code string
+				sections := f.Section(s.Name)
+				seclen := len(sections)
+				if seclen > 0 {
+					code = codegen.SectionCode(t, sections[0])
+				}
+				switch {
+				case seclen == 0 && s.Code == nil:
+				case seclen == 0 && s.Code != nil:
+					t.Errorf("invalid code for %s: got 0 %s sections, expected at least one", f.Path, s.Name)
+				case seclen > 0 && s.Code == nil:
+					t.Errorf("invalid code for %s: got %d %s sections, expected 0.\n%s", f.Path, seclen, s.Name, code)
+				default:
+					if code != *s.Code {
+						t.Errorf("invalid code for %s %s section, got:\n%s\ngot vs. expected:\n%s", f.Path, s.Name, code, codegen.Diff(t, code, *s.Code))
+					}
+				}
+			}
+		})
+	}
+}
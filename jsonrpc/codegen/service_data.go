@@ -0,0 +1,86 @@
+package codegen
+
+import (
+	"path"
+	"sort"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// JSONRPCServices holds the data computed from the design needed to generate
+// the transport code of the JSON-RPC services. The JSON-RPC services are
+// described with HTTP service expressions so the data is the same as the data
+// computed for the HTTP services.
+var JSONRPCServices = make(httpcodegen.ServicesData)
+
+type (
+	// ServiceData contains the data used to render the JSON-RPC server and
+	// client code of a service.
+	ServiceData struct {
+		*httpcodegen.ServiceData
+		// Paths lists the paths of the service JSON-RPC requests.
+		Paths []string
+		// Endpoints lists the service endpoints data.
+		Endpoints []*EndpointData
+		// HasUnary is true if at least one method is served via HTTP
+		// POST requests.
+		HasUnary bool
+		// HasStreams is true if at least one method is served via
+		// websocket connections.
+		HasStreams bool
+	}
+
+	// EndpointData contains the data used to render the JSON-RPC handler
+	// and client endpoint of a method.
+	EndpointData struct {
+		*httpcodegen.EndpointData
+		// Stream is true if the method is served via websocket
+		// connections.
+		Stream bool
+		// ErrorDecoder is the name of the client function that decodes
+		// the JSON-RPC errors into the method errors.
+		ErrorDecoder string
+		// ServerStreamStruct is the name of the struct that implements
+		// the service server stream interface.
+		ServerStreamStruct string
+		// ClientStreamStruct is the name of the struct that implements
+		// the service client stream interface.
+		ClientStreamStruct string
+	}
+)
+
+// jsonrpcService returns the data used to render the JSON-RPC code of the
+// given service.
+func jsonrpcService(svc *expr.HTTPServiceExpr) *ServiceData {
+	sd := JSONRPCServices.GetService(svc)
+	data := &ServiceData{ServiceData: sd, Paths: svc.FullPaths()}
+	sort.Strings(data.Paths)
+	for _, e := range sd.Endpoints {
+		ed := &EndpointData{
+			EndpointData: e,
+			Stream:       e.ServerWebSocket != nil,
+			ErrorDecoder: "Decode" + e.Method.VarName + "Error",
+		}
+		if ed.Stream {
+			ed.ServerStreamStruct = e.Method.ServerStream.VarName
+			ed.ClientStreamStruct = e.Method.ClientStream.VarName
+			data.HasStreams = true
+		} else {
+			data.HasUnary = true
+		}
+		data.Endpoints = append(data.Endpoints, ed)
+	}
+	return data
+}
+
+// serviceImports returns the imports of the service and views packages.
+func serviceImports(genpkg string, sd *ServiceData) []*codegen.ImportSpec {
+	svcPath := path.Join(genpkg, sd.Service.PathName)
+	imports := []*codegen.ImportSpec{
+		{Path: svcPath, Name: sd.Service.PkgName},
+		{Path: path.Join(svcPath, "views"), Name: sd.Service.ViewsPkg},
+	}
+	return append(imports, sd.Service.UserTypeImports...)
+}
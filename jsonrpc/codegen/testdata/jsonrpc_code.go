@@ -0,0 +1,247 @@
+package testdata
+
+var UnaryServerHandlerCode = `// NewDivHandler creates a JSON-RPC method handler which serves the "Calc"
+// service "Div" method.
+func NewDivHandler(endpoint goa.Endpoint) jsonrpc.MethodHandler {
+	return func(ctx context.Context, params json.RawMessage) (any, error) {
+		ctx = context.WithValue(ctx, goa.MethodKey, "Div")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "Calc")
+		var (
+			body DivRequestBody
+			err  error
+		)
+		if params == nil {
+			return nil, goa.MissingPayloadError()
+		}
+		if err = jsonrpc.DecodeParams(params, &body); err != nil {
+			return nil, err
+		}
+		err = ValidateDivRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+		payload := NewDivOperands(&body)
+		v, err := endpoint(ctx, payload)
+		if err != nil {
+			return nil, EncodeDivError(err)
+		}
+		return v, nil
+	}
+}
+`
+
+var UnaryServerErrorEncoderCode = `// EncodeDivError maps the errors returned by the "Calc" service "Div" method
+// to JSON-RPC errors.
+func EncodeDivError(v error) error {
+	var en goa.GoaErrorNamer
+	if !errors.As(v, &en) {
+		return v
+	}
+	switch en.GoaErrorName() {
+	case "div_by_zero":
+		var res *goa.ServiceError
+		errors.As(v, &res)
+		body := NewDivDivByZeroResponseBody(res)
+		return jsonrpc.NewError(-32002, v.Error(), body)
+	}
+	return v
+}
+`
+
+var StreamingServerHandlerCode = `// NewEchoHandler creates a JSON-RPC stream method handler which serves the
+// "Echo" service "Echo" streaming method.
+func NewEchoHandler(endpoint goa.Endpoint) jsonrpc.StreamMethodHandler {
+	return func(ctx context.Context, params json.RawMessage, stream *jsonrpc.ServerStream) error {
+		ctx = context.WithValue(ctx, goa.MethodKey, "Echo")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "Echo")
+		v := &echo.EchoEndpointInput{
+			Stream: &EchoServerStream{stream: stream},
+		}
+		if _, err := endpoint(ctx, v); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+`
+
+var StreamingServerStreamCode = `// EchoServerStream implements the echo.EchoServerStream interface using a
+// JSON-RPC stream.
+type EchoServerStream struct {
+	stream *jsonrpc.ServerStream
+}
+
+// Send streams instances of "string" to the "Echo" method JSON-RPC stream.
+func (s *EchoServerStream) Send(v string) error {
+	return s.stream.Send(v)
+}
+
+// Recv reads instances of "echo.EchoStreamingPayload" from the "Echo" method
+// JSON-RPC stream.
+func (s *EchoServerStream) Recv() (*echo.EchoStreamingPayload, error) {
+	var (
+		rv   *echo.EchoStreamingPayload
+		body EchoStreamingBody
+		err  error
+	)
+	if err = s.stream.Recv(&body); err != nil {
+		return rv, err
+	}
+	err = ValidateEchoStreamingBody(&body)
+	if err != nil {
+		return rv, err
+	}
+	return NewEchoStreamingBody(&body), nil
+}
+
+// Close closes the "Echo" method JSON-RPC stream.
+func (s *EchoServerStream) Close() error {
+	return s.stream.Close()
+}
+`
+
+var UnaryClientEndpointCode = `// Div returns an endpoint that makes JSON-RPC requests to the Calc service Div
+// server.
+func (c *Client) Div() goa.Endpoint {
+	return func(ctx context.Context, v any) (any, error) {
+		p, ok := v.(*calc.Operands)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("Calc", "Div", "*calc.Operands", v)
+		}
+		params := NewDivRequestBody(p)
+		var (
+			body int
+			err  error
+		)
+		if err = c.rpc.Call(ctx, "Div", &params, &body); err != nil {
+			return nil, DecodeDivError(err)
+		}
+		return body, nil
+	}
+}
+`
+
+var UnaryClientErrorDecoderCode = `// DecodeDivError maps the JSON-RPC errors returned by the "Calc" service "Div"
+// method to the method errors.
+func DecodeDivError(err error) error {
+	var jerr *jsonrpc.Error
+	if !errors.As(err, &jerr) {
+		return err
+	}
+	switch jerr.Code {
+	case -32002:
+		var body DivDivByZeroResponseBody
+		if err := json.Unmarshal(jerr.Data, &body); err != nil {
+			return goahttp.ErrDecodingError("Calc", "Div", err)
+		}
+		err = ValidateDivDivByZeroResponseBody(&body)
+		if err != nil {
+			return goahttp.ErrValidationError("Calc", "Div", err)
+		}
+		return NewDivDivByZero(&body)
+	}
+	return err
+}
+`
+
+var StreamingClientEndpointCode = `// Echo returns an endpoint that makes JSON-RPC requests to the Echo service
+// Echo server.
+func (c *Client) Echo() goa.Endpoint {
+	return func(ctx context.Context, v any) (any, error) {
+		stream, err := c.rpc.Stream(ctx, "Echo", nil, c.configurer)
+		if err != nil {
+			return nil, err
+		}
+		return &EchoClientStream{stream: stream}, nil
+	}
+}
+`
+
+var StreamingClientStreamCode = `// EchoClientStream implements the echo.EchoClientStream interface using a
+// JSON-RPC stream.
+type EchoClientStream struct {
+	stream *jsonrpc.ClientStream
+}
+
+// Send streams instances of "echo.EchoStreamingPayload" to the "Echo" method
+// JSON-RPC stream.
+func (s *EchoClientStream) Send(v *echo.EchoStreamingPayload) error {
+	body := NewEchoStreamingBody(v)
+	return s.stream.Send(body)
+}
+
+// Recv reads instances of "string" from the "Echo" method JSON-RPC stream.
+func (s *EchoClientStream) Recv() (string, error) {
+	var (
+		rv   string
+		body string
+		err  error
+	)
+	if err = s.stream.Recv(&body); err != nil {
+		return rv, err
+	}
+	return body, nil
+}
+
+// Close closes the "Echo" method JSON-RPC stream.
+func (s *EchoClientStream) Close() error {
+	if err := s.stream.CloseSend(); err != nil {
+		return err
+	}
+	return s.stream.Close()
+}
+`
+
+var UnaryServerRequestBodyTypeCode = `// DivRequestBody is the type of the "Calc" service "Div" endpoint JSON-RPC
+// request params.
+type DivRequestBody struct {
+	A *int ` + "`" + `form:"a,omitempty" json:"a,omitempty" xml:"a,omitempty"` + "`" + `
+	B *int ` + "`" + `form:"b,omitempty" json:"b,omitempty" xml:"b,omitempty"` + "`" + `
+}
+`
+
+var UnaryServerErrorBodyTypeCode = `// DivDivByZeroResponseBody is the type of the "Calc" service "Div" endpoint
+// JSON-RPC error data for the "div_by_zero" error.
+type DivDivByZeroResponseBody struct {
+	// Name is the name of this class of errors.
+	Name string ` + "`" + `form:"name" json:"name" xml:"name"` + "`" + `
+	// ID is a unique identifier for this particular occurrence of the problem.
+	ID string ` + "`" + `form:"id" json:"id" xml:"id"` + "`" + `
+	// Message is a human-readable explanation specific to this occurrence of the
+	// problem.
+	Message string ` + "`" + `form:"message" json:"message" xml:"message"` + "`" + `
+	// Is the error temporary?
+	Temporary bool ` + "`" + `form:"temporary" json:"temporary" xml:"temporary"` + "`" + `
+	// Is the error a timeout?
+	Timeout bool ` + "`" + `form:"timeout" json:"timeout" xml:"timeout"` + "`" + `
+	// Is the error a server-side fault?
+	Fault bool ` + "`" + `form:"fault" json:"fault" xml:"fault"` + "`" + `
+}
+`
+
+var UnaryClientRequestBodyCode = `// DivRequestBody is the type of the "Calc" service "Div" endpoint JSON-RPC
+// request params.
+type DivRequestBody struct {
+	A int ` + "`" + `form:"a" json:"a" xml:"a"` + "`" + `
+	B int ` + "`" + `form:"b" json:"b" xml:"b"` + "`" + `
+}
+`
+
+var UnaryClientErrorBodyCode = `// DivDivByZeroResponseBody is the type of the "Calc" service "Div" endpoint
+// JSON-RPC error data for the "div_by_zero" error.
+type DivDivByZeroResponseBody struct {
+	// Name is the name of this class of errors.
+	Name *string ` + "`" + `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"` + "`" + `
+	// ID is a unique identifier for this particular occurrence of the problem.
+	ID *string ` + "`" + `form:"id,omitempty" json:"id,omitempty" xml:"id,omitempty"` + "`" + `
+	// Message is a human-readable explanation specific to this occurrence of the
+	// problem.
+	Message *string ` + "`" + `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"` + "`" + `
+	// Is the error temporary?
+	Temporary *bool ` + "`" + `form:"temporary,omitempty" json:"temporary,omitempty" xml:"temporary,omitempty"` + "`" + `
+	// Is the error a timeout?
+	Timeout *bool ` + "`" + `form:"timeout,omitempty" json:"timeout,omitempty" xml:"timeout,omitempty"` + "`" + `
+	// Is the error a server-side fault?
+	Fault *bool ` + "`" + `form:"fault,omitempty" json:"fault,omitempty" xml:"fault,omitempty"` + "`" + `
+}
+`
@@ -0,0 +1,42 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var UnaryDSL = func() {
+	var Operands = Type("Operands", func() {
+		Attribute("a", Int)
+		Attribute("b", Int)
+		Required("a", "b")
+	})
+	Service("Calc", func() {
+		JSONRPC(func() {
+			Path("/calc")
+		})
+		Method("Div", func() {
+			Payload(Operands)
+			Result(Int)
+			Error("div_by_zero")
+			JSONRPC(func() {
+				Response("div_by_zero", -32002)
+			})
+		})
+	})
+}
+
+var StreamingDSL = func() {
+	Service("Echo", func() {
+		JSONRPC(func() {
+			Path("/echo")
+		})
+		Method("Echo", func() {
+			StreamingPayload(func() {
+				Attribute("message", String)
+				Required("message")
+			})
+			StreamingResult(String)
+			JSONRPC()
+		})
+	})
+}
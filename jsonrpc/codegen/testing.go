@@ -0,0 +1,19 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// RunJSONRPCDSL returns the JSON-RPC DSL root resulting from running the given
+// DSL.
+func RunJSONRPCDSL(t *testing.T, dsl func()) *expr.RootExpr {
+	// reset all roots and codegen data structures
+	service.Services = make(service.ServicesData)
+	httpcodegen.HTTPServices = make(httpcodegen.ServicesData)
+	JSONRPCServices = make(httpcodegen.ServicesData)
+	return expr.RunDSL(t, dsl)
+}
@@ -0,0 +1,164 @@
+/*
+Package jsonrpc contains the JSON-RPC 2.0 specific constructs used by the code
+generated by Goa for the JSON-RPC transport. The generated servers use a
+Handler to serve the requests sent in the bodies of HTTP POST requests,
+including batch requests and notifications, and a WebSocketHandler to serve the
+requests made to streaming methods over websocket connections. The generated
+clients use a Client to make the requests.
+*/
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Version is the version of the JSON-RPC protocol implemented by this package.
+const Version = "2.0"
+
+const (
+	// ParseError is the code of the error returned when the server cannot
+	// parse the request.
+	ParseError = -32700
+	// InvalidRequest is the code of the error returned when the request is
+	// not a valid JSON-RPC request object.
+	InvalidRequest = -32600
+	// MethodNotFound is the code of the error returned when the request
+	// method does not exist.
+	MethodNotFound = -32601
+	// InvalidParams is the code of the error returned when the request
+	// params are invalid.
+	InvalidParams = -32602
+	// InternalError is the code of the error returned for internal errors.
+	InternalError = -32603
+	// ServerError is the code of the error returned for temporary and
+	// timeout errors. The codes from -32099 to -32000 are reserved for
+	// implementation defined server errors.
+	ServerError = -32000
+)
+
+type (
+	// Request is a JSON-RPC request object. Requests without ID are
+	// notifications: the server does not reply to them.
+	Request struct {
+		// JSONRPC is the version of the protocol, always "2.0".
+		JSONRPC string `json:"jsonrpc"`
+		// Method is the name of the method to be invoked.
+		Method string `json:"method"`
+		// Params holds the parameter values used to invoke the method.
+		Params json.RawMessage `json:"params,omitempty"`
+		// ID identifies the request, it is nil for notifications.
+		ID json.RawMessage `json:"id,omitempty"`
+	}
+
+	// Response is a JSON-RPC response object.
+	Response struct {
+		// JSONRPC is the version of the protocol, always "2.0".
+		JSONRPC string `json:"jsonrpc"`
+		// Result is the result of the method invocation, nil on error.
+		Result json.RawMessage `json:"result,omitempty"`
+		// Error is the error returned by the method invocation if any.
+		Error *Error `json:"error,omitempty"`
+		// ID is the ID of the corresponding request, null if the ID of
+		// the request could not be read.
+		ID json.RawMessage `json:"id"`
+	}
+
+	// Error is a JSON-RPC error object.
+	Error struct {
+		// Code is the error code.
+		Code int `json:"code"`
+		// Message is a short description of the error.
+		Message string `json:"message"`
+		// Data contains additional information about the error if any.
+		Data json.RawMessage `json:"data,omitempty"`
+	}
+
+	// serviceErrorData is the data of the errors built from goa service
+	// errors that are not mapped to an error code in the design.
+	serviceErrorData struct {
+		// Name is the name of the error.
+		Name string `json:"name"`
+		// ID is the unique error occurrence ID.
+		ID string `json:"id"`
+	}
+)
+
+// NewError creates a JSON-RPC error with the given code and message. data is
+// marshaled as JSON into the error data if not nil.
+func NewError(code int, message string, data any) *Error {
+	e := &Error{Code: code, Message: message}
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return &Error{Code: InternalError, Message: fmt.Sprintf("failed to encode error data: %s", err)}
+		}
+		e.Data = b
+	}
+	return e
+}
+
+// AsError returns the JSON-RPC error corresponding to err. It returns err if
+// it is a JSON-RPC error. Otherwise the error code is computed from the
+// characteristics of the goa service error: InternalError for faults,
+// ServerError for temporary and timeout errors and InvalidParams otherwise,
+// for example for the validation errors. Errors that are not goa service
+// errors use the InternalError code.
+func AsError(err error) *Error {
+	var jerr *Error
+	if errors.As(err, &jerr) {
+		return jerr
+	}
+	var gerr *goa.ServiceError
+	if !errors.As(err, &gerr) {
+		return &Error{Code: InternalError, Message: err.Error()}
+	}
+	code := InvalidParams
+	switch {
+	case gerr.Fault:
+		code = InternalError
+	case gerr.Timeout, gerr.Temporary:
+		code = ServerError
+	}
+	return NewError(code, gerr.Message, &serviceErrorData{Name: gerr.Name, ID: gerr.ID})
+}
+
+// Error returns the error message.
+func (e *Error) Error() string {
+	return fmt.Sprintf("JSON-RPC error %d: %s", e.Code, e.Message)
+}
+
+// DecodeParams decodes the request params into v. It leaves v untouched if
+// params is empty or null and returns a goa decode payload error if the params
+// cannot be decoded.
+func DecodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return goa.DecodePayloadError(err.Error())
+	}
+	return nil
+}
+
+// validID returns true if id is a valid JSON-RPC request ID: a string, a
+// number or null.
+func validID(id json.RawMessage) bool {
+	if len(id) == 0 {
+		return true
+	}
+	switch id[0] {
+	case '{', '[', 't', 'f':
+		return false
+	}
+	return true
+}
+
+// errorResponse returns the JSON-RPC response that reports err for the request
+// with the given ID.
+func errorResponse(id json.RawMessage, err *Error) *Response {
+	return &Response{JSONRPC: Version, Error: err, ID: id}
+}
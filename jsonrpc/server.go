@@ -0,0 +1,167 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+type (
+	// MethodHandler handles the JSON-RPC requests made to a method. params
+	// holds the raw request params, it is nil if the request has none. The
+	// returned value is marshaled as JSON into the response result. The
+	// returned error is converted with AsError.
+	MethodHandler func(ctx context.Context, params json.RawMessage) (any, error)
+
+	// Handler is a HTTP handler that serves the JSON-RPC requests sent in the
+	// bodies of HTTP POST requests. It supports batch requests and
+	// notifications.
+	Handler struct {
+		methods    map[string]MethodHandler
+		errhandler func(context.Context, http.ResponseWriter, error)
+	}
+)
+
+// MaxRequestSize is the maximum size in bytes of the bodies of the HTTP
+// requests read by the JSON-RPC handlers.
+var MaxRequestSize int64 = 4 << 20
+
+// NewHandler returns a HTTP handler that dispatches the JSON-RPC requests to
+// the given method handlers indexed by JSON-RPC method name. errhandler is
+// called with the errors that occur while reading the HTTP request or writing
+// the HTTP response if not nil.
+func NewHandler(methods map[string]MethodHandler, errhandler func(context.Context, http.ResponseWriter, error)) *Handler {
+	return &Handler{methods: methods, errhandler: errhandler}
+}
+
+// ServeHTTP reads the JSON-RPC request or batch of requests from the request
+// body, invokes the corresponding method handlers and writes the responses.
+// The requests of a batch are handled sequentially in order. The handler
+// replies with status code 204 (No Content) if the request only contains
+// notifications.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxRequestSize))
+	if err != nil {
+		h.error(ctx, w, err)
+		return
+	}
+	body = bytes.TrimSpace(body)
+	if !json.Valid(body) {
+		h.write(ctx, w, errorResponse(nil, &Error{Code: ParseError, Message: "invalid JSON"}))
+		return
+	}
+	if body[0] != '[' {
+		if res := h.handle(ctx, body); res != nil {
+			h.write(ctx, w, res)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	var batch []json.RawMessage
+	if err := json.Unmarshal(body, &batch); err != nil {
+		h.write(ctx, w, errorResponse(nil, &Error{Code: ParseError, Message: err.Error()}))
+		return
+	}
+	if len(batch) == 0 {
+		h.write(ctx, w, errorResponse(nil, &Error{Code: InvalidRequest, Message: "empty batch"}))
+		return
+	}
+	var responses []*Response
+	for _, req := range batch {
+		if res := h.handle(ctx, req); res != nil {
+			responses = append(responses, res)
+		}
+	}
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	h.write(ctx, w, responses)
+}
+
+// handle invokes the method handler corresponding to the given request and
+// returns the response, nil if the request is a valid notification.
+func (h *Handler) handle(ctx context.Context, raw json.RawMessage) *Response {
+	var req Request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return errorResponse(nil, &Error{Code: InvalidRequest, Message: err.Error()})
+	}
+	if err := validateRequest(&req); err != nil {
+		return errorResponse(idOrNull(req.ID), err)
+	}
+	method, ok := h.methods[req.Method]
+	if !ok {
+		if req.ID == nil {
+			return nil
+		}
+		return errorResponse(req.ID, &Error{Code: MethodNotFound, Message: "method not found: " + req.Method})
+	}
+	res, err := method(ctx, req.Params)
+	if req.ID == nil {
+		return nil
+	}
+	if err != nil {
+		return errorResponse(req.ID, AsError(err))
+	}
+	return resultResponse(req.ID, res)
+}
+
+// error reports err to the error handler if any.
+func (h *Handler) error(ctx context.Context, w http.ResponseWriter, err error) {
+	if h.errhandler != nil {
+		h.errhandler(ctx, w, err)
+	}
+}
+
+// write writes the given response or batch of responses to w.
+func (h *Handler) write(ctx context.Context, w http.ResponseWriter, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		h.error(ctx, w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(b); err != nil {
+		h.error(ctx, w, err)
+	}
+}
+
+// validateRequest returns an InvalidRequest error if req is not a valid
+// JSON-RPC request.
+func validateRequest(req *Request) *Error {
+	switch {
+	case req.JSONRPC != Version:
+		return &Error{Code: InvalidRequest, Message: `jsonrpc must be "2.0"`}
+	case req.Method == "":
+		return &Error{Code: InvalidRequest, Message: "missing method"}
+	case !validID(req.ID):
+		return &Error{Code: InvalidRequest, Message: "id must be a string, a number or null"}
+	case len(req.Params) > 0 && req.Params[0] != '{' && req.Params[0] != '[':
+		return &Error{Code: InvalidRequest, Message: "params must be an object or an array"}
+	}
+	return nil
+}
+
+// resultResponse returns the JSON-RPC response that holds res for the request
+// with the given ID.
+func resultResponse(id json.RawMessage, res any) *Response {
+	b, err := json.Marshal(res)
+	if err != nil {
+		return errorResponse(id, &Error{Code: InternalError, Message: "failed to encode result: " + err.Error()})
+	}
+	return &Response{JSONRPC: Version, Result: b, ID: id}
+}
+
+// idOrNull returns id if it is a valid request ID, nil otherwise so that the
+// response ID is null.
+func idOrNull(id json.RawMessage) json.RawMessage {
+	if validID(id) {
+		return id
+	}
+	return nil
+}
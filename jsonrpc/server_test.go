@@ -0,0 +1,114 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestHandler(t *testing.T) {
+	var notifyCalls int
+	methods := map[string]MethodHandler{
+		"add": func(_ context.Context, params json.RawMessage) (any, error) {
+			var p struct{ A, B int }
+			if err := DecodeParams(params, &p); err != nil {
+				return nil, err
+			}
+			return p.A + p.B, nil
+		},
+		"notify": func(context.Context, json.RawMessage) (any, error) {
+			notifyCalls++
+			return nil, nil
+		},
+		"fail": func(context.Context, json.RawMessage) (any, error) {
+			return nil, goa.MissingFieldError("a", "body")
+		},
+		"fault": func(context.Context, json.RawMessage) (any, error) {
+			return nil, errors.New("boom")
+		},
+		"coded": func(context.Context, json.RawMessage) (any, error) {
+			return nil, NewError(-32001, "unauthorized", map[string]string{"reason": "token"})
+		},
+	}
+	cases := []struct {
+		Name         string
+		Body         string
+		ExpectedCode int
+		Expected     string
+	}{
+		{"call", `{"jsonrpc":"2.0","method":"add","params":{"A":1,"B":2},"id":1}`, http.StatusOK,
+			`{"jsonrpc":"2.0","result":3,"id":1}`},
+		{"string-id", `{"jsonrpc":"2.0","method":"add","params":{"A":1,"B":2},"id":"a"}`, http.StatusOK,
+			`{"jsonrpc":"2.0","result":3,"id":"a"}`},
+		{"null-result", `{"jsonrpc":"2.0","method":"notify","id":1}`, http.StatusOK,
+			`{"jsonrpc":"2.0","result":null,"id":1}`},
+		{"notification", `{"jsonrpc":"2.0","method":"notify"}`, http.StatusNoContent, ``},
+		{"parse-error", `{"jsonrpc":`, http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32700,"message":"invalid JSON"},"id":null}`},
+		{"invalid-version", `{"jsonrpc":"1.0","method":"add","id":1}`, http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32600,"message":"jsonrpc must be \"2.0\""},"id":1}`},
+		{"invalid-id", `{"jsonrpc":"2.0","method":"add","id":{}}`, http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32600,"message":"id must be a string, a number or null"},"id":null}`},
+		{"method-not-found", `{"jsonrpc":"2.0","method":"sub","id":1}`, http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32601,"message":"method not found: sub"},"id":1}`},
+		{"invalid-params", `{"jsonrpc":"2.0","method":"add","params":{"A":"a"},"id":1}`, http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32602,"message":"json: cannot unmarshal string into Go struct field .A of type int","data":{"name":"decode_payload","id":"ID"}},"id":1}`},
+		{"validation-error", `{"jsonrpc":"2.0","method":"fail","id":1}`, http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32602,"message":"\"a\" is missing from body","data":{"name":"missing_field","id":"ID"}},"id":1}`},
+		{"internal-error", `{"jsonrpc":"2.0","method":"fault","id":1}`, http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32603,"message":"boom"},"id":1}`},
+		{"coded-error", `{"jsonrpc":"2.0","method":"coded","id":1}`, http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32001,"message":"unauthorized","data":{"reason":"token"}},"id":1}`},
+		{"batch", `[{"jsonrpc":"2.0","method":"add","params":{"A":1,"B":2},"id":1},{"jsonrpc":"2.0","method":"notify"},{"jsonrpc":"2.0","method":"sub","id":2},1]`, http.StatusOK,
+			`[{"jsonrpc":"2.0","result":3,"id":1},{"jsonrpc":"2.0","error":{"code":-32601,"message":"method not found: sub"},"id":2},{"jsonrpc":"2.0","error":{"code":-32600,"message":"json: cannot unmarshal number into Go value of type jsonrpc.Request"},"id":null}]`},
+		{"batch-notifications", `[{"jsonrpc":"2.0","method":"notify"},{"jsonrpc":"2.0","method":"notify"}]`, http.StatusNoContent, ``},
+		{"empty-batch", `[]`, http.StatusOK,
+			`{"jsonrpc":"2.0","error":{"code":-32600,"message":"empty batch"},"id":null}`},
+	}
+	h := NewHandler(methods, nil)
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", strings.NewReader(c.Body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			if w.Code != c.ExpectedCode {
+				t.Errorf("got status %d, expected %d", w.Code, c.ExpectedCode)
+			}
+			if actual := replaceErrorIDs(w.Body.String()); actual != c.Expected {
+				t.Errorf("got body\n%s\nexpected\n%s", actual, c.Expected)
+			}
+		})
+	}
+	if notifyCalls != 5 {
+		t.Errorf("got %d calls to notify, expected 5", notifyCalls)
+	}
+}
+
+// replaceErrorIDs replaces the random goa error IDs in body with "ID".
+func replaceErrorIDs(body string) string {
+	const prefix = `"id":"`
+	var b strings.Builder
+	for {
+		i := strings.Index(body, `"name":"`)
+		if i < 0 {
+			break
+		}
+		j := strings.Index(body[i:], prefix)
+		if j < 0 {
+			break
+		}
+		start := i + j + len(prefix)
+		end := strings.IndexByte(body[start:], '"')
+		b.WriteString(body[:start])
+		b.WriteString("ID")
+		body = body[start+end:]
+	}
+	b.WriteString(body)
+	return b.String()
+}
@@ -0,0 +1,255 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	goahttp "goa.design/goa/v3/http"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// StreamMethodHandler handles the JSON-RPC requests made to a streaming
+	// method. params holds the raw params of the request that opened the
+	// stream. The handler uses stream to receive the streaming payloads and
+	// send the results. The returned error is converted with AsError and
+	// sent to the client.
+	StreamMethodHandler func(ctx context.Context, params json.RawMessage, stream *ServerStream) error
+
+	// WebSocketHandler is a HTTP handler that serves the JSON-RPC requests
+	// made to streaming methods. The connection is upgraded to the websocket
+	// protocol and the first message read from the connection is the
+	// JSON-RPC request that identifies the method.
+	WebSocketHandler struct {
+		methods    map[string]StreamMethodHandler
+		upgrader   goahttp.Upgrader
+		configurer goahttp.ConnConfigureFunc
+		errhandler func(context.Context, http.ResponseWriter, error)
+	}
+
+	// ServerStream is the server side of a JSON-RPC stream. The results are
+	// sent as JSON-RPC responses that use the ID of the request that opened
+	// the stream and the streaming payloads are received as JSON-RPC
+	// notifications.
+	ServerStream struct {
+		id   json.RawMessage
+		conn *websocket.Conn
+		// mu protects the connection from concurrent writes and closes.
+		mu     sync.Mutex
+		closed bool
+	}
+
+	// ClientStream is the client side of a JSON-RPC stream.
+	ClientStream struct {
+		method string
+		conn   *websocket.Conn
+		// mu protects the connection from concurrent writes and closes.
+		mu     sync.Mutex
+		closed bool
+	}
+)
+
+// NewWebSocketHandler returns a HTTP handler that dispatches the JSON-RPC
+// requests made over websocket connections to the given streaming method
+// handlers indexed by JSON-RPC method name. configurer is applied to the
+// upgraded connections if not nil. errhandler is called with the errors that
+// occur while upgrading the connection or writing to it if not nil.
+func NewWebSocketHandler(methods map[string]StreamMethodHandler, upgrader goahttp.Upgrader, configurer goahttp.ConnConfigureFunc, errhandler func(context.Context, http.ResponseWriter, error)) *WebSocketHandler {
+	return &WebSocketHandler{methods: methods, upgrader: upgrader, configurer: configurer, errhandler: errhandler}
+}
+
+// ServeHTTP upgrades the connection, reads the JSON-RPC request and invokes the
+// corresponding streaming method handler. The connection is closed when the
+// handler returns.
+func (h *WebSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.error(ctx, w, err)
+		return
+	}
+	if h.configurer != nil {
+		conn = h.configurer(conn, cancel)
+	}
+	stream := &ServerStream{conn: conn}
+	defer stream.Close()
+	var req Request
+	if err := conn.ReadJSON(&req); err != nil {
+		code := InvalidRequest
+		var serr *json.SyntaxError
+		if errors.As(err, &serr) {
+			code = ParseError
+		}
+		h.send(ctx, w, stream, &Error{Code: code, Message: err.Error()})
+		return
+	}
+	stream.id = idOrNull(req.ID)
+	if err := validateRequest(&req); err != nil {
+		h.send(ctx, w, stream, err)
+		return
+	}
+	method, ok := h.methods[req.Method]
+	if !ok {
+		h.send(ctx, w, stream, &Error{Code: MethodNotFound, Message: "method not found: " + req.Method})
+		return
+	}
+	if err := method(ctx, req.Params, stream); err != nil {
+		h.send(ctx, w, stream, AsError(err))
+	}
+}
+
+// error reports err to the error handler if any.
+func (h *WebSocketHandler) error(ctx context.Context, w http.ResponseWriter, err error) {
+	if h.errhandler != nil {
+		h.errhandler(ctx, w, err)
+	}
+}
+
+// send writes the given error to the stream, errors are reported to the error
+// handler.
+func (h *WebSocketHandler) send(ctx context.Context, w http.ResponseWriter, stream *ServerStream, jerr *Error) {
+	if err := stream.write(errorResponse(stream.id, jerr)); err != nil {
+		h.error(ctx, w, err)
+	}
+}
+
+// Send sends v as the result of a JSON-RPC response that uses the ID of the
+// request that opened the stream.
+func (s *ServerStream) Send(v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.write(&Response{JSONRPC: Version, Result: b, ID: s.id})
+}
+
+// Recv reads the next streaming payload from the params of a JSON-RPC
+// notification into v. It returns io.EOF once the client is done sending.
+func (s *ServerStream) Recv(v any) error {
+	_, msg, err := s.conn.ReadMessage()
+	if err != nil {
+		if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			return io.EOF
+		}
+		return err
+	}
+	if string(bytes.TrimSpace(msg)) == "null" {
+		// The client sends null once it is done sending.
+		return io.EOF
+	}
+	var req Request
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return goa.DecodePayloadError(err.Error())
+	}
+	if err := validateRequest(&req); err != nil {
+		return err
+	}
+	return DecodeParams(req.Params, v)
+}
+
+// Close closes the stream with a normal closure. Close can be called multiple
+// times.
+func (s *ServerStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.conn.WriteControl( // nolint: errcheck
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, "server closing connection"),
+		time.Now().Add(time.Second),
+	)
+	return s.conn.Close()
+}
+
+// write writes v to the connection unless the stream is closed.
+func (s *ServerStream) write(v any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return websocket.ErrCloseSent
+	}
+	return s.conn.WriteJSON(v)
+}
+
+// NewClientStream sends the JSON-RPC request made to the given streaming
+// method with the given ID and params over conn and returns the
+// corresponding client stream.
+func NewClientStream(conn *websocket.Conn, method string, id int64, params any) (*ClientStream, error) {
+	s := &ClientStream{method: method, conn: conn}
+	req, err := newRequest(method, id, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.write(req); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Send sends v as the params of a JSON-RPC notification.
+func (s *ClientStream) Send(v any) error {
+	req, err := newRequest(s.method, 0, v)
+	if err != nil {
+		return err
+	}
+	return s.write(req)
+}
+
+// Recv reads the next result into v. It returns a *Error if the server
+// replied with an error and io.EOF once the server closed the stream.
+func (s *ClientStream) Recv(v any) error {
+	var res Response
+	if err := s.conn.ReadJSON(&res); err != nil {
+		if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			return io.EOF
+		}
+		return err
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	return json.Unmarshal(res.Result, v)
+}
+
+// CloseSend notifies the server that the client is done sending.
+func (s *ClientStream) CloseSend() error {
+	return s.write(nil)
+}
+
+// Close closes the stream with a normal closure. Close can be called multiple
+// times.
+func (s *ClientStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.conn.WriteControl( // nolint: errcheck
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, "client closing connection"),
+		time.Now().Add(time.Second),
+	)
+	return s.conn.Close()
+}
+
+// write writes v to the connection unless the stream is closed.
+func (s *ClientStream) write(v any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return websocket.ErrCloseSent
+	}
+	return s.conn.WriteJSON(v)
+}
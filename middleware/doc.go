@@ -6,5 +6,9 @@ apply additional transformations prior to and after calling the original. The
 middlewares included in this package include a logger middleware to log incoming
 requests, a request ID middleware that makes sure every request as a unique ID
 stored in the context and a couple of middlewares used to implement tracing.
+
+The Logger interface may be backed by a stdlib logger (NewLogger) or a log/slog
+logger (NewSlogLogger). The SlogOptions configure the log/slog based HTTP and
+gRPC middlewares: levels, redacted headers and body fields and body logging.
 */
 package middleware
@@ -0,0 +1,187 @@
+// Package metrics contains a registry of Prometheus compatible metrics, the
+// options shared by the transport-specific metrics middlewares and an
+// endpoint middleware that labels the metrics recorded by the server
+// middlewares with the Goa service and method.
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// Option is a constructor option that makes it possible to customize
+	// the middleware.
+	Option func(*Options) *Options
+
+	// Options is the struct storing all the options for the metrics
+	// middlewares.
+	Options struct {
+		registry        *Registry
+		namespace       string
+		durationBuckets []float64
+		sizeBuckets     []float64
+	}
+
+	// Instruments groups the metrics recorded by a transport middleware.
+	Instruments struct {
+		// Requests counts the requests. Its labels are the instruments
+		// labels followed by "code".
+		Requests *Counter
+		// Duration records the request durations in seconds. Its labels
+		// are the instruments labels followed by "code".
+		Duration *Histogram
+		// RequestSize records the request payload sizes in bytes.
+		RequestSize *Histogram
+		// ResponseSize records the response payload sizes in bytes.
+		ResponseSize *Histogram
+		// InFlight records the number of requests being handled.
+		InFlight *Gauge
+	}
+
+	// Labels holds the Goa service and method names of the request being
+	// handled. The server middlewares store Labels in the request context
+	// and the Endpoint middleware sets them.
+	Labels struct {
+		mu      sync.Mutex
+		service string
+		method  string
+		onSet   func(service, method string)
+	}
+
+	// labelsKey is the private type used to store Labels in the context.
+	labelsKey struct{}
+)
+
+// NewOptions returns the metrics middleware options by running the given
+// constructors. The options default to DefaultRegistry, no namespace,
+// DefaultDurationBuckets and DefaultSizeBuckets.
+func NewOptions(opts ...Option) *Options {
+	o := &Options{
+		registry:        DefaultRegistry,
+		durationBuckets: DefaultDurationBuckets,
+		sizeBuckets:     DefaultSizeBuckets,
+	}
+	for _, opt := range opts {
+		o = opt(o)
+	}
+	return o
+}
+
+// Registry returns the registry the metrics are recorded in.
+func (o *Options) Registry() *Registry {
+	return o.registry
+}
+
+// Instruments returns the instruments recorded by the middleware of the
+// given transport ("http" or "grpc") and side ("server" or "client"). The
+// metric names are of the form [namespace_]transport_side_name.
+func (o *Options) Instruments(transport, side string, labels ...string) *Instruments {
+	prefix := transport + "_" + side + "_"
+	if o.namespace != "" {
+		prefix = o.namespace + "_" + prefix
+	}
+	withCode := append(append([]string(nil), labels...), "code")
+	return &Instruments{
+		Requests:     o.registry.Counter(prefix+"requests_total", "Total number of "+transport+" "+side+" requests.", withCode...),
+		Duration:     o.registry.Histogram(prefix+"request_duration_seconds", "Duration of "+transport+" "+side+" requests in seconds.", o.durationBuckets, withCode...),
+		RequestSize:  o.registry.Histogram(prefix+"request_size_bytes", "Size of "+transport+" "+side+" request payloads in bytes.", o.sizeBuckets, labels...),
+		ResponseSize: o.registry.Histogram(prefix+"response_size_bytes", "Size of "+transport+" "+side+" response payloads in bytes.", o.sizeBuckets, labels...),
+		InFlight:     o.registry.Gauge(prefix+"requests_in_flight", "Number of "+transport+" "+side+" requests being handled.", labels...),
+	}
+}
+
+// WithRegistry sets the registry the metrics are recorded in. Defaults to
+// DefaultRegistry.
+func WithRegistry(r *Registry) Option {
+	return func(o *Options) *Options {
+		o.registry = r
+		return o
+	}
+}
+
+// WithNamespace sets the prefix of the metric names, e.g. "calc" produces
+// "calc_http_server_requests_total".
+func WithNamespace(ns string) Option {
+	return func(o *Options) *Options {
+		o.namespace = ns
+		return o
+	}
+}
+
+// WithDurationBuckets sets the upper bounds in seconds of the request
+// duration histogram buckets. Defaults to DefaultDurationBuckets.
+func WithDurationBuckets(buckets ...float64) Option {
+	return func(o *Options) *Options {
+		o.durationBuckets = buckets
+		return o
+	}
+}
+
+// WithSizeBuckets sets the upper bounds in bytes of the payload size
+// histogram buckets. Defaults to DefaultSizeBuckets.
+func WithSizeBuckets(buckets ...float64) Option {
+	return func(o *Options) *Options {
+		o.sizeBuckets = buckets
+		return o
+	}
+}
+
+// ContextWithLabels returns a context that stores empty labels. onSet is
+// called when the Endpoint middleware sets the labels, it may be nil.
+func ContextWithLabels(ctx context.Context, onSet func(service, method string)) (context.Context, *Labels) {
+	l := &Labels{onSet: onSet}
+	return context.WithValue(ctx, labelsKey{}, l), l
+}
+
+// Get returns the service and method names set by the Endpoint middleware,
+// empty strings if the middleware did not run.
+func (l *Labels) Get() (service, method string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.service, l.method
+}
+
+// set sets the labels the first time it is called. onSet is called before
+// the new labels are visible to Get.
+func (l *Labels) set(service, method string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.service != "" || l.method != "" {
+		return
+	}
+	if l.onSet != nil {
+		l.onSet(service, method)
+	}
+	l.service, l.method = service, method
+}
+
+// ServiceMethod returns the Goa service and method names stored in the
+// context under the goa.ServiceKey and goa.MethodKey keys.
+func ServiceMethod(ctx context.Context) (service, method string) {
+	service, _ = ctx.Value(goa.ServiceKey).(string)
+	method, _ = ctx.Value(goa.MethodKey).(string)
+	return
+}
+
+// Endpoint returns an endpoint middleware that sets the labels stored in the
+// context by the HTTP or gRPC server metrics middleware to the Goa service
+// and method handling the request. The server middlewares record the number
+// of requests in flight only when the endpoints use this middleware.
+//
+// Example:
+//
+//	endpoints := genservice.NewEndpoints(svc)
+//	endpoints.Use(metrics.Endpoint())
+func Endpoint() func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			if l, ok := ctx.Value(labelsKey{}).(*Labels); ok {
+				l.set(ServiceMethod(ctx))
+			}
+			return e(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestInstruments(t *testing.T) {
+	r := NewRegistry()
+	ins := NewOptions(WithRegistry(r), WithNamespace("calc"), WithDurationBuckets(1)).Instruments("http", "server", "service")
+	ins.Requests.Inc("Calc", "200")
+	ins.Duration.Observe(0.5, "Calc", "200")
+	ins.RequestSize.Observe(10, "Calc")
+	ins.ResponseSize.Observe(20, "Calc")
+	ins.InFlight.Set(0, "Calc")
+
+	var sb strings.Builder
+	r.WriteText(&sb) // nolint: errcheck
+	got := sb.String()
+	for _, expected := range []string{
+		`calc_http_server_requests_total{service="Calc",code="200"} 1`,
+		`calc_http_server_request_duration_seconds_bucket{service="Calc",code="200",le="1"} 1`,
+		`calc_http_server_request_size_bytes_sum{service="Calc"} 10`,
+		`calc_http_server_response_size_bytes_sum{service="Calc"} 20`,
+		`calc_http_server_requests_in_flight{service="Calc"} 0`,
+	} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("metric %q not found in:\n%s", expected, got)
+		}
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	var calls int
+	ctx, labels := ContextWithLabels(context.Background(), func(svc, meth string) { calls++ })
+	ctx = context.WithValue(ctx, goa.ServiceKey, "Calc")
+	ctx = context.WithValue(ctx, goa.MethodKey, "Add")
+	e := Endpoint()(func(ctx context.Context, req any) (any, error) { return nil, nil })
+
+	if _, err := e(ctx, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := e(context.WithValue(ctx, goa.MethodKey, "Other"), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svc, meth := labels.Get()
+	if svc != "Calc" || meth != "Add" {
+		t.Errorf("got labels %q %q, expected %q %q", svc, meth, "Calc", "Add")
+	}
+	if calls != 1 {
+		t.Errorf("got %d onSet calls, expected 1", calls)
+	}
+}
@@ -0,0 +1,307 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// Registry stores metric families and writes them using the Prometheus
+	// text exposition format.
+	Registry struct {
+		mu       sync.Mutex
+		families map[string]*family
+	}
+
+	// Counter is a metric family whose values only go up.
+	Counter struct {
+		f *family
+	}
+
+	// Gauge is a metric family whose values go up and down.
+	Gauge struct {
+		f *family
+	}
+
+	// Histogram is a metric family that counts observations in buckets.
+	Histogram struct {
+		f *family
+	}
+
+	// family is a metric and all its labeled series.
+	family struct {
+		mu      sync.Mutex
+		name    string
+		help    string
+		kind    string
+		labels  []string
+		buckets []float64
+		series  map[string]*series
+	}
+
+	// series holds the value of a metric for a set of label values.
+	series struct {
+		values []string
+		value  float64
+		counts []uint64
+		sum    float64
+		count  uint64
+	}
+)
+
+const (
+	// ContentType is the content type of the Prometheus text exposition
+	// format written by WriteText.
+	ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+	kindCounter   = "counter"
+	kindGauge     = "gauge"
+	kindHistogram = "histogram"
+)
+
+var (
+	// DefaultRegistry is the registry used by the middlewares unless
+	// WithRegistry is used.
+	DefaultRegistry = NewRegistry()
+
+	// DefaultDurationBuckets are the default histogram buckets used to
+	// record request durations in seconds.
+	DefaultDurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+	// DefaultSizeBuckets are the default histogram buckets used to record
+	// payload sizes in bytes.
+	DefaultSizeBuckets = []float64{100, 1000, 10000, 100000, 1e6, 1e7, 1e8}
+)
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+// Counter returns the counter with the given name and labels, registering it
+// if needed. Counter panics if a metric with the same name but a different
+// type or labels is already registered.
+func (r *Registry) Counter(name, help string, labels ...string) *Counter {
+	return &Counter{r.register(name, help, kindCounter, nil, labels)}
+}
+
+// Gauge returns the gauge with the given name and labels, registering it if
+// needed. Gauge panics if a metric with the same name but a different type or
+// labels is already registered.
+func (r *Registry) Gauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{r.register(name, help, kindGauge, nil, labels)}
+}
+
+// Histogram returns the histogram with the given name, bucket upper bounds
+// and labels, registering it if needed. Histogram panics if a metric with the
+// same name but a different type or labels is already registered.
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	b := append([]float64(nil), buckets...)
+	sort.Float64s(b)
+	return &Histogram{r.register(name, help, kindHistogram, b, labels)}
+}
+
+// WriteText writes all the metrics of the registry to w using the Prometheus
+// text exposition format. The metrics are sorted by name and label values.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	families := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mu.Unlock()
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(bw)
+	}
+	return bw.Flush()
+}
+
+// Add adds v to the counter with the given label values. v must not be
+// negative.
+func (c *Counter) Add(v float64, values ...string) {
+	if v < 0 {
+		panic(fmt.Sprintf("metrics: counter %s cannot decrease", c.f.name))
+	}
+	c.f.update(values, func(s *series) { s.value += v })
+}
+
+// Inc increments the counter with the given label values.
+func (c *Counter) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Set sets the gauge with the given label values to v.
+func (g *Gauge) Set(v float64, values ...string) {
+	g.f.update(values, func(s *series) { s.value = v })
+}
+
+// Add adds v to the gauge with the given label values.
+func (g *Gauge) Add(v float64, values ...string) {
+	g.f.update(values, func(s *series) { s.value += v })
+}
+
+// Inc increments the gauge with the given label values.
+func (g *Gauge) Inc(values ...string) {
+	g.Add(1, values...)
+}
+
+// Dec decrements the gauge with the given label values.
+func (g *Gauge) Dec(values ...string) {
+	g.Add(-1, values...)
+}
+
+// Observe records v in the histogram with the given label values.
+func (h *Histogram) Observe(v float64, values ...string) {
+	i := sort.SearchFloat64s(h.f.buckets, v)
+	h.f.update(values, func(s *series) {
+		if i < len(s.counts) {
+			s.counts[i]++
+		}
+		s.sum += v
+		s.count++
+	})
+}
+
+// register returns the family with the given name, creating it if needed.
+func (r *Registry) register(name, help, kind string, buckets []float64, labels []string) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if f, ok := r.families[name]; ok {
+		if f.kind != kind || strings.Join(f.labels, ",") != strings.Join(labels, ",") {
+			panic(fmt.Sprintf("metrics: %s %s is already registered with a different type or labels", kind, name))
+		}
+		return f
+	}
+	f := &family{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		labels:  append([]string(nil), labels...),
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+	r.families[name] = f
+	return f
+}
+
+// update calls fn with the series identified by the given label values.
+func (f *family) update(values []string, fn func(*series)) {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", f.name, len(f.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{values: append([]string(nil), values...)}
+		if f.kind == kindHistogram {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	fn(s)
+}
+
+// write writes the family to w.
+func (f *family) write(w *bufio.Writer) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.series) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(f.series))
+	for k := range f.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if f.help != "" {
+		fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	}
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.kind)
+	for _, k := range keys {
+		s := f.series[k]
+		if f.kind != kindHistogram {
+			fmt.Fprintf(w, "%s%s %s\n", f.name, f.labelPairs(s.values, "", 0), formatFloat(s.value))
+			continue
+		}
+		var cumul uint64
+		for i, b := range f.buckets {
+			cumul += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, f.labelPairs(s.values, "le", b), cumul)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, f.labelPairs(s.values, "le", math.Inf(1)), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", f.name, f.labelPairs(s.values, "", 0), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", f.name, f.labelPairs(s.values, "", 0), s.count)
+	}
+}
+
+// labelPairs returns the label pairs of a sample. extra is the name of an
+// additional label whose value is extraValue (e.g. the "le" label of the
+// histogram buckets) if not empty.
+func (f *family) labelPairs(values []string, extra string, extraValue float64) string {
+	if len(values) == 0 && extra == "" {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, l := range f.labels {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(l)
+		sb.WriteString(`="`)
+		sb.WriteString(escapeLabelValue(values[i]))
+		sb.WriteByte('"')
+	}
+	if extra != "" {
+		if len(f.labels) > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(extra)
+		sb.WriteString(`="`)
+		sb.WriteString(formatFloat(extraValue))
+		sb.WriteByte('"')
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// formatFloat formats v as required by the text exposition format.
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpReplacer       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+// escapeHelp escapes the backslashes and line feeds of a help string.
+func escapeHelp(s string) string {
+	return helpReplacer.Replace(s)
+}
+
+// escapeLabelValue escapes the backslashes, double quotes and line feeds of
+// a label value.
+func escapeLabelValue(s string) string {
+	return labelValueReplacer.Replace(s)
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	r := NewRegistry()
+	c := r.Counter("requests_total", "Total number of requests.", "method", "code")
+	c.Inc("Get", "200")
+	c.Add(2, "Get", "200")
+	c.Inc("Create", "400")
+	g := r.Gauge("in_flight", "Requests in\nflight \\ now.")
+	g.Inc()
+	g.Inc()
+	g.Dec()
+	h := r.Histogram("duration_seconds", "", []float64{1, 0.1}, "path")
+	h.Observe(0.05, `/a"b`)
+	h.Observe(0.5, `/a"b`)
+	h.Observe(2, `/a"b`)
+	r.Counter("unused_total", "Not recorded.")
+
+	var sb strings.Builder
+	if err := r.WriteText(&sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `# TYPE duration_seconds histogram
+duration_seconds_bucket{path="/a\"b",le="0.1"} 1
+duration_seconds_bucket{path="/a\"b",le="1"} 2
+duration_seconds_bucket{path="/a\"b",le="+Inf"} 3
+duration_seconds_sum{path="/a\"b"} 2.55
+duration_seconds_count{path="/a\"b"} 3
+# HELP in_flight Requests in\nflight \\ now.
+# TYPE in_flight gauge
+in_flight 1
+# HELP requests_total Total number of requests.
+# TYPE requests_total counter
+requests_total{method="Create",code="400"} 1
+requests_total{method="Get",code="200"} 3
+`
+	if got := sb.String(); got != expected {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, expected)
+	}
+}
+
+func TestRegisterSameMetric(t *testing.T) {
+	r := NewRegistry()
+	c1 := r.Counter("requests_total", "", "code")
+	c2 := r.Counter("requests_total", "", "code")
+	c1.Inc("200")
+	c2.Inc("200")
+	var sb strings.Builder
+	r.WriteText(&sb) // nolint: errcheck
+	if !strings.Contains(sb.String(), `requests_total{code="200"} 2`) {
+		t.Errorf("got %q, expected counter shared by both registrations", sb.String())
+	}
+}
+
+func TestRegistryPanics(t *testing.T) {
+	cases := []struct {
+		Name string
+		Fn   func(r *Registry)
+	}{
+		{"kind-mismatch", func(r *Registry) { r.Counter("m", ""); r.Gauge("m", "") }},
+		{"labels-mismatch", func(r *Registry) { r.Counter("m", "", "a"); r.Counter("m", "", "b") }},
+		{"label-count", func(r *Registry) { r.Counter("m", "", "a").Inc() }},
+		{"negative-counter", func(r *Registry) { r.Counter("m", "").Add(-1) }},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			c.Fn(NewRegistry())
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Package otel contains the OpenTelemetry options and helpers shared by the
+// transport-specific OpenTelemetry middlewares as well as an endpoint middleware
+// that names the current span after the Goa service and method.
+package otel
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// Option is a constructor option that makes it possible to customize
+	// the middleware.
+	Option func(*Options) *Options
+
+	// Options is the struct storing all the options for the OpenTelemetry
+	// middlewares.
+	Options struct {
+		tracerProvider trace.TracerProvider
+		meterProvider  metric.MeterProvider
+		propagator     propagation.TextMapPropagator
+	}
+)
+
+const (
+	// InstrumentationName is the name of the instrumentation library used to
+	// create the tracers and meters.
+	InstrumentationName = "goa.design/goa/v3"
+
+	// ServiceAttributeKey is the span attribute key used to record the name
+	// of the Goa service.
+	ServiceAttributeKey = attribute.Key("goa.service")
+
+	// MethodAttributeKey is the span attribute key used to record the name
+	// of the Goa method.
+	MethodAttributeKey = attribute.Key("goa.method")
+
+	// ErrorNameAttributeKey is the span attribute key used to record the
+	// name of the Goa service error returned by the method if any.
+	ErrorNameAttributeKey = attribute.Key("goa.error.name")
+)
+
+// NewOptions returns the OpenTelemetry middleware options by running the given
+// constructors. The options default to the global tracer and meter providers
+// and to the W3C trace context and baggage propagators.
+func NewOptions(opts ...Option) *Options {
+	o := &Options{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	}
+	for _, opt := range opts {
+		o = opt(o)
+	}
+	return o
+}
+
+// Tracer returns the tracer used to create spans.
+func (o *Options) Tracer() trace.Tracer {
+	return o.tracerProvider.Tracer(InstrumentationName)
+}
+
+// Meter returns the meter used to create metric instruments.
+func (o *Options) Meter() metric.Meter {
+	return o.meterProvider.Meter(InstrumentationName)
+}
+
+// Propagator returns the propagator used to extract and inject the trace
+// context from and into requests.
+func (o *Options) Propagator() propagation.TextMapPropagator {
+	return o.propagator
+}
+
+// Histogram creates a histogram with the given name, unit and description
+// using the options meter. Errors are reported to the global OpenTelemetry
+// error handler and result in a no-op histogram being returned.
+func (o *Options) Histogram(name, unit, desc string) metric.Float64Histogram {
+	h, err := o.Meter().Float64Histogram(name, metric.WithUnit(unit), metric.WithDescription(desc))
+	if err != nil {
+		otel.Handle(err)
+	}
+	return h
+}
+
+// WithTracerProvider sets the tracer provider used to create spans. Defaults
+// to the global tracer provider.
+func WithTracerProvider(p trace.TracerProvider) Option {
+	return func(o *Options) *Options {
+		o.tracerProvider = p
+		return o
+	}
+}
+
+// WithMeterProvider sets the meter provider used to create the metric
+// instruments. Defaults to the global meter provider.
+func WithMeterProvider(p metric.MeterProvider) Option {
+	return func(o *Options) *Options {
+		o.meterProvider = p
+		return o
+	}
+}
+
+// WithPropagator sets the propagator used to extract and inject the trace
+// context. Defaults to the W3C trace context and baggage propagators.
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(o *Options) *Options {
+		o.propagator = p
+		return o
+	}
+}
+
+// SpanName returns the name of the span for the Goa service and method stored
+// in the context under the goa.ServiceKey and goa.MethodKey keys, that is
+// "service/method". It returns an empty string if the context does not
+// contain the service and method names.
+func SpanName(ctx context.Context) string {
+	svc, _ := ctx.Value(goa.ServiceKey).(string)
+	meth, _ := ctx.Value(goa.MethodKey).(string)
+	if svc == "" || meth == "" {
+		return ""
+	}
+	return svc + "/" + meth
+}
+
+// Endpoint returns an endpoint middleware that names the current span after
+// the Goa service and method and records the error returned by the endpoint
+// if any. The span is the one created by the HTTP or gRPC OpenTelemetry
+// server middleware. Endpoint creates a new span if the context does not
+// contain a recording span already.
+//
+// Example:
+//
+//	endpoints := genservice.NewEndpoints(svc)
+//	endpoints.Use(otel.Endpoint())
+func Endpoint(opts ...Option) func(goa.Endpoint) goa.Endpoint {
+	o := NewOptions(opts...)
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			span := trace.SpanFromContext(ctx)
+			if name := SpanName(ctx); name != "" {
+				if span.IsRecording() {
+					span.SetName(name)
+				} else {
+					ctx, span = o.Tracer().Start(ctx, name)
+					defer span.End()
+				}
+				span.SetAttributes(
+					ServiceAttributeKey.String(ctx.Value(goa.ServiceKey).(string)),
+					MethodAttributeKey.String(ctx.Value(goa.MethodKey).(string)),
+				)
+			}
+			res, err := e(ctx, req)
+			if err != nil {
+				RecordError(span, err)
+			}
+			return res, err
+		}
+	}
+}
+
+// RecordError records err on span. Goa service errors that are not faults
+// (e.g. validation errors) are recorded without setting the span status to
+// error.
+func RecordError(span trace.Span, err error) {
+	span.RecordError(err)
+	var serr *goa.ServiceError
+	if errors.As(err, &serr) {
+		span.SetAttributes(ErrorNameAttributeKey.String(serr.Name))
+		if !serr.Fault {
+			return
+		}
+	}
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,98 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestSpanName(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Service  string
+		Method   string
+		Expected string
+	}{
+		{"no-keys", "", "", ""},
+		{"service-only", "svc", "", ""},
+		{"service-and-method", "svc", "meth", "svc/meth"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.Service != "" {
+				ctx = context.WithValue(ctx, goa.ServiceKey, c.Service)
+			}
+			if c.Method != "" {
+				ctx = context.WithValue(ctx, goa.MethodKey, c.Method)
+			}
+			if actual := SpanName(ctx); actual != c.Expected {
+				t.Errorf("got span name %q, expected %q", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	cases := []struct {
+		Name       string
+		ParentSpan bool
+		Error      error
+		StatusCode codes.Code
+		ErrorName  string
+	}{
+		{"new-span", false, nil, codes.Unset, ""},
+		{"existing-span", true, nil, codes.Unset, ""},
+		{"error", true, errors.New("error"), codes.Error, ""},
+		{"fault", true, goa.Fault("fault"), codes.Error, "fault"},
+		{"service-error", true, goa.PermanentError("invalid", "invalid"), codes.Unset, "invalid"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			ctx := context.WithValue(context.Background(), goa.ServiceKey, "svc")
+			ctx = context.WithValue(ctx, goa.MethodKey, "meth")
+			var parent trace.Span
+			if c.ParentSpan {
+				ctx, parent = tp.Tracer("test").Start(ctx, "parent")
+			}
+			e := Endpoint(WithTracerProvider(tp))(func(context.Context, any) (any, error) { return nil, c.Error })
+			if _, err := e(ctx, nil); err != c.Error {
+				t.Fatalf("got error %v, expected %v", err, c.Error)
+			}
+			if parent != nil {
+				parent.End()
+			}
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, expected 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name != "svc/meth" {
+				t.Errorf("got span name %q, expected %q", span.Name, "svc/meth")
+			}
+			if span.Status.Code != c.StatusCode {
+				t.Errorf("got status %v, expected %v", span.Status.Code, c.StatusCode)
+			}
+			attrs := attribute.NewSet(span.Attributes...)
+			if v, _ := attrs.Value(ServiceAttributeKey); v.AsString() != "svc" {
+				t.Errorf("got service attribute %q, expected %q", v.AsString(), "svc")
+			}
+			if v, _ := attrs.Value(ErrorNameAttributeKey); v.AsString() != c.ErrorName {
+				t.Errorf("got error name attribute %q, expected %q", v.AsString(), c.ErrorName)
+			}
+			if c.Error != nil && len(span.Events) != 1 {
+				t.Errorf("got %d events, expected 1", len(span.Events))
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+//go:build go1.21
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// SlogOption is a constructor option that makes it possible to customize
+	// the log/slog based middlewares.
+	SlogOption func(*SlogOptions) *SlogOptions
+
+	// SlogOptions is the struct storing all the options for the log/slog
+	// based middlewares.
+	SlogOptions struct {
+		level       slog.Level
+		errorLevel  slog.Level
+		bodyLevel   slog.Level
+		maxBodySize int
+		headers     map[string]struct{}
+		fields      map[string]struct{}
+	}
+
+	// LogAttrs holds attributes added to the entry logged by the log/slog
+	// based middlewares once the request completes.
+	LogAttrs struct {
+		mu    sync.Mutex
+		attrs []slog.Attr
+	}
+
+	// slogAdapter adapts a slog logger to the Logger interface.
+	slogAdapter struct {
+		logger *slog.Logger
+		level  slog.Level
+	}
+
+	// logAttrsKey is the private type used to store LogAttrs in the
+	// context.
+	logAttrsKey struct{}
+)
+
+// Redacted is the value logged in place of redacted headers and fields.
+const Redacted = "[REDACTED]"
+
+// NewSlogLogger creates a Logger backed by a log/slog logger. The entries are
+// logged at the level set with WithLogLevel, the value of the "msg" key if any
+// is used as message.
+func NewSlogLogger(l *slog.Logger, opts ...SlogOption) Logger {
+	return &slogAdapter{logger: l, level: NewSlogOptions(opts...).Level()}
+}
+
+// NewSlogOptions returns the log/slog middleware options by running the given
+// constructors. By default requests are logged at the Info level, server
+// errors at the Error level and request and response bodies at the Debug
+// level, bodies are truncated to 4KB and nothing is redacted.
+func NewSlogOptions(opts ...SlogOption) *SlogOptions {
+	o := &SlogOptions{
+		level:       slog.LevelInfo,
+		errorLevel:  slog.LevelError,
+		bodyLevel:   slog.LevelDebug,
+		maxBodySize: 4096,
+		headers:     make(map[string]struct{}),
+		fields:      make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		o = opt(o)
+	}
+	return o
+}
+
+// Level returns the level of the request entries.
+func (o *SlogOptions) Level() slog.Level {
+	return o.level
+}
+
+// ErrorLevel returns the level of the entries of requests that failed with a
+// server error.
+func (o *SlogOptions) ErrorLevel() slog.Level {
+	return o.errorLevel
+}
+
+// BodyLevel returns the level of the entries that contain the request and
+// response headers and bodies.
+func (o *SlogOptions) BodyLevel() slog.Level {
+	return o.bodyLevel
+}
+
+// MaxBodySize returns the maximum number of body bytes logged.
+func (o *SlogOptions) MaxBodySize() int {
+	return o.maxBodySize
+}
+
+// ContextAttrs returns the request ID and trace attributes stored in ctx by
+// the RequestID and trace middlewares.
+func (o *SlogOptions) ContextAttrs(ctx context.Context) []slog.Attr {
+	var attrs []slog.Attr
+	for _, kv := range []struct{ name, key string }{
+		{"id", RequestIDKey},
+		{"trace_id", TraceIDKey},
+		{"span_id", TraceSpanIDKey},
+	} {
+		if v, ok := ctx.Value(kv.key).(string); ok && v != "" {
+			attrs = append(attrs, slog.String(kv.name, v))
+		}
+	}
+	return attrs
+}
+
+// HeadersAttr returns a group attribute with the given headers. The values of
+// the headers configured with WithRedactedHeaders are replaced with Redacted.
+func (o *SlogOptions) HeadersAttr(key string, headers map[string][]string) slog.Attr {
+	attrs := make([]any, 0, len(headers))
+	for name, vals := range headers {
+		v := strings.Join(vals, ", ")
+		if _, ok := o.headers[strings.ToLower(name)]; ok {
+			v = Redacted
+		}
+		attrs = append(attrs, slog.String(name, v))
+	}
+	return slog.Group(key, attrs...)
+}
+
+// BodyAttr returns an attribute with the given body truncated to MaxBodySize.
+// If body is a JSON document the values of the fields configured with
+// WithRedactedFields are replaced with Redacted. Bodies that look like JSON
+// but cannot be parsed (e.g. because they were truncated) are replaced with
+// Redacted altogether when fields are redacted.
+func (o *SlogOptions) BodyAttr(key string, body []byte) slog.Attr {
+	if len(o.fields) > 0 {
+		var v any
+		if err := json.Unmarshal(body, &v); err == nil {
+			if b, err := json.Marshal(o.redact(v)); err == nil {
+				body = b
+			}
+		} else if t := bytes.TrimSpace(body); len(t) > 0 && (t[0] == '{' || t[0] == '[') {
+			return slog.String(key, Redacted)
+		}
+	}
+	if o.maxBodySize >= 0 && len(body) > o.maxBodySize {
+		return slog.String(key, string(body[:o.maxBodySize])+"...")
+	}
+	return slog.String(key, string(body))
+}
+
+// redact replaces the values of the redacted fields found in v.
+func (o *SlogOptions) redact(v any) any {
+	switch actual := v.(type) {
+	case map[string]any:
+		for k, val := range actual {
+			if _, ok := o.fields[strings.ToLower(k)]; ok {
+				actual[k] = Redacted
+				continue
+			}
+			actual[k] = o.redact(val)
+		}
+	case []any:
+		for i, val := range actual {
+			actual[i] = o.redact(val)
+		}
+	}
+	return v
+}
+
+// WithLogLevel sets the level of the request entries. Defaults to Info.
+func WithLogLevel(l slog.Level) SlogOption {
+	return func(o *SlogOptions) *SlogOptions {
+		o.level = l
+		return o
+	}
+}
+
+// WithErrorLogLevel sets the level of the entries of requests that fail with
+// a server error. Defaults to Error.
+func WithErrorLogLevel(l slog.Level) SlogOption {
+	return func(o *SlogOptions) *SlogOptions {
+		o.errorLevel = l
+		return o
+	}
+}
+
+// WithBodyLogLevel sets the level of the entries that contain the request and
+// response headers and bodies. Defaults to Debug.
+func WithBodyLogLevel(l slog.Level) SlogOption {
+	return func(o *SlogOptions) *SlogOptions {
+		o.bodyLevel = l
+		return o
+	}
+}
+
+// WithMaxLogBodySize sets the maximum number of body bytes logged, a negative
+// value disables truncation. Defaults to 4096.
+func WithMaxLogBodySize(n int) SlogOption {
+	return func(o *SlogOptions) *SlogOptions {
+		o.maxBodySize = n
+		return o
+	}
+}
+
+// WithRedactedHeaders sets the names of the HTTP headers or gRPC metadata
+// keys whose values are redacted, e.g. "Authorization". The names are case
+// insensitive.
+func WithRedactedHeaders(names ...string) SlogOption {
+	return func(o *SlogOptions) *SlogOptions {
+		for _, n := range names {
+			o.headers[strings.ToLower(n)] = struct{}{}
+		}
+		return o
+	}
+}
+
+// WithRedactedFields sets the names of the JSON body fields whose values are
+// redacted at any depth, e.g. "password". The names are case insensitive.
+func WithRedactedFields(names ...string) SlogOption {
+	return func(o *SlogOptions) *SlogOptions {
+		for _, n := range names {
+			o.fields[strings.ToLower(n)] = struct{}{}
+		}
+		return o
+	}
+}
+
+// ContextWithLogAttrs returns a context that stores empty log attributes. The
+// log/slog based middlewares call it before handling the request and log the
+// attributes once the request completes.
+func ContextWithLogAttrs(ctx context.Context) (context.Context, *LogAttrs) {
+	la := &LogAttrs{}
+	return context.WithValue(ctx, logAttrsKey{}, la), la
+}
+
+// AddLogAttrs adds attributes to the entry logged by the log/slog based
+// middleware once the request completes. It does nothing if ctx was not
+// created by the middleware.
+func AddLogAttrs(ctx context.Context, attrs ...slog.Attr) {
+	if la, ok := ctx.Value(logAttrsKey{}).(*LogAttrs); ok {
+		la.mu.Lock()
+		defer la.mu.Unlock()
+		la.attrs = append(la.attrs, attrs...)
+	}
+}
+
+// Attrs returns the attributes added with AddLogAttrs.
+func (la *LogAttrs) Attrs() []slog.Attr {
+	la.mu.Lock()
+	defer la.mu.Unlock()
+	return append([]slog.Attr(nil), la.attrs...)
+}
+
+// SlogEndpoint returns an endpoint middleware that adds the Goa service and
+// method names to the entries logged by the log/slog based HTTP and gRPC
+// middlewares.
+//
+// Example:
+//
+//	endpoints := genservice.NewEndpoints(svc)
+//	endpoints.Use(middleware.SlogEndpoint())
+func SlogEndpoint() func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			svc, _ := ctx.Value(goa.ServiceKey).(string)
+			meth, _ := ctx.Value(goa.MethodKey).(string)
+			AddLogAttrs(ctx, slog.String("service", svc), slog.String("method", meth))
+			return e(ctx, req)
+		}
+	}
+}
+
+func (a *slogAdapter) Log(keyvals ...any) error {
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, "MISSING")
+	}
+	var msg string
+	args := make([]any, 0, len(keyvals))
+	for i := 0; i < len(keyvals); i += 2 {
+		if k, ok := keyvals[i].(string); ok && k == "msg" {
+			msg, _ = keyvals[i+1].(string)
+			continue
+		}
+		args = append(args, keyvals[i], keyvals[i+1])
+	}
+	a.logger.Log(context.Background(), a.level, msg, args...)
+	return nil
+}
@@ -0,0 +1,93 @@
+//go:build go1.21
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestNewSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)), WithLogLevel(slog.LevelWarn))
+
+	logger.Log("id", "abc", "msg", "started", "odd") // nolint: errcheck
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid log entry %q: %v", buf.String(), err)
+	}
+	expected := map[string]any{"level": "WARN", "msg": "started", "id": "abc", "odd": "MISSING"}
+	for k, v := range expected {
+		if entry[k] != v {
+			t.Errorf("got %s=%v, expected %v", k, entry[k], v)
+		}
+	}
+}
+
+func TestSlogOptionsBodyAttr(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Options  []SlogOption
+		Body     string
+		Expected string
+	}{
+		{"no-redaction", nil, `{"password":"secret"}`, `{"password":"secret"}`},
+		{"redacted", []SlogOption{WithRedactedFields("Password")}, `{"user":{"password":"secret"},"items":[{"password":"x"}]}`, `{"items":[{"password":"[REDACTED]"}],"user":{"password":"[REDACTED]"}}`},
+		{"not-json", []SlogOption{WithRedactedFields("password")}, `password=secret`, `password=secret`},
+		{"invalid-json", []SlogOption{WithRedactedFields("password")}, `{"password":"sec`, Redacted},
+		{"truncated", []SlogOption{WithMaxLogBodySize(4)}, `abcdefgh`, `abcd...`},
+		{"no-truncation", []SlogOption{WithMaxLogBodySize(-1)}, `abcdefgh`, `abcdefgh`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			attr := NewSlogOptions(c.Options...).BodyAttr("body", []byte(c.Body))
+			if got := attr.Value.String(); got != c.Expected {
+				t.Errorf("got %s, expected %s", got, c.Expected)
+			}
+		})
+	}
+}
+
+func TestSlogOptionsHeadersAttr(t *testing.T) {
+	attr := NewSlogOptions(WithRedactedHeaders("authorization")).HeadersAttr("headers", map[string][]string{
+		"Authorization": {"Bearer token"},
+		"Accept":        {"a", "b"},
+	})
+	got := make(map[string]string)
+	for _, a := range attr.Value.Group() {
+		got[a.Key] = a.Value.String()
+	}
+	if got["Authorization"] != Redacted {
+		t.Errorf("got Authorization %q, expected %q", got["Authorization"], Redacted)
+	}
+	if got["Accept"] != "a, b" {
+		t.Errorf("got Accept %q, expected %q", got["Accept"], "a, b")
+	}
+}
+
+func TestSlogEndpoint(t *testing.T) {
+	ctx, la := ContextWithLogAttrs(context.Background())
+	ctx = context.WithValue(ctx, goa.ServiceKey, "Calc")
+	ctx = context.WithValue(ctx, goa.MethodKey, "Add")
+	ctx = context.WithValue(ctx, TraceIDKey, "trace")
+	e := SlogEndpoint()(func(context.Context, any) (any, error) { return nil, nil })
+
+	if _, err := e(ctx, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	attrs := la.Attrs()
+	if len(attrs) != 2 || attrs[0].String() != "service=Calc" || attrs[1].String() != "method=Add" {
+		t.Errorf("got attributes %v, expected service and method", attrs)
+	}
+	ctxAttrs := NewSlogOptions().ContextAttrs(ctx)
+	if len(ctxAttrs) != 1 || ctxAttrs[0].String() != "trace_id=trace" {
+		t.Errorf("got context attributes %v, expected trace ID", ctxAttrs)
+	}
+}
@@ -0,0 +1,120 @@
+package goa
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+type (
+	// Preconditions lists the conditions attached to a request (RFC 7232).
+	// The HTTP servers initialize the preconditions of the requests made to
+	// the endpoints whose design uses the ETag or LastModified DSL from the
+	// "If-Match", "If-None-Match", "If-Modified-Since" and
+	// "If-Unmodified-Since" headers. The HTTP clients set these headers from
+	// the preconditions stored in the request context.
+	Preconditions struct {
+		// IfMatch lists the entity tags of the "If-Match" header, "*"
+		// matches any entity tag.
+		IfMatch []string
+		// IfNoneMatch lists the entity tags of the "If-None-Match"
+		// header, "*" matches any entity tag.
+		IfNoneMatch []string
+		// IfModifiedSince is the time of the "If-Modified-Since" header
+		// if not zero.
+		IfModifiedSince time.Time
+		// IfUnmodifiedSince is the time of the "If-Unmodified-Since"
+		// header if not zero.
+		IfUnmodifiedSince time.Time
+	}
+
+	// preconditionsKey is the context key used to store the request
+	// preconditions.
+	preconditionsKey struct{}
+)
+
+// PreconditionFailed is the name of the errors returned by CheckPreconditions
+// when a request precondition is not met.
+const PreconditionFailed = "precondition_failed"
+
+// WithPreconditions returns a copy of ctx that holds the given preconditions.
+func WithPreconditions(ctx context.Context, p *Preconditions) context.Context {
+	return context.WithValue(ctx, preconditionsKey{}, p)
+}
+
+// ContextPreconditions returns the preconditions held by ctx if any.
+func ContextPreconditions(ctx context.Context) *Preconditions {
+	p, _ := ctx.Value(preconditionsKey{}).(*Preconditions)
+	return p
+}
+
+// CheckPreconditions returns a PreconditionFailedError if the preconditions
+// held by ctx are not met by the resource with the given entity tag and
+// modification time. An empty etag indicates that the resource does not exist
+// and a zero lastModified that its modification time is unknown. Service
+// methods that modify resources call CheckPreconditions with the current
+// state of the resource prior to making any change so that clients may avoid
+// overwriting concurrent changes. CheckPreconditions returns nil if ctx holds
+// no precondition.
+func CheckPreconditions(ctx context.Context, etag string, lastModified time.Time) error {
+	p := ContextPreconditions(ctx)
+	if p == nil {
+		return nil
+	}
+	if len(p.IfMatch) > 0 {
+		if !MatchETag(p.IfMatch, etag, false) {
+			return PreconditionFailedError("If-Match precondition failed")
+		}
+	} else if !p.IfUnmodifiedSince.IsZero() && !lastModified.IsZero() {
+		if lastModified.Truncate(time.Second).After(p.IfUnmodifiedSince) {
+			return PreconditionFailedError("If-Unmodified-Since precondition failed")
+		}
+	}
+	if len(p.IfNoneMatch) > 0 && MatchETag(p.IfNoneMatch, etag, true) {
+		return PreconditionFailedError("If-None-Match precondition failed")
+	}
+	return nil
+}
+
+// PreconditionFailedError creates a permanent error with name
+// PreconditionFailed given a format and values a la fmt.Printf. The HTTP
+// servers return such errors with status code 412, the gRPC servers with code
+// FAILED_PRECONDITION.
+func PreconditionFailedError(format string, v ...any) *ServiceError {
+	return PermanentError(PreconditionFailed, format, v...)
+}
+
+// MatchETag returns true if etag matches one of the given entity tags. The
+// comparison is weak (the "W/" prefix is ignored) if weak is true and strong
+// otherwise (RFC 7232 section 2.3.2). "*" matches any non-empty etag.
+func MatchETag(tags []string, etag string, weak bool) bool {
+	if etag == "" {
+		return false
+	}
+	isWeak, opaque := parseETag(etag)
+	if isWeak && !weak {
+		return false
+	}
+	for _, t := range tags {
+		if t == "*" {
+			return true
+		}
+		w, o := parseETag(t)
+		if w && !weak {
+			continue
+		}
+		if o == opaque {
+			return true
+		}
+	}
+	return false
+}
+
+// parseETag returns whether the given entity tag is weak and its opaque value
+// stripped from the surrounding quotes.
+func parseETag(etag string) (bool, string) {
+	etag = strings.TrimSpace(etag)
+	weak := strings.HasPrefix(etag, "W/")
+	etag = strings.TrimPrefix(etag, "W/")
+	return weak, strings.Trim(etag, `"`)
+}
@@ -0,0 +1,72 @@
+package goa
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMatchETag(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Tags     []string
+		ETag     string
+		Weak     bool
+		Expected bool
+	}{
+		{"strong", []string{`"a"`, `"b"`}, `"b"`, false, true},
+		{"strong-unquoted", []string{"a"}, `"a"`, false, true},
+		{"strong-weak-tag", []string{`W/"a"`}, `"a"`, false, false},
+		{"strong-weak-etag", []string{`"a"`}, `W/"a"`, false, false},
+		{"weak", []string{`W/"a"`}, `"a"`, true, true},
+		{"mismatch", []string{`"a"`}, `"b"`, true, false},
+		{"wildcard", []string{"*"}, `"a"`, false, true},
+		{"wildcard-no-etag", []string{"*"}, "", false, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if got := MatchETag(c.Tags, c.ETag, c.Weak); got != c.Expected {
+				t.Errorf("got %v, expected %v", got, c.Expected)
+			}
+		})
+	}
+}
+
+func TestCheckPreconditions(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	cases := []struct {
+		Name   string
+		Pre    *Preconditions
+		ETag   string
+		Failed bool
+	}{
+		{"none", nil, `"a"`, false},
+		{"if-match", &Preconditions{IfMatch: []string{`"a"`}}, `"a"`, false},
+		{"if-match-failed", &Preconditions{IfMatch: []string{`"a"`}}, `"b"`, true},
+		{"if-match-missing", &Preconditions{IfMatch: []string{"*"}}, "", true},
+		{"if-none-match", &Preconditions{IfNoneMatch: []string{"*"}}, "", false},
+		{"if-none-match-failed", &Preconditions{IfNoneMatch: []string{"*"}}, `"a"`, true},
+		{"if-unmodified-since", &Preconditions{IfUnmodifiedSince: now}, `"a"`, false},
+		{"if-unmodified-since-failed", &Preconditions{IfUnmodifiedSince: now.Add(-time.Hour)}, `"a"`, true},
+		{"if-match-overrides-if-unmodified-since", &Preconditions{IfMatch: []string{`"a"`}, IfUnmodifiedSince: now.Add(-time.Hour)}, `"a"`, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.Pre != nil {
+				ctx = WithPreconditions(ctx, c.Pre)
+			}
+			err := CheckPreconditions(ctx, c.ETag, now)
+			if c.Failed {
+				serr, ok := err.(*ServiceError)
+				if !ok || serr.Name != PreconditionFailed {
+					t.Errorf("got error %v, expected %q error", err, PreconditionFailed)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("got error %v, expected nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package goa
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type (
+	// HealthCheck is a named dependency check. The generated services
+	// initialize a health check for each check defined with the Check DSL.
+	HealthCheck struct {
+		// Name is the name of the checked dependency.
+		Name string
+		// Check returns an error if the dependency is not available.
+		Check func(context.Context) error
+	}
+
+	// HealthReport describes the result of running health checks.
+	HealthReport struct {
+		// Status is HealthStatusOK if all the checks succeeded,
+		// HealthStatusFail otherwise.
+		Status string `json:"status"`
+		// Checks lists the results of the checks in the order they were
+		// given.
+		Checks []*HealthCheckResult `json:"checks,omitempty"`
+	}
+
+	// HealthCheckResult describes the result of a single check.
+	HealthCheckResult struct {
+		// Name is the name of the checked dependency.
+		Name string `json:"name"`
+		// Status is HealthStatusOK if the check succeeded,
+		// HealthStatusFail otherwise.
+		Status string `json:"status"`
+		// Error is the error returned by the check if any.
+		Error string `json:"error,omitempty"`
+		// Duration is the time it took to run the check.
+		Duration time.Duration `json:"duration_ns"`
+	}
+)
+
+// DefaultHealthTimeout is the maximum duration of the checks run by the HTTP
+// readiness probes and by the gRPC health service.
+var DefaultHealthTimeout = 5 * time.Second
+
+const (
+	// HealthStatusOK is the status of successful checks.
+	HealthStatusOK = "ok"
+	// HealthStatusFail is the status of failed checks.
+	HealthStatusFail = "fail"
+)
+
+// CheckHealth runs the given checks concurrently and returns the report.
+// Checks that panic are reported as failed.
+func CheckHealth(ctx context.Context, checks ...*HealthCheck) *HealthReport {
+	report := &HealthReport{Status: HealthStatusOK, Checks: make([]*HealthCheckResult, len(checks))}
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c *HealthCheck) {
+			defer wg.Done()
+			report.Checks[i] = runHealthCheck(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+	for _, r := range report.Checks {
+		if r.Status != HealthStatusOK {
+			report.Status = HealthStatusFail
+			break
+		}
+	}
+	return report
+}
+
+// OK returns true if all the checks succeeded.
+func (r *HealthReport) OK() bool {
+	return r.Status == HealthStatusOK
+}
+
+// runHealthCheck runs c and returns its result.
+func runHealthCheck(ctx context.Context, c *HealthCheck) (res *HealthCheckResult) {
+	res = &HealthCheckResult{Name: c.Name, Status: HealthStatusOK}
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			res.Status = HealthStatusFail
+			res.Error = fmt.Sprintf("panic: %v", r)
+		}
+		res.Duration = time.Since(start)
+	}()
+	if err := c.Check(ctx); err != nil {
+		res.Status = HealthStatusFail
+		res.Error = err.Error()
+	}
+	return res
+}
@@ -0,0 +1,52 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCheckHealth(t *testing.T) {
+	var (
+		ok     = &HealthCheck{Name: "ok", Check: func(context.Context) error { return nil }}
+		fail   = &HealthCheck{Name: "fail", Check: func(context.Context) error { return errors.New("unavailable") }}
+		panics = &HealthCheck{Name: "panic", Check: func(context.Context) error { panic("boom") }}
+	)
+	cases := []struct {
+		Name     string
+		Checks   []*HealthCheck
+		Status   string
+		Statuses []string
+		Errors   []string
+	}{
+		{"no check", nil, HealthStatusOK, nil, nil},
+		{"ok", []*HealthCheck{ok}, HealthStatusOK, []string{HealthStatusOK}, []string{""}},
+		{"fail", []*HealthCheck{ok, fail}, HealthStatusFail, []string{HealthStatusOK, HealthStatusFail}, []string{"", "unavailable"}},
+		{"panic", []*HealthCheck{panics, ok}, HealthStatusFail, []string{HealthStatusFail, HealthStatusOK}, []string{"panic: boom", ""}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			report := CheckHealth(context.Background(), c.Checks...)
+			if report.Status != c.Status {
+				t.Errorf("got status %q, expected %q", report.Status, c.Status)
+			}
+			if report.OK() != (c.Status == HealthStatusOK) {
+				t.Errorf("got OK %v, expected %v", report.OK(), c.Status == HealthStatusOK)
+			}
+			if len(report.Checks) != len(c.Checks) {
+				t.Fatalf("got %d results, expected %d", len(report.Checks), len(c.Checks))
+			}
+			for i, res := range report.Checks {
+				if res.Name != c.Checks[i].Name {
+					t.Errorf("got name %q at index %d, expected %q", res.Name, i, c.Checks[i].Name)
+				}
+				if res.Status != c.Statuses[i] {
+					t.Errorf("got status %q for %q, expected %q", res.Status, res.Name, c.Statuses[i])
+				}
+				if res.Error != c.Errors[i] {
+					t.Errorf("got error %q for %q, expected %q", res.Error, res.Name, c.Errors[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+type (
+	// RateLimit describes the rate and concurrency limits enforced by the
+	// RateLimiter endpoint middleware. The generated services initialize a
+	// rate limit for each method whose design uses the RateLimit or
+	// ConcurrencyLimit DSL.
+	RateLimit struct {
+		// Name identifies the limit in the limiter store, the generated
+		// code uses the service and method names.
+		Name string
+		// Requests is the number of requests allowed during Interval.
+		// Zero disables rate limiting.
+		Requests int
+		// Interval is the period during which Requests requests are
+		// allowed.
+		Interval time.Duration
+		// Burst is the maximum number of requests allowed at once. It
+		// defaults to Requests.
+		Burst int
+		// MaxInFlight is the maximum number of concurrent requests. Zero
+		// disables concurrency limiting.
+		MaxInFlight int
+	}
+
+	// LimiterStore is the interface implemented by the stores that keep
+	// track of the requests made to rate limited endpoints. Stores shared
+	// by multiple processes (e.g. backed by Redis) make it possible to
+	// enforce limits across service instances.
+	LimiterStore interface {
+		// Allow consumes one request from the quota identified by key.
+		// It returns zero if the request is allowed or the delay after
+		// which the next request would be allowed otherwise.
+		Allow(ctx context.Context, key string, limit *RateLimit) (time.Duration, error)
+		// Acquire reserves one of the max concurrent request slots
+		// identified by key. It returns false if no slot is available.
+		// The returned function releases the slot and must be called
+		// once the request completes.
+		Acquire(ctx context.Context, key string, max int) (release func(), ok bool, err error)
+	}
+
+	// RateLimitKeyFunc returns the key used to group requests when
+	// enforcing limits, for example a tenant ID or a user name. req is the
+	// endpoint request.
+	RateLimitKeyFunc func(ctx context.Context, req any) string
+
+	// MemoryLimiterStore is a LimiterStore that keeps track of requests in
+	// memory. It implements rate limits with token buckets.
+	MemoryLimiterStore struct {
+		mu        sync.Mutex
+		buckets   map[string]*tokenBucket
+		inFlight  map[string]int
+		lastSweep time.Time
+		now       func() time.Time
+	}
+
+	// tokenBucket is the state of a single rate limit.
+	tokenBucket struct {
+		tokens   float64
+		capacity float64
+		rate     float64 // tokens per second
+		last     time.Time
+	}
+
+	// rateLimitedError is the error wrapped by the ServiceError returned
+	// when a request is rejected.
+	rateLimitedError struct {
+		msg        string
+		retryAfter time.Duration
+	}
+)
+
+const (
+	// RateLimited is the name of the errors returned by the RateLimiter
+	// endpoint middleware when a request exceeds a limit.
+	RateLimited = "rate_limited"
+
+	// sweepInterval is the minimum delay between two removals of the
+	// full buckets by the memory store.
+	sweepInterval = time.Minute
+)
+
+// RateLimiter returns an endpoint middleware that enforces the given rate and
+// concurrency limits using store. Requests are grouped by the value returned
+// by key if not nil. The middleware returns a RateLimitedError when a request
+// exceeds a limit.
+func RateLimiter(store LimiterStore, limit *RateLimit, key RateLimitKeyFunc) func(Endpoint) Endpoint {
+	return func(e Endpoint) Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			k := limit.Name
+			if key != nil {
+				k += ":" + key(ctx, req)
+			}
+			if limit.Requests > 0 && limit.Interval > 0 {
+				retryAfter, err := store.Allow(ctx, k, limit)
+				if err != nil {
+					return nil, err
+				}
+				if retryAfter > 0 {
+					return nil, RateLimitedError(retryAfter, "rate limit exceeded for %s", limit.Name)
+				}
+			}
+			if limit.MaxInFlight > 0 {
+				release, ok, err := store.Acquire(ctx, k, limit.MaxInFlight)
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					return nil, RateLimitedError(0, "too many concurrent requests for %s", limit.Name)
+				}
+				defer release()
+			}
+			return e(ctx, req)
+		}
+	}
+}
+
+// RateLimitedError creates a temporary error with name RateLimited given a
+// format and values a la fmt.Printf. retryAfter is the delay after which the
+// request may be retried, zero if unknown. The HTTP servers return such errors
+// with status code 429 and a "Retry-After" header if retryAfter is not zero,
+// the gRPC servers with code RESOURCE_EXHAUSTED.
+func RateLimitedError(retryAfter time.Duration, format string, v ...any) *ServiceError {
+	err := &rateLimitedError{msg: fmt.Sprintf(format, v...), retryAfter: retryAfter}
+	return NewServiceError(err, RateLimited, false, true, false)
+}
+
+// RetryAfter returns the delay after which the request that caused err may be
+// retried and true if err was created with RateLimitedError, false otherwise.
+func RetryAfter(err error) (time.Duration, bool) {
+	var rerr *rateLimitedError
+	if !errors.As(err, &rerr) {
+		return 0, false
+	}
+	return rerr.retryAfter, true
+}
+
+// NewMemoryLimiterStore creates a limiter store that keeps track of requests
+// in memory. The limits are thus enforced per process.
+func NewMemoryLimiterStore() *MemoryLimiterStore {
+	return &MemoryLimiterStore{
+		buckets:  make(map[string]*tokenBucket),
+		inFlight: make(map[string]int),
+		now:      time.Now,
+	}
+}
+
+// Allow implements LimiterStore.
+func (s *MemoryLimiterStore) Allow(_ context.Context, key string, limit *RateLimit) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.sweep(now)
+	b, ok := s.buckets[key]
+	if !ok {
+		capacity := limit.Burst
+		if capacity <= 0 {
+			capacity = limit.Requests
+		}
+		b = &tokenBucket{
+			tokens:   float64(capacity),
+			capacity: float64(capacity),
+			rate:     float64(limit.Requests) / limit.Interval.Seconds(),
+			last:     now,
+		}
+		s.buckets[key] = b
+	}
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, nil
+	}
+	wait := (1 - b.tokens) / b.rate
+	return time.Duration(math.Ceil(wait * float64(time.Second))), nil
+}
+
+// Acquire implements LimiterStore.
+func (s *MemoryLimiterStore) Acquire(_ context.Context, key string, max int) (func(), bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inFlight[key] >= max {
+		return nil, false, nil
+	}
+	s.inFlight[key]++
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.inFlight[key]--; s.inFlight[key] <= 0 {
+				delete(s.inFlight, key)
+			}
+		})
+	}
+	return release, true, nil
+}
+
+// sweep removes the buckets that are full as they are equivalent to missing
+// buckets. It must be called with the lock held.
+func (s *MemoryLimiterStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for k, b := range s.buckets {
+		if b.refill(now); b.tokens >= b.capacity {
+			delete(s.buckets, k)
+		}
+	}
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.capacity, b.tokens+elapsed*b.rate)
+	}
+	b.last = now
+}
+
+// Error returns the error message.
+func (e *rateLimitedError) Error() string { return e.msg }
@@ -0,0 +1,100 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	var (
+		now   = time.Unix(0, 0)
+		store = NewMemoryLimiterStore()
+		limit = &RateLimit{Name: "svc.method", Requests: 2, Interval: time.Second}
+		key   = func(_ context.Context, req any) string { return req.(string) }
+		e     = RateLimiter(store, limit, key)(func(context.Context, any) (any, error) { return "ok", nil })
+	)
+	store.now = func() time.Time { return now }
+	for i := 0; i < 2; i++ {
+		if _, err := e(context.Background(), "a"); err != nil {
+			t.Fatalf("request %d: unexpected error %v", i, err)
+		}
+	}
+	_, err := e(context.Background(), "a")
+	var serr *ServiceError
+	if !errors.As(err, &serr) || serr.Name != RateLimited || !serr.Temporary {
+		t.Fatalf("got error %v, expected temporary %s error", err, RateLimited)
+	}
+	if d, ok := RetryAfter(err); !ok || d != 500*time.Millisecond {
+		t.Errorf("got retry after %v (%v), expected 500ms", d, ok)
+	}
+	if _, err := e(context.Background(), "b"); err != nil {
+		t.Errorf("other key: unexpected error %v", err)
+	}
+	now = now.Add(500 * time.Millisecond)
+	if _, err := e(context.Background(), "a"); err != nil {
+		t.Errorf("after refill: unexpected error %v", err)
+	}
+}
+
+func TestRateLimiterBurst(t *testing.T) {
+	var (
+		now   = time.Unix(0, 0)
+		store = NewMemoryLimiterStore()
+		limit = &RateLimit{Name: "burst", Requests: 1, Interval: time.Minute, Burst: 3}
+		e     = RateLimiter(store, limit, nil)(func(context.Context, any) (any, error) { return nil, nil })
+	)
+	store.now = func() time.Time { return now }
+	for i := 0; i < 3; i++ {
+		if _, err := e(context.Background(), nil); err != nil {
+			t.Fatalf("request %d: unexpected error %v", i, err)
+		}
+	}
+	if _, err := e(context.Background(), nil); err == nil {
+		t.Fatal("expected error")
+	}
+	now = now.Add(2 * sweepInterval)
+	if _, err := e(context.Background(), nil); err != nil {
+		t.Errorf("after refill: unexpected error %v", err)
+	}
+	if len(store.buckets) != 1 {
+		t.Errorf("got %d buckets, expected full bucket to be swept", len(store.buckets))
+	}
+}
+
+func TestRateLimiterConcurrency(t *testing.T) {
+	var (
+		store   = NewMemoryLimiterStore()
+		limit   = &RateLimit{Name: "concurrency", MaxInFlight: 1}
+		started = make(chan struct{})
+		done    = make(chan struct{})
+		e       = RateLimiter(store, limit, nil)(func(_ context.Context, req any) (any, error) {
+			if req != nil {
+				close(started)
+				<-done
+			}
+			return nil, nil
+		})
+		errc = make(chan error)
+	)
+	go func() {
+		_, err := e(context.Background(), "block")
+		errc <- err
+	}()
+	<-started
+	_, err := e(context.Background(), nil)
+	if d, ok := RetryAfter(err); !ok || d != 0 {
+		t.Errorf("got error %v, expected %s error", err, RateLimited)
+	}
+	close(done)
+	if err := <-errc; err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := e(context.Background(), nil); err != nil {
+		t.Errorf("after release: unexpected error %v", err)
+	}
+	if len(store.inFlight) != 0 {
+		t.Errorf("got %d in-flight keys, expected 0", len(store.inFlight))
+	}
+}
@@ -0,0 +1,187 @@
+package goa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Redacted is the value that replaces sensitive values in logs, debug output
+// and error messages.
+const Redacted = "[REDACTED]"
+
+type (
+	// SensitiveNames is the set of the names of the body fields, headers and
+	// parameters of an endpoint that hold sensitive values. The names are
+	// case insensitive. A nil SensitiveNames holds no name.
+	SensitiveNames struct {
+		mu    sync.RWMutex
+		names map[string]struct{}
+	}
+
+	// sensitiveKey is the context key used to store the sensitive names.
+	sensitiveKey struct{}
+)
+
+// ContextWithSensitive returns a context that stores an empty set of
+// sensitive names. The HTTP debug middleware calls it before handling the
+// request and redacts the names added by the endpoint handler with
+// WithSensitive once the request completes.
+func ContextWithSensitive(ctx context.Context) (context.Context, *SensitiveNames) {
+	s := &SensitiveNames{names: make(map[string]struct{})}
+	return context.WithValue(ctx, sensitiveKey{}, s), s
+}
+
+// WithSensitive adds the given names to the set of sensitive names stored in
+// ctx. It returns ctx if ctx was created with ContextWithSensitive and a
+// child context that stores a new set otherwise. The generated HTTP handlers
+// and clients call WithSensitive with the names of the endpoint attributes
+// defined with the Sensitive DSL.
+func WithSensitive(ctx context.Context, names ...string) context.Context {
+	s := SensitiveFromContext(ctx)
+	if s == nil {
+		ctx, s = ContextWithSensitive(ctx)
+	}
+	s.Add(names...)
+	return ctx
+}
+
+// SensitiveFromContext returns the set of sensitive names stored in ctx or
+// nil if there is none.
+func SensitiveFromContext(ctx context.Context) *SensitiveNames {
+	s, _ := ctx.Value(sensitiveKey{}).(*SensitiveNames)
+	return s
+}
+
+// Add adds the given names to the set.
+func (s *SensitiveNames) Add(names ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, n := range names {
+		s.names[strings.ToLower(n)] = struct{}{}
+	}
+}
+
+// IsSensitive returns true if name belongs to the set.
+func (s *SensitiveNames) IsSensitive(name string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.names[strings.ToLower(name)]
+	return ok
+}
+
+// RedactJSON returns a copy of the given JSON document where the values of
+// the sensitive fields are replaced with Redacted. It returns body unchanged
+// if the set is empty or body is not a JSON document.
+func (s *SensitiveNames) RedactJSON(body []byte) []byte {
+	if s.empty() {
+		return body
+	}
+	if t := bytes.TrimSpace(body); len(t) == 0 || (t[0] != '{' && t[0] != '[') {
+		return body
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	b, err := json.Marshal(s.redactValue(v))
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+// RedactQuery returns a copy of the given URL where the values of the
+// sensitive query string parameters are replaced with Redacted.
+func (s *SensitiveNames) RedactQuery(u *url.URL) *url.URL {
+	if s.empty() {
+		return u
+	}
+	q := u.Query()
+	redacted := false
+	for k, vals := range q {
+		if s.IsSensitive(k) {
+			for i := range vals {
+				vals[i] = Redacted
+			}
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u
+	}
+	c := *u
+	c.RawQuery = q.Encode()
+	return &c
+}
+
+// RedactError returns a copy of the given validation error whose message does
+// not include the rejected value. The generated code uses RedactError to
+// report invalid sensitive values. RedactError returns err unchanged if it is
+// not a validation error.
+func RedactError(err error) error {
+	e, ok := err.(*ServiceError)
+	if !ok || e == nil {
+		return err
+	}
+	var field string
+	if e.Field != nil {
+		field = *e.Field
+	}
+	var msg string
+	switch e.Name {
+	case InvalidFieldType:
+		msg = fmt.Sprintf("invalid value for %q", field)
+	case InvalidEnumValue:
+		msg = fmt.Sprintf("value of %s must be one of the allowed values", field)
+	case InvalidFormat:
+		msg = fmt.Sprintf("%s has an invalid format", field)
+	case InvalidPattern:
+		msg = fmt.Sprintf("%s does not match the expected pattern", field)
+	case InvalidRange:
+		msg = fmt.Sprintf("%s is out of range", field)
+	case InvalidLength:
+		msg = fmt.Sprintf("length of %s is invalid", field)
+	default:
+		return err
+	}
+	c := *e
+	c.Message = msg
+	return &c
+}
+
+// empty returns true if the set holds no name.
+func (s *SensitiveNames) empty() bool {
+	if s == nil {
+		return true
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.names) == 0
+}
+
+// redactValue replaces the values of the sensitive fields found in v.
+func (s *SensitiveNames) redactValue(v any) any {
+	switch actual := v.(type) {
+	case map[string]any:
+		for k, val := range actual {
+			if s.IsSensitive(k) {
+				actual[k] = Redacted
+				continue
+			}
+			actual[k] = s.redactValue(val)
+		}
+	case []any:
+		for i, val := range actual {
+			actual[i] = s.redactValue(val)
+		}
+	}
+	return v
+}
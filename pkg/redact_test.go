@@ -0,0 +1,147 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newSensitive(names ...string) *SensitiveNames {
+	_, s := ContextWithSensitive(context.Background())
+	s.Add(names...)
+	return s
+}
+
+func TestIsSensitive(t *testing.T) {
+	s := newSensitive("Password", "X-API-Key")
+	cases := map[string]bool{
+		"password":  true,
+		"PASSWORD":  true,
+		"x-api-key": true,
+		"username":  false,
+	}
+	for name, want := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := s.IsSensitive(name); got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+	var none *SensitiveNames
+	if none.IsSensitive("password") {
+		t.Errorf("got sensitive name in nil set")
+	}
+}
+
+func TestWithSensitive(t *testing.T) {
+	if s := SensitiveFromContext(context.Background()); s != nil {
+		t.Errorf("got %v, want nil", s)
+	}
+
+	ctx := WithSensitive(context.Background(), "password")
+	if !SensitiveFromContext(ctx).IsSensitive("password") {
+		t.Errorf("password not stored in context")
+	}
+
+	ctx, s := ContextWithSensitive(context.Background())
+	if got := WithSensitive(ctx, "token"); got != ctx {
+		t.Errorf("got new context, want names added to existing set")
+	}
+	if !s.IsSensitive("token") {
+		t.Errorf("token not added to existing set")
+	}
+
+	other := WithSensitive(context.Background(), "email")
+	if SensitiveFromContext(other).IsSensitive("token") || s.IsSensitive("email") {
+		t.Errorf("sensitive names leaked across contexts")
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Sensitive []string
+		Body      string
+		Expected  string
+	}{
+		{"none-sensitive", nil, `{"password":"secret"}`, `{"password":"secret"}`},
+		{"object", []string{"password"}, `{"password":"secret","username":"joe"}`, `{"password":"[REDACTED]","username":"joe"}`},
+		{"nested", []string{"pin"}, `{"card":{"pin":"1234"},"cards":[{"pin":"5678"}]}`, `{"card":{"pin":"[REDACTED]"},"cards":[{"pin":"[REDACTED]"}]}`},
+		{"not-json", []string{"password"}, `password=secret`, `password=secret`},
+		{"invalid-json", []string{"password"}, `{"password":`, `{"password":`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			got := string(newSensitive(c.Sensitive...).RedactJSON([]byte(c.Body)))
+			if got != c.Expected {
+				t.Errorf("got %s, want %s", got, c.Expected)
+			}
+		})
+	}
+	var none *SensitiveNames
+	if got := string(none.RedactJSON([]byte(`{"password":"secret"}`))); got != `{"password":"secret"}` {
+		t.Errorf("got %s, want body unchanged", got)
+	}
+}
+
+func TestRedactQuery(t *testing.T) {
+	s := newSensitive("api_key")
+	u, err := url.Parse("https://example.com/path?api_key=secret&page=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := s.RedactQuery(u)
+	if v := got.Query().Get("api_key"); v != Redacted {
+		t.Errorf("got api_key %q, want %q", v, Redacted)
+	}
+	if v := got.Query().Get("page"); v != "2" {
+		t.Errorf("got page %q, want %q", v, "2")
+	}
+	if u.Query().Get("api_key") != "secret" {
+		t.Errorf("original URL was modified: %s", u)
+	}
+	u2, _ := url.Parse("https://example.com/path?page=2")
+	if got := s.RedactQuery(u2); got != u2 {
+		t.Errorf("got %s, want URL unchanged", got)
+	}
+}
+
+func TestRedactError(t *testing.T) {
+	cases := []struct {
+		Name string
+		Err  error
+	}{
+		{"invalid-field-type", InvalidFieldTypeError("password", "secret", "int")},
+		{"enum", InvalidEnumValueError("password", "secret", []any{"a", "b"})},
+		{"format", InvalidFormatError("password", "secret", FormatEmail, errors.New("invalid email"))},
+		{"pattern", InvalidPatternError("password", "secret", "^[0-9]+$")},
+		{"range", InvalidRangeError("password", 42, 10, true)},
+		{"length", InvalidLengthError("password", "secret", 6, 8, true)},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			got := RedactError(c.Err)
+			if strings.Contains(got.Error(), "secret") || strings.Contains(got.Error(), "42") {
+				t.Errorf("got %q, value not redacted", got.Error())
+			}
+			if !strings.Contains(got.Error(), "password") {
+				t.Errorf("got %q, missing field name", got.Error())
+			}
+			if c.Err.(*ServiceError).Name != got.(*ServiceError).Name {
+				t.Errorf("got name %q, want %q", got.(*ServiceError).Name, c.Err.(*ServiceError).Name)
+			}
+			if !strings.Contains(c.Err.Error(), "password") || c.Err.Error() == got.Error() {
+				t.Errorf("original error was modified: %q", c.Err.Error())
+			}
+		})
+	}
+	if got := RedactError(nil); got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+	missing := MissingFieldError("password", "body")
+	if got := RedactError(missing); got != missing {
+		t.Errorf("got %v, want error unchanged", got)
+	}
+}
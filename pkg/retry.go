@@ -0,0 +1,234 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+type (
+	// RetryPolicy describes how client endpoints retry failed requests. The
+	// generated clients initialize a retry policy for each method whose
+	// design uses the Retry DSL.
+	RetryPolicy struct {
+		// MaxAttempts is the maximum number of attempts including the
+		// initial request. Values lower than 2 disable retries.
+		MaxAttempts int
+		// InitialBackoff is the delay before the first retry. The delay
+		// doubles after each attempt.
+		InitialBackoff time.Duration
+		// MaxBackoff caps the delay between two attempts if not zero.
+		MaxBackoff time.Duration
+		// Jitter is the fraction of the backoff delay that is
+		// randomized, e.g. 0.2 yields delays between 80% and 120% of the
+		// computed backoff.
+		Jitter float64
+		// HedgeDelay enables hedging if not zero: a new attempt is made
+		// if the previous one has not completed after HedgeDelay. The
+		// first successful response is returned and the other attempts
+		// are canceled.
+		HedgeDelay time.Duration
+		// Errors lists the names of the errors that may be retried in
+		// addition to the errors designed as temporary or timeouts.
+		Errors []string
+		// Retryable is an optional function that classifies transport
+		// specific errors, e.g. HTTP responses with a given status code.
+		Retryable func(error) bool
+	}
+
+	// RetryAttempt describes a retry decision made by the Retry endpoint
+	// middleware.
+	RetryAttempt struct {
+		// Attempt is the number of the attempt that failed starting at 1.
+		Attempt int
+		// Err is the error returned by the failed attempt, nil when
+		// hedging starts a new attempt before the previous one completes.
+		Err error
+		// Delay is the time the middleware waits before making the next
+		// attempt.
+		Delay time.Duration
+	}
+
+	// RetryHook is a function called by the Retry endpoint middleware each
+	// time it decides to make a new attempt.
+	RetryHook func(ctx context.Context, attempt *RetryAttempt)
+
+	// private type used to define the retry context keys.
+	retryContextKey int
+
+	// attemptResult is the result of a single hedged attempt.
+	attemptResult struct {
+		res any
+		err error
+	}
+)
+
+const (
+	// retryHookKey is the context key used to store the retry hook.
+	retryHookKey retryContextKey = iota + 1
+)
+
+// WithRetryHook returns a copy of ctx that causes the Retry endpoint
+// middleware to call hook each time it retries a request made with the
+// returned context. This makes it possible to observe retry decisions for
+// example to log them or record metrics.
+func WithRetryHook(ctx context.Context, hook RetryHook) context.Context {
+	return context.WithValue(ctx, retryHookKey, hook)
+}
+
+// Retry returns an endpoint middleware that retries the requests that fail
+// with a retryable error according to policy. An error is retryable if it is
+// a ServiceError designed as temporary or as a timeout, if its name is listed
+// in the policy Errors field or if the policy Retryable function returns true.
+// Retry never retries requests whose context is done and does not wait past
+// the context deadline.
+func Retry(policy *RetryPolicy) func(Endpoint) Endpoint {
+	return func(e Endpoint) Endpoint {
+		if policy.MaxAttempts < 2 {
+			return e
+		}
+		if policy.HedgeDelay > 0 {
+			return func(ctx context.Context, req any) (any, error) {
+				return policy.hedge(ctx, e, req)
+			}
+		}
+		return func(ctx context.Context, req any) (any, error) {
+			return policy.retry(ctx, e, req)
+		}
+	}
+}
+
+// IsRetryable returns true if err may be retried according to the policy.
+func (p *RetryPolicy) IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var name string
+	var serr *ServiceError
+	if errors.As(err, &serr) {
+		if serr.Temporary || serr.Timeout {
+			return true
+		}
+		name = serr.Name
+	} else {
+		var namer GoaErrorNamer
+		if errors.As(err, &namer) {
+			name = namer.GoaErrorName()
+		}
+	}
+	if name != "" {
+		for _, n := range p.Errors {
+			if n == name {
+				return true
+			}
+		}
+	}
+	return p.Retryable != nil && p.Retryable(err)
+}
+
+// Backoff returns the delay before the attempt following the given failed
+// attempt (starting at 1).
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt && d < math.MaxInt64/2; i++ {
+		d *= 2
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 && d > 0 {
+		d += time.Duration(p.Jitter * float64(d) * (2*rand.Float64() - 1)) // nolint: gosec
+	}
+	return d
+}
+
+// retry calls e until it succeeds, returns a non-retryable error or the
+// maximum number of attempts is reached.
+func (p *RetryPolicy) retry(ctx context.Context, e Endpoint, req any) (any, error) {
+	for attempt := 1; ; attempt++ {
+		res, err := e(ctx, req)
+		if err == nil || attempt >= p.MaxAttempts || ctx.Err() != nil || !p.IsRetryable(err) {
+			return res, err
+		}
+		delay := p.Backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			return res, err
+		}
+		notify(ctx, &RetryAttempt{Attempt: attempt, Err: err, Delay: delay})
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return res, err
+		}
+	}
+}
+
+// hedge starts a new attempt each time the hedging delay expires or an
+// attempt fails with a retryable error until one attempt succeeds or the
+// maximum number of attempts is reached.
+func (p *RetryPolicy) hedge(ctx context.Context, e Endpoint, req any) (any, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan *attemptResult, p.MaxAttempts)
+	var launched, pending int
+	launch := func() {
+		launched++
+		pending++
+		go func() {
+			res, err := e(ctx, req)
+			results <- &attemptResult{res, err}
+		}()
+	}
+	launch()
+	timer := time.NewTimer(p.HedgeDelay)
+	defer timer.Stop()
+	var lastErr error
+	for {
+		select {
+		case r := <-results:
+			pending--
+			if r.err == nil || !p.IsRetryable(r.err) {
+				return r.res, r.err
+			}
+			lastErr = r.err
+			if launched < p.MaxAttempts && ctx.Err() == nil {
+				notify(ctx, &RetryAttempt{Attempt: launched, Err: r.err})
+				launch()
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(p.HedgeDelay)
+			} else if pending == 0 {
+				return nil, lastErr
+			}
+		case <-timer.C:
+			if launched < p.MaxAttempts {
+				notify(ctx, &RetryAttempt{Attempt: launched, Delay: p.HedgeDelay})
+				launch()
+				timer.Reset(p.HedgeDelay)
+			}
+		case <-ctx.Done():
+			if lastErr == nil {
+				lastErr = ctx.Err()
+			}
+			return nil, lastErr
+		}
+	}
+}
+
+// notify calls the retry hook stored in ctx if any.
+func notify(ctx context.Context, attempt *RetryAttempt) {
+	if hook, ok := ctx.Value(retryHookKey).(RetryHook); ok && hook != nil {
+		hook(ctx, attempt)
+	}
+}
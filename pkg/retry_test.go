@@ -0,0 +1,165 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	var (
+		errTemporary = NewServiceError(errors.New("temporary"), "unavailable", false, true, false)
+		errTimeout   = NewServiceError(errors.New("timeout"), "timeout", true, false, false)
+		errNamed     = NewServiceError(errors.New("conflict"), "conflict", false, false, false)
+		errOther     = NewServiceError(errors.New("invalid"), "invalid", false, false, false)
+		errTransport = errors.New("transport")
+	)
+	cases := map[string]struct {
+		errs     []error
+		want     error
+		attempts int
+	}{
+		"success":           {nil, nil, 1},
+		"temporary":         {[]error{errTemporary, errTemporary}, nil, 3},
+		"timeout":           {[]error{errTimeout}, nil, 2},
+		"named":             {[]error{errNamed}, nil, 2},
+		"retryable func":    {[]error{errTransport}, nil, 2},
+		"not retryable":     {[]error{errOther}, errOther, 1},
+		"max attempts":      {[]error{errTemporary, errTemporary, errTemporary, errTemporary}, errTemporary, 3},
+		"context cancelled": {[]error{context.Canceled}, context.Canceled, 1},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			var attempts int
+			endpoint := func(context.Context, any) (any, error) {
+				attempts++
+				if attempts <= len(tc.errs) {
+					return nil, tc.errs[attempts-1]
+				}
+				return "ok", nil
+			}
+			policy := &RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     2 * time.Millisecond,
+				Errors:         []string{"conflict"},
+				Retryable:      func(err error) bool { return err == errTransport },
+			}
+
+			res, err := Retry(policy)(endpoint)(context.Background(), nil)
+
+			if err != tc.want {
+				t.Errorf("got error %v, want %v", err, tc.want)
+			}
+			if err == nil && res != "ok" {
+				t.Errorf("got result %v, want %q", res, "ok")
+			}
+			if attempts != tc.attempts {
+				t.Errorf("got %d attempts, want %d", attempts, tc.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryDeadline(t *testing.T) {
+	errTemporary := NewServiceError(errors.New("temporary"), "unavailable", false, true, false)
+	var attempts int
+	endpoint := func(context.Context, any) (any, error) {
+		attempts++
+		return nil, errTemporary
+	}
+	policy := &RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Second}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := Retry(policy)(endpoint)(ctx, nil)
+
+	if err != errTemporary {
+		t.Errorf("got error %v, want %v", err, errTemporary)
+	}
+	if attempts != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+	if d := time.Since(start); d > 50*time.Millisecond {
+		t.Errorf("retry waited %s past the context deadline", d)
+	}
+}
+
+func TestRetryHook(t *testing.T) {
+	errTemporary := NewServiceError(errors.New("temporary"), "unavailable", false, true, false)
+	var attempts int
+	endpoint := func(context.Context, any) (any, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errTemporary
+		}
+		return "ok", nil
+	}
+	policy := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	var got []*RetryAttempt
+	ctx := WithRetryHook(context.Background(), func(_ context.Context, a *RetryAttempt) {
+		got = append(got, a)
+	})
+
+	if _, err := Retry(policy)(endpoint)(ctx, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d hook calls, want 2", len(got))
+	}
+	for i, a := range got {
+		if a.Attempt != i+1 {
+			t.Errorf("got attempt %d, want %d", a.Attempt, i+1)
+		}
+		if a.Err != errTemporary {
+			t.Errorf("got error %v, want %v", a.Err, errTemporary)
+		}
+		if want := time.Millisecond << i; a.Delay != want {
+			t.Errorf("got delay %s, want %s", a.Delay, want)
+		}
+	}
+}
+
+func TestRetryHedge(t *testing.T) {
+	var attempts int32
+	endpoint := func(ctx context.Context, _ any) (any, error) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			<-ctx.Done() // first attempt hangs until canceled
+			return nil, ctx.Err()
+		}
+		return "ok", nil
+	}
+	policy := &RetryPolicy{MaxAttempts: 2, HedgeDelay: 10 * time.Millisecond}
+
+	res, err := Retry(policy)(endpoint)(context.Background(), nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if res != "ok" {
+		t.Errorf("got result %v, want %q", res, "ok")
+	}
+	if n := atomic.LoadInt32(&attempts); n != 2 {
+		t.Errorf("got %d attempts, want 2", n)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	cases := map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 4: 800 * time.Millisecond, 5: time.Second, 50: time.Second}
+	for attempt, want := range cases {
+		if got := policy.Backoff(attempt); got != want {
+			t.Errorf("attempt %d: got backoff %s, want %s", attempt, got, want)
+		}
+	}
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := policy.Backoff(1); got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("got backoff %s with jitter, want between 50ms and 150ms", got)
+		}
+	}
+}